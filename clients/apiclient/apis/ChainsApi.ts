@@ -16,7 +16,10 @@ import { ContractInfoResponse } from '../models/ContractInfoResponse';
 import { EstimateGasRequestOffledger } from '../models/EstimateGasRequestOffledger';
 import { EstimateGasRequestOnledger } from '../models/EstimateGasRequestOnledger';
 import { JSONDict } from '../models/JSONDict';
+import { PruneStatesRequest } from '../models/PruneStatesRequest';
+import { PruneStatesResponse } from '../models/PruneStatesResponse';
 import { ReceiptResponse } from '../models/ReceiptResponse';
+import { StateProofResponse } from '../models/StateProofResponse';
 import { StateResponse } from '../models/StateResponse';
 import { ValidationError } from '../models/ValidationError';
 
@@ -552,6 +555,45 @@ export class ChainsApiRequestFactory extends BaseAPIRequestFactory {
 
 
         
+        const defaultAuth: SecurityAuthentication | undefined = _options?.authMethods?.default || this.configuration?.authMethods?.default
+        if (defaultAuth?.applySecurityAuthentication) {
+            await defaultAuth?.applySecurityAuthentication(requestContext);
+        }
+
+        return requestContext;
+    }
+
+    /**
+     * Fetch the value associated with the given key in the chain state, together with a proof anchored to the latest confirmed alias output
+     * @param chainID ChainID (Bech32)
+     * @param stateKey State Key (Hex)
+     */
+    public async getStateProof(chainID: string, stateKey: string, _options?: Configuration): Promise<RequestContext> {
+        let _config = _options || this.configuration;
+
+        // verify required parameter 'chainID' is not null or undefined
+        if (chainID === null || chainID === undefined) {
+            throw new RequiredError("ChainsApi", "getStateProof", "chainID");
+        }
+
+
+        // verify required parameter 'stateKey' is not null or undefined
+        if (stateKey === null || stateKey === undefined) {
+            throw new RequiredError("ChainsApi", "getStateProof", "stateKey");
+        }
+
+
+        // Path Params
+        const localVarPath = '/v1/chains/{chainID}/state/{stateKey}/proof'
+            .replace('{' + 'chainID' + '}', encodeURIComponent(String(chainID)))
+            .replace('{' + 'stateKey' + '}', encodeURIComponent(String(stateKey)));
+
+        // Make Request Context
+        const requestContext = _config.baseServer.makeRequestContext(localVarPath, HttpMethod.GET);
+        requestContext.setHeaderParam("Accept", "application/json, */*;q=0.8")
+
+
+        
         const defaultAuth: SecurityAuthentication | undefined = _options?.authMethods?.default || this.configuration?.authMethods?.default
         if (defaultAuth?.applySecurityAuthentication) {
             await defaultAuth?.applySecurityAuthentication(requestContext);
@@ -599,6 +641,61 @@ export class ChainsApiRequestFactory extends BaseAPIRequestFactory {
         return requestContext;
     }
 
+    /**
+     * Prune the historical states of a block range
+     * @param chainID ChainID (Bech32)
+     * @param pruneStatesRequest The range of blocks to prune
+     */
+    public async pruneStates(chainID: string, pruneStatesRequest: PruneStatesRequest, _options?: Configuration): Promise<RequestContext> {
+        let _config = _options || this.configuration;
+
+        // verify required parameter 'chainID' is not null or undefined
+        if (chainID === null || chainID === undefined) {
+            throw new RequiredError("ChainsApi", "pruneStates", "chainID");
+        }
+
+
+        // verify required parameter 'pruneStatesRequest' is not null or undefined
+        if (pruneStatesRequest === null || pruneStatesRequest === undefined) {
+            throw new RequiredError("ChainsApi", "pruneStates", "pruneStatesRequest");
+        }
+
+
+        // Path Params
+        const localVarPath = '/v1/chains/{chainID}/prune'
+            .replace('{' + 'chainID' + '}', encodeURIComponent(String(chainID)));
+
+        // Make Request Context
+        const requestContext = _config.baseServer.makeRequestContext(localVarPath, HttpMethod.POST);
+        requestContext.setHeaderParam("Accept", "application/json, */*;q=0.8")
+
+
+        // Body Params
+        const contentType = ObjectSerializer.getPreferredMediaType([
+            "application/json"
+        ]);
+        requestContext.setHeaderParam("Content-Type", contentType);
+        const serializedBody = ObjectSerializer.stringify(
+            ObjectSerializer.serialize(pruneStatesRequest, "PruneStatesRequest", ""),
+            contentType
+        );
+        requestContext.setBody(serializedBody);
+
+        let authMethod: SecurityAuthentication | undefined;
+        // Apply auth methods
+        authMethod = _config.authMethods["Authorization"]
+        if (authMethod?.applySecurityAuthentication) {
+            await authMethod?.applySecurityAuthentication(requestContext);
+        }
+        
+        const defaultAuth: SecurityAuthentication | undefined = _options?.authMethods?.default || this.configuration?.authMethods?.default
+        if (defaultAuth?.applySecurityAuthentication) {
+            await defaultAuth?.applySecurityAuthentication(requestContext);
+        }
+
+        return requestContext;
+    }
+
     /**
      * Remove an access node.
      * @param chainID ChainID (Bech32)
@@ -1244,6 +1341,35 @@ export class ChainsApiResponseProcessor {
         throw new ApiException<string | Blob | undefined>(response.httpStatusCode, "Unknown API Status Code!", await response.getBodyAsAny(), response.headers);
     }
 
+    /**
+     * Unwraps the actual response sent by the server from the response context and deserializes the response content
+     * to the expected objects
+     *
+     * @params response Response returned by the server for a request to getStateProof
+     * @throws ApiException if the response code was not in [200, 299]
+     */
+     public async getStateProof(response: ResponseContext): Promise<StateProofResponse > {
+        const contentType = ObjectSerializer.normalizeMediaType(response.headers["content-type"]);
+        if (isCodeInRange("200", response.httpStatusCode)) {
+            const body: StateProofResponse = ObjectSerializer.deserialize(
+                ObjectSerializer.parse(await response.body.text(), contentType),
+                "StateProofResponse", ""
+            ) as StateProofResponse;
+            return body;
+        }
+
+        // Work around for missing responses in specification, e.g. for petstore.yaml
+        if (response.httpStatusCode >= 200 && response.httpStatusCode <= 299) {
+            const body: StateProofResponse = ObjectSerializer.deserialize(
+                ObjectSerializer.parse(await response.body.text(), contentType),
+                "StateProofResponse", ""
+            ) as StateProofResponse;
+            return body;
+        }
+
+        throw new ApiException<string | Blob | undefined>(response.httpStatusCode, "Unknown API Status Code!", await response.getBodyAsAny(), response.headers);
+    }
+
     /**
      * Unwraps the actual response sent by the server from the response context and deserializes the response content
      * to the expected objects
@@ -1273,6 +1399,45 @@ export class ChainsApiResponseProcessor {
         throw new ApiException<string | Blob | undefined>(response.httpStatusCode, "Unknown API Status Code!", await response.getBodyAsAny(), response.headers);
     }
 
+    /**
+     * Unwraps the actual response sent by the server from the response context and deserializes the response content
+     * to the expected objects
+     *
+     * @params response Response returned by the server for a request to pruneStates
+     * @throws ApiException if the response code was not in [200, 299]
+     */
+     public async pruneStates(response: ResponseContext): Promise<PruneStatesResponse > {
+        const contentType = ObjectSerializer.normalizeMediaType(response.headers["content-type"]);
+        if (isCodeInRange("200", response.httpStatusCode)) {
+            const body: PruneStatesResponse = ObjectSerializer.deserialize(
+                ObjectSerializer.parse(await response.body.text(), contentType),
+                "PruneStatesResponse", ""
+            ) as PruneStatesResponse;
+            return body;
+        }
+        if (isCodeInRange("401", response.httpStatusCode)) {
+            const body: ValidationError = ObjectSerializer.deserialize(
+                ObjectSerializer.parse(await response.body.text(), contentType),
+                "ValidationError", ""
+            ) as ValidationError;
+            throw new ApiException<ValidationError>(response.httpStatusCode, "Unauthorized (Wrong permissions, missing token)", body, response.headers);
+        }
+        if (isCodeInRange("423", response.httpStatusCode)) {
+            throw new ApiException<undefined>(response.httpStatusCode, "Pruning is already in progress", undefined, response.headers);
+        }
+
+        // Work around for missing responses in specification, e.g. for petstore.yaml
+        if (response.httpStatusCode >= 200 && response.httpStatusCode <= 299) {
+            const body: PruneStatesResponse = ObjectSerializer.deserialize(
+                ObjectSerializer.parse(await response.body.text(), contentType),
+                "PruneStatesResponse", ""
+            ) as PruneStatesResponse;
+            return body;
+        }
+
+        throw new ApiException<string | Blob | undefined>(response.httpStatusCode, "Unknown API Status Code!", await response.getBodyAsAny(), response.headers);
+    }
+
     /**
      * Unwraps the actual response sent by the server from the response context and deserializes the response content
      * to the expected objects
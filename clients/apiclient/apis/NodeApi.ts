@@ -10,6 +10,7 @@ import {SecurityAuthentication} from '../auth/auth';
 
 import { DKSharesInfo } from '../models/DKSharesInfo';
 import { DKSharesPostRequest } from '../models/DKSharesPostRequest';
+import { DKSharesReshareRequest } from '../models/DKSharesReshareRequest';
 import { InfoResponse } from '../models/InfoResponse';
 import { NodeOwnerCertificateResponse } from '../models/NodeOwnerCertificateResponse';
 import { PeeringNodeIdentityResponse } from '../models/PeeringNodeIdentityResponse';
@@ -341,6 +342,61 @@ export class NodeApiRequestFactory extends BaseAPIRequestFactory {
         return requestContext;
     }
 
+    /**
+     * Hand the distributed key over to a new committee, keeping the shared address
+     * @param sharedAddress SharedAddress (Bech32)
+     * @param dKSharesReshareRequest Request parameters
+     */
+    public async reshareDKS(sharedAddress: string, dKSharesReshareRequest: DKSharesReshareRequest, _options?: Configuration): Promise<RequestContext> {
+        let _config = _options || this.configuration;
+
+        // verify required parameter 'sharedAddress' is not null or undefined
+        if (sharedAddress === null || sharedAddress === undefined) {
+            throw new RequiredError("NodeApi", "reshareDKS", "sharedAddress");
+        }
+
+
+        // verify required parameter 'dKSharesReshareRequest' is not null or undefined
+        if (dKSharesReshareRequest === null || dKSharesReshareRequest === undefined) {
+            throw new RequiredError("NodeApi", "reshareDKS", "dKSharesReshareRequest");
+        }
+
+
+        // Path Params
+        const localVarPath = '/v1/node/dks/{sharedAddress}/reshare'
+            .replace('{' + 'sharedAddress' + '}', encodeURIComponent(String(sharedAddress)));
+
+        // Make Request Context
+        const requestContext = _config.baseServer.makeRequestContext(localVarPath, HttpMethod.POST);
+        requestContext.setHeaderParam("Accept", "application/json, */*;q=0.8")
+
+
+        // Body Params
+        const contentType = ObjectSerializer.getPreferredMediaType([
+            "application/json"
+        ]);
+        requestContext.setHeaderParam("Content-Type", contentType);
+        const serializedBody = ObjectSerializer.stringify(
+            ObjectSerializer.serialize(dKSharesReshareRequest, "DKSharesReshareRequest", ""),
+            contentType
+        );
+        requestContext.setBody(serializedBody);
+
+        let authMethod: SecurityAuthentication | undefined;
+        // Apply auth methods
+        authMethod = _config.authMethods["Authorization"]
+        if (authMethod?.applySecurityAuthentication) {
+            await authMethod?.applySecurityAuthentication(requestContext);
+        }
+        
+        const defaultAuth: SecurityAuthentication | undefined = _options?.authMethods?.default || this.configuration?.authMethods?.default
+        if (defaultAuth?.applySecurityAuthentication) {
+            await defaultAuth?.applySecurityAuthentication(requestContext);
+        }
+
+        return requestContext;
+    }
+
     /**
      * Shut down the node
      */
@@ -776,6 +832,42 @@ export class NodeApiResponseProcessor {
         throw new ApiException<string | Blob | undefined>(response.httpStatusCode, "Unknown API Status Code!", await response.getBodyAsAny(), response.headers);
     }
 
+    /**
+     * Unwraps the actual response sent by the server from the response context and deserializes the response content
+     * to the expected objects
+     *
+     * @params response Response returned by the server for a request to reshareDKS
+     * @throws ApiException if the response code was not in [200, 299]
+     */
+     public async reshareDKS(response: ResponseContext): Promise<DKSharesInfo > {
+        const contentType = ObjectSerializer.normalizeMediaType(response.headers["content-type"]);
+        if (isCodeInRange("200", response.httpStatusCode)) {
+            const body: DKSharesInfo = ObjectSerializer.deserialize(
+                ObjectSerializer.parse(await response.body.text(), contentType),
+                "DKSharesInfo", ""
+            ) as DKSharesInfo;
+            return body;
+        }
+        if (isCodeInRange("401", response.httpStatusCode)) {
+            const body: ValidationError = ObjectSerializer.deserialize(
+                ObjectSerializer.parse(await response.body.text(), contentType),
+                "ValidationError", ""
+            ) as ValidationError;
+            throw new ApiException<ValidationError>(response.httpStatusCode, "Unauthorized (Wrong permissions, missing token)", body, response.headers);
+        }
+
+        // Work around for missing responses in specification, e.g. for petstore.yaml
+        if (response.httpStatusCode >= 200 && response.httpStatusCode <= 299) {
+            const body: DKSharesInfo = ObjectSerializer.deserialize(
+                ObjectSerializer.parse(await response.body.text(), contentType),
+                "DKSharesInfo", ""
+            ) as DKSharesInfo;
+            return body;
+        }
+
+        throw new ApiException<string | Blob | undefined>(response.httpStatusCode, "Unknown API Status Code!", await response.getBodyAsAny(), response.headers);
+    }
+
     /**
      * Unwraps the actual response sent by the server from the response context and deserializes the response content
      * to the expected objects
@@ -25,17 +25,20 @@ type ContractInfoResponse struct {
 	Name string `json:"name"`
 	// The hash of the contract. (Hex encoded)
 	ProgramHash string `json:"programHash"`
+	// Whether the contract is retired and rejects further calls.
+	Retired bool `json:"retired"`
 }
 
 // NewContractInfoResponse instantiates a new ContractInfoResponse object
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewContractInfoResponse(hName string, name string, programHash string) *ContractInfoResponse {
+func NewContractInfoResponse(hName string, name string, programHash string, retired bool) *ContractInfoResponse {
 	this := ContractInfoResponse{}
 	this.HName = hName
 	this.Name = name
 	this.ProgramHash = programHash
+	this.Retired = retired
 	return &this
 }
 
@@ -119,6 +122,30 @@ func (o *ContractInfoResponse) SetProgramHash(v string) {
 	o.ProgramHash = v
 }
 
+// GetRetired returns the Retired field value
+func (o *ContractInfoResponse) GetRetired() bool {
+	if o == nil {
+		var ret bool
+		return ret
+	}
+
+	return o.Retired
+}
+
+// GetRetiredOk returns a tuple with the Retired field value
+// and a boolean to check if the value has been set.
+func (o *ContractInfoResponse) GetRetiredOk() (*bool, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Retired, true
+}
+
+// SetRetired sets field value
+func (o *ContractInfoResponse) SetRetired(v bool) {
+	o.Retired = v
+}
+
 func (o ContractInfoResponse) MarshalJSON() ([]byte, error) {
 	toSerialize,err := o.ToMap()
 	if err != nil {
@@ -132,6 +159,7 @@ func (o ContractInfoResponse) ToMap() (map[string]interface{}, error) {
 	toSerialize["hName"] = o.HName
 	toSerialize["name"] = o.Name
 	toSerialize["programHash"] = o.ProgramHash
+	toSerialize["retired"] = o.Retired
 	return toSerialize, nil
 }
 
@@ -0,0 +1,62 @@
+/**
+ * Wasp API
+ * REST API for the Wasp node
+ *
+ * OpenAPI spec version: 0
+ * 
+ *
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+import { HttpFile } from '../http/http';
+
+export class APIKeyResponse {
+    'createdAt': Date;
+    /**
+    * Not set, if the key never expires
+    */
+    'expiresAt'?: Date;
+    'name': string;
+    /**
+    * The permissions of the key; empty means all the permissions of the user
+    */
+    'permissions': Array<string>;
+
+    static readonly discriminator: string | undefined = undefined;
+
+    static readonly attributeTypeMap: Array<{name: string, baseName: string, type: string, format: string}> = [
+        {
+            "name": "createdAt",
+            "baseName": "createdAt",
+            "type": "Date",
+            "format": "date-time"
+        },
+        {
+            "name": "expiresAt",
+            "baseName": "expiresAt",
+            "type": "Date",
+            "format": "date-time"
+        },
+        {
+            "name": "name",
+            "baseName": "name",
+            "type": "string",
+            "format": "string"
+        },
+        {
+            "name": "permissions",
+            "baseName": "permissions",
+            "type": "Array<string>",
+            "format": "string"
+        }    ];
+
+    static getAttributeTypeMap() {
+        return APIKeyResponse.attributeTypeMap;
+    }
+
+    public constructor() {
+    }
+}
+
@@ -0,0 +1,55 @@
+/**
+ * Wasp API
+ * REST API for the Wasp node
+ *
+ * OpenAPI spec version: 0
+ * 
+ *
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+import { HttpFile } from '../http/http';
+
+export class AddAPIKeyRequest {
+    /**
+    * Optional expiry time of the key
+    */
+    'expiresAt'?: Date;
+    'name': string;
+    /**
+    * A subset of the permissions of the user; empty means all the permissions of the user
+    */
+    'permissions': Array<string>;
+
+    static readonly discriminator: string | undefined = undefined;
+
+    static readonly attributeTypeMap: Array<{name: string, baseName: string, type: string, format: string}> = [
+        {
+            "name": "expiresAt",
+            "baseName": "expiresAt",
+            "type": "Date",
+            "format": "date-time"
+        },
+        {
+            "name": "name",
+            "baseName": "name",
+            "type": "string",
+            "format": "string"
+        },
+        {
+            "name": "permissions",
+            "baseName": "permissions",
+            "type": "Array<string>",
+            "format": "string"
+        }    ];
+
+    static getAttributeTypeMap() {
+        return AddAPIKeyRequest.attributeTypeMap;
+    }
+
+    public constructor() {
+    }
+}
+
@@ -0,0 +1,38 @@
+/**
+ * Wasp API
+ * REST API for the Wasp node
+ *
+ * OpenAPI spec version: 0
+ * 
+ *
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+import { HttpFile } from '../http/http';
+
+export class AddAPIKeyResponse {
+    /**
+    * The API key; it is only returned once and has to be sent in the X-API-Key header
+    */
+    'key': string;
+
+    static readonly discriminator: string | undefined = undefined;
+
+    static readonly attributeTypeMap: Array<{name: string, baseName: string, type: string, format: string}> = [
+        {
+            "name": "key",
+            "baseName": "key",
+            "type": "string",
+            "format": "string"
+        }    ];
+
+    static getAttributeTypeMap() {
+        return AddAPIKeyResponse.attributeTypeMap;
+    }
+
+    public constructor() {
+    }
+}
+
@@ -25,6 +25,10 @@ export class ContractInfoResponse {
     * The hash of the contract. (Hex encoded)
     */
     'programHash': string;
+    /**
+    * Whether the contract is retired and rejects further calls.
+    */
+    'retired': boolean;
 
     static readonly discriminator: string | undefined = undefined;
 
@@ -46,6 +50,12 @@ export class ContractInfoResponse {
             "baseName": "programHash",
             "type": "string",
             "format": "string"
+        },
+        {
+            "name": "retired",
+            "baseName": "retired",
+            "type": "boolean",
+            "format": "boolean"
         }    ];
 
     static getAttributeTypeMap() {
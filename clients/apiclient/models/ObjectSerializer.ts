@@ -1,6 +1,9 @@
+export * from '../models/APIKeyResponse';
 export * from '../models/AccountFoundriesResponse';
 export * from '../models/AccountNFTsResponse';
 export * from '../models/AccountNonceResponse';
+export * from '../models/AddAPIKeyRequest';
+export * from '../models/AddAPIKeyResponse';
 export * from '../models/AddUserRequest';
 export * from '../models/AliasOutputMetricItem';
 export * from '../models/AssetsJSON';
@@ -24,6 +27,7 @@ export * from '../models/ContractInfoResponse';
 export * from '../models/ControlAddressesResponse';
 export * from '../models/DKSharesInfo';
 export * from '../models/DKSharesPostRequest';
+export * from '../models/DKSharesReshareRequest';
 export * from '../models/ErrorMessageFormatResponse';
 export * from '../models/EstimateGasRequestOffledger';
 export * from '../models/EstimateGasRequestOnledger';
@@ -63,6 +67,8 @@ export * from '../models/PeeringNodeIdentityResponse';
 export * from '../models/PeeringNodeStatusResponse';
 export * from '../models/PeeringTrustRequest';
 export * from '../models/ProtocolParameters';
+export * from '../models/PruneStatesRequest';
+export * from '../models/PruneStatesResponse';
 export * from '../models/PublicChainMetadata';
 export * from '../models/PublisherStateTransactionItem';
 export * from '../models/Ratio32';
@@ -71,6 +77,8 @@ export * from '../models/RentStructure';
 export * from '../models/RequestIDsResponse';
 export * from '../models/RequestJSON';
 export * from '../models/RequestProcessedResponse';
+export * from '../models/RevokeTokenRequest';
+export * from '../models/StateProofResponse';
 export * from '../models/StateResponse';
 export * from '../models/StateTransaction';
 export * from '../models/Transaction';
@@ -86,9 +94,12 @@ export * from '../models/User';
 export * from '../models/ValidationError';
 export * from '../models/VersionResponse';
 
+import { APIKeyResponse } from '../models/APIKeyResponse';
 import { AccountFoundriesResponse } from '../models/AccountFoundriesResponse';
 import { AccountNFTsResponse } from '../models/AccountNFTsResponse';
 import { AccountNonceResponse } from '../models/AccountNonceResponse';
+import { AddAPIKeyRequest } from '../models/AddAPIKeyRequest';
+import { AddAPIKeyResponse } from '../models/AddAPIKeyResponse';
 import { AddUserRequest } from '../models/AddUserRequest';
 import { AliasOutputMetricItem } from '../models/AliasOutputMetricItem';
 import { AssetsJSON } from '../models/AssetsJSON';
@@ -112,6 +123,7 @@ import { ContractInfoResponse } from '../models/ContractInfoResponse';
 import { ControlAddressesResponse } from '../models/ControlAddressesResponse';
 import { DKSharesInfo } from '../models/DKSharesInfo';
 import { DKSharesPostRequest } from '../models/DKSharesPostRequest';
+import { DKSharesReshareRequest } from '../models/DKSharesReshareRequest';
 import { ErrorMessageFormatResponse } from '../models/ErrorMessageFormatResponse';
 import { EstimateGasRequestOffledger } from '../models/EstimateGasRequestOffledger';
 import { EstimateGasRequestOnledger } from '../models/EstimateGasRequestOnledger';
@@ -151,6 +163,8 @@ import { PeeringNodeIdentityResponse } from '../models/PeeringNodeIdentityRespon
 import { PeeringNodeStatusResponse } from '../models/PeeringNodeStatusResponse';
 import { PeeringTrustRequest } from '../models/PeeringTrustRequest';
 import { ProtocolParameters } from '../models/ProtocolParameters';
+import { PruneStatesRequest } from '../models/PruneStatesRequest';
+import { PruneStatesResponse } from '../models/PruneStatesResponse';
 import { PublicChainMetadata } from '../models/PublicChainMetadata';
 import { PublisherStateTransactionItem } from '../models/PublisherStateTransactionItem';
 import { Ratio32 } from '../models/Ratio32';
@@ -159,6 +173,8 @@ import { RentStructure } from '../models/RentStructure';
 import { RequestIDsResponse } from '../models/RequestIDsResponse';
 import { RequestJSON } from '../models/RequestJSON';
 import { RequestProcessedResponse } from '../models/RequestProcessedResponse';
+import { RevokeTokenRequest } from '../models/RevokeTokenRequest';
+import { StateProofResponse } from '../models/StateProofResponse';
 import { StateResponse } from '../models/StateResponse';
 import { StateTransaction } from '../models/StateTransaction';
 import { Transaction } from '../models/Transaction';
@@ -197,9 +213,12 @@ let enumsMap: Set<string> = new Set<string>([
 ]);
 
 let typeMap: {[index: string]: any} = {
+    "APIKeyResponse": APIKeyResponse,
     "AccountFoundriesResponse": AccountFoundriesResponse,
     "AccountNFTsResponse": AccountNFTsResponse,
     "AccountNonceResponse": AccountNonceResponse,
+    "AddAPIKeyRequest": AddAPIKeyRequest,
+    "AddAPIKeyResponse": AddAPIKeyResponse,
     "AddUserRequest": AddUserRequest,
     "AliasOutputMetricItem": AliasOutputMetricItem,
     "AssetsJSON": AssetsJSON,
@@ -223,6 +242,7 @@ let typeMap: {[index: string]: any} = {
     "ControlAddressesResponse": ControlAddressesResponse,
     "DKSharesInfo": DKSharesInfo,
     "DKSharesPostRequest": DKSharesPostRequest,
+    "DKSharesReshareRequest": DKSharesReshareRequest,
     "ErrorMessageFormatResponse": ErrorMessageFormatResponse,
     "EstimateGasRequestOffledger": EstimateGasRequestOffledger,
     "EstimateGasRequestOnledger": EstimateGasRequestOnledger,
@@ -262,6 +282,8 @@ let typeMap: {[index: string]: any} = {
     "PeeringNodeStatusResponse": PeeringNodeStatusResponse,
     "PeeringTrustRequest": PeeringTrustRequest,
     "ProtocolParameters": ProtocolParameters,
+    "PruneStatesRequest": PruneStatesRequest,
+    "PruneStatesResponse": PruneStatesResponse,
     "PublicChainMetadata": PublicChainMetadata,
     "PublisherStateTransactionItem": PublisherStateTransactionItem,
     "Ratio32": Ratio32,
@@ -270,6 +292,8 @@ let typeMap: {[index: string]: any} = {
     "RequestIDsResponse": RequestIDsResponse,
     "RequestJSON": RequestJSON,
     "RequestProcessedResponse": RequestProcessedResponse,
+    "RevokeTokenRequest": RevokeTokenRequest,
+    "StateProofResponse": StateProofResponse,
     "StateResponse": StateResponse,
     "StateTransaction": StateTransaction,
     "Transaction": Transaction,
@@ -0,0 +1,58 @@
+/**
+ * Wasp API
+ * REST API for the Wasp node
+ *
+ * OpenAPI spec version: 0
+ * 
+ *
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+import { HttpFile } from '../http/http';
+
+export class PruneStatesRequest {
+    /**
+    * Prune the states even if the chain keeps all the blocks
+    */
+    'force': boolean;
+    /**
+    * The index of the first block to prune; must not be above the oldest available block
+    */
+    'fromBlockIndex': number;
+    /**
+    * The index of the last block to prune
+    */
+    'toBlockIndex': number;
+
+    static readonly discriminator: string | undefined = undefined;
+
+    static readonly attributeTypeMap: Array<{name: string, baseName: string, type: string, format: string}> = [
+        {
+            "name": "force",
+            "baseName": "force",
+            "type": "boolean",
+            "format": "boolean"
+        },
+        {
+            "name": "fromBlockIndex",
+            "baseName": "fromBlockIndex",
+            "type": "number",
+            "format": "int32"
+        },
+        {
+            "name": "toBlockIndex",
+            "baseName": "toBlockIndex",
+            "type": "number",
+            "format": "int32"
+        }    ];
+
+    static getAttributeTypeMap() {
+        return PruneStatesRequest.attributeTypeMap;
+    }
+
+    public constructor() {
+    }
+}
+
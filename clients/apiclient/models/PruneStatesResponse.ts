@@ -0,0 +1,68 @@
+/**
+ * Wasp API
+ * REST API for the Wasp node
+ *
+ * OpenAPI spec version: 0
+ * 
+ *
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+import { HttpFile } from '../http/http';
+
+export class PruneStatesResponse {
+    /**
+    * The number of deleted trie nodes
+    */
+    'deletedNodes': number;
+    /**
+    * The number of deleted trie values
+    */
+    'deletedValues': number;
+    /**
+    * The index of the oldest state still available
+    */
+    'oldestBlockIndex': number;
+    /**
+    * The number of pruned states
+    */
+    'prunedBlocks': number;
+
+    static readonly discriminator: string | undefined = undefined;
+
+    static readonly attributeTypeMap: Array<{name: string, baseName: string, type: string, format: string}> = [
+        {
+            "name": "deletedNodes",
+            "baseName": "deletedNodes",
+            "type": "number",
+            "format": "int32"
+        },
+        {
+            "name": "deletedValues",
+            "baseName": "deletedValues",
+            "type": "number",
+            "format": "int32"
+        },
+        {
+            "name": "oldestBlockIndex",
+            "baseName": "oldestBlockIndex",
+            "type": "number",
+            "format": "int32"
+        },
+        {
+            "name": "prunedBlocks",
+            "baseName": "prunedBlocks",
+            "type": "number",
+            "format": "int32"
+        }    ];
+
+    static getAttributeTypeMap() {
+        return PruneStatesResponse.attributeTypeMap;
+    }
+
+    public constructor() {
+    }
+}
+
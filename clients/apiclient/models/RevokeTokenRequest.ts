@@ -0,0 +1,38 @@
+/**
+ * Wasp API
+ * REST API for the Wasp node
+ *
+ * OpenAPI spec version: 0
+ * 
+ *
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+import { HttpFile } from '../http/http';
+
+export class RevokeTokenRequest {
+    /**
+    * The token to revoke
+    */
+    'jwt': string;
+
+    static readonly discriminator: string | undefined = undefined;
+
+    static readonly attributeTypeMap: Array<{name: string, baseName: string, type: string, format: string}> = [
+        {
+            "name": "jwt",
+            "baseName": "jwt",
+            "type": "string",
+            "format": "string"
+        }    ];
+
+    static getAttributeTypeMap() {
+        return RevokeTokenRequest.attributeTypeMap;
+    }
+
+    public constructor() {
+    }
+}
+
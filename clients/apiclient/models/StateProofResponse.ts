@@ -0,0 +1,88 @@
+/**
+ * Wasp API
+ * REST API for the Wasp node
+ *
+ * OpenAPI spec version: 0
+ * 
+ *
+ * NOTE: This class is auto generated by OpenAPI Generator (https://openapi-generator.tech).
+ * https://openapi-generator.tech
+ * Do not edit the class manually.
+ */
+
+import { HttpFile } from '../http/http';
+
+export class StateProofResponse {
+    /**
+    * The confirmed alias output (with its ID) containing the L1 commitment (Hex-encoded)
+    */
+    'aliasOutput': string;
+    /**
+    * The requested key (Hex-encoded)
+    */
+    'key': string;
+    /**
+    * The L1 commitment containing the trie root (Hex-encoded)
+    */
+    'l1Commitment': string;
+    /**
+    * The Merkle proof of the key/value, or of its absence (Hex-encoded)
+    */
+    'proof': string;
+    /**
+    * The trie root the proof is verified against (Hex-encoded)
+    */
+    'trieRoot': string;
+    /**
+    * The value of the requested key, empty if absent (Hex-encoded)
+    */
+    'value': string;
+
+    static readonly discriminator: string | undefined = undefined;
+
+    static readonly attributeTypeMap: Array<{name: string, baseName: string, type: string, format: string}> = [
+        {
+            "name": "aliasOutput",
+            "baseName": "aliasOutput",
+            "type": "string",
+            "format": "string"
+        },
+        {
+            "name": "key",
+            "baseName": "key",
+            "type": "string",
+            "format": "string"
+        },
+        {
+            "name": "l1Commitment",
+            "baseName": "l1Commitment",
+            "type": "string",
+            "format": "string"
+        },
+        {
+            "name": "proof",
+            "baseName": "proof",
+            "type": "string",
+            "format": "string"
+        },
+        {
+            "name": "trieRoot",
+            "baseName": "trieRoot",
+            "type": "string",
+            "format": "string"
+        },
+        {
+            "name": "value",
+            "baseName": "value",
+            "type": "string",
+            "format": "string"
+        }    ];
+
+    static getAttributeTypeMap() {
+        return StateProofResponse.attributeTypeMap;
+    }
+
+    public constructor() {
+    }
+}
+
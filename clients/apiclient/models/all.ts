@@ -1,6 +1,9 @@
+export * from '../models/APIKeyResponse'
 export * from '../models/AccountFoundriesResponse'
 export * from '../models/AccountNFTsResponse'
 export * from '../models/AccountNonceResponse'
+export * from '../models/AddAPIKeyRequest'
+export * from '../models/AddAPIKeyResponse'
 export * from '../models/AddUserRequest'
 export * from '../models/AliasOutputMetricItem'
 export * from '../models/AssetsJSON'
@@ -24,6 +27,7 @@ export * from '../models/ContractInfoResponse'
 export * from '../models/ControlAddressesResponse'
 export * from '../models/DKSharesInfo'
 export * from '../models/DKSharesPostRequest'
+export * from '../models/DKSharesReshareRequest'
 export * from '../models/ErrorMessageFormatResponse'
 export * from '../models/EstimateGasRequestOffledger'
 export * from '../models/EstimateGasRequestOnledger'
@@ -63,6 +67,8 @@ export * from '../models/PeeringNodeIdentityResponse'
 export * from '../models/PeeringNodeStatusResponse'
 export * from '../models/PeeringTrustRequest'
 export * from '../models/ProtocolParameters'
+export * from '../models/PruneStatesRequest'
+export * from '../models/PruneStatesResponse'
 export * from '../models/PublicChainMetadata'
 export * from '../models/PublisherStateTransactionItem'
 export * from '../models/Ratio32'
@@ -71,6 +77,8 @@ export * from '../models/RentStructure'
 export * from '../models/RequestIDsResponse'
 export * from '../models/RequestJSON'
 export * from '../models/RequestProcessedResponse'
+export * from '../models/RevokeTokenRequest'
+export * from '../models/StateProofResponse'
 export * from '../models/StateResponse'
 export * from '../models/StateTransaction'
 export * from '../models/Transaction'
@@ -1,9 +1,12 @@
 import { ResponseContext, RequestContext, HttpFile } from '../http/http';
 import { Configuration} from '../configuration'
 
+import { APIKeyResponse } from '../models/APIKeyResponse';
 import { AccountFoundriesResponse } from '../models/AccountFoundriesResponse';
 import { AccountNFTsResponse } from '../models/AccountNFTsResponse';
 import { AccountNonceResponse } from '../models/AccountNonceResponse';
+import { AddAPIKeyRequest } from '../models/AddAPIKeyRequest';
+import { AddAPIKeyResponse } from '../models/AddAPIKeyResponse';
 import { AddUserRequest } from '../models/AddUserRequest';
 import { AliasOutputMetricItem } from '../models/AliasOutputMetricItem';
 import { AssetsJSON } from '../models/AssetsJSON';
@@ -27,6 +30,7 @@ import { ContractInfoResponse } from '../models/ContractInfoResponse';
 import { ControlAddressesResponse } from '../models/ControlAddressesResponse';
 import { DKSharesInfo } from '../models/DKSharesInfo';
 import { DKSharesPostRequest } from '../models/DKSharesPostRequest';
+import { DKSharesReshareRequest } from '../models/DKSharesReshareRequest';
 import { ErrorMessageFormatResponse } from '../models/ErrorMessageFormatResponse';
 import { EstimateGasRequestOffledger } from '../models/EstimateGasRequestOffledger';
 import { EstimateGasRequestOnledger } from '../models/EstimateGasRequestOnledger';
@@ -66,6 +70,8 @@ import { PeeringNodeIdentityResponse } from '../models/PeeringNodeIdentityRespon
 import { PeeringNodeStatusResponse } from '../models/PeeringNodeStatusResponse';
 import { PeeringTrustRequest } from '../models/PeeringTrustRequest';
 import { ProtocolParameters } from '../models/ProtocolParameters';
+import { PruneStatesRequest } from '../models/PruneStatesRequest';
+import { PruneStatesResponse } from '../models/PruneStatesResponse';
 import { PublicChainMetadata } from '../models/PublicChainMetadata';
 import { PublisherStateTransactionItem } from '../models/PublisherStateTransactionItem';
 import { Ratio32 } from '../models/Ratio32';
@@ -74,6 +80,8 @@ import { RentStructure } from '../models/RentStructure';
 import { RequestIDsResponse } from '../models/RequestIDsResponse';
 import { RequestJSON } from '../models/RequestJSON';
 import { RequestProcessedResponse } from '../models/RequestProcessedResponse';
+import { RevokeTokenRequest } from '../models/RevokeTokenRequest';
+import { StateProofResponse } from '../models/StateProofResponse';
 import { StateResponse } from '../models/StateResponse';
 import { StateTransaction } from '../models/StateTransaction';
 import { Transaction } from '../models/Transaction';
@@ -104,6 +112,15 @@ export interface AuthApiAuthenticateRequest {
     loginRequest: LoginRequest
 }
 
+export interface AuthApiRevokeTokenRequest {
+    /**
+     * The token to revoke
+     * @type RevokeTokenRequest
+     * @memberof AuthApirevokeToken
+     */
+    revokeTokenRequest: RevokeTokenRequest
+}
+
 export class ObjectAuthApi {
     private api: ObservableAuthApi
 
@@ -127,6 +144,14 @@ export class ObjectAuthApi {
         return this.api.authenticate(param.loginRequest,  options).toPromise();
     }
 
+    /**
+     * Revoke a JWT before it expires
+     * @param param the request object
+     */
+    public revokeToken(param: AuthApiRevokeTokenRequest, options?: Configuration): Promise<void> {
+        return this.api.revokeToken(param.revokeTokenRequest,  options).toPromise();
+    }
+
 }
 
 import { ObservableChainsApi } from "./ObservableAPI";
@@ -291,6 +316,21 @@ export interface ChainsApiGetReceiptRequest {
     requestID: string
 }
 
+export interface ChainsApiGetStateProofRequest {
+    /**
+     * ChainID (Bech32)
+     * @type string
+     * @memberof ChainsApigetStateProof
+     */
+    chainID: string
+    /**
+     * State Key (Hex)
+     * @type string
+     * @memberof ChainsApigetStateProof
+     */
+    stateKey: string
+}
+
 export interface ChainsApiGetStateValueRequest {
     /**
      * ChainID (Bech32)
@@ -306,6 +346,21 @@ export interface ChainsApiGetStateValueRequest {
     stateKey: string
 }
 
+export interface ChainsApiPruneStatesRequest {
+    /**
+     * ChainID (Bech32)
+     * @type string
+     * @memberof ChainsApipruneStates
+     */
+    chainID: string
+    /**
+     * The range of blocks to prune
+     * @type PruneStatesRequest
+     * @memberof ChainsApipruneStates
+     */
+    pruneStatesRequest: PruneStatesRequest
+}
+
 export interface ChainsApiRemoveAccessNodeRequest {
     /**
      * ChainID (Bech32)
@@ -493,6 +548,14 @@ export class ObjectChainsApi {
         return this.api.getReceipt(param.chainID, param.requestID,  options).toPromise();
     }
 
+    /**
+     * Fetch the value associated with the given key in the chain state, together with a proof anchored to the latest confirmed alias output
+     * @param param the request object
+     */
+    public getStateProof(param: ChainsApiGetStateProofRequest, options?: Configuration): Promise<StateProofResponse> {
+        return this.api.getStateProof(param.chainID, param.stateKey,  options).toPromise();
+    }
+
     /**
      * Fetch the raw value associated with the given key in the chain state
      * @param param the request object
@@ -501,6 +564,14 @@ export class ObjectChainsApi {
         return this.api.getStateValue(param.chainID, param.stateKey,  options).toPromise();
     }
 
+    /**
+     * Prune the historical states of a block range
+     * @param param the request object
+     */
+    public pruneStates(param: ChainsApiPruneStatesRequest, options?: Configuration): Promise<PruneStatesResponse> {
+        return this.api.pruneStates(param.chainID, param.pruneStatesRequest,  options).toPromise();
+    }
+
     /**
      * Remove an access node.
      * @param param the request object
@@ -1423,6 +1494,21 @@ export interface NodeApiGetVersionRequest {
 export interface NodeApiOwnerCertificateRequest {
 }
 
+export interface NodeApiReshareDKSRequest {
+    /**
+     * SharedAddress (Bech32)
+     * @type string
+     * @memberof NodeApireshareDKS
+     */
+    sharedAddress: string
+    /**
+     * Request parameters
+     * @type DKSharesReshareRequest
+     * @memberof NodeApireshareDKS
+     */
+    dKSharesReshareRequest: DKSharesReshareRequest
+}
+
 export interface NodeApiShutdownNodeRequest {
 }
 
@@ -1522,6 +1608,14 @@ export class ObjectNodeApi {
         return this.api.ownerCertificate( options).toPromise();
     }
 
+    /**
+     * Hand the distributed key over to a new committee, keeping the shared address
+     * @param param the request object
+     */
+    public reshareDKS(param: NodeApiReshareDKSRequest, options?: Configuration): Promise<DKSharesInfo> {
+        return this.api.reshareDKS(param.sharedAddress, param.dKSharesReshareRequest,  options).toPromise();
+    }
+
     /**
      * Shut down the node
      * @param param the request object
@@ -1572,6 +1666,21 @@ export class ObjectRequestsApi {
 import { ObservableUsersApi } from "./ObservableAPI";
 import { UsersApiRequestFactory, UsersApiResponseProcessor} from "../apis/UsersApi";
 
+export interface UsersApiAddAPIKeyRequest {
+    /**
+     * The username
+     * @type string
+     * @memberof UsersApiaddAPIKey
+     */
+    username: string
+    /**
+     * The API key data
+     * @type AddAPIKeyRequest
+     * @memberof UsersApiaddAPIKey
+     */
+    addAPIKeyRequest: AddAPIKeyRequest
+}
+
 export interface UsersApiAddUserRequest {
     /**
      * The user data
@@ -1611,6 +1720,21 @@ export interface UsersApiChangeUserPermissionsRequest {
     updateUserPermissionsRequest: UpdateUserPermissionsRequest
 }
 
+export interface UsersApiDeleteAPIKeyRequest {
+    /**
+     * The username
+     * @type string
+     * @memberof UsersApideleteAPIKey
+     */
+    username: string
+    /**
+     * The name of the API key
+     * @type string
+     * @memberof UsersApideleteAPIKey
+     */
+    apiKeyName: string
+}
+
 export interface UsersApiDeleteUserRequest {
     /**
      * The username
@@ -1620,6 +1744,15 @@ export interface UsersApiDeleteUserRequest {
     username: string
 }
 
+export interface UsersApiGetAPIKeysRequest {
+    /**
+     * The username
+     * @type string
+     * @memberof UsersApigetAPIKeys
+     */
+    username: string
+}
+
 export interface UsersApiGetUserRequest {
     /**
      * The username
@@ -1632,6 +1765,30 @@ export interface UsersApiGetUserRequest {
 export interface UsersApiGetUsersRequest {
 }
 
+export interface UsersApiRevokeUserTokenRequest {
+    /**
+     * The username
+     * @type string
+     * @memberof UsersApirevokeUserToken
+     */
+    username: string
+    /**
+     * The ID of the JWT (jti claim)
+     * @type string
+     * @memberof UsersApirevokeUserToken
+     */
+    tokenID: string
+}
+
+export interface UsersApiRevokeUserTokensRequest {
+    /**
+     * The username
+     * @type string
+     * @memberof UsersApirevokeUserTokens
+     */
+    username: string
+}
+
 export class ObjectUsersApi {
     private api: ObservableUsersApi
 
@@ -1639,6 +1796,14 @@ export class ObjectUsersApi {
         this.api = new ObservableUsersApi(configuration, requestFactory, responseProcessor);
     }
 
+    /**
+     * Add an API key to a user
+     * @param param the request object
+     */
+    public addAPIKey(param: UsersApiAddAPIKeyRequest, options?: Configuration): Promise<AddAPIKeyResponse> {
+        return this.api.addAPIKey(param.username, param.addAPIKeyRequest,  options).toPromise();
+    }
+
     /**
      * Add a user
      * @param param the request object
@@ -1663,6 +1828,14 @@ export class ObjectUsersApi {
         return this.api.changeUserPermissions(param.username, param.updateUserPermissionsRequest,  options).toPromise();
     }
 
+    /**
+     * Revoke an API key of a user
+     * @param param the request object
+     */
+    public deleteAPIKey(param: UsersApiDeleteAPIKeyRequest, options?: Configuration): Promise<void> {
+        return this.api.deleteAPIKey(param.username, param.apiKeyName,  options).toPromise();
+    }
+
     /**
      * Deletes a user
      * @param param the request object
@@ -1671,6 +1844,14 @@ export class ObjectUsersApi {
         return this.api.deleteUser(param.username,  options).toPromise();
     }
 
+    /**
+     * Get the API keys of a user
+     * @param param the request object
+     */
+    public getAPIKeys(param: UsersApiGetAPIKeysRequest, options?: Configuration): Promise<Array<APIKeyResponse>> {
+        return this.api.getAPIKeys(param.username,  options).toPromise();
+    }
+
     /**
      * Get a user
      * @param param the request object
@@ -1687,4 +1868,20 @@ export class ObjectUsersApi {
         return this.api.getUsers( options).toPromise();
     }
 
+    /**
+     * Revoke a JWT of a user by its ID
+     * @param param the request object
+     */
+    public revokeUserToken(param: UsersApiRevokeUserTokenRequest, options?: Configuration): Promise<void> {
+        return this.api.revokeUserToken(param.username, param.tokenID,  options).toPromise();
+    }
+
+    /**
+     * Revoke all the JWTs issued to a user until now
+     * @param param the request object
+     */
+    public revokeUserTokens(param: UsersApiRevokeUserTokensRequest, options?: Configuration): Promise<void> {
+        return this.api.revokeUserTokens(param.username,  options).toPromise();
+    }
+
 }
@@ -2,9 +2,12 @@ import { ResponseContext, RequestContext, HttpFile } from '../http/http';
 import { Configuration} from '../configuration'
 import { Observable, of, from } from '../rxjsStub';
 import {mergeMap, map} from  '../rxjsStub';
+import { APIKeyResponse } from '../models/APIKeyResponse';
 import { AccountFoundriesResponse } from '../models/AccountFoundriesResponse';
 import { AccountNFTsResponse } from '../models/AccountNFTsResponse';
 import { AccountNonceResponse } from '../models/AccountNonceResponse';
+import { AddAPIKeyRequest } from '../models/AddAPIKeyRequest';
+import { AddAPIKeyResponse } from '../models/AddAPIKeyResponse';
 import { AddUserRequest } from '../models/AddUserRequest';
 import { AliasOutputMetricItem } from '../models/AliasOutputMetricItem';
 import { AssetsJSON } from '../models/AssetsJSON';
@@ -28,6 +31,7 @@ import { ContractInfoResponse } from '../models/ContractInfoResponse';
 import { ControlAddressesResponse } from '../models/ControlAddressesResponse';
 import { DKSharesInfo } from '../models/DKSharesInfo';
 import { DKSharesPostRequest } from '../models/DKSharesPostRequest';
+import { DKSharesReshareRequest } from '../models/DKSharesReshareRequest';
 import { ErrorMessageFormatResponse } from '../models/ErrorMessageFormatResponse';
 import { EstimateGasRequestOffledger } from '../models/EstimateGasRequestOffledger';
 import { EstimateGasRequestOnledger } from '../models/EstimateGasRequestOnledger';
@@ -67,6 +71,8 @@ import { PeeringNodeIdentityResponse } from '../models/PeeringNodeIdentityRespon
 import { PeeringNodeStatusResponse } from '../models/PeeringNodeStatusResponse';
 import { PeeringTrustRequest } from '../models/PeeringTrustRequest';
 import { ProtocolParameters } from '../models/ProtocolParameters';
+import { PruneStatesRequest } from '../models/PruneStatesRequest';
+import { PruneStatesResponse } from '../models/PruneStatesResponse';
 import { PublicChainMetadata } from '../models/PublicChainMetadata';
 import { PublisherStateTransactionItem } from '../models/PublisherStateTransactionItem';
 import { Ratio32 } from '../models/Ratio32';
@@ -75,6 +81,8 @@ import { RentStructure } from '../models/RentStructure';
 import { RequestIDsResponse } from '../models/RequestIDsResponse';
 import { RequestJSON } from '../models/RequestJSON';
 import { RequestProcessedResponse } from '../models/RequestProcessedResponse';
+import { RevokeTokenRequest } from '../models/RevokeTokenRequest';
+import { StateProofResponse } from '../models/StateProofResponse';
 import { StateResponse } from '../models/StateResponse';
 import { StateTransaction } from '../models/StateTransaction';
 import { Transaction } from '../models/Transaction';
@@ -151,6 +159,29 @@ export class ObservableAuthApi {
             }));
     }
 
+    /**
+     * Revoke a JWT before it expires
+     * @param revokeTokenRequest The token to revoke
+     */
+    public revokeToken(revokeTokenRequest: RevokeTokenRequest, _options?: Configuration): Observable<void> {
+        const requestContextPromise = this.requestFactory.revokeToken(revokeTokenRequest, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.revokeToken(rsp)));
+            }));
+    }
+
 }
 
 import { ChainsApiRequestFactory, ChainsApiResponseProcessor} from "../apis/ChainsApi";
@@ -476,6 +507,30 @@ export class ObservableChainsApi {
             }));
     }
 
+    /**
+     * Fetch the value associated with the given key in the chain state, together with a proof anchored to the latest confirmed alias output
+     * @param chainID ChainID (Bech32)
+     * @param stateKey State Key (Hex)
+     */
+    public getStateProof(chainID: string, stateKey: string, _options?: Configuration): Observable<StateProofResponse> {
+        const requestContextPromise = this.requestFactory.getStateProof(chainID, stateKey, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.getStateProof(rsp)));
+            }));
+    }
+
     /**
      * Fetch the raw value associated with the given key in the chain state
      * @param chainID ChainID (Bech32)
@@ -500,6 +555,30 @@ export class ObservableChainsApi {
             }));
     }
 
+    /**
+     * Prune the historical states of a block range
+     * @param chainID ChainID (Bech32)
+     * @param pruneStatesRequest The range of blocks to prune
+     */
+    public pruneStates(chainID: string, pruneStatesRequest: PruneStatesRequest, _options?: Configuration): Observable<PruneStatesResponse> {
+        const requestContextPromise = this.requestFactory.pruneStates(chainID, pruneStatesRequest, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.pruneStates(rsp)));
+            }));
+    }
+
     /**
      * Remove an access node.
      * @param chainID ChainID (Bech32)
@@ -1695,6 +1774,30 @@ export class ObservableNodeApi {
             }));
     }
 
+    /**
+     * Hand the distributed key over to a new committee, keeping the shared address
+     * @param sharedAddress SharedAddress (Bech32)
+     * @param dKSharesReshareRequest Request parameters
+     */
+    public reshareDKS(sharedAddress: string, dKSharesReshareRequest: DKSharesReshareRequest, _options?: Configuration): Observable<DKSharesInfo> {
+        const requestContextPromise = this.requestFactory.reshareDKS(sharedAddress, dKSharesReshareRequest, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.reshareDKS(rsp)));
+            }));
+    }
+
     /**
      * Shut down the node
      */
@@ -1799,6 +1902,30 @@ export class ObservableUsersApi {
         this.responseProcessor = responseProcessor || new UsersApiResponseProcessor();
     }
 
+    /**
+     * Add an API key to a user
+     * @param username The username
+     * @param addAPIKeyRequest The API key data
+     */
+    public addAPIKey(username: string, addAPIKeyRequest: AddAPIKeyRequest, _options?: Configuration): Observable<AddAPIKeyResponse> {
+        const requestContextPromise = this.requestFactory.addAPIKey(username, addAPIKeyRequest, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.addAPIKey(rsp)));
+            }));
+    }
+
     /**
      * Add a user
      * @param addUserRequest The user data
@@ -1870,6 +1997,30 @@ export class ObservableUsersApi {
             }));
     }
 
+    /**
+     * Revoke an API key of a user
+     * @param username The username
+     * @param apiKeyName The name of the API key
+     */
+    public deleteAPIKey(username: string, apiKeyName: string, _options?: Configuration): Observable<void> {
+        const requestContextPromise = this.requestFactory.deleteAPIKey(username, apiKeyName, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.deleteAPIKey(rsp)));
+            }));
+    }
+
     /**
      * Deletes a user
      * @param username The username
@@ -1893,6 +2044,29 @@ export class ObservableUsersApi {
             }));
     }
 
+    /**
+     * Get the API keys of a user
+     * @param username The username
+     */
+    public getAPIKeys(username: string, _options?: Configuration): Observable<Array<APIKeyResponse>> {
+        const requestContextPromise = this.requestFactory.getAPIKeys(username, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.getAPIKeys(rsp)));
+            }));
+    }
+
     /**
      * Get a user
      * @param username The username
@@ -1938,4 +2112,51 @@ export class ObservableUsersApi {
             }));
     }
 
+    /**
+     * Revoke a JWT of a user by its ID
+     * @param username The username
+     * @param tokenID The ID of the JWT (jti claim)
+     */
+    public revokeUserToken(username: string, tokenID: string, _options?: Configuration): Observable<void> {
+        const requestContextPromise = this.requestFactory.revokeUserToken(username, tokenID, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.revokeUserToken(rsp)));
+            }));
+    }
+
+    /**
+     * Revoke all the JWTs issued to a user until now
+     * @param username The username
+     */
+    public revokeUserTokens(username: string, _options?: Configuration): Observable<void> {
+        const requestContextPromise = this.requestFactory.revokeUserTokens(username, _options);
+
+        // build promise chain
+        let middlewarePreObservable = from<RequestContext>(requestContextPromise);
+        for (let middleware of this.configuration.middleware) {
+            middlewarePreObservable = middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => middleware.pre(ctx)));
+        }
+
+        return middlewarePreObservable.pipe(mergeMap((ctx: RequestContext) => this.configuration.httpApi.send(ctx))).
+            pipe(mergeMap((response: ResponseContext) => {
+                let middlewarePostObservable = of(response);
+                for (let middleware of this.configuration.middleware) {
+                    middlewarePostObservable = middlewarePostObservable.pipe(mergeMap((rsp: ResponseContext) => middleware.post(rsp)));
+                }
+                return middlewarePostObservable.pipe(map((rsp: ResponseContext) => this.responseProcessor.revokeUserTokens(rsp)));
+            }));
+    }
+
 }
@@ -1,9 +1,12 @@
 import { ResponseContext, RequestContext, HttpFile } from '../http/http';
 import { Configuration} from '../configuration'
 
+import { APIKeyResponse } from '../models/APIKeyResponse';
 import { AccountFoundriesResponse } from '../models/AccountFoundriesResponse';
 import { AccountNFTsResponse } from '../models/AccountNFTsResponse';
 import { AccountNonceResponse } from '../models/AccountNonceResponse';
+import { AddAPIKeyRequest } from '../models/AddAPIKeyRequest';
+import { AddAPIKeyResponse } from '../models/AddAPIKeyResponse';
 import { AddUserRequest } from '../models/AddUserRequest';
 import { AliasOutputMetricItem } from '../models/AliasOutputMetricItem';
 import { AssetsJSON } from '../models/AssetsJSON';
@@ -27,6 +30,7 @@ import { ContractInfoResponse } from '../models/ContractInfoResponse';
 import { ControlAddressesResponse } from '../models/ControlAddressesResponse';
 import { DKSharesInfo } from '../models/DKSharesInfo';
 import { DKSharesPostRequest } from '../models/DKSharesPostRequest';
+import { DKSharesReshareRequest } from '../models/DKSharesReshareRequest';
 import { ErrorMessageFormatResponse } from '../models/ErrorMessageFormatResponse';
 import { EstimateGasRequestOffledger } from '../models/EstimateGasRequestOffledger';
 import { EstimateGasRequestOnledger } from '../models/EstimateGasRequestOnledger';
@@ -66,6 +70,8 @@ import { PeeringNodeIdentityResponse } from '../models/PeeringNodeIdentityRespon
 import { PeeringNodeStatusResponse } from '../models/PeeringNodeStatusResponse';
 import { PeeringTrustRequest } from '../models/PeeringTrustRequest';
 import { ProtocolParameters } from '../models/ProtocolParameters';
+import { PruneStatesRequest } from '../models/PruneStatesRequest';
+import { PruneStatesResponse } from '../models/PruneStatesResponse';
 import { PublicChainMetadata } from '../models/PublicChainMetadata';
 import { PublisherStateTransactionItem } from '../models/PublisherStateTransactionItem';
 import { Ratio32 } from '../models/Ratio32';
@@ -74,6 +80,8 @@ import { RentStructure } from '../models/RentStructure';
 import { RequestIDsResponse } from '../models/RequestIDsResponse';
 import { RequestJSON } from '../models/RequestJSON';
 import { RequestProcessedResponse } from '../models/RequestProcessedResponse';
+import { RevokeTokenRequest } from '../models/RevokeTokenRequest';
+import { StateProofResponse } from '../models/StateProofResponse';
 import { StateResponse } from '../models/StateResponse';
 import { StateTransaction } from '../models/StateTransaction';
 import { Transaction } from '../models/Transaction';
@@ -119,6 +127,15 @@ export class PromiseAuthApi {
         return result.toPromise();
     }
 
+    /**
+     * Revoke a JWT before it expires
+     * @param revokeTokenRequest The token to revoke
+     */
+    public revokeToken(revokeTokenRequest: RevokeTokenRequest, _options?: Configuration): Promise<void> {
+        const result = this.api.revokeToken(revokeTokenRequest, _options);
+        return result.toPromise();
+    }
+
 
 }
 
@@ -263,6 +280,16 @@ export class PromiseChainsApi {
         return result.toPromise();
     }
 
+    /**
+     * Fetch the value associated with the given key in the chain state, together with a proof anchored to the latest confirmed alias output
+     * @param chainID ChainID (Bech32)
+     * @param stateKey State Key (Hex)
+     */
+    public getStateProof(chainID: string, stateKey: string, _options?: Configuration): Promise<StateProofResponse> {
+        const result = this.api.getStateProof(chainID, stateKey, _options);
+        return result.toPromise();
+    }
+
     /**
      * Fetch the raw value associated with the given key in the chain state
      * @param chainID ChainID (Bech32)
@@ -273,6 +300,16 @@ export class PromiseChainsApi {
         return result.toPromise();
     }
 
+    /**
+     * Prune the historical states of a block range
+     * @param chainID ChainID (Bech32)
+     * @param pruneStatesRequest The range of blocks to prune
+     */
+    public pruneStates(chainID: string, pruneStatesRequest: PruneStatesRequest, _options?: Configuration): Promise<PruneStatesResponse> {
+        const result = this.api.pruneStates(chainID, pruneStatesRequest, _options);
+        return result.toPromise();
+    }
+
     /**
      * Remove an access node.
      * @param chainID ChainID (Bech32)
@@ -814,6 +851,16 @@ export class PromiseNodeApi {
         return result.toPromise();
     }
 
+    /**
+     * Hand the distributed key over to a new committee, keeping the shared address
+     * @param sharedAddress SharedAddress (Bech32)
+     * @param dKSharesReshareRequest Request parameters
+     */
+    public reshareDKS(sharedAddress: string, dKSharesReshareRequest: DKSharesReshareRequest, _options?: Configuration): Promise<DKSharesInfo> {
+        const result = this.api.reshareDKS(sharedAddress, dKSharesReshareRequest, _options);
+        return result.toPromise();
+    }
+
     /**
      * Shut down the node
      */
@@ -878,6 +925,16 @@ export class PromiseUsersApi {
         this.api = new ObservableUsersApi(configuration, requestFactory, responseProcessor);
     }
 
+    /**
+     * Add an API key to a user
+     * @param username The username
+     * @param addAPIKeyRequest The API key data
+     */
+    public addAPIKey(username: string, addAPIKeyRequest: AddAPIKeyRequest, _options?: Configuration): Promise<AddAPIKeyResponse> {
+        const result = this.api.addAPIKey(username, addAPIKeyRequest, _options);
+        return result.toPromise();
+    }
+
     /**
      * Add a user
      * @param addUserRequest The user data
@@ -907,6 +964,16 @@ export class PromiseUsersApi {
         return result.toPromise();
     }
 
+    /**
+     * Revoke an API key of a user
+     * @param username The username
+     * @param apiKeyName The name of the API key
+     */
+    public deleteAPIKey(username: string, apiKeyName: string, _options?: Configuration): Promise<void> {
+        const result = this.api.deleteAPIKey(username, apiKeyName, _options);
+        return result.toPromise();
+    }
+
     /**
      * Deletes a user
      * @param username The username
@@ -916,6 +983,15 @@ export class PromiseUsersApi {
         return result.toPromise();
     }
 
+    /**
+     * Get the API keys of a user
+     * @param username The username
+     */
+    public getAPIKeys(username: string, _options?: Configuration): Promise<Array<APIKeyResponse>> {
+        const result = this.api.getAPIKeys(username, _options);
+        return result.toPromise();
+    }
+
     /**
      * Get a user
      * @param username The username
@@ -933,6 +1009,25 @@ export class PromiseUsersApi {
         return result.toPromise();
     }
 
+    /**
+     * Revoke a JWT of a user by its ID
+     * @param username The username
+     * @param tokenID The ID of the JWT (jti claim)
+     */
+    public revokeUserToken(username: string, tokenID: string, _options?: Configuration): Promise<void> {
+        const result = this.api.revokeUserToken(username, tokenID, _options);
+        return result.toPromise();
+    }
+
+    /**
+     * Revoke all the JWTs issued to a user until now
+     * @param username The username
+     */
+    public revokeUserTokens(username: string, _options?: Configuration): Promise<void> {
+        const result = this.api.revokeUserTokens(username, _options);
+        return result.toPromise();
+    }
+
 
 }
 
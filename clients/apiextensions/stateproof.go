@@ -0,0 +1,69 @@
+package apiextensions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/transaction"
+	"github.com/nnikolash/wasp-types-exported/packages/trie"
+)
+
+// VerifyStateProof verifies a StateProofResponse without contacting the node:
+// the alias output must commit to the L1 commitment, the L1 commitment to the
+// trie root and the Merkle proof to the value (or absence) of the key.
+// It returns the alias output the proof is anchored to, which is the only part
+// that still has to be checked against L1 to fully trust the value.
+func VerifyStateProof(resp *apiclient.StateProofResponse) (*isc.AliasOutputWithID, error) {
+	key, err := iotago.DecodeHex(resp.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	value, err := iotago.DecodeHex(resp.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	proofBytes, err := iotago.DecodeHex(resp.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	proof, err := trie.MerkleProofFromBytes(proofBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	aliasOutputBytes, err := iotago.DecodeHex(resp.AliasOutput)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias output: %w", err)
+	}
+	aliasOutput, err := isc.AliasOutputWithIDFromBytes(aliasOutputBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias output: %w", err)
+	}
+	l1Commitment, err := transaction.L1CommitmentFromAliasOutput(aliasOutput.GetAliasOutput())
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias output state metadata: %w", err)
+	}
+
+	l1CommitmentBytes, err := iotago.DecodeHex(resp.L1Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid L1 commitment: %w", err)
+	}
+	if !bytes.Equal(l1CommitmentBytes, l1Commitment.Bytes()) {
+		return nil, errors.New("L1 commitment does not match the alias output")
+	}
+	trieRoot, err := iotago.DecodeHex(resp.TrieRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trie root: %w", err)
+	}
+	if !bytes.Equal(trieRoot, l1Commitment.TrieRoot().Bytes()) {
+		return nil, errors.New("trie root does not match the L1 commitment")
+	}
+
+	if err := proof.ValidateKeyValue(l1Commitment.TrieRoot(), key, value); err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	return aliasOutput, nil
+}
@@ -49,6 +49,7 @@ type PostRequestParams struct {
 	gasBudget                uint64
 	AutoAdjustStorageDeposit bool
 	OnlyUnlockedOutputs      bool
+	Sponsor                  isc.AgentID // pays the gas fee of off-ledger requests, nil if the sender pays
 }
 
 func (par *PostRequestParams) GasBudget() uint64 {
@@ -205,6 +206,7 @@ func (c *Client) PostOffLedgerRequest(ctx context.Context,
 	req := isc.NewOffLedgerRequest(c.ChainID, contractHname, entrypoint, par.Args, par.Nonce, par.GasBudget())
 	req.WithAllowance(par.Allowance)
 	req.WithNonce(par.Nonce)
+	req.WithSponsor(par.Sponsor)
 	signed := req.Sign(c.KeyPair)
 
 	request := iotago.EncodeHex(signed.Bytes())
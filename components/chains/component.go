@@ -109,6 +109,8 @@ func provide(c *dig.Container) error {
 				ParamsWAL.LoadToStore,
 				ParamsWAL.Enabled,
 				ParamsWAL.Path,
+				ParamsWAL.SegmentMaxSize,
+				ParamsWAL.RetentionStates,
 				ParamsStateManager.BlockCacheMaxSize,
 				ParamsStateManager.BlockCacheBlocksInCacheDuration,
 				ParamsStateManager.BlockCacheBlockCleaningPeriod,
@@ -119,9 +121,11 @@ func provide(c *dig.Container) error {
 				ParamsStateManager.StateManagerTimerTickPeriod,
 				ParamsStateManager.PruningMinStatesToKeep,
 				ParamsStateManager.PruningMaxStatesToDelete,
+				ParamsStateManager.PruningMinStatesAge,
 				ParamsSnapshotManager.SnapshotsToLoad,
 				ParamsSnapshotManager.Period,
 				ParamsSnapshotManager.Delay,
+				ParamsSnapshotManager.DeltasPerFull,
 				ParamsSnapshotManager.LocalPath,
 				ParamsSnapshotManager.NetworkPaths,
 				deps.ChainRecordRegistryProvider,
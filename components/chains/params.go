@@ -30,9 +30,11 @@ type ParametersChains struct {
 }
 
 type ParametersWAL struct {
-	LoadToStore bool   `default:"false" usage:"load blocks from \"write-ahead log\" to the store on node start-up"`
-	Enabled     bool   `default:"true" usage:"whether the \"write-ahead logging\" is enabled"`
-	Path        string `default:"waspdb/wal" usage:"the path to the \"write-ahead logging\" folder"`
+	LoadToStore     bool   `default:"false" usage:"load blocks from \"write-ahead log\" to the store on node start-up"`
+	Enabled         bool   `default:"true" usage:"whether the \"write-ahead logging\" is enabled"`
+	Path            string `default:"waspdb/wal" usage:"the path to the \"write-ahead logging\" folder"`
+	SegmentMaxSize  int64  `default:"67108864" usage:"the size in bytes after which a \"write-ahead log\" segment file is sealed and a new one is started"`
+	RetentionStates uint32 `default:"0" usage:"how many latest states are kept in the \"write-ahead log\"; older segments are deleted; if 0 - the log is never cleaned"`
 }
 
 type ParametersValidator struct {
@@ -50,12 +52,14 @@ type ParametersStateManager struct {
 	StateManagerTimerTickPeriod       time.Duration `default:"1s" usage:"how often timer tick fires in state manager"`
 	PruningMinStatesToKeep            int           `default:"10000" usage:"this number of states will always be available in the store; if 0 - store pruning is disabled"`
 	PruningMaxStatesToDelete          int           `default:"10" usage:"on single store pruning attempt at most this number of states will be deleted; NOTE: pruning takes considerable amount of time; setting this parameter large may seriously damage Wasp responsiveness if many blocks require pruning"`
+	PruningMinStatesAge               time.Duration `default:"0s" usage:"states younger than this will always be available in the store (in addition to 'pruningMinStatesToKeep'); e.g. set 'pruningMinStatesToKeep' to 1 and this to 720h to keep only the last 30 days of states; if 0 - states are kept by count only"`
 }
 
 type ParametersSnapshotManager struct {
 	SnapshotsToLoad []string `default:"" usage:"list of snapshots to load; can be either single block hash of a snapshot (if a single chain has to be configured) or list of '<chainID>:<blockHash>' to configure many chains"`
 	Period          uint32   `default:"0" usage:"how often state snapshots should be made: 1000 meaning \"every 1000th state\", 0 meaning \"making snapshots is disabled\""`
 	Delay           uint32   `default:"20" usage:"how many states should pass before snapshot is produced"`
+	DeltasPerFull   uint32   `default:"0" usage:"how many delta snapshots, containing only the state changes since the previous snapshot, should be produced after each full snapshot; 0 meaning \"only full snapshots are produced\""`
 	LocalPath       string   `default:"waspdb/snap" usage:"the path to the snapshots folder in this node's disk"`
 	NetworkPaths    []string `default:"" usage:"the list of paths to the remote (http(s)) snapshot locations; each of listed locations must contain 'INDEX' file with list of snapshot files"`
 }
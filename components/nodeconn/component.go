@@ -12,6 +12,7 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/chain"
 	"github.com/nnikolash/wasp-types-exported/packages/daemon"
 	"github.com/nnikolash/wasp-types-exported/packages/nodeconn"
+	"github.com/nnikolash/wasp-types-exported/packages/nodeconn/utxodbconn"
 )
 
 func init() {
@@ -37,6 +38,28 @@ type dependencies struct {
 }
 
 func provide(c *dig.Container) error {
+	if ParamsUtxoDB.Enabled {
+		if err := c.Provide(func() chain.NodeConnection {
+			nodeConnection, err := utxodbconn.New(
+				Component.Logger().Named("nc"),
+				utxodbconn.Config{
+					MilestoneInterval: ParamsUtxoDB.MilestoneInterval,
+					FaucetAmount:      ParamsUtxoDB.FaucetAmount,
+					DatabasePath:      ParamsUtxoDB.DatabasePath,
+				},
+			)
+			if err != nil {
+				Component.LogPanicf("Creating NodeConnection failed: %s", err.Error())
+			}
+			Component.LogWarn("Using the in-process L1 ledger, this node is not connected to any network")
+			return nodeConnection
+		}); err != nil {
+			Component.LogPanic(err)
+		}
+
+		return nil
+	}
+
 	if err := c.Provide(func() (*nodebridge.NodeBridge, error) {
 		nodeBridge := nodebridge.NewNodeBridge(
 			Component.Logger(),
@@ -1,15 +1,28 @@
 package nodeconn
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/inx-app/core/inx"
 )
 
-var ParamsINX = &inx.ParametersINX{}
+type ParametersUtxoDB struct {
+	Enabled           bool          `default:"false" usage:"whether to run against an in-process L1 ledger instead of connecting to a node via INX (for development only)"`
+	MilestoneInterval time.Duration `default:"1s" usage:"the interval at which the milestones of the in-process ledger are issued"`
+	FaucetAmount      uint64        `default:"1000000000" usage:"the amount of base tokens returned by the faucet of the in-process ledger"`
+	DatabasePath      string        `default:"waspdb/utxodb.json" usage:"the file the in-process ledger is saved to (empty to keep it in memory only)"`
+}
+
+var (
+	ParamsINX    = &inx.ParametersINX{}
+	ParamsUtxoDB = &ParametersUtxoDB{}
+)
 
 var params = &app.ComponentParams{
 	Params: map[string]any{
-		"inx": ParamsINX,
+		"inx":    ParamsINX,
+		"utxodb": ParamsUtxoDB,
 	},
 	Masked: nil,
 }
@@ -9,11 +9,11 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/hive.go/app"
-	hivep2p "github.com/iotaledger/hive.go/crypto/p2p"
 	"github.com/iotaledger/hive.go/runtime/ioutils"
 	"github.com/nnikolash/wasp-types-exported/packages/chain"
 	"github.com/nnikolash/wasp-types-exported/packages/chain/cmt_log"
 	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
+	"github.com/nnikolash/wasp-types-exported/packages/keystore"
 	"github.com/nnikolash/wasp-types-exported/packages/registry"
 )
 
@@ -28,8 +28,32 @@ func init() {
 var Component *app.Component
 
 func provide(c *dig.Container) error {
-	if err := c.Provide(func() registry.NodeIdentityProvider {
-		return nodeIdentityRegistry()
+	if err := c.Provide(func() keystore.KeyStore {
+		passphrase, err := keystore.ReadPassphrase(ParamsKeyStore.PassphraseEnv, ParamsKeyStore.PassphraseFilePath)
+		if err != nil {
+			Component.LogPanicf("unable to read key store passphrase: %s", err)
+		}
+		keyStore, err := keystore.New(ParamsKeyStore.Type, &keystore.Config{
+			Passphrase:  passphrase,
+			KeyFilePath: ParamsKeyStore.KeyFilePath,
+			Options:     ParamsKeyStore.Options,
+		})
+		if err != nil {
+			Component.LogPanicf("unable to create key store: %s", err)
+		}
+		return keyStore
+	}); err != nil {
+		Component.LogPanic(err)
+	}
+
+	type nodeIdentityDeps struct {
+		dig.In
+
+		KeyStore keystore.KeyStore
+	}
+
+	if err := c.Provide(func(deps nodeIdentityDeps) registry.NodeIdentityProvider {
+		return nodeIdentityRegistry(deps.KeyStore)
 	}); err != nil {
 		Component.LogPanic(err)
 	}
@@ -65,10 +89,11 @@ func provide(c *dig.Container) error {
 
 		NodeIdentityProvider registry.NodeIdentityProvider
 		NodeConnection       chain.NodeConnection
+		KeyStore             keystore.KeyStore
 	}
 
 	if err := c.Provide(func(deps dkSharesRegistryDeps) registry.DKShareRegistryProvider {
-		dkSharesRegistry, err := registry.NewDKSharesRegistry(ParamsRegistries.DKShares.Path, deps.NodeIdentityProvider.NodeIdentity().GetPrivateKey(), deps.NodeConnection.GetBech32HRP())
+		dkSharesRegistry, err := registry.NewDKSharesRegistry(ParamsRegistries.DKShares.Path, deps.NodeIdentityProvider.NodeIdentity().GetPrivateKey(), deps.NodeConnection.GetBech32HRP(), deps.KeyStore)
 		if err != nil {
 			Component.LogPanic(err)
 		}
@@ -90,16 +115,19 @@ func provide(c *dig.Container) error {
 	return nil
 }
 
-func nodeIdentityRegistry() *registry.NodeIdentity {
+func nodeIdentityRegistry(keyStore keystore.KeyStore) *registry.NodeIdentity {
 	if err := ioutils.CreateDirectory(ParamsP2P.Database.Path, 0o700); err != nil {
 		Component.LogPanicf("could not create peer store database dir '%s': %w", ParamsP2P.Database.Path, err)
 	}
 
 	// make sure nobody copies around the peer store since it contains the private key of the node
+	if keyStore != nil {
+		Component.LogInfof("the node identity, the DKShares and the user credentials are encrypted using the %q key store", ParamsKeyStore.Type)
+	}
 	Component.LogInfof(`WARNING: never share your "%s" or "%s" folder as both contain your node's private key!`, ParamsP2P.Database.Path, path.Dir(ParamsP2P.Identity.FilePath))
 
 	// load up the previously generated identity or create a new one
-	privKey, newlyCreated, err := hivep2p.LoadOrCreateIdentityPrivateKey(ParamsP2P.Identity.FilePath, ParamsP2P.Identity.PrivateKey)
+	privKey, newlyCreated, err := keystore.LoadOrCreateIdentityPrivateKey(keyStore, ParamsP2P.Identity.FilePath, ParamsP2P.Identity.PrivateKey)
 	if err != nil {
 		Component.LogPanic(err)
 	}
@@ -32,15 +32,27 @@ type ParametersP2P struct {
 	} `name:"db"`
 }
 
+// ParametersKeyStore contains the definition of the parameters used to encrypt the node secrets at rest.
+// The passphrase is never read from the configuration, but from an environment variable or a file.
+type ParametersKeyStore struct {
+	Type               string            `default:"none" usage:"the key store encrypting the node identity, the DKShares and the user credentials (none, passphrase, keyFile, pkcs11)"`
+	PassphraseEnv      string            `default:"WASP_KEYSTORE_PASSPHRASE" usage:"the environment variable containing the passphrase the encryption key is derived from (passphrase key store) or the user PIN of the token (pkcs11 key store)"`
+	PassphraseFilePath string            `default:"" usage:"the path to the file containing the passphrase or the PIN, takes precedence over the environment variable"`
+	KeyFilePath        string            `default:"" usage:"the path to the file containing the hex encoded encryption key (keyFile key store)"`
+	Options            map[string]string `noflag:"true" usage:"the options of the other key store backends, e.g. module, tokenLabel and keyLabel of the pkcs11 key store"`
+}
+
 var (
 	ParamsRegistries = &ParametersRegistries{}
 	ParamsP2P        = &ParametersP2P{}
+	ParamsKeyStore   = &ParametersKeyStore{}
 )
 
 var params = &app.ComponentParams{
 	Params: map[string]any{
 		"registries": ParamsRegistries,
 		"p2p":        ParamsP2P,
+		"keyStore":   ParamsKeyStore,
 	},
 	Masked: []string{"p2p.identity.privateKey"},
 }
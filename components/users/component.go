@@ -1,12 +1,12 @@
 package users
 
 import (
-	"encoding/hex"
-
+	"github.com/samber/lo"
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/app/configuration"
+	"github.com/nnikolash/wasp-types-exported/packages/keystore"
 	"github.com/nnikolash/wasp-types-exported/packages/users"
 )
 
@@ -26,6 +26,7 @@ func provide(c *dig.Container) error {
 
 		UsersConfig         *configuration.Configuration `name:"usersConfig"`
 		UsersConfigFilePath *string                      `name:"usersConfigFilePath"`
+		KeyStore            keystore.KeyStore
 	}
 
 	type userManagerResult struct {
@@ -35,16 +36,16 @@ func provide(c *dig.Container) error {
 	}
 
 	if err := c.Provide(func(deps userManagerDeps) userManagerResult {
-		userManager := users.NewUserManager((func(users []*users.User) error {
-			// store users from user manager to the config file
+		// store users from user manager to the config file
+		storeUsers := func(users []*users.User) error {
 			cfgUsers := make(map[string]*User)
 
 			for _, u := range users {
-				cfgUsers[u.Name] = &User{
-					PasswordHash: hex.EncodeToString(u.PasswordHash),
-					PasswordSalt: hex.EncodeToString(u.PasswordSalt),
-					Permissions:  u.PermissionsSlice(),
+				cfgUser, err := UserFromUser(u, deps.KeyStore)
+				if err != nil {
+					return err
 				}
+				cfgUsers[u.Name] = cfgUser
 			}
 
 			if err := deps.UsersConfig.Set(CfgUsers, cfgUsers); err != nil {
@@ -52,11 +53,13 @@ func provide(c *dig.Container) error {
 			}
 
 			return deps.UsersConfig.StoreFile(*deps.UsersConfigFilePath, 0o600)
-		}))
+		}
+		userManager := users.NewUserManager(storeUsers)
 
 		// add users from config file to the user manager
+		migrate := false
 		for name, u := range ParamsUsers.Users {
-			user, err := users.NewUser(name, u.PasswordHash, u.PasswordSalt, u.PermissionsMap())
+			user, err := u.ToUser(name, deps.KeyStore)
 			if err != nil {
 				Component.LogPanicf("unable to add user to user manager %s: %s", name, err)
 			}
@@ -64,6 +67,18 @@ func provide(c *dig.Container) error {
 			if err := userManager.AddUser(user); err != nil {
 				Component.LogPanicf("unable to add user to user manager %s: %s", name, err)
 			}
+
+			if deps.KeyStore != nil && !u.IsEncrypted() {
+				migrate = true
+			}
+		}
+
+		// encrypt the hashes stored in plain text, the same way as it is done for the DKShares
+		if migrate {
+			if err := storeUsers(lo.Values(userManager.Users())); err != nil {
+				Component.LogPanicf("unable to encrypt the users: %s", err)
+			}
+			Component.LogInfof("encrypted the users stored in plain text in %s", *deps.UsersConfigFilePath)
 		}
 
 		userManager.EnableStoreOnChange()
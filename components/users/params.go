@@ -1,28 +1,175 @@
 package users
 
 import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared/permissions"
+	"github.com/nnikolash/wasp-types-exported/packages/keystore"
+	"github.com/nnikolash/wasp-types-exported/packages/users"
 )
 
 const (
 	CfgUsers = "users.users"
 )
 
+// User is the representation of a user in the users config file.
+// The hashes are encrypted, if a key store is configured.
 type User struct {
 	PasswordHash string   `default:"0000000000000000000000000000000000000000000000000000000000000000" usage:"the auth password+salt as a scrypt hash"`
 	PasswordSalt string   `default:"0000000000000000000000000000000000000000000000000000000000000000" usage:"the auth salt used for hashing the password"`
 	Permissions  []string `default:"" usage:"permissions of the user"`
+	// APIKeys by their names
+	APIKeys map[string]*APIKey `noflag:"true" usage:"the API keys of the user"`
+	// RevokedTokens maps the IDs of the revoked JWTs to their expiry times (unix seconds, 0 if the token does not expire)
+	RevokedTokens map[string]int64 `noflag:"true" usage:"the revoked JWTs of the user"`
+	// TokensNotBefore revokes all the JWTs of the user issued until this time (unix seconds, 0 if none)
+	TokensNotBefore int64 `noflag:"true" usage:"all the JWTs of the user issued until this time are revoked"`
+}
+
+type APIKey struct {
+	KeyHash     string   `usage:"the SHA-256 hash of the API key"`
+	Permissions []string `usage:"permissions of the API key, empty means all the permissions of the user"`
+	CreatedAt   int64    `usage:"creation time of the API key (unix seconds)"`
+	ExpiresAt   int64    `usage:"expiry time of the API key (unix seconds), 0 if the key does not expire"`
+}
+
+// IsEncrypted returns, if all the hashes of the user are encrypted by a key store.
+func (u *User) IsEncrypted() bool {
+	for _, apiKey := range u.APIKeys {
+		if !keystore.IsEncryptedString(apiKey.KeyHash) {
+			return false
+		}
+	}
+	return keystore.IsEncryptedString(u.PasswordHash) && keystore.IsEncryptedString(u.PasswordSalt)
 }
 
 // PermissionsMap returns the permissions of the user as a map.
 func (u *User) PermissionsMap() map[string]struct{} {
-	permissionsMap := make(map[string]struct{})
-	for _, v := range u.Permissions {
-		permissionsMap[v] = struct{}{}
+	return permissionsMap(u.Permissions)
+}
+
+func permissionsMap(permissions []string) map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, v := range permissions {
+		result[v] = struct{}{}
+	}
+
+	return result
+}
+
+// ToUser converts the config user to a user of the user manager.
+// The hashes encrypted by the key store are decrypted, the unencrypted ones are accepted as well.
+func (u *User) ToUser(name string, keyStore keystore.KeyStore) (*users.User, error) {
+	passwordHash, err := keystore.DecryptString(keyStore, u.PasswordHash, passwordHashAD(name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the password hash: %w", err)
+	}
+	passwordSalt, err := keystore.DecryptString(keyStore, u.PasswordSalt, passwordSaltAD(name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt the password salt: %w", err)
+	}
+
+	user, err := users.NewUser(name, passwordHash, passwordSalt, u.PermissionsMap())
+	if err != nil {
+		return nil, err
 	}
 
-	return permissionsMap
+	for apiKeyName, apiKey := range u.APIKeys {
+		keyHashHex, err := keystore.DecryptString(keyStore, apiKey.KeyHash, apiKeyHashAD(name, apiKeyName))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt the hash of the API key %s: %w", apiKeyName, err)
+		}
+		keyHash, err := hex.DecodeString(keyHashHex)
+		if err != nil {
+			return nil, fmt.Errorf("API key hash of %s must be hex encoded", apiKeyName)
+		}
+
+		user.APIKeys[apiKeyName] = &users.APIKey{
+			Name:        apiKeyName,
+			KeyHash:     keyHash,
+			Permissions: permissionsMap(apiKey.Permissions),
+			CreatedAt:   time.Unix(apiKey.CreatedAt, 0),
+			ExpiresAt:   unixTime(apiKey.ExpiresAt),
+		}
+	}
+
+	for tokenID, expiresAt := range u.RevokedTokens {
+		user.RevokedTokens[tokenID] = unixTime(expiresAt)
+	}
+	user.TokensNotBefore = unixTime(u.TokensNotBefore)
+
+	return user, nil
+}
+
+// UserFromUser converts a user of the user manager to the config user.
+// The hashes are encrypted, if the key store is not nil.
+func UserFromUser(u *users.User, keyStore keystore.KeyStore) (*User, error) {
+	passwordHash, err := keystore.EncryptString(keyStore, hex.EncodeToString(u.PasswordHash), passwordHashAD(u.Name))
+	if err != nil {
+		return nil, err
+	}
+	passwordSalt, err := keystore.EncryptString(keyStore, hex.EncodeToString(u.PasswordSalt), passwordSaltAD(u.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	cfgUser := &User{
+		PasswordHash:    passwordHash,
+		PasswordSalt:    passwordSalt,
+		Permissions:     u.PermissionsSlice(),
+		APIKeys:         make(map[string]*APIKey, len(u.APIKeys)),
+		RevokedTokens:   make(map[string]int64, len(u.RevokedTokens)),
+		TokensNotBefore: unixSeconds(u.TokensNotBefore),
+	}
+
+	for name, apiKey := range u.APIKeys {
+		keyHash, err := keystore.EncryptString(keyStore, hex.EncodeToString(apiKey.KeyHash), apiKeyHashAD(u.Name, name))
+		if err != nil {
+			return nil, err
+		}
+		cfgUser.APIKeys[name] = &APIKey{
+			KeyHash:     keyHash,
+			Permissions: apiKey.PermissionsSlice(),
+			CreatedAt:   apiKey.CreatedAt.Unix(),
+			ExpiresAt:   unixSeconds(apiKey.ExpiresAt),
+		}
+	}
+
+	for tokenID, expiresAt := range u.RevokedTokens {
+		cfgUser.RevokedTokens[tokenID] = unixSeconds(expiresAt)
+	}
+
+	return cfgUser, nil
+}
+
+// The associated data binds the encrypted hashes to the user and the field.
+func passwordHashAD(userName string) string {
+	return "users/" + userName + "/passwordHash"
+}
+
+func passwordSaltAD(userName string) string {
+	return "users/" + userName + "/passwordSalt"
+}
+
+func apiKeyHashAD(userName, apiKeyName string) string {
+	return "users/" + userName + "/apiKeys/" + apiKeyName
+}
+
+func unixTime(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
 }
 
 type ParametersUsers struct {
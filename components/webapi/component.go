@@ -32,10 +32,12 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/peering"
 	"github.com/nnikolash/wasp-types-exported/packages/publisher"
 	"github.com/nnikolash/wasp-types-exported/packages/registry"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
 	"github.com/nnikolash/wasp-types-exported/packages/users"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/apierrors"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/controllers/controllerutils"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/interfaces"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/websocket"
 )
 
@@ -68,7 +70,8 @@ type dependencies struct {
 	EchoSwagger        echoswagger.ApiRoot `name:"webapiServer"`
 	WebsocketHub       *websockethub.Hub   `name:"websocketHub"`
 	NodeConnection     chain.NodeConnection
-	WebsocketPublisher *websocket.Service `name:"websocketService"`
+	WebsocketPublisher *websocket.Service    `name:"websocketService"`
+	EVMService         interfaces.EVMService `name:"evmService"`
 }
 
 func initConfigParams(c *dig.Container) error {
@@ -230,15 +233,17 @@ func provide(c *dig.Container) error {
 		Node                        *dkg.Node
 		UserManager                 *users.UserManager
 		Publisher                   *publisher.Publisher
+		NodeConnection              chain.NodeConnection
 	}
 
 	type webapiServerResult struct {
 		dig.Out
 
-		Echo               *echo.Echo          `name:"webapiEcho"`
-		EchoSwagger        echoswagger.ApiRoot `name:"webapiServer"`
-		WebsocketHub       *websockethub.Hub   `name:"websocketHub"`
-		WebsocketPublisher *websocket.Service  `name:"websocketService"`
+		Echo               *echo.Echo            `name:"webapiEcho"`
+		EchoSwagger        echoswagger.ApiRoot   `name:"webapiServer"`
+		WebsocketHub       *websockethub.Hub     `name:"websocketHub"`
+		WebsocketPublisher *websocket.Service    `name:"websocketService"`
+		EVMService         interfaces.EVMService `name:"evmService"`
 	}
 
 	if err := c.Provide(func(deps webapiServerDeps) webapiServerResult {
@@ -261,7 +266,18 @@ func provide(c *dig.Container) error {
 			publisher.ISCEventKindReceipt,
 			publisher.ISCEventIssuerVM,
 			publisher.ISCEventKindBlockEvents,
-		}, deps.Publisher, websocket.WithMaxTopicSubscriptionsPerClient(ParamsWebAPI.Limits.MaxTopicSubscriptionsPerClient))
+		}, deps.Publisher,
+			websocket.WithMaxTopicSubscriptionsPerClient(ParamsWebAPI.Limits.MaxTopicSubscriptionsPerClient),
+			websocket.WithMaxReplayBlocks(ParamsWebAPI.Limits.MaxReplayBlocks),
+			websocket.WithMaxWithheldEventsPerClient(ParamsWebAPI.Limits.MaxWithheldEventsPerClient),
+			websocket.WithLatestStateProvider(func(chainID isc.ChainID) (state.State, error) {
+				ch, err := deps.Chains.Get(chainID)
+				if err != nil {
+					return nil, err
+				}
+				return ch.LatestState(chain.ActiveOrCommittedState)
+			}),
+		)
 
 		if ParamsWebAPI.DebugRequestLoggerEnabled {
 			echoSwagger.Echo().Use(middleware.BodyDump(func(c echo.Context, reqBody, resBody []byte) {
@@ -269,7 +285,10 @@ func provide(c *dig.Container) error {
 			}))
 		}
 
-		webapi.Init(
+		// the node connection exposes its ledger only when it is the in-process L1 ledger
+		l1Service, _ := deps.NodeConnection.(interfaces.L1Service)
+
+		evmService := webapi.Init(
 			logger,
 			echoSwagger,
 			deps.AppInfo.Version,
@@ -297,17 +316,22 @@ func provide(c *dig.Container) error {
 			jsonrpc.NewParameters(
 				ParamsWebAPI.Limits.Jsonrpc.MaxBlocksInLogsFilterRange,
 				ParamsWebAPI.Limits.Jsonrpc.MaxLogsInResult,
+				ParamsWebAPI.Limits.Jsonrpc.FilterTimeout,
+				ParamsWebAPI.Limits.Jsonrpc.MaxFilters,
+				ParamsWebAPI.Limits.Jsonrpc.MaxItemsPerFilter,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketRateLimitMessagesPerSecond,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketRateLimitBurst,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketConnectionCleanupDuration,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketClientBlockDuration,
 			),
+			l1Service,
 		)
 
 		return webapiServerResult{
 			EchoSwagger:        echoSwagger,
 			WebsocketHub:       hub,
 			WebsocketPublisher: websocketService,
+			EVMService:         evmService,
 		}
 	}); err != nil {
 		Component.LogPanic(err)
@@ -350,6 +374,7 @@ func run() error {
 		if err := deps.EchoSwagger.Echo().Shutdown(shutdownCtx); err != nil {
 			Component.LogWarn(err)
 		}
+		deps.EVMService.Close()
 
 		Component.LogInfof("Stopping %s server ... done", Component.Name)
 	}, daemon.PriorityWebAPI); err != nil {
@@ -23,14 +23,20 @@ type ParametersWebAPILimits struct {
 	WriteTimeout                   time.Duration `default:"60s" usage:"the write timeout for the HTTP response body"`
 	MaxBodyLength                  string        `default:"2M" usage:"the maximum number of characters that the body of an API call may contain"`
 	MaxTopicSubscriptionsPerClient int           `default:"0" usage:"defines the max amount of subscriptions per client. 0 = deactivated (default)"`
+	MaxReplayBlocks                int           `default:"1000" usage:"the max amount of past blocks whose events can be replayed by a websocket subscription. 0 = deactivated"`
+	MaxWithheldEventsPerClient     int           `default:"10000" usage:"the max amount of live events withheld for a websocket client while replaying. 0 = deactivated"`
 	ConfirmedStateLagThreshold     uint32        `default:"2" usage:"the threshold that define a chain is unsynchronized"`
 	Jsonrpc                        ParametersJSONRPC
 }
 
 type ParametersJSONRPC struct {
-	MaxBlocksInLogsFilterRange int `default:"1000" usage:"maximum amount of blocks in eth_getLogs filter range"`
+	MaxBlocksInLogsFilterRange int `default:"1000" usage:"maximum amount of blocks in eth_getLogs filter range (on archive nodes, only blocks that may contain matching logs are counted)"`
 	MaxLogsInResult            int `default:"10000" usage:"maximum amount of logs in eth_getLogs result"`
 
+	FilterTimeout     time.Duration `default:"5m" usage:"the duration after which a filter installed via eth_newFilter and friends is removed if it is not polled"`
+	MaxFilters        int           `default:"1000" usage:"maximum amount of filters installed via eth_newFilter and friends at the same time"`
+	MaxItemsPerFilter int           `default:"10000" usage:"maximum amount of logs or hashes kept by a filter between two polls"`
+
 	WebsocketRateLimitMessagesPerSecond int           `default:"20" usage:"the websocket rate limit (messages per second)"`
 	WebsocketRateLimitBurst             int           `default:"5" usage:"the websocket burst limit"`
 	WebsocketConnectionCleanupDuration  time.Duration `default:"5m" usage:"defines in which interval stale connections will be cleaned up"`
@@ -0,0 +1,146 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/contracts/wasm/fairauction/go/fairauctionimpl"
+	"github.com/nnikolash/wasp-types-exported/packages/evm/evmutil"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/solo"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/evm/iscmagic"
+	"github.com/nnikolash/wasp-types-exported/tools/schema/generator"
+	"github.com/nnikolash/wasp-types-exported/tools/schema/model"
+	"github.com/nnikolash/wasp-types-exported/tools/schema/model/yaml"
+)
+
+// solAuctionInfo mirrors the GetAuctionInfoResults struct of the generated Solidity interface
+type solAuctionInfo struct {
+	Bidders       uint32
+	Creator       iscmagic.ISCAgentID
+	Deposit       uint64
+	Description   string
+	Duration      uint32
+	HighestBid    uint64
+	HighestBidder iscmagic.ISCAgentID
+	MinimumBid    uint64
+	Nft           iscmagic.NFTID
+	OwnerMargin   uint64
+	WhenStarted   uint64
+}
+
+// compileSolidity generates the Solidity interface of the contract with the schema tool,
+// and compiles its implementation with solc. The test is skipped, if solc is not installed.
+func compileSolidity(t *testing.T) (abiJSON string, bytecode []byte) {
+	solc, err := exec.LookPath("solc")
+	if err != nil {
+		t.Skip("solc is not installed")
+	}
+
+	data, err := os.ReadFile("../schema.yaml")
+	require.NoError(t, err)
+	schemaDef := model.NewSchemaDef()
+	require.NoError(t, yaml.Unmarshal(data, schemaDef))
+	s := model.NewSchema()
+	require.NoError(t, s.Compile(schemaDef))
+	iscMagicPath, err := filepath.Abs("../../../../packages/vm/core/evm/iscmagic")
+	require.NoError(t, err)
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, os.Chdir(dir))
+	defer func() { require.NoError(t, os.Chdir(cwd)) }()
+	require.NoError(t, generator.NewSolidityGenerator(s).GenerateInterface())
+
+	out, err := exec.Command(solc, "--abi", "--bin", "-o", "build",
+		"--allow-paths", iscMagicPath, "@iota/iscmagic="+iscMagicPath,
+		"sol/fairauction/contract.sol").CombinedOutput()
+	require.NoError(t, err, string(out))
+	b, err := os.ReadFile(filepath.Join(dir, "build", "FairAuction.abi"))
+	require.NoError(t, err)
+	abiJSON = string(b)
+	b, err = os.ReadFile(filepath.Join(dir, "build", "FairAuction.bin"))
+	require.NoError(t, err)
+	return abiJSON, common.FromHex(strings.TrimSpace(string(b)))
+}
+
+func sendEthTx(t *testing.T, ch *solo.Chain, sender *ecdsa.PrivateKey, to common.Address, data []byte) {
+	from := crypto.PubkeyToAddress(sender.PublicKey)
+	gas, err := ch.EVM().EstimateGas(ethereum.CallMsg{From: from, To: &to, Data: data}, nil)
+	require.NoError(t, err)
+	nonce := ch.Nonce(isc.NewEthereumAddressAgentID(ch.ChainID, from))
+	tx, err := types.SignTx(
+		types.NewTransaction(nonce, to, big.NewInt(0), gas, ch.EVM().GasPrice(), data),
+		evmutil.Signer(big.NewInt(int64(ch.EVM().ChainID()))),
+		sender,
+	)
+	require.NoError(t, err)
+	require.NoError(t, ch.EVM().SendTransaction(tx))
+}
+
+func TestSolidityInterface(t *testing.T) {
+	abiJSON, bytecode := compileSolidity(t)
+
+	ctx, auctioneer, nftID := startAuction(t)
+	ethKey, ethAddress := ctx.Chain.NewEthereumAccountWithL2Funds()
+	fairAuction, contractABI := ctx.Chain.DeployEVMContract(ethKey, abiJSON, bytecode, big.NewInt(0))
+	nft := iscmagic.WrapNFTID(*ctx.Cvt.IscNFTID(&nftID))
+
+	getAuctionInfo := func() *solAuctionInfo {
+		callData, err := contractABI.Pack("getAuctionInfo", struct{ Nft iscmagic.NFTID }{nft})
+		require.NoError(t, err)
+		ret, err := ctx.Chain.EVM().CallContract(ethereum.CallMsg{From: ethAddress, To: &fairAuction, Data: callData}, nil)
+		require.NoError(t, err)
+		out, err := contractABI.Unpack("getAuctionInfo", ret)
+		require.NoError(t, err)
+		return abi.ConvertType(out[0], new(solAuctionInfo)).(*solAuctionInfo)
+	}
+
+	// the view results are decoded into typed fields
+	info := getAuctionInfo()
+	require.EqualValues(t, 0, info.Bidders)
+	require.Equal(t, nft, info.Nft)
+	require.Equal(t, auctioneer.AgentID().Bytes(), info.Creator.Data)
+	require.Equal(t, deposit, info.Deposit)
+	require.Equal(t, description, info.Description)
+	require.EqualValues(t, fairauctionimpl.DurationDefault, info.Duration)
+	require.Equal(t, minBid, info.MinimumBid)
+
+	// the func takes the allowance of the caller and passes it on as the bid
+	bid := 2 * minBid
+	sandboxABI, err := abi.JSON(strings.NewReader(iscmagic.SandboxABI))
+	require.NoError(t, err)
+	allowData, err := sandboxABI.Pack("allow", fairAuction, iscmagic.WrapISCAssets(isc.NewAssetsBaseTokens(bid)))
+	require.NoError(t, err)
+	sendEthTx(t, ctx.Chain, ethKey, iscmagic.Address, allowData)
+	placeBidData, err := contractABI.Pack("placeBid", struct{ Nft iscmagic.NFTID }{nft}, iscmagic.WrapISCAssets(isc.NewAssetsBaseTokens(bid)))
+	require.NoError(t, err)
+	sendEthTx(t, ctx.Chain, ethKey, fairAuction, placeBidData)
+
+	info = getAuctionInfo()
+	require.EqualValues(t, 1, info.Bidders)
+	require.Equal(t, bid, info.HighestBid)
+	require.Equal(t, isc.NewEthereumAddressAgentID(ctx.Chain.ChainID, fairAuction).Bytes(), info.HighestBidder.Data)
+
+	// remove pending finalize_auction from backlog
+	ctx.WaitForPendingRequestsMark()
+	ctx.AdvanceClockBy(61 * time.Minute)
+	require.True(t, ctx.WaitForPendingRequests(1))
+}
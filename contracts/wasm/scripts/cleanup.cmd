@@ -3,5 +3,5 @@ cd ..
 go install ../../tools/schema
 del /s /q cargo.lock
 del /s /q target\*.*
-schema -go -rs -ts -clean
+schema -go -rs -ts -sol -clean
 cd scripts
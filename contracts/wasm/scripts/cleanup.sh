@@ -3,4 +3,4 @@ contracts_path=$(git rev-parse --show-toplevel)/contracts/wasm
 cd $contracts_path
 go install ../../tools/schema
 find . -name "Cargo.lock" -type f -delete
-schema -go -rs -ts -clean
+schema -go -rs -ts -sol -clean
@@ -1,6 +1,6 @@
 @echo off
 cd ..
 go install ../../tools/schema
-schema -go -rs -ts
+schema -go -rs -ts -sol
 golangci-lint run --fix
 cd ..\scripts
@@ -5,4 +5,4 @@ contracts_path=$root_path/contracts/wasm
 go install $root_path/tools/schema
 
 cd $contracts_path
-schema -go -rs -ts
+schema -go -rs -ts -sol
@@ -11,6 +11,7 @@ import (
 
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
+	"github.com/nnikolash/wasp-types-exported/packages/parameters"
 )
 
 // RunDKG runs DKG procedure on specific Wasp hosts: generates new keys and puts corresponding committee records
@@ -40,3 +41,34 @@ func RunDKG(client *apiclient.APIClient, peerPubKeys []string, threshold uint16,
 
 	return addr, nil
 }
+
+// ReshareDKG hands the existing distributed key over to a new committee, keeping the shared
+// address. The client has to be connected to a node holding a share of the key.
+func ReshareDKG(client *apiclient.APIClient, sharedAddress iotago.Address, peerPubKeys []string, threshold uint16, timeout ...time.Duration) error {
+	to := uint32(60 * 1000)
+	if len(timeout) > 0 {
+		n := timeout[0].Milliseconds()
+		if n < int64(math.MaxUint16) {
+			to = uint32(n)
+		}
+	}
+
+	dkShares, _, err := client.NodeApi.ReshareDKS(context.Background(), sharedAddress.Bech32(parameters.L1().Protocol.Bech32HRP)).DKSharesReshareRequest(apiclient.DKSharesReshareRequest{
+		Threshold:      uint32(threshold),
+		TimeoutMS:      to,
+		PeerIdentities: peerPubKeys,
+	}).Execute()
+	if err != nil {
+		return err
+	}
+
+	_, addr, err := iotago.ParseBech32(dkShares.Address)
+	if err != nil {
+		return fmt.Errorf("ReshareDKG: invalid address returned from DKG: %w", err)
+	}
+	if !addr.Equal(sharedAddress) {
+		return fmt.Errorf("ReshareDKG: address changed after resharing: %v", dkShares.Address)
+	}
+
+	return nil
+}
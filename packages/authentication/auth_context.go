@@ -17,3 +17,13 @@ func (a *AuthContext) Name() string {
 func (a *AuthContext) Scheme() string {
 	return a.scheme
 }
+
+// HasPermission returns true, if the authenticated user has the given permission.
+// Every permission is granted, if the authentication is disabled.
+func (a *AuthContext) HasPermission(permission string) bool {
+	if a.scheme == AuthNone {
+		return true
+	}
+
+	return a.claims != nil && a.claims.HasPermission(permission)
+}
@@ -1,7 +1,10 @@
 package authentication
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -40,12 +43,18 @@ func NewJWTAuth(duration time.Duration, nodeIDKeypair *cryptolib.KeyPair) *JWTAu
 func (j *JWTAuth) IssueJWT(username string, claims *WaspClaims) (string, error) {
 	now := time.Now()
 
+	// the unique token ID allows revoking the token
+	tokenID := make([]byte, 16)
+	if _, err := rand.Read(tokenID); err != nil {
+		return "", err
+	}
+
 	// Set claims
 	registeredClaims := jwt.RegisteredClaims{
 		Subject:   username,
 		Issuer:    j.nodeID,
 		Audience:  jwt.ClaimStrings{username},
-		ID:        fmt.Sprintf("%d", now.Unix()),
+		ID:        hex.EncodeToString(tokenID),
 		IssuedAt:  jwt.NewNumericDate(now),
 		NotBefore: jwt.NewNumericDate(now),
 	}
@@ -63,26 +72,45 @@ func (j *JWTAuth) IssueJWT(username string, claims *WaspClaims) (string, error)
 	return token.SignedString(j.secret)
 }
 
-type WaspClaims struct {
-	jwt.RegisteredClaims
-	Permissions map[string]struct{} `json:"permissions"`
-}
-
-func (c *WaspClaims) HasPermission(permission string) bool {
-	_, exists := c.Permissions[permission]
+// ParseJWT verifies the token and returns its claims.
+func (j *JWTAuth) ParseJWT(tokenString string) (*jwt.Token, *WaspClaims, error) {
+	keyFunc := func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
 
-	if exists {
-		return true
+		return j.secret, nil
 	}
 
-	if permission == permissions.Read {
-		// If a user only has write permissions, it should still be able to read.
-		_, exists = c.Permissions[permissions.Write]
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		&WaspClaims{},
+		keyFunc,
+		jwt.WithValidMethods([]string{"HS256"}),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !token.Valid {
+		return nil, nil, errors.New("invalid token")
+	}
 
-		return exists
+	claims, ok := token.Claims.(*WaspClaims)
+	if !ok {
+		return nil, nil, errors.New("wrong JWT claim type")
 	}
 
-	return false
+	return token, claims, nil
+}
+
+type WaspClaims struct {
+	jwt.RegisteredClaims
+	Permissions map[string]struct{} `json:"permissions"`
+}
+
+// HasPermission returns true, if any of the permissions of the user includes the given one, see permissions.Includes.
+func (c *WaspClaims) HasPermission(permission string) bool {
+	return permissions.IsGranted(c.Permissions, permission)
 }
 
 func (c *WaspClaims) compare(field, expected string) bool {
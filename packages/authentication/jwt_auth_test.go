@@ -13,6 +13,7 @@ import (
 
 	"github.com/nnikolash/wasp-types-exported/packages/authentication"
 	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared"
+	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared/permissions"
 	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
 	"github.com/nnikolash/wasp-types-exported/packages/users"
 )
@@ -66,6 +67,7 @@ func TestGetJWTAuthMiddleware(t *testing.T) {
 			"/",
 			shared.AuthRoute(),
 			shared.AuthInfoRoute(),
+			shared.AuthRevokeRoute(),
 			"/doc",
 		}
 		notSkipPaths := []string{
@@ -158,3 +160,163 @@ func TestJWTAuthIssueAndVerify(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, res.Code)
 }
+
+func newTestAuthServer(userManager *users.UserManager, jwtAuthConfig authentication.JWTAuthConfiguration, nodeIDKeypair *cryptolib.KeyPair) *echo.Echo {
+	e := echo.New()
+	e.GET("/test-route", func(c echo.Context) error {
+		authContext := c.Get("auth").(*authentication.AuthContext)
+		return c.JSON(http.StatusOK, authContext.Name())
+	})
+
+	_, middleware := authentication.GetJWTAuthMiddleware(jwtAuthConfig, nodeIDKeypair, userManager)
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("auth", &authentication.AuthContext{})
+			return next(c)
+		}
+	})
+	e.Use(middleware)
+
+	return e
+}
+
+func TestJWTRevocation(t *testing.T) {
+	username := "wasp"
+	nodeIDKeypair := cryptolib.KeyPairFromSeed(cryptolib.SeedFromBytes([]byte("abc")))
+	jwtAuth := authentication.NewJWTAuth(time.Hour, nodeIDKeypair)
+
+	userManager := users.NewUserManager(func(users []*users.User) error {
+		return nil
+	})
+	require.NoError(t, userManager.AddUser(&users.User{Name: username}))
+
+	e := newTestAuthServer(userManager, authentication.JWTAuthConfiguration{Duration: time.Hour}, nodeIDKeypair)
+
+	request := func(jwtString string) int {
+		req := httptest.NewRequest(http.MethodGet, "/test-route", http.NoBody)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+jwtString)
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	revoked, err := jwtAuth.IssueJWT(username, &authentication.WaspClaims{Permissions: map[string]struct{}{"write": {}}})
+	require.NoError(t, err)
+	other, err := jwtAuth.IssueJWT(username, &authentication.WaspClaims{Permissions: map[string]struct{}{"write": {}}})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, request(revoked))
+
+	_, claims, err := jwtAuth.ParseJWT(revoked)
+	require.NoError(t, err)
+	require.NotEmpty(t, claims.ID)
+	require.NoError(t, userManager.RevokeToken(claims.Subject, claims.ID, claims.ExpiresAt.Time))
+
+	require.Equal(t, http.StatusUnauthorized, request(revoked))
+	require.Equal(t, http.StatusOK, request(other))
+}
+
+func TestJWTRevokeAll(t *testing.T) {
+	username := "wasp"
+	nodeIDKeypair := cryptolib.KeyPairFromSeed(cryptolib.SeedFromBytes([]byte("abc")))
+	jwtAuth := authentication.NewJWTAuth(time.Hour, nodeIDKeypair)
+
+	userManager := users.NewUserManager(func(users []*users.User) error {
+		return nil
+	})
+	require.NoError(t, userManager.AddUser(&users.User{Name: username}))
+
+	e := newTestAuthServer(userManager, authentication.JWTAuthConfiguration{Duration: time.Hour}, nodeIDKeypair)
+
+	request := func(jwtString string) int {
+		req := httptest.NewRequest(http.MethodGet, "/test-route", http.NoBody)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+jwtString)
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, req)
+		return res.Code
+	}
+	issue := func() string {
+		jwtString, err := jwtAuth.IssueJWT(username, &authentication.WaspClaims{Permissions: map[string]struct{}{"write": {}}})
+		require.NoError(t, err)
+		return jwtString
+	}
+	// moves the revocation time to the past, as if the next tokens are issued later
+	rewind := func() {
+		user, err := userManager.User(username)
+		require.NoError(t, err)
+		require.False(t, user.TokensNotBefore.IsZero())
+		user.TokensNotBefore = user.TokensNotBefore.Add(-2 * time.Second)
+		require.NoError(t, userManager.ModifyUser(user))
+	}
+
+	before := issue()
+	require.Equal(t, http.StatusOK, request(before))
+	require.NoError(t, userManager.RevokeAllTokens(username))
+	require.Equal(t, http.StatusUnauthorized, request(before))
+	rewind()
+
+	// changing the password revokes the tokens issued with the old one
+	before = issue()
+	require.Equal(t, http.StatusOK, request(before))
+	passwordHash, passwordSalt, err := users.DerivePasswordKey("new password")
+	require.NoError(t, err)
+	require.NoError(t, userManager.ChangeUserPassword(username, passwordHash, passwordSalt))
+	require.Equal(t, http.StatusUnauthorized, request(before))
+	rewind()
+
+	require.Equal(t, http.StatusOK, request(issue()))
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	userManager := users.NewUserManager(func(users []*users.User) error {
+		return nil
+	})
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "bot",
+		Permissions: map[string]struct{}{permissions.Read: {}, permissions.PeeringWrite: {}},
+	}))
+
+	addKey := func(name string, keyPermissions map[string]struct{}, expiresAt time.Time) string {
+		key, keyHash, err := users.GenerateAPIKey()
+		require.NoError(t, err)
+		require.NoError(t, userManager.AddAPIKey("bot", &users.APIKey{
+			Name:        name,
+			KeyHash:     keyHash,
+			Permissions: keyPermissions,
+			CreatedAt:   time.Now(),
+			ExpiresAt:   expiresAt,
+		}))
+		return key
+	}
+	validKey := addKey("valid", map[string]struct{}{permissions.Read: {}}, time.Time{})
+	expiredKey := addKey("expired", nil, time.Now().Add(-time.Minute))
+
+	// the key permissions can't exceed the permissions of the user
+	err := userManager.AddAPIKey("bot", &users.APIKey{Name: "admin", KeyHash: users.HashAPIKey("x"), Permissions: map[string]struct{}{permissions.Write: {}}})
+	require.Error(t, err)
+
+	e := newTestAuthServer(userManager, authentication.JWTAuthConfiguration{}, cryptolib.NewKeyPair())
+	e.GET("/peers", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, authentication.ValidatePermissions([]string{permissions.PeeringWrite}))
+
+	request := func(path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		req.Header.Set(authentication.APIKeyHeader, key)
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, req)
+		return res
+	}
+
+	res := request("/test-route", validKey)
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Equal(t, "\"bot\"\n", res.Body.String())
+
+	// the key is restricted to the read permission, although the user may change the peers
+	require.Equal(t, http.StatusUnauthorized, request("/peers", validKey).Code)
+
+	require.Equal(t, http.StatusUnauthorized, request("/test-route", expiredKey).Code)
+	require.Equal(t, http.StatusUnauthorized, request("/test-route", users.APIKeyPrefix+"invalid").Code)
+
+	require.NoError(t, userManager.RemoveAPIKey("bot", "valid"))
+	require.Equal(t, http.StatusUnauthorized, request("/test-route", validKey).Code)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -38,6 +39,30 @@ func (a *AuthHandler) JWTLoginHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, shared.LoginResponse{JWT: token})
 }
 
+// RevokeTokenHandler revokes the given JWT; the token itself authorizes its revocation.
+func (a *AuthHandler) RevokeTokenHandler(c echo.Context) error {
+	request := &shared.RevokeTokenRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid revoke request")
+	}
+
+	_, claims, err := a.Jwt.ParseJWT(request.JWT)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+	}
+
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	if err := a.UserManager.RevokeToken(claims.Subject, claims.ID, expiresAt); err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (a *AuthHandler) parseAuthRequest(c echo.Context) (*shared.LoginRequest, *users.User, error) {
 	request := &shared.LoginRequest{}
 
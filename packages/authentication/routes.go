@@ -65,6 +65,7 @@ func AddAuthentication(
 	// set Auth route
 	var middleware echo.MiddlewareFunc
 	var handler echo.HandlerFunc
+	var revokeHandler echo.HandlerFunc
 	switch authConfig.Scheme {
 	case AuthJWT:
 		var jwtAuth *JWTAuth
@@ -74,10 +75,12 @@ func AddAuthentication(
 		jwtAuth, middleware = GetJWTAuthMiddleware(authConfig.JWTConfig, nodeIDKeypair, userManager)
 		authHandler := &AuthHandler{Jwt: jwtAuth, UserManager: userManager}
 		handler = authHandler.JWTLoginHandler
+		revokeHandler = authHandler.RevokeTokenHandler
 
 	case AuthNone:
 		middleware = GetNoneAuthMiddleware()
 		handler = nil
+		revokeHandler = nil
 
 	default:
 		panic(fmt.Sprintf("Unknown auth scheme %s", authConfig.Scheme))
@@ -90,6 +93,14 @@ func AddAuthentication(
 		AddResponse(http.StatusOK, "Login was successful", mocker.Get(shared.LoginResponse{}), nil).
 		SetOperationId("authenticate").
 		SetSummary("Authenticate towards the node")
+
+	authGroup.POST(shared.AuthRevokeRoute(), revokeHandler).
+		AddParamBody(mocker.Get(shared.RevokeTokenRequest{}), "", "The token to revoke", true).
+		AddResponse(http.StatusUnauthorized, "Invalid token", nil, nil).
+		AddResponse(http.StatusMethodNotAllowed, "auth type: none", nil, nil).
+		AddResponse(http.StatusOK, "The token was revoked", nil, nil).
+		SetOperationId("revokeToken").
+		SetSummary("Revoke a JWT before it expires")
 	return middleware
 }
 
@@ -1,7 +1,136 @@
 package permissions
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+)
+
+// Node wide permissions
 const (
 	API   = "api"
 	Read  = "read"
 	Write = "write"
 )
+
+// Access levels of the scoped permissions. A higher level includes the lower ones.
+const (
+	AccessRead  = "read"
+	AccessWrite = "write"
+	AccessAdmin = "admin"
+)
+
+// Scoped permissions have the form `<scope>:<access>`, chain permissions `chain:<chainID>:<access>`.
+const (
+	ScopePeering = "peering"
+	ScopeUsers   = "users"
+	ScopeChain   = "chain"
+
+	PeeringRead  = ScopePeering + ":" + AccessRead
+	PeeringWrite = ScopePeering + ":" + AccessWrite
+	UsersRead    = ScopeUsers + ":" + AccessRead
+	UsersAdmin   = ScopeUsers + ":" + AccessAdmin
+)
+
+// ChainIDParam is replaced with the chain ID of the request path when validating the permissions of a route.
+const ChainIDParam = "{chainID}"
+
+var (
+	ChainRead  = Chain(ChainIDParam, AccessRead)
+	ChainWrite = Chain(ChainIDParam, AccessWrite)
+)
+
+var accessLevels = map[string]int{
+	AccessRead:  1,
+	AccessWrite: 2,
+	AccessAdmin: 3,
+}
+
+// maximal access levels of the scopes
+var scopes = map[string]string{
+	ScopePeering: AccessWrite,
+	ScopeUsers:   AccessAdmin,
+	ScopeChain:   AccessWrite,
+}
+
+// Chain returns the permission with the given access to the chain.
+func Chain(chainID, access string) string {
+	return ScopeChain + ":" + chainID + ":" + access
+}
+
+// Parse splits a scoped permission into its scope, the ID of the scoped object (only for chains) and the access level.
+func Parse(permission string) (scope, id, access string, ok bool) {
+	parts := strings.Split(permission, ":")
+	switch {
+	case len(parts) == 2 && parts[0] != ScopeChain:
+		scope, access = parts[0], parts[1]
+	case len(parts) == 3 && parts[0] == ScopeChain:
+		scope, id, access = parts[0], parts[1], parts[2]
+	default:
+		return "", "", "", false
+	}
+	maxAccess, ok := scopes[scope]
+	if !ok || accessLevels[access] == 0 || accessLevels[access] > accessLevels[maxAccess] {
+		return "", "", "", false
+	}
+	return scope, id, access, true
+}
+
+// Validate checks that the permission is either a node wide or a valid scoped permission.
+func Validate(permission string) error {
+	switch permission {
+	case API, Read, Write:
+		return nil
+	}
+	scope, id, _, ok := Parse(permission)
+	if !ok {
+		return fmt.Errorf("unknown permission %q", permission)
+	}
+	if scope == ScopeChain {
+		if _, err := isc.ChainIDFromString(id); err != nil {
+			return fmt.Errorf("invalid chain ID in permission %q: %w", permission, err)
+		}
+	}
+	return nil
+}
+
+// Includes returns true, if the granted permission includes the required one.
+// The node wide `write` includes every scoped permission, `read` includes the scoped
+// read permissions, and a scoped permission includes the lower access levels of the scope.
+func Includes(granted, required string) bool {
+	if granted == required {
+		return true
+	}
+	if required == Read {
+		// If a user only has write permissions, it should still be able to read.
+		return granted == Write
+	}
+	scope, id, access, ok := Parse(required)
+	if !ok {
+		return false
+	}
+	switch granted {
+	case Write:
+		return true
+	case Read:
+		return access == AccessRead
+	}
+	grantedScope, grantedID, grantedAccess, ok := Parse(granted)
+	return ok && grantedScope == scope && grantedID == id && accessLevels[grantedAccess] >= accessLevels[access]
+}
+
+// IsGranted returns true, if any of the granted permissions includes the required one.
+func IsGranted(granted map[string]struct{}, required string) bool {
+	if _, exists := granted[required]; exists {
+		return true
+	}
+
+	for permission := range granted {
+		if Includes(permission, required) {
+			return true
+		}
+	}
+
+	return false
+}
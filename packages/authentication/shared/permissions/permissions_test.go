@@ -0,0 +1,44 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+)
+
+func TestValidate(t *testing.T) {
+	chainID := isc.RandomChainID().String()
+
+	for _, permission := range []string{API, Read, Write, PeeringRead, PeeringWrite, UsersRead, UsersAdmin, Chain(chainID, AccessRead), Chain(chainID, AccessWrite)} {
+		require.NoError(t, Validate(permission), permission)
+	}
+	for _, permission := range []string{"", "admin", "peering", "peering:admin", "users:write:x", "nodes:read", "chain:read", Chain("abc", AccessRead), Chain(chainID, AccessAdmin)} {
+		require.Error(t, Validate(permission), permission)
+	}
+}
+
+func TestIncludes(t *testing.T) {
+	chainID := isc.RandomChainID().String()
+	otherChainID := isc.RandomChainID().String()
+
+	require.True(t, Includes(Write, Read))
+	require.False(t, Includes(Read, Write))
+	require.True(t, Includes(Write, UsersAdmin))
+	require.True(t, Includes(Write, Chain(chainID, AccessWrite)))
+	require.True(t, Includes(Read, Chain(chainID, AccessRead)))
+	require.False(t, Includes(Read, Chain(chainID, AccessWrite)))
+	require.False(t, Includes(Read, UsersAdmin))
+
+	require.True(t, Includes(Chain(chainID, AccessWrite), Chain(chainID, AccessRead)))
+	require.False(t, Includes(Chain(chainID, AccessRead), Chain(chainID, AccessWrite)))
+	require.False(t, Includes(Chain(chainID, AccessWrite), Chain(otherChainID, AccessRead)))
+	require.True(t, Includes(UsersAdmin, UsersRead))
+	require.False(t, Includes(PeeringWrite, UsersRead))
+
+	// scoped permissions never include the node wide ones
+	require.False(t, Includes(Chain(chainID, AccessWrite), Read))
+	require.False(t, Includes(PeeringWrite, Write))
+	require.False(t, Includes(UsersAdmin, Write))
+}
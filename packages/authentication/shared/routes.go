@@ -8,6 +8,10 @@ func AuthInfoRoute() string {
 	return "/auth/info"
 }
 
+func AuthRevokeRoute() string {
+	return "/auth/revoke"
+}
+
 type AuthInfoModel struct {
 	Scheme  string `json:"scheme" swagger:"desc(Authentication scheme (jwt, basic, ip)),required"`
 	AuthURL string `json:"authURL" swagger:"desc(JWT only),required"`
@@ -22,3 +26,7 @@ type LoginResponse struct {
 	JWT   string `json:"jwt,omitempty" swagger:"required"`
 	Error error  `json:"error,omitempty" swagger:"required"`
 }
+
+type RevokeTokenRequest struct {
+	JWT string `json:"jwt" swagger:"desc(The token to revoke),required"`
+}
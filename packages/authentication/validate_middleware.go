@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,12 +10,16 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared"
+	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared/permissions"
 	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
 	"github.com/nnikolash/wasp-types-exported/packages/users"
 )
 
 var DefaultJWTDuration time.Duration
 
+// APIKeyHeader is the request header carrying an API key, which is accepted instead of a JWT.
+const APIKeyHeader = "X-API-Key"
+
 func GetJWTAuthMiddleware(
 	config JWTAuthConfiguration,
 	nodeIDKeypair *cryptolib.KeyPair,
@@ -38,6 +43,7 @@ func GetJWTAuthMiddleware(
 			if path == "/" ||
 				path == shared.AuthRoute() ||
 				path == shared.AuthInfoRoute() ||
+				path == shared.AuthRevokeRoute() ||
 				path == "/doc" {
 				return true
 			}
@@ -47,31 +53,10 @@ func GetJWTAuthMiddleware(
 		SigningKey:  jwtAuth.secret,
 		TokenLookup: "header:Authorization:Bearer ,cookie:jwt",
 		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) {
-			keyFunc := func(t *jwt.Token) (interface{}, error) {
-				if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-				}
-
-				return jwtAuth.secret, nil
-			}
-
-			token, err := jwt.ParseWithClaims(
-				auth,
-				&WaspClaims{},
-				keyFunc,
-				jwt.WithValidMethods([]string{"HS256"}),
-			)
+			token, claims, err := jwtAuth.ParseJWT(auth)
 			if err != nil {
 				return nil, err
 			}
-			if !token.Valid {
-				return nil, fmt.Errorf("invalid token")
-			}
-
-			claims, ok := token.Claims.(*WaspClaims)
-			if !ok {
-				return nil, fmt.Errorf("wrong JWT claim type")
-			}
 
 			userMap := userManager.Users()
 			audience, err := claims.GetAudience()
@@ -86,14 +71,67 @@ func GetJWTAuthMiddleware(
 				return nil, fmt.Errorf("invalid subject")
 			}
 
+			var issuedAt time.Time
+			if claims.IssuedAt != nil {
+				issuedAt = claims.IssuedAt.Time
+			}
+			if userManager.IsTokenRevoked(claims.Subject, claims.ID, issuedAt) {
+				return nil, fmt.Errorf("token revoked")
+			}
+
 			authContext := c.Get("auth").(*AuthContext)
 			authContext.claims = claims
+			authContext.name = claims.Subject
 
 			return token, nil
 		},
 	})
 
-	return jwtAuth, authMiddleware
+	return jwtAuth, withAPIKeyAuth(userManager, authMiddleware)
+}
+
+// withAPIKeyAuth authenticates the requests carrying an API key, the other requests are passed to the JWT middleware.
+func withAPIKeyAuth(userManager *users.UserManager, jwtMiddleware echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		jwtHandler := jwtMiddleware(next)
+
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(APIKeyHeader)
+			if key == "" {
+				return jwtHandler(c)
+			}
+
+			user, apiKey, err := userManager.UserByAPIKey(key)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+			}
+
+			authContext := c.Get("auth").(*AuthContext)
+			authContext.claims = &WaspClaims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: user.Name},
+				Permissions:      apiKeyPermissions(user, apiKey),
+			}
+			authContext.name = user.Name
+
+			return next(c)
+		}
+	}
+}
+
+// apiKeyPermissions returns the permissions of the key, which are still granted to its user.
+func apiKeyPermissions(user *users.User, apiKey *users.APIKey) map[string]struct{} {
+	if len(apiKey.Permissions) == 0 {
+		return user.Permissions
+	}
+
+	result := make(map[string]struct{}, len(apiKey.Permissions))
+	for permission := range apiKey.Permissions {
+		if permissions.IsGranted(user.Permissions, permission) {
+			result[permission] = struct{}{}
+		}
+	}
+
+	return result
 }
 
 func GetNoneAuthMiddleware() echo.MiddlewareFunc {
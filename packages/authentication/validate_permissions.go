@@ -2,8 +2,12 @@ package authentication
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared/permissions"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/params"
 )
 
 type ValidationError struct {
@@ -11,7 +15,9 @@ type ValidationError struct {
 	Error             string `json:"error" swagger:"required"`
 }
 
-func ValidatePermissions(permissions []string) func(next echo.HandlerFunc) echo.HandlerFunc {
+// ValidatePermissions requires the user to have all the given permissions. The chain scoped
+// permissions (permissions.ChainRead, permissions.ChainWrite) apply to the chain of the request path.
+func ValidatePermissions(requiredPermissions []string) func(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(e echo.Context) error {
 			auth := e.Get("auth")
@@ -28,7 +34,8 @@ func ValidatePermissions(permissions []string) func(next echo.HandlerFunc) echo.
 				return next(e)
 			}
 
-			for _, permission := range permissions {
+			for _, permission := range requiredPermissions {
+				permission = strings.ReplaceAll(permission, permissions.ChainIDParam, e.Param(params.ParamChainID))
 				if !authContext.claims.HasPermission(permission) {
 					return e.JSON(http.StatusUnauthorized, ValidationError{MissingPermission: permission, Error: "Missing permission"})
 				}
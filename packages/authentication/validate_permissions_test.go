@@ -0,0 +1,72 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared/permissions"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+)
+
+func TestValidateChainPermissions(t *testing.T) {
+	chainID := isc.RandomChainID().String()
+	otherChainID := isc.RandomChainID().String()
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("auth", &AuthContext{
+				scheme: AuthJWT,
+				claims: &WaspClaims{Permissions: map[string]struct{}{permissions.Chain(chainID, permissions.AccessWrite): {}}},
+			})
+			return next(c)
+		}
+	})
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/chains/:chainID/mempool", ok, ValidatePermissions([]string{permissions.ChainRead}))
+	e.POST("/chains/:chainID/access-node", ok, ValidatePermissions([]string{permissions.ChainWrite}))
+	e.POST("/node/shutdown", ok, ValidatePermissions([]string{permissions.Write}))
+	e.POST("/users", ok, ValidatePermissions([]string{permissions.UsersAdmin}))
+
+	for _, test := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/chains/" + chainID + "/mempool", http.StatusOK},
+		{http.MethodPost, "/chains/" + chainID + "/access-node", http.StatusOK},
+		{http.MethodGet, "/chains/" + otherChainID + "/mempool", http.StatusUnauthorized},
+		{http.MethodPost, "/chains/" + otherChainID + "/access-node", http.StatusUnauthorized},
+		{http.MethodPost, "/node/shutdown", http.StatusUnauthorized},
+		{http.MethodPost, "/users", http.StatusUnauthorized},
+	} {
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, httptest.NewRequest(test.method, test.path, http.NoBody))
+		require.Equal(t, test.status, res.Code, test.path)
+	}
+}
+
+func TestAuthContextHasPermission(t *testing.T) {
+	chainID := isc.RandomChainID().String()
+
+	admin := &AuthContext{
+		scheme: AuthJWT,
+		claims: &WaspClaims{Permissions: map[string]struct{}{
+			permissions.API:        {},
+			permissions.UsersAdmin: {},
+			permissions.Chain(chainID, permissions.AccessWrite): {},
+		}},
+	}
+	require.True(t, admin.HasPermission(permissions.UsersRead))
+	require.True(t, admin.HasPermission(permissions.Chain(chainID, permissions.AccessRead)))
+	require.False(t, admin.HasPermission(permissions.Write))
+	require.False(t, admin.HasPermission(permissions.Read))
+	require.False(t, admin.HasPermission(permissions.PeeringWrite))
+
+	require.False(t, (&AuthContext{scheme: AuthJWT}).HasPermission(permissions.UsersRead))
+	require.True(t, (&AuthContext{scheme: AuthNone}).HasPermission(permissions.Write))
+}
@@ -77,6 +77,7 @@ const (
 	distShareRePublishTick  = 5 * time.Second
 	waitRequestCleanupEvery = 10
 	forceCleanMempoolTick   = 1 * time.Minute
+	scheduledRequestsTick   = 1 * time.Second
 )
 
 // Partial interface for providing chain events to the outside.
@@ -113,6 +114,9 @@ type Mempool interface {
 	ConsensusInstancesUpdated(activeConsensusInstances []consGR.ConsensusID)
 
 	GetContents() io.Reader
+	// Returns the off-ledger requests currently held in the pool, ordered
+	// by nonce for each of the senders.
+	GetOffLedgerRequests() []isc.OffLedgerRequest
 }
 
 type Settings struct {
@@ -140,6 +144,7 @@ type mempoolImpl struct {
 	distSync                       gpa.GPA
 	chainHeadAO                    *isc.AliasOutputWithID
 	chainHeadState                 state.State
+	scheduledRequests              []*isc.ScheduledRequest // Calls scheduled in the blocklog, due in the block after the chain head.
 	serverNodesUpdatedPipe         pipe.Pipe[*reqServerNodesUpdated]
 	serverNodes                    []*cryptolib.PublicKey
 	accessNodesUpdatedPipe         pipe.Pipe[*reqAccessNodesUpdated]
@@ -155,6 +160,7 @@ type mempoolImpl struct {
 	reqReceiveOffLedgerRequestPipe pipe.Pipe[isc.OffLedgerRequest]
 	reqTangleTimeUpdatedPipe       pipe.Pipe[time.Time]
 	reqTrackNewChainHeadPipe       pipe.Pipe[*reqTrackNewChainHead]
+	reqGetOffLedgerRequestsPipe    pipe.Pipe[*reqGetOffLedgerRequests]
 	netRecvPipe                    pipe.Pipe[*peering.PeerMessageIn]
 	netPeeringID                   peering.PeeringID
 	netPeerPubs                    map[gpa.NodeID]*cryptolib.PublicKey
@@ -167,6 +173,7 @@ type mempoolImpl struct {
 	listener                       ChainListener
 	refreshOnLedgerRequests        func()
 	lastRefreshTimestamp           time.Time
+	ctx                            context.Context
 }
 
 var _ Mempool = &mempoolImpl{}
@@ -216,6 +223,10 @@ type reqTrackNewChainHead struct {
 	responseCh chan<- bool // only for tests, shouldn't be used in the chain package
 }
 
+type reqGetOffLedgerRequests struct {
+	responseCh chan<- []isc.OffLedgerRequest
+}
+
 func New(
 	ctx context.Context,
 	chainID isc.ChainID,
@@ -251,6 +262,7 @@ func New(
 		reqReceiveOffLedgerRequestPipe: pipe.NewInfinitePipe[isc.OffLedgerRequest](),
 		reqTangleTimeUpdatedPipe:       pipe.NewInfinitePipe[time.Time](),
 		reqTrackNewChainHeadPipe:       pipe.NewInfinitePipe[*reqTrackNewChainHead](),
+		reqGetOffLedgerRequestsPipe:    pipe.NewInfinitePipe[*reqGetOffLedgerRequests](),
 		netRecvPipe:                    pipe.NewInfinitePipe[*peering.PeerMessageIn](),
 		netPeeringID:                   netPeeringID,
 		netPeerPubs:                    map[gpa.NodeID]*cryptolib.PublicKey{},
@@ -264,6 +276,7 @@ func New(
 		listener:                       listener,
 		refreshOnLedgerRequests:        refreshOnLedgerRequests,
 		lastRefreshTimestamp:           time.Now(),
+		ctx:                            ctx,
 	}
 
 	pipeMetrics.TrackPipeLen("mp-serverNodesUpdatedPipe", mpi.serverNodesUpdatedPipe.Len)
@@ -274,6 +287,7 @@ func New(
 	pipeMetrics.TrackPipeLen("mp-reqReceiveOffLedgerRequestPipe", mpi.reqReceiveOffLedgerRequestPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTangleTimeUpdatedPipe", mpi.reqTangleTimeUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTrackNewChainHeadPipe", mpi.reqTrackNewChainHeadPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-reqGetOffLedgerRequestsPipe", mpi.reqGetOffLedgerRequestsPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-netRecvPipe", mpi.netRecvPipe.Len)
 
 	mpi.distSync = distsync.New(
@@ -373,6 +387,24 @@ func (mpi *mempoolImpl) GetContents() io.Reader {
 	return pr
 }
 
+// GetOffLedgerRequests returns a snapshot of the off-ledger requests in the
+// pool. The pool is only accessed from the mempool's event loop, so the query
+// is passed to it and the caller waits for the response.
+func (mpi *mempoolImpl) GetOffLedgerRequests() []isc.OffLedgerRequest {
+	responseCh := make(chan []isc.OffLedgerRequest, 1)
+	select {
+	case mpi.reqGetOffLedgerRequestsPipe.In() <- &reqGetOffLedgerRequests{responseCh: responseCh}:
+	case <-mpi.ctx.Done():
+		return nil
+	}
+	select {
+	case reqs := <-responseCh:
+		return reqs
+	case <-mpi.ctx.Done():
+		return nil
+	}
+}
+
 func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc) { //nolint:gocyclo
 	serverNodesUpdatedPipeOutCh := mpi.serverNodesUpdatedPipe.Out()
 	accessNodesUpdatedPipeOutCh := mpi.accessNodesUpdatedPipe.Out()
@@ -383,10 +415,12 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 	reqReceiveOffLedgerRequestPipeOutCh := mpi.reqReceiveOffLedgerRequestPipe.Out()
 	reqTangleTimeUpdatedPipeOutCh := mpi.reqTangleTimeUpdatedPipe.Out()
 	reqTrackNewChainHeadPipeOutCh := mpi.reqTrackNewChainHeadPipe.Out()
+	reqGetOffLedgerRequestsPipeOutCh := mpi.reqGetOffLedgerRequestsPipe.Out()
 	netRecvPipeOutCh := mpi.netRecvPipe.Out()
 	debugTicker := time.NewTicker(distShareDebugTick)
 	timeTicker := time.NewTicker(distShareTimeTick)
 	rePublishTicker := time.NewTicker(distShareRePublishTick)
+	scheduledRequestsTicker := time.NewTicker(scheduledRequestsTick)
 	forceCleanMempoolTicker := time.NewTicker(forceCleanMempoolTick) // this exists to force mempool cleanup on access nodes // thought: maybe access nodes shouldn't have a mempool at all
 	for {
 		select {
@@ -439,6 +473,12 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 				break
 			}
 			mpi.handleTrackNewChainHead(recv)
+		case recv, ok := <-reqGetOffLedgerRequestsPipeOutCh:
+			if !ok {
+				reqGetOffLedgerRequestsPipeOutCh = nil
+				break
+			}
+			mpi.handleGetOffLedgerRequests(recv)
 		case recv, ok := <-consensusInstancesUpdatedPipeOutCh:
 			if !ok {
 				break
@@ -456,6 +496,8 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 			mpi.handleDistSyncTimeTick()
 		case <-rePublishTicker.C:
 			mpi.handleRePublishTimeTick()
+		case <-scheduledRequestsTicker.C:
+			mpi.handleScheduledRequestsTick()
 		case <-forceCleanMempoolTicker.C:
 			mpi.handleForceCleanMempool()
 		case <-ctx.Done():
@@ -470,6 +512,7 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 			// mpi.netRecvPipe.Close()
 			debugTicker.Stop()
 			timeTicker.Stop()
+			scheduledRequestsTicker.Stop()
 			util.ExecuteIfNotNil(cleanupFunc)
 			return
 		}
@@ -540,9 +583,20 @@ func (mpi *mempoolImpl) shouldAddOffledgerRequest(req isc.OffLedgerRequest) erro
 		return fmt.Errorf("bad nonce, expected: %d", accountNonce)
 	}
 
-	// check user has on-chain balance
+	// check user (or the sponsor paying for the user) has on-chain balance
 	accountsState := accounts.NewStateAccess(mpi.chainHeadState)
-	if !accountsState.AccountExists(req.SenderAccount(), mpi.chainID) {
+	if sponsor := req.Sponsor(); sponsor != nil {
+		if mpi.chainHeadState.SchemaVersion() < accounts.SchemaVersionGasSponsorship {
+			return fmt.Errorf("gas sponsorship is not enabled on this chain")
+		}
+		// the fee is paid by the sponsor, it must have approved the sender
+		if accountsState.SponsorAllowance(sponsor, req.SenderAccount(), mpi.chainID) == 0 {
+			return fmt.Errorf("sender is not sponsored by %s", sponsor.String())
+		}
+		if accountsState.BaseTokensBalance(sponsor, mpi.chainID) == 0 {
+			return fmt.Errorf("no funds on chain for sponsor %s", sponsor.String())
+		}
+	} else if !accountsState.AccountExists(req.SenderAccount(), mpi.chainID) {
 		// make an exception for gov calls (sender is chan owner and target is gov contract)
 		governanceState := governance.NewStateAccess(mpi.chainHeadState)
 		chainOwner := governanceState.ChainOwnerID()
@@ -700,13 +754,20 @@ func (mpi *mempoolImpl) refsToPropose(consensusID consGR.ConsensusID) []*isc.Req
 
 func (mpi *mempoolImpl) handleConsensusProposalForChainHead(recv *reqConsensusProposal) {
 	refs := mpi.refsToPropose(recv.consensusID)
+	if len(refs) == 0 {
+		// The due scheduled calls are injected by the VM in any block,
+		// they are only proposed to produce a block when there are no other requests.
+		refs = lo.Map(mpi.scheduledRequests, func(req *isc.ScheduledRequest, _ int) *isc.RequestRef {
+			return isc.RequestRefFromRequest(req)
+		})
+	}
 	if len(refs) > 0 {
 		recv.Respond(refs)
 		return
 	}
 
 	//
-	// Wait for any request.
+	// Wait for any request, a scheduled call becoming due included.
 	mpi.waitReq.WaitAny(recv.ctx, func(_ isc.Request) {
 		mpi.handleConsensusProposalForChainHead(recv)
 	})
@@ -722,6 +783,11 @@ func (mpi *mempoolImpl) handleConsensusRequests(recv *reqConsensusRequests) {
 		if reqs[i] == nil {
 			reqs[i] = mpi.offLedgerPool.Get(reqRef)
 		}
+		if reqs[i] == nil {
+			if req, ok := lo.Find(mpi.scheduledRequests, func(req *isc.ScheduledRequest) bool { return reqRef.IsFor(req) }); ok {
+				reqs[i] = req
+			}
+		}
 		if reqs[i] == nil && mpi.chainHeadState != nil {
 			// Check also the processed backlog, to avoid consensus blocking while waiting for processed request.
 			// It will be rejected later (or state branch will change).
@@ -895,6 +961,8 @@ func (mpi *mempoolImpl) handleTrackNewChainHead(req *reqTrackNewChainHead) {
 	// Record the head state.
 	mpi.chainHeadState = req.st
 	mpi.chainHeadAO = req.till
+	mpi.scheduledRequests = nil
+	mpi.handleScheduledRequestsTick()
 	//
 	// Process the pending consensus proposal requests if any.
 	if len(mpi.waitChainHead) != 0 {
@@ -916,6 +984,20 @@ func (mpi *mempoolImpl) handleTrackNewChainHead(req *reqTrackNewChainHead) {
 	mpi.offLedgerPool.SetMinGasPrice(governance.NewStateAccess(mpi.chainHeadState).DefaultGasPrice())
 }
 
+func (mpi *mempoolImpl) handleGetOffLedgerRequests(req *reqGetOffLedgerRequests) {
+	reqs := []isc.OffLedgerRequest{}
+	mpi.offLedgerPool.Iterate(func(_ string, entries []*OrderedPoolEntry) {
+		for _, e := range entries {
+			if e.old {
+				continue // replaced by a newer request with the same nonce
+			}
+			reqs = append(reqs, e.req)
+		}
+	})
+	req.responseCh <- reqs
+	close(req.responseCh)
+}
+
 func (mpi *mempoolImpl) handleNetMessage(recv *peering.PeerMessageIn) {
 	msg, err := mpi.distSync.UnmarshalMessage(recv.MsgData)
 	if err != nil {
@@ -963,6 +1045,23 @@ func (mpi *mempoolImpl) handleRePublishTimeTick() {
 	}
 }
 
+// Collects the scheduled calls which became due for the block after the chain head.
+// The consensus instances waiting for requests are notified, so that a block is
+// produced for the due calls even if the chain is idle otherwise.
+func (mpi *mempoolImpl) handleScheduledRequestsTick() {
+	if mpi.chainHeadState == nil || mpi.chainHeadState.SchemaVersion() < blocklog.SchemaVersionScheduledRequests {
+		return
+	}
+	due := blocklog.GetDueScheduledRequests(mpi.chainHeadState, mpi.chainID, time.Now(), mpi.chainHeadState.BlockIndex()+1)
+	for _, req := range due {
+		if lo.ContainsBy(mpi.scheduledRequests, func(known *isc.ScheduledRequest) bool { return known.ID() == req.ID() }) {
+			continue
+		}
+		mpi.scheduledRequests = append(mpi.scheduledRequests, req)
+		mpi.waitReq.MarkAvailable(req)
+	}
+}
+
 func (mpi *mempoolImpl) handleForceCleanMempool() {
 	mpi.offLedgerPool.Iterate(func(account string, entries []*OrderedPoolEntry) {
 		for _, e := range entries {
@@ -987,6 +1086,8 @@ func (mpi *mempoolImpl) tryReAddRequest(req isc.Request) {
 	case isc.OffLedgerRequest:
 		mpi.log.Debugf("re-adding off-ledger request to mempool: %s", req.ID())
 		mpi.offLedgerPool.Add(req)
+	case *isc.ScheduledRequest:
+		// scheduled requests are injected by the VM, they are never in the mempool
 	default:
 		panic(fmt.Errorf("unexpected request type: %T", req))
 	}
@@ -1000,6 +1101,8 @@ func (mpi *mempoolImpl) tryRemoveRequest(req isc.Request) {
 	case isc.OffLedgerRequest:
 		mpi.log.Debugf("removing off-ledger request from mempool: %s", req.ID())
 		mpi.offLedgerPool.Remove(req)
+	case *isc.ScheduledRequest:
+		// scheduled requests are injected by the VM, they are never in the mempool
 	default:
 		mpi.log.Warn("Trying to remove request of unexpected type %T: %+v", req, req)
 	}
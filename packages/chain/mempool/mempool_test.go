@@ -24,7 +24,9 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
 	"github.com/nnikolash/wasp-types-exported/packages/hashing"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/subrealm"
 	"github.com/nnikolash/wasp-types-exported/packages/metrics"
 	"github.com/nnikolash/wasp-types-exported/packages/origin"
 	"github.com/nnikolash/wasp-types-exported/packages/peering"
@@ -659,6 +661,7 @@ func TestMempoolOverrideNonce(t *testing.T) {
 
 	require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(overwritingReq))
 	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+	require.Equal(t, []isc.OffLedgerRequest{overwritingReq}, te.mempools[0].GetOffLedgerRequests())
 	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO, consGR.ConsensusID{})
 	proposedReqs := <-te.mempools[0].ConsensusRequestsAsync(te.ctx, reqRefs)
 	require.Len(t, proposedReqs, 1)
@@ -737,6 +740,54 @@ func TestTTL(t *testing.T) {
 	require.Len(t, reqs2, 1) // only the last request is returned
 }
 
+func TestScheduledRequests(t *testing.T) {
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+	mp := te.mempools[0]
+	mp.TangleTimeUpdated(time.Now())
+	<-mp.TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	// the chain is idle, a call is scheduled in a second
+	store := te.stores[0]
+	l1Commitment, err := transaction.L1CommitmentFromAliasOutput(te.originAO.GetAliasOutput())
+	require.NoError(t, err)
+	draft, err := store.NewStateDraft(time.Now(), l1Commitment)
+	require.NoError(t, err)
+	scheduleID := blocklog.ScheduleRequest(subrealm.New(draft, kv.Key(blocklog.Contract.Hname().Bytes())), &blocklog.ScheduledRecord{
+		Owner:      isc.NewAgentID(te.governor.Address()),
+		Contract:   inccounter.Contract.Hname(),
+		EntryPoint: inccounter.FuncIncCounter.Hname(),
+		Params:     dict.New(),
+		GasBudget:  gas.LimitsDefault.MaxGasPerRequest,
+		Prepaid:    blocklog.MinScheduledPrepaid,
+		DueTime:    time.Now().Add(time.Second),
+	})
+	block := store.Commit(draft)
+	chainState, err := store.StateByTrieRoot(block.TrieRoot())
+	require.NoError(t, err)
+	currentAO := te.tcl.FakeStateTransition(te.originAO, block.L1Commitment())
+	<-mp.TrackNewChainHead(chainState, te.originAO, currentAO, []state.Block{}, []state.Block{})
+
+	// the proposal waits until the call is due
+	proposal := mp.ConsensusProposalAsync(te.ctx, currentAO, consGR.ConsensusID{})
+	select {
+	case <-proposal:
+		t.Fatal("the scheduled call was proposed before it is due")
+	case <-time.After(500 * time.Millisecond):
+	}
+	var refs []*isc.RequestRef
+	select {
+	case refs = <-proposal:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the scheduled call was not proposed")
+	}
+	require.Len(t, refs, 1)
+	reqs := <-mp.ConsensusRequestsAsync(te.ctx, refs)
+	require.Len(t, reqs, 1)
+	require.Equal(t, scheduleID, reqs[0].(*isc.ScheduledRequest).ScheduleID())
+	require.Equal(t, chainState.BlockIndex()+1, reqs[0].(*isc.ScheduledRequest).BlockIndex())
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // testEnv
 
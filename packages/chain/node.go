@@ -86,6 +86,19 @@ type Chain interface {
 	GetConsensusPipeMetrics() ConsensusPipeMetrics // TODO: Review this.
 	GetConsensusWorkflowStatus() ConsensusWorkflowStatus
 	GetMempoolContents() io.Reader
+	GetMempoolOffLedgerRequests() []isc.OffLedgerRequest
+	GetSyncStatus() *SyncStatus
+}
+
+// SyncStatus compares the latest block available in the local store with the
+// latest block known to the chain (from the active or confirmed alias output).
+type SyncStatus struct {
+	CurrentBlockIndex uint32
+	HighestBlockIndex uint32
+}
+
+func (s *SyncStatus) IsSynced() bool {
+	return s.CurrentBlockIndex >= s.HighestBlockIndex
 }
 
 type CommitteeInfo struct {
@@ -1262,29 +1275,37 @@ func (cni *chainNodeImpl) GetMempoolContents() io.Reader {
 	return cni.mempool.GetContents()
 }
 
+func (cni *chainNodeImpl) GetMempoolOffLedgerRequests() []isc.OffLedgerRequest {
+	return cni.mempool.GetOffLedgerRequests()
+}
+
+func (cni *chainNodeImpl) GetSyncStatus() *SyncStatus {
+	cni.accessLock.RLock()
+	latestActiveAO := cni.latestActiveAO
+	latestConfirmedAO := cni.latestConfirmedAO
+	cni.accessLock.RUnlock()
+
+	status := &SyncStatus{}
+	if latestState, err := cni.LatestState(ActiveOrCommittedState); err == nil {
+		status.CurrentBlockIndex = latestState.BlockIndex()
+	}
+	status.HighestBlockIndex = status.CurrentBlockIndex
+	for _, ao := range []*isc.AliasOutputWithID{latestActiveAO, latestConfirmedAO} {
+		if ao != nil && ao.GetStateIndex() > status.HighestBlockIndex {
+			status.HighestBlockIndex = ao.GetStateIndex()
+		}
+	}
+	return status
+}
+
 func (cni *chainNodeImpl) recoverStoreFromWAL(chainStore indexedstore.IndexedStore, chainWAL sm_gpa_utils.BlockWAL) {
 	//
 	// Load all the existing blocks from the WAL.
-	blocksAdded := 0
-	err := chainWAL.ReadAllByStateIndex(func(stateIndex uint32, block state.Block) bool {
-		cni.log.Debugf("TryRecoverStoreFromWAL: Adding a block to the store, stateIndex=%v, l1Commitment=%v, previousL1Commitment=%v", block.StateIndex(), block.L1Commitment(), block.PreviousL1Commitment())
-		var stateDraft state.StateDraft
-		if block.StateIndex() == 0 {
-			stateDraft = chainStore.NewOriginStateDraft()
-		} else {
-			var stateErr error
-			stateDraft, stateErr = chainStore.NewEmptyStateDraft(block.PreviousL1Commitment())
-			if stateErr != nil {
-				panic(fmt.Errorf("cannot create new state draft for previousL1Commitment=%v: %w", block.PreviousL1Commitment(), stateErr))
-			}
-		}
-		block.Mutations().ApplyTo(stateDraft)
-		chainStore.Commit(stateDraft)
-		blocksAdded++
-		return true
+	blocksAdded, _, err := sm_gpa_utils.ReplayBlockWAL(chainWAL, chainStore, func(block state.Block) {
+		cni.log.Debugf("TryRecoverStoreFromWAL: Added a block to the store, stateIndex=%v, l1Commitment=%v, previousL1Commitment=%v", block.StateIndex(), block.L1Commitment(), block.PreviousL1Commitment())
 	})
 	if err != nil {
-		panic(fmt.Errorf("failed to iterate over WAL blocks: %w", err))
+		panic(err)
 	}
 	cni.log.Infof("TryRecoverStoreFromWAL: Done, added %v blocks.", blocksAdded)
 }
@@ -1,6 +1,7 @@
 package sm_gpa_utils
 
 import (
+	"cmp"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/samber/lo"
 
@@ -19,11 +21,22 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/util/rwutil"
 )
 
+// blockWAL keeps the blocks in append-only segment files. A segment is sealed when it
+// reaches the maximum size: an index file of the segment is written then and a new
+// segment is started. Only the last (active) segment is scanned on start-up; it is
+// truncated to the last valid record, if the node crashed while writing it.
+// For backward compatibility, blocks are also read from the block per file WAL
+// format used before.
 type blockWAL struct {
 	*logger.WrappedLogger
 
-	dir     string
-	metrics *metrics.ChainBlockWALMetrics
+	dir            string
+	parameters     BlockWALParameters
+	metrics        *metrics.ChainBlockWALMetrics
+	mutex          sync.Mutex
+	segments       []*blockWALSegment // Ordered by segment ID; the last one is active
+	records        map[state.BlockHash]*blockWALRecordInfo
+	lastStateIndex uint32
 }
 
 const (
@@ -31,7 +44,13 @@ const (
 	constBlockWALTmpFileSuffix = ".tmp"
 )
 
-func NewBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metrics *metrics.ChainBlockWALMetrics) (BlockWAL, error) {
+func NewBlockWAL(
+	log *logger.Logger,
+	baseDir string,
+	chainID isc.ChainID,
+	metrics *metrics.ChainBlockWALMetrics,
+	parameters BlockWALParameters,
+) (BlockWAL, error) {
 	dir := filepath.Join(baseDir, chainID.String())
 	if err := ioutils.CreateDirectory(dir, 0o777); err != nil {
 		return nil, fmt.Errorf("BlockWAL cannot create folder %v: %w", dir, err)
@@ -40,67 +59,236 @@ func NewBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metric
 	result := &blockWAL{
 		WrappedLogger: logger.NewWrappedLogger(log.Named("WAL")),
 		dir:           dir,
+		parameters:    parameters,
 		metrics:       metrics,
+		records:       make(map[state.BlockHash]*blockWALRecordInfo),
 	}
-	result.LogDebugf("BlockWAL created in folder %v", dir)
+	if err := result.load(); err != nil {
+		return nil, fmt.Errorf("BlockWAL cannot load segments from folder %v: %w", dir, err)
+	}
+	result.LogDebugf("BlockWAL created in folder %v: %v segments, %v blocks", dir, len(result.segments), len(result.records))
 	return result, nil
 }
 
-// Overwrites, if block is already in WAL
-// Block format (version 1):
-//   - Version (4 bytes, unsigned int); value 1
-//   - State index (4 bytes, unsigned int)
-//   - Block bytes
-//
-// Block format (legacy = version 0):
-//   - Block bytes
+// load reads the indexes of the sealed segments and scans the active one
+func (bwT *blockWAL) load() error {
+	dirEntries, err := os.ReadDir(bwT.dir)
+	if err != nil {
+		return err
+	}
+	var segmentIDs []uint32
+	for _, dirEntry := range dirEntries {
+		if segmentID, ok := blockWALSegmentIDFromFileName(dirEntry.Name()); ok && !dirEntry.IsDir() {
+			segmentIDs = append(segmentIDs, segmentID)
+		}
+	}
+	slices.Sort(segmentIDs)
+	for i, segmentID := range segmentIDs {
+		isActive := i == len(segmentIDs)-1
+		segment, records, err := bwT.loadSegment(segmentID, isActive)
+		if err != nil {
+			return err
+		}
+		bwT.segments = append(bwT.segments, segment)
+		for _, info := range records {
+			bwT.records[info.blockHash] = info
+		}
+		if segment.count > 0 && segment.maxStateIndex > bwT.lastStateIndex {
+			bwT.lastStateIndex = segment.maxStateIndex
+		}
+	}
+	return nil
+}
+
+func (bwT *blockWAL) loadSegment(segmentID uint32, isActive bool) (*blockWALSegment, []*blockWALRecordInfo, error) {
+	segmentPath := filepath.Join(bwT.dir, blockWALSegmentFileName(segmentID))
+	indexPath := filepath.Join(bwT.dir, blockWALIndexFileName(segmentID))
+	segment := &blockWALSegment{id: segmentID}
+	addRecords := func(records []*blockWALRecordInfo) {
+		for _, info := range records {
+			segment.addRecord(info, isActive)
+		}
+	}
+	if !isActive {
+		records, err := readBlockWALIndex(indexPath, segmentID)
+		if err == nil {
+			fileInfo, err := os.Stat(segmentPath)
+			if err != nil {
+				return nil, nil, err
+			}
+			segment.size = fileInfo.Size()
+			addRecords(records)
+			return segment, records, nil
+		}
+		bwT.LogWarnf("Index of segment %v cannot be used, rebuilding it: %v", segmentID, err)
+	}
+
+	records, validSize, damaged, err := scanBlockWALSegment(segmentPath, segmentID)
+	segment.size = validSize
+	addRecords(records)
+	if damaged > 0 {
+		bwT.metrics.IncFailedReads()
+		bwT.LogWarnf("Segment %v contains %v damaged blocks, they are skipped", segmentID, damaged)
+	}
+	if err != nil {
+		bwT.metrics.IncFailedReads()
+		if !isActive {
+			bwT.LogWarnf("Segment %v is damaged, %v blocks before the damage are used: %v", segmentID, len(records), err)
+		} else {
+			// Most likely the node crashed while writing the last record; the record is lost
+			bwT.LogWarnf("Segment %v is incomplete, truncating it to %v bytes: %v", segmentID, validSize, err)
+			if validSize < constBlockWALSegmentHeaderSize {
+				if err = writeFileSynced(segmentPath, blockWALSegmentHeader()); err != nil {
+					return nil, nil, err
+				}
+				segment.size = constBlockWALSegmentHeaderSize
+			} else if err = truncateFileSynced(segmentPath, validSize); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if !isActive {
+		if err = writeBlockWALIndex(indexPath, records); err != nil {
+			return nil, nil, err
+		}
+	}
+	return segment, records, nil
+}
+
+// Block is appended to the active segment; if block is already in WAL, the new record supersedes the old one
 func (bwT *blockWAL) Write(block state.Block) error {
+	bwT.mutex.Lock()
+	defer bwT.mutex.Unlock()
+
 	blockIndex := block.StateIndex()
 	commitment := block.L1Commitment()
-	subfolderName := blockWALSubFolderName(commitment.BlockHash())
-	folderPath := filepath.Join(bwT.dir, subfolderName)
-	if err := ioutils.CreateDirectory(folderPath, 0o777); err != nil {
-		return fmt.Errorf("failed to create folder %s for writing block: %w", folderPath, err)
-	}
-	tmpFileName := blockWALTmpFileName(commitment.BlockHash())
-	tmpFilePath := filepath.Join(folderPath, tmpFileName)
-	err := func() error { // Function is used to make defered close occur when it is needed even if write is successful
-		f, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
+	record := blockWALRecord(block)
+	segment, sealed, err := bwT.activeSegment(int64(len(record)))
+	if err != nil {
+		bwT.metrics.IncFailedWrites()
+		return err
+	}
+	segmentPath := filepath.Join(bwT.dir, blockWALSegmentFileName(segment.id))
+	err = func() error { // Function is used to make defered close occur when it is needed even if write is successful
+		f, err := os.OpenFile(segmentPath, os.O_WRONLY|os.O_APPEND, 0o666)
 		if err != nil {
-			bwT.metrics.IncFailedWrites()
-			return fmt.Errorf("failed to create temporary file %s for writing block: %w", tmpFilePath, err)
+			return fmt.Errorf("failed to open segment %s for writing block: %w", segmentPath, err)
 		}
 		defer f.Close()
-		ww := rwutil.NewWriter(f)
-		ww.WriteUint32(1) // Version; 4 bytes (instead of just 1) to lower number of possible collisions with legacy WAL format
-		ww.WriteUint32(blockIndex)
-		if ww.Err != nil {
-			bwT.metrics.IncFailedWrites()
-			return fmt.Errorf("failed to write block index into temporary file %s: %w", tmpFilePath, ww.Err)
-		}
-		err = block.Write(f)
-		if err != nil {
-			bwT.metrics.IncFailedWrites()
-			return fmt.Errorf("writing block to temporary file %s failed: %w", tmpFilePath, err)
+		if _, err = f.Write(record); err != nil {
+			// Remove the partially written record, so that further records are appended after the valid ones
+			_ = f.Truncate(segment.size)
+			return fmt.Errorf("failed to write block to segment %s: %w", segmentPath, err)
+		}
+		if err = f.Sync(); err != nil {
+			return fmt.Errorf("failed to sync segment %s: %w", segmentPath, err)
 		}
 		return nil
 	}()
 	if err != nil {
+		bwT.metrics.IncFailedWrites()
 		return err
 	}
-	finalFileName := blockWALFileName(commitment.BlockHash())
-	finalFilePath := filepath.Join(folderPath, finalFileName)
-	err = os.Rename(tmpFilePath, finalFilePath)
-	if err != nil {
-		return fmt.Errorf("failed to move temporary WAL file %s to permanent location %s: %v",
-			tmpFilePath, finalFilePath, err)
+
+	info := &blockWALRecordInfo{
+		segmentID:  segment.id,
+		offset:     segment.size,
+		length:     uint32(len(record) - constBlockWALRecordHeaderSize),
+		stateIndex: blockIndex,
+		blockHash:  commitment.BlockHash(),
+	}
+	segment.size += int64(len(record))
+	segment.addRecord(info, true)
+	bwT.records[info.blockHash] = info
+	if blockIndex > bwT.lastStateIndex {
+		bwT.lastStateIndex = blockIndex
+	}
+	if sealed {
+		bwT.applyRetention()
 	}
 
 	bwT.metrics.BlockWritten(block.StateIndex())
-	bwT.LogDebugf("Block index %v %s written to wal; file name - %s", blockIndex, commitment, finalFilePath)
+	bwT.LogDebugf("Block index %v %s written to wal; segment %v, offset %v", blockIndex, commitment, segment.id, info.offset)
 	return nil
 }
 
+// activeSegment returns the segment the record of the given size must be written to.
+// If the record does not fit into the active segment, the segment is sealed and a new one is started.
+func (bwT *blockWAL) activeSegment(recordSize int64) (*blockWALSegment, bool, error) {
+	if len(bwT.segments) > 0 {
+		segment := bwT.segments[len(bwT.segments)-1]
+		if segment.count == 0 || segment.size+recordSize <= bwT.parameters.SegmentMaxSize {
+			return segment, false, nil
+		}
+		indexPath := filepath.Join(bwT.dir, blockWALIndexFileName(segment.id))
+		if err := writeBlockWALIndex(indexPath, segment.records); err != nil {
+			return nil, false, fmt.Errorf("failed to seal segment %v: %w", segment.id, err)
+		}
+		segment.records = nil
+		bwT.LogDebugf("Segment %v sealed: %v blocks, state indexes %v-%v", segment.id, segment.count, segment.minStateIndex, segment.maxStateIndex)
+	}
+
+	segmentID := uint32(1)
+	if len(bwT.segments) > 0 {
+		segmentID = bwT.segments[len(bwT.segments)-1].id + 1
+	}
+	segmentPath := filepath.Join(bwT.dir, blockWALSegmentFileName(segmentID))
+	if err := writeFileSynced(segmentPath, blockWALSegmentHeader()); err != nil {
+		return nil, false, fmt.Errorf("failed to create segment %s: %w", segmentPath, err)
+	}
+	segment := &blockWALSegment{id: segmentID, size: constBlockWALSegmentHeaderSize}
+	bwT.segments = append(bwT.segments, segment)
+	return segment, len(bwT.segments) > 1, nil
+}
+
+// applyRetention deletes the sealed segments, which contain only the states older than the retention limit
+func (bwT *blockWAL) applyRetention() {
+	if bwT.parameters.RetentionStates == 0 || bwT.lastStateIndex < bwT.parameters.RetentionStates {
+		return
+	}
+	oldestStateIndex := bwT.lastStateIndex - bwT.parameters.RetentionStates
+	sealedSegments := bwT.segments[:len(bwT.segments)-1]
+	deleted := make(map[uint32]struct{})
+	for _, segment := range sealedSegments {
+		if segment.maxStateIndex >= oldestStateIndex {
+			continue
+		}
+		segmentPath := filepath.Join(bwT.dir, blockWALSegmentFileName(segment.id))
+		indexPath := filepath.Join(bwT.dir, blockWALIndexFileName(segment.id))
+		if err := os.Remove(segmentPath); err != nil {
+			bwT.LogWarnf("Failed to delete segment %s: %v", segmentPath, err)
+			continue
+		}
+		if err := os.Remove(indexPath); err != nil {
+			bwT.LogWarnf("Failed to delete index %s: %v", indexPath, err)
+		}
+		deleted[segment.id] = struct{}{}
+		bwT.LogDebugf("Segment %v with state indexes %v-%v deleted", segment.id, segment.minStateIndex, segment.maxStateIndex)
+	}
+	if len(deleted) == 0 {
+		return
+	}
+	bwT.segments = lo.Filter(bwT.segments, func(segment *blockWALSegment, _ int) bool {
+		_, isDeleted := deleted[segment.id]
+		return !isDeleted
+	})
+	for blockHash, info := range bwT.records {
+		if _, isDeleted := deleted[info.segmentID]; isDeleted {
+			delete(bwT.records, blockHash)
+		}
+	}
+}
+
+func (bwT *blockWAL) readRecord(info *blockWALRecordInfo) (state.Block, error) {
+	segmentPath := filepath.Join(bwT.dir, blockWALSegmentFileName(info.segmentID))
+	f, err := os.Open(segmentPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open segment %s: %w", segmentPath, err)
+	}
+	defer f.Close()
+	return readBlockWALRecord(f, info)
+}
+
 func (bwT *blockWAL) blockFilepath(blockHash state.BlockHash) (string, bool) {
 	subfolderName := blockWALSubFolderName(blockHash)
 	fileName := blockWALFileName(blockHash)
@@ -121,11 +309,29 @@ func (bwT *blockWAL) blockFilepath(blockHash state.BlockHash) (string, bool) {
 }
 
 func (bwT *blockWAL) Contains(blockHash state.BlockHash) bool {
-	_, exists := bwT.blockFilepath(blockHash)
+	bwT.mutex.Lock()
+	_, exists := bwT.records[blockHash]
+	bwT.mutex.Unlock()
+	if exists {
+		return true
+	}
+	_, exists = bwT.blockFilepath(blockHash)
 	return exists
 }
 
 func (bwT *blockWAL) Read(blockHash state.BlockHash) (state.Block, error) {
+	bwT.mutex.Lock()
+	info, exists := bwT.records[blockHash]
+	bwT.mutex.Unlock()
+	if exists {
+		block, err := bwT.readRecord(info)
+		if err != nil {
+			bwT.metrics.IncFailedReads()
+			return nil, fmt.Errorf("failed to read block %s from segment %v: %w", blockHash, info.segmentID, err)
+		}
+		return block, nil
+	}
+
 	filePath, exists := bwT.blockFilepath(blockHash)
 	if !exists {
 		return nil, fmt.Errorf("block hash %s is not present in WAL", blockHash)
@@ -138,38 +344,104 @@ func (bwT *blockWAL) Read(blockHash state.BlockHash) (state.Block, error) {
 	return block, nil
 }
 
-// This reads all the existing blocks from the WAL dir and passes them to the supplied callback.
+// This reads all the existing blocks from the WAL and passes them to the supplied callback.
 // The blocks are provided ordered by the state index, so that they can be applied to the store.
-// This function reads blocks twice, but tries to minimize the amount of memory required to load the WAL.
-func (bwT *blockWAL) ReadAllByStateIndex(cb func(stateIndex uint32, block state.Block) bool) error { //nolint:funlen
+// The blocks of the segments are located using the index and read once; the blocks stored
+// in the legacy block per file format are read twice to minimize the amount of memory required.
+func (bwT *blockWAL) ReadAllByStateIndex(cb func(stateIndex uint32, block state.Block) bool) error {
 	bwT.LogDebugf("Reading entire WAL...")
+	bwT.mutex.Lock()
+	records := lo.Values(bwT.records)
+	bwT.mutex.Unlock()
+	slices.SortFunc(records, func(a, b *blockWALRecordInfo) int {
+		if a.stateIndex != b.stateIndex {
+			return cmp.Compare(a.stateIndex, b.stateIndex)
+		}
+		if a.segmentID != b.segmentID {
+			return cmp.Compare(a.segmentID, b.segmentID)
+		}
+		return cmp.Compare(a.offset, b.offset)
+	})
+
+	legacyBlocksByStateIndex, err := bwT.legacyBlocksByStateIndex()
+	if err != nil {
+		return err
+	}
+	legacyStateIndexes := lo.Keys(legacyBlocksByStateIndex)
+	slices.Sort(legacyStateIndexes)
+	bwT.LogDebugf("Reading entire WAL: %v blocks in segments and %v legacy blocks found, notifying caller...",
+		len(records), len(legacyStateIndexes))
+
+	segmentFiles := make(map[uint32]*os.File)
+	defer func() {
+		for _, f := range segmentFiles {
+			f.Close()
+		}
+	}()
+	readRecord := func(info *blockWALRecordInfo) (state.Block, error) {
+		f, ok := segmentFiles[info.segmentID]
+		if !ok {
+			var err error
+			f, err = os.Open(filepath.Join(bwT.dir, blockWALSegmentFileName(info.segmentID)))
+			if err != nil {
+				return nil, err
+			}
+			segmentFiles[info.segmentID] = f
+		}
+		return readBlockWALRecord(f, info)
+	}
+
+	for len(records) > 0 || len(legacyStateIndexes) > 0 {
+		if len(records) > 0 && (len(legacyStateIndexes) == 0 || records[0].stateIndex <= legacyStateIndexes[0]) {
+			info := records[0]
+			records = records[1:]
+			block, err := readRecord(info)
+			if err != nil {
+				bwT.metrics.IncFailedReads()
+				bwT.LogWarnf("Reading entire WAL: unable to read block %s from segment %v: %v", info.blockHash, info.segmentID, err)
+				continue
+			}
+			if !cb(info.stateIndex, block) {
+				return nil
+			}
+			continue
+		}
+		stateIndex := legacyStateIndexes[0]
+		legacyStateIndexes = legacyStateIndexes[1:]
+		for _, filePath := range legacyBlocksByStateIndex[stateIndex] {
+			fileBlock, fileErr := BlockFromFilePath(filePath)
+			if fileErr != nil {
+				bwT.metrics.IncFailedReads()
+				bwT.LogWarnf("Reading entire WAL: unable to read block from %v: %v", filePath, fileErr)
+				continue
+			}
+			if !cb(stateIndex, fileBlock) {
+				return nil
+			}
+		}
+	}
+	bwT.LogDebugf("Reading entire WAL completed")
+	return nil
+}
+
+// legacyBlocksByStateIndex finds the blocks stored in the block per file format
+func (bwT *blockWAL) legacyBlocksByStateIndex() (map[uint32][]string, error) {
 	blocksByStateIndex := map[uint32][]string{}
-	checkFile := func(filePath string) bool {
+	checkFile := func(filePath string) {
 		if !strings.HasSuffix(filePath, constBlockWALFileSuffix) {
-			return false
+			return
 		}
 		stateIndex, err := BlockIndexFromFilePath(filePath)
 		if err != nil {
 			bwT.metrics.IncFailedReads()
-			bwT.LogWarn("Reading entire WAL: unable to read block index from %v: %v", filePath, err)
-			return false
-		}
-		stateIndexPaths, found := blocksByStateIndex[stateIndex]
-		if found {
-			stateIndexPaths = append(stateIndexPaths, filePath)
-		} else {
-			stateIndexPaths = []string{filePath}
+			bwT.LogWarnf("Reading entire WAL: unable to read block index from %v: %v", filePath, err)
+			return
 		}
-		blocksByStateIndex[stateIndex] = stateIndexPaths
-		return true
+		blocksByStateIndex[stateIndex] = append(blocksByStateIndex[stateIndex], filePath)
 	}
 
-	blocksTotal := 0
 	var checkDir func(dirPath string, dirEntries []os.DirEntry)
 	checkDir = func(dirPath string, dirEntries []os.DirEntry) {
-		bwT.LogDebugf("Reading entire WAL: checking folder %v with %v entries...", dirPath, len(dirEntries))
-		entries := 0
-		blocks := 0
 		for _, dirEntry := range dirEntries {
 			entryPath := filepath.Join(dirPath, dirEntry.Name())
 			if dirEntry.IsDir() {
@@ -178,48 +450,17 @@ func (bwT *blockWAL) ReadAllByStateIndex(cb func(stateIndex uint32, block state.
 					checkDir(entryPath, subDirEntries)
 				}
 			} else {
-				isBlock := checkFile(entryPath)
-				if isBlock {
-					blocks++
-					blocksTotal++
-				}
-			}
-			entries++
-			if entries%1000 == 0 {
-				bwT.LogDebugf("Reading entire WAL: checking folder %v: %v entries checked, %v blocks found (%v in total)...",
-					dirPath, entries, blocks, blocksTotal)
+				checkFile(entryPath)
 			}
 		}
-		bwT.LogDebugf("Reading entire WAL: checking folder %v completed: %v entries checked, %v blocks found (%v in total)",
-			dirPath, entries, blocks, blocksTotal)
 	}
 
 	dirEntries, err := os.ReadDir(bwT.dir)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	checkDir(bwT.dir, dirEntries)
-
-	bwT.LogDebugf("Reading entire WAL: %v blocks found, sorting them by index...", blocksTotal)
-	allStateIndexes := lo.Keys(blocksByStateIndex)
-	slices.Sort(allStateIndexes)
-	bwT.LogDebugf("Reading entire WAL: blocks sorted, notifying caller...")
-	for _, stateIndex := range allStateIndexes {
-		stateIndexPaths := blocksByStateIndex[stateIndex]
-		for _, stateIndexPath := range stateIndexPaths {
-			fileBlock, fileErr := BlockFromFilePath(stateIndexPath)
-			if fileErr != nil {
-				bwT.metrics.IncFailedReads()
-				bwT.LogWarn("Reading entire WAL: unable to read block from %v: %v", stateIndexPath, err)
-				continue
-			}
-			if !cb(stateIndex, fileBlock) {
-				return nil
-			}
-		}
-	}
-	bwT.LogDebugf("Reading entire WAL completed")
-	return nil
+	return blocksByStateIndex, nil
 }
 
 func blockInfoFromFilePath[I any](filePath string, getInfoFun func(uint32, io.Reader) (I, error)) (I, error) {
@@ -315,7 +556,3 @@ func blockWALSubFolderName(blockHash state.BlockHash) string {
 func blockWALFileName(blockHash state.BlockHash) string {
 	return blockHash.String() + constBlockWALFileSuffix
 }
-
-func blockWALTmpFileName(blockHash state.BlockHash) string {
-	return blockWALFileName(blockHash) + constBlockWALTmpFileSuffix
-}
@@ -0,0 +1,16 @@
+package sm_gpa_utils
+
+type BlockWALParameters struct {
+	// Size in bytes after which a WAL segment is sealed and a new one is started
+	SegmentMaxSize int64
+	// How many latest states must be kept in WAL; segments containing only older states
+	// are deleted when a segment is sealed; 0 means WAL is never cleaned
+	RetentionStates uint32
+}
+
+func NewBlockWALParameters() BlockWALParameters {
+	return BlockWALParameters{
+		SegmentMaxSize:  64 * 1024 * 1024,
+		RetentionStates: 0,
+	}
+}
@@ -1,7 +1,6 @@
 package sm_gpa_utils
 
 import (
-	"crypto/rand"
 	"os"
 	"testing"
 
@@ -22,7 +21,6 @@ type blockWALTestSM struct { // State machine for block WAL property based Rapid
 	factory             *BlockFactory
 	lastBlockCommitment *state.L1Commitment
 	blocks              map[state.BlockHash]state.Block
-	blocksMoved         []state.BlockHash
 	blocksDamaged       []state.BlockHash
 	log                 *logger.Logger
 }
@@ -35,10 +33,9 @@ func newBlockWALTestSM(t *rapid.T) *blockWALTestSM {
 	bwtsmT.factory = NewBlockFactory(t)
 	bwtsmT.lastBlockCommitment = origin.L1Commitment(0, nil, 0)
 	bwtsmT.log = testlogger.NewLogger(t)
-	bwtsmT.bw, err = NewBlockWAL(bwtsmT.log, constTestFolder, bwtsmT.factory.GetChainID(), mockBlockWALMetrics())
+	bwtsmT.bw, err = NewBlockWAL(bwtsmT.log, constTestFolder, bwtsmT.factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
 	bwtsmT.blocks = make(map[state.BlockHash]state.Block)
-	bwtsmT.blocksMoved = make([]state.BlockHash, 0)
 	bwtsmT.blocksDamaged = make([]state.BlockHash, 0)
 	return bwtsmT
 }
@@ -61,76 +58,30 @@ func (bwtsmT *blockWALTestSM) WriteBlock(t *rapid.T) {
 	t.Logf("Block %s written", bwtsmT.lastBlockCommitment.BlockHash())
 }
 
-// Correct the damaged block file
+// Correct the damaged block record
 func (bwtsmT *blockWALTestSM) ReWriteBlock(t *rapid.T) {
-	var takeFrom int
-	if len(bwtsmT.blocksMoved) == 0 {
-		if len(bwtsmT.blocksDamaged) == 0 {
-			t.Skip()
-		} else {
-			takeFrom = 1
-		}
-	} else if len(bwtsmT.blocksDamaged) == 0 {
-		takeFrom = 0
-	} else {
-		takeFrom = rapid.IntRange(0, 1).Example()
-	}
-	var blockHash state.BlockHash
-	if takeFrom == 0 {
-		blockHash = rapid.SampledFrom(bwtsmT.blocksMoved).Example()
-	} else {
-		require.Equal(t, 1, takeFrom)
-		blockHash = rapid.SampledFrom(bwtsmT.blocksDamaged).Example()
+	if len(bwtsmT.blocksDamaged) == 0 {
+		t.Skip()
 	}
+	blockHash := rapid.SampledFrom(bwtsmT.blocksDamaged).Example()
 	block, ok := bwtsmT.blocks[blockHash]
 	require.True(t, ok)
 	err := bwtsmT.bw.Write(block)
 	require.NoError(t, err)
-	if takeFrom == 0 {
-		bwtsmT.blocksMoved = lo.Without(bwtsmT.blocksMoved, blockHash)
-	} else {
-		bwtsmT.blocksDamaged = lo.Without(bwtsmT.blocksDamaged, blockHash)
-	}
+	bwtsmT.blocksDamaged = lo.Without(bwtsmT.blocksDamaged, blockHash)
 	t.Logf("Block %s rewritten", blockHash)
 }
 
-// Damage the block by overwriting its file with bytes of other block
-func (bwtsmT *blockWALTestSM) MoveBlock(t *rapid.T) {
-	blockHashes := bwtsmT.getGoodBlockHashes()
-	if len(blockHashes) < 2 {
-		t.Skip()
-	}
-	gen := rapid.SampledFrom(blockHashes)
-	blockHashOrig := gen.Example(0)
-	blockHashToDamage := gen.Example(1)
-	if blockHashOrig.Equals(blockHashToDamage) {
-		t.Skip()
-	}
-	fileOrigPath := walPathFromHash(bwtsmT.factory.GetChainID(), blockHashOrig)
-	fileToDamagePath := walPathFromHash(bwtsmT.factory.GetChainID(), blockHashToDamage)
-	data, err := os.ReadFile(fileOrigPath)
-	require.NoError(t, err)
-	err = os.WriteFile(fileToDamagePath, data, 0o644)
-	require.NoError(t, err)
-	bwtsmT.blocksMoved = append(bwtsmT.blocksMoved, blockHashToDamage)
-	t.Logf("Block %s damaged: block %s written instead", blockHashToDamage, blockHashOrig)
-}
-
-// Damage the block by writing random bytes to it
+// Damage the block by corrupting the bytes of its record
 func (bwtsmT *blockWALTestSM) DamageBlock(t *rapid.T) {
 	blockHashes := bwtsmT.getGoodBlockHashes()
 	if len(blockHashes) == 0 {
 		t.Skip()
 	}
 	blockHash := rapid.SampledFrom(blockHashes).Example()
-	filePath := walPathFromHash(bwtsmT.factory.GetChainID(), blockHash)
-	data := make([]byte, 50)
-	_, err := rand.Read(data)
-	require.NoError(t, err)
-	err = os.WriteFile(filePath, data, 0o644)
-	require.NoError(t, err)
+	damageBlockInWAL(t, bwtsmT.bw, blockHash)
 	bwtsmT.blocksDamaged = append(bwtsmT.blocksDamaged, blockHash)
-	t.Logf("Block %s damaged: 50 random bytes written instead", blockHash)
+	t.Logf("Block %s damaged: record corrupted", blockHash)
 }
 
 func (bwtsmT *blockWALTestSM) ReadGoodBlock(t *rapid.T) {
@@ -147,19 +98,6 @@ func (bwtsmT *blockWALTestSM) ReadGoodBlock(t *rapid.T) {
 	t.Logf("Block %s read", blockHash)
 }
 
-func (bwtsmT *blockWALTestSM) ReadMovedBlock(t *rapid.T) {
-	if len(bwtsmT.blocksMoved) == 0 {
-		t.Skip()
-	}
-	blockHash := rapid.SampledFrom(bwtsmT.blocksMoved).Example()
-	block, err := bwtsmT.bw.Read(blockHash)
-	require.NoError(t, err)
-	blockExpected, ok := bwtsmT.blocks[blockHash]
-	require.True(t, ok)
-	require.False(t, blockExpected.Equals(block))
-	t.Logf("Moved block %s read", blockHash)
-}
-
 func (bwtsmT *blockWALTestSM) ReadDamagedBlock(t *rapid.T) {
 	if len(bwtsmT.blocksDamaged) == 0 {
 		t.Skip()
@@ -172,15 +110,21 @@ func (bwtsmT *blockWALTestSM) ReadDamagedBlock(t *rapid.T) {
 
 func (bwtsmT *blockWALTestSM) Restart(t *rapid.T) {
 	var err error
-	bwtsmT.bw, err = NewBlockWAL(bwtsmT.log, constTestFolder, bwtsmT.factory.GetChainID(), mockBlockWALMetrics())
+	bwtsmT.bw, err = NewBlockWAL(bwtsmT.log, constTestFolder, bwtsmT.factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
+	// Damaged records are dropped when the segment is scanned on restart
+	for _, blockHash := range bwtsmT.blocksDamaged {
+		require.False(t, bwtsmT.bw.Contains(blockHash))
+		delete(bwtsmT.blocks, blockHash)
+	}
+	bwtsmT.blocksDamaged = make([]state.BlockHash, 0)
 	t.Log("Block WAL restarted")
 }
 
 func (bwtsmT *blockWALTestSM) getGoodBlockHashes() []state.BlockHash {
 	result := make([]state.BlockHash, 0)
 	for blockHash := range bwtsmT.blocks {
-		if !lo.Contains(bwtsmT.blocksMoved, blockHash) && !lo.Contains(bwtsmT.blocksDamaged, blockHash) {
+		if !lo.Contains(bwtsmT.blocksDamaged, blockHash) {
 			result = append(result, blockHash)
 		}
 	}
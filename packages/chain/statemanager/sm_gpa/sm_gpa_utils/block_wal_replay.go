@@ -0,0 +1,45 @@
+package sm_gpa_utils
+
+import (
+	"fmt"
+
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+)
+
+// ReplayBlockWAL commits all the blocks of the WAL to the store in the order of
+// their state indexes. The replay fails if the predecessor of some block is neither
+// in the store nor in the WAL (e.g., if it was removed by the WAL retention).
+// `onBlock` (if not nil) is called after each committed block. The number of
+// committed blocks and the committed block with the highest state index are returned.
+func ReplayBlockWAL(wal BlockWAL, store state.Store, onBlock func(state.Block)) (int, state.Block, error) {
+	blocksAdded := 0
+	var lastBlock state.Block
+	var replayErr error
+	err := wal.ReadAllByStateIndex(func(stateIndex uint32, block state.Block) bool {
+		var stateDraft state.StateDraft
+		if block.StateIndex() == 0 {
+			stateDraft = store.NewOriginStateDraft()
+		} else {
+			var stateErr error
+			stateDraft, stateErr = store.NewEmptyStateDraft(block.PreviousL1Commitment())
+			if stateErr != nil {
+				replayErr = fmt.Errorf("cannot create new state draft for previousL1Commitment=%v: %w", block.PreviousL1Commitment(), stateErr)
+				return false
+			}
+		}
+		block.Mutations().ApplyTo(stateDraft)
+		store.Commit(stateDraft)
+		blocksAdded++
+		if lastBlock == nil || block.StateIndex() >= lastBlock.StateIndex() {
+			lastBlock = block
+		}
+		if onBlock != nil {
+			onBlock(block)
+		}
+		return true
+	})
+	if err != nil {
+		return blocksAdded, lastBlock, fmt.Errorf("failed to iterate over WAL blocks: %w", err)
+	}
+	return blocksAdded, lastBlock, replayErr
+}
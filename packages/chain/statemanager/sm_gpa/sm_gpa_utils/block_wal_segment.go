@@ -0,0 +1,317 @@
+package sm_gpa_utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+)
+
+// Segment file format:
+//   - Magic (4 bytes): "BWAL"
+//   - Version (4 bytes, unsigned int); value 2 (version 1 is the block per file format)
+//   - Records
+//
+// Record format:
+//   - Payload length (4 bytes, unsigned int)
+//   - CRC-32C checksum of the payload (4 bytes, unsigned int)
+//   - Payload:
+//     -- State index (4 bytes, unsigned int)
+//     -- Block hash (20 bytes)
+//     -- Block bytes
+//
+// Index file of a sealed segment:
+//   - Magic (4 bytes): "BWIX"
+//   - Version (4 bytes, unsigned int); value 1
+//   - Count of records (4 bytes, unsigned int)
+//   - Records in the order they are in the segment:
+//     -- State index (4 bytes, unsigned int)
+//     -- Block hash (20 bytes)
+//     -- Offset of the record in the segment (8 bytes, unsigned int)
+//     -- Payload length (4 bytes, unsigned int)
+//   - CRC-32C checksum of all the preceding bytes (4 bytes, unsigned int)
+//
+// All the integers are little endian.
+
+const (
+	constBlockWALSegmentSuffix     = ".seg"
+	constBlockWALIndexSuffix       = ".idx"
+	constBlockWALSegmentMagic      = "BWAL"
+	constBlockWALIndexMagic        = "BWIX"
+	constBlockWALSegmentVersion    = uint32(2)
+	constBlockWALIndexVersion      = uint32(1)
+	constBlockWALSegmentHeaderSize = 8
+	constBlockWALRecordHeaderSize  = 8
+	constBlockWALPayloadHeaderSize = 4 + state.BlockHashSize
+	constBlockWALIndexEntrySize    = 4 + state.BlockHashSize + 8 + 4
+)
+
+var blockWALChecksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// blockWALRecordInfo describes where the block is stored in the WAL
+type blockWALRecordInfo struct {
+	segmentID  uint32
+	offset     int64
+	length     uint32
+	stateIndex uint32
+	blockHash  state.BlockHash
+}
+
+// blockWALSegment is a sparse index of the segment by state index: only the range
+// of state indexes is kept for sealed segments. Records are kept for the active
+// segment only, to write its index file when it is sealed.
+type blockWALSegment struct {
+	id            uint32
+	size          int64
+	count         int
+	minStateIndex uint32
+	maxStateIndex uint32
+	records       []*blockWALRecordInfo
+}
+
+func (bwsT *blockWALSegment) addRecord(info *blockWALRecordInfo, keepRecord bool) {
+	if bwsT.count == 0 || info.stateIndex < bwsT.minStateIndex {
+		bwsT.minStateIndex = info.stateIndex
+	}
+	if bwsT.count == 0 || info.stateIndex > bwsT.maxStateIndex {
+		bwsT.maxStateIndex = info.stateIndex
+	}
+	bwsT.count++
+	if keepRecord {
+		bwsT.records = append(bwsT.records, info)
+	}
+}
+
+func blockWALSegmentFileName(segmentID uint32) string {
+	return fmt.Sprintf("%010d%s", segmentID, constBlockWALSegmentSuffix)
+}
+
+func blockWALIndexFileName(segmentID uint32) string {
+	return fmt.Sprintf("%010d%s", segmentID, constBlockWALIndexSuffix)
+}
+
+func blockWALSegmentIDFromFileName(fileName string) (uint32, bool) {
+	name, found := strings.CutSuffix(fileName, constBlockWALSegmentSuffix)
+	if !found {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(name, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(id), true
+}
+
+func blockWALSegmentHeader() []byte {
+	header := make([]byte, 0, constBlockWALSegmentHeaderSize)
+	header = append(header, constBlockWALSegmentMagic...)
+	return binary.LittleEndian.AppendUint32(header, constBlockWALSegmentVersion)
+}
+
+// blockWALRecord serializes the block into a WAL record
+func blockWALRecord(block state.Block) []byte {
+	blockHash := block.Hash()
+	blockBytes := block.Bytes()
+	payload := make([]byte, 0, constBlockWALPayloadHeaderSize+len(blockBytes))
+	payload = binary.LittleEndian.AppendUint32(payload, block.StateIndex())
+	payload = append(payload, blockHash[:]...)
+	payload = append(payload, blockBytes...)
+
+	record := make([]byte, 0, constBlockWALRecordHeaderSize+len(payload))
+	record = binary.LittleEndian.AppendUint32(record, uint32(len(payload)))
+	record = binary.LittleEndian.AppendUint32(record, crc32.Checksum(payload, blockWALChecksumTable))
+	return append(record, payload...)
+}
+
+// readBlockWALRecord reads and verifies the record from the segment file
+func readBlockWALRecord(f io.ReaderAt, info *blockWALRecordInfo) (state.Block, error) {
+	data := make([]byte, constBlockWALRecordHeaderSize+int(info.length))
+	if _, err := f.ReadAt(data, info.offset); err != nil {
+		return nil, fmt.Errorf("failed to read record at offset %v: %w", info.offset, err)
+	}
+	length := binary.LittleEndian.Uint32(data[0:4])
+	if length != info.length {
+		return nil, fmt.Errorf("record at offset %v has length %v, expected %v", info.offset, length, info.length)
+	}
+	payload := data[constBlockWALRecordHeaderSize:]
+	if checksum := binary.LittleEndian.Uint32(data[4:8]); checksum != crc32.Checksum(payload, blockWALChecksumTable) {
+		return nil, fmt.Errorf("checksum mismatch of record at offset %v", info.offset)
+	}
+	block, err := state.BlockFromBytes(payload[constBlockWALPayloadHeaderSize:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse block of record at offset %v: %w", info.offset, err)
+	}
+	if block.StateIndex() != info.stateIndex {
+		return nil, fmt.Errorf("block index in record header %v does not match block index in block %v",
+			info.stateIndex, block.StateIndex())
+	}
+	if !block.Hash().Equals(info.blockHash) {
+		return nil, fmt.Errorf("block hash in record header %s does not match hash of the block %s",
+			info.blockHash, block.Hash())
+	}
+	return block, nil
+}
+
+// scanBlockWALSegment reads all the records of the segment verifying their checksums.
+// Records with checksum mismatch are skipped and counted as damaged. The scan stops at
+// the first incomplete record and returns the size of the valid part of the segment
+// together with the error describing why the rest of the segment cannot be read.
+func scanBlockWALSegment(filePath string, segmentID uint32) (records []*blockWALRecordInfo, validSize int64, damaged int, err error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to open segment %s: %w", filePath, err)
+	}
+	defer f.Close()
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to stat segment %s: %w", filePath, err)
+	}
+	header := make([]byte, constBlockWALSegmentHeaderSize)
+	if _, err = io.ReadFull(f, header); err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to read header of segment %s: %w", filePath, err)
+	}
+	if !bytes.Equal(header, blockWALSegmentHeader()) {
+		return nil, 0, 0, fmt.Errorf("segment %s has invalid header", filePath)
+	}
+
+	offset := int64(constBlockWALSegmentHeaderSize)
+	recordHeader := make([]byte, constBlockWALRecordHeaderSize)
+	for {
+		n, err := io.ReadFull(f, recordHeader)
+		if errors.Is(err, io.EOF) {
+			return records, offset, damaged, nil
+		}
+		if err != nil {
+			return records, offset, damaged, fmt.Errorf("incomplete header of record at offset %v (%v bytes): %w", offset, n, err)
+		}
+		length := binary.LittleEndian.Uint32(recordHeader[0:4])
+		checksum := binary.LittleEndian.Uint32(recordHeader[4:8])
+		if length < constBlockWALPayloadHeaderSize {
+			return records, offset, damaged, fmt.Errorf("record at offset %v is too short: %v bytes", offset, length)
+		}
+		if offset+constBlockWALRecordHeaderSize+int64(length) > fileInfo.Size() {
+			return records, offset, damaged, fmt.Errorf("incomplete record at offset %v: %v bytes expected", offset, length)
+		}
+		payload := make([]byte, length)
+		if _, err = io.ReadFull(f, payload); err != nil {
+			return records, offset, damaged, fmt.Errorf("incomplete record at offset %v: %w", offset, err)
+		}
+		if checksum == crc32.Checksum(payload, blockWALChecksumTable) {
+			info := &blockWALRecordInfo{
+				segmentID:  segmentID,
+				offset:     offset,
+				length:     length,
+				stateIndex: binary.LittleEndian.Uint32(payload[0:4]),
+			}
+			copy(info.blockHash[:], payload[4:constBlockWALPayloadHeaderSize])
+			records = append(records, info)
+		} else {
+			damaged++
+		}
+		offset += constBlockWALRecordHeaderSize + int64(length)
+	}
+}
+
+func writeBlockWALIndex(filePath string, records []*blockWALRecordInfo) error {
+	data := make([]byte, 0, 12+len(records)*constBlockWALIndexEntrySize+4)
+	data = append(data, constBlockWALIndexMagic...)
+	data = binary.LittleEndian.AppendUint32(data, constBlockWALIndexVersion)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(records)))
+	for _, info := range records {
+		data = binary.LittleEndian.AppendUint32(data, info.stateIndex)
+		data = append(data, info.blockHash[:]...)
+		data = binary.LittleEndian.AppendUint64(data, uint64(info.offset))
+		data = binary.LittleEndian.AppendUint32(data, info.length)
+	}
+	data = binary.LittleEndian.AppendUint32(data, crc32.Checksum(data, blockWALChecksumTable))
+	return writeFileSynced(filePath, data)
+}
+
+func readBlockWALIndex(filePath string, segmentID uint32) ([]*blockWALRecordInfo, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 16 {
+		return nil, fmt.Errorf("index %s is too short: %v bytes", filePath, len(data))
+	}
+	checksumOffset := len(data) - 4
+	if binary.LittleEndian.Uint32(data[checksumOffset:]) != crc32.Checksum(data[:checksumOffset], blockWALChecksumTable) {
+		return nil, fmt.Errorf("checksum mismatch of index %s", filePath)
+	}
+	if string(data[0:4]) != constBlockWALIndexMagic {
+		return nil, fmt.Errorf("index %s has invalid header", filePath)
+	}
+	if version := binary.LittleEndian.Uint32(data[4:8]); version != constBlockWALIndexVersion {
+		return nil, fmt.Errorf("index %s has unknown version %v", filePath, version)
+	}
+	count := int(binary.LittleEndian.Uint32(data[8:12]))
+	if 12+count*constBlockWALIndexEntrySize != checksumOffset {
+		return nil, fmt.Errorf("index %s has invalid size %v for %v records", filePath, len(data), count)
+	}
+	records := make([]*blockWALRecordInfo, count)
+	entry := data[12:checksumOffset]
+	for i := range records {
+		info := &blockWALRecordInfo{segmentID: segmentID}
+		info.stateIndex = binary.LittleEndian.Uint32(entry[0:4])
+		copy(info.blockHash[:], entry[4:4+state.BlockHashSize])
+		info.offset = int64(binary.LittleEndian.Uint64(entry[4+state.BlockHashSize : 12+state.BlockHashSize]))
+		info.length = binary.LittleEndian.Uint32(entry[12+state.BlockHashSize : constBlockWALIndexEntrySize])
+		records[i] = info
+		entry = entry[constBlockWALIndexEntrySize:]
+	}
+	return records, nil
+}
+
+// writeFileSynced writes the file atomically: the data is written to a temporary
+// file, which is synced to disk and then renamed to the final name.
+func writeFileSynced(filePath string, data []byte) error {
+	tmpFilePath := filePath + constBlockWALTmpFileSuffix
+	err := func() error {
+		f, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err = f.Write(data); err != nil {
+			return err
+		}
+		return f.Sync()
+	}()
+	if err != nil {
+		return fmt.Errorf("failed to write temporary file %s: %w", tmpFilePath, err)
+	}
+	if err = os.Rename(tmpFilePath, filePath); err != nil {
+		return fmt.Errorf("failed to move temporary file %s to %s: %w", tmpFilePath, filePath, err)
+	}
+	return syncDir(filepath.Dir(filePath))
+}
+
+func truncateFileSynced(filePath string, size int64) error {
+	f, err := os.OpenFile(filePath, os.O_WRONLY, 0o666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err = f.Truncate(size); err != nil {
+		return fmt.Errorf("failed to truncate file %s: %w", filePath, err)
+	}
+	return f.Sync()
+}
+
+func syncDir(dirPath string) error {
+	d, err := os.Open(dirPath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/state"
 	"github.com/nnikolash/wasp-types-exported/packages/testutil/testlogger"
@@ -22,9 +23,9 @@ func TestBlockWALBasic(t *testing.T) {
 	factory := NewBlockFactory(t)
 	blocks := factory.GetBlocks(5, 1)
 	blocksInWAL := blocks[:4]
-	walGood, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	walGood, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
-	walBad, err := NewBlockWAL(log, constTestFolder, isc.RandomChainID(), mockBlockWALMetrics())
+	walBad, err := NewBlockWAL(log, constTestFolder, isc.RandomChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
 	for i := range blocksInWAL {
 		err = walGood.Write(blocks[i])
@@ -57,7 +58,7 @@ func TestBlockWALLegacy(t *testing.T) {
 
 	factory := NewBlockFactory(t)
 	blocks := factory.GetBlocks(4, 1)
-	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
 	writeBlocksLegacy(t, factory.GetChainID(), blocks)
 	for i := range blocks {
@@ -67,24 +68,23 @@ func TestBlockWALLegacy(t *testing.T) {
 	}
 }
 
-// Check if existing block in WAL is found even if it is not in a subfolder
-func TestBlockWALNoSubfolder(t *testing.T) {
+// Check if legacy block in WAL is found if it is in a subfolder
+func TestBlockWALLegacySubfolder(t *testing.T) {
 	log := testlogger.NewLogger(t)
 	defer log.Sync()
 	defer cleanupAfterTest(t)
 
 	factory := NewBlockFactory(t)
 	blocks := factory.GetBlocks(4, 1)
-	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
-	for i := range blocks {
-		err = wal.Write(blocks[i])
-		require.NoError(t, err)
-	}
+	writeBlocksLegacy(t, factory.GetChainID(), blocks)
 	for _, block := range blocks {
 		pathWithSubfolder := walPathFromHash(factory.GetChainID(), block.Hash())
 		pathNoSubfolder := walPathNoSubfolderFromHash(factory.GetChainID(), block.Hash())
-		err = os.Rename(pathWithSubfolder, pathNoSubfolder)
+		err = os.MkdirAll(filepath.Dir(pathWithSubfolder), 0o777)
+		require.NoError(t, err)
+		err = os.Rename(pathNoSubfolder, pathWithSubfolder)
 		require.NoError(t, err)
 	}
 	for _, block := range blocks {
@@ -95,7 +95,7 @@ func TestBlockWALNoSubfolder(t *testing.T) {
 	}
 }
 
-// Check if existing WAL record is overwritten
+// Check if damaged WAL record is superseded by writing the block again
 func TestBlockWALOverwrite(t *testing.T) {
 	log := testlogger.NewLogger(t)
 	defer log.Sync()
@@ -103,34 +103,172 @@ func TestBlockWALOverwrite(t *testing.T) {
 
 	factory := NewBlockFactory(t)
 	blocks := factory.GetBlocks(4, 1)
-	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
 	for i := range blocks {
 		err = wal.Write(blocks[i])
 		require.NoError(t, err)
 	}
-	file0Path := walPathFromHash(factory.GetChainID(), blocks[0].Hash())
-	file1Path := walPathFromHash(factory.GetChainID(), blocks[1].Hash())
-	err = os.Rename(file1Path, file0Path)
-	require.NoError(t, err)
-	// block[1] is no longer in WAL
-	// instead of block[0] WAL record there is block[1] record named as block[0] record
-
+	damageBlockInWAL(t, wal, blocks[0].Hash())
 	require.True(t, wal.Contains(blocks[0].Hash()))
-	require.False(t, wal.Contains(blocks[1].Hash()))
-	block, err := wal.Read(blocks[0].Hash())
-	require.NoError(t, err)
-	// blocks[0] read, but hash is of blocks[1] - the situation is as expected
-	// It simulates a messed up block and checks if further Write rectifies the
-	// situation
-	require.True(t, blocks[1].Hash().Equals(block.Hash()))
+	_, err = wal.Read(blocks[0].Hash())
+	require.Error(t, err)
 
 	err = wal.Write(blocks[0])
 	require.NoError(t, err)
 	require.True(t, wal.Contains(blocks[0].Hash()))
-	block, err = wal.Read(blocks[0].Hash())
+	block, err := wal.Read(blocks[0].Hash())
 	require.NoError(t, err)
 	require.True(t, blocks[0].Equals(block))
+
+	// Restart: damaged record is skipped, the rewritten one is used
+	wal, err = NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
+	require.NoError(t, err)
+	for i := range blocks {
+		block, err := wal.Read(blocks[i].Hash())
+		require.NoError(t, err)
+		require.True(t, blocks[i].Equals(block))
+	}
+}
+
+// Check if incomplete record at the end of the active segment is truncated on restart
+func TestBlockWALTruncate(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(5, 1)
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		err = wal.Write(blocks[i])
+		require.NoError(t, err)
+	}
+	segmentPath := walSegmentPath(factory.GetChainID(), 1)
+	sizeBefore := fileSize(t, segmentPath)
+	// Simulate a crash while writing a record: only a part of it reaches the disk
+	record := blockWALRecord(blocks[4])
+	f, err := os.OpenFile(segmentPath, os.O_WRONLY|os.O_APPEND, 0o666)
+	require.NoError(t, err)
+	_, err = f.Write(record[:len(record)/2])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	wal, err = NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
+	require.NoError(t, err)
+	require.Equal(t, sizeBefore, fileSize(t, segmentPath))
+	require.False(t, wal.Contains(blocks[4].Hash()))
+	err = wal.Write(blocks[4])
+	require.NoError(t, err)
+
+	wal, err = NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
+	require.NoError(t, err)
+	for i := range blocks {
+		block, err := wal.Read(blocks[i].Hash())
+		require.NoError(t, err)
+		require.True(t, blocks[i].Equals(block))
+	}
+}
+
+// Check if blocks are written to several segments and found using segment indexes after restart
+func TestBlockWALSegments(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(10, 1)
+	parameters := NewBlockWALParameters()
+	parameters.SegmentMaxSize = 1 // Every block in its own segment
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), parameters)
+	require.NoError(t, err)
+	for i := range blocks {
+		err = wal.Write(blocks[i])
+		require.NoError(t, err)
+	}
+	for i := range blocks {
+		require.FileExists(t, walSegmentPath(factory.GetChainID(), uint32(i+1)))
+	}
+	for i := 0; i < len(blocks)-1; i++ {
+		require.FileExists(t, walIndexPath(factory.GetChainID(), uint32(i+1)))
+	}
+	require.NoFileExists(t, walIndexPath(factory.GetChainID(), uint32(len(blocks))))
+
+	// Lost index is rebuilt on restart
+	err = os.Remove(walIndexPath(factory.GetChainID(), 3))
+	require.NoError(t, err)
+	wal, err = NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), parameters)
+	require.NoError(t, err)
+	require.FileExists(t, walIndexPath(factory.GetChainID(), 3))
+	for i := range blocks {
+		require.True(t, wal.Contains(blocks[i].Hash()))
+		block, err := wal.Read(blocks[i].Hash())
+		require.NoError(t, err)
+		require.True(t, blocks[i].Equals(block))
+	}
+}
+
+// Check if segments with old blocks are deleted
+func TestBlockWALRetention(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(10, 1)
+	parameters := NewBlockWALParameters()
+	parameters.SegmentMaxSize = 1 // Every block in its own segment
+	parameters.RetentionStates = 3
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), parameters)
+	require.NoError(t, err)
+	for i := range blocks {
+		err = wal.Write(blocks[i])
+		require.NoError(t, err)
+	}
+	// Blocks have state indexes 1..10; the states 7..10 must be kept
+	for i := range blocks {
+		require.Equal(t, blocks[i].StateIndex() >= 7, wal.Contains(blocks[i].Hash()), "state index %v", blocks[i].StateIndex())
+	}
+	require.NoFileExists(t, walSegmentPath(factory.GetChainID(), 1))
+	require.NoFileExists(t, walIndexPath(factory.GetChainID(), 1))
+
+	wal, err = NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), parameters)
+	require.NoError(t, err)
+	var stateIndexes []uint32
+	err = wal.ReadAllByStateIndex(func(stateIndex uint32, block state.Block) bool {
+		stateIndexes = append(stateIndexes, stateIndex)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint32{7, 8, 9, 10}, stateIndexes)
+}
+
+// Check if all the blocks of WAL are committed to an empty store
+func TestBlockWALReplay(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := append([]state.Block{factory.GetOriginBlock()}, factory.GetBlocks(6, 1)...)
+	parameters := NewBlockWALParameters()
+	parameters.SegmentMaxSize = 1
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), parameters)
+	require.NoError(t, err)
+	for i := len(blocks) - 1; i >= 0; i-- {
+		err = wal.Write(blocks[i])
+		require.NoError(t, err)
+	}
+
+	store := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	replayed, lastBlock, err := ReplayBlockWAL(wal, store, nil)
+	require.NoError(t, err)
+	require.Equal(t, len(blocks), replayed)
+	require.True(t, blocks[len(blocks)-1].Equals(lastBlock))
+	for _, block := range blocks {
+		require.True(t, store.HasTrieRoot(block.TrieRoot()))
+	}
 }
 
 // Check if after restart wal is functioning correctly
@@ -141,7 +279,7 @@ func TestBlockWALRestart(t *testing.T) {
 
 	factory := NewBlockFactory(t)
 	blocks := factory.GetBlocks(4, 1)
-	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
 	for i := range blocks {
 		err = wal.Write(blocks[i])
@@ -149,7 +287,7 @@ func TestBlockWALRestart(t *testing.T) {
 	}
 
 	// Restart: WAL object is recreated
-	wal, err = NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	wal, err = NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
 	for i := range blocks {
 		require.True(t, wal.Contains(blocks[i].Hash()))
@@ -170,7 +308,7 @@ func testReadAllByStateIndex(t *testing.T, addToWALFun func(isc.ChainID, BlockWA
 	branchBlockIndex := mainBlocks - branchBlocks - 1
 	blocksMain := factory.GetBlocks(mainBlocks, 1)
 	blocksBranch := factory.GetBlocksFrom(branchBlocks, 1, blocksMain[branchBlockIndex].L1Commitment(), 2)
-	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), NewBlockWALParameters())
 	require.NoError(t, err)
 	addToWALFun(factory.GetChainID(), wal, blocksMain)
 	addToWALFun(factory.GetChainID(), wal, blocksBranch)
@@ -224,6 +362,37 @@ func walPathNoSubfolderFromHash(chainID isc.ChainID, blockHash state.BlockHash)
 	return filepath.Join(constTestFolder, chainID.String(), blockWALFileName(blockHash))
 }
 
+func walSegmentPath(chainID isc.ChainID, segmentID uint32) string {
+	return filepath.Join(constTestFolder, chainID.String(), blockWALSegmentFileName(segmentID))
+}
+
+func walIndexPath(chainID isc.ChainID, segmentID uint32) string {
+	return filepath.Join(constTestFolder, chainID.String(), blockWALIndexFileName(segmentID))
+}
+
+func fileSize(t require.TestingT, filePath string) int64 {
+	fileInfo, err := os.Stat(filePath)
+	require.NoError(t, err)
+	return fileInfo.Size()
+}
+
+// damageBlockInWAL flips a byte of the block bytes in the latest WAL record of the block
+func damageBlockInWAL(t require.TestingT, wal BlockWAL, blockHash state.BlockHash) {
+	info, ok := wal.(*blockWAL).records[blockHash]
+	require.True(t, ok)
+	segmentPath := filepath.Join(wal.(*blockWAL).dir, blockWALSegmentFileName(info.segmentID))
+	f, err := os.OpenFile(segmentPath, os.O_RDWR, 0o666)
+	require.NoError(t, err)
+	defer f.Close()
+	offset := info.offset + constBlockWALRecordHeaderSize + constBlockWALPayloadHeaderSize
+	data := make([]byte, 1)
+	_, err = f.ReadAt(data, offset)
+	require.NoError(t, err)
+	data[0] ^= 0xff
+	_, err = f.WriteAt(data, offset)
+	require.NoError(t, err)
+}
+
 func writeBlocksLegacy(t *testing.T, chainID isc.ChainID, blocks []state.Block) {
 	for _, block := range blocks {
 		filePath := walPathNoSubfolderFromHash(chainID, block.Hash())
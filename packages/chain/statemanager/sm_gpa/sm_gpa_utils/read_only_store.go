@@ -87,6 +87,10 @@ func (ros *readOnlyStore) TakeSnapshot(trieRoot trie.Hash, w io.Writer) error {
 	return ros.store.TakeSnapshot(trieRoot, w)
 }
 
+func (ros *readOnlyStore) TakeDeltaSnapshot(baseTrieRoot, trieRoot trie.Hash, w io.Writer) error {
+	return ros.store.TakeDeltaSnapshot(baseTrieRoot, trieRoot, w)
+}
+
 func (ros *readOnlyStore) RestoreSnapshot(trie.Hash, io.Reader) error {
 	return fmt.Errorf("cannot write snapshot into read-only store")
 }
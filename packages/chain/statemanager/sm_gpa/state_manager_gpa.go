@@ -641,8 +641,16 @@ func (smT *stateManagerGPA) pruneStore(commitment *state.L1Commitment, stateInde
 		statesToPrune = smT.parameters.PruningMaxStatesToDelete
 	}
 	i := 0
-	for ; i < statesToPrune; i++ {
+	for ; statesToPrune > 0; statesToPrune-- {
 		bi := smT.chainOfBlocks.PeekStart()
+		if !smT.store.HasTrieRoot(bi.trieRoot) {
+			// The state has already been pruned on demand (e.g., via admin API)
+			smT.chainOfBlocks.RemoveStart()
+			continue
+		}
+		if !smT.isStateOldEnoughToPrune(bi) {
+			break // Newer states are even younger
+		}
 		singleStart := time.Now()
 		stats, err := smT.store.Prune(bi.trieRoot)
 		if err != nil {
@@ -652,11 +660,24 @@ func (smT *stateManagerGPA) pruneStore(commitment *state.L1Commitment, stateInde
 		smT.chainOfBlocks.RemoveStart()
 		smT.metrics.StatePruned(time.Since(singleStart), bi.blockIndex)
 		smT.log.Debugf("Block index %v %s pruned: %v nodes and %v values deleted", bi.blockIndex, bi.trieRoot, stats.DeletedNodes, stats.DeletedValues)
+		i++
 	}
 	smT.metrics.PruningCompleted(time.Since(start), i)
 	smT.log.Debugf("Pruning completed, %v trie roots pruned", i)
 }
 
+func (smT *stateManagerGPA) isStateOldEnoughToPrune(bi *blockInfo) bool {
+	if smT.parameters.PruningMinStatesAge <= 0 {
+		return true
+	}
+	st, err := smT.store.StateByTrieRoot(bi.trieRoot)
+	if err != nil {
+		smT.log.Errorf("Failed to retrieve state %s while pruning: %v", bi.trieRoot, err)
+		return false
+	}
+	return smT.parameters.TimeProvider.GetNow().Sub(st.Timestamp()) >= smT.parameters.PruningMinStatesAge
+}
+
 // updateChainOfBlocks updates chain of blocks to contain trie roots/block indexes
 // of all the blocks starting from the one with passed commitment and going back
 // to the oldest unpruned block. Usually some block chain is currently known.
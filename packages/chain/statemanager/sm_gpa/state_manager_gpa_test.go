@@ -472,6 +472,85 @@ func TestPruningTooMuch(t *testing.T) {
 	}
 }
 
+// Single node setting
+//   - pruning leaves 10 historic blocks, but only if they are older than 1 hour.
+//   - 20 blocks are committed, none of them are pruned as they are too young.
+//   - time passes and another block is committed to trigger pruning: 10 blocks
+//     (origin+9 committed blocks) are pruned.
+func TestPruningByAge(t *testing.T) {
+	blocksToKeep := 10
+	blocksToSend := 20
+
+	nodeIDs := gpa.MakeTestNodeIDs(1)
+	nodeID := nodeIDs[0]
+	smParameters := NewStateManagerParameters()
+	smParameters.PruningMinStatesToKeep = blocksToKeep // Also initializes chain with this value in governance contract
+	smParameters.PruningMaxStatesToDelete = 100
+	smParameters.PruningMinStatesAge = 1 * time.Hour
+	env := newTestEnv(t, nodeIDs, sm_gpa_utils.NewEmptyTestBlockWAL, newEmptySnapshotManagerFun, smParameters)
+	defer env.finalize()
+
+	blocks := env.bf.GetBlocks(blocksToSend+1, 1)
+	env.sendBlocksToNode(nodeID, 0*time.Second, blocks[:blocksToSend]...)
+	require.True(env.t, env.ensureStoreContainsBlocksNoWait(nodeID, blocks[:blocksToSend]))
+	for j := 0; j < blocksToSend; j++ {
+		env.checkBlock(nodeID, blocks[j])
+	}
+
+	env.parameters.TimeProvider.SetNow(env.parameters.TimeProvider.GetNow().Add(2 * time.Hour))
+	env.sendBlocksToNode(nodeID, 0*time.Second, blocks[blocksToSend])
+	lastExistingBlockIndex := blocksToSend - blocksToKeep
+	require.True(env.t, env.ensureStoreContainsBlocksNoWait(nodeID, blocks[lastExistingBlockIndex:]))
+	for j := 0; j < lastExistingBlockIndex; j++ {
+		env.doesNotContainBlock(nodeID, blocks[j])
+	}
+	for j := lastExistingBlockIndex; j <= blocksToSend; j++ {
+		env.checkBlock(nodeID, blocks[j])
+	}
+}
+
+// Single node setting, pruning leaves 10 historic blocks.
+//   - 15 blocks are committed, the oldest are pruned by state manager.
+//   - some of the remaining oldest blocks are pruned directly in the store
+//     (as on demand pruning does).
+//   - more blocks are committed; state manager skips the already pruned blocks
+//     and continues pruning the rest.
+func TestPruningAfterPruningOnDemand(t *testing.T) {
+	blocksToKeep := 10
+	blocksToSend := 15
+	blocksToPruneOnDemand := 3
+
+	nodeIDs := gpa.MakeTestNodeIDs(1)
+	nodeID := nodeIDs[0]
+	smParameters := NewStateManagerParameters()
+	smParameters.PruningMinStatesToKeep = blocksToKeep
+	env := newTestEnv(t, nodeIDs, sm_gpa_utils.NewEmptyTestBlockWAL, newEmptySnapshotManagerFun, smParameters)
+	defer env.finalize()
+
+	blocks := env.bf.GetBlocks(blocksToSend+5, 1)
+	env.sendBlocksToNode(nodeID, 0*time.Second, blocks[:blocksToSend]...)
+	lastExistingBlockIndex := blocksToSend - blocksToKeep - 1
+	require.True(env.t, env.ensureStoreContainsBlocksNoWait(nodeID, blocks[lastExistingBlockIndex:blocksToSend]))
+
+	store := env.stores[nodeID]
+	for j := lastExistingBlockIndex; j < lastExistingBlockIndex+blocksToPruneOnDemand; j++ {
+		_, err := store.Prune(blocks[j].TrieRoot())
+		require.NoError(env.t, err)
+	}
+
+	for i := blocksToSend; i < len(blocks); i++ {
+		env.sendBlocksToNode(nodeID, 0*time.Second, blocks[i])
+		lastExistingBlockIndex = i - blocksToKeep
+		if lastExistingBlockIndex < blocksToSend-blocksToKeep-1+blocksToPruneOnDemand {
+			lastExistingBlockIndex = blocksToSend - blocksToKeep - 1 + blocksToPruneOnDemand
+		}
+		require.True(env.t, env.ensureStoreContainsBlocksNoWait(nodeID, blocks[lastExistingBlockIndex:i+1]))
+		for j := 0; j < lastExistingBlockIndex; j++ {
+			env.doesNotContainBlock(nodeID, blocks[j])
+		}
+	}
+}
+
 // One node setting
 //   - 30 blocks are committed to the node.
 //   - snapshots are produced on every 5th state.
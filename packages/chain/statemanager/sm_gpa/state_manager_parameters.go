@@ -30,6 +30,8 @@ type StateManagerParameters struct {
 	PruningMinStatesToKeep int
 	// On single store pruning attempt at most this number of states will be deleted
 	PruningMaxStatesToDelete int
+	// States younger than this will always be available in the database; 0 means no age limit
+	PruningMinStatesAge time.Duration
 
 	TimeProvider sm_gpa_utils.TimeProvider
 }
@@ -52,6 +54,7 @@ func NewStateManagerParameters(tpOpt ...sm_gpa_utils.TimeProvider) StateManagerP
 		StateManagerTimerTickPeriod:       1 * time.Second,
 		PruningMinStatesToKeep:            10000,
 		PruningMaxStatesToDelete:          10,
+		PruningMinStatesAge:               0,
 		TimeProvider:                      tp,
 	}
 }
@@ -50,3 +50,33 @@ func (si *snapshotInfoImpl) Equals(other SnapshotInfo) bool {
 	}
 	return si.Commitment().Equals(other.Commitment())
 }
+
+// deltaSnapshotInfoImpl describes a delta snapshot: it contains only the trie
+// nodes, which are not present in the state of the base snapshot. Therefore it
+// can only be loaded, when the base snapshot is already loaded.
+type deltaSnapshotInfoImpl struct {
+	SnapshotInfo
+	base SnapshotInfo
+}
+
+var _ SnapshotInfo = &deltaSnapshotInfoImpl{}
+
+func newDeltaSnapshotInfo(snapshotInfo SnapshotInfo, base SnapshotInfo) SnapshotInfo {
+	return &deltaSnapshotInfoImpl{
+		SnapshotInfo: snapshotInfo,
+		base:         base,
+	}
+}
+
+func (dsi *deltaSnapshotInfoImpl) String() string {
+	return fmt.Sprintf("%s (delta of %s)", dsi.SnapshotInfo, dsi.base)
+}
+
+// Returns the base snapshot of the delta snapshot or nil, if the snapshot is full.
+func baseSnapshotInfo(snapshotInfo SnapshotInfo) SnapshotInfo {
+	dsi, ok := snapshotInfo.(*deltaSnapshotInfoImpl)
+	if !ok {
+		return nil
+	}
+	return dsi.base
+}
@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/iotaledger/hive.go/logger"
@@ -27,10 +29,22 @@ type snapshotManagerImpl struct {
 	chainID isc.ChainID
 	metrics *metrics.ChainSnapshotsMetrics
 
+	store            state.Store
 	snapshotter      snapshotter
 	localPath        string
 	baseNetworkPaths []string
 	snapshotToLoad   *state.BlockHash
+
+	deltasPerFull     uint32
+	lastSnapshot      SnapshotInfo // The last created snapshot; a base for the next delta snapshot
+	deltasSinceFull   uint32
+	lastSnapshotMutex sync.Mutex
+}
+
+// Information about snapshot, found when searching for snapshots to load
+type snapshotFile struct {
+	info SnapshotInfo
+	path string
 }
 
 var (
@@ -42,6 +56,7 @@ const (
 	constDownloadTimeout                     = 10 * time.Minute
 	constSnapshotIndexHashFileNameSepparator = "-"
 	constSnapshotFileSuffix                  = ".snap"
+	constDeltaSnapshotFileSuffix             = ".delta"
 	constSnapshotTmpFileSuffix               = ".tmp"
 	constSnapshotDownloaded                  = "net"
 	constIndexFileName                       = "INDEX" // Index file contains a new-line separated list of snapshot files
@@ -57,6 +72,7 @@ func NewSnapshotManager(
 	snapshotToLoad *state.BlockHash,
 	createPeriod uint32,
 	delayPeriod uint32,
+	deltasPerFull uint32,
 	baseLocalPath string,
 	baseNetworkPaths []string,
 	store state.Store,
@@ -70,10 +86,12 @@ func NewSnapshotManager(
 		ctx:              ctx,
 		chainID:          chainID,
 		metrics:          metrics,
+		store:            store,
 		snapshotter:      newSnapshotter(store),
 		localPath:        localPath,
 		baseNetworkPaths: baseNetworkPaths,
 		snapshotToLoad:   snapshotToLoad,
+		deltasPerFull:    deltasPerFull,
 	}
 	if err := ioutils.CreateDirectory(localPath, 0o777); err != nil {
 		return nil, fmt.Errorf("cannot create folder %s: %v", localPath, err)
@@ -101,12 +119,25 @@ func NewSnapshotManager(
 // file, needed to create a snapshot, already exists, it assumes that another go
 // routine is already making a snapshot and returns. For this reason it is important
 // to delete all temporary files on snapshot manager start.
+// If `deltasPerFull` is positive, after each full snapshot, that many delta
+// snapshots are made, each of them containing only the trie nodes, which are
+// new since the previous snapshot.
 func (smiT *snapshotManagerImpl) createSnapshot(snapshotInfo SnapshotInfo) {
 	start := time.Now()
 	stateIndex := snapshotInfo.StateIndex()
 	commitment := snapshotInfo.Commitment()
-	smiT.log.Debugf("Creating snapshot %v %s...", stateIndex, commitment)
-	tmpFileName := tempSnapshotFileName(stateIndex, commitment.BlockHash())
+	base := smiT.getDeltaSnapshotBase()
+	var tmpFileName, finalFileName string
+	if base == nil {
+		smiT.log.Debugf("Creating snapshot %v %s...", stateIndex, commitment)
+		tmpFileName = tempSnapshotFileName(stateIndex, commitment.BlockHash())
+		finalFileName = snapshotFileName(stateIndex, commitment.BlockHash())
+	} else {
+		smiT.log.Debugf("Creating snapshot %v %s as a delta of snapshot %s...", stateIndex, commitment, base)
+		snapshotInfo = newDeltaSnapshotInfo(snapshotInfo, base)
+		tmpFileName = tempDeltaSnapshotFileName(stateIndex, commitment.BlockHash())
+		finalFileName = deltaSnapshotFileName(stateIndex, commitment.BlockHash())
+	}
 	tmpFilePath := filepath.Join(smiT.localPath, tmpFileName)
 	exists, _, _ := ioutils.PathExists(tmpFilePath)
 	if exists {
@@ -129,7 +160,6 @@ func (smiT *snapshotManagerImpl) createSnapshot(snapshotInfo SnapshotInfo) {
 			return
 		}
 
-		finalFileName := snapshotFileName(stateIndex, commitment.BlockHash())
 		finalFilePath := filepath.Join(smiT.localPath, finalFileName)
 		err = os.Rename(tmpFilePath, finalFilePath)
 		if err != nil {
@@ -138,6 +168,7 @@ func (smiT *snapshotManagerImpl) createSnapshot(snapshotInfo SnapshotInfo) {
 			return
 		}
 		smiT.snapshotManagerRunner.snapshotCreated(snapshotInfo)
+		smiT.deltaSnapshotBaseCreated(snapshotInfo)
 		smiT.log.Infof("Creating snapshot %v %s: snapshot created in %s", stateIndex, commitment, finalFilePath)
 		smiT.metrics.SnapshotCreated(time.Since(start), stateIndex)
 	}()
@@ -186,12 +217,17 @@ func (smiT *snapshotManagerImpl) loadSnapshot() SnapshotInfo {
 		searchCondition = fmt.Sprintf("block hash %s", *smiT.snapshotToLoad)
 	}
 
-	smiT.searchLocalSnapshots(considerSnapshotFun)
-	smiT.searchNetworkSnapshots(smiT.baseNetworkPaths, considerSnapshotFun)
+	foundSnapshots := make([]snapshotFile, 0) // Base snapshots of delta snapshots will be searched for in this list
+	addSnapshotFun := func(snapshotInfo SnapshotInfo, path string) {
+		foundSnapshots = append(foundSnapshots, snapshotFile{info: snapshotInfo, path: path})
+		considerSnapshotFun(snapshotInfo, path)
+	}
+	smiT.searchLocalSnapshots(addSnapshotFun)
+	smiT.searchNetworkSnapshots(smiT.baseNetworkPaths, addSnapshotFun)
 	smiT.log.Debugf("%v snapshots with %s will be considered for loading in this order: %v", len(snapshotPaths), searchCondition, snapshotPaths)
 
 	for i := range snapshotPaths {
-		err := smiT.loadSnapshotFromPath(snapshotInfos[i], snapshotPaths[i])
+		err := smiT.loadSnapshotWithBases(snapshotInfos[i], snapshotPaths[i], foundSnapshots)
 		if err == nil {
 			smiT.log.Infof("Snapshot %s successfully loaded from %s", snapshotInfos[i], snapshotPaths[i])
 			return snapshotInfos[i]
@@ -206,12 +242,39 @@ func (smiT *snapshotManagerImpl) loadSnapshot() SnapshotInfo {
 // Internal functions
 // -------------------------------------
 
+// Returns the base of the next snapshot to be created or nil, if the next
+// snapshot must be full.
+func (smiT *snapshotManagerImpl) getDeltaSnapshotBase() SnapshotInfo {
+	smiT.lastSnapshotMutex.Lock()
+	defer smiT.lastSnapshotMutex.Unlock()
+	if smiT.lastSnapshot == nil || smiT.deltasSinceFull >= smiT.deltasPerFull {
+		return nil
+	}
+	if !smiT.store.HasTrieRoot(smiT.lastSnapshot.TrieRoot()) {
+		smiT.log.Debugf("State of the last snapshot %s is not in the store anymore; full snapshot will be created", smiT.lastSnapshot)
+		return nil
+	}
+	return smiT.lastSnapshot
+}
+
+func (smiT *snapshotManagerImpl) deltaSnapshotBaseCreated(snapshotInfo SnapshotInfo) {
+	smiT.lastSnapshotMutex.Lock()
+	defer smiT.lastSnapshotMutex.Unlock()
+	if smiT.lastSnapshot != nil && smiT.lastSnapshot.StateIndex() >= snapshotInfo.StateIndex() {
+		return
+	}
+	smiT.lastSnapshot = snapshotInfo
+	if baseSnapshotInfo(snapshotInfo) == nil {
+		smiT.deltasSinceFull = 0
+	} else {
+		smiT.deltasSinceFull++
+	}
+}
+
 // This happens strictly before snapshot manager starts to produce new snapshots.
 // So there is no way that this function will delete temp file, which is needed.
 func (smiT *snapshotManagerImpl) cleanTempFiles() {
-	tempFileRegExp := tempSnapshotFileNameString("*", "*")
-	tempFileRegExpWithPath := filepath.Join(smiT.localPath, tempFileRegExp)
-	tempFiles, err := filepath.Glob(tempFileRegExpWithPath)
+	tempFiles, err := smiT.globLocalFiles(tempSnapshotFileNameString("*", "*"), tempDeltaSnapshotFileNameString("*", "*"))
 	if err != nil {
 		smiT.log.Errorf("Failed to obtain temporary snapshot file list: %v", err)
 		return
@@ -230,9 +293,7 @@ func (smiT *snapshotManagerImpl) cleanTempFiles() {
 }
 
 func (smiT *snapshotManagerImpl) searchLocalSnapshots(considerSnapshotFun func(SnapshotInfo, string)) {
-	fileRegExp := snapshotFileNameString("*", "*")
-	fileRegExpWithPath := filepath.Join(smiT.localPath, fileRegExp)
-	files, err := filepath.Glob(fileRegExpWithPath)
+	files, err := smiT.globLocalFiles(snapshotFileNameString("*", "*"), deltaSnapshotFileNameString("*", "*"))
 	if err != nil {
 		smiT.log.Errorf("Search local snapshots: failed to obtain snapshot file list: %v", err)
 		return
@@ -246,7 +307,7 @@ func (smiT *snapshotManagerImpl) searchLocalSnapshots(considerSnapshotFun func(S
 				return
 			}
 			defer f.Close()
-			snapshotInfo, err := readSnapshotInfo(f)
+			snapshotInfo, err := getReadSnapshotInfoFun(file)(f)
 			if err != nil {
 				smiT.log.Errorf("Search local snapshots: failed to read snapshot info from file %s: %v", file, err)
 				return
@@ -289,7 +350,7 @@ func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []strin
 						return
 					}
 					defer sReader.Close()
-					snapshotInfo, er := readSnapshotInfo(sReader)
+					snapshotInfo, er := getReadSnapshotInfoFun(snapshotFileName)(sReader)
 					if er != nil {
 						smiT.log.Errorf("Search network snapshots: failed to read snapshot info from %s in %s: %v", snapshotFileName, basePath, er)
 						return
@@ -312,6 +373,43 @@ func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []strin
 	}
 }
 
+func (smiT *snapshotManagerImpl) globLocalFiles(fileRegExps ...string) ([]string, error) {
+	result := make([]string, 0)
+	for _, fileRegExp := range fileRegExps {
+		fileRegExpWithPath := filepath.Join(smiT.localPath, fileRegExp)
+		files, err := filepath.Glob(fileRegExpWithPath)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, files...)
+	}
+	return result, nil
+}
+
+// Loads the snapshot; if it is a delta snapshot, the chain of its base snapshots,
+// which are searched for in `foundSnapshots`, is loaded first.
+func (smiT *snapshotManagerImpl) loadSnapshotWithBases(snapshotInfo SnapshotInfo, url string, foundSnapshots []snapshotFile) error {
+	base := baseSnapshotInfo(snapshotInfo)
+	if base != nil && !smiT.store.HasTrieRoot(base.TrieRoot()) {
+		err := fmt.Errorf("base snapshot %s not found", base)
+		for _, foundSnapshot := range foundSnapshots {
+			if !foundSnapshot.info.Equals(base) {
+				continue
+			}
+			err = smiT.loadSnapshotWithBases(foundSnapshot.info, foundSnapshot.path, foundSnapshots)
+			if err == nil {
+				smiT.log.Debugf("Base snapshot %s successfully loaded from %s", foundSnapshot.info, foundSnapshot.path)
+				break
+			}
+			smiT.log.Warnf("Failed to load base snapshot %s from %s: %v", foundSnapshot.info, foundSnapshot.path, err)
+		}
+		if err != nil {
+			return fmt.Errorf("loading base of snapshot %s failed: %v", snapshotInfo, err)
+		}
+	}
+	return smiT.loadSnapshotFromPath(snapshotInfo, url)
+}
+
 func (smiT *snapshotManagerImpl) loadSnapshotFromPath(snapshotInfo SnapshotInfo, url string) error {
 	loadSnapshotFun := func(r io.Reader) error {
 		err := smiT.snapshotter.loadSnapshot(snapshotInfo, r)
@@ -329,7 +427,12 @@ func (smiT *snapshotManagerImpl) loadSnapshotFromPath(snapshotInfo SnapshotInfo,
 		return loadSnapshotFun(f)
 	}
 	loadNetworkFun := func(url string) error {
-		fileNameLocal := downloadedSnapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+		var fileNameLocal string
+		if baseSnapshotInfo(snapshotInfo) == nil {
+			fileNameLocal = downloadedSnapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+		} else {
+			fileNameLocal = downloadedDeltaSnapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+		}
 		filePathLocal := filepath.Join(smiT.localPath, fileNameLocal)
 		addProgressReporterFun := func(r io.Reader, f string, s uint64) io.Reader {
 			return smiT.addProgressReporter(r, fmt.Sprintf("snapshot %s", snapshotInfo), f, s)
@@ -427,3 +530,36 @@ func downloadedSnapshotFileNameString(index, blockHash string) string {
 	return index + constSnapshotIndexHashFileNameSepparator + blockHash +
 		constSnapshotIndexHashFileNameSepparator + constSnapshotDownloaded + constSnapshotFileSuffix
 }
+
+func tempDeltaSnapshotFileName(index uint32, blockHash state.BlockHash) string {
+	return tempDeltaSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
+}
+
+func tempDeltaSnapshotFileNameString(index, blockHash string) string {
+	return deltaSnapshotFileNameString(index, blockHash) + constSnapshotTmpFileSuffix
+}
+
+func deltaSnapshotFileName(index uint32, blockHash state.BlockHash) string {
+	return deltaSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
+}
+
+func deltaSnapshotFileNameString(index, blockHash string) string {
+	return index + constSnapshotIndexHashFileNameSepparator + blockHash + constDeltaSnapshotFileSuffix
+}
+
+func downloadedDeltaSnapshotFileName(index uint32, blockHash state.BlockHash) string {
+	return downloadedDeltaSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
+}
+
+func downloadedDeltaSnapshotFileNameString(index, blockHash string) string {
+	return index + constSnapshotIndexHashFileNameSepparator + blockHash +
+		constSnapshotIndexHashFileNameSepparator + constSnapshotDownloaded + constDeltaSnapshotFileSuffix
+}
+
+// Delta snapshot files have different suffix and different header than full snapshot files.
+func getReadSnapshotInfoFun(fileName string) func(io.Reader) (SnapshotInfo, error) {
+	if strings.HasSuffix(fileName, constDeltaSnapshotFileSuffix) {
+		return readDeltaSnapshotInfo
+	}
+	return readSnapshotInfo
+}
@@ -59,6 +59,26 @@ func TestSnapshotManagerLoadMiddleNetworkFile(t *testing.T) {
 	testSnapshotManager(t, getNetworkFileFuns, testSnapshotManagerMiddle)
 }
 
+func TestSnapshotManagerDeltaLocal(t *testing.T) {
+	testSnapshotManager(t, getLocalFuns, testSnapshotManagerDeltaLast)
+}
+
+func TestSnapshotManagerDeltaNetworkHTTP(t *testing.T) {
+	testSnapshotManager(t, getNetworkHTTPFuns, testSnapshotManagerDeltaLast)
+}
+
+func TestSnapshotManagerDeltaNetworkFile(t *testing.T) {
+	testSnapshotManager(t, getNetworkFileFuns, testSnapshotManagerDeltaLast)
+}
+
+func TestSnapshotManagerDeltaLoadMiddleLocal(t *testing.T) {
+	testSnapshotManager(t, getLocalFuns, testSnapshotManagerDeltaMiddle)
+}
+
+func TestSnapshotManagerDeltaLoadMiddleNetworkHTTP(t *testing.T) {
+	testSnapshotManager(t, getNetworkHTTPFuns, testSnapshotManagerDeltaMiddle)
+}
+
 func testSnapshotManager(
 	t *testing.T,
 	getFunsFun func(*testing.T) (createNewNodeFun, snapshotsAvailableFun),
@@ -77,6 +97,7 @@ func getLocalFuns(t *testing.T) (createNewNodeFun, snapshotsAvailableFun) {
 				snapshotToLoad,
 				0,
 				0,
+				0,
 				localSnapshotsCreatePathConst,
 				[]string{},
 				store,
@@ -112,6 +133,7 @@ func getNetworkFuns(t *testing.T, networkPaths []string) (createNewNodeFun, snap
 				snapshotToLoad,
 				0,
 				0,
+				0,
 				localSnapshotsDownloadPathConst,
 				networkPaths,
 				store,
@@ -128,7 +150,7 @@ func getNetworkFuns(t *testing.T, networkPaths []string) (createNewNodeFun, snap
 			defer f.Close()
 			w := bufio.NewWriter(f)
 			for _, snapshotInfo := range snapshotInfos {
-				w.WriteString(snapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash()) + "\n")
+				w.WriteString(snapshotInfoFileName(snapshotInfo) + "\n")
 			}
 			w.Flush()
 		}
@@ -139,7 +161,7 @@ func testSnapshotManagerLast(
 	createNewNodeFun createNewNodeFun,
 	snapshotsAvailableFun snapshotsAvailableFun,
 ) {
-	testSnapshotManagerAny(t, createNewNodeFun, snapshotsAvailableFun, 0)
+	testSnapshotManagerAny(t, createNewNodeFun, snapshotsAvailableFun, 0, 0)
 }
 
 func testSnapshotManagerMiddle(
@@ -147,7 +169,25 @@ func testSnapshotManagerMiddle(
 	createNewNodeFun createNewNodeFun,
 	snapshotsAvailableFun snapshotsAvailableFun,
 ) {
-	testSnapshotManagerAny(t, createNewNodeFun, snapshotsAvailableFun, 2)
+	testSnapshotManagerAny(t, createNewNodeFun, snapshotsAvailableFun, 2, 0)
+}
+
+// Snapshot 4 is full, snapshots 8, 12 and 16 are deltas; snapshot 16 is loaded
+func testSnapshotManagerDeltaLast(
+	t *testing.T,
+	createNewNodeFun createNewNodeFun,
+	snapshotsAvailableFun snapshotsAvailableFun,
+) {
+	testSnapshotManagerAny(t, createNewNodeFun, snapshotsAvailableFun, 0, 3)
+}
+
+// Snapshot 4 is full, snapshots 8, 12 and 16 are deltas; snapshot 8 is loaded
+func testSnapshotManagerDeltaMiddle(
+	t *testing.T,
+	createNewNodeFun createNewNodeFun,
+	snapshotsAvailableFun snapshotsAvailableFun,
+) {
+	testSnapshotManagerAny(t, createNewNodeFun, snapshotsAvailableFun, 2, 3)
 }
 
 func testSnapshotManagerAny(
@@ -155,6 +195,7 @@ func testSnapshotManagerAny(
 	createNewNodeFun createNewNodeFun,
 	snapshotsAvailableFun snapshotsAvailableFun,
 	numberBeforeLast int,
+	deltasPerFull int,
 ) {
 	log := testlogger.NewLogger(t)
 	defer log.Sync()
@@ -176,6 +217,7 @@ func testSnapshotManagerAny(
 		nil,
 		uint32(snapshotCreatePeriod),
 		uint32(snapshotDelayPeriod),
+		uint32(deltasPerFull),
 		localSnapshotsCreatePathConst,
 		[]string{},
 		storeOrig,
@@ -185,21 +227,38 @@ func testSnapshotManagerAny(
 	require.NoError(t, err)
 	require.Equal(t, uint32(0), snapshotManagerOrig.GetLoadedSnapshotStateIndex())
 
-	// "Running" node, making snapshots
+	// "Running" node, making snapshots; each snapshot is waited for, because
+	// it might be the base of the next (delta) snapshot
 	for _, block := range blocks {
 		snapshotManagerOrig.BlockCommittedAsync(NewSnapshotInfo(block.StateIndex(), block.L1Commitment()))
-	}
-	for i := snapshotCreatePeriod - 1; i < numberOfBlocks-snapshotDelayPeriod; i += snapshotCreatePeriod {
-		require.True(t, waitForBlock(t, factory.GetChainID(), blocks[i], 10, 50*time.Millisecond))
+		snapshotIndex := int(block.StateIndex()) - snapshotDelayPeriod
+		if snapshotIndex > 0 && snapshotIndex%snapshotCreatePeriod == 0 {
+			require.True(t, waitForBlock(t, factory.GetChainID(), blocks[snapshotIndex-1], 10, 50*time.Millisecond))
+		}
 	}
 	createdSnapshots := make([]SnapshotInfo, 0)
+	var lastSnapshot SnapshotInfo
+	deltasSinceFull := 0
 	for _, block := range blocks {
-		exists := snapshotExists(t, factory.GetChainID(), block.StateIndex(), block.L1Commitment())
+		fullExists := snapshotFileExists(t, factory.GetChainID(), snapshotFileName(block.StateIndex(), block.Hash()))
+		deltaExists := snapshotFileExists(t, factory.GetChainID(), deltaSnapshotFileName(block.StateIndex(), block.Hash()))
 		if block.StateIndex()%uint32(snapshotCreatePeriod) == 0 && block.StateIndex() <= uint32(numberOfBlocks-snapshotDelayPeriod) {
-			require.True(t, exists)
-			createdSnapshots = append(createdSnapshots, NewSnapshotInfo(block.StateIndex(), block.L1Commitment()))
+			snapshotInfo := NewSnapshotInfo(block.StateIndex(), block.L1Commitment())
+			if lastSnapshot == nil || deltasSinceFull >= deltasPerFull {
+				require.True(t, fullExists)
+				require.False(t, deltaExists)
+				deltasSinceFull = 0
+			} else {
+				require.False(t, fullExists)
+				require.True(t, deltaExists)
+				snapshotInfo = newDeltaSnapshotInfo(snapshotInfo, lastSnapshot)
+				deltasSinceFull++
+			}
+			createdSnapshots = append(createdSnapshots, snapshotInfo)
+			lastSnapshot = snapshotInfo
 		} else {
-			require.False(t, exists)
+			require.False(t, fullExists)
+			require.False(t, deltaExists)
 		}
 	}
 	snapshotsAvailableFun(factory.GetChainID(), createdSnapshots)
@@ -216,9 +275,18 @@ func testSnapshotManagerAny(
 	snapshotManagerNew := createNewNodeFun(factory.GetChainID(), snapshotToLoad, storeNew, log)
 	require.Equal(t, uint32(snapshotToLoadStateIndex), snapshotManagerNew.GetLoadedSnapshotStateIndex())
 
-	// Check the loaded snapshot
+	// Check the loaded snapshot; if it is a delta snapshot, all its bases are loaded as well
+	loadedStateIndexes := make(map[uint32]struct{})
+	for _, snapshotInfo := range createdSnapshots {
+		if snapshotInfo.StateIndex() == uint32(snapshotToLoadStateIndex) {
+			for ; snapshotInfo != nil; snapshotInfo = baseSnapshotInfo(snapshotInfo) {
+				loadedStateIndexes[snapshotInfo.StateIndex()] = struct{}{}
+			}
+		}
+	}
+	require.NotEmpty(t, loadedStateIndexes)
 	for i := 0; i < len(blocks); i++ {
-		if i == snapshotToLoadStateIndex-1 {
+		if _, ok := loadedStateIndexes[blocks[i].StateIndex()]; ok {
 			require.True(t, storeNew.HasTrieRoot(blocks[i].TrieRoot()))
 			sm_gpa_utils.CheckBlockInStore(t, storeNew, blocks[i])
 			sm_gpa_utils.CheckStateInStores(t, storeOrig, storeNew, blocks[i].L1Commitment())
@@ -229,13 +297,25 @@ func testSnapshotManagerAny(
 }
 
 func snapshotExists(t *testing.T, chainID isc.ChainID, stateIndex uint32, commitment *state.L1Commitment) bool {
-	path := filepath.Join(localSnapshotsCreatePathConst, chainID.String(), snapshotFileName(stateIndex, commitment.BlockHash()))
+	return snapshotFileExists(t, chainID, snapshotFileName(stateIndex, commitment.BlockHash())) ||
+		snapshotFileExists(t, chainID, deltaSnapshotFileName(stateIndex, commitment.BlockHash()))
+}
+
+func snapshotFileExists(t *testing.T, chainID isc.ChainID, fileName string) bool {
+	path := filepath.Join(localSnapshotsCreatePathConst, chainID.String(), fileName)
 	exists, isDir, err := ioutils.PathExists(path)
 	require.False(t, isDir)
 	require.NoError(t, err)
 	return exists
 }
 
+func snapshotInfoFileName(snapshotInfo SnapshotInfo) string {
+	if baseSnapshotInfo(snapshotInfo) == nil {
+		return snapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+	}
+	return deltaSnapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+}
+
 func waitForBlock(t *testing.T, chainID isc.ChainID, block state.Block, maxIterations int, sleep time.Duration) bool {
 	updateAndWaitFun := func() {
 		time.Sleep(sleep)
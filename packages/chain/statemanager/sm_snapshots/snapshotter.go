@@ -21,35 +21,49 @@ func newSnapshotter(store state.Store) snapshotter {
 	return &snapshotterImpl{store: store}
 }
 
+// If `snapshotInfo` describes a delta snapshot, only the trie nodes, which are
+// not present in the state of the base snapshot, are stored.
 func (sn *snapshotterImpl) storeSnapshot(snapshotInfo SnapshotInfo, w io.Writer) error {
-	indexArray := make([]byte, 4) // Size of block index, which is of type uint32: 4 bytes
-	binary.LittleEndian.PutUint32(indexArray, snapshotInfo.StateIndex())
-	err := writeBytes(indexArray, w)
+	err := writeSnapshotInfo(snapshotInfo, w)
 	if err != nil {
-		return fmt.Errorf("failed writing block index %v: %w", snapshotInfo.StateIndex(), err)
+		return err
 	}
 
-	trieRootBytes := snapshotInfo.Commitment().Bytes()
-	err = writeBytes(trieRootBytes, w)
-	if err != nil {
-		return fmt.Errorf("failed writing L1 commitment %s: %w", snapshotInfo.Commitment(), err)
+	base := baseSnapshotInfo(snapshotInfo)
+	if base == nil {
+		err = sn.store.TakeSnapshot(snapshotInfo.TrieRoot(), w)
+	} else {
+		err = writeSnapshotInfo(base, w)
+		if err != nil {
+			return fmt.Errorf("failed writing base snapshot info: %w", err)
+		}
+		err = sn.store.TakeDeltaSnapshot(base.TrieRoot(), snapshotInfo.TrieRoot(), w)
 	}
-
-	err = sn.store.TakeSnapshot(snapshotInfo.TrieRoot(), w)
 	if err != nil {
 		return fmt.Errorf("failed to store snapshot: %w", err)
 	}
 	return nil
 }
 
+// If `snapshotInfo` describes a delta snapshot, its base snapshot must already
+// be loaded to the store.
 func (sn *snapshotterImpl) loadSnapshot(snapshotInfo SnapshotInfo, r io.Reader) error {
-	readSnapshotInfo, err := readSnapshotInfo(r)
+	var readSnapshotInfoFun func(io.Reader) (SnapshotInfo, error)
+	if baseSnapshotInfo(snapshotInfo) == nil {
+		readSnapshotInfoFun = readSnapshotInfo
+	} else {
+		readSnapshotInfoFun = readDeltaSnapshotInfo
+	}
+	readSnapshotInfo, err := readSnapshotInfoFun(r)
 	if err != nil {
 		return fmt.Errorf("failed reading snapshot info: %w", err)
 	}
 	if !readSnapshotInfo.Equals(snapshotInfo) {
 		return fmt.Errorf("snapshot read %s is different than expected %v", readSnapshotInfo, snapshotInfo)
 	}
+	if base := baseSnapshotInfo(snapshotInfo); base != nil && !base.Equals(baseSnapshotInfo(readSnapshotInfo)) {
+		return fmt.Errorf("base snapshot read %s is different than expected %v", baseSnapshotInfo(readSnapshotInfo), base)
+	}
 	err = sn.store.RestoreSnapshot(readSnapshotInfo.TrieRoot(), r)
 	if err != nil {
 		return fmt.Errorf("failed restoring snapshot: %w", err)
@@ -57,6 +71,60 @@ func (sn *snapshotterImpl) loadSnapshot(snapshotInfo SnapshotInfo, r io.Reader)
 	return nil
 }
 
+// WriteFullSnapshot writes the full snapshot of the state, described by `snapshotInfo`,
+// in the same format as the snapshot files of the snapshot manager.
+func WriteFullSnapshot(store state.Store, snapshotInfo SnapshotInfo, w io.Writer) error {
+	return newSnapshotter(store).storeSnapshot(snapshotInfo, w)
+}
+
+// ReadFullSnapshot reads the full snapshot (as stored in the snapshot files of the
+// snapshot manager) and loads it to the store. The loaded state is not set as the
+// latest state of the store.
+func ReadFullSnapshot(store state.Store, r io.Reader) (SnapshotInfo, error) {
+	snapshotInfo, err := readSnapshotInfo(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading snapshot info: %w", err)
+	}
+	err = store.RestoreSnapshot(snapshotInfo.TrieRoot(), r)
+	if err != nil {
+		return nil, fmt.Errorf("failed restoring snapshot: %w", err)
+	}
+	return snapshotInfo, nil
+}
+
+func writeSnapshotInfo(snapshotInfo SnapshotInfo, w io.Writer) error {
+	indexArray := make([]byte, 4) // Size of block index, which is of type uint32: 4 bytes
+	binary.LittleEndian.PutUint32(indexArray, snapshotInfo.StateIndex())
+	err := writeBytes(indexArray, w)
+	if err != nil {
+		return fmt.Errorf("failed writing block index %v: %w", snapshotInfo.StateIndex(), err)
+	}
+
+	trieRootBytes := snapshotInfo.Commitment().Bytes()
+	err = writeBytes(trieRootBytes, w)
+	if err != nil {
+		return fmt.Errorf("failed writing L1 commitment %s: %w", snapshotInfo.Commitment(), err)
+	}
+	return nil
+}
+
+// Delta snapshot starts with the info of the snapshot followed by the info of
+// its base snapshot.
+func readDeltaSnapshotInfo(r io.Reader) (SnapshotInfo, error) {
+	snapshotInfo, err := readSnapshotInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	base, err := readSnapshotInfo(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base snapshot info: %w", err)
+	}
+	if base.StateIndex() >= snapshotInfo.StateIndex() {
+		return nil, fmt.Errorf("base snapshot %s is not older than delta snapshot %s", base, snapshotInfo)
+	}
+	return newDeltaSnapshotInfo(snapshotInfo, base), nil
+}
+
 func readSnapshotInfo(r io.Reader) (SnapshotInfo, error) {
 	indexArray, err := readBytes(r)
 	if err != nil {
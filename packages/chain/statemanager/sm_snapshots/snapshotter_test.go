@@ -1,6 +1,7 @@
 package sm_snapshots
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
@@ -46,3 +47,76 @@ func TestWriteReadDifferentStores(t *testing.T) {
 	sm_gpa_utils.CheckBlockInStore(t, store, lastBlock)
 	sm_gpa_utils.CheckStateInStores(t, factory.GetStore(), store, lastCommitment)
 }
+
+func TestWriteReadDeltaDifferentStores(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+
+	numberOfBlocks := 10
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(numberOfBlocks, 1)
+	baseBlock := blocks[numberOfBlocks/2-1]
+	lastBlock := blocks[numberOfBlocks-1]
+	baseInfo := NewSnapshotInfo(baseBlock.StateIndex(), baseBlock.L1Commitment())
+	deltaInfo := newDeltaSnapshotInfo(NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment()), baseInfo)
+	snapshotterOrig := newSnapshotter(factory.GetStore())
+	baseSnapshot := new(bytes.Buffer)
+	err := snapshotterOrig.storeSnapshot(baseInfo, baseSnapshot)
+	require.NoError(t, err)
+	deltaSnapshot := new(bytes.Buffer)
+	err = snapshotterOrig.storeSnapshot(deltaInfo, deltaSnapshot)
+	require.NoError(t, err)
+	fullSnapshot := new(bytes.Buffer)
+	err = snapshotterOrig.storeSnapshot(NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment()), fullSnapshot)
+	require.NoError(t, err)
+	require.Less(t, deltaSnapshot.Len(), fullSnapshot.Len())
+
+	store := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	snapshotterNew := newSnapshotter(store)
+	// Delta snapshot cannot be loaded without its base
+	err = snapshotterNew.loadSnapshot(deltaInfo, bytes.NewReader(deltaSnapshot.Bytes()))
+	require.Error(t, err)
+	require.False(t, store.HasTrieRoot(lastBlock.TrieRoot()))
+
+	err = snapshotterNew.loadSnapshot(baseInfo, baseSnapshot)
+	require.NoError(t, err)
+	err = snapshotterNew.loadSnapshot(deltaInfo, deltaSnapshot)
+	require.NoError(t, err)
+
+	sm_gpa_utils.CheckBlockInStore(t, store, baseBlock)
+	sm_gpa_utils.CheckStateInStores(t, factory.GetStore(), store, baseBlock.L1Commitment())
+	sm_gpa_utils.CheckBlockInStore(t, store, lastBlock)
+	sm_gpa_utils.CheckStateInStores(t, factory.GetStore(), store, lastBlock.L1Commitment())
+
+	// Pruning the base state must not affect the state of delta snapshot
+	_, err = store.Prune(baseBlock.TrieRoot())
+	require.NoError(t, err)
+	require.False(t, store.HasTrieRoot(baseBlock.TrieRoot()))
+	sm_gpa_utils.CheckStateInStores(t, factory.GetStore(), store, lastBlock.L1Commitment())
+}
+
+func TestWriteReadFullSnapshot(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+
+	numberOfBlocks := 10
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(numberOfBlocks, 1)
+	lastBlock := blocks[numberOfBlocks-1]
+	lastCommitment := lastBlock.L1Commitment()
+	snapshot := new(bytes.Buffer)
+	err := WriteFullSnapshot(factory.GetStore(), NewSnapshotInfo(lastBlock.StateIndex(), lastCommitment), snapshot)
+	require.NoError(t, err)
+
+	store := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	snapshotInfo, err := ReadFullSnapshot(store, snapshot)
+	require.NoError(t, err)
+	require.Equal(t, lastBlock.StateIndex(), snapshotInfo.StateIndex())
+	require.True(t, lastCommitment.Equals(snapshotInfo.Commitment()))
+
+	sm_gpa_utils.CheckBlockInStore(t, store, lastBlock)
+	sm_gpa_utils.CheckStateInStores(t, factory.GetStore(), store, lastCommitment)
+
+	_, err = ReadFullSnapshot(state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB()), bytes.NewReader([]byte{1, 2, 3}))
+	require.Error(t, err)
+}
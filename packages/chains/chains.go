@@ -60,6 +60,8 @@ type Chains struct {
 	walLoadToStore                      bool
 	walEnabled                          bool
 	walFolderPath                       string
+	walSegmentMaxSize                   int64
+	walRetentionStates                  uint32
 	smBlockCacheMaxSize                 int
 	smBlockCacheBlocksInCacheDuration   time.Duration
 	smBlockCacheBlockCleaningPeriod     time.Duration
@@ -70,10 +72,12 @@ type Chains struct {
 	smStateManagerTimerTickPeriod       time.Duration
 	smPruningMinStatesToKeep            int
 	smPruningMaxStatesToDelete          int
+	smPruningMinStatesAge               time.Duration
 	defaultSnapshotToLoad               *state.BlockHash
 	snapshotsToLoad                     map[isc.ChainIDKey]state.BlockHash
 	snapshotPeriod                      uint32
 	snapshotDelay                       uint32
+	snapshotDeltasPerFull               uint32
 	snapshotFolderPath                  string
 	snapshotNetworkPaths                []string
 
@@ -119,6 +123,8 @@ func New(
 	walLoadToStore bool,
 	walEnabled bool,
 	walFolderPath string,
+	walSegmentMaxSize int64,
+	walRetentionStates uint32,
 	smBlockCacheMaxSize int,
 	smBlockCacheBlocksInCacheDuration time.Duration,
 	smBlockCacheBlockCleaningPeriod time.Duration,
@@ -129,9 +135,11 @@ func New(
 	smStateManagerTimerTickPeriod time.Duration,
 	smPruningMinStatesToKeep int,
 	smPruningMaxStatesToDelete int,
+	smPruningMinStatesAge time.Duration,
 	snapshotsToLoad []string,
 	snapshotPeriod uint32,
 	snapshotDelay uint32,
+	snapshotDeltasPerFull uint32,
 	snapshotFolderPath string,
 	snapshotNetworkPaths []string,
 	chainRecordRegistryProvider registry.ChainRecordRegistryProvider,
@@ -171,6 +179,8 @@ func New(
 		walLoadToStore:                      walLoadToStore,
 		walEnabled:                          walEnabled,
 		walFolderPath:                       walFolderPath,
+		walSegmentMaxSize:                   walSegmentMaxSize,
+		walRetentionStates:                  walRetentionStates,
 		smBlockCacheMaxSize:                 smBlockCacheMaxSize,
 		smBlockCacheBlocksInCacheDuration:   smBlockCacheBlocksInCacheDuration,
 		smBlockCacheBlockCleaningPeriod:     smBlockCacheBlockCleaningPeriod,
@@ -181,8 +191,10 @@ func New(
 		smStateManagerTimerTickPeriod:       smStateManagerTimerTickPeriod,
 		smPruningMinStatesToKeep:            smPruningMinStatesToKeep,
 		smPruningMaxStatesToDelete:          smPruningMaxStatesToDelete,
+		smPruningMinStatesAge:               smPruningMinStatesAge,
 		snapshotPeriod:                      snapshotPeriod,
 		snapshotDelay:                       snapshotDelay,
+		snapshotDeltasPerFull:               snapshotDeltasPerFull,
 		snapshotFolderPath:                  snapshotFolderPath,
 		snapshotNetworkPaths:                snapshotNetworkPaths,
 		chainRecordRegistryProvider:         chainRecordRegistryProvider,
@@ -346,7 +358,10 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 	chainLog := c.log.Named(chainID.ShortString())
 	var chainWAL sm_gpa_utils.BlockWAL
 	if c.walEnabled {
-		chainWAL, err = sm_gpa_utils.NewBlockWAL(chainLog, c.walFolderPath, chainID, chainMetrics.BlockWAL)
+		walParameters := sm_gpa_utils.NewBlockWALParameters()
+		walParameters.SegmentMaxSize = c.walSegmentMaxSize
+		walParameters.RetentionStates = c.walRetentionStates
+		chainWAL, err = sm_gpa_utils.NewBlockWAL(chainLog, c.walFolderPath, chainID, chainMetrics.BlockWAL, walParameters)
 		if err != nil {
 			panic(fmt.Errorf("cannot create WAL: %w", err))
 		}
@@ -365,6 +380,7 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 	stateManagerParameters.StateManagerTimerTickPeriod = c.smStateManagerTimerTickPeriod
 	stateManagerParameters.PruningMinStatesToKeep = c.smPruningMinStatesToKeep
 	stateManagerParameters.PruningMaxStatesToDelete = c.smPruningMaxStatesToDelete
+	stateManagerParameters.PruningMinStatesAge = c.smPruningMinStatesAge
 
 	// Initialize Snapshotter
 	chainStore := indexedstore.New(state.NewStoreWithMetrics(chainKVStore, writeMutex, chainMetrics.State))
@@ -388,6 +404,7 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 		snapshotToLoad,
 		c.snapshotPeriod,
 		c.snapshotDelay,
+		c.snapshotDeltasPerFull,
 		c.snapshotFolderPath,
 		c.snapshotNetworkPaths,
 		chainStore,
@@ -0,0 +1,126 @@
+package chainutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+	"github.com/nnikolash/wasp-types-exported/packages/trie"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
+)
+
+var (
+	ErrInvalidPruneRange = errors.New("invalid prune range")
+	ErrArchiveChain      = errors.New("the chain keeps all the blocks")
+)
+
+type PruneResult struct {
+	// PrunedBlocks is the number of states deleted from the store
+	PrunedBlocks uint32
+	// OldestBlockIndex is the index of the oldest state still available in the store
+	OldestBlockIndex uint32
+	trie.PruneStats
+}
+
+// PruneStates deletes from the store the states of the blocks with indexes in
+// [fromIndex, toIndex]. As the store must not contain gaps of pruned states,
+// fromIndex must not be above the index of the oldest available state; the
+// states below fromIndex, if any, must already be pruned.
+// The latest state and the states the chain requires to keep (governance
+// block keep amount) are never pruned. An archive chain (the block keep amount
+// is governance.BlockKeepAll) is only pruned if force is set, and then all the
+// states but the latest one can be pruned.
+// onPruned, if not nil, is called after each pruned state to report progress.
+func PruneStates(
+	ctx context.Context,
+	store state.Store,
+	fromIndex uint32,
+	toIndex uint32,
+	force bool,
+	onPruned func(blockIndex uint32, stats trie.PruneStats),
+) (*PruneResult, error) {
+	if fromIndex > toIndex {
+		return nil, fmt.Errorf("%w: from block index %d is above to block index %d", ErrInvalidPruneRange, fromIndex, toIndex)
+	}
+	latestState, err := store.LatestState()
+	if err != nil {
+		return nil, err
+	}
+	latestIndex := latestState.BlockIndex()
+	keepAmount := governance.NewStateAccess(latestState).GetBlockKeepAmount()
+	if keepAmount == governance.BlockKeepAll && !force {
+		return nil, fmt.Errorf("%w, pruning it requires to force it", ErrArchiveChain)
+	}
+	if keepAmount < 1 {
+		keepAmount = 1
+	}
+	if latestIndex < uint32(keepAmount) || toIndex > latestIndex-uint32(keepAmount) {
+		return nil, fmt.Errorf("%w: latest block index is %d and the chain requires to keep %d blocks",
+			ErrInvalidPruneRange, latestIndex, keepAmount)
+	}
+
+	// walk back from the latest state to the oldest available one
+	trieRoots := make([]trie.Hash, 0)
+	blockIndex := latestIndex
+	trieRoot := latestState.TrieRoot()
+	for {
+		if blockIndex <= toIndex {
+			trieRoots = append(trieRoots, trieRoot)
+		}
+		block, err := store.BlockByTrieRoot(trieRoot)
+		if err != nil {
+			return nil, err
+		}
+		prev := block.PreviousL1Commitment()
+		if prev == nil || !store.HasTrieRoot(prev.TrieRoot()) {
+			break
+		}
+		trieRoot = prev.TrieRoot()
+		blockIndex--
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if fromIndex > blockIndex {
+		return nil, fmt.Errorf("%w: oldest available block index is %d, pruning from %d would leave a gap",
+			ErrInvalidPruneRange, blockIndex, fromIndex)
+	}
+
+	result := &PruneResult{OldestBlockIndex: blockIndex}
+	for i := len(trieRoots) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		stats, pruned, err := pruneState(store, trieRoots[i])
+		if err != nil {
+			return result, err
+		}
+		if pruned {
+			result.PrunedBlocks++
+			result.DeletedNodes += stats.DeletedNodes
+			result.DeletedValues += stats.DeletedValues
+			if onPruned != nil {
+				onPruned(result.OldestBlockIndex, stats)
+			}
+		}
+		result.OldestBlockIndex++
+	}
+	return result, nil
+}
+
+// pruneState prunes the state, unless it was already pruned, which is not an
+// error: the state manager might prune it concurrently.
+func pruneState(store state.Store, trieRoot trie.Hash) (stats trie.PruneStats, pruned bool, err error) {
+	if !store.HasTrieRoot(trieRoot) {
+		return stats, false, nil
+	}
+	stats, err = store.Prune(trieRoot)
+	if err != nil {
+		if !store.HasTrieRoot(trieRoot) {
+			return stats, false, nil
+		}
+		return stats, false, err
+	}
+	return stats, true, nil
+}
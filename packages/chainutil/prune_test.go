@@ -0,0 +1,137 @@
+package chainutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/nnikolash/wasp-types-exported/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
+	"github.com/nnikolash/wasp-types-exported/packages/origin"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+	"github.com/nnikolash/wasp-types-exported/packages/trie"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
+)
+
+func TestPruneStates(t *testing.T) {
+	initParams := dict.New()
+	initParams.Set(origin.ParamBlockKeepAmount, codec.EncodeInt32(5))
+	bf := sm_gpa_utils.NewBlockFactory(t, initParams)
+	blocks := bf.GetBlocks(20, 1) // block indexes 1..20
+	store := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	origin.InitChain(0, store, initParams, 0)
+	for _, block := range blocks {
+		store.Commit(bf.GetStateDraft(block))
+	}
+	require.NoError(t, store.SetLatest(blocks[19].TrieRoot()))
+	ctx := context.Background()
+
+	// the chain requires to keep the last 5 blocks
+	_, err := PruneStates(ctx, store, 0, 16, false, nil)
+	require.ErrorIs(t, err, ErrInvalidPruneRange)
+	_, err = PruneStates(ctx, store, 5, 4, false, nil)
+	require.ErrorIs(t, err, ErrInvalidPruneRange)
+
+	var progress []uint32
+	result, err := PruneStates(ctx, store, 0, 9, false, func(blockIndex uint32, stats trie.PruneStats) {
+		progress = append(progress, blockIndex)
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 10, result.PrunedBlocks)
+	require.EqualValues(t, 10, result.OldestBlockIndex)
+	require.Positive(t, result.DeletedNodes)
+	require.Equal(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, progress)
+	for i := 0; i < 9; i++ {
+		require.False(t, store.HasTrieRoot(blocks[i].TrieRoot()))
+	}
+	for i := 9; i < 20; i++ {
+		require.True(t, store.HasTrieRoot(blocks[i].TrieRoot()))
+	}
+
+	// pruning above the oldest available state would leave a gap
+	_, err = PruneStates(ctx, store, 12, 15, false, nil)
+	require.ErrorIs(t, err, ErrInvalidPruneRange)
+
+	// already pruned states are skipped
+	result, err = PruneStates(ctx, store, 5, 15, false, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 6, result.PrunedBlocks)
+	require.EqualValues(t, 16, result.OldestBlockIndex)
+	for i := 0; i < 15; i++ {
+		require.False(t, store.HasTrieRoot(blocks[i].TrieRoot()))
+	}
+	for i := 15; i < 20; i++ {
+		require.True(t, store.HasTrieRoot(blocks[i].TrieRoot()))
+	}
+	latestState, err := store.LatestState()
+	require.NoError(t, err)
+	require.EqualValues(t, 20, latestState.BlockIndex())
+}
+
+func TestPruneStatesArchive(t *testing.T) {
+	initParams := dict.New()
+	initParams.Set(origin.ParamBlockKeepAmount, codec.EncodeInt32(governance.BlockKeepAll))
+	bf := sm_gpa_utils.NewBlockFactory(t, initParams)
+	blocks := bf.GetBlocks(5, 1) // block indexes 1..5
+	store := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	origin.InitChain(0, store, initParams, 0)
+	for _, block := range blocks {
+		store.Commit(bf.GetStateDraft(block))
+	}
+	require.NoError(t, store.SetLatest(blocks[4].TrieRoot()))
+	ctx := context.Background()
+
+	// the archive chain is not pruned unless forced
+	_, err := PruneStates(ctx, store, 0, 2, false, nil)
+	require.ErrorIs(t, err, ErrArchiveChain)
+	require.True(t, store.HasTrieRoot(blocks[0].TrieRoot()))
+
+	// the latest state is kept even if forced
+	_, err = PruneStates(ctx, store, 0, 5, true, nil)
+	require.ErrorIs(t, err, ErrInvalidPruneRange)
+
+	result, err := PruneStates(ctx, store, 0, 4, true, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, result.PrunedBlocks)
+	require.EqualValues(t, 5, result.OldestBlockIndex)
+	require.True(t, store.HasTrieRoot(blocks[4].TrieRoot()))
+}
+
+// concurrentlyPrunedStore simulates the state manager pruning the states
+// between the checks of PruneStates and its calls to Prune
+type concurrentlyPrunedStore struct {
+	state.Store
+}
+
+func (s *concurrentlyPrunedStore) Prune(trieRoot trie.Hash) (trie.PruneStats, error) {
+	if _, err := s.Store.Prune(trieRoot); err != nil {
+		return trie.PruneStats{}, err
+	}
+	return s.Store.Prune(trieRoot)
+}
+
+func TestPruneStatesConcurrently(t *testing.T) {
+	initParams := dict.New()
+	initParams.Set(origin.ParamBlockKeepAmount, codec.EncodeInt32(5))
+	bf := sm_gpa_utils.NewBlockFactory(t, initParams)
+	blocks := bf.GetBlocks(10, 1) // block indexes 1..10
+	store := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	origin.InitChain(0, store, initParams, 0)
+	for _, block := range blocks {
+		store.Commit(bf.GetStateDraft(block))
+	}
+	require.NoError(t, store.SetLatest(blocks[9].TrieRoot()))
+
+	// the states already pruned by the state manager are skipped
+	result, err := PruneStates(context.Background(), &concurrentlyPrunedStore{store}, 0, 4, false, nil)
+	require.NoError(t, err)
+	require.Zero(t, result.PrunedBlocks)
+	require.EqualValues(t, 5, result.OldestBlockIndex)
+	for i := 0; i < 4; i++ {
+		require.False(t, store.HasTrieRoot(blocks[i].TrieRoot()))
+	}
+	require.True(t, store.HasTrieRoot(blocks[4].TrieRoot()))
+}
@@ -32,7 +32,8 @@ const (
 	//
 	// NOTE: initiatorInitMsgType must be unique across all the uses of peering package,
 	// because it is used to start new chain, thus peeringID is not used for message recognition.
-	initiatorInitMsgType = peering.FirstUserMsgCode + 184 // Initiator -> Peer: init new DKG, reply with initiatorStatusMsgType.
+	initiatorInitMsgType        = peering.FirstUserMsgCode + 184 // Initiator -> Peer: init new DKG, reply with initiatorStatusMsgType.
+	initiatorReshareInitMsgType = peering.FirstUserMsgCode + 185 // Initiator -> Peer: init new resharing, reply with initiatorReshareKeyMsgType.
 	//
 	// Initiator <-> Peer proc communication.
 	initiatorMsgBase         = peering.FirstUserMsgCode + 4 // 4 to align with round numbers.
@@ -65,6 +66,16 @@ const (
 	// NOTE: There is not enough bits to encode KeySetType and Echo flags as bits.
 	rabinKeySetTypeFrom = rabinEchoTill
 	rabinKeySetTypeTill = rabinKeySetTypeFrom + (rabinEchoTill - rabinMsgFrom)
+	//
+	// Initiator <-> Peer and Peer <-> Peer communication for the resharing procedure.
+	reshareMsgFrom                   = rabinKeySetTypeTill
+	initiatorReshareKeyMsgType       = reshareMsgFrom + 0 // Peer -> Initiator; transport key of the peer, response to initiatorReshareInitMsgType.
+	initiatorReshareStartMsgType     = reshareMsgFrom + 1 // Initiator -> Peer: start the resharing, reply with initiatorStatusMsgType.
+	initiatorReshareProposalMsgType  = reshareMsgFrom + 2 // Peer -> Initiator; dealers usable for the new shares, response to initiatorStepMsgType.
+	initiatorReshareAgreementMsgType = reshareMsgFrom + 3 // Initiator -> Peer: dealers to use, reply with initiatorPubShareMsgType or initiatorStatusMsgType.
+	reshareEdMsgType                 = reshareMsgFrom + 4 // Peer <-> Peer: messages of the Ed25519 key set resharing.
+	reshareBLSMsgType                = reshareMsgFrom + 5 // Peer <-> Peer: messages of the BLS key set resharing.
+	reshareMsgTill                   = reshareMsgFrom + 6 // Just a placeholder for first unallocated message type.
 )
 
 type keySetType byte
@@ -154,6 +165,16 @@ func readInitiatorMsg(peerMessage *peering.PeerMessageData, edSuite, blsSuite ky
 		msg = &initiatorPubShareMsg{edSuite: edSuite, blsSuite: blsSuite}
 	case initiatorStatusMsgType:
 		msg = new(initiatorStatusMsg)
+	case initiatorReshareInitMsgType:
+		msg = &initiatorReshareInitMsg{edSuite: edSuite, blsSuite: blsSuite}
+	case initiatorReshareKeyMsgType:
+		msg = &initiatorReshareKeyMsg{blsSuite: blsSuite}
+	case initiatorReshareStartMsgType:
+		msg = &initiatorReshareStartMsg{blsSuite: blsSuite}
+	case initiatorReshareProposalMsgType:
+		msg = new(initiatorReshareProposalMsg)
+	case initiatorReshareAgreementMsgType:
+		msg = new(initiatorReshareAgreementMsg)
 	default:
 		return nil, nil
 	}
@@ -30,17 +30,19 @@ type NodeProvider func() *Node
 // It receives commands from the initiator as a dkg.NodeProvider,
 // and communicates with other DKG nodes via the peering network.
 type Node struct {
-	identity                *cryptolib.KeyPair                        // Keys of the current node.
-	secKey                  kyber.Scalar                              // Derived from the identity.
-	pubKey                  kyber.Point                               // Derived from the identity.
-	blsSuite                Suite                                     // Cryptography to use for the Pairing based operations.
-	edSuite                 suites.Suite                              // Cryptography to use for the Ed25519 based operations.
-	netProvider             peering.NetworkProvider                   // Network to communicate through.
-	dkShareRegistryProvider registry.DKShareRegistryProvider          // Where to store the generated keys.
-	processes               *shrinkingmap.ShrinkingMap[string, *proc] // Only for introspection.
-	procLock                *sync.RWMutex                             // To guard access to the process pool.
-	initMsgQueue            chan *initiatorInitMsgIn                  // Incoming events processed async.
-	cleanupFunc             context.CancelFunc                        // Peering cleanup func
+	identity                *cryptolib.KeyPair                               // Keys of the current node.
+	secKey                  kyber.Scalar                                     // Derived from the identity.
+	pubKey                  kyber.Point                                      // Derived from the identity.
+	blsSuite                Suite                                            // Cryptography to use for the Pairing based operations.
+	edSuite                 suites.Suite                                     // Cryptography to use for the Ed25519 based operations.
+	netProvider             peering.NetworkProvider                          // Network to communicate through.
+	dkShareRegistryProvider registry.DKShareRegistryProvider                 // Where to store the generated keys.
+	processes               *shrinkingmap.ShrinkingMap[string, *proc]        // Only for introspection.
+	reshareProcesses        *shrinkingmap.ShrinkingMap[string, *reshareProc] // Only for introspection.
+	procLock                *sync.RWMutex                                    // To guard access to the process pool.
+	initMsgQueue            chan *initiatorInitMsgIn                         // Incoming events processed async.
+	reshareInitMsgQueue     chan *initiatorReshareInitMsgIn                  // Incoming events processed async.
+	cleanupFunc             context.CancelFunc                               // Peering cleanup func
 	log                     *logger.Logger
 }
 
@@ -65,13 +67,16 @@ func NewNode(
 		netProvider:             netProvider,
 		dkShareRegistryProvider: dkShareRegistryProvider,
 		processes:               shrinkingmap.New[string, *proc](),
+		reshareProcesses:        shrinkingmap.New[string, *reshareProc](),
 		procLock:                &sync.RWMutex{},
 		initMsgQueue:            make(chan *initiatorInitMsgIn),
+		reshareInitMsgQueue:     make(chan *initiatorReshareInitMsgIn),
 		log:                     log,
 	}
 	unhook := netProvider.Attach(&initPeeringID, peering.ReceiverDkgInit, n.receiveInitMessage)
 	n.cleanupFunc = unhook
 	go n.recvLoop()
+	go n.recvReshareLoop()
 	return &n, nil
 }
 
@@ -80,22 +85,35 @@ func (n *Node) receiveInitMessage(peerMsg *peering.PeerMessageIn) {
 		panic(fmt.Errorf("DKG init handler does not accept peer messages of other receiver type %v, message type=%v",
 			peerMsg.MsgReceiver, peerMsg.MsgType))
 	}
-	if peerMsg.MsgType != initiatorInitMsgType {
+	switch peerMsg.MsgType {
+	case initiatorInitMsgType:
+		msg := &initiatorInitMsg{}
+		if err := msgFromBytes(peerMsg.MsgData, msg); err != nil {
+			n.log.Warnf("Dropping unknown message: %v", peerMsg)
+			return
+		}
+		n.initMsgQueue <- &initiatorInitMsgIn{
+			initiatorInitMsg: *msg,
+			SenderPubKey:     peerMsg.SenderPubKey,
+		}
+	case initiatorReshareInitMsgType:
+		msg := &initiatorReshareInitMsg{edSuite: n.edSuite, blsSuite: n.blsSuite}
+		if err := msgFromBytes(peerMsg.MsgData, msg); err != nil {
+			n.log.Warnf("Dropping unknown message: %v", peerMsg)
+			return
+		}
+		n.reshareInitMsgQueue <- &initiatorReshareInitMsgIn{
+			initiatorReshareInitMsg: *msg,
+			SenderPubKey:            peerMsg.SenderPubKey,
+		}
+	default:
 		panic(fmt.Errorf("wrong type of DKG init message: %v", peerMsg.MsgType))
 	}
-	msg := &initiatorInitMsg{}
-	if err := msgFromBytes(peerMsg.MsgData, msg); err != nil {
-		n.log.Warnf("Dropping unknown message: %v", peerMsg)
-		return
-	}
-	n.initMsgQueue <- &initiatorInitMsgIn{
-		initiatorInitMsg: *msg,
-		SenderPubKey:     peerMsg.SenderPubKey,
-	}
 }
 
 func (n *Node) Close() {
 	close(n.initMsgQueue)
+	close(n.reshareInitMsgQueue)
 	util.ExecuteIfNotNil(n.cleanupFunc)
 }
 
@@ -336,6 +354,58 @@ func (n *Node) dropProcess(p *proc) bool {
 	return n.processes.Delete(p.dkgRef)
 }
 
+// Async recv is needed to avoid locking on the even publisher (Recv vs Attach in reshareProc).
+func (n *Node) recvReshareLoop() {
+	for recv := range n.reshareInitMsgQueue {
+		n.onReshareInitMsg(recv)
+	}
+}
+
+// onReshareInitMsg is a callback to handle the resharing initialization messages.
+// The response carries the transport key of this node, thus it is
+// sent for the duplicate messages as well.
+func (n *Node) onReshareInitMsg(msg *initiatorReshareInitMsgIn) {
+	n.procLock.RLock()
+	if p, ok := n.reshareProcesses.Get(msg.dkgRef); ok {
+		n.procLock.RUnlock()
+		n.netProvider.SendMsgByPubKey(msg.SenderPubKey, makePeerMessage(msg.peeringID, peering.ReceiverDkg, msg.step, &initiatorReshareKeyMsg{
+			blsTransportPub: p.blsTransport.Public,
+		}))
+		return
+	}
+	n.procLock.RUnlock()
+	go func() {
+		// This part should be executed async, because it accesses the network again, and can
+		// be locked because of the naive implementation of `event.Event`. It locks on all the callbacks.
+		n.procLock.Lock()
+		p, ok := n.reshareProcesses.Get(msg.dkgRef)
+		var err error
+		if !ok { // Check again, the retried messages could be handled concurrently.
+			if p, err = onInitiatorReshareInit(msg.peeringID, &msg.initiatorReshareInitMsg, n); err == nil {
+				n.reshareProcesses.Set(p.dkgRef, p)
+			}
+		}
+		n.procLock.Unlock()
+		if err != nil {
+			n.netProvider.SendMsgByPubKey(msg.SenderPubKey, makePeerMessage(msg.peeringID, peering.ReceiverDkg, msg.step, &initiatorStatusMsg{
+				error: err,
+			}))
+			return
+		}
+		n.netProvider.SendMsgByPubKey(msg.SenderPubKey, makePeerMessage(msg.peeringID, peering.ReceiverDkg, msg.step, &initiatorReshareKeyMsg{
+			blsTransportPub: p.blsTransport.Public,
+		}))
+	}()
+}
+
+// Called by the resharing process on termination.
+func (n *Node) dropReshareProcess(p *reshareProc) bool {
+	n.procLock.Lock()
+	defer n.procLock.Unlock()
+
+	return n.reshareProcesses.Delete(p.dkgRef)
+}
+
 func (n *Node) exchangeInitiatorStep(
 	netGroup peering.GroupProvider,
 	peers map[uint16]peering.PeerSender,
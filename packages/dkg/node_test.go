@@ -12,8 +12,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/share"
 
 	"github.com/iotaledger/hive.go/logger"
+	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
 	"github.com/nnikolash/wasp-types-exported/packages/dkg"
 	"github.com/nnikolash/wasp-types-exported/packages/registry"
 	"github.com/nnikolash/wasp-types-exported/packages/tcrypto"
@@ -190,3 +192,91 @@ func TestLowN(t *testing.T) {
 		require.NotNil(t, dkShare.GetSharedPublic())
 	}
 }
+
+// TestReshare checks, if the key can be handed over to another committee and refreshed, keeping the address.
+func TestReshare(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	//
+	// Create a fake network and keys for the tests.
+	timeout := 100 * time.Second
+	var peerCount uint16 = 6
+	peeringURLs, peerIdentities := testpeers.SetupKeys(peerCount)
+	peeringNetwork := testutil.NewPeeringNetwork(
+		peeringURLs, peerIdentities, 10000,
+		testutil.NewPeeringNetReliable(log),
+		testlogger.WithLevel(log, logger.LevelWarn, false),
+	)
+	networkProviders := peeringNetwork.NetworkProviders()
+	//
+	// Initialize the DKG subsystem in each node.
+	dkgNodes := make([]*dkg.Node, len(peeringURLs))
+	dkShareRegistryProviders := make([]registry.DKShareRegistryProvider, len(peeringURLs))
+	for i := range peeringURLs {
+		dkShareRegistryProviders[i] = testutil.NewDkgRegistryProvider(peerIdentities[i].GetPrivateKey())
+		dkgNode, err := dkg.NewNode(
+			peerIdentities[i], networkProviders[i], dkShareRegistryProviders[i],
+			testlogger.WithLevel(log.With("PeeringURL", peeringURLs[i]), logger.LevelWarn, false),
+		)
+		require.NoError(t, err)
+		dkgNodes[i] = dkgNode
+	}
+	allPubKeys := testpeers.PublicKeys(peerIdentities)
+	//
+	// Generate the key for the nodes 0..3.
+	oldDKShare, err := dkgNodes[0].GenerateDistributedKey(allPubKeys[:4], 3, 1*time.Second, 2*time.Second, timeout)
+	require.NoError(t, err)
+	//
+	// Hand it over to the nodes 5, 3, 2, 4 (the node 0 is the initiator, it has the old share).
+	newPubKeys := []*cryptolib.PublicKey{allPubKeys[5], allPubKeys[3], allPubKeys[2], allPubKeys[4]}
+	newDKShare, err := dkgNodes[0].ReshareDistributedKey(oldDKShare.GetAddress(), newPubKeys, 3, 1*time.Second, 2*time.Second, timeout)
+	require.NoError(t, err)
+	require.True(t, oldDKShare.GetAddress().Equal(newDKShare.GetAddress()))
+	require.True(t, oldDKShare.GetSharedPublic().Equals(newDKShare.GetSharedPublic()))
+	for i := range []int{0, 1} {
+		_, err2 := dkShareRegistryProviders[i].LoadDKShare(oldDKShare.GetAddress())
+		require.Error(t, err2) // Old shares are deleted.
+	}
+	requireReshared := func(pubKeys []*cryptolib.PublicKey, nodeIndexes []int, threshold uint16) {
+		dataToSign := []byte{112, 117, 116, 105, 110, 32, 99, 104, 117, 105, 108, 111, 33}
+		blsPartSigs := make([][]byte, len(nodeIndexes))
+		edPriShares := make([]*share.PriShare, len(nodeIndexes))
+		var aggrDks tcrypto.DKShare
+		for i, nodeIndex := range nodeIndexes {
+			dks, err2 := dkShareRegistryProviders[nodeIndex].LoadDKShare(oldDKShare.GetAddress())
+			require.NoError(t, err2)
+			require.Equal(t, uint16(i), *dks.GetIndex())
+			require.Equal(t, len(pubKeys), len(dks.GetNodePubKeys()))
+			require.True(t, oldDKShare.GetSharedPublic().Equals(dks.GetSharedPublic()))
+			require.True(t, oldDKShare.BLSSharedPublic().Equal(dks.BLSSharedPublic()))
+			require.True(t, dks.DSSPublicShares()[i].Equal(tcrypto.DefaultEd25519Suite().Point().Mul(dks.DSS().PriShare().V, nil)))
+			require.Equal(t, threshold, dks.GetT())
+			edPriShares[i] = dks.DSS().PriShare()
+			if i == 0 {
+				aggrDks = dks
+			}
+			blsPartSigs[i], err2 = dks.BLSSignShare(dataToSign)
+			require.NoError(t, err2)
+		}
+		blsAggrSig, err2 := aggrDks.BLSRecoverMasterSignature(blsPartSigs, dataToSign)
+		require.NoError(t, err2)
+		require.NoError(t, aggrDks.BLSVerifyMasterSignature(dataToSign, blsAggrSig.Signature[:]))
+		//
+		// The threshold is kept: T shares recover the key, T-1 shares don't.
+		suite := tcrypto.DefaultEd25519Suite()
+		secret, err2 := share.RecoverSecret(suite, edPriShares[:threshold], int(threshold), len(nodeIndexes))
+		require.NoError(t, err2)
+		require.True(t, oldDKShare.DSSSharedPublic().Equal(suite.Point().Mul(secret, nil)))
+		if threshold > 1 {
+			secret, err2 = share.RecoverSecret(suite, edPriShares[:threshold-1], int(threshold-1), len(nodeIndexes))
+			require.NoError(t, err2)
+			require.False(t, oldDKShare.DSSSharedPublic().Equal(suite.Point().Mul(secret, nil)))
+		}
+	}
+	requireReshared(newPubKeys, []int{5, 3, 2, 4}, 3)
+	//
+	// Refresh the shares within the same committee, initiated by a node of the new committee.
+	_, err = dkgNodes[3].ReshareDistributedKey(oldDKShare.GetAddress(), newPubKeys, 4, 1*time.Second, 2*time.Second, timeout)
+	require.NoError(t, err)
+	requireReshared(newPubKeys, []int{5, 3, 2, 4}, 4)
+}
@@ -0,0 +1,234 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package dkg
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.dedis.ch/kyber/v3"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
+	"github.com/nnikolash/wasp-types-exported/packages/peering"
+	"github.com/nnikolash/wasp-types-exported/packages/tcrypto"
+	"github.com/nnikolash/wasp-types-exported/packages/util"
+	"github.com/nnikolash/wasp-types-exported/packages/util/byz_quorum"
+)
+
+// ReshareDistributedKey hands the existing distributed key over to a new committee.
+// The shared public key, and therefore the shared address, remain the same, thus
+// a chain using the key keeps its state controller address. The new committee can
+// intersect with the old one or even be the same, in which case the shares are just
+// refreshed. The nodes of the old committee delete their old shares on completion.
+//
+// This function is executed on the initiator node, that has to be a member of the
+// old committee, because the public information of the old key is taken from its
+// DKShare registry.
+//
+// NOTE: The resharing can only produce the secret sharings with the threshold up to
+// F+1 of all the participating nodes, so the reshared key is recoverable from
+// min(T, F+1) shares. The threshold T is still required to produce the signatures.
+//
+//nolint:funlen,gocyclo
+func (n *Node) ReshareDistributedKey(
+	sharedAddress iotago.Address,
+	peerPubs []*cryptolib.PublicKey,
+	threshold uint16,
+	roundRetry time.Duration, // Retry for Peer <-> Peer communication.
+	stepRetry time.Duration, // Retry for Initiator -> Peer communication.
+	timeout time.Duration, // Timeout for the entire procedure.
+) (tcrypto.DKShare, error) {
+	n.log.Infof("Starting new DKG Reshare procedure, initiator=%v, sharedAddress=%v, peers=%+v", n.netProvider.Self().PeeringURL(), sharedAddress, peerPubs)
+	var err error
+	peerCount := uint16(len(peerPubs))
+	//
+	// Some validation for the parameters.
+	if peerCount < 1 || threshold < 1 || threshold > peerCount {
+		return nil, invalidParams(fmt.Errorf("wrong DKG parameters: N = %d, T = %d", peerCount, threshold))
+	}
+	if threshold < uint16(byz_quorum.MinQuorum(int(peerCount))) {
+		return nil, invalidParams(fmt.Errorf("wrong DKG parameters: for N = %d value T must be at least %d", peerCount, peerCount/2+1))
+	}
+	oldDKShare, err := n.dkShareRegistryProvider.LoadDKShare(sharedAddress)
+	if err != nil {
+		return nil, invalidParams(fmt.Errorf("the initiator has no DKShare for %v: %w", sharedAddress, err))
+	}
+	//
+	// Setup network connections.
+	dkgID := peering.RandomPeeringID()
+	nodePubs := reshareNodePubs(peerPubs, oldDKShare.GetNodePubKeys())
+	var netGroup peering.GroupProvider
+	if netGroup, err = n.netProvider.PeerGroup(dkgID, nodePubs); err != nil {
+		return nil, err
+	}
+	defer netGroup.Close()
+	recvCh := make(chan *peering.PeerMessageIn, len(nodePubs)*2)
+	unhook := n.netProvider.Attach(&dkgID, peering.ReceiverDkg, func(recv *peering.PeerMessageIn) {
+		recvCh <- recv
+	})
+	defer util.ExecuteIfNotNil(unhook)
+	rTimeout := stepRetry
+	gTimeout := timeout
+	//
+	// Initialize the peers and collect their transport keys.
+	blsTransportPubs := make([]kyber.Point, len(nodePubs))
+	if err = n.exchangeInitiatorMsgs(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, reshareStep0Initialize,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends reshare step=%v command to %v", reshareStep0Initialize, peer.PeeringURL())
+			peer.SendMsg(makePeerMessage(initPeeringID, peering.ReceiverDkgInit, reshareStep0Initialize, &initiatorReshareInitMsg{
+				dkgRef:          dkgID.String(), // It could be some other identifier.
+				peeringID:       dkgID,
+				newPeerPubs:     peerPubs,
+				oldPeerPubs:     oldDKShare.GetNodePubKeys(),
+				initiatorPub:    n.identity.GetPublicKey(),
+				threshold:       threshold,
+				oldThreshold:    oldDKShare.GetT(),
+				oldBLSThreshold: oldDKShare.BLSThreshold(),
+				edSharedPublic:  oldDKShare.DSSSharedPublic(),
+				edPublicShares:  oldDKShare.DSSPublicShares(),
+				blsSharedPublic: oldDKShare.BLSSharedPublic(),
+				blsPublicShares: oldDKShare.BLSPublicShares(),
+				timeout:         timeout,
+				roundRetry:      roundRetry,
+			}))
+		},
+		func(recv *peering.PeerMessageGroupIn, initMsg initiatorMsg) (bool, error) {
+			switch msg := initMsg.(type) {
+			case *initiatorReshareKeyMsg:
+				blsTransportPubs[recv.SenderIndex] = msg.blsTransportPub
+				return true, nil
+			default:
+				n.log.Errorf("msgType != initiatorReshareKeyMsg: %v", msg)
+				return false, errors.New("msgType != initiatorReshareKeyMsg")
+			}
+		},
+	); err != nil {
+		return nil, err
+	}
+	//
+	// Start the resharing at all the peers.
+	if err = n.exchangeInitiatorAcks(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, reshareStep1Start,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends reshare step=%v command to %v", reshareStep1Start, peer.PeeringURL())
+			peer.SendMsg(makePeerMessage(dkgID, peering.ReceiverDkg, reshareStep1Start, &initiatorReshareStartMsg{
+				blsTransportPubs: blsTransportPubs,
+			}))
+		},
+	); err != nil {
+		return nil, err
+	}
+	//
+	// Wait for the peers to receive enough of the sub-shares and decide, which
+	// of the old committee members to use. Any proposal is good, because the
+	// sub-shares received by a single peer will eventually be received by all.
+	var proposal *initiatorReshareProposalMsg
+	if err = n.exchangeInitiatorMsgs(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, reshareStep2Propose,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends reshare step=%v command to %v", reshareStep2Propose, peer.PeeringURL())
+			peer.SendMsg(makePeerMessage(dkgID, peering.ReceiverDkg, reshareStep2Propose, &initiatorStepMsg{}))
+		},
+		func(recv *peering.PeerMessageGroupIn, initMsg initiatorMsg) (bool, error) {
+			switch msg := initMsg.(type) {
+			case *initiatorReshareProposalMsg:
+				if proposal == nil {
+					proposal = msg
+				}
+				return true, nil
+			default:
+				n.log.Errorf("msgType != initiatorReshareProposalMsg: %v", msg)
+				return false, errors.New("msgType != initiatorReshareProposalMsg")
+			}
+		},
+	); err != nil {
+		return nil, err
+	}
+	//
+	// Now get the public keys of the new committee.
+	pubShareResponses := map[int]*initiatorPubShareMsg{}
+	if err = n.exchangeInitiatorMsgs(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, reshareStep3Agree,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends reshare step=%v command to %v", reshareStep3Agree, peer.PeeringURL())
+			peer.SendMsg(makePeerMessage(dkgID, peering.ReceiverDkg, reshareStep3Agree, &initiatorReshareAgreementMsg{
+				edIndexes:  proposal.edIndexes,
+				blsIndexes: proposal.blsIndexes,
+			}))
+		},
+		func(recv *peering.PeerMessageGroupIn, initMsg initiatorMsg) (bool, error) {
+			switch msg := initMsg.(type) {
+			case *initiatorPubShareMsg:
+				pubShareResponses[int(recv.SenderIndex)] = msg
+				return true, nil
+			case *initiatorStatusMsg:
+				if recv.SenderIndex < peerCount {
+					return false, errors.New("member of the new committee responded without a public share")
+				}
+				return true, nil // Old committee only member.
+			default:
+				n.log.Errorf("msgType != initiatorPubShareMsg: %v", msg)
+				return false, errors.New("msgType != initiatorPubShareMsg")
+			}
+		},
+	); err != nil {
+		return nil, err
+	}
+	edPublicShares := make([]kyber.Point, peerCount)
+	blsPublicShares := make([]kyber.Point, peerCount)
+	for i := range edPublicShares {
+		pubShareResponse, ok := pubShareResponses[i]
+		if !ok {
+			return nil, fmt.Errorf("no public share from the new committee member %v", i)
+		}
+		if !sharedAddress.Equal(pubShareResponse.sharedAddress) {
+			return nil, errors.New("nodes generated different addresses")
+		}
+		if !oldDKShare.DSSSharedPublic().Equal(pubShareResponse.edSharedPublic) {
+			return nil, errors.New("nodes generated different Ed25519 shared public keys")
+		}
+		if !oldDKShare.BLSSharedPublic().Equal(pubShareResponse.blsSharedPublic) {
+			return nil, errors.New("nodes generated different BLS shared public keys")
+		}
+		edPublicShares[i] = pubShareResponse.edPublicShare
+		blsPublicShares[i] = pubShareResponse.blsPublicShare
+	}
+	dkShare := tcrypto.NewDKSharePublic(
+		sharedAddress,
+		peerCount,
+		threshold,
+		n.identity.GetPrivateKey(),
+		peerPubs,
+		n.edSuite,
+		oldDKShare.DSSSharedPublic(),
+		edPublicShares,
+		n.blsSuite,
+		uint16(deriveBlsThreshold(&initiatorInitMsg{peerPubs: peerPubs, threshold: threshold})),
+		oldDKShare.BLSSharedPublic(),
+		blsPublicShares,
+	)
+	for i := range edPublicShares { // Verify the BLS key signatures.
+		var blsPubShareBytes []byte
+		if blsPubShareBytes, err = pubShareResponses[i].blsPublicShare.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if err = dkShare.BLSVerify(pubShareResponses[i].blsPublicShare, blsPubShareBytes, pubShareResponses[i].blsSignature); err != nil {
+			return nil, fmt.Errorf("failed to verify BLS signature: %w", err)
+		}
+	}
+	n.log.Debugf("Reshared SharedAddress=%v, SharedPublic=%v", sharedAddress, oldDKShare.DSSSharedPublic())
+	//
+	// Commit the new keys to persistent storage and delete the old ones.
+	if err = n.exchangeInitiatorAcks(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, reshareStep4CommitAndTerminate,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends reshare step=%v command to %v", reshareStep4CommitAndTerminate, peer.PeeringURL())
+			peer.SendMsg(makePeerMessage(dkgID, peering.ReceiverDkg, reshareStep4CommitAndTerminate, &initiatorDoneMsg{
+				edPubShares:  edPublicShares,
+				blsPubShares: blsPublicShares,
+			}))
+		},
+	); err != nil {
+		return nil, err
+	}
+	return dkShare, nil
+}
@@ -0,0 +1,349 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package dkg
+
+//
+// This file contains message types, exchanged between the nodes
+// via the peering network while resharing an existing key.
+//
+
+import (
+	"io"
+	"time"
+
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
+	"github.com/nnikolash/wasp-types-exported/packages/peering"
+	"github.com/nnikolash/wasp-types-exported/packages/util/rwutil"
+)
+
+type initiatorReshareInitMsgIn struct {
+	initiatorReshareInitMsg
+	SenderPubKey *cryptolib.PublicKey
+}
+
+// initiatorReshareInitMsg
+//
+// This is a message sent by the initiator to all the peers of the old
+// and the new committees to initiate the resharing of an existing key.
+type initiatorReshareInitMsg struct {
+	step            byte
+	dkgRef          string // Some unique string to identify duplicate initialization.
+	peeringID       peering.PeeringID
+	newPeerPubs     []*cryptolib.PublicKey
+	oldPeerPubs     []*cryptolib.PublicKey
+	initiatorPub    *cryptolib.PublicKey
+	threshold       uint16        // Threshold for the new committee.
+	oldThreshold    uint16        // Threshold of the existing Ed25519 key set.
+	oldBLSThreshold uint16        // Threshold of the existing BLS key set.
+	edSharedPublic  kyber.Point   // The shared public key, that is kept.
+	edPublicShares  []kyber.Point // Public shares of the old committee.
+	edSuite         kyber.Group   // Transient, for un-marshaling only.
+	blsSharedPublic kyber.Point
+	blsPublicShares []kyber.Point
+	blsSuite        kyber.Group // Transient, for un-marshaling only.
+	timeout         time.Duration
+	roundRetry      time.Duration
+}
+
+var _ initiatorMsg = new(initiatorReshareInitMsg)
+
+func (msg *initiatorReshareInitMsg) MsgType() byte {
+	return initiatorReshareInitMsgType
+}
+
+func (msg *initiatorReshareInitMsg) Step() byte {
+	return msg.step
+}
+
+func (msg *initiatorReshareInitMsg) SetStep(step byte) {
+	msg.step = step
+}
+
+func (msg *initiatorReshareInitMsg) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msg.step = rr.ReadByte()
+	msg.dkgRef = rr.ReadString()
+	rr.ReadN(msg.peeringID[:])
+	msg.newPeerPubs = readPublicKeys(rr)
+	msg.oldPeerPubs = readPublicKeys(rr)
+	msg.initiatorPub = cryptolib.NewEmptyPublicKey()
+	rr.Read(msg.initiatorPub)
+	msg.threshold = rr.ReadUint16()
+	msg.oldThreshold = rr.ReadUint16()
+	msg.oldBLSThreshold = rr.ReadUint16()
+	msg.edSharedPublic = cryptolib.PointFromReader(rr, msg.edSuite)
+	msg.edPublicShares = readPoints(rr, msg.edSuite)
+	msg.blsSharedPublic = cryptolib.PointFromReader(rr, msg.blsSuite)
+	msg.blsPublicShares = readPoints(rr, msg.blsSuite)
+	msg.timeout = rr.ReadDuration()
+	msg.roundRetry = rr.ReadDuration()
+	return rr.Err
+}
+
+func (msg *initiatorReshareInitMsg) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteByte(msg.step)
+	ww.WriteString(msg.dkgRef)
+	ww.WriteN(msg.peeringID[:])
+	writePublicKeys(ww, msg.newPeerPubs)
+	writePublicKeys(ww, msg.oldPeerPubs)
+	ww.Write(msg.initiatorPub)
+	ww.WriteUint16(msg.threshold)
+	ww.WriteUint16(msg.oldThreshold)
+	ww.WriteUint16(msg.oldBLSThreshold)
+	cryptolib.PointToWriter(ww, msg.edSharedPublic)
+	writePoints(ww, msg.edPublicShares)
+	cryptolib.PointToWriter(ww, msg.blsSharedPublic)
+	writePoints(ww, msg.blsPublicShares)
+	ww.WriteDuration(msg.timeout)
+	ww.WriteDuration(msg.roundRetry)
+	return ww.Err
+}
+
+func (msg *initiatorReshareInitMsg) Error() error {
+	return nil
+}
+
+func (msg *initiatorReshareInitMsg) IsResponse() bool {
+	return false
+}
+
+// initiatorReshareKeyMsg
+//
+// This is a response to the initiatorReshareInitMsg. It carries an
+// ephemeral public key, used to encrypt the BLS shares for the peer.
+type initiatorReshareKeyMsg struct {
+	step            byte
+	blsTransportPub kyber.Point
+	blsSuite        kyber.Group // Transient, for un-marshaling only.
+}
+
+var _ initiatorMsg = new(initiatorReshareKeyMsg)
+
+func (msg *initiatorReshareKeyMsg) MsgType() byte {
+	return initiatorReshareKeyMsgType
+}
+
+func (msg *initiatorReshareKeyMsg) Step() byte {
+	return msg.step
+}
+
+func (msg *initiatorReshareKeyMsg) SetStep(step byte) {
+	msg.step = step
+}
+
+func (msg *initiatorReshareKeyMsg) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msg.step = rr.ReadByte()
+	msg.blsTransportPub = cryptolib.PointFromReader(rr, msg.blsSuite)
+	return rr.Err
+}
+
+func (msg *initiatorReshareKeyMsg) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteByte(msg.step)
+	cryptolib.PointToWriter(ww, msg.blsTransportPub)
+	return ww.Err
+}
+
+func (msg *initiatorReshareKeyMsg) Error() error {
+	return nil
+}
+
+func (msg *initiatorReshareKeyMsg) IsResponse() bool {
+	return true
+}
+
+// initiatorReshareStartMsg
+//
+// This is a message distributing the transport keys of all the
+// peers and starting the resharing protocol at each peer.
+type initiatorReshareStartMsg struct {
+	step             byte
+	blsTransportPubs []kyber.Point
+	blsSuite         kyber.Group // Transient, for un-marshaling only.
+}
+
+var _ initiatorMsg = new(initiatorReshareStartMsg)
+
+func (msg *initiatorReshareStartMsg) MsgType() byte {
+	return initiatorReshareStartMsgType
+}
+
+func (msg *initiatorReshareStartMsg) Step() byte {
+	return msg.step
+}
+
+func (msg *initiatorReshareStartMsg) SetStep(step byte) {
+	msg.step = step
+}
+
+func (msg *initiatorReshareStartMsg) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msg.step = rr.ReadByte()
+	msg.blsTransportPubs = readPoints(rr, msg.blsSuite)
+	return rr.Err
+}
+
+func (msg *initiatorReshareStartMsg) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteByte(msg.step)
+	writePoints(ww, msg.blsTransportPubs)
+	return ww.Err
+}
+
+func (msg *initiatorReshareStartMsg) Error() error {
+	return nil
+}
+
+func (msg *initiatorReshareStartMsg) IsResponse() bool {
+	return false
+}
+
+// initiatorReshareProposalMsg
+//
+// This is a response of a peer listing the old committee members,
+// whose shares were successfully reshared to this peer.
+type initiatorReshareProposalMsg struct {
+	step       byte
+	edIndexes  []int
+	blsIndexes []int
+}
+
+var _ initiatorMsg = new(initiatorReshareProposalMsg)
+
+func (msg *initiatorReshareProposalMsg) MsgType() byte {
+	return initiatorReshareProposalMsgType
+}
+
+func (msg *initiatorReshareProposalMsg) Step() byte {
+	return msg.step
+}
+
+func (msg *initiatorReshareProposalMsg) SetStep(step byte) {
+	msg.step = step
+}
+
+func (msg *initiatorReshareProposalMsg) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msg.step = rr.ReadByte()
+	msg.edIndexes = readIndexes(rr)
+	msg.blsIndexes = readIndexes(rr)
+	return rr.Err
+}
+
+func (msg *initiatorReshareProposalMsg) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteByte(msg.step)
+	writeIndexes(ww, msg.edIndexes)
+	writeIndexes(ww, msg.blsIndexes)
+	return ww.Err
+}
+
+func (msg *initiatorReshareProposalMsg) Error() error {
+	return nil
+}
+
+func (msg *initiatorReshareProposalMsg) IsResponse() bool {
+	return true
+}
+
+// initiatorReshareAgreementMsg
+//
+// This is a message sent by the initiator to all the peers to
+// decide, which old committee members are used for the new shares.
+type initiatorReshareAgreementMsg struct {
+	step       byte
+	edIndexes  []int
+	blsIndexes []int
+}
+
+var _ initiatorMsg = new(initiatorReshareAgreementMsg)
+
+func (msg *initiatorReshareAgreementMsg) MsgType() byte {
+	return initiatorReshareAgreementMsgType
+}
+
+func (msg *initiatorReshareAgreementMsg) Step() byte {
+	return msg.step
+}
+
+func (msg *initiatorReshareAgreementMsg) SetStep(step byte) {
+	msg.step = step
+}
+
+func (msg *initiatorReshareAgreementMsg) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msg.step = rr.ReadByte()
+	msg.edIndexes = readIndexes(rr)
+	msg.blsIndexes = readIndexes(rr)
+	return rr.Err
+}
+
+func (msg *initiatorReshareAgreementMsg) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteByte(msg.step)
+	writeIndexes(ww, msg.edIndexes)
+	writeIndexes(ww, msg.blsIndexes)
+	return ww.Err
+}
+
+func (msg *initiatorReshareAgreementMsg) Error() error {
+	return nil
+}
+
+func (msg *initiatorReshareAgreementMsg) IsResponse() bool {
+	return false
+}
+
+func readPublicKeys(rr *rwutil.Reader) []*cryptolib.PublicKey {
+	size := rr.ReadSize16()
+	ret := make([]*cryptolib.PublicKey, size)
+	for i := range ret {
+		ret[i] = cryptolib.NewEmptyPublicKey()
+		rr.Read(ret[i])
+	}
+	return ret
+}
+
+func writePublicKeys(ww *rwutil.Writer, pubKeys []*cryptolib.PublicKey) {
+	ww.WriteSize16(len(pubKeys))
+	for i := range pubKeys {
+		ww.Write(pubKeys[i])
+	}
+}
+
+func readPoints(rr *rwutil.Reader, suite kyber.Group) []kyber.Point {
+	size := rr.ReadSize16()
+	ret := make([]kyber.Point, size)
+	for i := range ret {
+		ret[i] = cryptolib.PointFromReader(rr, suite)
+	}
+	return ret
+}
+
+func writePoints(ww *rwutil.Writer, points []kyber.Point) {
+	ww.WriteSize16(len(points))
+	for i := range points {
+		cryptolib.PointToWriter(ww, points[i])
+	}
+}
+
+func readIndexes(rr *rwutil.Reader) []int {
+	size := rr.ReadSize16()
+	ret := make([]int, size)
+	for i := range ret {
+		ret[i] = int(rr.ReadUint16())
+	}
+	return ret
+}
+
+func writeIndexes(ww *rwutil.Writer, indexes []int) {
+	ww.WriteSize16(len(indexes))
+	for _, i := range indexes {
+		ww.WriteUint16(uint16(i))
+	}
+}
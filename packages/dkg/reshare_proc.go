@@ -0,0 +1,412 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package dkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/util/key"
+
+	"github.com/iotaledger/hive.go/logger"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
+	"github.com/nnikolash/wasp-types-exported/packages/gpa"
+	"github.com/nnikolash/wasp-types-exported/packages/gpa/adkg/reshare"
+	"github.com/nnikolash/wasp-types-exported/packages/peering"
+	"github.com/nnikolash/wasp-types-exported/packages/tcrypto"
+	"github.com/nnikolash/wasp-types-exported/packages/util"
+)
+
+const (
+	reshareStep0Initialize         = byte(0)
+	reshareStep1Start              = byte(1)
+	reshareStep2Propose            = byte(2)
+	reshareStep3Agree              = byte(3)
+	reshareStep4CommitAndTerminate = byte(4)
+)
+
+// Stands for a resharing procedure instance on a particular node.
+//
+// All the nodes of the old and the new committees participate in the procedure.
+// The old committee members deal their existing shares using the ACSS, and the
+// new committee members combine the received sub-shares into the new shares of
+// the same secret, see the `gpa/adkg/reshare` package for the details.
+type reshareProc struct {
+	dkgRef       string            // User supplied unique ID for this instance.
+	dkgID        peering.PeeringID // Resharing procedure ID we are participating in.
+	initMsg      *initiatorReshareInitMsg
+	node         *Node                 // DKG node we are running in.
+	netGroup     peering.GroupProvider // The new committee nodes first, then the nodes of the old committee only.
+	nodeIDs      []gpa.NodeID          // In the same order as in the netGroup.
+	nodePubs     map[gpa.NodeID]*cryptolib.PublicKey
+	me           gpa.NodeID
+	oldDKShare   tcrypto.DKShare                      // Nil, if this node is not a member of the old committee.
+	blsTransport *key.Pair                            // Ephemeral key used to receive the BLS sub-shares.
+	edThreshold  int                                  // Threshold of the new Ed25519 sharing.
+	blsThreshold int                                  // Threshold of the new BLS sharing.
+	edReshare    gpa.AckHandler                       // Nil, until the procedure is started.
+	blsReshare   gpa.AckHandler                       // Nil, until the procedure is started.
+	agreed       bool                                 // True, if the agreement result was already provided to the GPAs.
+	dkShare      tcrypto.DKShare                      // This will be generated as a result of this procedure.
+	pending      map[byte]*peering.PeerMessageGroupIn // Initiator requests, waiting for the protocol to progress.
+	responses    map[byte]*peering.PeerMessageData    // Responses to the initiator, kept for resends.
+	peerMsgCh    chan *peering.PeerMessageGroupIn     // A buffer for the received peer messages.
+	cleanupFunc  context.CancelFunc                   // We keep it here to be able to detach from the network.
+	log          *logger.Logger
+}
+
+func onInitiatorReshareInit(dkgID peering.PeeringID, msg *initiatorReshareInitMsg, node *Node) (*reshareProc, error) {
+	log := node.log.With("dkgID", dkgID.String())
+	var err error
+
+	if len(msg.edPublicShares) != len(msg.oldPeerPubs) || len(msg.blsPublicShares) != len(msg.oldPeerPubs) {
+		return nil, errors.New("public shares have to be provided for all the old committee members")
+	}
+	nodePubList := reshareNodePubs(msg.newPeerPubs, msg.oldPeerPubs)
+	var netGroup peering.GroupProvider
+	if netGroup, err = node.netProvider.PeerGroup(dkgID, nodePubList); err != nil {
+		return nil, err
+	}
+	nodeIDs := make([]gpa.NodeID, len(nodePubList))
+	nodePubs := make(map[gpa.NodeID]*cryptolib.PublicKey, len(nodePubList))
+	for i := range nodePubList {
+		nodeIDs[i] = gpa.NodeIDFromPublicKey(nodePubList[i])
+		nodePubs[nodeIDs[i]] = nodePubList[i]
+	}
+	oldDKShare, err := reshareCheckOldDKShare(msg, node)
+	if err != nil {
+		netGroup.Close()
+		return nil, err
+	}
+	edThreshold := int(msg.threshold)
+	blsThreshold := deriveBlsThreshold(&initiatorInitMsg{peerPubs: msg.newPeerPubs, threshold: msg.threshold})
+	if edThreshold < 1 || edThreshold > len(msg.newPeerPubs) || blsThreshold < 1 || blsThreshold > len(msg.newPeerPubs) {
+		netGroup.Close()
+		return nil, fmt.Errorf("threshold must be in [1, %v], got %v", len(msg.newPeerPubs), msg.threshold)
+	}
+	p := reshareProc{
+		dkgRef:       msg.dkgRef,
+		dkgID:        dkgID,
+		initMsg:      msg,
+		node:         node,
+		netGroup:     netGroup,
+		nodeIDs:      nodeIDs,
+		nodePubs:     nodePubs,
+		me:           gpa.NodeIDFromPublicKey(node.identity.GetPublicKey()),
+		oldDKShare:   oldDKShare,
+		blsTransport: key.NewKeyPair(node.blsSuite),
+		edThreshold:  edThreshold,
+		blsThreshold: blsThreshold,
+		pending:      map[byte]*peering.PeerMessageGroupIn{},
+		responses:    map[byte]*peering.PeerMessageData{},
+		peerMsgCh:    make(chan *peering.PeerMessageGroupIn, len(nodePubList)),
+		log:          log,
+	}
+	p.log.Infof("Starting DKG Reshare process at %v for DkgID=%v", node.identity.GetPublicKey().String(), p.dkgID.String())
+	p.cleanupFunc = p.netGroup.Attach(peering.ReceiverDkg, p.onPeerMessage)
+	go p.processLoop(msg.timeout)
+	return &p, nil
+}
+
+// reshareNodePubs returns the nodes participating in the resharing:
+// the new committee first (in its order), then the nodes of the old committee only.
+func reshareNodePubs(newPeerPubs, oldPeerPubs []*cryptolib.PublicKey) []*cryptolib.PublicKey {
+	nodePubs := util.CloneSlice(newPeerPubs)
+	for _, oldPub := range oldPeerPubs {
+		if !lo.ContainsBy(nodePubs, oldPub.Equals) {
+			nodePubs = append(nodePubs, oldPub)
+		}
+	}
+	return nodePubs
+}
+
+// reshareCheckOldDKShare loads the share of the old committee member and checks,
+// if it matches the public information sent by the initiator.
+func reshareCheckOldDKShare(msg *initiatorReshareInitMsg, node *Node) (tcrypto.DKShare, error) {
+	_, oldIndex, isOld := lo.FindIndexOf(msg.oldPeerPubs, node.identity.GetPublicKey().Equals)
+	if !isOld {
+		return nil, nil
+	}
+	edSharedPublicBytes, err := msg.edSharedPublic.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	sharedAddress := iotago.Ed25519AddressFromPubKey(edSharedPublicBytes)
+	oldDKShare, err := node.dkShareRegistryProvider.LoadDKShare(&sharedAddress)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the DKShare to reshare: %w", err)
+	}
+	if oldDKShare.GetIndex() == nil || int(*oldDKShare.GetIndex()) != oldIndex {
+		return nil, fmt.Errorf("index of the node in the old committee mismatch, expected %v", oldIndex)
+	}
+	if !oldDKShare.DSSSharedPublic().Equal(msg.edSharedPublic) || !oldDKShare.BLSSharedPublic().Equal(msg.blsSharedPublic) {
+		return nil, errors.New("shared public key mismatch")
+	}
+	for i := range msg.oldPeerPubs {
+		if !oldDKShare.DSSPublicShares()[i].Equal(msg.edPublicShares[i]) || !oldDKShare.BLSPublicShares()[i].Equal(msg.blsPublicShares[i]) {
+			return nil, fmt.Errorf("public share of the old committee member %v mismatch", i)
+		}
+	}
+	if oldDKShare.GetT() != msg.oldThreshold || oldDKShare.BLSThreshold() != msg.oldBLSThreshold {
+		return nil, errors.New("threshold of the old committee mismatch")
+	}
+	return oldDKShare, nil
+}
+
+// Handles a message from a peer and pass it to the main thread.
+func (p *reshareProc) onPeerMessage(peerMsg *peering.PeerMessageGroupIn) {
+	p.peerMsgCh <- peerMsg
+}
+
+// That's the main thread executing the procedure.
+// We use a single process to make all the actions sequential.
+func (p *reshareProc) processLoop(timeout time.Duration) {
+	timeoutCh := time.After(timeout)
+	retryCh := time.After(p.initMsg.roundRetry)
+	for {
+		select {
+		case recv := <-p.peerMsgCh:
+			switch recv.MsgType {
+			case reshareEdMsgType:
+				p.handleGPAMessage(p.edReshare, recv)
+			case reshareBLSMsgType:
+				p.handleGPAMessage(p.blsReshare, recv)
+			case initiatorReshareStartMsgType, initiatorStepMsgType, initiatorReshareAgreementMsgType, initiatorDoneMsgType:
+				p.handleInitiatorMessage(recv)
+			}
+			continue // Drop messages sent for the initiator.
+		case t := <-retryCh:
+			retryCh = time.After(p.initMsg.roundRetry)
+			if p.edReshare != nil {
+				p.sendMessages(reshareEdMsgType, p.edReshare.Input(p.edReshare.MakeTickInput(t)))
+				p.sendMessages(reshareBLSMsgType, p.blsReshare.Input(p.blsReshare.MakeTickInput(t)))
+			}
+		case <-timeoutCh:
+			// We cannot terminate the process on completion, because other peers can still
+			// request to resend some messages. We will wait until the timeout.
+			util.ExecuteIfNotNil(p.cleanupFunc)
+			if p.node.dropReshareProcess(p) {
+				if p.responses[reshareStep4CommitAndTerminate] != nil {
+					p.log.Debug("Deleting completed DkgReshareProc.")
+				} else {
+					p.log.Warn("Deleting non-completed a DkgReshareProc on timeout.")
+				}
+			}
+			return
+		}
+	}
+}
+
+func (p *reshareProc) handleGPAMessage(reshareGPA gpa.AckHandler, recv *peering.PeerMessageGroupIn) {
+	if reshareGPA == nil {
+		return // Not started yet, the message will be redelivered.
+	}
+	msg, err := reshareGPA.UnmarshalMessage(recv.MsgData)
+	if err != nil {
+		p.log.Warnf("cannot parse message: %v", err)
+		return
+	}
+	msg.SetSender(gpa.NodeIDFromPublicKey(recv.SenderPubKey))
+	p.sendMessages(recv.MsgType, reshareGPA.Message(msg))
+	p.tryRespond()
+}
+
+func (p *reshareProc) sendMessages(msgType byte, outMsgs gpa.OutMessages) {
+	if outMsgs == nil {
+		return
+	}
+	outMsgs.MustIterate(func(msg gpa.Message) {
+		p.node.netProvider.SendMsgByPubKey(p.nodePubs[msg.Recipient()], peering.NewPeerMessageData(p.dkgID, peering.ReceiverDkg, msgType, msg))
+	})
+}
+
+func (p *reshareProc) handleInitiatorMessage(recv *peering.PeerMessageGroupIn) {
+	step := readDkgMessageStep(recv.MsgData)
+	if resp, ok := p.responses[step]; ok {
+		p.log.Debugf("Resending initiator response for step %v.", step)
+		p.netGroup.SendMsgByIndex(recv.SenderIndex, resp.MsgReceiver, resp.MsgType, resp.MsgData)
+		return
+	}
+	p.pending[step] = recv
+	p.tryRespond()
+}
+
+// tryRespond responds to the initiator requests, for which the results are already available.
+func (p *reshareProc) tryRespond() {
+	for step, recv := range p.pending {
+		resp, err := p.makeResp(step, recv)
+		if err != nil {
+			p.log.Errorf("Step %v failed to make response, reason=%v", step, err)
+			resp = makePeerMessage(p.dkgID, peering.ReceiverDkg, step, &initiatorStatusMsg{error: err})
+		}
+		if resp == nil {
+			continue // Have to wait for the protocol to progress.
+		}
+		delete(p.pending, step)
+		p.responses[step] = resp
+		p.netGroup.SendMsgByIndex(recv.SenderIndex, resp.MsgReceiver, resp.MsgType, resp.MsgData)
+	}
+}
+
+func (p *reshareProc) makeResp(step byte, recv *peering.PeerMessageGroupIn) (*peering.PeerMessageData, error) {
+	initMsg, err := readInitiatorMsg(recv.PeerMessageData, p.node.edSuite, p.node.blsSuite)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := initMsg.(type) {
+	case *initiatorReshareStartMsg:
+		return p.reshareStep1StartMakeResp(step, msg)
+	case *initiatorStepMsg:
+		return p.reshareStep2ProposeMakeResp(step)
+	case *initiatorReshareAgreementMsg:
+		return p.reshareStep3AgreeMakeResp(step, msg)
+	case *initiatorDoneMsg:
+		return p.reshareStep4CommitAndTerminateMakeResp(step, msg)
+	default:
+		return nil, fmt.Errorf("unexpected initiator message %T at step %v", initMsg, step)
+	}
+}
+
+// reshareStep1Start
+func (p *reshareProc) reshareStep1StartMakeResp(step byte, msg *initiatorReshareStartMsg) (*peering.PeerMessageData, error) {
+	if len(msg.blsTransportPubs) != len(p.nodeIDs) {
+		return nil, fmt.Errorf("expected %v transport keys, received %v", len(p.nodeIDs), len(msg.blsTransportPubs))
+	}
+	edPeerPKs := make(map[gpa.NodeID]kyber.Point, len(p.nodeIDs))
+	blsPeerPKs := make(map[gpa.NodeID]kyber.Point, len(p.nodeIDs))
+	for i, nodeID := range p.nodeIDs {
+		edPeerPK, err := cryptolib.PointFromBytes(p.nodePubs[nodeID].AsBytes(), p.node.edSuite)
+		if err != nil {
+			return nil, err
+		}
+		edPeerPKs[nodeID] = edPeerPK
+		blsPeerPKs[nodeID] = msg.blsTransportPubs[i]
+	}
+	dealers := make([]gpa.NodeID, len(p.initMsg.oldPeerPubs))
+	for i := range dealers {
+		dealers[i] = gpa.NodeIDFromPublicKey(p.initMsg.oldPeerPubs[i])
+	}
+	var edShare, blsShare *share.PriShare
+	if p.oldDKShare != nil {
+		edShare = p.oldDKShare.DSS().PriShare()
+		blsShare = p.oldDKShare.BLSPriShare()
+	}
+	newN := len(p.initMsg.newPeerPubs)
+	edReshare, err := reshare.New(
+		p.node.edSuite, p.nodeIDs, edPeerPKs, newN, p.edThreshold,
+		dealers, p.initMsg.edPublicShares, int(p.initMsg.oldThreshold),
+		p.me, p.node.secKey, edShare, p.log.Named("Ed"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot start the Ed25519 resharing: %w", err)
+	}
+	blsReshare, err := reshare.New(
+		p.node.blsSuite, p.nodeIDs, blsPeerPKs, newN, p.blsThreshold,
+		dealers, p.initMsg.blsPublicShares, int(p.initMsg.oldBLSThreshold),
+		p.me, p.blsTransport.Private, blsShare, p.log.Named("BLS"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot start the BLS resharing: %w", err)
+	}
+	p.edReshare = gpa.NewAckHandler(p.me, edReshare, p.initMsg.roundRetry)
+	p.blsReshare = gpa.NewAckHandler(p.me, blsReshare, p.initMsg.roundRetry)
+	p.sendMessages(reshareEdMsgType, p.edReshare.Input(reshare.NewInputStart()))
+	p.sendMessages(reshareBLSMsgType, p.blsReshare.Input(reshare.NewInputStart()))
+	return makePeerMessage(p.dkgID, peering.ReceiverDkg, step, &initiatorStatusMsg{error: nil}), nil
+}
+
+// reshareStep2Propose
+func (p *reshareProc) reshareStep2ProposeMakeResp(step byte) (*peering.PeerMessageData, error) {
+	if p.edReshare == nil {
+		return nil, errors.New("resharing is not started")
+	}
+	edOutput := p.edReshare.Output()
+	blsOutput := p.blsReshare.Output()
+	if edOutput == nil || blsOutput == nil {
+		return nil, nil
+	}
+	return makePeerMessage(p.dkgID, peering.ReceiverDkg, step, &initiatorReshareProposalMsg{
+		edIndexes:  edOutput.(*reshare.Output).Indexes,
+		blsIndexes: blsOutput.(*reshare.Output).Indexes,
+	}), nil
+}
+
+// reshareStep3Agree
+func (p *reshareProc) reshareStep3AgreeMakeResp(step byte, msg *initiatorReshareAgreementMsg) (*peering.PeerMessageData, error) {
+	if p.edReshare == nil {
+		return nil, errors.New("resharing is not started")
+	}
+	if !p.agreed {
+		p.agreed = true
+		p.sendMessages(reshareEdMsgType, p.edReshare.Input(reshare.NewInputAgreementResult(msg.edIndexes)))
+		p.sendMessages(reshareBLSMsgType, p.blsReshare.Input(reshare.NewInputAgreementResult(msg.blsIndexes)))
+	}
+	edOutput, _ := p.edReshare.Output().(*reshare.Output)
+	blsOutput, _ := p.blsReshare.Output().(*reshare.Output)
+	if edOutput == nil || edOutput.Commits == nil || blsOutput == nil || blsOutput.Commits == nil {
+		return nil, nil
+	}
+	if edOutput.PriShare == nil {
+		// Not a member of the new committee, nothing to generate.
+		return makePeerMessage(p.dkgID, peering.ReceiverDkg, step, &initiatorStatusMsg{error: nil}), nil
+	}
+	newN := uint16(len(p.initMsg.newPeerPubs))
+	ownIndex := uint16(edOutput.PriShare.I)
+	publicSharesEd := make([]kyber.Point, newN)
+	publicSharesEd[ownIndex] = p.node.edSuite.Point().Mul(edOutput.PriShare.V, nil)
+	publicSharesBLS := make([]kyber.Point, newN)
+	publicSharesBLS[ownIndex] = p.node.blsSuite.Point().Mul(blsOutput.PriShare.V, nil)
+	var err error
+	p.dkShare, err = tcrypto.NewDKShare(
+		ownIndex,                        // Index
+		newN,                            // N
+		p.initMsg.threshold,             // T
+		p.node.identity.GetPrivateKey(), // NodePrivKey
+		p.initMsg.newPeerPubs,           // NodePubKeys
+		p.node.edSuite,                  // Ed25519: Suite
+		edOutput.PubKey,                 // Ed25519: SharedPublic
+		edOutput.Commits,                // Ed25519: PublicCommits
+		publicSharesEd,                  // Ed25519: PublicShares
+		edOutput.PriShare.V,             // Ed25519: PrivateShare
+		p.node.blsSuite,                 // BLS: Suite
+		uint16(p.blsThreshold),          // BLS: Threshold
+		blsOutput.PubKey,                // BLS: SharedPublic
+		blsOutput.Commits,               // BLS: PublicCommits
+		publicSharesBLS,                 // BLS: PublicShares
+		blsOutput.PriShare.V,            // BLS: PrivateShare
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.log.Debugf("All the shares are reshared, shared public: %v.", p.dkShare.GetSharedPublic())
+	pubShareMsg, err := makeInitiatorPubShareMsg(p.dkShare, step)
+	if err != nil {
+		return nil, err
+	}
+	return makePeerMessage(p.dkgID, peering.ReceiverDkg, step, pubShareMsg), nil
+}
+
+// reshareStep4CommitAndTerminate
+func (p *reshareProc) reshareStep4CommitAndTerminateMakeResp(step byte, msg *initiatorDoneMsg) (*peering.PeerMessageData, error) {
+	if p.oldDKShare != nil {
+		// The old share must be deleted, otherwise it would be possible to combine
+		// it with the shares of other old committee members and recover the key.
+		if err := p.node.dkShareRegistryProvider.DeleteDKShare(p.oldDKShare.GetAddress()); err != nil {
+			return nil, err
+		}
+	}
+	if p.dkShare != nil {
+		p.dkShare.SetPublicShares(msg.edPubShares, msg.blsPubShares) // Store public shares of all the other peers.
+		if err := p.node.dkShareRegistryProvider.SaveDKShare(p.dkShare); err != nil {
+			return nil, err
+		}
+	}
+	return makePeerMessage(p.dkgID, peering.ReceiverDkg, step, &initiatorStatusMsg{error: nil}), nil
+}
@@ -19,3 +19,20 @@ func CheckGasPrice(gasPrice *big.Int, gasFeePolicy *gas.FeePolicy) error {
 	}
 	return nil
 }
+
+// EffectiveGasPrice returns the price per gas unit paid by an EVM transaction.
+// The gas price set by the fee policy acts as the EIP-1559 base fee, so a
+// dynamic fee transaction pays min(gasFeeCap, baseFee+gasTipCap). For legacy
+// transactions gasFeeCap and gasTipCap are equal to gasPrice, and the result
+// is always gasPrice.
+func EffectiveGasPrice(gasPrice, gasFeeCap, gasTipCap *big.Int, gasFeePolicy *gas.FeePolicy) *big.Int {
+	if gasFeeCap == nil || gasTipCap == nil {
+		return gasPrice
+	}
+	baseFee := gasFeePolicy.DefaultGasPriceFullDecimals(parameters.L1().BaseToken.Decimals)
+	price := new(big.Int).Add(baseFee, gasTipCap)
+	if price.Cmp(gasFeeCap) > 0 {
+		return new(big.Int).Set(gasFeeCap)
+	}
+	return price
+}
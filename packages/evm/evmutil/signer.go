@@ -12,10 +12,27 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/util"
 )
 
+// Signer returns a signer that accepts all the transaction types supported by
+// ISC chains: legacy, EIP-2930 and EIP-1559 transactions.
 func Signer(chainID *big.Int) types.Signer {
+	return types.NewLondonSigner(chainID)
+}
+
+// LegacySigner returns the signer used by chains that have not been migrated
+// to support typed transactions yet. It only accepts legacy transactions.
+func LegacySigner(chainID *big.Int) types.Signer {
 	return types.NewEIP155Signer(chainID)
 }
 
+// SignerForTxTypes returns Signer if typed transactions are enabled, or
+// LegacySigner otherwise.
+func SignerForTxTypes(chainID *big.Int, typedTxsEnabled bool) types.Signer {
+	if typedTxsEnabled {
+		return Signer(chainID)
+	}
+	return LegacySigner(chainID)
+}
+
 func GetSender(tx *types.Transaction) (common.Address, error) {
 	return types.Sender(Signer(tx.ChainId()), tx)
 }
@@ -6,6 +6,7 @@ package jsonrpc
 import (
 	"crypto/ecdsa"
 	"slices"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -14,11 +15,16 @@ import (
 type AccountManager struct {
 	accounts map[common.Address]*ecdsa.PrivateKey
 	addrs    []common.Address
+	// impersonated accounts can send txs without their keys (on development chains only)
+	impersonated      map[common.Address]struct{}
+	impersonatedMutex *sync.RWMutex
 }
 
 func NewAccountManager(accounts []*ecdsa.PrivateKey) *AccountManager {
 	a := &AccountManager{
-		accounts: make(map[common.Address]*ecdsa.PrivateKey),
+		accounts:          make(map[common.Address]*ecdsa.PrivateKey),
+		impersonated:      make(map[common.Address]struct{}),
+		impersonatedMutex: &sync.RWMutex{},
 	}
 	for _, account := range accounts {
 		a.Add(account)
@@ -42,3 +48,22 @@ func (a *AccountManager) Get(addr common.Address) *ecdsa.PrivateKey {
 func (a *AccountManager) Addresses() []common.Address {
 	return slices.Clone(a.addrs)
 }
+
+func (a *AccountManager) Impersonate(addr common.Address) {
+	a.impersonatedMutex.Lock()
+	defer a.impersonatedMutex.Unlock()
+	a.impersonated[addr] = struct{}{}
+}
+
+func (a *AccountManager) StopImpersonating(addr common.Address) {
+	a.impersonatedMutex.Lock()
+	defer a.impersonatedMutex.Unlock()
+	delete(a.impersonated, addr)
+}
+
+func (a *AccountManager) IsImpersonated(addr common.Address) bool {
+	a.impersonatedMutex.RLock()
+	defer a.impersonatedMutex.RUnlock()
+	_, ok := a.impersonated[addr]
+	return ok
+}
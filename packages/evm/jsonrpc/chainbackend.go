@@ -4,12 +4,15 @@
 package jsonrpc
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
+	"github.com/nnikolash/wasp-types-exported/packages/chain"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
 	"github.com/nnikolash/wasp-types-exported/packages/parameters"
@@ -29,9 +32,30 @@ type ChainBackend interface {
 	ISCCallView(chainState state.State, scName string, funName string, args dict.Dict) (dict.Dict, error)
 	ISCLatestAliasOutput() (*isc.AliasOutputWithID, error)
 	ISCLatestState() (state.State, error)
+	ISCMempoolOffLedgerRequests() []isc.OffLedgerRequest
+	ISCSyncStatus() *chain.SyncStatus
 	ISCStateByBlockIndex(blockIndex uint32) (state.State, error)
 	ISCStateByTrieRoot(trieRoot trie.Hash) (state.State, error)
 	BaseToken() *parameters.BaseToken
 	TakeSnapshot() (int, error)
 	RevertToSnapshot(int) error
 }
+
+// DevChainBackend is implemented by the chain backends of development
+// environments (e.g. Solo) to support the Anvil/Hardhat compatible RPC methods.
+type DevChainBackend interface {
+	// DevMine produces a new block.
+	DevMine() error
+	// DevTime returns the timestamp of the next block.
+	DevTime() time.Time
+	// DevAdvanceClock moves the timestamp of the next blocks forward.
+	DevAdvanceClock(step time.Duration) error
+	// DevSetBalance sets the balance (in wei) of the L2 account of the EVM address,
+	// the base tokens in excess are moved to a sink account.
+	DevSetBalance(addr common.Address, balance *big.Int) error
+	DevSetCode(addr common.Address, code []byte) error
+	DevSetStorageAt(addr common.Address, key, value common.Hash) error
+	DevSetNonce(addr common.Address, nonce uint64) error
+	// DevSendImpersonatedTransaction executes the unsigned tx on behalf of the sender.
+	DevSendImpersonatedTransaction(tx *types.Transaction, sender common.Address) error
+}
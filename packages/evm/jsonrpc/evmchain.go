@@ -4,17 +4,21 @@
 package jsonrpc
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"path"
+	"slices"
+	"sync"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -35,6 +39,7 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/parameters"
 	"github.com/nnikolash/wasp-types-exported/packages/publisher"
 	"github.com/nnikolash/wasp-types-exported/packages/state"
+	"github.com/nnikolash/wasp-types-exported/packages/transaction"
 	"github.com/nnikolash/wasp-types-exported/packages/trie"
 	"github.com/nnikolash/wasp-types-exported/packages/util"
 	"github.com/nnikolash/wasp-types-exported/packages/util/pipe"
@@ -54,6 +59,12 @@ type EVMChain struct {
 	newBlock *event.Event1[*NewBlockEvent]
 	log      *logger.Logger
 	index    *jsonrpcindex.Index // only indexes blocks that will be pruned from the active state
+
+	// stopPublishing stops the goroutine publishing and indexing the new blocks
+	stopPublishing func()
+
+	syncMutex         sync.Mutex
+	syncStartingBlock *uint64 // set while the node is catching up with the chain
 }
 
 type NewBlockEvent struct {
@@ -83,15 +94,22 @@ func NewEVMChain(
 
 	blocksFromPublisher := pipe.NewInfinitePipe[*publisher.BlockWithTrieRoot]()
 
-	pub.Events.NewBlock.Hook(func(ev *publisher.ISCEvent[*publisher.BlockWithTrieRoot]) {
+	hook := pub.Events.NewBlock.Hook(func(ev *publisher.ISCEvent[*publisher.BlockWithTrieRoot]) {
 		if !ev.ChainID.Equals(*e.backend.ISCChainID()) {
 			return
 		}
-		blocksFromPublisher.In() <- ev.Payload
+		blocksFromPublisher.TryAdd(ev.Payload, log.Debugf)
 	})
 
 	// publish blocks on a separate goroutine so that we don't block the publisher
+	publishingDone := make(chan struct{})
+	e.stopPublishing = func() {
+		hook.Unhook()
+		blocksFromPublisher.Discard()
+		<-publishingDone
+	}
 	go func() {
+		defer close(publishingDone)
 		for ev := range blocksFromPublisher.Out() {
 			e.publishNewBlock(ev.BlockInfo.BlockIndex(), ev.TrieRoot)
 			if isArchiveNode {
@@ -103,6 +121,12 @@ func NewEVMChain(
 	return e
 }
 
+// Close stops publishing the new blocks and closes the index
+func (e *EVMChain) Close() {
+	e.stopPublishing()
+	e.index.Close()
+}
+
 func (e *EVMChain) publishNewBlock(blockIndex uint32, trieRoot trie.Hash) {
 	state, err := e.backend.ISCStateByTrieRoot(trieRoot)
 	if err != nil {
@@ -126,9 +150,16 @@ func (e *EVMChain) publishNewBlock(blockIndex uint32, trieRoot trie.Hash) {
 	})
 }
 
+// Signer returns the signer that accepts the transaction types supported by
+// the latest state of the chain.
 func (e *EVMChain) Signer() (types.Signer, error) {
 	chainID := e.ChainID()
-	return evmutil.Signer(big.NewInt(int64(chainID))), nil
+	latestState, err := e.backend.ISCLatestState()
+	if err != nil {
+		return nil, err
+	}
+	typedTxsEnabled := latestState.SchemaVersion() >= evm.SchemaVersionTypedTxs
+	return evmutil.SignerForTxTypes(big.NewInt(int64(chainID)), typedTxsEnabled), nil
 }
 
 func (e *EVMChain) ChainID() uint16 {
@@ -152,6 +183,97 @@ func (e *EVMChain) BlockNumber() *big.Int {
 	return big.NewInt(0).SetUint64(db.GetNumber())
 }
 
+// SyncProgress returns nil if the node has all the blocks known to the chain,
+// or the progress of the synchronization otherwise.
+func (e *EVMChain) SyncProgress() *ethereum.SyncProgress {
+	e.log.Debugf("SyncProgress()")
+	status := e.backend.ISCSyncStatus()
+
+	e.syncMutex.Lock()
+	defer e.syncMutex.Unlock()
+	if status.IsSynced() {
+		e.syncStartingBlock = nil
+		return nil
+	}
+	current := evmBlockNumberByISCBlockIndex(status.CurrentBlockIndex)
+	if e.syncStartingBlock == nil {
+		e.syncStartingBlock = &current
+	}
+	return &ethereum.SyncProgress{
+		StartingBlock: *e.syncStartingBlock,
+		CurrentBlock:  current,
+		HighestBlock:  evmBlockNumberByISCBlockIndex(status.HighestBlockIndex),
+	}
+}
+
+// MempoolTransactions returns the EVM transactions held in the mempool,
+// grouped by sender and nonce. Transactions that can be executed on top of
+// the latest state are reported as pending; the ones that are waiting for a
+// nonce gap to be filled are reported as queued.
+func (e *EVMChain) MempoolTransactions() (pending, queued map[common.Address]map[uint64]*types.Transaction, err error) {
+	e.log.Debugf("MempoolTransactions()")
+	signer, err := e.Signer()
+	if err != nil {
+		return nil, nil, err
+	}
+	latestState, err := e.backend.ISCLatestState()
+	if err != nil {
+		return nil, nil, err
+	}
+	stateDB := stateDBSubrealmR(latestState)
+
+	txsBySender := make(map[common.Address][]*types.Transaction)
+	for _, req := range e.backend.ISCMempoolOffLedgerRequests() {
+		tx := evmTransactionFromRequest(req)
+		if tx == nil {
+			continue
+		}
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		txsBySender[sender] = append(txsBySender[sender], tx)
+	}
+
+	pending = make(map[common.Address]map[uint64]*types.Transaction)
+	queued = make(map[common.Address]map[uint64]*types.Transaction)
+	for sender, txs := range txsBySender {
+		slices.SortFunc(txs, func(a, b *types.Transaction) int {
+			return cmp.Compare(a.Nonce(), b.Nonce())
+		})
+		nextNonce := emulator.GetNonce(stateDB, sender)
+		for _, tx := range txs {
+			switch {
+			case tx.Nonce() < nextNonce:
+				continue // already executed, will be removed from the mempool
+			case tx.Nonce() == nextNonce:
+				if pending[sender] == nil {
+					pending[sender] = make(map[uint64]*types.Transaction)
+				}
+				pending[sender][tx.Nonce()] = tx
+				nextNonce++
+			default:
+				if queued[sender] == nil {
+					queued[sender] = make(map[uint64]*types.Transaction)
+				}
+				queued[sender][tx.Nonce()] = tx
+			}
+		}
+	}
+	return pending, queued, nil
+}
+
+// PendingTransactionHashes returns the hashes of the EVM transactions in the mempool
+func (e *EVMChain) PendingTransactionHashes() []common.Hash {
+	var ret []common.Hash
+	for _, req := range e.backend.ISCMempoolOffLedgerRequests() {
+		if tx := evmTransactionFromRequest(req); tx != nil {
+			ret = append(ret, tx.Hash())
+		}
+	}
+	return ret
+}
+
 func (e *EVMChain) GasRatio() util.Ratio32 {
 	e.log.Debugf("GasRatio()")
 	govPartition := subrealm.NewReadOnly(lo.Must(e.backend.ISCLatestState()), kv.Key(governance.Contract.Hname().Bytes()))
@@ -160,9 +282,12 @@ func (e *EVMChain) GasRatio() util.Ratio32 {
 }
 
 func (e *EVMChain) GasFeePolicy() *gas.FeePolicy {
-	govPartition := subrealm.NewReadOnly(lo.Must(e.backend.ISCLatestState()), kv.Key(governance.Contract.Hname().Bytes()))
-	gasFeePolicy := governance.MustGetGasFeePolicy(govPartition)
-	return gasFeePolicy
+	return gasFeePolicy(lo.Must(e.backend.ISCLatestState()))
+}
+
+func gasFeePolicy(chainState state.State) *gas.FeePolicy {
+	govPartition := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
+	return governance.MustGetGasFeePolicy(govPartition)
 }
 
 func (e *EVMChain) gasLimits() *gas.Limits {
@@ -185,7 +310,37 @@ func (e *EVMChain) SendTransaction(tx *types.Transaction) error {
 	if err != nil {
 		return fmt.Errorf("invalid transaction: %w", err)
 	}
+	if err := e.checkTransaction(sender, tx); err != nil {
+		return err
+	}
+	return e.backend.EVMSendTransaction(tx)
+}
+
+// SendImpersonatedTransaction sends the unsigned tx on behalf of the sender.
+// It is only supported by development chains.
+func (e *EVMChain) SendImpersonatedTransaction(tx *types.Transaction, sender common.Address) error {
+	e.log.Debugf("SendImpersonatedTransaction(tx=%v, sender=%v)", tx, sender)
+	dev, err := e.DevBackend()
+	if err != nil {
+		return err
+	}
+	if err := e.checkTransaction(sender, tx); err != nil {
+		return err
+	}
+	return dev.DevSendImpersonatedTransaction(tx, sender)
+}
+
+// DevBackend returns the backend of the development chain, or an error if the
+// chain does not support the development methods.
+func (e *EVMChain) DevBackend() (DevChainBackend, error) {
+	dev, ok := e.backend.(DevChainBackend)
+	if !ok {
+		return nil, errors.New("method is only supported on development chains")
+	}
+	return dev, nil
+}
 
+func (e *EVMChain) checkTransaction(sender common.Address, tx *types.Transaction) error {
 	expectedNonce, err := e.TransactionCount(sender, nil)
 	if err != nil {
 		return fmt.Errorf("invalid transaction: %w", err)
@@ -198,10 +353,7 @@ func (e *EVMChain) SendTransaction(tx *types.Transaction) error {
 	if err := evmutil.CheckGasPrice(tx.GasPrice(), gasFeePolicy); err != nil {
 		return err
 	}
-	if err := e.checkEnoughL2FundsForGasBudget(sender, tx, gasFeePolicy); err != nil {
-		return err
-	}
-	return e.backend.EVMSendTransaction(tx)
+	return e.checkEnoughL2FundsForGasBudget(sender, tx, gasFeePolicy)
 }
 
 func (e *EVMChain) checkEnoughL2FundsForGasBudget(sender common.Address, tx *types.Transaction, gasFeePolicy *gas.FeePolicy) error {
@@ -502,6 +654,151 @@ func (e *EVMChain) GasPrice() *big.Int {
 	return e.GasFeePolicy().DefaultGasPriceFullDecimals(parameters.L1().BaseToken.Decimals)
 }
 
+// maxFeeHistory is the maximum number of blocks that can be requested via
+// eth_feeHistory.
+const maxFeeHistory = 1024
+
+// FeeHistory returns the fee market history of the requested block range.
+// The base fee of each block is the gas price set by the fee policy in effect
+// at that block, and the gas used ratio is computed from the ISC gas burned in
+// the block.
+func (e *EVMChain) FeeHistory(blockCount uint64, newestBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	e.log.Debugf("FeeHistory(blockCount=%v, newestBlock=%v, rewardPercentiles=%v)", blockCount, newestBlock, rewardPercentiles)
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p <= rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("invalid reward percentile: #%d:%f >= #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+
+	latestState, err := e.backend.ISCLatestState()
+	if err != nil {
+		return nil, err
+	}
+	latest := evmBlockNumberByISCBlockIndex(latestState.BlockIndex())
+	newest := latest
+	if n := parseBlockNumber(newestBlock); n != nil {
+		if !n.IsUint64() || n.Uint64() > latest {
+			return nil, fmt.Errorf("requested block %v is ahead of the latest block %v", n, latest)
+		}
+		newest = n.Uint64()
+	}
+	blockCount = min(blockCount, maxFeeHistory, newest+1)
+	oldest := newest + 1 - blockCount
+
+	result := &FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(new(big.Int).SetUint64(oldest)),
+		BaseFee:      make([]*hexutil.Big, 0, blockCount+1),
+		GasUsedRatio: make([]float64, 0, blockCount),
+	}
+	if blockCount == 0 {
+		return result, nil
+	}
+	if len(rewardPercentiles) > 0 {
+		result.Reward = make([][]*hexutil.Big, 0, blockCount)
+	}
+	for n := oldest; n <= newest; n++ {
+		chainState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, err
+		}
+		baseFee, gasUsedRatio, rewards, err := blockFeeHistory(chainState, n, rewardPercentiles)
+		if err != nil {
+			return nil, err
+		}
+		result.BaseFee = append(result.BaseFee, (*hexutil.Big)(baseFee))
+		result.GasUsedRatio = append(result.GasUsedRatio, gasUsedRatio)
+		if result.Reward != nil {
+			result.Reward = append(result.Reward, rewards)
+		}
+	}
+
+	// base fee of the block following the newest one
+	nextBaseFee := result.BaseFee[len(result.BaseFee)-1]
+	if newest < latest {
+		nextState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(newest + 1))
+		if err != nil {
+			return nil, err
+		}
+		nextBaseFee = (*hexutil.Big)(gasFeePolicy(nextState).DefaultGasPriceFullDecimals(parameters.L1().BaseToken.Decimals))
+	}
+	result.BaseFee = append(result.BaseFee, nextBaseFee)
+	return result, nil
+}
+
+// blockFeeHistory returns the base fee, the gas used ratio and the rewards
+// paid at the given percentiles for a single block.
+func blockFeeHistory(chainState state.State, blockNumber uint64, rewardPercentiles []float64) (*big.Int, float64, []*hexutil.Big, error) {
+	feePolicy := gasFeePolicy(chainState)
+	baseFee := feePolicy.DefaultGasPriceFullDecimals(parameters.L1().BaseToken.Decimals)
+
+	var gasUsedRatio float64
+	blocklogPartition := subrealm.NewReadOnly(chainState, kv.Key(blocklog.Contract.Hname().Bytes()))
+	blockInfo, ok := blocklog.GetBlockInfo(blocklogPartition, uint32(blockNumber))
+	if !ok {
+		return nil, 0, nil, fmt.Errorf("block not found: %d", blockNumber)
+	}
+	govPartition := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
+	if maxGas := governance.MustGetGasLimits(govPartition).MaxGasPerBlock; maxGas > 0 {
+		gasUsedRatio = float64(blockInfo.GasBurned) / float64(maxGas)
+	}
+
+	if len(rewardPercentiles) == 0 {
+		return baseFee, gasUsedRatio, nil, nil
+	}
+	rewards := make([]*hexutil.Big, len(rewardPercentiles))
+	for i := range rewards {
+		rewards[i] = (*hexutil.Big)(new(big.Int))
+	}
+	db := blockchainDB(chainState)
+	block := db.GetBlockByNumber(blockNumber)
+	receipts := db.GetReceiptsByBlockNumber(blockNumber)
+	if block == nil || len(block.Transactions()) == 0 || len(receipts) != len(block.Transactions()) {
+		return baseFee, gasUsedRatio, rewards, nil
+	}
+
+	type txGasAndReward struct {
+		gasUsed uint64
+		reward  *big.Int
+	}
+	sorted := make([]txGasAndReward, len(receipts))
+	for i, tx := range block.Transactions() {
+		reward := new(big.Int).Sub(effectiveGasPrice(tx, feePolicy), baseFee)
+		if reward.Sign() < 0 {
+			reward.SetUint64(0)
+		}
+		sorted[i] = txGasAndReward{gasUsed: receipts[i].GasUsed, reward: reward}
+	}
+	slices.SortStableFunc(sorted, func(a, b txGasAndReward) int {
+		return a.reward.Cmp(b.reward)
+	})
+
+	txIndex := 0
+	sumGasUsed := sorted[0].gasUsed
+	for i, p := range rewardPercentiles {
+		thresholdGasUsed := uint64(float64(block.GasUsed()) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].gasUsed
+		}
+		rewards[i] = (*hexutil.Big)(sorted[txIndex].reward)
+	}
+	return baseFee, gasUsedRatio, rewards, nil
+}
+
+// effectiveGasPrice returns the gas price paid by the tx, given the fee
+// policy in effect at the block where it was included.
+func effectiveGasPrice(tx *types.Transaction, feePolicy *gas.FeePolicy) *big.Int {
+	gasPrice := evmutil.EffectiveGasPrice(tx.GasPrice(), tx.GasFeeCap(), tx.GasTipCap(), feePolicy)
+	if gasPrice.Sign() == 0 && !feePolicy.GasPerToken.IsEmpty() {
+		// tx sent before gasPrice was mandatory
+		gasPrice = feePolicy.DefaultGasPriceFullDecimals(parameters.L1().BaseToken.Decimals)
+	}
+	return gasPrice
+}
+
 func (e *EVMChain) StorageAt(address common.Address, key common.Hash, blockNumberOrHash *rpc.BlockNumberOrHash) (common.Hash, error) {
 	e.log.Debugf("StorageAt(address=%v, key=%v, blockNumberOrHash=%v)", address, key, blockNumberOrHash)
 	chainState, err := e.iscStateFromEVMBlockNumberOrHash(blockNumberOrHash)
@@ -511,6 +808,70 @@ func (e *EVMChain) StorageAt(address common.Address, key common.Hash, blockNumbe
 	return emulator.GetState(stateDBSubrealmR(chainState), address, key), nil
 }
 
+// GetProof returns the Merkle proofs of the account and of the given storage
+// slots, anchored to the ISC state committed in the alias output of the
+// requested block.
+func (e *EVMChain) GetProof(
+	address common.Address,
+	storageKeys []common.Hash,
+	blockNumberOrHash *rpc.BlockNumberOrHash,
+) (*AccountProofResult, error) {
+	e.log.Debugf("GetProof(address=%v, storageKeys=%v, blockNumberOrHash=%v)", address, storageKeys, blockNumberOrHash)
+	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	l1c, err := transaction.L1CommitmentFromAliasOutput(aliasOutput.GetAliasOutput())
+	if err != nil {
+		return nil, err
+	}
+	chainState, err := e.backend.ISCStateByTrieRoot(l1c.TrieRoot())
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := *e.backend.ISCChainID()
+	accountKey := accounts.AccountKey(isc.NewEthereumAddressAgentID(chainID, address), chainID)
+	accountsPrefix := kv.Key(accounts.Contract.Hname().Bytes())
+	accountProof := []hexutil.Bytes{
+		chainState.GetMerkleProof([]byte(stateDBPrefix + emulator.AccountNonceKey(address))).Bytes(),
+		chainState.GetMerkleProof([]byte(stateDBPrefix + emulator.AccountCodeKey(address))).Bytes(),
+		chainState.GetMerkleProof([]byte(accountsPrefix + accounts.BaseTokensKey(accountKey))).Bytes(),
+	}
+
+	stateDB := stateDBSubrealmR(chainState)
+	storageProof := make([]StorageProofResult, len(storageKeys))
+	for i, key := range storageKeys {
+		proof := chainState.GetMerkleProof([]byte(stateDBPrefix + emulator.AccountStateKey(address, key)))
+		storageProof[i] = StorageProofResult{
+			Key:   key.Hex(),
+			Value: (*hexutil.Big)(emulator.GetState(stateDB, address, key).Big()),
+			Proof: []hexutil.Bytes{proof.Bytes()},
+		}
+	}
+
+	codeHash := types.EmptyCodeHash
+	if code := emulator.GetCode(stateDB, address); len(code) > 0 {
+		codeHash = crypto.Keccak256Hash(code)
+	}
+	balance := accounts.GetBaseTokensBalanceFullDecimals(
+		chainState.SchemaVersion(),
+		subrealm.NewReadOnly(chainState, accountsPrefix),
+		isc.NewEthereumAddressAgentID(chainID, address),
+		chainID,
+	)
+	return &AccountProofResult{
+		Address:      address,
+		AccountProof: accountProof,
+		Balance:      (*hexutil.Big)(balance),
+		CodeHash:     codeHash,
+		Nonce:        hexutil.Uint64(emulator.GetNonce(stateDB, address)),
+		StorageHash:  common.BytesToHash(l1c.TrieRoot().Bytes()),
+		StorageProof: storageProof,
+		L1Commitment: l1c.Bytes(),
+	}, nil
+}
+
 func (e *EVMChain) BlockTransactionCountByHash(blockHash common.Hash) uint64 {
 	e.log.Debugf("BlockTransactionCountByHash(blockHash=%v)", blockHash)
 	block := e.BlockByHash(blockHash)
@@ -571,31 +932,86 @@ func (e *EVMChain) Logs(query *ethereum.FilterQuery, params *LogsLimits) ([]*typ
 	if !from.IsUint64() || !to.IsUint64() {
 		return nil, errors.New("block number is too large")
 	}
-	{
-		from := from.Uint64()
-		to := to.Uint64()
-		if to > from && to-from > uint64(params.MaxBlocksInLogsFilterRange) {
-			return nil, errors.New("ServerError(-32000) too many blocks in filter range") // ServerError(-32000) part is necessary because subgraph expects that string in the error msg: https://github.com/graphprotocol/graph-node/blob/591ad93b5144ff5e6037b73862c607effad90e7f/chain/ethereum/src/ethereum_adapter.rs#L335
+	blocks, err := e.blocksToFilterLogs(query, from.Uint64(), to.Uint64(), params.MaxBlocksInLogsFilterRange)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range blocks {
+		state, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, err
 		}
-		for i := from; i <= to; i++ {
-			state, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(i))
-			if err != nil {
-				return nil, err
-			}
-			err = filterAndAppendToLogs(
-				query,
-				blockchainDB(state).GetReceiptsByBlockNumber(i),
-				&logs,
-				params.MaxLogsInResult,
-			)
-			if err != nil {
-				return nil, err
-			}
+		err = filterAndAppendToLogs(
+			query,
+			blockchainDB(state).GetReceiptsByBlockNumber(i),
+			&logs,
+			params.MaxLogsInResult,
+		)
+		if err != nil {
+			return nil, err
 		}
 	}
 	return logs, nil
 }
 
+var errTooManyBlocksInFilterRange = errors.New("ServerError(-32000) too many blocks in filter range") // ServerError(-32000) part is necessary because subgraph expects that string in the error msg: https://github.com/graphprotocol/graph-node/blob/591ad93b5144ff5e6037b73862c607effad90e7f/chain/ethereum/src/ethereum_adapter.rs#L335
+
+// blocksToFilterLogs returns the blocks in the range [from, to] that need to
+// be inspected in order to find the logs matching the query. Blocks covered by
+// the log index are only returned if they may contain matching logs; the rest
+// of the range has to be scanned block by block.
+func (e *EVMChain) blocksToFilterLogs(query *ethereum.FilterQuery, from, to uint64, maxBlocks int) ([]uint64, error) {
+	first, last, ok := e.index.LogsIndexedRange()
+	if !ok || to < uint64(first) || from > uint64(last) {
+		if to > from && to-from > uint64(maxBlocks) {
+			return nil, errTooManyBlocksInFilterRange
+		}
+		return blockRange(from, to), nil
+	}
+	var notIndexed uint64
+	if from < uint64(first) {
+		notIndexed += uint64(first) - from
+	}
+	if to > uint64(last) {
+		notIndexed += to - uint64(last)
+	}
+	if notIndexed > uint64(maxBlocks)+1 {
+		return nil, errTooManyBlocksInFilterRange
+	}
+
+	var blocks []uint64
+	if from < uint64(first) {
+		blocks = append(blocks, blockRange(from, uint64(first)-1)...)
+	}
+	for _, i := range e.index.BlocksWithMatchingLogs(
+		uint32(max(from, uint64(first))),
+		uint32(min(to, uint64(last))),
+		query.Addresses,
+		query.Topics,
+	) {
+		blocks = append(blocks, uint64(i))
+	}
+	if to > uint64(last) {
+		// the latest blocks may not be indexed yet
+		blocks = append(blocks, blockRange(uint64(last)+1, to)...)
+	}
+	if len(blocks) > maxBlocks+1 {
+		return nil, errTooManyBlocksInFilterRange
+	}
+	return blocks, nil
+}
+
+func blockRange(from, to uint64) []uint64 {
+	if to < from {
+		return nil
+	}
+	ret := make([]uint64, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		ret = append(ret, i)
+	}
+	return ret
+}
+
 func filterAndAppendToLogs(query *ethereum.FilterQuery, receipts []*types.Receipt, logs *[]*types.Log, maxLogsInResult int) error {
 	for _, r := range receipts {
 		if r.Status == types.ReceiptStatusFailed {
@@ -632,28 +1048,33 @@ func (e *EVMChain) SubscribeNewHeads(ch chan<- *types.Header) (unsubscribe func(
 func (e *EVMChain) SubscribeLogs(q *ethereum.FilterQuery, ch chan<- []*types.Log) (unsubscribe func()) {
 	e.log.Debugf("SubscribeLogs(q=%v, ch=?)", q)
 	return e.newBlock.Hook(func(ev *NewBlockEvent) {
-		if q.BlockHash != nil && *q.BlockHash != ev.block.Hash() {
-			return
-		}
-		if q.FromBlock != nil && q.FromBlock.IsUint64() && q.FromBlock.Cmp(ev.block.Number()) > 0 {
-			return
-		}
-		if q.ToBlock != nil && q.ToBlock.IsUint64() && q.ToBlock.Cmp(ev.block.Number()) < 0 {
-			return
-		}
-
-		var matchedLogs []*types.Log
-		for _, log := range ev.logs {
-			if evmtypes.LogMatches(log, q.Addresses, q.Topics) {
-				matchedLogs = append(matchedLogs, log)
-			}
-		}
-		if len(matchedLogs) > 0 {
+		if matchedLogs := ev.matchingLogs(q); len(matchedLogs) > 0 {
 			ch <- matchedLogs
 		}
 	}).Unhook
 }
 
+// matchingLogs returns the logs of the block that match the given query.
+func (ev *NewBlockEvent) matchingLogs(q *ethereum.FilterQuery) []*types.Log {
+	if q.BlockHash != nil && *q.BlockHash != ev.block.Hash() {
+		return nil
+	}
+	if q.FromBlock != nil && q.FromBlock.IsUint64() && q.FromBlock.Cmp(ev.block.Number()) > 0 {
+		return nil
+	}
+	if q.ToBlock != nil && q.ToBlock.IsUint64() && q.ToBlock.Cmp(ev.block.Number()) < 0 {
+		return nil
+	}
+
+	var matchedLogs []*types.Log
+	for _, log := range ev.logs {
+		if evmtypes.LogMatches(log, q.Addresses, q.Topics) {
+			matchedLogs = append(matchedLogs, log)
+		}
+	}
+	return matchedLogs
+}
+
 func (e *EVMChain) iscRequestsInBlock(evmBlockNumber uint64) (*blocklog.BlockInfo, []isc.Request, error) {
 	iscState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(evmBlockNumber))
 	if err != nil {
@@ -675,9 +1096,9 @@ func (e *EVMChain) traceTransaction(
 	txIndex uint64,
 	blockHash common.Hash,
 ) (json.RawMessage, error) {
-	tracerType := "callTracer"
-	if config.Tracer != nil {
-		tracerType = *config.Tracer
+	tracerType, tracerConfig, err := tracerTypeAndConfig(config)
+	if err != nil {
+		return nil, err
 	}
 
 	blockNumber := uint64(blockInfo.BlockIndex())
@@ -687,7 +1108,7 @@ func (e *EVMChain) traceTransaction(
 		BlockNumber: new(big.Int).SetUint64(blockNumber),
 		TxIndex:     int(txIndex),
 		TxHash:      tx.Hash(),
-	}, config.TracerConfig, false, nil)
+	}, tracerConfig, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -717,9 +1138,9 @@ func (e *EVMChain) debugTraceBlock(config *tracers.TraceConfig, block *types.Blo
 		return nil, err
 	}
 
-	tracerType := "callTracer"
-	if config.Tracer != nil {
-		tracerType = *config.Tracer
+	tracerType, tracerConfig, err := tracerTypeAndConfig(config)
+	if err != nil {
+		return nil, err
 	}
 
 	blockNumber := uint64(iscBlock.BlockIndex())
@@ -729,7 +1150,7 @@ func (e *EVMChain) debugTraceBlock(config *tracers.TraceConfig, block *types.Blo
 	tracer, err := newTracer(tracerType, &tracers.Context{
 		BlockHash:   block.Hash(),
 		BlockNumber: new(big.Int).SetUint64(blockNumber),
-	}, config.TracerConfig, true, blockTxs)
+	}, tracerConfig, true, blockTxs)
 	if err != nil {
 		return nil, err
 	}
@@ -866,9 +1287,10 @@ func (e *EVMChain) TraceBlock(bn rpc.BlockNumber) (any, error) {
 		ID:      1,
 	}
 
+	callTracer := "callTracer"
 	for i, tx := range blockTxs {
 		debugResultJSON, err := e.traceTransaction(
-			&tracers.TraceConfig{},
+			&tracers.TraceConfig{Tracer: &callTracer},
 			iscBlock,
 			iscRequestsInBlock,
 			tx,
@@ -902,6 +1324,20 @@ func iscBlockIndexByEVMBlockNumber(blockNumber *big.Int) (uint32, error) {
 	return uint32(blockNumber.Uint64()), nil
 }
 
+// evmTransactionFromRequest returns the EVM transaction wrapped by the
+// request, or nil if the request is not an EVM transaction.
+func evmTransactionFromRequest(req isc.Request) *types.Transaction {
+	target := req.CallTarget()
+	if target.Contract != evm.Contract.Hname() || target.EntryPoint != evm.FuncSendTransaction.Hname() {
+		return nil
+	}
+	tx, err := evmtypes.DecodeTransaction(req.Params().Get(evm.FieldTransaction))
+	if err != nil {
+		return nil
+	}
+	return tx
+}
+
 // the first EVM block (number 0) is "minted" at ISC block index 1 (init chain)
 func evmBlockNumberByISCBlockIndex(n uint32) uint64 {
 	return uint64(n)
@@ -919,6 +1355,9 @@ func blockchainDB(chainState state.State) *emulator.BlockchainDB {
 	)
 }
 
+// stateDBPrefix is the prefix of the emulator StateDB keys in the ISC state
+var stateDBPrefix = kv.Key(evm.Contract.Hname().Bytes()) + evm.KeyEmulatorState + emulator.KeyStateDB
+
 func stateDBSubrealmR(chainState state.State) kv.KVStoreReader {
 	return emulator.StateDBSubrealmR(evm.EmulatorStateSubrealmR(evm.ContractPartitionR(chainState)))
 }
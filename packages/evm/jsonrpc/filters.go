@@ -0,0 +1,312 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/samber/lo"
+)
+
+var (
+	errFilterNotFound = errors.New("filter not found")
+	errTooManyFilters = errors.New("too many filters installed")
+)
+
+// minFilterTimeoutTick is the shortest interval at which expired filters are
+// checked, so that very small timeouts don't turn into a busy loop.
+const minFilterTimeoutTick = 10 * time.Millisecond
+
+type filterType int
+
+const (
+	logsFilter filterType = iota
+	blocksFilter
+	pendingTxFilter
+)
+
+// filter holds the changes accumulated by an installed filter since the
+// last time they were polled.
+type filter struct {
+	typ      filterType
+	query    *ethereum.FilterQuery // only for logsFilter
+	deadline time.Time
+	hashes   []common.Hash
+	logs     []*types.Log
+	unhook   func()
+	// pending holds the hashes of the transactions in the mempool which were
+	// already reported, only for pendingTxFilter
+	pending map[common.Hash]struct{}
+}
+
+// FilterLimits bounds the resources used by the filters installed via
+// eth_newFilter and friends.
+type FilterLimits struct {
+	// Timeout is the duration after which a filter that is not polled is
+	// uninstalled. A non-positive value is replaced by DefaultFilterTimeout.
+	Timeout time.Duration
+	// MaxFilters is the maximum number of filters installed at the same time.
+	MaxFilters int
+	// MaxItemsPerFilter is the maximum number of logs or hashes kept by a
+	// filter between two polls; the newer ones are dropped.
+	MaxItemsPerFilter int
+}
+
+const DefaultFilterTimeout = 5 * time.Minute
+
+// filterManager keeps track of the filters installed via eth_newFilter,
+// eth_newBlockFilter and eth_newPendingTransactionFilter. Filters that are
+// not polled for longer than the configured timeout are uninstalled
+// automatically.
+type filterManager struct {
+	evmChain *EVMChain
+	limits   FilterLimits
+
+	mutex       sync.Mutex
+	filters     map[rpc.ID]*filter
+	loopRunning bool
+}
+
+func newFilterManager(evmChain *EVMChain, limits FilterLimits) *filterManager {
+	if limits.Timeout <= 0 {
+		limits.Timeout = DefaultFilterTimeout
+	}
+	return &filterManager{
+		evmChain: evmChain,
+		limits:   limits,
+		filters:  make(map[rpc.ID]*filter),
+	}
+}
+
+func (m *filterManager) install(f *filter) (rpc.ID, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.limits.MaxFilters > 0 && len(m.filters) >= m.limits.MaxFilters {
+		return "", errTooManyFilters
+	}
+	id := rpc.NewID()
+	f.deadline = time.Now().Add(m.limits.Timeout)
+	m.filters[id] = f
+	if !m.loopRunning {
+		m.loopRunning = true
+		go m.timeoutLoop()
+	}
+	return id, nil
+}
+
+// hook subscribes the installed filter to the new blocks. The handler is
+// called by the goroutine publishing the blocks, so it must not block: it
+// only appends to the filter's buffer.
+func (m *filterManager) hook(id rpc.ID, f *filter, handler func(ev *NewBlockEvent)) {
+	unhook := m.evmChain.newBlock.Hook(handler).Unhook
+	m.mutex.Lock()
+	if m.filters[id] == f {
+		f.unhook = unhook
+		m.mutex.Unlock()
+		return
+	}
+	m.mutex.Unlock()
+	unhook() // expired or uninstalled meanwhile
+}
+
+// bufferSpace returns how many more items the filter can buffer.
+func (m *filterManager) bufferSpace(buffered int) int {
+	if m.limits.MaxItemsPerFilter <= 0 {
+		return math.MaxInt
+	}
+	return max(0, m.limits.MaxItemsPerFilter-buffered)
+}
+
+func (m *filterManager) NewLogsFilter(q *ethereum.FilterQuery) (rpc.ID, error) {
+	f := &filter{typ: logsFilter, query: q}
+	id, err := m.install(f)
+	if err != nil {
+		return "", err
+	}
+	m.hook(id, f, func(ev *NewBlockEvent) {
+		logs := ev.matchingLogs(q)
+		if len(logs) == 0 {
+			return
+		}
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		f.logs = append(f.logs, logs[:min(len(logs), m.bufferSpace(len(f.logs)))]...)
+	})
+	return id, nil
+}
+
+// NewBlocksFilter installs a filter that collects the hashes of new blocks.
+func (m *filterManager) NewBlocksFilter() (rpc.ID, error) {
+	f := &filter{typ: blocksFilter}
+	id, err := m.install(f)
+	if err != nil {
+		return "", err
+	}
+	m.hook(id, f, func(ev *NewBlockEvent) {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		if m.bufferSpace(len(f.hashes)) > 0 {
+			f.hashes = append(f.hashes, ev.block.Hash())
+		}
+	})
+	return id, nil
+}
+
+// NewPendingTxFilter installs a filter that reports the hashes of the EVM
+// transactions that entered the mempool since the last poll. The mempool is
+// checked when the filter is polled, so a transaction that enters and leaves
+// the mempool between two polls is not reported.
+func (m *filterManager) NewPendingTxFilter() (rpc.ID, error) {
+	pending := lo.SliceToMap(m.evmChain.PendingTransactionHashes(), func(h common.Hash) (common.Hash, struct{}) {
+		return h, struct{}{}
+	})
+	return m.install(&filter{typ: pendingTxFilter, pending: pending})
+}
+
+// newPendingHashes returns the hashes of the given mempool transactions that
+// were not reported yet, and forgets the ones that left the mempool.
+// Must be called with the mutex locked.
+func (m *filterManager) newPendingHashes(f *filter, mempoolHashes []common.Hash) []common.Hash {
+	pending := make(map[common.Hash]struct{}, len(mempoolHashes))
+	ret := []common.Hash{}
+	for _, h := range mempoolHashes {
+		if _, ok := f.pending[h]; !ok {
+			if m.bufferSpace(len(ret)) == 0 {
+				continue // reported by a later poll
+			}
+			ret = append(ret, h)
+		}
+		pending[h] = struct{}{}
+	}
+	f.pending = pending
+	return ret
+}
+
+// uninstall must be called with the mutex locked; the returned filter must
+// be unhooked after unlocking it, as the hooks lock the mutex themselves.
+func (m *filterManager) uninstall(id rpc.ID) (*filter, bool) {
+	f, ok := m.filters[id]
+	if !ok {
+		return nil, false
+	}
+	delete(m.filters, id)
+	return f, true
+}
+
+func (f *filter) unhookBlocks() {
+	if f.unhook != nil {
+		f.unhook()
+	}
+}
+
+func (m *filterManager) Uninstall(id rpc.ID) bool {
+	m.mutex.Lock()
+	f, ok := m.uninstall(id)
+	m.mutex.Unlock()
+
+	if ok {
+		f.unhookBlocks()
+	}
+	return ok
+}
+
+// Changes returns the changes accumulated by the filter since the last poll
+// and resets the filter's idle timeout. The result is a []*types.Log for logs
+// filters and a []common.Hash for block and pending transaction filters.
+func (m *filterManager) Changes(id rpc.ID) (any, error) {
+	// the mempool is queried without holding the mutex, which is needed by
+	// the goroutine publishing the blocks
+	var mempoolHashes []common.Hash
+	if typ, ok := m.filterType(id); ok && typ == pendingTxFilter {
+		mempoolHashes = m.evmChain.PendingTransactionHashes()
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	f, ok := m.filters[id]
+	if !ok {
+		return nil, errFilterNotFound
+	}
+	f.deadline = time.Now().Add(m.limits.Timeout)
+
+	switch f.typ {
+	case pendingTxFilter:
+		return m.newPendingHashes(f, mempoolHashes), nil
+	case logsFilter:
+		logs := f.logs
+		f.logs = nil
+		if logs == nil {
+			return []*types.Log{}, nil
+		}
+		return logs, nil
+	}
+	hashes := f.hashes
+	f.hashes = nil
+	if hashes == nil {
+		return []common.Hash{}, nil
+	}
+	return hashes, nil
+}
+
+func (m *filterManager) filterType(id rpc.ID) (filterType, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	f, ok := m.filters[id]
+	if !ok {
+		return 0, false
+	}
+	return f.typ, true
+}
+
+// LogsQuery returns the query of the given logs filter and resets the
+// filter's idle timeout.
+func (m *filterManager) LogsQuery(id rpc.ID) (*ethereum.FilterQuery, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	f, ok := m.filters[id]
+	if !ok || f.typ != logsFilter {
+		return nil, errFilterNotFound
+	}
+	f.deadline = time.Now().Add(m.limits.Timeout)
+	return f.query, nil
+}
+
+// timeoutLoop periodically uninstalls the filters that have not been polled
+// within the timeout. It exits once there are no filters left.
+func (m *filterManager) timeoutLoop() {
+	ticker := time.NewTicker(max(m.limits.Timeout/2, minFilterTimeoutTick))
+	defer ticker.Stop()
+	for now := range ticker.C {
+		var expired []*filter
+		m.mutex.Lock()
+		for id, f := range m.filters {
+			if now.After(f.deadline) {
+				m.uninstall(id)
+				expired = append(expired, f)
+			}
+		}
+		done := len(m.filters) == 0
+		if done {
+			m.loopRunning = false
+		}
+		m.mutex.Unlock()
+
+		for _, f := range expired {
+			f.unhookBlocks()
+		}
+		if done {
+			return
+		}
+	}
+}
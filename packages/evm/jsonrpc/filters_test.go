@@ -0,0 +1,127 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+)
+
+func TestFilterManagerDefaultTimeout(t *testing.T) {
+	m := newFilterManager(nil, FilterLimits{})
+	require.Equal(t, DefaultFilterTimeout, m.limits.Timeout)
+	id, err := m.install(&filter{typ: blocksFilter})
+	require.NoError(t, err)
+	require.True(t, m.loopRunning)
+
+	time.Sleep(2 * minFilterTimeoutTick)
+	_, err = m.Changes(id)
+	require.NoError(t, err)
+}
+
+func TestFilterManagerTinyTimeout(t *testing.T) {
+	m := newFilterManager(nil, FilterLimits{Timeout: time.Nanosecond})
+	id, err := m.install(&filter{typ: blocksFilter})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		_, ok := m.filters[id]
+		return !ok && !m.loopRunning
+	}, time.Second, minFilterTimeoutTick)
+}
+
+func TestFilterManagerLimits(t *testing.T) {
+	evmChain := &EVMChain{newBlock: event.New1[*NewBlockEvent]()}
+	m := newFilterManager(evmChain, FilterLimits{MaxFilters: 2, MaxItemsPerFilter: 3})
+
+	blocksID, err := m.NewBlocksFilter()
+	require.NoError(t, err)
+	logsID, err := m.NewLogsFilter(&ethereum.FilterQuery{})
+	require.NoError(t, err)
+	_, err = m.NewBlocksFilter()
+	require.ErrorIs(t, err, errTooManyFilters)
+
+	for i := int64(1); i <= 5; i++ {
+		evmChain.newBlock.Trigger(&NewBlockEvent{
+			block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(i)}),
+			logs:  []*types.Log{{Index: 0}, {Index: 1}},
+		})
+	}
+	hashes, err := m.Changes(blocksID)
+	require.NoError(t, err)
+	require.Len(t, hashes, 3)
+	logs, err := m.Changes(logsID)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+
+	// the buffer is emptied by polling
+	evmChain.newBlock.Trigger(&NewBlockEvent{block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(6)})})
+	hashes, err = m.Changes(blocksID)
+	require.NoError(t, err)
+	require.Len(t, hashes, 1)
+
+	// uninstalling frees a slot and unhooks the filter
+	f := m.filters[blocksID]
+	require.True(t, m.Uninstall(blocksID))
+	_, err = m.NewBlocksFilter()
+	require.NoError(t, err)
+	evmChain.newBlock.Trigger(&NewBlockEvent{block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(7)})})
+	require.Empty(t, f.hashes)
+}
+
+type mempoolBackend struct {
+	ChainBackend
+	reqs []isc.OffLedgerRequest
+}
+
+func (b *mempoolBackend) ISCMempoolOffLedgerRequests() []isc.OffLedgerRequest {
+	return b.reqs
+}
+
+func TestFilterManagerPendingTxs(t *testing.T) {
+	backend := &mempoolBackend{}
+	m := newFilterManager(&EVMChain{backend: backend, newBlock: event.New1[*NewBlockEvent]()}, FilterLimits{MaxItemsPerFilter: 2})
+	chainID := isc.RandomChainID()
+	var txs []*types.Transaction
+	addTx := func() {
+		tx := types.NewTransaction(uint64(len(txs)), common.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+		txs = append(txs, tx)
+		backend.reqs = append(backend.reqs, isc.NewImpersonatedEVMOffLedgerTxRequest(chainID, tx, common.Address{}))
+	}
+
+	// the transactions in the mempool before the filter was installed are not reported
+	addTx()
+	id, err := m.NewPendingTxFilter()
+	require.NoError(t, err)
+	hashes, err := m.Changes(id)
+	require.NoError(t, err)
+	require.Empty(t, hashes)
+
+	// the new ones are reported once, up to the limit per poll
+	addTx()
+	addTx()
+	addTx()
+	hashes, err = m.Changes(id)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{txs[1].Hash(), txs[2].Hash()}, hashes)
+	hashes, err = m.Changes(id)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{txs[3].Hash()}, hashes)
+
+	// mined transactions leave the mempool and are not reported by the filter
+	backend.reqs = backend.reqs[:1]
+	m.evmChain.newBlock.Trigger(&NewBlockEvent{block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody(types.Body{Transactions: txs[1:]})})
+	hashes, err = m.Changes(id)
+	require.NoError(t, err)
+	require.Empty(t, hashes)
+}
@@ -1,9 +1,12 @@
 package jsonrpcindex
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
+	"math/bits"
+	"slices"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +15,7 @@ import (
 	hivedb "github.com/iotaledger/hive.go/db"
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/nnikolash/wasp-types-exported/packages/database"
+	"github.com/nnikolash/wasp-types-exported/packages/evm/evmtypes"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
 	"github.com/nnikolash/wasp-types-exported/packages/state"
 	"github.com/nnikolash/wasp-types-exported/packages/trie"
@@ -26,6 +30,18 @@ type Index struct {
 	stateByTrieRoot func(trieRoot trie.Hash) (state.State, error)
 
 	mu sync.Mutex
+	// the latest block passed to IndexBlock, the backfill of the logs index
+	// walks back from its state
+	latestTrieRoot trie.Hash
+	latestIndex    uint32
+	// oldestIndex is the oldest block which is not pruned from the state, the
+	// logs of the older blocks are removed from the index
+	oldestIndex uint32
+	// backfill wakes up the goroutine indexing the logs of the missing blocks
+	backfill chan struct{}
+	// stop makes the backfill goroutine exit, which closes backfillDone
+	stop         chan struct{}
+	backfillDone chan struct{}
 }
 
 func New(
@@ -38,22 +54,45 @@ func New(
 	if err != nil {
 		panic(err)
 	}
-	return &Index{
+	c := &Index{
 		store:           db.KVStore(),
 		blockchainDB:    blockchainDB,
 		stateByTrieRoot: stateByTrieRoot,
 		mu:              sync.Mutex{},
+		backfill:        make(chan struct{}, 1),
+		stop:            make(chan struct{}),
+		backfillDone:    make(chan struct{}),
+	}
+	go c.backfillLogs()
+	return c
+}
+
+// Close stops the backfill of the logs index and closes the index database.
+// No blocks must be indexed after the index is closed.
+func (c *Index) Close() {
+	close(c.stop)
+	<-c.backfillDone
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.store.Flush(); err != nil {
+		panic(err)
+	}
+	if err := c.store.Close(); err != nil {
+		panic(err)
 	}
 }
 
 func (c *Index) IndexBlock(trieRoot trie.Hash) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	defer c.store.Flush()
 	state, err := c.stateByTrieRoot(trieRoot)
 	if err != nil {
 		panic(err)
 	}
 	blockKeepAmount := governance.NewStateAccess(state).GetBlockKeepAmount()
+	c.indexLogs(trieRoot, state, blockKeepAmount)
 	if blockKeepAmount == -1 {
 		return // pruning disabled, never cache anything
 	}
@@ -110,7 +149,6 @@ func (c *Index) IndexBlock(trieRoot trie.Hash) {
 		}
 	}
 	c.setLastBlockIndexed(blockIndexToCache)
-	c.store.Flush()
 }
 
 func (c *Index) BlockByNumber(n *big.Int) *types.Block {
@@ -206,6 +244,240 @@ func (c *Index) TxsByBlockNumber(blockNumber *big.Int) types.Transactions {
 	return block.Transactions()
 }
 
+// LogsIndexedRange returns the range of blocks covered by the log index.
+// The returned bool is false if no blocks have been indexed yet.
+func (c *Index) LogsIndexedRange() (first, last uint32, ok bool) {
+	return c.logsIndexedRange()
+}
+
+// BlocksWithMatchingLogs returns the sorted indexes of the blocks in the
+// range [from, to] that may contain logs matching the given addresses and
+// topics. The range must be covered by the log index (see LogsIndexedRange).
+// False positives are possible, so the logs of the returned blocks still have
+// to be filtered.
+func (c *Index) BlocksWithMatchingLogs(from, to uint32, addresses []common.Address, topics [][]common.Hash) []uint32 {
+	var ret []uint32
+	if len(addresses) == 0 {
+		c.iterateBlockRange(keyPrefixBlockBloomByIndex(), from, to, func(blockIndex uint32, value kvstore.Value) {
+			if evmtypes.BloomFilter(types.BytesToBloom(value), nil, topics) {
+				ret = append(ret, blockIndex)
+			}
+		})
+		return ret
+	}
+
+	var prefixes []kvstore.KeyPrefix
+	for _, addr := range addresses {
+		if len(topics) == 0 || len(topics[0]) == 0 {
+			prefixes = append(prefixes, keyPrefixBlockIndexByLogAddress(addr))
+			continue
+		}
+		for _, topic0 := range topics[0] {
+			prefixes = append(prefixes, keyPrefixBlockIndexByLogAddressAndTopic(addr, topic0))
+		}
+	}
+	for _, prefix := range prefixes {
+		c.iterateBlockRange(prefix, from, to, func(blockIndex uint32, _ kvstore.Value) {
+			ret = append(ret, blockIndex)
+		})
+	}
+	slices.Sort(ret)
+	return slices.Compact(ret)
+}
+
+// iterateBlockRange calls f for each key made of the given prefix followed by
+// a big endian block index in the range [from, to]. As the store can only
+// iterate over prefixes, the range is covered by the prefixes of the aligned
+// pages of blocks it spans (see blockRangePrefixes), so the iteration starts
+// at most one page before from.
+func (c *Index) iterateBlockRange(prefix kvstore.KeyPrefix, from, to uint32, f func(blockIndex uint32, value kvstore.Value)) {
+	for _, pagePrefix := range blockRangePrefixes(from, to) {
+		err := c.store.Iterate(append(slices.Clone(prefix), pagePrefix...), func(key kvstore.Key, value kvstore.Value) bool {
+			blockIndex := binary.BigEndian.Uint32(key[len(key)-4:])
+			if blockIndex >= from && blockIndex <= to {
+				f(blockIndex, value)
+			}
+			return true
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// blockRangePrefixes returns the big endian prefixes of the pages of 2^8,
+// 2^16, 2^24 or 2^32 block indexes that exactly cover the pages of 256 blocks
+// spanned by [from, to], using the largest aligned pages possible.
+func blockRangePrefixes(from, to uint32) [][]byte {
+	var ret [][]byte
+	start := uint64(from) &^ 0xff
+	end := uint64(to) | 0xff
+	for start <= end {
+		size := uint64(1 << 8)
+		for size < 1<<32 && start%(size<<8) == 0 && start+(size<<8)-1 <= end {
+			size <<= 8
+		}
+		prefixLen := 4 - bits.Len64(size-1)/8
+		ret = append(ret, binary.BigEndian.AppendUint32(nil, uint32(start))[:prefixLen])
+		start += size
+	}
+	return ret
+}
+
+// indexLogs indexes the logs of the given state, which is the latest one.
+// If there are blocks missing from the index (e.g. the node was restarted, or
+// the index was created on an existing chain), they are indexed in the
+// background by backfillLogs. The indexed range is only extended when it
+// stays contiguous. On a pruned chain, the logs of the blocks which are not
+// kept anymore are removed from the index.
+func (c *Index) indexLogs(trieRoot trie.Hash, state state.State, blockKeepAmount int32) {
+	blockIndex := state.BlockIndex()
+	c.latestTrieRoot = trieRoot
+	c.latestIndex = blockIndex
+	c.oldestIndex = 0
+	if blockKeepAmount > 0 && blockIndex >= uint32(blockKeepAmount) {
+		c.oldestIndex = blockIndex - uint32(blockKeepAmount) + 1
+	}
+	c.indexBlockLogs(state)
+	first, last, ok := c.logsIndexedRange()
+	switch {
+	case !ok || blockIndex < first:
+		first, last = blockIndex, blockIndex
+	case blockIndex <= last+1:
+		last = max(last, blockIndex)
+	}
+	for ; first < c.oldestIndex && first <= last; first++ {
+		c.deleteBlockLogs(first)
+	}
+	if first > last {
+		// all the blocks of the range were pruned
+		first, last = blockIndex, blockIndex
+	}
+	c.setLogsIndexedRange(first, last)
+	if first > c.oldestIndex || last < blockIndex {
+		select {
+		case c.backfill <- struct{}{}:
+		default: // already signalled
+		}
+	}
+}
+
+// backfillLogs indexes the logs of the blocks missing from the log index:
+// first the ones between the indexed range and the latest block, then the
+// ones below the indexed range, down to the oldest available state. The
+// mutex is only held while indexing each block, so the new blocks are not
+// delayed. It exits when the index is closed.
+func (c *Index) backfillLogs() {
+	defer close(c.backfillDone)
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-c.backfill:
+		}
+		for c.backfillBlockLogs() {
+			select {
+			case <-c.stop:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// backfillBlockLogs indexes the logs of the next missing block, returning
+// false if there are none left or its state is not available anymore.
+func (c *Index) backfillBlockLogs() bool {
+	c.mu.Lock()
+	latestTrieRoot, latestIndex, oldestIndex := c.latestTrieRoot, c.latestIndex, c.oldestIndex
+	first, last, ok := c.logsIndexedRange()
+	c.mu.Unlock()
+
+	var blockIndex uint32
+	switch {
+	case !ok:
+		return false
+	case last < latestIndex:
+		blockIndex = last + 1
+	case first > oldestIndex:
+		blockIndex = first - 1
+	default:
+		return false
+	}
+	blockState, err := c.stateByTrieRoot(latestTrieRoot)
+	if err != nil {
+		return false
+	}
+	if blockIndex < latestIndex {
+		blockInfo, found := blocklog.NewStateAccess(blockState).BlockInfo(blockIndex + 1)
+		if !found {
+			return false
+		}
+		blockState, err = c.stateByTrieRoot(blockInfo.PreviousL1Commitment().TrieRoot())
+		if err != nil {
+			return false // pruned
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if first2, last2, _ := c.logsIndexedRange(); first2 != first || last2 != last {
+		return true // a new block was indexed meanwhile, start over
+	}
+	c.indexBlockLogs(blockState)
+	if blockIndex > last {
+		c.setLogsIndexedRange(first, blockIndex)
+	} else {
+		c.setLogsIndexedRange(blockIndex, last)
+	}
+	if err := c.store.Flush(); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+func (c *Index) indexBlockLogs(state state.State) {
+	blockIndex := state.BlockIndex()
+	db := c.blockchainDB(state)
+	block := db.GetCurrentBlock()
+	if block.Bloom() != (types.Bloom{}) {
+		c.setBlockBloomByIndex(blockIndex, block.Bloom())
+	}
+	// the address and topic pairs of the block are kept to remove its keys when it is pruned
+	var logKeys []byte
+	for _, receipt := range db.GetReceiptsByBlockNumber(uint64(blockIndex)) {
+		for _, log := range receipt.Logs {
+			topic0 := common.Hash{}
+			if len(log.Topics) > 0 {
+				topic0 = log.Topics[0]
+			}
+			key := KeyBlockIndexByLogAddressAndTopic(log.Address, topic0, blockIndex)
+			if c.get(key) != nil {
+				continue
+			}
+			c.set(KeyBlockIndexByLogAddress(log.Address, blockIndex), []byte{})
+			c.set(key, []byte{})
+			logKeys = append(append(logKeys, log.Address[:]...), topic0[:]...)
+		}
+	}
+	if len(logKeys) > 0 {
+		c.set(KeyLogKeysByBlockIndex(blockIndex), logKeys)
+	}
+}
+
+// deleteBlockLogs removes the logs of the given block from the index
+func (c *Index) deleteBlockLogs(blockIndex uint32) {
+	logKeys := c.get(KeyLogKeysByBlockIndex(blockIndex))
+	for ; len(logKeys) >= common.AddressLength+common.HashLength; logKeys = logKeys[common.AddressLength+common.HashLength:] {
+		addr := common.BytesToAddress(logKeys[:common.AddressLength])
+		topic0 := common.BytesToHash(logKeys[common.AddressLength : common.AddressLength+common.HashLength])
+		c.del(KeyBlockIndexByLogAddress(addr, blockIndex))
+		c.del(KeyBlockIndexByLogAddressAndTopic(addr, topic0, blockIndex))
+	}
+	c.del(KeyLogKeysByBlockIndex(blockIndex))
+	c.del(KeyBlockBloomByIndex(blockIndex))
+}
+
 // internals
 
 const (
@@ -213,6 +485,11 @@ const (
 	PrefixBlockTrieRootByIndex
 	PrefixBlockIndexByTxHash
 	PrefixBlockIndexByHash
+	PrefixLogsIndexedRange
+	PrefixBlockBloomByIndex
+	PrefixBlockIndexByLogAddressAndTopic
+	PrefixBlockIndexByLogAddress
+	PrefixLogKeysByBlockIndex
 )
 
 func KeyLastBlockIndexed() kvstore.Key {
@@ -237,6 +514,55 @@ func KeyBlockIndexByHash(hash common.Hash) kvstore.Key {
 	return key
 }
 
+func KeyLogsIndexedRange() kvstore.Key {
+	return []byte{PrefixLogsIndexedRange}
+}
+
+// KeyBlockBloomByIndex returns the key of the bloom of a block with logs. The
+// block index is encoded in big endian so that the blooms are iterated in
+// block order.
+func KeyBlockBloomByIndex(i uint32) kvstore.Key {
+	return binary.BigEndian.AppendUint32(keyPrefixBlockBloomByIndex(), i)
+}
+
+func keyPrefixBlockBloomByIndex() kvstore.KeyPrefix {
+	return []byte{PrefixBlockBloomByIndex}
+}
+
+// KeyBlockIndexByLogAddressAndTopic returns the key that marks the block as
+// containing a log emitted by the given address with the given first topic.
+// The block index is encoded in big endian so that the keys sharing the same
+// address and topic are iterated in block order.
+func KeyBlockIndexByLogAddressAndTopic(addr common.Address, topic0 common.Hash, blockIndex uint32) kvstore.Key {
+	key := keyPrefixBlockIndexByLogAddressAndTopic(addr, topic0)
+	return binary.BigEndian.AppendUint32(key, blockIndex)
+}
+
+// KeyBlockIndexByLogAddress returns the key that marks the block as
+// containing a log emitted by the given address, with any topic.
+func KeyBlockIndexByLogAddress(addr common.Address, blockIndex uint32) kvstore.Key {
+	return binary.BigEndian.AppendUint32(keyPrefixBlockIndexByLogAddress(addr), blockIndex)
+}
+
+// KeyLogKeysByBlockIndex returns the key of the address and first topic pairs
+// of the logs of a block, which are needed to remove them from the index.
+func KeyLogKeysByBlockIndex(blockIndex uint32) kvstore.Key {
+	return binary.BigEndian.AppendUint32([]byte{PrefixLogKeysByBlockIndex}, blockIndex)
+}
+
+func keyPrefixBlockIndexByLogAddress(addr common.Address) kvstore.KeyPrefix {
+	key := []byte{PrefixBlockIndexByLogAddress}
+	key = append(key, addr[:]...)
+	return key
+}
+
+func keyPrefixBlockIndexByLogAddressAndTopic(addr common.Address, topic0 common.Hash) kvstore.KeyPrefix {
+	key := []byte{PrefixBlockIndexByLogAddressAndTopic}
+	key = append(key, addr[:]...)
+	key = append(key, topic0[:]...)
+	return key
+}
+
 func (c *Index) get(key kvstore.Key) []byte {
 	ret, err := c.store.Get(key)
 	if err != nil {
@@ -255,6 +581,13 @@ func (c *Index) set(key kvstore.Key, value []byte) {
 	}
 }
 
+func (c *Index) del(key kvstore.Key) {
+	err := c.store.Delete(key)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (c *Index) setLastBlockIndexed(n uint32) {
 	c.set(KeyLastBlockIndexed(), codec.EncodeUint32(n))
 }
@@ -310,6 +643,22 @@ func (c *Index) blockIndexByHash(hash common.Hash) *uint32 {
 	return &ret
 }
 
+func (c *Index) setLogsIndexedRange(first, last uint32) {
+	c.set(KeyLogsIndexedRange(), append(codec.EncodeUint32(first), codec.EncodeUint32(last)...))
+}
+
+func (c *Index) logsIndexedRange() (first, last uint32, ok bool) {
+	bytes := c.get(KeyLogsIndexedRange())
+	if bytes == nil {
+		return 0, 0, false
+	}
+	return codec.MustDecodeUint32(bytes[:4]), codec.MustDecodeUint32(bytes[4:]), true
+}
+
+func (c *Index) setBlockBloomByIndex(i uint32, bloom types.Bloom) {
+	c.set(KeyBlockBloomByIndex(i), bloom.Bytes())
+}
+
 func (c *Index) evmDBFromBlockIndex(n uint32) *emulator.BlockchainDB {
 	trieRoot := c.blockTrieRootByIndex(n)
 	if trieRoot == nil {
@@ -0,0 +1,70 @@
+package jsonrpcindex
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	hivedb "github.com/iotaledger/hive.go/db"
+)
+
+func TestBlockRangePrefixes(t *testing.T) {
+	require.Equal(t, [][]byte{{}}, blockRangePrefixes(0, math.MaxUint32))
+	require.Equal(t, [][]byte{{0, 0, 0}}, blockRangePrefixes(5, 10))
+	require.Len(t, blockRangePrefixes(250, 70_000), 1+18)
+
+	covers := func(prefixes [][]byte, blockIndex uint32) bool {
+		key := binary.BigEndian.AppendUint32(nil, blockIndex)
+		for _, prefix := range prefixes {
+			if string(key[:len(prefix)]) == string(prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 0; i < 100; i++ {
+		from := rand.Uint32() >> rand.Intn(32)
+		to := from + rand.Uint32()>>rand.Intn(32)
+		if to < from {
+			to = math.MaxUint32
+		}
+		prefixes := blockRangePrefixes(from, to)
+		require.Less(t, len(prefixes), 6*255)
+		for _, blockIndex := range []uint32{from, to, from + (to-from)/2} {
+			require.True(t, covers(prefixes, blockIndex))
+		}
+		// only the pages of from and to can contain blocks outside of the range
+		if from&^0xff > 0 {
+			require.False(t, covers(prefixes, from&^0xff-1))
+		}
+		if to|0xff < math.MaxUint32 {
+			require.False(t, covers(prefixes, to|0xff+1))
+		}
+	}
+}
+
+func TestDeleteBlockLogs(t *testing.T) {
+	c := New(nil, nil, hivedb.EngineMapDB, "")
+	defer c.Close()
+
+	addr := common.Address{1}
+	topic0 := common.Hash{2}
+	for _, blockIndex := range []uint32{1, 2} {
+		c.setBlockBloomByIndex(blockIndex, types.Bloom{3})
+		c.set(KeyBlockIndexByLogAddress(addr, blockIndex), []byte{})
+		c.set(KeyBlockIndexByLogAddressAndTopic(addr, topic0, blockIndex), []byte{})
+		c.set(KeyLogKeysByBlockIndex(blockIndex), append(addr.Bytes(), topic0.Bytes()...))
+	}
+	require.Equal(t, []uint32{1, 2}, c.BlocksWithMatchingLogs(0, 10, []common.Address{addr}, [][]common.Hash{{topic0}}))
+
+	c.deleteBlockLogs(1)
+	require.Equal(t, []uint32{2}, c.BlocksWithMatchingLogs(0, 10, []common.Address{addr}, nil))
+	require.Equal(t, []uint32{2}, c.BlocksWithMatchingLogs(0, 10, []common.Address{addr}, [][]common.Hash{{topic0}}))
+	require.Equal(t, []uint32{2}, c.BlocksWithMatchingLogs(0, 10, nil, nil))
+	require.Nil(t, c.get(KeyLogKeysByBlockIndex(1)))
+}
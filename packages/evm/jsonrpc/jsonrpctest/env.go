@@ -290,6 +290,19 @@ func (e *Env) getLogs(q ethereum.FilterQuery) []types.Log {
 	return logs
 }
 
+func (e *Env) traceTransaction(txHash common.Hash, config tracers.TraceConfig) (json.RawMessage, error) {
+	var res json.RawMessage
+	// we have to use the raw client, because the normal client does not support debug methods
+	err := e.RawClient.CallContext(
+		context.Background(),
+		&res,
+		"debug_traceTransaction",
+		txHash,
+		config,
+	)
+	return res, err
+}
+
 func (e *Env) traceTransactionWithCallTracer(txHash common.Hash) (jsonrpc.CallFrame, error) {
 	var res json.RawMessage
 	// we have to use the raw client, because the normal client does not support debug methods
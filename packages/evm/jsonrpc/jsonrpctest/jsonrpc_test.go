@@ -21,7 +21,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	tracerslogger "github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -35,9 +37,12 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/evm/evmutil"
 	"github.com/nnikolash/wasp-types-exported/packages/evm/jsonrpc"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
 	"github.com/nnikolash/wasp-types-exported/packages/parameters"
 	"github.com/nnikolash/wasp-types-exported/packages/solo"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
 	"github.com/nnikolash/wasp-types-exported/packages/testutil/testlogger"
+	"github.com/nnikolash/wasp-types-exported/packages/trie"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/evm"
 )
 
@@ -48,6 +53,10 @@ type soloTestEnv struct {
 }
 
 func newSoloTestEnv(t testing.TB) *soloTestEnv {
+	return newSoloTestEnvWithParams(t, jsonrpc.ParametersDefault())
+}
+
+func newSoloTestEnvWithParams(t testing.TB, params *jsonrpc.Parameters) *soloTestEnv {
 	var log *logger.Logger
 	if _, ok := t.(*testing.B); ok {
 		log = testlogger.NewSilentLogger(t.Name(), true)
@@ -67,7 +76,7 @@ func newSoloTestEnv(t testing.TB) *soloTestEnv {
 		chain.EVM(),
 		accounts,
 		chain.GetChainMetrics().WebAPI,
-		jsonrpc.ParametersDefault(),
+		params,
 	)
 	require.NoError(t, err)
 	t.Cleanup(rpcsrv.Stop)
@@ -150,6 +159,74 @@ func TestRPCGetStorage(t *testing.T) {
 	require.Equal(t, uint32(42), v)
 }
 
+func TestRPCGetProof(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, contractAddress, _ := env.deployStorageContract(creator)
+
+	getProof := func(address common.Address, keys ...common.Hash) (*jsonrpc.AccountProofResult, *state.L1Commitment) {
+		var res jsonrpc.AccountProofResult
+		err := env.RawClient.Call(&res, "eth_getProof", address, keys, "latest")
+		require.NoError(t, err)
+		require.Equal(t, address, res.Address)
+		require.Len(t, res.AccountProof, 3)
+		require.Len(t, res.StorageProof, len(keys))
+		l1c, err := state.L1CommitmentFromBytes(res.L1Commitment)
+		require.NoError(t, err)
+		require.Equal(t, common.BytesToHash(l1c.TrieRoot().Bytes()), res.StorageHash)
+		return &res, l1c
+	}
+	mustProof := func(b []byte) *trie.MerkleProof {
+		proof, err := trie.MerkleProofFromBytes(b)
+		require.NoError(t, err)
+		return proof
+	}
+
+	// contract: nonce, code and storage slot 0 are present, slot 1 is empty
+	{
+		res, l1c := getProof(contractAddress, common.Hash{}, common.BigToHash(big.NewInt(1)))
+		root := l1c.TrieRoot()
+
+		require.EqualValues(t, 1, res.Nonce)
+		nonceProof := mustProof(res.AccountProof[0])
+		require.NoError(t, nonceProof.ValidateValue(root, codec.EncodeUint64(uint64(res.Nonce))))
+
+		code := env.Code(contractAddress)
+		require.Equal(t, crypto.Keccak256Hash(code), res.CodeHash)
+		codeProof := mustProof(res.AccountProof[1])
+		require.NoError(t, codeProof.ValidateValue(root, code))
+
+		require.Zero(t, res.Balance.ToInt().Sign())
+		balanceProof := mustProof(res.AccountProof[2])
+		require.NoError(t, balanceProof.Validate(root.Bytes()))
+		require.True(t, balanceProof.IsProofOfAbsence())
+
+		require.EqualValues(t, 42, res.StorageProof[0].Value.ToInt().Uint64())
+		require.Len(t, res.StorageProof[0].Proof, 1)
+		slotProof := mustProof(res.StorageProof[0].Proof[0])
+		require.NoError(t, slotProof.ValidateValue(root, common.BigToHash(res.StorageProof[0].Value.ToInt()).Bytes()))
+
+		require.Zero(t, res.StorageProof[1].Value.ToInt().Sign())
+		emptySlotProof := mustProof(res.StorageProof[1].Proof[0])
+		require.NoError(t, emptySlotProof.Validate(root.Bytes()))
+		require.True(t, emptySlotProof.IsProofOfAbsence())
+
+		// the proof does not validate against a different value
+		require.Error(t, slotProof.ValidateValue(root, common.BigToHash(big.NewInt(43)).Bytes()))
+	}
+
+	// EOA: the balance is present, there is no code
+	{
+		res, l1c := getProof(creatorAddress)
+		require.Equal(t, types.EmptyCodeHash, res.CodeHash)
+		require.Equal(t, env.Balance(creatorAddress), res.Balance.ToInt())
+		require.True(t, mustProof(res.AccountProof[1]).IsProofOfAbsence())
+		balanceProof := mustProof(res.AccountProof[2])
+		require.NoError(t, balanceProof.Validate(l1c.TrieRoot().Bytes()))
+		require.False(t, balanceProof.IsProofOfAbsence())
+	}
+}
+
 func TestRPCBlockNumber(t *testing.T) {
 	env := newSoloTestEnv(t)
 	require.EqualValues(t, 0, env.BlockNumber())
@@ -459,6 +536,58 @@ func TestRPCLogIndex(t *testing.T) {
 	require.EqualValues(t, 1, logs[1].Index)
 }
 
+func TestRPCGetLogsIndexed(t *testing.T) {
+	params := jsonrpc.ParametersDefault()
+	params.Logs.MaxBlocksInLogsFilterRange = 5
+	env := newSoloTestEnvWithParams(t, params)
+
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+	require.NoError(t, err)
+	_, _, contractAddress := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+
+	// produce more blocks than allowed in a filter range
+	for i := 0; i < 10; i++ {
+		_, _ = env.soloChain.NewEthereumAccountWithL2Funds()
+	}
+
+	_, recipientAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	callArguments, err := contractABI.Pack("transfer", recipientAddress, big.NewInt(1337))
+	require.NoError(t, err)
+	tx, err := types.SignTx(
+		types.NewTransaction(env.NonceAt(creatorAddress), contractAddress, big.NewInt(0), 100_000, env.MustGetGasPrice(), callArguments),
+		env.Signer(),
+		creator,
+	)
+	require.NoError(t, err)
+	env.mustSendTransactionAndWait(tx)
+
+	// wait until the latest block is indexed
+	require.Eventually(t, func() bool {
+		logs, err := env.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: big.NewInt(1),
+			Addresses: []common.Address{contractAddress},
+		})
+		return err == nil && len(logs) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// without addresses, the blocks are selected by their bloom
+	logs, err := env.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(1),
+		Topics:    [][]common.Hash{{contractABI.Events["Transfer"].ID}},
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+
+	logs, err = env.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(int64(logs[1].BlockNumber)),
+		Addresses: []common.Address{contractAddress},
+		Topics:    [][]common.Hash{{contractABI.Events["Transfer"].ID}},
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+}
+
 func TestRPCEthChainID(t *testing.T) {
 	env := newSoloTestEnv(t)
 	var chainID hexutil.Uint
@@ -467,6 +596,121 @@ func TestRPCEthChainID(t *testing.T) {
 	require.EqualValues(t, evm.DefaultChainID, chainID)
 }
 
+func TestRPCFeeHistory(t *testing.T) {
+	env := newSoloTestEnv(t)
+	from, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, toAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	gasPrice := env.MustGetGasPrice()
+
+	var tip hexutil.Big
+	err := env.RawClient.Call(&tip, "eth_maxPriorityFeePerGas")
+	require.NoError(t, err)
+	require.Zero(t, tip.ToInt().Sign())
+
+	// EIP-1559 tx paying a tip of 1 wei on top of the base fee
+	tx, err := types.SignTx(
+		types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(int64(env.ChainID)),
+			Nonce:     env.NonceAt(crypto.PubkeyToAddress(from.PublicKey)),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: new(big.Int).Mul(gasPrice, big.NewInt(2)),
+			Gas:       100_000,
+			To:        &toAddress,
+			Value:     big.NewInt(0),
+		}),
+		env.Signer(),
+		from,
+	)
+	require.NoError(t, err)
+	receipt := env.mustSendTransactionAndWait(tx)
+	require.EqualValues(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.EqualValues(t, types.DynamicFeeTxType, receipt.Type)
+	require.Equal(t, new(big.Int).Add(gasPrice, big.NewInt(1)), receipt.EffectiveGasPrice)
+
+	rpcTx, err := env.TransactionByBlockNumberAndIndex(receipt.BlockNumber, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, types.DynamicFeeTxType, rpcTx.Type)
+	require.Equal(t, tx.GasFeeCap(), rpcTx.GasFeeCap.ToInt())
+	require.Equal(t, tx.GasTipCap(), rpcTx.GasTipCap.ToInt())
+
+	var res jsonrpc.FeeHistoryResult
+	err = env.RawClient.Call(&res, "eth_feeHistory", "0x3", "latest", []float64{25, 75})
+	require.NoError(t, err)
+	latest := env.BlockNumber()
+	require.EqualValues(t, latest-2, res.OldestBlock.ToInt().Uint64())
+	require.Len(t, res.BaseFee, 4)
+	for _, baseFee := range res.BaseFee {
+		require.Equal(t, gasPrice, baseFee.ToInt())
+	}
+	require.Len(t, res.GasUsedRatio, 3)
+	require.Positive(t, res.GasUsedRatio[2])
+	require.Len(t, res.Reward, 3)
+	require.Len(t, res.Reward[2], 2)
+	require.EqualValues(t, 1, res.Reward[2][0].ToInt().Uint64())
+	require.EqualValues(t, 1, res.Reward[2][1].ToInt().Uint64())
+
+	err = env.RawClient.Call(&res, "eth_feeHistory", 3, "latest", []float64{75, 25})
+	require.ErrorContains(t, err, "invalid reward percentile")
+}
+
+func TestRPCTxPool(t *testing.T) {
+	env := newSoloTestEnv(t)
+	from, fromAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, toAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+
+	var status map[string]hexutil.Uint
+	err := env.RawClient.Call(&status, "txpool_status")
+	require.NoError(t, err)
+	require.EqualValues(t, 0, status["pending"])
+	require.EqualValues(t, 0, status["queued"])
+
+	// nonces 0 and 1 are executable, 3 is waiting for nonce 2
+	txs := map[uint64]*types.Transaction{}
+	for _, nonce := range []uint64{0, 1, 3} {
+		tx, err := types.SignTx(
+			types.NewTransaction(nonce, toAddress, big.NewInt(0), 100_000, env.MustGetGasPrice(), nil),
+			env.Signer(),
+			from,
+		)
+		require.NoError(t, err)
+		req, err := isc.NewEVMOffLedgerTxRequest(env.soloChain.ChainID, tx)
+		require.NoError(t, err)
+		env.solo.AddRequestsToMempool(env.soloChain, []isc.Request{req})
+		txs[nonce] = tx
+	}
+
+	err = env.RawClient.Call(&status, "txpool_status")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, status["pending"])
+	require.EqualValues(t, 1, status["queued"])
+
+	var content map[string]map[string]map[string]*jsonrpc.RPCTransaction
+	err = env.RawClient.Call(&content, "txpool_content")
+	require.NoError(t, err)
+	require.Len(t, content["pending"][fromAddress.Hex()], 2)
+	require.Equal(t, txs[0].Hash(), content["pending"][fromAddress.Hex()]["0"].Hash)
+	require.Equal(t, txs[1].Hash(), content["pending"][fromAddress.Hex()]["1"].Hash)
+	require.Equal(t, fromAddress, content["pending"][fromAddress.Hex()]["1"].From)
+	require.Len(t, content["queued"][fromAddress.Hex()], 1)
+	require.Equal(t, txs[3].Hash(), content["queued"][fromAddress.Hex()]["3"].Hash)
+
+	var inspect map[string]map[string]map[string]string
+	err = env.RawClient.Call(&inspect, "txpool_inspect")
+	require.NoError(t, err)
+	require.Equal(t,
+		fmt.Sprintf("%s: 0 wei + 100000 gas × %v wei", toAddress.Hex(), env.MustGetGasPrice()),
+		inspect["queued"][fromAddress.Hex()]["3"],
+	)
+}
+
+func TestRPCSyncing(t *testing.T) {
+	env := newSoloTestEnv(t)
+	var syncing any
+	err := env.RawClient.Call(&syncing, "eth_syncing")
+	require.NoError(t, err)
+	require.Equal(t, false, syncing)
+}
+
 func TestRPCTxRejectedIfNotEnoughFunds(t *testing.T) {
 	creator, creatorAddress := solo.NewEthereumAccount()
 
@@ -602,6 +846,57 @@ func TestRPCTraceTx(t *testing.T) {
 		require.NotEmpty(t, diff.Post)
 		// t.Logf("%s", lo.Must(json.MarshalIndent(diff, "", "  ")))
 	})
+
+	t.Run("structLogger", func(t *testing.T) {
+		tracer := "structLogger"
+		res, err := env.traceTransaction(tx1.Hash(), tracers.TraceConfig{Tracer: &tracer})
+		require.NoError(t, err)
+		var result tracerslogger.ExecutionResult
+		require.NoError(t, json.Unmarshal(res, &result))
+		require.False(t, result.Failed)
+		require.NotZero(t, result.Gas)
+		require.NotEmpty(t, result.StructLogs)
+
+		var firstOp map[string]any
+		require.NoError(t, json.Unmarshal(result.StructLogs[0], &firstOp))
+		require.Equal(t, "PUSH1", firstOp["op"])
+		require.Contains(t, firstOp, "stack")
+
+		res, err = env.traceTransaction(tx1.Hash(), tracers.TraceConfig{Tracer: &tracer, Config: &tracerslogger.Config{DisableStack: true}})
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(res, &result))
+		firstOp = nil
+		require.NoError(t, json.Unmarshal(result.StructLogs[0], &firstOp))
+		require.NotContains(t, firstOp, "stack")
+
+		// the struct logger is the default tracer
+		res, err = env.traceTransaction(tx1.Hash(), tracers.TraceConfig{})
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(res, &result))
+		require.NotEmpty(t, result.StructLogs)
+	})
+
+	t.Run("4byteTracer", func(t *testing.T) {
+		tracer := "4byteTracer"
+		res, err := env.traceTransaction(tx1.Hash(), tracers.TraceConfig{Tracer: &tracer})
+		require.NoError(t, err)
+		var ids map[string]int
+		require.NoError(t, json.Unmarshal(res, &ids))
+		selector := hexutil.Encode(contractABI.Methods["sendTo"].ID)
+		require.Equal(t, map[string]int{selector + "-64": 1}, ids)
+	})
+
+	t.Run("flatCallTracer", func(t *testing.T) {
+		tracer := "flatCallTracer"
+		res, err := env.traceTransaction(tx1.Hash(), tracers.TraceConfig{Tracer: &tracer})
+		require.NoError(t, err)
+		var traces []jsonrpc.Trace
+		require.NoError(t, json.Unmarshal(res, &traces))
+		require.Len(t, traces, 2)
+		require.Equal(t, tx1.Hash(), *traces[0].TransactionHash)
+		require.Equal(t, 1, traces[0].Subtraces)
+		require.Equal(t, []int{0}, traces[1].TraceAddress)
+	})
 }
 
 // Transfer calls produce "fake" Transactions to simulate EVM behavior.
@@ -642,6 +937,33 @@ func TestRPCTraceEVMDeposit(t *testing.T) {
 		require.Empty(t, prestate)
 	})
 
+	t.Run("structLogger_tx", func(t *testing.T) {
+		tracer := "structLogger"
+		res, err := env.traceTransaction(tx.Hash(), tracers.TraceConfig{Tracer: &tracer})
+		require.NoError(t, err)
+		var result tracerslogger.ExecutionResult
+		require.NoError(t, json.Unmarshal(res, &result))
+		require.False(t, result.Failed)
+		require.Empty(t, result.StructLogs)
+	})
+
+	t.Run("4byteTracer_tx", func(t *testing.T) {
+		tracer := "4byteTracer"
+		res, err := env.traceTransaction(tx.Hash(), tracers.TraceConfig{Tracer: &tracer})
+		require.NoError(t, err)
+		require.JSONEq(t, "{}", string(res))
+	})
+
+	t.Run("flatCallTracer_tx", func(t *testing.T) {
+		tracer := "flatCallTracer"
+		res, err := env.traceTransaction(tx.Hash(), tracers.TraceConfig{Tracer: &tracer})
+		require.NoError(t, err)
+		var traces []jsonrpc.Trace
+		require.NoError(t, json.Unmarshal(res, &traces))
+		require.Len(t, traces, 1)
+		require.Equal(t, tx.Hash(), *traces[0].TransactionHash)
+	})
+
 	t.Run("prestateTracerDiff_tx", func(t *testing.T) {
 		var prestateDiff jsonrpc.PrestateDiffResult
 		prestateDiff, err = env.traceTransactionWithPrestateDiff(tx.Hash())
@@ -1183,3 +1505,72 @@ func BenchmarkRPCEstimateGas(b *testing.B) {
 		require.NotZero(b, n)
 	}
 }
+
+func TestRPCDevMethods(t *testing.T) {
+	env := newSoloTestEnv(t)
+	ctx := context.Background()
+
+	// evm_mine, anvil_mine
+	blockNumber := env.BlockNumber()
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "evm_mine"))
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "anvil_mine", 2))
+	require.EqualValues(t, blockNumber+3, env.BlockNumber())
+	require.Error(t, env.RawClient.CallContext(ctx, nil, "anvil_mine", 1001))
+	require.EqualValues(t, blockNumber+3, env.BlockNumber())
+
+	// evm_increaseTime, evm_setNextBlockTimestamp
+	timestamp := env.BlockByNumber(nil).Time()
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "evm_increaseTime", 3600))
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "evm_mine"))
+	require.GreaterOrEqual(t, env.BlockByNumber(nil).Time(), timestamp+3600)
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "evm_setNextBlockTimestamp", hexutil.Uint64(timestamp+7200)))
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "evm_mine"))
+	require.EqualValues(t, timestamp+7200, env.BlockByNumber(nil).Time())
+	require.Error(t, env.RawClient.CallContext(ctx, nil, "evm_setNextBlockTimestamp", timestamp))
+
+	// anvil_setBalance
+	_, addr := solo.NewEthereumAccount()
+	balance := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "anvil_setBalance", addr, (*hexutil.Big)(balance)))
+	require.Equal(t, balance, env.Balance(addr))
+	sinkBalance := env.Balance(solo.DevBalanceSink)
+	lowerBalance := new(big.Int).Mul(big.NewInt(400), big.NewInt(1e18))
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "hardhat_setBalance", addr, (*hexutil.Big)(lowerBalance)))
+	require.Equal(t, lowerBalance, env.Balance(addr))
+	require.Equal(t, new(big.Int).Add(sinkBalance, new(big.Int).Sub(balance, lowerBalance)), env.Balance(solo.DevBalanceSink))
+
+	// anvil_setCode, anvil_setStorageAt, anvil_setNonce
+	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, storageAddress, storageABI := env.deployStorageContract(creator)
+	_, contractAddress := solo.NewEthereumAccount()
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "anvil_setCode", contractAddress, hexutil.Bytes(env.Code(storageAddress))))
+	require.Equal(t, env.Code(storageAddress), env.Code(contractAddress))
+	value := common.BigToHash(big.NewInt(43))
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "hardhat_setStorageAt", contractAddress, "0x0", hexutil.Bytes(value[:])))
+	var v uint32
+	require.NoError(t, storageABI.UnpackIntoInterface(&v, "retrieve", env.Storage(contractAddress, common.Hash{})))
+	require.EqualValues(t, 43, v)
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "anvil_setNonce", addr, "0x5"))
+	require.EqualValues(t, 5, env.NonceAt(addr))
+
+	// anvil_impersonateAccount
+	_, err := env.SendTransaction(&jsonrpc.SendTxArgs{From: addr})
+	require.ErrorContains(t, err, "account is not unlocked")
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "anvil_impersonateAccount", addr))
+	_, target := solo.NewEthereumAccount()
+	gas := hexutil.Uint64(100_000)
+	txHash := env.MustSendTransaction(&jsonrpc.SendTxArgs{
+		From:  addr,
+		To:    &target,
+		Gas:   &gas,
+		Value: (*hexutil.Big)(big.NewInt(1e18)),
+	})
+	receipt := env.MustTxReceipt(txHash)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Equal(t, big.NewInt(1e18), env.Balance(target))
+	require.EqualValues(t, 6, env.NonceAt(addr))
+
+	require.NoError(t, env.RawClient.CallContext(ctx, nil, "anvil_stopImpersonatingAccount", addr))
+	_, err = env.SendTransaction(&jsonrpc.SendTxArgs{From: addr, To: &target, Gas: &gas})
+	require.ErrorContains(t, err, "account is not unlocked")
+}
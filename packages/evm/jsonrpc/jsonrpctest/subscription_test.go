@@ -5,6 +5,7 @@ package jsonrpctest
 
 import (
 	"context"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
@@ -88,3 +89,76 @@ func TestSubscriptionLogs(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterNewBlocks(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	var id string
+	err := env.RawClient.Call(&id, "eth_newBlockFilter")
+	require.NoError(t, err)
+
+	var hashes []common.Hash
+	err = env.RawClient.Call(&hashes, "eth_getFilterChanges", id)
+	require.NoError(t, err)
+	require.Empty(t, hashes)
+
+	// this will create a new block
+	_, _ = env.soloChain.NewEthereumAccountWithL2Funds()
+
+	require.Eventually(t, func() bool {
+		err = env.RawClient.Call(&hashes, "eth_getFilterChanges", id)
+		require.NoError(t, err)
+		return len(hashes) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Equal(t, env.BlockByNumber(big.NewInt(1)).Hash(), hashes[0])
+
+	// changes are reset after each poll
+	err = env.RawClient.Call(&hashes, "eth_getFilterChanges", id)
+	require.NoError(t, err)
+	require.Empty(t, hashes)
+
+	var uninstalled bool
+	err = env.RawClient.Call(&uninstalled, "eth_uninstallFilter", id)
+	require.NoError(t, err)
+	require.True(t, uninstalled)
+
+	err = env.RawClient.Call(&uninstalled, "eth_uninstallFilter", id)
+	require.NoError(t, err)
+	require.False(t, uninstalled)
+
+	err = env.RawClient.Call(&hashes, "eth_getFilterChanges", id)
+	require.ErrorContains(t, err, "filter not found")
+}
+
+func TestFilterLogs(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	creator, creatorAddress := env.NewAccountWithL2Funds()
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+	require.NoError(env.T, err)
+	contractAddress := crypto.CreateAddress(creatorAddress, env.NonceAt(creatorAddress))
+
+	var id string
+	err = env.RawClient.Call(&id, "eth_newFilter", map[string]any{
+		"address": contractAddress,
+	})
+	require.NoError(t, err)
+
+	_, receipt, _ := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+	require.Equal(env.T, 1, len(receipt.Logs))
+
+	var logs []types.Log
+	require.Eventually(t, func() bool {
+		err = env.RawClient.Call(&logs, "eth_getFilterChanges", id)
+		require.NoError(t, err)
+		return len(logs) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Len(t, logs, 1)
+	require.Equal(t, contractAddress, logs[0].Address)
+
+	// eth_getFilterLogs returns all matching logs, not only the new ones
+	err = env.RawClient.Call(&logs, "eth_getFilterLogs", id)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, receipt.TxHash, logs[0].TxHash)
+}
@@ -13,6 +13,7 @@ import (
 
 type Parameters struct {
 	Logs                                LogsLimits
+	Filters                             FilterLimits
 	WebsocketRateLimitMessagesPerSecond int
 	WebsocketRateLimitBurst             int
 	WebsocketConnectionCleanupDuration  time.Duration
@@ -22,6 +23,9 @@ type Parameters struct {
 func NewParameters(
 	maxBlocksInLogsFilterRange int,
 	maxLogsInResult int,
+	filterTimeout time.Duration,
+	maxFilters int,
+	maxItemsPerFilter int,
 	websocketRateLimitMessagesPerSecond int,
 	websocketRateLimitBurst int,
 	websocketConnectionCleanupDuration time.Duration,
@@ -32,6 +36,11 @@ func NewParameters(
 			MaxBlocksInLogsFilterRange: maxBlocksInLogsFilterRange,
 			MaxLogsInResult:            maxLogsInResult,
 		},
+		Filters: FilterLimits{
+			Timeout:           filterTimeout,
+			MaxFilters:        maxFilters,
+			MaxItemsPerFilter: maxItemsPerFilter,
+		},
 		WebsocketRateLimitMessagesPerSecond: websocketRateLimitMessagesPerSecond,
 		WebsocketRateLimitBurst:             websocketRateLimitBurst,
 		WebsocketConnectionCleanupDuration:  websocketConnectionCleanupDuration,
@@ -45,6 +54,11 @@ func ParametersDefault() *Parameters {
 			MaxBlocksInLogsFilterRange: 1000,
 			MaxLogsInResult:            10000,
 		},
+		Filters: FilterLimits{
+			Timeout:           DefaultFilterTimeout,
+			MaxFilters:        1000,
+			MaxItemsPerFilter: 10000,
+		},
 		WebsocketRateLimitMessagesPerSecond: 20,
 		WebsocketRateLimitBurst:             5,
 		WebsocketConnectionCleanupDuration:  5 * time.Minute,
@@ -69,7 +83,7 @@ func NewServer(
 		{"net", NewNetService(int(chainID))},
 		{"eth", NewEthService(evmChain, accountManager, metrics, params)},
 		{"debug", NewDebugService(evmChain, metrics)},
-		{"txpool", NewTxPoolService()},
+		{"txpool", NewTxPoolService(evmChain, metrics)},
 		{"evm", NewEVMService(evmChain)},
 		{"trace", NewTraceService(evmChain, metrics)},
 	} {
@@ -78,5 +92,13 @@ func NewServer(
 			return nil, err
 		}
 	}
+	if _, err := evmChain.DevBackend(); err == nil {
+		anvilService := NewAnvilService(evmChain, accountManager)
+		for _, namespace := range []string{"anvil", "hardhat"} {
+			if err := rpcsrv.RegisterName(namespace, anvilService); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return rpcsrv, nil
 }
@@ -9,16 +9,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethmath "github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/tracers"
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/samber/lo"
 	"golang.org/x/crypto/sha3"
@@ -26,7 +28,6 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/evm/evmerrors"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/metrics"
-	"github.com/nnikolash/wasp-types-exported/packages/parameters"
 	vmerrors "github.com/nnikolash/wasp-types-exported/packages/vm/core/errors"
 )
 
@@ -40,6 +41,7 @@ type EthService struct {
 	accounts *AccountManager
 	metrics  *metrics.ChainWebAPIMetrics
 	params   *Parameters
+	filters  *filterManager
 }
 
 func NewEthService(
@@ -53,6 +55,7 @@ func NewEthService(
 		accounts: accounts,
 		metrics:  metrics,
 		params:   params,
+		filters:  newFilterManager(evmChain, params.Filters),
 	}
 }
 
@@ -205,19 +208,14 @@ func (e *EthService) GetTransactionReceipt(txHash common.Hash) (map[string]any,
 		if err != nil {
 			return nil, err
 		}
-		effectiveGasPrice := tx.GasPrice()
-		if effectiveGasPrice.Sign() == 0 && !feePolicy.GasPerToken.IsEmpty() {
-			// tx sent before gasPrice was mandatory
-			effectiveGasPrice = feePolicy.DefaultGasPriceFullDecimals(parameters.L1().BaseToken.Decimals)
-		}
-		return RPCMarshalReceipt(r, tx, effectiveGasPrice), nil
+		return RPCMarshalReceipt(r, tx, effectiveGasPrice(tx, feePolicy)), nil
 	})
 }
 
 func (e *EthService) SendRawTransaction(txBytes hexutil.Bytes) (common.Hash, error) {
 	return withMetrics(e.metrics, "eth_sendRawTransaction", func() (common.Hash, error) {
 		tx := new(types.Transaction)
-		if err := rlp.DecodeBytes(txBytes, tx); err != nil {
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
 			return common.Hash{}, err
 		}
 		if err := e.evmChain.SendTransaction(tx); err != nil {
@@ -248,6 +246,17 @@ func (e *EthService) GetStorageAt(address common.Address, key string, blockNumbe
 	})
 }
 
+func (e *EthService) GetProof(
+	address common.Address,
+	storageKeys []common.Hash,
+	blockNumberOrHash *rpc.BlockNumberOrHash,
+) (*AccountProofResult, error) {
+	return withMetrics(e.metrics, "eth_getProof", func() (*AccountProofResult, error) {
+		ret, err := e.evmChain.GetProof(address, storageKeys, blockNumberOrHash)
+		return ret, e.resolveError(err)
+	})
+}
+
 func (e *EthService) GetBlockTransactionCountByHash(blockHash common.Hash) (hexutil.Uint, error) {
 	return withMetrics(e.metrics, "eth_getBlockTransactionCountByHash", func() (hexutil.Uint, error) {
 		ret := e.evmChain.BlockTransactionCountByHash(blockHash)
@@ -292,6 +301,25 @@ func (e *EthService) GasPrice() (*hexutil.Big, error) {
 	})
 }
 
+// MaxPriorityFeePerGas returns the suggested priority fee (tip). The gas
+// price on ISC chains is fixed by the fee policy, which acts as the base fee,
+// so there is no need to pay a tip for the tx to be included.
+func (e *EthService) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	return withMetrics(e.metrics, "eth_maxPriorityFeePerGas", func() (*hexutil.Big, error) {
+		return (*hexutil.Big)(big.NewInt(0)), nil
+	})
+}
+
+func (e *EthService) FeeHistory(
+	blockCount gethmath.HexOrDecimal64,
+	newestBlock rpc.BlockNumber,
+	rewardPercentiles []float64,
+) (*FeeHistoryResult, error) {
+	return withMetrics(e.metrics, "eth_feeHistory", func() (*FeeHistoryResult, error) {
+		return e.evmChain.FeeHistory(uint64(blockCount), newestBlock, rewardPercentiles)
+	})
+}
+
 func (e *EthService) Mining() bool {
 	return false
 }
@@ -304,8 +332,20 @@ func (e *EthService) Coinbase() common.Address {
 	return common.Address{}
 }
 
-func (e *EthService) Syncing() bool {
-	return false
+// Syncing returns false if the node has all the blocks known to the chain,
+// or an object describing the synchronization progress otherwise.
+func (e *EthService) Syncing() (any, error) {
+	return withMetrics(e.metrics, "eth_syncing", func() (any, error) {
+		progress := e.evmChain.SyncProgress()
+		if progress == nil {
+			return false, nil
+		}
+		return map[string]hexutil.Uint64{
+			"startingBlock": hexutil.Uint64(progress.StartingBlock),
+			"currentBlock":  hexutil.Uint64(progress.CurrentBlock),
+			"highestBlock":  hexutil.Uint64(progress.HighestBlock),
+		}, nil
+	})
 }
 
 func (e *EthService) GetCompilers() []string {
@@ -348,6 +388,9 @@ func (e *EthService) SignTransaction(args *SendTxArgs) (hexutil.Bytes, error) {
 
 func (e *EthService) SendTransaction(args *SendTxArgs) (common.Hash, error) {
 	return withMetrics(e.metrics, "eth_sendTransaction", func() (common.Hash, error) {
+		if e.accounts.Get(args.From) == nil && e.accounts.IsImpersonated(args.From) {
+			return e.sendImpersonatedTransaction(args)
+		}
 		tx, err := e.parseTxArgs(args)
 		if err != nil {
 			return common.Hash{}, err
@@ -359,6 +402,17 @@ func (e *EthService) SendTransaction(args *SendTxArgs) (common.Hash, error) {
 	})
 }
 
+func (e *EthService) sendImpersonatedTransaction(args *SendTxArgs) (common.Hash, error) {
+	if err := args.setDefaults(e); err != nil {
+		return common.Hash{}, err
+	}
+	tx := args.toTransaction()
+	if err := e.evmChain.SendImpersonatedTransaction(tx, args.From); err != nil {
+		return common.Hash{}, e.resolveError(err)
+	}
+	return tx.Hash(), nil
+}
+
 func (e *EthService) parseTxArgs(args *SendTxArgs) (*types.Transaction, error) {
 	account := e.accounts.Get(args.From)
 	if account == nil {
@@ -470,27 +524,66 @@ func (e *EthService) GetBlockReceipts(blockNumber rpc.BlockNumberOrHash) ([]map[
 				return nil, err
 			}
 
-			effectiveGasPrice := txs[i].GasPrice()
-			if effectiveGasPrice.Sign() == 0 && !feePolicy.GasPerToken.IsEmpty() {
-				// tx sent before gasPrice was mandatory
-				effectiveGasPrice = feePolicy.DefaultGasPriceFullDecimals(parameters.L1().BaseToken.Decimals)
-			}
-
-			result[i] = RPCMarshalReceipt(receipt, txs[i], effectiveGasPrice)
+			result[i] = RPCMarshalReceipt(receipt, txs[i], effectiveGasPrice(txs[i], feePolicy))
 		}
 
 		return result, nil
 	})
 }
 
+// NewFilter implements the eth_newFilter method. The returned ID can be
+// polled with eth_getFilterChanges to receive the logs that match the query.
+func (e *EthService) NewFilter(q *RPCFilterQuery) (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newFilter", func() (rpc.ID, error) {
+		return e.filters.NewLogsFilter((*ethereum.FilterQuery)(q))
+	})
+}
+
+// NewBlockFilter implements the eth_newBlockFilter method. The returned ID
+// can be polled with eth_getFilterChanges to receive the hashes of new blocks.
+func (e *EthService) NewBlockFilter() (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newBlockFilter", func() (rpc.ID, error) {
+		return e.filters.NewBlocksFilter()
+	})
+}
+
+// NewPendingTransactionFilter implements the eth_newPendingTransactionFilter
+// method. The returned ID can be polled with eth_getFilterChanges to receive
+// the hashes of the EVM transactions that entered the mempool.
+func (e *EthService) NewPendingTransactionFilter() (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newPendingTransactionFilter", func() (rpc.ID, error) {
+		return e.filters.NewPendingTxFilter()
+	})
+}
+
+func (e *EthService) UninstallFilter(id rpc.ID) (bool, error) {
+	return withMetrics(e.metrics, "eth_uninstallFilter", func() (bool, error) {
+		return e.filters.Uninstall(id), nil
+	})
+}
+
+func (e *EthService) GetFilterChanges(id rpc.ID) (any, error) {
+	return withMetrics(e.metrics, "eth_getFilterChanges", func() (any, error) {
+		return e.filters.Changes(id)
+	})
+}
+
+func (e *EthService) GetFilterLogs(id rpc.ID) ([]*types.Log, error) {
+	return withMetrics(e.metrics, "eth_getFilterLogs", func() ([]*types.Log, error) {
+		q, err := e.filters.LogsQuery(id)
+		if err != nil {
+			return nil, err
+		}
+		logs, err := e.evmChain.Logs(q, &e.params.Logs)
+		if err != nil {
+			return nil, e.resolveError(err)
+		}
+		return logs, nil
+	})
+}
+
 /*
 Not implemented:
-func (e *EthService) NewFilter()
-func (e *EthService) NewBlockFilter()
-func (e *EthService) NewPendingTransactionFilter()
-func (e *EthService) UninstallFilter()
-func (e *EthService) GetFilterChanges()
-func (e *EthService) GetFilterLogs()
 func (e *EthService) SubmitWork()
 func (e *EthService) GetWork()
 func (e *EthService) SubmitHashrate()
@@ -530,31 +623,79 @@ func (s *Web3Service) Sha3(input hexutil.Bytes) hexutil.Bytes {
 	return crypto.Keccak256(input)
 }
 
-type TxPoolService struct{}
-
-func NewTxPoolService() *TxPoolService {
-	return &TxPoolService{}
+type TxPoolService struct {
+	evmChain *EVMChain
+	metrics  *metrics.ChainWebAPIMetrics
 }
 
-func (s *TxPoolService) Content() map[string]map[string]map[string]*RPCTransaction {
-	return map[string]map[string]map[string]*RPCTransaction{
-		"pending": make(map[string]map[string]*RPCTransaction),
-		"queued":  make(map[string]map[string]*RPCTransaction),
+func NewTxPoolService(evmChain *EVMChain, metrics *metrics.ChainWebAPIMetrics) *TxPoolService {
+	return &TxPoolService{
+		evmChain: evmChain,
+		metrics:  metrics,
 	}
 }
 
-func (s *TxPoolService) Inspect() map[string]map[string]map[string]string {
-	return map[string]map[string]map[string]string{
-		"pending": make(map[string]map[string]string),
-		"queued":  make(map[string]map[string]string),
-	}
+func (s *TxPoolService) Content() (map[string]map[string]map[string]*RPCTransaction, error) {
+	return withMetrics(s.metrics, "txpool_content", func() (map[string]map[string]map[string]*RPCTransaction, error) {
+		return txPoolContent(s.evmChain, func(tx *types.Transaction) *RPCTransaction {
+			return newRPCTransaction(tx, common.Hash{}, 0, 0)
+		})
+	})
+}
+
+func (s *TxPoolService) Inspect() (map[string]map[string]map[string]string, error) {
+	return withMetrics(s.metrics, "txpool_inspect", func() (map[string]map[string]map[string]string, error) {
+		return txPoolContent(s.evmChain, func(tx *types.Transaction) string {
+			if to := tx.To(); to != nil {
+				return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+			}
+			return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		})
+	})
+}
+
+func (s *TxPoolService) Status() (map[string]hexutil.Uint, error) {
+	return withMetrics(s.metrics, "txpool_status", func() (map[string]hexutil.Uint, error) {
+		pending, queued, err := s.evmChain.MempoolTransactions()
+		if err != nil {
+			return nil, err
+		}
+		count := func(txs map[common.Address]map[uint64]*types.Transaction) hexutil.Uint {
+			n := 0
+			for _, txsBySender := range txs {
+				n += len(txsBySender)
+			}
+			return hexutil.Uint(n)
+		}
+		return map[string]hexutil.Uint{
+			"pending": count(pending),
+			"queued":  count(queued),
+		}, nil
+	})
 }
 
-func (s *TxPoolService) Status() map[string]hexutil.Uint {
-	return map[string]hexutil.Uint{
-		"pending": hexutil.Uint(0),
-		"queued":  hexutil.Uint(0),
+// txPoolContent returns the transactions in the mempool, grouped by status
+// ("pending" / "queued"), sender and nonce, as expected by txpool_content
+// and txpool_inspect.
+func txPoolContent[T any](evmChain *EVMChain, format func(tx *types.Transaction) T) (map[string]map[string]map[string]T, error) {
+	pending, queued, err := evmChain.MempoolTransactions()
+	if err != nil {
+		return nil, err
 	}
+	group := func(txs map[common.Address]map[uint64]*types.Transaction) map[string]map[string]T {
+		ret := make(map[string]map[string]T, len(txs))
+		for sender, txsBySender := range txs {
+			ret[sender.Hex()] = make(map[string]T, len(txsBySender))
+			for nonce, tx := range txsBySender {
+				ret[sender.Hex()][fmt.Sprint(nonce)] = format(tx)
+			}
+		}
+		return ret
+	}
+	return map[string]map[string]map[string]T{
+		"pending": group(pending),
+		"queued":  group(queued),
+	}, nil
 }
 
 type DebugService struct {
@@ -630,3 +771,155 @@ func (e *EVMService) Snapshot() (hexutil.Uint, error) {
 func (e *EVMService) Revert(snapshot hexutil.Uint) error {
 	return e.evmChain.backend.RevertToSnapshot(int(snapshot))
 }
+
+// Mine implements the `evm_mine` RPC. If the timestamp is set, it is used for the new block.
+func (e *EVMService) Mine(timestamp *RPCQuantity) (string, error) {
+	dev, err := e.evmChain.DevBackend()
+	if err != nil {
+		return "", err
+	}
+	if timestamp != nil {
+		if err := setNextBlockTimestamp(dev, uint64(*timestamp)); err != nil {
+			return "", err
+		}
+	}
+	return "0x0", dev.DevMine()
+}
+
+// IncreaseTime implements the `evm_increaseTime` RPC. It returns the new
+// timestamp of the next block.
+func (e *EVMService) IncreaseTime(seconds RPCQuantity) (hexutil.Uint64, error) {
+	dev, err := e.evmChain.DevBackend()
+	if err != nil {
+		return 0, err
+	}
+	if err := dev.DevAdvanceClock(time.Duration(seconds) * time.Second); err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(dev.DevTime().Unix()), nil
+}
+
+// SetNextBlockTimestamp implements the `evm_setNextBlockTimestamp` RPC.
+func (e *EVMService) SetNextBlockTimestamp(timestamp RPCQuantity) error {
+	dev, err := e.evmChain.DevBackend()
+	if err != nil {
+		return err
+	}
+	return setNextBlockTimestamp(dev, uint64(timestamp))
+}
+
+func setNextBlockTimestamp(dev DevChainBackend, timestamp uint64) error {
+	step := time.Unix(int64(timestamp), 0).Sub(dev.DevTime())
+	if step < 0 {
+		return fmt.Errorf("timestamp %d is lower than the timestamp of the next block %d", timestamp, dev.DevTime().Unix())
+	}
+	return dev.DevAdvanceClock(step)
+}
+
+// AnvilService contains the implementations for the Anvil/Hardhat compatible
+// development endpoints, registered both as `anvil_*` and `hardhat_*`.
+// They are only available on development chains (e.g. Solo).
+type AnvilService struct {
+	evmChain *EVMChain
+	accounts *AccountManager
+}
+
+func NewAnvilService(evmChain *EVMChain, accounts *AccountManager) *AnvilService {
+	return &AnvilService{
+		evmChain: evmChain,
+		accounts: accounts,
+	}
+}
+
+// maxDevMineBlocks is the maximum amount of blocks produced by a single `anvil_mine` call
+const maxDevMineBlocks = 1000
+
+// Mine implements the `anvil_mine` RPC: it produces the given amount of blocks
+// (1 by default, at most maxDevMineBlocks), with the given interval in seconds between them.
+func (a *AnvilService) Mine(blocks, interval *RPCQuantity) error {
+	dev, err := a.evmChain.DevBackend()
+	if err != nil {
+		return err
+	}
+	n := uint64(1)
+	if blocks != nil {
+		n = uint64(*blocks)
+	}
+	if n > maxDevMineBlocks {
+		return fmt.Errorf("cannot mine more than %d blocks at once", maxDevMineBlocks)
+	}
+	for i := uint64(0); i < n; i++ {
+		if i > 0 && interval != nil {
+			if err := dev.DevAdvanceClock(time.Duration(*interval) * time.Second); err != nil {
+				return err
+			}
+		}
+		if err := dev.DevMine(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetBalance implements the `anvil_setBalance` RPC. As the base tokens can't be
+// created or destroyed, they are taken from the faucet to increase the balance,
+// and moved to a sink account to decrease it.
+func (a *AnvilService) SetBalance(address common.Address, balance *hexutil.Big) error {
+	dev, err := a.evmChain.DevBackend()
+	if err != nil {
+		return err
+	}
+	if balance == nil {
+		return errors.New("balance not specified")
+	}
+	return dev.DevSetBalance(address, balance.ToInt())
+}
+
+// SetCode implements the `anvil_setCode` RPC.
+func (a *AnvilService) SetCode(address common.Address, code hexutil.Bytes) error {
+	dev, err := a.evmChain.DevBackend()
+	if err != nil {
+		return err
+	}
+	return dev.DevSetCode(address, code)
+}
+
+// SetStorageAt implements the `anvil_setStorageAt` RPC.
+func (a *AnvilService) SetStorageAt(address common.Address, slot *hexutil.Big, value hexutil.Bytes) error {
+	dev, err := a.evmChain.DevBackend()
+	if err != nil {
+		return err
+	}
+	if slot == nil {
+		return errors.New("storage slot not specified")
+	}
+	if len(value) != common.HashLength {
+		return fmt.Errorf("storage value must be %d bytes long", common.HashLength)
+	}
+	return dev.DevSetStorageAt(address, common.BigToHash(slot.ToInt()), common.BytesToHash(value))
+}
+
+// SetNonce implements the `anvil_setNonce` RPC.
+func (a *AnvilService) SetNonce(address common.Address, nonce RPCQuantity) error {
+	dev, err := a.evmChain.DevBackend()
+	if err != nil {
+		return err
+	}
+	return dev.DevSetNonce(address, uint64(nonce))
+}
+
+// ImpersonateAccount implements the `anvil_impersonateAccount` RPC: the
+// `eth_sendTransaction` calls from the address are accepted without its key.
+func (a *AnvilService) ImpersonateAccount(address common.Address) error {
+	if _, err := a.evmChain.DevBackend(); err != nil {
+		return err
+	}
+	a.accounts.Impersonate(address)
+	return nil
+}
+
+// StopImpersonatingAccount implements the `anvil_stopImpersonatingAccount` RPC.
+func (a *AnvilService) StopImpersonatingAccount(address common.Address) error {
+	a.accounts.StopImpersonating(address)
+	return nil
+}
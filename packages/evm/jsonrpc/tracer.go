@@ -31,6 +31,21 @@ func newTracer(tracerType string, ctx *tracers.Context, cfg json.RawMessage, tra
 	return fn(ctx, cfg, traceBlock, initValue)
 }
 
+// tracerTypeAndConfig returns the tracer requested by the given trace config,
+// along with its configuration. Like in geth, the struct logger is used when
+// no tracer is specified; the other tracers (e.g. callTracer) are opt-in.
+func tracerTypeAndConfig(config *tracers.TraceConfig) (string, json.RawMessage, error) {
+	if config != nil && config.Tracer != nil && *config.Tracer != "" && *config.Tracer != structLoggerTracer {
+		return *config.Tracer, config.TracerConfig, nil
+	}
+	// the struct logger config is embedded in the main object
+	if config == nil || config.Config == nil {
+		return structLoggerTracer, nil, nil
+	}
+	cfg, err := json.Marshal(config.Config)
+	return structLoggerTracer, cfg, err
+}
+
 func GetTraceResults(
 	blockTxs []*types.Transaction,
 	traceBlock bool,
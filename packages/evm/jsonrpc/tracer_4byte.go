@@ -0,0 +1,136 @@
+// Code on this file adapted from
+// https://github.com/ethereum/go-ethereum/blob/master/eth/tracers/native/4byte.go
+
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+func init() {
+	registerTracer("4byteTracer", newFourByteTracer)
+}
+
+// fourByteTracer searches for 4byte-identifiers, and collects them for post-processing.
+// It collects the methods identifiers along with the size of the supplied data, so
+// a reversed signature can be matched against the size of the data.
+//
+// Example:
+//
+//	> debug.traceTransaction( "0x214e597e35da083692f5386141e69f47e973b2c56e7a8073b1ea08fd7571e9de", {tracer: "4byteTracer"})
+//	{
+//	  0x27dc297e-128: 1,
+//	  0x38cc4831-0: 2,
+//	  0x524f3889-96: 1,
+//	  0xadf59f99-288: 1,
+//	  0xc281d19e-0: 1
+//	}
+type fourByteTracer struct {
+	txToIDs    map[common.Hash]map[string]int // ids aggregates the 4byte ids found, for each tx
+	interrupt  atomic.Bool                    // Atomic flag to signal execution interruption
+	reason     error                          // Textual reason for the interruption
+	currentTx  common.Hash
+	traceBlock bool
+	blockTxs   types.Transactions
+}
+
+func newFourByteTracer(ctx *tracers.Context, cfg json.RawMessage, traceBlock bool, initValue any) (*Tracer, error) {
+	var blockTxs types.Transactions
+
+	if initValue == nil && traceBlock {
+		return nil, fmt.Errorf("initValue with block transactions is required for block tracing")
+	}
+
+	if initValue != nil {
+		var ok bool
+		blockTxs, ok = initValue.(types.Transactions)
+		if !ok {
+			return nil, fmt.Errorf("invalid init value type for 4byteTracer: %T", initValue)
+		}
+	}
+	t := &fourByteTracer{
+		txToIDs:    make(map[common.Hash]map[string]int),
+		traceBlock: traceBlock,
+		blockTxs:   blockTxs,
+	}
+	return &Tracer{
+		Tracer: &tracers.Tracer{
+			Hooks: &tracing.Hooks{
+				OnTxStart: t.OnTxStart,
+				OnEnter:   t.OnEnter,
+			},
+			GetResult: t.GetResult,
+			Stop:      t.Stop,
+		},
+		TraceFakeTx: t.TraceFakeTx,
+	}, nil
+}
+
+func (t *fourByteTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.currentTx = tx.Hash()
+	t.txToIDs[t.currentTx] = make(map[string]int)
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *fourByteTracer) OnEnter(depth int, opcode byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	// Skip if tracing was interrupted
+	if t.interrupt.Load() {
+		return
+	}
+	if len(input) < 4 {
+		return
+	}
+	op := vm.OpCode(opcode)
+	// primarily we want to avoid CREATE/CREATE2/SELFDESTRUCT
+	if op != vm.DELEGATECALL && op != vm.STATICCALL &&
+		op != vm.CALL && op != vm.CALLCODE {
+		return
+	}
+	// Skip any pre-compile invocations, those are just fancy opcodes
+	if isPrecompiled(&to) {
+		return
+	}
+	key := hexutil.Encode(input[0:4]) + "-" + strconv.Itoa(len(input)-4)
+	t.txToIDs[t.currentTx][key]++
+}
+
+// GetResult returns the json-encoded 4byte-identifiers found, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *fourByteTracer) GetResult() (json.RawMessage, error) {
+	return GetTraceResults(
+		t.blockTxs,
+		t.traceBlock,
+		t.TraceFakeTx,
+		func(tx *types.Transaction) (json.RawMessage, error) {
+			ids, ok := t.txToIDs[tx.Hash()]
+			if !ok {
+				return nil, fmt.Errorf("no 4byte ids for tx %s", tx.Hash().Hex())
+			}
+			return json.Marshal(ids)
+		},
+		func() (json.RawMessage, error) {
+			return json.Marshal(t.txToIDs[t.currentTx])
+		},
+		t.reason)
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *fourByteTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+func (t *fourByteTracer) TraceFakeTx(tx *types.Transaction) (json.RawMessage, error) {
+	return json.Marshal(map[string]int{})
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+func init() {
+	registerTracer("flatCallTracer", newFlatCallTracer)
+}
+
+// flatCallTracer reports the calls captured by the callTracer as a flat list
+// of parity-style traces, the same format that is returned by trace_block.
+type flatCallTracer struct {
+	*callTracer
+	ctx *tracers.Context
+}
+
+func newFlatCallTracer(ctx *tracers.Context, cfg json.RawMessage, traceBlock bool, initValue any) (*Tracer, error) {
+	var blockTxs types.Transactions
+
+	if initValue == nil && traceBlock {
+		return nil, fmt.Errorf("initValue with block transactions is required for block tracing")
+	}
+
+	if initValue != nil {
+		var ok bool
+		blockTxs, ok = initValue.(types.Transactions)
+		if !ok {
+			return nil, fmt.Errorf("invalid init value type for flatCallTracer: %T", initValue)
+		}
+	}
+	ct, err := newCallTracerObject(ctx, cfg, traceBlock, blockTxs)
+	if err != nil {
+		return nil, err
+	}
+	t := &flatCallTracer{callTracer: ct, ctx: ctx}
+	return &Tracer{
+		Tracer: &tracers.Tracer{
+			Hooks: &tracing.Hooks{
+				OnTxStart: t.OnTxStart,
+				OnTxEnd:   t.OnTxEnd,
+				OnEnter:   t.OnEnter,
+				OnExit:    t.OnExit,
+				OnLog:     t.OnLog,
+			},
+			GetResult: t.GetResult,
+			Stop:      t.Stop,
+		},
+		TraceFakeTx: t.TraceFakeTx,
+	}, nil
+}
+
+// GetResult returns the json-encoded flat list of call traces, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *flatCallTracer) GetResult() (json.RawMessage, error) {
+	return GetTraceResults(
+		t.blockTxs,
+		t.traceBlock,
+		t.TraceFakeTx,
+		func(tx *types.Transaction) (json.RawMessage, error) {
+			stack, ok := t.txToStack[tx.Hash()]
+			if !ok {
+				return nil, fmt.Errorf("no call stack for tx %s", tx.Hash().Hex())
+			}
+			return t.flatten(stack[0], tx.Hash())
+		},
+		func() (json.RawMessage, error) {
+			return t.flatten(t.txToStack[t.currentTx][0], t.currentTx)
+		},
+		t.reason)
+}
+
+func (t *flatCallTracer) TraceFakeTx(tx *types.Transaction) (json.RawMessage, error) {
+	res, err := t.callTracer.TraceFakeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	var frame CallFrame
+	if err := json.Unmarshal(res, &frame); err != nil {
+		return nil, err
+	}
+	return t.flatten(frame, tx.Hash())
+}
+
+func (t *flatCallTracer) flatten(frame CallFrame, txHash common.Hash) (json.RawMessage, error) {
+	return json.Marshal(convertToTrace(frame, &t.ctx.BlockHash, t.ctx.BlockNumber.Uint64(), &txHash, t.txPosition(txHash)))
+}
+
+func (t *flatCallTracer) txPosition(txHash common.Hash) uint64 {
+	if !t.traceBlock {
+		return uint64(t.ctx.TxIndex)
+	}
+	for i, tx := range t.blockTxs {
+		if tx.Hash() == txHash {
+			return uint64(i)
+		}
+	}
+	return 0
+}
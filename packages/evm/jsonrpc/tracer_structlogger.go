@@ -0,0 +1,135 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+)
+
+// structLoggerTracer is the opcode-level tracer that is used when no tracer
+// is specified in the trace config, like in geth.
+const structLoggerTracer = "structLogger"
+
+func init() {
+	registerTracer(structLoggerTracer, newStructLogger)
+}
+
+// structLogger wraps geth's StructLogger, which is only able to trace a
+// single transaction, keeping a separate logger for each traced transaction.
+type structLogger struct {
+	config     logger.Config
+	loggers    map[common.Hash]*logger.StructLogger
+	current    *logger.StructLogger
+	currentTx  common.Hash
+	reason     error
+	traceBlock bool
+	blockTxs   types.Transactions
+}
+
+func newStructLogger(ctx *tracers.Context, cfg json.RawMessage, traceBlock bool, initValue any) (*Tracer, error) {
+	var blockTxs types.Transactions
+
+	if initValue == nil && traceBlock {
+		return nil, fmt.Errorf("initValue with block transactions is required for block tracing")
+	}
+
+	if initValue != nil {
+		var ok bool
+		blockTxs, ok = initValue.(types.Transactions)
+		if !ok {
+			return nil, fmt.Errorf("invalid init value type for structLogger: %T", initValue)
+		}
+	}
+	var config logger.Config
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	t := &structLogger{
+		config:     config,
+		loggers:    make(map[common.Hash]*logger.StructLogger),
+		traceBlock: traceBlock,
+		blockTxs:   blockTxs,
+	}
+	return &Tracer{
+		Tracer: &tracers.Tracer{
+			Hooks: &tracing.Hooks{
+				OnTxStart: t.OnTxStart,
+				OnTxEnd:   t.OnTxEnd,
+				OnExit:    t.OnExit,
+				OnOpcode:  t.OnOpcode,
+			},
+			GetResult: t.GetResult,
+			Stop:      t.Stop,
+		},
+		TraceFakeTx: t.TraceFakeTx,
+	}, nil
+}
+
+func (t *structLogger) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.currentTx = tx.Hash()
+	t.current = logger.NewStructLogger(&t.config)
+	t.loggers[t.currentTx] = t.current
+	t.current.OnTxStart(env, tx, from)
+}
+
+func (t *structLogger) OnTxEnd(receipt *types.Receipt, err error) {
+	t.current.OnTxEnd(receipt, err)
+}
+
+func (t *structLogger) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	t.current.OnExit(depth, output, gasUsed, err, reverted)
+}
+
+func (t *structLogger) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	t.current.OnOpcode(pc, opcode, gas, cost, scope, rData, depth, err)
+}
+
+// GetResult returns the json-encoded list of executed opcodes, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *structLogger) GetResult() (json.RawMessage, error) {
+	return GetTraceResults(
+		t.blockTxs,
+		t.traceBlock,
+		t.TraceFakeTx,
+		func(tx *types.Transaction) (json.RawMessage, error) {
+			l, ok := t.loggers[tx.Hash()]
+			if !ok {
+				return nil, fmt.Errorf("no struct logs for tx %s", tx.Hash().Hex())
+			}
+			return l.GetResult()
+		},
+		func() (json.RawMessage, error) {
+			if t.current == nil {
+				return nil, fmt.Errorf("no struct logs for tx %s", t.currentTx.Hex())
+			}
+			return t.current.GetResult()
+		},
+		t.reason)
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *structLogger) Stop(err error) {
+	t.reason = err
+	if t.current != nil {
+		t.current.Stop(err)
+	}
+}
+
+func (t *structLogger) TraceFakeTx(tx *types.Transaction) (json.RawMessage, error) {
+	return json.Marshal(logger.ExecutionResult{
+		Gas:         tx.Gas(),
+		Failed:      false,
+		ReturnValue: "",
+		StructLogs:  []json.RawMessage{},
+	})
+}
@@ -127,6 +127,14 @@ func (b *WaspEVMBackend) ISCLatestState() (state.State, error) {
 	return latestState, nil
 }
 
+func (b *WaspEVMBackend) ISCMempoolOffLedgerRequests() []isc.OffLedgerRequest {
+	return b.chain.GetMempoolOffLedgerRequests()
+}
+
+func (b *WaspEVMBackend) ISCSyncStatus() *chain.SyncStatus {
+	return b.chain.GetSyncStatus()
+}
+
 func (b *WaspEVMBackend) ISCStateByBlockIndex(blockIndex uint32) (state.State, error) {
 	latestState, err := b.chain.LatestState(chain.ActiveOrCommittedState)
 	if err != nil {
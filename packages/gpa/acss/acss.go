@@ -111,6 +111,7 @@ type acssImpl struct {
 	suite         suites.Suite
 	n             int
 	f             int
+	t             int // Threshold of the sharing, i.e. degree of the polynomial + 1.
 	me            gpa.NodeID
 	mySK          kyber.Scalar
 	myPK          kyber.Point
@@ -145,6 +146,43 @@ func New(
 	dealer gpa.NodeID, // The dealer node for this protocol instance.
 	dealCB func(int, []byte) []byte, // For tests only: interceptor for the deal to be shared.
 	log *logger.Logger, // A logger to use.
+) gpa.GPA {
+	return newACSS(suite, peers, peerPKs, f, crypto.DefaultThreshold(len(peers)), me, mySK, dealer, dealCB, log)
+}
+
+// NewWithThreshold is the same as New, but the secret is shared with the specified
+// threshold t instead of the default one. At least n-2f honest nodes are guaranteed
+// to have valid shares, and the RECOVER procedure needs t of them to reconstruct a
+// missing share, thus t must be in [1, n-2f].
+func NewWithThreshold(
+	suite suites.Suite, // Ed25519
+	peers []gpa.NodeID, // Participating nodes in a specific order.
+	peerPKs map[gpa.NodeID]kyber.Point, // Public keys for all the peers.
+	f int, // Max number of expected faulty nodes.
+	t int, // Threshold of the sharing.
+	me gpa.NodeID, // ID of this node.
+	mySK kyber.Scalar, // Secret Key of this node.
+	dealer gpa.NodeID, // The dealer node for this protocol instance.
+	dealCB func(int, []byte) []byte, // For tests only: interceptor for the deal to be shared.
+	log *logger.Logger, // A logger to use.
+) (gpa.GPA, error) {
+	if n := len(peers); t < 1 || t > n-2*f {
+		return nil, fmt.Errorf("acss: threshold must be in [1, n-2f], t=%v, n=%v, f=%v", t, n, f)
+	}
+	return newACSS(suite, peers, peerPKs, f, t, me, mySK, dealer, dealCB, log), nil
+}
+
+func newACSS(
+	suite suites.Suite,
+	peers []gpa.NodeID,
+	peerPKs map[gpa.NodeID]kyber.Point,
+	f int,
+	t int,
+	me gpa.NodeID,
+	mySK kyber.Scalar,
+	dealer gpa.NodeID,
+	dealCB func(int, []byte) []byte,
+	log *logger.Logger,
 ) gpa.GPA {
 	n := len(peers)
 	if dealCB == nil {
@@ -154,6 +192,7 @@ func New(
 		suite:         suite,
 		n:             n,
 		f:             f,
+		t:             t,
 		me:            me,
 		mySK:          mySK,
 		myPK:          peerPKs[me],
@@ -241,7 +280,7 @@ func (a *acssImpl) handleInput(secretToShare kyber.Scalar) gpa.OutMessages {
 	for _, peerID := range a.peerIdx {
 		pubKeys = append(pubKeys, a.peerPKs[peerID])
 	}
-	deal := crypto.NewDeal(a.suite, pubKeys, secretToShare)
+	deal := crypto.NewDealWithThreshold(a.suite, pubKeys, secretToShare, a.t)
 	data, err := deal.MarshalBinary()
 	if err != nil {
 		panic(fmt.Sprintf("acss: internal error: %v", err))
@@ -293,7 +332,7 @@ func (a *acssImpl) handleRBCOutput(rbcOutput *msgRBCCEPayload) gpa.OutMessages {
 	if rbcOutput.err != nil {
 		return a.broadcastImplicate(rbcOutput.err, msgs)
 	}
-	deal, err := crypto.DealUnmarshalBinary(a.suite, a.n, rbcOutput.data)
+	deal, err := crypto.DealUnmarshalBinaryWithThreshold(a.suite, a.n, a.t, rbcOutput.data)
 	if err != nil {
 		return a.broadcastImplicate(errors.New("cannot unmarshal msgRBCCEPayload.data"), msgs)
 	}
@@ -443,6 +482,8 @@ func (a *acssImpl) handleImplicate(msg *msgImplicateRecover) gpa.OutMessages {
 // >       sᵢ = SSS.Recover(T, f+1, n)(i)
 // >       out = true
 // >       output sᵢ
+//
+// NOTE: For the sharing with a threshold t > f+1, we wait for t shares instead.
 func (a *acssImpl) handleRecover(msg *msgImplicateRecover) gpa.OutMessages {
 	if a.output {
 		// Ignore the RECOVER messages, if we are done with the output.
@@ -469,13 +510,13 @@ func (a *acssImpl) handleRecover(msg *msgImplicateRecover) gpa.OutMessages {
 	// >       sᵢ = SSS.Recover(T, f+1, n)(i)
 	// >       out = true
 	// >       output sᵢ
-	if len(a.recoverRecv) >= a.f+1 {
+	if len(a.recoverRecv) >= max(a.t, a.f+1) {
 		priShares := []*share.PriShare{}
 		for i := range a.recoverRecv {
 			priShares = append(priShares, a.recoverRecv[i])
 		}
 
-		myPriShare, err := crypto.InterpolateShare(a.suite, priShares, a.n, a.myIdx)
+		myPriShare, err := crypto.InterpolateShareWithThreshold(a.suite, priShares, a.t, a.n, a.myIdx)
 		if err != nil {
 			a.log.Warnf("Failed to recover pri-poly: %v", err)
 		}
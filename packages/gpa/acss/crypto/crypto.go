@@ -89,7 +89,13 @@ func DecryptShare(g kyber.Group, deal *Deal, index int, secret []byte) (*share.P
 
 // InterpolateShare interpolates a new private share for index i.
 func InterpolateShare(g kyber.Group, shares []*Share, n, i int) (*Share, error) {
-	poly, err := share.RecoverPriPoly(g, shares, threshold(n), n)
+	return InterpolateShareWithThreshold(g, shares, threshold(n), n, i)
+}
+
+// InterpolateShareWithThreshold interpolates a new private share for index i,
+// assuming the polynomial of degree t-1 was used to share the secret.
+func InterpolateShareWithThreshold(g kyber.Group, shares []*Share, t, n, i int) (*Share, error) {
+	poly, err := share.RecoverPriPoly(g, shares, t, n)
 	if err != nil {
 		return nil, err
 	}
@@ -31,18 +31,28 @@ func (d *Deal) MarshalBinary() ([]byte, error) {
 
 // DealLen returns the length of Deal in bytes.
 func DealLen(g kyber.Group, n int) int {
+	return DealLenWithThreshold(g, n, threshold(n))
+}
+
+// DealLenWithThreshold returns the length of Deal with t commitments in bytes.
+func DealLenWithThreshold(g kyber.Group, n, t int) int {
 	// t commitments, ephemeral public key, n encrypted shares
-	return threshold(n)*g.PointLen() + g.PointLen() + n*ShareLen(g)
+	return t*g.PointLen() + g.PointLen() + n*ShareLen(g)
 }
 
 // NewDeal creates data necessary to distribute scalar to the peers.
 // It returns the commitments C, public key pk_d and the encrypted shares Z.
 func NewDeal(suite suites.Suite, pubKeys []kyber.Point, scalar kyber.Scalar) *Deal {
+	return NewDealWithThreshold(suite, pubKeys, scalar, threshold(len(pubKeys)))
+}
+
+// NewDealWithThreshold is the same as NewDeal, but the scalar is shared
+// using the polynomial of degree t-1 instead of the default one.
+func NewDealWithThreshold(suite suites.Suite, pubKeys []kyber.Point, scalar kyber.Scalar, t int) *Deal {
 	var deal Deal
-	n := len(pubKeys)
 
 	// generate Feldman commitments
-	poly := share.NewPriPoly(suite, threshold(n), scalar, suite.RandomStream())
+	poly := share.NewPriPoly(suite, t, scalar, suite.RandomStream())
 	_, deal.Commits = poly.Commit(nil).Info()
 
 	// generate ephemeral keypair
@@ -50,6 +60,7 @@ func NewDeal(suite suites.Suite, pubKeys []kyber.Point, scalar kyber.Scalar) *De
 	deal.PubKey = suite.Point().Mul(sk, nil)
 
 	// generate a private share for each peer
+	n := len(pubKeys)
 	priShares := poly.Shares(n)
 
 	salt, err := deal.Commits.MarshalBinary()
@@ -73,14 +84,20 @@ func NewDeal(suite suites.Suite, pubKeys []kyber.Point, scalar kyber.Scalar) *De
 // If an error is returned, the data is invalid and cannot be used by any peer.
 // Otherwise, it returns the commitments C, public key pk_d and the encrypted shares.
 func DealUnmarshalBinary(g kyber.Group, n int, data []byte) (*Deal, error) {
-	if len(data) != DealLen(g, n) {
+	return DealUnmarshalBinaryWithThreshold(g, n, threshold(n), data)
+}
+
+// DealUnmarshalBinaryWithThreshold is the same as DealUnmarshalBinary, but
+// expects the deal to contain t commitments.
+func DealUnmarshalBinaryWithThreshold(g kyber.Group, n, t int, data []byte) (*Deal, error) {
+	if len(data) != DealLenWithThreshold(g, n, t) {
 		return nil, ErrInvalidInputLength
 	}
 	var deal Deal
 	buf := bytes.NewBuffer(data)
 
 	// load all commitments
-	deal.Commits = make(Commits, threshold(n))
+	deal.Commits = make(Commits, t)
 	for i := range deal.Commits {
 		c := g.Point()
 		if _, err := PointUnmarshalFrom(c, buf); err != nil {
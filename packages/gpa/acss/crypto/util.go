@@ -11,6 +11,11 @@ import (
 	"golang.org/x/crypto/hkdf"
 )
 
+// DefaultThreshold returns the threshold used for n peers, if not specified explicitly.
+func DefaultThreshold(n int) int {
+	return threshold(n)
+}
+
 // threshold returns the threshold for n.
 func threshold(n int) int {
 	return (n-1)/3 + 1 // threshold is fixed ⌊n/3⌋+1
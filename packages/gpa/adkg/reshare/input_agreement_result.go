@@ -0,0 +1,17 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package reshare
+
+import (
+	"github.com/nnikolash/wasp-types-exported/packages/gpa"
+)
+
+// inputAgreementResult is the set of dealers decided by the external agreement.
+type inputAgreementResult struct {
+	indexes []int
+}
+
+func NewInputAgreementResult(indexes []int) gpa.Input {
+	return &inputAgreementResult{indexes: indexes}
+}
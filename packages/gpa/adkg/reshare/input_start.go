@@ -0,0 +1,15 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package reshare
+
+import (
+	"github.com/nnikolash/wasp-types-exported/packages/gpa"
+)
+
+// inputStart makes the dealers to share their old shares.
+type inputStart struct{}
+
+func NewInputStart() gpa.Input {
+	return &inputStart{}
+}
@@ -0,0 +1,25 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package reshare
+
+import (
+	"fmt"
+
+	"github.com/nnikolash/wasp-types-exported/packages/gpa"
+)
+
+func (r *reshareImpl) subsystemFunc(subsystem byte, index int) (gpa.GPA, error) {
+	if subsystem == msgWrapperACSS {
+		if index < 0 || index >= len(r.acss) {
+			return nil, fmt.Errorf("unexpected acss index: %v", index)
+		}
+		return r.acss[index], nil
+	}
+	return nil, fmt.Errorf("unexpected subsystem: %v", subsystem)
+}
+
+func (r *reshareImpl) UnmarshalMessage(data []byte) (gpa.Message, error) {
+	// All non-node-local messages are from the ACSS, so just pass it there.
+	return r.wrapper.UnmarshalMessage(data)
+}
@@ -0,0 +1,338 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// reshare package implements the proactive secret sharing on top of the ACSS.
+// It allows an old committee to hand the shares of a secret over to a new
+// committee (possibly intersecting with the old one, or even the same committee
+// to refresh the shares) without changing the secret and the public key.
+//
+// >   Setup
+// >       The old committee holds the shares sᵢ of the secret s with the threshold t,
+// >       and the public shares Sᵢ = sᵢ⋅G are known to everybody.
+// >   Sharing
+// >       For every old party i (dealer):
+// >           Run ACSSᵢ(sᵢ) among all the old and new parties:
+// >               The new party j receives sᵢⱼ, and the commitments Cᵢ of the polynomial.
+// >       For every party j:
+// >           On termination of ACSSᵢ:
+// >               If Cᵢ₀ ≠ Sᵢ: ignore the dealer i (it has shared not its share).
+// >               Tⱼ ← Tⱼ ∪ {i}
+// >           Wait until |Tⱼ| ≥ t
+// >   Agreement
+// >       The parties agree on a set 𝒯 of exactly t dealers (using the external agreement,
+// >       e.g. the DKG initiator), such that for every i in 𝒯 ACSSᵢ has terminated at some
+// >       honest party, thus will terminate eventually at all the honest parties.
+// >       For every new party j:
+// >           Wait until 𝒯 ⊆ Tⱼ
+// >           s'ⱼ ← ∑ λᵢ⋅sᵢⱼ for i in 𝒯, where λᵢ are the Lagrange coefficients for 𝒯 at 0.
+// >           C' ← ∑ λᵢ⋅Cᵢ for i in 𝒯.
+//
+// Because ∑ λᵢ⋅sᵢ = s for any t old shares, the new shares s'ⱼ are the shares of the
+// same secret s, and C'₀ = s⋅G is the same public key. The new shares are independent
+// from the old ones, thus the old shares become useless after they are deleted.
+//
+// All the parties (old and new) have to participate in the ACSS instances, thus the
+// peers are the union of the old and the new committees. The new committee members
+// must be the first in the peer list, so that their indexes in the peer list are
+// the indexes of the new shares.
+//
+// The new sharing keeps the requested threshold t, even if it is higher than f+1.
+// The ACSS can only guarantee that n-2f honest parties hold valid sub-shares, thus
+// the number of tolerated faulty parties is lowered to ⌊(n-t)/2⌋ for such thresholds,
+// see FaultyForThreshold.
+package reshare
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/suites"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/nnikolash/wasp-types-exported/packages/gpa"
+	"github.com/nnikolash/wasp-types-exported/packages/gpa/acss"
+)
+
+type Output struct {
+	Indexes   []int           // Dealers (indexes in the old committee) used to construct the new shares.
+	PubKey    kyber.Point     // The shared public key, the same as before the resharing.
+	PriShare  *share.PriShare // New share (nil for the intermediate output or if not in the new committee).
+	Commits   []kyber.Point   // Commitments for the new shares (nil for the intermediate output).
+	Threshold int             // Threshold of the new sharing.
+}
+
+type reshareImpl struct {
+	suite           suites.Suite
+	newN            int           // Number of nodes in the new committee (they are first in the nodeIDs).
+	threshold       int           // Threshold of the new sharing.
+	dealers         []gpa.NodeID  // The old committee.
+	dealerPubShares []kyber.Point // Public shares of the old committee.
+	oldThreshold    int           // Threshold of the old sharing.
+	me              gpa.NodeID
+	myIdx           int
+	myShare         *share.PriShare // Old share of this node, nil if not in the old committee.
+	acss            []gpa.GPA       // ACSS instance for each dealer.
+	st              map[int]*share.PriShare
+	stCommits       map[int][]kyber.Point
+	agreedT         []int
+	output          *Output
+	wrapper         *gpa.MsgWrapper
+	log             *logger.Logger
+}
+
+var _ gpa.GPA = &reshareImpl{}
+
+const (
+	msgWrapperACSS byte = iota // subsystem code.
+)
+
+const (
+	msgTypeWrapped gpa.MessageType = iota
+)
+
+// New creates the resharing protocol instance.
+//
+// The nodeIDs contain the nodes of the new committee first (in the order of the new committee),
+// then the nodes that are only in the old committee. The dealers are the old committee in its order.
+func New(
+	suite suites.Suite,
+	nodeIDs []gpa.NodeID,
+	peerPKs map[gpa.NodeID]kyber.Point,
+	newN int,
+	threshold int,
+	dealers []gpa.NodeID,
+	dealerPubShares []kyber.Point,
+	oldThreshold int,
+	me gpa.NodeID,
+	mySK kyber.Scalar,
+	myShare *share.PriShare,
+	log *logger.Logger,
+) (gpa.GPA, error) {
+	myIdx := lo.IndexOf(nodeIDs, me)
+	if myIdx == -1 {
+		return nil, errors.New("i'm not in the peer list")
+	}
+	if len(dealers) != len(dealerPubShares) {
+		return nil, fmt.Errorf("public shares have to be provided for all the dealers, dealers=%v, pubShares=%v", len(dealers), len(dealerPubShares))
+	}
+	for _, dealer := range dealers {
+		if !lo.Contains(nodeIDs, dealer) {
+			return nil, fmt.Errorf("dealer %v is not in the peer list", dealer.ShortString())
+		}
+	}
+	if newN < 1 || newN > len(nodeIDs) {
+		return nil, fmt.Errorf("invalid size of the new committee: %v", newN)
+	}
+	if threshold < 1 || threshold > newN {
+		return nil, fmt.Errorf("threshold must be in [1, %v], got %v", newN, threshold)
+	}
+	r := &reshareImpl{
+		suite:           suite,
+		newN:            newN,
+		threshold:       threshold,
+		dealers:         dealers,
+		dealerPubShares: dealerPubShares,
+		oldThreshold:    oldThreshold,
+		me:              me,
+		myIdx:           myIdx,
+		myShare:         myShare,
+		acss:            nil, // Will be set bellow.
+		st:              map[int]*share.PriShare{},
+		stCommits:       map[int][]kyber.Point{},
+		agreedT:         nil, // Will be set, when the agreement result will be received.
+		output:          nil,
+		log:             log,
+	}
+	r.wrapper = gpa.NewMsgWrapper(msgTypeWrapped, r.subsystemFunc)
+	f := FaultyForThreshold(len(nodeIDs), threshold)
+	r.acss = make([]gpa.GPA, len(dealers))
+	for i := range r.acss {
+		var err error
+		if r.acss[i], err = acss.NewWithThreshold(suite, nodeIDs, peerPKs, f, threshold, me, mySK, dealers[i], nil, log); err != nil {
+			return nil, err
+		}
+	}
+	return gpa.NewOwnHandler(me, r), nil
+}
+
+// FaultyForThreshold returns the number of faulty parties tolerated by the resharing
+// among n parties with the new threshold t. It is the usual ⌊(n-1)/3⌋, unless the
+// threshold is too high for it. In that case, it is lowered to ⌊(n-t)/2⌋, so that
+// the ACSS still guarantees t honest parties with valid sub-shares.
+func FaultyForThreshold(n, t int) int {
+	return max(0, min((n-1)/3, (n-t)/2))
+}
+
+func (r *reshareImpl) Input(input gpa.Input) gpa.OutMessages {
+	switch input := input.(type) {
+	case *inputStart:
+		dealerIdx := lo.IndexOf(r.dealers, r.me)
+		if dealerIdx == -1 || r.myShare == nil {
+			// Not a dealer, just participate in the ACSS instances of others.
+			return nil
+		}
+		msgs := r.wrapper.WrapMessages(msgWrapperACSS, dealerIdx, r.acss[dealerIdx].Input(r.myShare.V))
+		return r.tryHandleACSSTermination(dealerIdx, msgs)
+	case *inputAgreementResult:
+		return r.handleAgreementResult(input)
+	}
+	panic(fmt.Errorf("unexpected input %T: %+v", input, input))
+}
+
+func (r *reshareImpl) Message(msg gpa.Message) gpa.OutMessages {
+	switch msgT := msg.(type) {
+	case *gpa.WrappingMsg:
+		switch msgT.Subsystem() {
+		case msgWrapperACSS:
+			return r.handleACSSMessage(msgT)
+		default:
+			r.log.Warnf("unexpected message subsystem: %+v", msg)
+			return nil
+		}
+	default:
+		panic(fmt.Errorf("unexpected message: %+v", msg))
+	}
+}
+
+func (r *reshareImpl) Output() gpa.Output {
+	if r.output == nil {
+		return nil
+	}
+	return r.output
+}
+
+func (r *reshareImpl) StatusString() string {
+	acssStats := ""
+	for i := range r.acss {
+		acssStats += "\n" + r.acss[i].StatusString()
+	}
+	return fmt.Sprintf("{ADKG:Reshare, st=%v, agreedT=%v, acss: %s}", lo.Keys(r.st), r.agreedT, acssStats)
+}
+
+func (r *reshareImpl) handleACSSMessage(msg *gpa.WrappingMsg) gpa.OutMessages {
+	msgIndex := msg.Index()
+	msgs := r.wrapper.WrapMessages(msgWrapperACSS, msgIndex, r.acss[msgIndex].Message(msg.Wrapped()))
+	return r.tryHandleACSSTermination(msgIndex, msgs)
+}
+
+func (r *reshareImpl) tryHandleACSSTermination(acssIndex int, msgs gpa.OutMessages) gpa.OutMessages {
+	out := r.acss[acssIndex].Output()
+	if out != nil && r.st[acssIndex] == nil {
+		acssOutput, ok := out.(*acss.Output)
+		if !ok {
+			panic(fmt.Errorf("acss output wrong type: %+v", out))
+		}
+		msgs.AddAll(r.handleACSSOutput(acssIndex, acssOutput.PriShare, acssOutput.Commits))
+	}
+	return msgs
+}
+
+// > On termination of ACSSᵢ:
+// >     If Cᵢ₀ ≠ Sᵢ: ignore the dealer i (it has shared not its share).
+// >     Tⱼ ← Tⱼ ∪ {i}
+// > Wait until |Tⱼ| ≥ t
+func (r *reshareImpl) handleACSSOutput(index int, priShare *share.PriShare, commits []kyber.Point) gpa.OutMessages {
+	if _, ok := r.st[index]; ok {
+		// Already set. Ignore the duplicate messages.
+		return nil
+	}
+	if !commits[0].Equal(r.dealerPubShares[index]) {
+		r.log.Warnf("Dealer %v has shared a secret not matching its public share, ignoring it.", index)
+		return nil
+	}
+	r.st[index] = priShare
+	r.stCommits[index] = commits
+	if len(r.st) == r.oldThreshold && r.output == nil {
+		t := lo.Keys(r.st)
+		sort.Ints(t)
+		r.output = &Output{Indexes: t, Threshold: r.threshold} // That's intermediate output.
+	}
+	//
+	// It is possible that the indexes are already decided and are waiting for the ACSS only.
+	// Thus we have to try produce the final output.
+	return r.tryMakeFinalOutput()
+}
+
+func (r *reshareImpl) handleAgreementResult(input *inputAgreementResult) gpa.OutMessages {
+	if r.agreedT != nil {
+		return nil
+	}
+	agreedT := lo.Uniq(input.indexes)
+	if len(agreedT) != r.oldThreshold || len(agreedT) != len(input.indexes) {
+		r.log.Warnf("Agreed dealers must be %v distinct dealers, got %v", r.oldThreshold, input.indexes)
+		return nil
+	}
+	for _, i := range agreedT {
+		if i < 0 || i >= len(r.dealers) {
+			r.log.Warnf("Agreed dealer index out of range: %v", i)
+			return nil
+		}
+	}
+	sort.Ints(agreedT)
+	r.agreedT = agreedT
+	return r.tryMakeFinalOutput()
+}
+
+// > Wait until 𝒯 ⊆ Tⱼ
+// > s'ⱼ ← ∑ λᵢ⋅sᵢⱼ for i in 𝒯, where λᵢ are the Lagrange coefficients for 𝒯 at 0.
+// > C' ← ∑ λᵢ⋅Cᵢ for i in 𝒯.
+func (r *reshareImpl) tryMakeFinalOutput() gpa.OutMessages {
+	if r.agreedT == nil || (r.output != nil && r.output.Commits != nil) {
+		return nil
+	}
+	for _, i := range r.agreedT {
+		if _, ok := r.st[i]; !ok {
+			r.log.Debugf("Don't have S/T[%v] yet, have to wait, agreedT=%+v, have S/T indexes: %v.", i, r.agreedT, lo.Keys(r.st))
+			return nil
+		}
+	}
+	lambdas := lagrangeCoefficients(r.suite, r.agreedT)
+	sum := r.suite.Scalar().Zero()
+	commits := make([]kyber.Point, r.threshold)
+	for k := range commits {
+		commits[k] = r.suite.Point().Null()
+	}
+	for _, i := range r.agreedT {
+		sum.Add(sum, r.suite.Scalar().Mul(lambdas[i], r.st[i].V))
+		for k := range commits {
+			commits[k].Add(commits[k], r.suite.Point().Mul(lambdas[i], r.stCommits[i][k]))
+		}
+	}
+	var priShare *share.PriShare
+	if r.myIdx < r.newN {
+		priShare = &share.PriShare{I: r.myIdx, V: sum}
+	}
+	r.output = &Output{
+		Indexes:   r.agreedT,
+		PubKey:    commits[0],
+		PriShare:  priShare,
+		Commits:   commits,
+		Threshold: r.threshold,
+	}
+	return nil
+}
+
+// lagrangeCoefficients returns the Lagrange basis polynomials at 0 for the share indexes
+// (the share with the index i is the polynomial evaluated at i+1).
+func lagrangeCoefficients(suite suites.Suite, indexes []int) map[int]kyber.Scalar {
+	lambdas := make(map[int]kyber.Scalar, len(indexes))
+	for _, i := range indexes {
+		xi := suite.Scalar().SetInt64(int64(i) + 1)
+		num := suite.Scalar().One()
+		den := suite.Scalar().One()
+		for _, j := range indexes {
+			if i == j {
+				continue
+			}
+			xj := suite.Scalar().SetInt64(int64(j) + 1)
+			num.Mul(num, xj)
+			den.Mul(den, suite.Scalar().Sub(xj, xi))
+		}
+		lambdas[i] = num.Div(num, den)
+	}
+	return lambdas
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package reshare_test
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/nnikolash/wasp-types-exported/packages/gpa"
+	"github.com/nnikolash/wasp-types-exported/packages/gpa/adkg"
+	"github.com/nnikolash/wasp-types-exported/packages/gpa/adkg/reshare"
+	"github.com/nnikolash/wasp-types-exported/packages/tcrypto"
+	"github.com/nnikolash/wasp-types-exported/packages/testutil/testlogger"
+)
+
+func TestBasic(t *testing.T) {
+	log := testlogger.WithLevel(testlogger.NewLogger(t), logger.LevelWarn, false)
+	defer log.Sync()
+	suite := tcrypto.DefaultEd25519Suite()
+	//
+	// The old and the new committees are given as indexes in the list of all the nodes.
+	test := func(tt *testing.T, all int, oldIdx, newIdx []int, threshold int) {
+		allIDs := gpa.MakeTestNodeIDs(all)
+		nodeSKs := map[gpa.NodeID]kyber.Scalar{}
+		nodePKs := map[gpa.NodeID]kyber.Point{}
+		for _, nid := range allIDs {
+			nodeSKs[nid] = suite.Scalar().Pick(suite.RandomStream())
+			nodePKs[nid] = suite.Point().Mul(nodeSKs[nid], nil)
+		}
+		oldIDs := make([]gpa.NodeID, len(oldIdx))
+		for i := range oldIdx {
+			oldIDs[i] = allIDs[oldIdx[i]]
+		}
+		newIDs := make([]gpa.NodeID, len(newIdx))
+		for i := range newIdx {
+			newIDs[i] = allIDs[newIdx[i]]
+		}
+		nodeIDs := append([]gpa.NodeID{}, newIDs...)
+		for _, nid := range oldIDs {
+			if !lo.Contains(nodeIDs, nid) {
+				nodeIDs = append(nodeIDs, nid)
+			}
+		}
+		//
+		// Make the key to reshare.
+		oldF := (len(oldIDs) - 1) / 3
+		oldThreshold := oldF + 1
+		pubKey, oldDKS := adkg.MakeTestDistributedKey(tt, suite, oldIDs, nodeSKs, nodePKs, oldF, log)
+		oldPubPoly := share.NewPubPoly(suite, nil, oldDKS[oldIDs[0]].Commitments())
+		oldPubShares := make([]kyber.Point, len(oldIDs))
+		for i := range oldIDs {
+			oldPubShares[i] = oldPubPoly.Eval(i).V
+		}
+		//
+		// Setup nodes.
+		nodes := map[gpa.NodeID]gpa.GPA{}
+		for _, nid := range nodeIDs {
+			var myShare *share.PriShare
+			if dks, ok := oldDKS[nid]; ok {
+				myShare = dks.PriShare()
+			}
+			node, err := reshare.New(suite, nodeIDs, nodePKs, len(newIDs), threshold, oldIDs, oldPubShares, oldThreshold, nid, nodeSKs[nid], myShare, log)
+			require.NoError(tt, err)
+			nodes[nid] = node
+		}
+		tc := gpa.NewTestContext(nodes)
+		inputs := make(map[gpa.NodeID]gpa.Input)
+		for _, nid := range nodeIDs {
+			inputs[nid] = reshare.NewInputStart()
+		}
+		tc.WithInputs(inputs).WithInputProbability(0.01)
+		tc.RunUntil(tc.NumberOfOutputsPredicate(len(newIDs)))
+		//
+		// Emulate the agreement by taking the proposal of any node.
+		var decided []int
+		for _, nid := range newIDs {
+			if o := nodes[nid].Output(); o != nil {
+				decided = o.(*reshare.Output).Indexes
+				require.Len(tt, decided, oldThreshold)
+				require.Nil(tt, o.(*reshare.Output).PriShare)
+				break
+			}
+		}
+		require.NotNil(tt, decided)
+		for _, nid := range nodeIDs {
+			tc.WithInput(nid, reshare.NewInputAgreementResult(decided))
+		}
+		tc.WithInputProbability(0.001)
+		tc.RunUntil(tc.OutOfMessagesPredicate())
+		//
+		// Check the FINAL result: the same public key, and the new shares are consistent.
+		priShares := make([]*share.PriShare, 0, len(newIDs))
+		var commits []kyber.Point
+		for i, nid := range nodeIDs {
+			o := nodes[nid].Output()
+			require.NotNil(tt, o)
+			out := o.(*reshare.Output)
+			require.NotNil(tt, out.Commits)
+			require.True(tt, pubKey.Equal(out.PubKey))
+			if commits == nil {
+				commits = out.Commits
+			}
+			for j := range commits {
+				require.True(tt, commits[j].Equal(out.Commits[j]))
+			}
+			if i >= len(newIDs) {
+				require.Nil(tt, out.PriShare)
+				continue
+			}
+			require.NotNil(tt, out.PriShare)
+			require.Equal(tt, i, out.PriShare.I)
+			require.True(tt, suite.Point().Mul(out.PriShare.V, nil).Equal(share.NewPubPoly(suite, nil, commits).Eval(i).V))
+			priShares = append(priShares, out.PriShare)
+		}
+		require.Len(tt, commits, threshold)
+		secret, err := share.RecoverSecret(suite, priShares, threshold, len(newIDs))
+		require.NoError(tt, err)
+		require.True(tt, pubKey.Equal(suite.Point().Mul(secret, nil)))
+		_, err = share.RecoverSecret(suite, priShares[:threshold-1], threshold, len(newIDs))
+		require.Error(tt, err)
+	}
+	t.Run("refresh,n=4", func(tt *testing.T) { test(tt, 4, []int{0, 1, 2, 3}, []int{0, 1, 2, 3}, 2) })
+	t.Run("refresh,n=4,T=3", func(tt *testing.T) { test(tt, 4, []int{0, 1, 2, 3}, []int{0, 1, 2, 3}, 3) })
+	t.Run("refresh,n=4,T=4", func(tt *testing.T) { test(tt, 4, []int{0, 1, 2, 3}, []int{0, 1, 2, 3}, 4) })
+	t.Run("grow,n=4->7", func(tt *testing.T) { test(tt, 7, []int{0, 1, 2, 3}, []int{0, 1, 2, 3, 4, 5, 6}, 3) })
+	t.Run("grow,n=4->7,T=5", func(tt *testing.T) { test(tt, 7, []int{0, 1, 2, 3}, []int{0, 1, 2, 3, 4, 5, 6}, 5) })
+	t.Run("move,n=4->4", func(tt *testing.T) { test(tt, 6, []int{0, 1, 2, 3}, []int{5, 3, 2, 4}, 2) })
+	t.Run("shrink,n=7->4", func(tt *testing.T) { test(tt, 7, []int{0, 1, 2, 3, 4, 5, 6}, []int{6, 0, 3, 1}, 2) })
+}
+
+func TestThresholdRejected(t *testing.T) {
+	log := testlogger.WithLevel(testlogger.NewLogger(t), logger.LevelWarn, false)
+	defer log.Sync()
+	suite := tcrypto.DefaultEd25519Suite()
+	nodeIDs := gpa.MakeTestNodeIDs(4)
+	nodePKs := map[gpa.NodeID]kyber.Point{}
+	pubShares := make([]kyber.Point, len(nodeIDs))
+	for i, nid := range nodeIDs {
+		nodePKs[nid] = suite.Point().Pick(suite.RandomStream())
+		pubShares[i] = suite.Point().Pick(suite.RandomStream())
+	}
+	for _, threshold := range []int{0, 5} {
+		_, err := reshare.New(suite, nodeIDs, nodePKs, len(nodeIDs), threshold, nodeIDs, pubShares, 2, nodeIDs[0], suite.Scalar().One(), nil, log)
+		require.Error(t, err)
+	}
+}
@@ -1,12 +1,14 @@
 package isc
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -29,8 +31,12 @@ type Request interface {
 }
 
 func EVMCallDataFromTx(tx *types.Transaction) *ethereum.CallMsg {
+	return evmCallDataFromTx(tx, evmutil.MustGetSender(tx))
+}
+
+func evmCallDataFromTx(tx *types.Transaction, from common.Address) *ethereum.CallMsg {
 	return &ethereum.CallMsg{
-		From:       evmutil.MustGetSender(tx),
+		From:       from,
 		To:         tx.To(),
 		Gas:        tx.Gas(),
 		GasPrice:   tx.GasPrice(),
@@ -69,6 +75,7 @@ type UnsignedOffLedgerRequest interface {
 	WithGasBudget(gasBudget uint64) UnsignedOffLedgerRequest
 	WithAllowance(allowance *Assets) UnsignedOffLedgerRequest
 	WithSender(sender *cryptolib.PublicKey) UnsignedOffLedgerRequest
+	WithSponsor(sponsor AgentID) UnsignedOffLedgerRequest
 	Sign(key cryptolib.VariantKeyPair) OffLedgerRequest
 }
 
@@ -76,12 +83,26 @@ type ImpersonatedOffLedgerRequest interface {
 	WithSenderAddress(senderAddress *iotago.Ed25519Address) OffLedgerRequest
 }
 
+// ErrImpersonatedRequest is returned when verifying the signature of an impersonated request,
+// which has none.
+var ErrImpersonatedRequest = errors.New("impersonated request is not signed")
+
+// IsImpersonatedRequest returns true, if the request is sent on behalf of its sender without
+// the sender's signature. Such requests are only accepted in the estimate gas mode, or by VMs
+// with impersonation enabled (e.g. Solo).
+func IsImpersonatedRequest(req Calldata) bool {
+	imp, ok := req.(interface{ IsImpersonated() bool })
+	return ok && imp.IsImpersonated()
+}
+
 type OffLedgerRequest interface {
 	Request
 	ChainID() ChainID
 	Nonce() uint64
 	VerifySignature() error
 	GasPrice() *big.Int
+	// Sponsor is the agent that pays the gas fee on behalf of the sender, nil if the sender pays
+	Sponsor() AgentID
 }
 
 type OnLedgerRequest interface {
@@ -131,3 +131,7 @@ func (req *evmOffLedgerCallRequest) EVMCallMsg() *ethereum.CallMsg {
 func (req *evmOffLedgerCallRequest) GasPrice() *big.Int {
 	return req.callMsg.GasPrice
 }
+
+func (req *evmOffLedgerCallRequest) Sponsor() AgentID {
+	return nil
+}
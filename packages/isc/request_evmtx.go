@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -22,7 +23,9 @@ import (
 type evmOffLedgerTxRequest struct {
 	chainID ChainID
 	tx      *types.Transaction
-	sender  *EthereumAddressAgentID // not serialized
+	sender  *EthereumAddressAgentID // not serialized, unless impersonated
+	// impersonated requests are sent on behalf of the sender without its signature
+	impersonated bool
 }
 
 var _ OffLedgerRequest = &evmOffLedgerTxRequest{}
@@ -39,9 +42,24 @@ func NewEVMOffLedgerTxRequest(chainID ChainID, tx *types.Transaction) (OffLedger
 	}, nil
 }
 
+// NewImpersonatedEVMOffLedgerTxRequest wraps an unsigned EVM tx, sent on behalf of the
+// given address. Such requests are only executed by VMs with impersonation enabled,
+// which is meant for development tools like Solo.
+func NewImpersonatedEVMOffLedgerTxRequest(chainID ChainID, tx *types.Transaction, sender common.Address) OffLedgerRequest {
+	return &evmOffLedgerTxRequest{
+		chainID:      chainID,
+		tx:           tx,
+		sender:       NewEthereumAddressAgentID(chainID, sender),
+		impersonated: true,
+	}
+}
+
 func (req *evmOffLedgerTxRequest) Read(r io.Reader) error {
 	rr := rwutil.NewReader(r)
-	rr.ReadKindAndVerify(rwutil.Kind(requestKindOffLedgerEVMTx))
+	kind := RequestKind(rr.ReadKind())
+	if kind != requestKindOffLedgerEVMTx && kind != requestKindOffLedgerEVMImpersonatedTx && rr.Err == nil {
+		rr.Err = errors.New("unexpected object kind")
+	}
 	rr.Read(&req.chainID)
 	txData := rr.ReadBytes()
 	if rr.Err != nil {
@@ -51,6 +69,13 @@ func (req *evmOffLedgerTxRequest) Read(r io.Reader) error {
 	if rr.Err != nil {
 		return rr.Err
 	}
+	if kind == requestKindOffLedgerEVMImpersonatedTx {
+		var sender common.Address
+		rr.ReadN(sender[:])
+		req.sender = NewEthereumAddressAgentID(req.chainID, sender)
+		req.impersonated = true
+		return rr.Err
+	}
 	// derive req.sender from req.tx
 	sender, err := evmutil.GetSender(req.tx)
 	if err != nil {
@@ -62,13 +87,21 @@ func (req *evmOffLedgerTxRequest) Read(r io.Reader) error {
 
 func (req *evmOffLedgerTxRequest) Write(w io.Writer) error {
 	ww := rwutil.NewWriter(w)
-	ww.WriteKind(rwutil.Kind(requestKindOffLedgerEVMTx))
+	kind := requestKindOffLedgerEVMTx
+	if req.impersonated {
+		kind = requestKindOffLedgerEVMImpersonatedTx
+	}
+	ww.WriteKind(rwutil.Kind(kind))
 	ww.Write(&req.chainID)
 	if ww.Err == nil {
 		txData := evmtypes.EncodeTransaction(req.tx)
 		ww.WriteBytes(txData)
 	}
-	// no need to write req.sender, it can be derived from req.tx
+	// no need to write req.sender, it can be derived from req.tx, unless impersonated
+	if req.impersonated {
+		sender := req.sender.EthAddress()
+		ww.WriteN(sender[:])
+	}
 	return ww.Err
 }
 
@@ -141,6 +174,9 @@ func (req *evmOffLedgerTxRequest) TargetAddress() iotago.Address {
 }
 
 func (req *evmOffLedgerTxRequest) VerifySignature() error {
+	if req.impersonated {
+		return ErrImpersonatedRequest
+	}
 	sender, err := evmutil.GetSender(req.tx)
 	if err != nil {
 		return fmt.Errorf("cannot verify Ethereum tx sender: %w", err)
@@ -152,7 +188,11 @@ func (req *evmOffLedgerTxRequest) VerifySignature() error {
 }
 
 func (req *evmOffLedgerTxRequest) EVMCallMsg() *ethereum.CallMsg {
-	return EVMCallDataFromTx(req.tx)
+	return evmCallDataFromTx(req.tx, req.sender.EthAddress())
+}
+
+func (req *evmOffLedgerTxRequest) IsImpersonated() bool {
+	return req.impersonated
 }
 
 func (req *evmOffLedgerTxRequest) TxValue() *big.Int {
@@ -162,3 +202,7 @@ func (req *evmOffLedgerTxRequest) TxValue() *big.Int {
 func (req *evmOffLedgerTxRequest) GasPrice() *big.Int {
 	return req.tx.GasPrice()
 }
+
+func (req *evmOffLedgerTxRequest) Sponsor() AgentID {
+	return nil
+}
@@ -31,6 +31,8 @@ type OffLedgerRequestData struct {
 	nonce      uint64
 	params     dict.Dict
 	signature  offLedgerSignature
+	// sponsor pays the gas fee on behalf of the sender, nil if the sender pays
+	sponsor AgentID
 }
 
 var (
@@ -68,6 +70,10 @@ func (r *ImpersonatedOffLedgerRequestData) SenderAccount() AgentID {
 	return NewAgentID(r.address)
 }
 
+func (r *ImpersonatedOffLedgerRequestData) IsImpersonated() bool {
+	return true
+}
+
 func NewOffLedgerRequest(
 	chainID ChainID,
 	contract, entryPoint Hname,
@@ -108,7 +114,10 @@ func (req *OffLedgerRequestData) Write(w io.Writer) error {
 }
 
 func (req *OffLedgerRequestData) readEssence(rr *rwutil.Reader) {
-	rr.ReadKindAndVerify(rwutil.Kind(requestKindOffLedgerISC))
+	kind := RequestKind(rr.ReadKind())
+	if kind != requestKindOffLedgerISC && kind != requestKindOffLedgerSponsored && rr.Err == nil {
+		rr.Err = errors.New("unexpected object kind")
+	}
 	rr.Read(&req.chainID)
 	rr.Read(&req.contract)
 	rr.Read(&req.entryPoint)
@@ -118,10 +127,23 @@ func (req *OffLedgerRequestData) readEssence(rr *rwutil.Reader) {
 	req.gasBudget = rr.ReadGas64()
 	req.allowance = NewEmptyAssets()
 	rr.Read(req.allowance)
+	req.sponsor = nil
+	if kind == requestKindOffLedgerSponsored {
+		req.sponsor = AgentIDFromReader(rr)
+		if req.sponsor == nil && rr.Err == nil {
+			rr.Err = errors.New("sponsored request without sponsor")
+		}
+	}
 }
 
+// writeEssence uses the sponsored request kind only when a sponsor is set,
+// so that the encoding of unsponsored requests stays unchanged
 func (req *OffLedgerRequestData) writeEssence(ww *rwutil.Writer) {
-	ww.WriteKind(rwutil.Kind(requestKindOffLedgerISC))
+	kind := requestKindOffLedgerISC
+	if req.sponsor != nil {
+		kind = requestKindOffLedgerSponsored
+	}
+	ww.WriteKind(rwutil.Kind(kind))
 	ww.Write(&req.chainID)
 	ww.Write(&req.contract)
 	ww.Write(&req.entryPoint)
@@ -129,6 +151,9 @@ func (req *OffLedgerRequestData) writeEssence(ww *rwutil.Writer) {
 	ww.WriteAmount64(req.nonce)
 	ww.WriteGas64(req.gasBudget)
 	ww.Write(req.allowance)
+	if req.sponsor != nil {
+		ww.Write(req.sponsor)
+	}
 }
 
 // Allowance from the sender's account to the target smart contract. Nil mean no Allowance
@@ -217,6 +242,11 @@ func (req *OffLedgerRequestData) Sign(key cryptolib.VariantKeyPair) OffLedgerReq
 	return req
 }
 
+// Sponsor is the agent that pays the gas fee on behalf of the sender, nil if the sender pays
+func (req *OffLedgerRequestData) Sponsor() AgentID {
+	return req.sponsor
+}
+
 func (req *OffLedgerRequestData) String() string {
 	return fmt.Sprintf("offLedgerRequestData::{ ID: %s, sender: %s, target: %s, entrypoint: %s, Params: %s, nonce: %d }",
 		req.ID().String(),
@@ -273,6 +303,12 @@ func (req *OffLedgerRequestData) WithSender(sender *cryptolib.PublicKey) Unsigne
 	return req
 }
 
+// WithSponsor names the agent that pays the gas fee, it must be set before signing
+func (req *OffLedgerRequestData) WithSponsor(sponsor AgentID) UnsignedOffLedgerRequest {
+	req.sponsor = sponsor
+	return req
+}
+
 func (req *OffLedgerRequestData) GasPrice() *big.Int {
 	return nil
 }
@@ -0,0 +1,151 @@
+package isc
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/hashing"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
+	"github.com/nnikolash/wasp-types-exported/packages/util/rwutil"
+)
+
+// ScheduledRequest is a synthetic request injected by the VM when a call scheduled
+// in the blocklog contract becomes due. The sender is the agent that scheduled the call.
+type ScheduledRequest struct {
+	chainID    ChainID
+	scheduleID uint32
+	blockIndex uint32
+	sender     AgentID
+	contract   Hname
+	entryPoint Hname
+	params     dict.Dict
+	gasBudget  uint64
+}
+
+var _ Request = new(ScheduledRequest)
+
+func NewScheduledRequest(
+	chainID ChainID,
+	scheduleID uint32,
+	blockIndex uint32,
+	sender AgentID,
+	contract, entryPoint Hname,
+	params dict.Dict,
+	gasBudget uint64,
+) *ScheduledRequest {
+	return &ScheduledRequest{
+		chainID:    chainID,
+		scheduleID: scheduleID,
+		blockIndex: blockIndex,
+		sender:     sender,
+		contract:   contract,
+		entryPoint: entryPoint,
+		params:     params,
+		gasBudget:  gasBudget,
+	}
+}
+
+func (req *ScheduledRequest) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	rr.ReadKindAndVerify(rwutil.Kind(requestKindScheduled))
+	rr.Read(&req.chainID)
+	req.scheduleID = rr.ReadUint32()
+	req.blockIndex = rr.ReadUint32()
+	req.sender = AgentIDFromReader(rr)
+	rr.Read(&req.contract)
+	rr.Read(&req.entryPoint)
+	req.params = dict.New()
+	rr.Read(&req.params)
+	req.gasBudget = rr.ReadGas64()
+	return rr.Err
+}
+
+func (req *ScheduledRequest) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteKind(rwutil.Kind(requestKindScheduled))
+	ww.Write(&req.chainID)
+	ww.WriteUint32(req.scheduleID)
+	ww.WriteUint32(req.blockIndex)
+	AgentIDToWriter(ww, req.sender)
+	ww.Write(&req.contract)
+	ww.Write(&req.entryPoint)
+	ww.Write(&req.params)
+	ww.WriteGas64(req.gasBudget)
+	return ww.Err
+}
+
+func (req *ScheduledRequest) Allowance() *Assets {
+	return NewEmptyAssets()
+}
+
+func (req *ScheduledRequest) Assets() *Assets {
+	return nil
+}
+
+// BlockIndex is the index of the block in which the scheduled call runs
+func (req *ScheduledRequest) BlockIndex() uint32 {
+	return req.blockIndex
+}
+
+func (req *ScheduledRequest) Bytes() []byte {
+	return rwutil.WriteToBytes(req)
+}
+
+func (req *ScheduledRequest) CallTarget() CallTarget {
+	return CallTarget{
+		Contract:   req.contract,
+		EntryPoint: req.entryPoint,
+	}
+}
+
+func (req *ScheduledRequest) EVMCallMsg() *ethereum.CallMsg {
+	return nil
+}
+
+func (req *ScheduledRequest) GasBudget() (gasBudget uint64, isEVM bool) {
+	return req.gasBudget, false
+}
+
+// ID is unique for every run of the scheduled call, because it includes the block index
+func (req *ScheduledRequest) ID() RequestID {
+	return NewRequestID(iotago.TransactionID(hashing.HashData(req.Bytes())), 0)
+}
+
+func (req *ScheduledRequest) IsOffLedger() bool {
+	return false
+}
+
+func (req *ScheduledRequest) NFT() *NFT {
+	return nil
+}
+
+func (req *ScheduledRequest) Params() dict.Dict {
+	return req.params
+}
+
+// ScheduleID is the ID of the schedule in the blocklog contract
+func (req *ScheduledRequest) ScheduleID() uint32 {
+	return req.scheduleID
+}
+
+func (req *ScheduledRequest) SenderAccount() AgentID {
+	return req.sender
+}
+
+func (req *ScheduledRequest) String() string {
+	return fmt.Sprintf("scheduledRequest::{ ID: %s, schedule: %d, sender: %s, target: %s, entrypoint: %s, Params: %s }",
+		req.ID().String(),
+		req.scheduleID,
+		req.sender.String(),
+		req.contract.String(),
+		req.entryPoint.String(),
+		req.params.String(),
+	)
+}
+
+func (req *ScheduledRequest) TargetAddress() iotago.Address {
+	return req.chainID.AsAddress()
+}
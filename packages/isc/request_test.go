@@ -23,6 +23,29 @@ func TestRequestDataSerialization(t *testing.T) {
 		rwutil.BytesTest(t, req, RequestFromBytes)
 	})
 
+	t.Run("sponsored off ledger", func(t *testing.T) {
+		sponsor := NewAgentID(tpkg.RandEd25519Address())
+		req = NewOffLedgerRequest(RandomChainID(), 3, 14, dict.New(), 1337, 100).
+			WithSponsor(sponsor).
+			Sign(cryptolib.NewKeyPair())
+		rwutil.ReadWriteTest(t, req.(*OffLedgerRequestData), new(OffLedgerRequestData))
+		rwutil.BytesTest(t, req, RequestFromBytes)
+		back, err2 := RequestFromBytes(req.Bytes())
+		require.NoError(t, err2)
+		require.True(t, back.(OffLedgerRequest).Sponsor().Equals(sponsor))
+		require.NoError(t, back.(OffLedgerRequest).VerifySignature())
+	})
+
+	t.Run("scheduled", func(t *testing.T) {
+		params := dict.Dict{"x": []byte{1, 2, 3}}
+		req = NewScheduledRequest(RandomChainID(), 7, 42, NewAgentID(tpkg.RandEd25519Address()), 3, 14, params, 1337)
+		rwutil.ReadWriteTest(t, req.(*ScheduledRequest), new(ScheduledRequest))
+		rwutil.BytesTest(t, req, RequestFromBytes)
+		// every run of the scheduled call has its own request ID
+		next := NewScheduledRequest(req.(*ScheduledRequest).chainID, 7, 43, req.SenderAccount(), 3, 14, params, 1337)
+		require.NotEqual(t, req.ID(), next.ID())
+	})
+
 	t.Run("on ledger", func(t *testing.T) {
 		sender := tpkg.RandAliasAddress()
 		requestMetadata := &RequestMetadata{
@@ -20,11 +20,14 @@ const (
 	requestKindOffLedgerISC
 	requestKindOffLedgerEVMTx
 	requestKindOffLedgerEVMCall
+	requestKindOffLedgerSponsored
+	requestKindScheduled
+	requestKindOffLedgerEVMImpersonatedTx
 )
 
 func IsOffledgerKind(b byte) bool {
 	switch RequestKind(b) {
-	case requestKindOffLedgerISC, requestKindOffLedgerEVMTx:
+	case requestKindOffLedgerISC, requestKindOffLedgerEVMTx, requestKindOffLedgerSponsored:
 		return true
 	}
 	return false
@@ -40,12 +43,14 @@ func RequestFromReader(rr *rwutil.Reader) (ret Request) {
 	switch RequestKind(kind) {
 	case requestKindOnLedger:
 		ret = new(OnLedgerRequestData)
-	case requestKindOffLedgerISC:
+	case requestKindOffLedgerISC, requestKindOffLedgerSponsored:
 		ret = new(OffLedgerRequestData)
-	case requestKindOffLedgerEVMTx:
+	case requestKindOffLedgerEVMTx, requestKindOffLedgerEVMImpersonatedTx:
 		ret = new(evmOffLedgerTxRequest)
 	case requestKindOffLedgerEVMCall:
 		ret = new(evmOffLedgerCallRequest)
+	case requestKindScheduled:
+		ret = new(ScheduledRequest)
 	default:
 		if rr.Err == nil {
 			rr.Err = errors.New("invalid Request kind")
@@ -0,0 +1,167 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeySize is the size of the keys, read from the key files.
+const KeySize = chacha20poly1305.KeySize
+
+func seal(aead cipher.AEAD, prefix, plaintext, associatedData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("cannot generate nonce: %w", err)
+	}
+	out := make([]byte, 0, len(prefix)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, prefix...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, associatedData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, associatedData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrDecryptionFailed
+	}
+	nonce := ciphertext[:aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, ciphertext[aead.NonceSize():], associatedData)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+// staticKeyStore encrypts the data with a key, provided from outside.
+type staticKeyStore struct {
+	aead cipher.AEAD
+}
+
+var _ KeyStore = &staticKeyStore{}
+
+// NewStaticKeyStore creates a key store, encrypting the data with the given key.
+func NewStaticKeyStore(key []byte) (KeyStore, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return &staticKeyStore{aead: aead}, nil
+}
+
+// NewKeyFileKeyStore creates a key store, encrypting the data with the key, read from
+// the file. The file contains the hex encoded key of KeySize bytes. It is meant to be
+// provisioned by an external secret manager, e.g. mounted to a tmpfs.
+func NewKeyFileKeyStore(filePath string) (KeyStore, error) {
+	if filePath == "" {
+		return nil, errors.New("key file path not specified")
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the key file: %w", err)
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("the key file has to contain a hex encoded key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("the key has to be %v bytes long, got %v", KeySize, len(key))
+	}
+	return NewStaticKeyStore(key)
+}
+
+func (ks *staticKeyStore) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	return seal(ks.aead, nil, plaintext, associatedData)
+}
+
+func (ks *staticKeyStore) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	return open(ks.aead, ciphertext, associatedData)
+}
+
+const (
+	passphraseSaltSize = 16
+	scryptN            = 1 << 15
+	scryptR            = 8
+	scryptP            = 1
+)
+
+// passphraseKeyStore encrypts the data with a key, derived from a passphrase.
+// The salt of the key derivation is stored in front of each ciphertext, the keys
+// are cached per salt to avoid the costly derivation for each of them.
+type passphraseKeyStore struct {
+	passphrase []byte
+	salt       []byte
+	aeads      map[string]cipher.AEAD
+	mutex      *sync.Mutex
+}
+
+var _ KeyStore = &passphraseKeyStore{}
+
+// NewPassphraseKeyStore creates a key store, encrypting the data with a key, derived from the passphrase.
+func NewPassphraseKeyStore(passphrase string) (KeyStore, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase must not be empty")
+	}
+	salt := make([]byte, passphraseSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("cannot generate salt: %w", err)
+	}
+	ks := &passphraseKeyStore{
+		passphrase: []byte(passphrase),
+		salt:       salt,
+		aeads:      map[string]cipher.AEAD{},
+		mutex:      &sync.Mutex{},
+	}
+	if _, err := ks.aead(salt); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+func (ks *passphraseKeyStore) aead(salt []byte) (cipher.AEAD, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	if aead, ok := ks.aeads[string(salt)]; ok {
+		return aead, nil
+	}
+	key, err := scrypt.Key(ks.passphrase, salt, scryptN, scryptR, scryptP, KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive key: %w", err)
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	ks.aeads[string(salt)] = aead
+	return aead, nil
+}
+
+func (ks *passphraseKeyStore) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	aead, err := ks.aead(ks.salt)
+	if err != nil {
+		return nil, err
+	}
+	return seal(aead, ks.salt, plaintext, associatedData)
+}
+
+func (ks *passphraseKeyStore) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	if len(ciphertext) < passphraseSaltSize {
+		return nil, ErrDecryptionFailed
+	}
+	aead, err := ks.aead(ciphertext[:passphraseSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, ciphertext[passphraseSaltSize:], associatedData)
+}
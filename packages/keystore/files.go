@@ -0,0 +1,195 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+
+	hivep2p "github.com/iotaledger/hive.go/crypto/p2p"
+	hivepem "github.com/iotaledger/hive.go/crypto/pem"
+	"github.com/iotaledger/hive.go/runtime/ioutils"
+)
+
+var ErrNoKeyStore = errors.New("the data is encrypted, but no key store is configured")
+
+// encryptedFile is the JSON representation of a file, encrypted by a key store.
+type encryptedFile struct {
+	Encrypted string `json:"encrypted"`
+}
+
+// WriteJSONToFile stores the data as JSON. If the key store is not nil, the JSON is
+// encrypted, and the name of the file is used as the associated data, so that the
+// encrypted files can't be swapped.
+func WriteJSONToFile(ks KeyStore, filePath string, data any, perm os.FileMode) error {
+	if ks == nil {
+		return ioutils.WriteJSONToFile(filePath, data, perm)
+	}
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	ciphertext, err := ks.Encrypt(plaintext, []byte(filepath.Base(filePath)))
+	if err != nil {
+		return fmt.Errorf("unable to encrypt %s: %w", filePath, err)
+	}
+	return ioutils.WriteJSONToFile(filePath, &encryptedFile{Encrypted: hex.EncodeToString(ciphertext)}, perm)
+}
+
+// ReadJSONFromFile reads the data, stored by WriteJSONToFile. The unencrypted files are
+// accepted as well, to allow migrating the existing data, thus it is returned, if the file
+// was encrypted.
+func ReadJSONFromFile(ks KeyStore, filePath string, data any) (bool, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	encrypted := &encryptedFile{}
+	if err := json.Unmarshal(content, encrypted); err != nil || encrypted.Encrypted == "" {
+		return false, json.Unmarshal(content, data)
+	}
+	if ks == nil {
+		return true, ErrNoKeyStore
+	}
+	ciphertext, err := hex.DecodeString(encrypted.Encrypted)
+	if err != nil {
+		return true, err
+	}
+	plaintext, err := ks.Decrypt(ciphertext, []byte(filepath.Base(filePath)))
+	if err != nil {
+		return true, fmt.Errorf("unable to decrypt %s: %w", filePath, err)
+	}
+	return true, json.Unmarshal(plaintext, data)
+}
+
+const encryptedStringPrefix = "enc:"
+
+// EncryptString encrypts a value, stored in the configuration files. The value is
+// returned as is, if the key store is nil.
+func EncryptString(ks KeyStore, value, associatedData string) (string, error) {
+	if ks == nil {
+		return value, nil
+	}
+	ciphertext, err := ks.Encrypt([]byte(value), []byte(associatedData))
+	if err != nil {
+		return "", err
+	}
+	return encryptedStringPrefix + hex.EncodeToString(ciphertext), nil
+}
+
+// IsEncryptedString returns, if the value was produced by EncryptString with a key store.
+func IsEncryptedString(value string) bool {
+	return strings.HasPrefix(value, encryptedStringPrefix)
+}
+
+// DecryptString decrypts the value, produced by EncryptString. The unencrypted
+// values are returned as is.
+func DecryptString(ks KeyStore, value, associatedData string) (string, error) {
+	if !IsEncryptedString(value) {
+		return value, nil
+	}
+	if ks == nil {
+		return "", ErrNoKeyStore
+	}
+	ciphertext, err := hex.DecodeString(strings.TrimPrefix(value, encryptedStringPrefix))
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := ks.Decrypt(ciphertext, []byte(associatedData))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+const identityPEMType = "ENCRYPTED ED25519 PRIVATE KEY"
+
+// LoadOrCreateIdentityPrivateKey loads the private key of the node identity from the
+// PEM file or creates a new one, the same way as the hive.go p2p package does. If the
+// key store is not nil, the key is kept encrypted, and an existing unencrypted file
+// is encrypted in place. It is returned, if a new key was stored.
+func LoadOrCreateIdentityPrivateKey(ks KeyStore, filePath, identityPrivKey string) (libp2pcrypto.PrivKey, bool, error) {
+	if ks == nil {
+		return hivep2p.LoadOrCreateIdentityPrivateKey(filePath, identityPrivKey)
+	}
+
+	privKeyFromConfig, err := hivep2p.ParseLibp2pEd25519PrivateKeyFromString(identityPrivKey)
+	if err != nil && !errors.Is(err, hivep2p.ErrNoPrivKeyFound) {
+		return nil, false, fmt.Errorf("unable to parse private key from config: %w", err)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		privKey := privKeyFromConfig
+		if privKey == nil {
+			if privKey, _, err = libp2pcrypto.GenerateKeyPair(libp2pcrypto.Ed25519, -1); err != nil {
+				return nil, false, fmt.Errorf("unable to generate Ed25519 private key for peer identity: %w", err)
+			}
+		}
+		if err := writeEncryptedIdentity(ks, filePath, privKey); err != nil {
+			return nil, false, err
+		}
+		return privKey, true, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read private key file for peer identity (%s): %w", filePath, err)
+	}
+
+	var privKey libp2pcrypto.PrivKey
+	if block, _ := pem.Decode(content); block != nil && block.Type == identityPEMType {
+		plaintext, err2 := ks.Decrypt(block.Bytes, []byte(identityPEMType))
+		if err2 != nil {
+			return nil, false, fmt.Errorf("unable to decrypt private key for peer identity: %w", err2)
+		}
+		if privKey, err = libp2pcrypto.UnmarshalEd25519PrivateKey(plaintext); err != nil {
+			return nil, false, fmt.Errorf("unable to load Ed25519 private key for peer identity: %w", err)
+		}
+	} else {
+		edPrivKey, err2 := hivepem.ReadEd25519PrivateKeyFromPEMFile(filePath)
+		if err2 != nil {
+			return nil, false, fmt.Errorf("unable to load Ed25519 private key for peer identity: %w", err2)
+		}
+		if privKey, err = hivep2p.Ed25519PrivateKeyToLibp2pPrivateKey(edPrivKey); err != nil {
+			return nil, false, err
+		}
+		if err := writeEncryptedIdentity(ks, filePath, privKey); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if privKeyFromConfig != nil && !privKeyFromConfig.Equals(privKey) {
+		return nil, false, errors.New("stored Ed25519 private key for peer identity doesn't match private key in config")
+	}
+	return privKey, false, nil
+}
+
+func writeEncryptedIdentity(ks KeyStore, filePath string, privKey libp2pcrypto.PrivKey) error {
+	privKeyBytes, err := privKey.Raw()
+	if err != nil {
+		return err
+	}
+	if len(privKeyBytes) != ed25519.PrivateKeySize {
+		return fmt.Errorf("unexpected Ed25519 private key length %v", len(privKeyBytes))
+	}
+	ciphertext, err := ks.Encrypt(privKeyBytes, []byte(identityPEMType))
+	if err != nil {
+		return fmt.Errorf("unable to encrypt private key for peer identity: %w", err)
+	}
+	if err := ioutils.CreateDirectory(filepath.Dir(filePath), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, pem.EncodeToMemory(&pem.Block{Type: identityPEMType, Bytes: ciphertext}), 0o600); err != nil {
+		return fmt.Errorf("unable to store private key file for peer identity: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// Package keystore encrypts the secrets of the node (the node identity, the
+// DKShares and the hashes of the user credentials) at rest.
+//
+// The key used for that is held by a KeyStore. The key stores provided here
+// derive the key from a passphrase, read it from a key file, which can be
+// provisioned by an external secret manager, or keep it on a PKCS#11 token
+// (or SoftHSM for the local setups and the tests). Other backends can be
+// plugged in via Register, because the KeyStore interface never exposes the
+// key itself.
+//
+// The passphrase (or the PIN of the token) is never part of the configuration,
+// it is read from an environment variable or a file, see ReadPassphrase.
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// KeyStore holds the long-term key, used to encrypt the node secrets at rest.
+type KeyStore interface {
+	// Encrypt seals the plaintext. The associated data is authenticated, but not
+	// encrypted, it binds the ciphertext to its purpose (e.g. the file name).
+	Encrypt(plaintext, associatedData []byte) ([]byte, error)
+	// Decrypt opens the ciphertext, produced by Encrypt with the same associated data.
+	Decrypt(ciphertext, associatedData []byte) ([]byte, error)
+}
+
+const (
+	TypeNone       = "none"
+	TypePassphrase = "passphrase"
+	TypeKeyFile    = "keyFile"
+	TypePKCS11     = "pkcs11" // Available in the builds with cgo only.
+)
+
+var ErrDecryptionFailed = errors.New("decryption failed")
+
+// Config contains the parameters, the key store backends are created with.
+type Config struct {
+	// Passphrase of the passphrase key store, or the user PIN of the PKCS#11 token.
+	Passphrase  string
+	KeyFilePath string
+	// Options are backend specific, e.g. the module path, the slot and the key label of a PKCS#11 token.
+	Options map[string]string
+}
+
+// ReadPassphrase reads the passphrase from the file, if its path is given,
+// or from the environment variable otherwise. The trailing line break of
+// the file is not part of the passphrase.
+func ReadPassphrase(envName, filePath string) (string, error) {
+	if filePath == "" {
+		return os.Getenv(envName), nil
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("cannot read the passphrase file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Factory creates a key store backend.
+type Factory func(cfg *Config) (KeyStore, error)
+
+var (
+	factories = map[string]Factory{
+		TypePassphrase: func(cfg *Config) (KeyStore, error) {
+			return NewPassphraseKeyStore(cfg.Passphrase)
+		},
+		TypeKeyFile: func(cfg *Config) (KeyStore, error) {
+			return NewKeyFileKeyStore(cfg.KeyFilePath)
+		},
+	}
+	factoriesMutex = &sync.RWMutex{}
+)
+
+// Register makes a key store backend available by its name.
+func Register(name string, factory Factory) {
+	factoriesMutex.Lock()
+	defer factoriesMutex.Unlock()
+
+	if _, ok := factories[name]; ok || name == TypeNone {
+		panic(fmt.Sprintf("key store %q is already registered", name))
+	}
+	factories[name] = factory
+}
+
+// New creates the key store backend by its name. For TypeNone (or an empty name)
+// nil is returned, which means the secrets are stored unencrypted.
+func New(name string, cfg *Config) (KeyStore, error) {
+	if name == "" || name == TypeNone {
+		return nil, nil
+	}
+
+	factoriesMutex.RLock()
+	factory, ok := factories[name]
+	factoriesMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown key store %q, known are: %v", name, registeredNames())
+	}
+	return factory(cfg)
+}
+
+func registeredNames() []string {
+	factoriesMutex.RLock()
+	defer factoriesMutex.RUnlock()
+
+	names := make([]string, 0, len(factories)+1)
+	names = append(names, TypeNone)
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
@@ -0,0 +1,199 @@
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	hivep2p "github.com/iotaledger/hive.go/crypto/p2p"
+	"github.com/iotaledger/hive.go/runtime/ioutils"
+)
+
+func TestPassphraseKeyStore(t *testing.T) {
+	ks, err := NewPassphraseKeyStore("secret")
+	require.NoError(t, err)
+
+	ciphertext, err := ks.Encrypt([]byte("data"), []byte("ad"))
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext), "data")
+
+	plaintext, err := ks.Decrypt(ciphertext, []byte("ad"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), plaintext)
+
+	_, err = ks.Decrypt(ciphertext, []byte("other"))
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+
+	// The same passphrase opens the data, encrypted by another instance (with another salt).
+	ks2, err := NewPassphraseKeyStore("secret")
+	require.NoError(t, err)
+	plaintext, err = ks2.Decrypt(ciphertext, []byte("ad"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), plaintext)
+
+	ks3, err := NewPassphraseKeyStore("wrong")
+	require.NoError(t, err)
+	_, err = ks3.Decrypt(ciphertext, []byte("ad"))
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+}
+
+func TestKeyFileKeyStore(t *testing.T) {
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	keyFilePath := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFilePath, []byte("0x"+hex.EncodeToString(key)+"\n"), 0o600))
+
+	ks, err := New(TypeKeyFile, &Config{KeyFilePath: keyFilePath})
+	require.NoError(t, err)
+	ciphertext, err := ks.Encrypt([]byte("data"), nil)
+	require.NoError(t, err)
+
+	ks2, err := NewStaticKeyStore(key)
+	require.NoError(t, err)
+	plaintext, err := ks2.Decrypt(ciphertext, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), plaintext)
+
+	require.NoError(t, os.WriteFile(keyFilePath, []byte("00"), 0o600))
+	_, err = New(TypeKeyFile, &Config{KeyFilePath: keyFilePath})
+	require.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	ks, err := New(TypeNone, &Config{})
+	require.NoError(t, err)
+	require.Nil(t, ks)
+
+	_, err = New("unknown", &Config{})
+	require.Error(t, err)
+
+	require.Panics(t, func() { Register(TypePassphrase, nil) })
+}
+
+func TestReadPassphrase(t *testing.T) {
+	t.Setenv("TEST_KEYSTORE_PASSPHRASE", "from env")
+	passphrase, err := ReadPassphrase("TEST_KEYSTORE_PASSPHRASE", "")
+	require.NoError(t, err)
+	require.Equal(t, "from env", passphrase)
+
+	// the file takes precedence
+	filePath := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(filePath, []byte("from file\n"), 0o600))
+	passphrase, err = ReadPassphrase("TEST_KEYSTORE_PASSPHRASE", filePath)
+	require.NoError(t, err)
+	require.Equal(t, "from file", passphrase)
+
+	_, err = ReadPassphrase("TEST_KEYSTORE_PASSPHRASE", filePath+"-missing")
+	require.Error(t, err)
+}
+
+type testData struct {
+	Value string `json:"value"`
+}
+
+func TestJSONFileMigration(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, ioutils.WriteJSONToFile(filePath, &testData{Value: "secret"}, 0o600))
+
+	ks, err := NewPassphraseKeyStore("passphrase")
+	require.NoError(t, err)
+
+	data := &testData{}
+	encrypted, err := ReadJSONFromFile(ks, filePath, data)
+	require.NoError(t, err)
+	require.False(t, encrypted)
+	require.Equal(t, "secret", data.Value)
+
+	require.NoError(t, WriteJSONToFile(ks, filePath, data, 0o600))
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "secret")
+
+	data = &testData{}
+	encrypted, err = ReadJSONFromFile(ks, filePath, data)
+	require.NoError(t, err)
+	require.True(t, encrypted)
+	require.Equal(t, "secret", data.Value)
+
+	_, err = ReadJSONFromFile(nil, filePath, &testData{})
+	require.ErrorIs(t, err, ErrNoKeyStore)
+
+	// the file name is bound to the ciphertext
+	otherFilePath := filepath.Join(filepath.Dir(filePath), "other.json")
+	require.NoError(t, os.Rename(filePath, otherFilePath))
+	_, err = ReadJSONFromFile(ks, otherFilePath, &testData{})
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+}
+
+func TestEncryptString(t *testing.T) {
+	value, err := EncryptString(nil, "value", "ad")
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+
+	ks, err := NewPassphraseKeyStore("passphrase")
+	require.NoError(t, err)
+
+	encrypted, err := EncryptString(ks, "value", "ad")
+	require.NoError(t, err)
+	require.NotEqual(t, "value", encrypted)
+
+	value, err = DecryptString(ks, encrypted, "ad")
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+
+	value, err = DecryptString(ks, "plain", "ad")
+	require.NoError(t, err)
+	require.Equal(t, "plain", value)
+
+	_, err = DecryptString(ks, encrypted, "other")
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = DecryptString(nil, encrypted, "ad")
+	require.ErrorIs(t, err, ErrNoKeyStore)
+}
+
+func TestLoadOrCreateIdentityPrivateKey(t *testing.T) {
+	ks, err := NewPassphraseKeyStore("passphrase")
+	require.NoError(t, err)
+
+	// an unencrypted identity is migrated
+	filePath := filepath.Join(t.TempDir(), "identity.key")
+	privKey, created, err := hivep2p.LoadOrCreateIdentityPrivateKey(filePath, "")
+	require.NoError(t, err)
+	require.True(t, created)
+
+	loaded, created, err := LoadOrCreateIdentityPrivateKey(ks, filePath, "")
+	require.NoError(t, err)
+	require.False(t, created)
+	require.True(t, privKey.Equals(loaded))
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Contains(t, string(content), identityPEMType)
+
+	loaded, created, err = LoadOrCreateIdentityPrivateKey(ks, filePath, "")
+	require.NoError(t, err)
+	require.False(t, created)
+	require.True(t, privKey.Equals(loaded))
+
+	ks2, err := NewPassphraseKeyStore("wrong")
+	require.NoError(t, err)
+	_, _, err = LoadOrCreateIdentityPrivateKey(ks2, filePath, "")
+	require.Error(t, err)
+
+	// a new identity is stored encrypted
+	filePath = filepath.Join(t.TempDir(), "new", "identity.key")
+	privKey, created, err = LoadOrCreateIdentityPrivateKey(ks, filePath, "")
+	require.NoError(t, err)
+	require.True(t, created)
+
+	loaded, created, err = LoadOrCreateIdentityPrivateKey(ks, filePath, "")
+	require.NoError(t, err)
+	require.False(t, created)
+	require.True(t, privKey.Equals(loaded))
+}
@@ -0,0 +1,209 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build cgo
+
+package keystore
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+const (
+	// DefaultPKCS11KeyLabel is the label of the key on the token, if none is configured.
+	DefaultPKCS11KeyLabel = "wasp-keystore"
+
+	pkcs11NonceSize = 12
+	pkcs11TagBits   = 128
+)
+
+func init() {
+	Register(TypePKCS11, func(cfg *Config) (KeyStore, error) {
+		return NewPKCS11KeyStore(cfg.Options["module"], cfg.Options["tokenLabel"], cfg.Options["keyLabel"], cfg.Passphrase)
+	})
+}
+
+// pkcs11KeyStore encrypts the data with AES-GCM, using a key which never leaves the PKCS#11 token.
+type pkcs11KeyStore struct {
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	key     pkcs11.ObjectHandle
+	mutex   *sync.Mutex // The operations of a session can't run concurrently.
+}
+
+var (
+	_ KeyStore  = &pkcs11KeyStore{}
+	_ io.Closer = &pkcs11KeyStore{}
+)
+
+// NewPKCS11KeyStore creates a key store, encrypting the data with the AES key labeled keyLabel
+// on the token labeled tokenLabel (which can be omitted, if there is a single token). The key is
+// generated on the token, if it doesn't exist yet. SoftHSM can be used as the module for the local
+// setups and the tests.
+func NewPKCS11KeyStore(modulePath, tokenLabel, keyLabel, pin string) (KeyStore, error) {
+	if modulePath == "" {
+		return nil, errors.New("PKCS#11 module path not specified")
+	}
+	if keyLabel == "" {
+		keyLabel = DefaultPKCS11KeyLabel
+	}
+	ctx := pkcs11.New(modulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf("cannot load the PKCS#11 module %s", modulePath)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("cannot initialize the PKCS#11 module: %w", err)
+	}
+	ks := &pkcs11KeyStore{ctx: ctx, mutex: &sync.Mutex{}}
+	if err := ks.open(tokenLabel, keyLabel, pin); err != nil {
+		_ = ctx.Finalize()
+		ctx.Destroy()
+		return nil, err
+	}
+	return ks, nil
+}
+
+func (ks *pkcs11KeyStore) open(tokenLabel, keyLabel, pin string) error {
+	slot, err := findPKCS11Slot(ks.ctx, tokenLabel)
+	if err != nil {
+		return err
+	}
+	if ks.session, err = ks.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION); err != nil {
+		return fmt.Errorf("cannot open a session with the PKCS#11 token: %w", err)
+	}
+	if err = ks.ctx.Login(ks.session, pkcs11.CKU_USER, pin); err != nil {
+		return fmt.Errorf("cannot log in to the PKCS#11 token: %w", err)
+	}
+	if ks.key, err = ks.findOrGenerateKey(keyLabel); err != nil {
+		return err
+	}
+	return nil
+}
+
+func findPKCS11Slot(ctx *pkcs11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("cannot list the PKCS#11 slots: %w", err)
+	}
+	if tokenLabel == "" {
+		if len(slots) != 1 {
+			return 0, fmt.Errorf("the PKCS#11 token label has to be specified, there are %v tokens", len(slots))
+		}
+		return slots[0], nil
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("cannot get the PKCS#11 token info: %w", err)
+		}
+		if strings.TrimRight(info.Label, " \x00") == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("PKCS#11 token %q not found", tokenLabel)
+}
+
+func (ks *pkcs11KeyStore) findOrGenerateKey(keyLabel string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+	}
+	if err := ks.ctx.FindObjectsInit(ks.session, template); err != nil {
+		return 0, fmt.Errorf("cannot look for the key on the PKCS#11 token: %w", err)
+	}
+	keys, _, err := ks.ctx.FindObjects(ks.session, 2)
+	if err2 := ks.ctx.FindObjectsFinal(ks.session); err == nil {
+		err = err2
+	}
+	if err != nil {
+		return 0, fmt.Errorf("cannot look for the key on the PKCS#11 token: %w", err)
+	}
+	switch len(keys) {
+	case 0:
+	case 1:
+		return keys[0], nil
+	default:
+		return 0, fmt.Errorf("there are several keys labeled %q on the PKCS#11 token", keyLabel)
+	}
+
+	key, err := ks.ctx.GenerateKey(
+		ks.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_GEN, nil)},
+		append(template,
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+			pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+			pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, KeySize),
+		),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("cannot generate the key on the PKCS#11 token: %w", err)
+	}
+	return key, nil
+}
+
+func (ks *pkcs11KeyStore) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	nonce := make([]byte, pkcs11NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("cannot generate nonce: %w", err)
+	}
+	ciphertext, err := ks.crypt(true, nonce, plaintext, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encrypt with the PKCS#11 token: %w", err)
+	}
+	return append(nonce, ciphertext...), nil
+}
+
+func (ks *pkcs11KeyStore) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	if len(ciphertext) < pkcs11NonceSize+pkcs11TagBits/8 {
+		return nil, ErrDecryptionFailed
+	}
+	plaintext, err := ks.crypt(false, ciphertext[:pkcs11NonceSize], ciphertext[pkcs11NonceSize:], associatedData)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+func (ks *pkcs11KeyStore) crypt(encrypt bool, nonce, data, associatedData []byte) ([]byte, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	params := pkcs11.NewGCMParams(nonce, associatedData, pkcs11TagBits)
+	defer params.Free()
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_GCM, params)}
+	if encrypt {
+		if err := ks.ctx.EncryptInit(ks.session, mechanism, ks.key); err != nil {
+			return nil, err
+		}
+		return ks.ctx.Encrypt(ks.session, data)
+	}
+	if err := ks.ctx.DecryptInit(ks.session, mechanism, ks.key); err != nil {
+		return nil, err
+	}
+	return ks.ctx.Decrypt(ks.session, data)
+}
+
+// Close logs out of the token and unloads the module.
+func (ks *pkcs11KeyStore) Close() error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	_ = ks.ctx.Logout(ks.session)
+	_ = ks.ctx.CloseSession(ks.session)
+	err := ks.ctx.Finalize()
+	ks.ctx.Destroy()
+	return err
+}
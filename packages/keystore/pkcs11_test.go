@@ -0,0 +1,104 @@
+//go:build cgo
+
+package keystore
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/require"
+)
+
+// The PKCS#11 key store is tested with SoftHSM, the path to its module can be
+// set with SOFTHSM2_MODULE. The test is skipped, if SoftHSM is not installed.
+var softHSMModules = []string{
+	"/usr/lib/softhsm/libsofthsm2.so",
+	"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/lib/aarch64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/local/lib/softhsm/libsofthsm2.so",
+	"/opt/homebrew/lib/softhsm/libsofthsm2.so",
+}
+
+const (
+	testTokenLabel = "wasp-test"
+	testPIN        = "1234"
+)
+
+// initSoftHSM initializes a fresh SoftHSM token in a temporary directory and returns the path to the module.
+func initSoftHSM(t *testing.T) string {
+	module := os.Getenv("SOFTHSM2_MODULE")
+	if module == "" {
+		for _, m := range softHSMModules {
+			if _, err := os.Stat(m); err == nil {
+				module = m
+				break
+			}
+		}
+	}
+	if module == "" {
+		t.Skip("SoftHSM is not installed")
+	}
+
+	dir := t.TempDir()
+	tokenDir := filepath.Join(dir, "tokens")
+	require.NoError(t, os.Mkdir(tokenDir, 0o700))
+	confPath := filepath.Join(dir, "softhsm2.conf")
+	require.NoError(t, os.WriteFile(confPath, []byte("directories.tokendir = "+tokenDir+"\nobjectstore.backend = file\n"), 0o600))
+	t.Setenv("SOFTHSM2_CONF", confPath)
+
+	ctx := pkcs11.New(module)
+	require.NotNil(t, ctx)
+	defer ctx.Destroy()
+	require.NoError(t, ctx.Initialize())
+	defer func() { require.NoError(t, ctx.Finalize()) }()
+
+	slots, err := ctx.GetSlotList(false)
+	require.NoError(t, err)
+	require.NoError(t, ctx.InitToken(slots[0], "so-pin", testTokenLabel))
+	// SoftHSM moves the initialized token to a new slot
+	slot, err := findPKCS11Slot(ctx, testTokenLabel)
+	require.NoError(t, err)
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	require.NoError(t, ctx.Login(session, pkcs11.CKU_SO, "so-pin"))
+	require.NoError(t, ctx.InitPIN(session, testPIN))
+	require.NoError(t, ctx.Logout(session))
+	require.NoError(t, ctx.CloseSession(session))
+	return module
+}
+
+func TestPKCS11KeyStore(t *testing.T) {
+	module := initSoftHSM(t)
+
+	_, err := NewPKCS11KeyStore(module, testTokenLabel, "", "wrong")
+	require.Error(t, err)
+
+	// the key is generated on the first use
+	ks, err := New(TypePKCS11, &Config{
+		Passphrase: testPIN,
+		Options:    map[string]string{"module": module, "tokenLabel": testTokenLabel},
+	})
+	require.NoError(t, err)
+	ciphertext, err := ks.Encrypt([]byte("data"), []byte("ad"))
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext), "data")
+
+	plaintext, err := ks.Decrypt(ciphertext, []byte("ad"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), plaintext)
+
+	_, err = ks.Decrypt(ciphertext, []byte("other"))
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+	require.NoError(t, ks.(io.Closer).Close())
+
+	// the key is found on the token afterwards
+	ks, err = NewPKCS11KeyStore(module, "", DefaultPKCS11KeyLabel, testPIN)
+	require.NoError(t, err)
+	defer ks.(io.Closer).Close()
+	plaintext, err = ks.Decrypt(ciphertext, []byte("ad"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), plaintext)
+}
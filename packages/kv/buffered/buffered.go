@@ -137,28 +137,43 @@ func (b *BufferedKVStore) IterateSorted(prefix kv.Key, f func(key kv.Key, value
 	})
 }
 
+// IterateKeysSorted merges the sorted mutations with the sorted keys of the backing store,
+// so the iteration can stop early without reading all the keys with the prefix
 func (b *BufferedKVStore) IterateKeysSorted(prefix kv.Key, f func(key kv.Key) bool) {
-	var keys []kv.Key
-
+	var sets []kv.Key
 	for k := range b.muts.Sets {
-		if !k.HasPrefix(prefix) {
-			continue
+		if k.HasPrefix(prefix) {
+			sets = append(sets, k)
 		}
-		keys = append(keys, k)
 	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i] < sets[j] })
 
+	stopped := false
 	b.r.IterateKeysSorted(prefix, func(k kv.Key) bool {
-		if !b.muts.Contains(k) {
-			keys = append(keys, k)
+		for len(sets) > 0 && sets[0] < k {
+			if !f(sets[0]) {
+				stopped = true
+				return false
+			}
+			sets = sets[1:]
+		}
+		if len(sets) > 0 && sets[0] == k {
+			sets = sets[1:]
+		} else if b.muts.Contains(k) {
+			return true // deleted
+		}
+		if !f(k) {
+			stopped = true
+			return false
 		}
 		return true
 	})
-
-	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
-
-	for _, k := range keys {
+	if stopped {
+		return
+	}
+	for _, k := range sets {
 		if !f(k) {
-			break
+			return
 		}
 	}
 }
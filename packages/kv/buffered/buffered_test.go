@@ -104,4 +104,12 @@ func TestIterateSorted(t *testing.T) {
 		return true
 	})
 	require.Equal(t, []kv.Key{"234", "245", "247", "248", "250", "259"}, seen)
+
+	// the iteration stops early, with the mutations merged in order
+	seen = nil
+	b.IterateKeysSorted("2", func(k kv.Key) bool {
+		seen = append(seen, k)
+		return k < "249"
+	})
+	require.Equal(t, []kv.Key{"234", "245", "247", "248", "250"}, seen)
 }
@@ -0,0 +1,177 @@
+package l1connection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+
+	"github.com/nnikolash/wasp-types-exported/packages/parameters"
+	"github.com/nnikolash/wasp-types-exported/packages/util"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/models"
+)
+
+var _ Client = &devClient{}
+
+// devClient uses the in-process L1 ledger of a Wasp node running in the development mode
+// (nodeconn.utxodb.enabled), through the webapi of the node.
+type devClient struct {
+	apiAddress string
+	log        *logger.Logger
+}
+
+// NewDevClient creates a client for the in-process L1 ledger of a Wasp node.
+// The apiAddress is the address of the webapi of the node, e.g. http://localhost:9090.
+func NewDevClient(apiAddress string, log *logger.Logger, timeout ...time.Duration) Client {
+	c := &devClient{
+		apiAddress: strings.TrimSuffix(apiAddress, "/"),
+		log:        log.Named("nc"),
+	}
+
+	var l1Params models.L1Params
+	if err := c.do(http.MethodGet, "/v1/l1/info", nil, &l1Params, timeout...); err != nil {
+		panic(fmt.Errorf("error getting L1 connection info: %w", err))
+	}
+	tokenSupply, err := iotago.DecodeUint64(l1Params.Protocol.TokenSupply)
+	if err != nil {
+		panic(fmt.Errorf("error getting L1 connection info: %w", err))
+	}
+	parameters.InitL1(&parameters.L1Params{
+		MaxPayloadSize: l1Params.MaxPayloadSize,
+		Protocol: &iotago.ProtocolParameters{
+			Version:       l1Params.Protocol.Version,
+			NetworkName:   l1Params.Protocol.NetworkName,
+			Bech32HRP:     l1Params.Protocol.Bech32HRP,
+			MinPoWScore:   l1Params.Protocol.MinPoWScore,
+			BelowMaxDepth: l1Params.Protocol.BelowMaxDepth,
+			RentStructure: iotago.RentStructure{
+				VByteCost:    l1Params.Protocol.RentStructure.VByteCost,
+				VBFactorData: l1Params.Protocol.RentStructure.VBFactorData,
+				VBFactorKey:  l1Params.Protocol.RentStructure.VBFactorKey,
+			},
+			TokenSupply: tokenSupply,
+		},
+		BaseToken: &l1Params.BaseToken,
+	})
+
+	return c
+}
+
+func (c *devClient) do(method, path string, body, result any, timeout ...time.Duration) error {
+	ctxWithTimeout, cancelContext := newCtx(context.Background(), timeout...)
+	defer cancelContext()
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	httpReq, err := http.NewRequestWithContext(ctxWithTimeout, method, c.apiAddress+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("unable to call %s: %w", path, err)
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("status=%v, unable to read response body: %w", res.Status, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("call to %s failed, response status=%v, body=%v", path, res.Status, string(resBody))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resBody, result)
+}
+
+func (c *devClient) RequestFunds(addr iotago.Address, timeout ...time.Duration) error {
+	return c.do(http.MethodPost, "/v1/l1/faucet/"+addr.Bech32(parameters.L1().Protocol.Bech32HRP), nil, nil, timeout...)
+}
+
+// PostTxAndWaitUntilConfirmation adds the tx to the ledger, which is immediately visible to the clients.
+// There are no blocks in the ledger, so the returned block ID is empty.
+func (c *devClient) PostTxAndWaitUntilConfirmation(tx *iotago.Transaction, timeout ...time.Duration) (iotago.BlockID, error) {
+	txBytes, err := tx.Serialize(serializer.DeSeriModePerformValidation, parameters.L1().Protocol)
+	if err != nil {
+		return iotago.EmptyBlockID(), err
+	}
+	request := &models.PostTransactionRequest{Transaction: iotago.EncodeHex(txBytes)}
+	return iotago.EmptyBlockID(), c.do(http.MethodPost, "/v1/l1/transactions", request, nil, timeout...)
+}
+
+func (c *devClient) OutputMap(myAddress iotago.Address, timeout ...time.Duration) (iotago.OutputSet, error) {
+	var outputs []*models.InOutput
+	if err := c.do(http.MethodGet, "/v1/l1/outputs/"+myAddress.Bech32(parameters.L1().Protocol.Bech32HRP), nil, &outputs, timeout...); err != nil {
+		return nil, err
+	}
+	result := make(iotago.OutputSet, len(outputs))
+	for _, o := range outputs {
+		outputID, output, err := outputFromModel(o)
+		if err != nil {
+			return nil, err
+		}
+		result[outputID] = output
+	}
+	return result, nil
+}
+
+func (c *devClient) OutputMapNonLocked(myAddress iotago.Address, timeout ...time.Duration) (iotago.OutputSet, error) {
+	outputs, err := c.OutputMap(myAddress, timeout...)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	for outputID, output := range outputs {
+		if timelock := output.UnlockConditionSet().Timelock(); timelock != nil && int64(timelock.UnixTime) > now {
+			delete(outputs, outputID)
+		}
+	}
+	return outputs, nil
+}
+
+func (c *devClient) GetAliasOutput(aliasID iotago.AliasID, timeout ...time.Duration) (iotago.OutputID, iotago.Output, error) {
+	var output models.InOutput
+	if err := c.do(http.MethodGet, "/v1/l1/aliases/"+aliasID.ToHex(), nil, &output, timeout...); err != nil {
+		return iotago.OutputID{}, nil, err
+	}
+	return outputFromModel(&output)
+}
+
+func (c *devClient) Health(timeout ...time.Duration) (bool, error) {
+	if err := c.do(http.MethodGet, "/v1/l1/info", nil, nil, timeout...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func outputFromModel(o *models.InOutput) (iotago.OutputID, iotago.Output, error) {
+	outputID, err := iotago.OutputIDFromHex(o.OutputID)
+	if err != nil {
+		return iotago.OutputID{}, nil, err
+	}
+	outputBytes, err := iotago.DecodeHex(o.Output.Raw)
+	if err != nil {
+		return iotago.OutputID{}, nil, err
+	}
+	output, err := util.OutputFromBytes(outputBytes)
+	if err != nil {
+		return iotago.OutputID{}, nil, err
+	}
+	return outputID, output, nil
+}
@@ -0,0 +1,355 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// utxodbconn package provides a stand-in for the L1 node (Hornet), backed by an
+// in-process utxodb ledger. It allows running a single Wasp node for development,
+// without any L1 infrastructure:
+//   - Milestones are issued on a timer, following the wall clock.
+//   - Transactions are added to the ledger immediately and confirmed on the next milestone.
+//   - A built-in faucet hands out base tokens.
+//
+// The ledger is kept in memory and, optionally, saved to a file on each milestone.
+package utxodbconn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/chain"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/parameters"
+	"github.com/nnikolash/wasp-types-exported/packages/testutil/utxodb"
+)
+
+type Config struct {
+	// MilestoneInterval is the interval at which the milestones are issued.
+	MilestoneInterval time.Duration
+	// FaucetAmount is the amount of base tokens returned by the faucet. If 0, the utxodb default is used.
+	FaucetAmount uint64
+	// DatabasePath is the file the ledger is saved to. If empty, the ledger is not persisted.
+	DatabasePath string
+}
+
+// NodeConnection implements chain.NodeConnection on top of utxodb.
+type NodeConnection struct {
+	log    *logger.Logger
+	config Config
+	ledger *utxodb.UtxoDB
+
+	synced chan struct{}
+
+	mutex      sync.Mutex
+	chains     map[isc.ChainID]*ncChain
+	pendingTxs []*pendingTx
+	dirty      bool
+}
+
+type ncChain struct {
+	chainID              isc.ChainID
+	requestOutputHandler chain.RequestOutputHandler
+	aliasOutputHandler   chain.AliasOutputHandler
+	milestoneHandler     chain.MilestoneHandler
+	lastAliasOutputID    iotago.OutputID
+	// the owned outputs already passed to the chain
+	sentOutputs map[iotago.OutputID]struct{}
+}
+
+type pendingTx struct {
+	ctx      context.Context
+	tx       *iotago.Transaction
+	callback chain.TxPostHandler
+}
+
+var _ chain.NodeConnection = &NodeConnection{}
+
+// New creates the node connection and its ledger. The L1 parameters of the ledger are
+// the ones used for testing, they are set as the global L1 parameters of the node.
+func New(log *logger.Logger, config Config) (*NodeConnection, error) {
+	if config.MilestoneInterval <= 0 {
+		return nil, fmt.Errorf("invalid milestone interval: %s", config.MilestoneInterval)
+	}
+	parameters.InitL1(parameters.L1ForTesting)
+
+	ledger := utxodb.New(utxodb.DefaultInitParams().WithInitialTime(time.Now()))
+	if config.DatabasePath != "" {
+		state, err := loadLedgerState(config.DatabasePath)
+		if err != nil {
+			return nil, err
+		}
+		if state != nil {
+			ledger.SetState(state)
+			log.Infof("loaded the L1 ledger from %s", config.DatabasePath)
+		}
+	}
+
+	return &NodeConnection{
+		log:    log,
+		config: config,
+		ledger: ledger,
+		synced: make(chan struct{}),
+		chains: make(map[isc.ChainID]*ncChain),
+	}, nil
+}
+
+func loadLedgerState(path string) (*utxodb.UtxoDBState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the L1 ledger: %w", err)
+	}
+	var state utxodb.UtxoDBState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse the L1 ledger from %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func (nc *NodeConnection) saveLedgerState() error {
+	data, err := json.Marshal(nc.ledger.State())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(nc.config.DatabasePath), 0o700); err != nil {
+		return err
+	}
+	// write to a temporary file first, so that the ledger is not lost if the node is killed midway
+	tmpPath := nc.config.DatabasePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, nc.config.DatabasePath)
+}
+
+// Run issues the milestones until the context is canceled.
+func (nc *NodeConnection) Run(ctx context.Context) error {
+	ticker := time.NewTicker(nc.config.MilestoneInterval)
+	defer ticker.Stop()
+
+	nc.issueMilestone()
+	close(nc.synced)
+	for {
+		select {
+		case <-ctx.Done():
+			nc.mutex.Lock()
+			defer nc.mutex.Unlock()
+			if nc.dirty && nc.config.DatabasePath != "" {
+				return nc.saveLedgerState()
+			}
+			return nil
+		case <-ticker.C:
+			nc.issueMilestone()
+		}
+	}
+}
+
+func (nc *NodeConnection) WaitUntilInitiallySynced(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-nc.synced:
+		return nil
+	}
+}
+
+func (nc *NodeConnection) GetBech32HRP() iotago.NetworkPrefix {
+	return nc.GetL1ProtocolParams().Bech32HRP
+}
+
+func (nc *NodeConnection) GetL1Params() *parameters.L1Params {
+	return parameters.L1ForTesting
+}
+
+func (nc *NodeConnection) GetL1ProtocolParams() *iotago.ProtocolParameters {
+	return nc.GetL1Params().Protocol
+}
+
+// issueMilestone advances the ledger time to the wall clock, passes the ledger changes
+// to the attached chains and confirms the transactions published since the previous milestone.
+func (nc *NodeConnection) issueMilestone() {
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+
+	// the ledger time can be ahead of the wall clock, because each transaction advances it
+	if step := time.Since(nc.ledger.GlobalTime()); step > 0 {
+		nc.ledger.AdvanceClockBy(step)
+	}
+	timestamp := nc.ledger.GlobalTime()
+
+	for _, ncc := range nc.chains {
+		ncc.milestoneHandler(timestamp)
+		nc.sendLedgerUpdates(ncc)
+	}
+
+	for _, ptx := range nc.pendingTxs {
+		if ptx.ctx.Err() == nil {
+			go ptx.callback(ptx.tx, true)
+		}
+	}
+	nc.pendingTxs = nil
+
+	if nc.dirty && nc.config.DatabasePath != "" {
+		if err := nc.saveLedgerState(); err != nil {
+			nc.log.Errorf("failed to save the L1 ledger: %s", err.Error())
+			return
+		}
+		nc.dirty = false
+	}
+}
+
+// sendLedgerUpdates passes the latest alias output of the chain, if it has changed,
+// followed by the owned outputs not yet passed to the chain.
+// The intermediate alias outputs of the chain, created between two milestones, are skipped.
+func (nc *NodeConnection) sendLedgerUpdates(ncc *ncChain) {
+	outputs, _ := nc.ledger.GetUnspentOutputs(ncc.chainID.AsAddress())
+
+	outputIDs := make(iotago.OutputIDs, 0, len(outputs))
+	for outputID, output := range outputs {
+		if output.Type() == iotago.OutputAlias {
+			if outputID != ncc.lastAliasOutputID {
+				ncc.lastAliasOutputID = outputID
+				ncc.aliasOutputHandler(isc.NewOutputInfo(outputID, output, iotago.TransactionID{}))
+			}
+			continue
+		}
+		outputIDs = append(outputIDs, outputID)
+	}
+
+	sort.Slice(outputIDs, func(i, j int) bool {
+		return outputIDs[i].ToHex() < outputIDs[j].ToHex()
+	})
+	sentOutputs := make(map[iotago.OutputID]struct{}, len(outputIDs))
+	for _, outputID := range outputIDs {
+		sentOutputs[outputID] = struct{}{}
+		if _, ok := ncc.sentOutputs[outputID]; ok {
+			continue
+		}
+		ncc.requestOutputHandler(isc.NewOutputInfo(outputID, outputs[outputID], iotago.TransactionID{}))
+	}
+	// the consumed outputs are forgotten
+	ncc.sentOutputs = sentOutputs
+}
+
+func (nc *NodeConnection) PublishTX(
+	ctx context.Context,
+	chainID isc.ChainID,
+	tx *iotago.Transaction,
+	callback chain.TxPostHandler,
+) error {
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+
+	if err := nc.ledger.AddToLedger(tx); err != nil {
+		nc.log.Warnf("transaction of chain %s rejected: %s", chainID, err.Error())
+		go callback(tx, false)
+		return nil
+	}
+	nc.dirty = true
+	nc.pendingTxs = append(nc.pendingTxs, &pendingTx{ctx: ctx, tx: tx, callback: callback})
+	return nil
+}
+
+func (nc *NodeConnection) AttachChain(
+	ctx context.Context,
+	chainID isc.ChainID,
+	recvRequestCB chain.RequestOutputHandler,
+	recvAliasOutput chain.AliasOutputHandler,
+	recvMilestone chain.MilestoneHandler,
+	onChainConnect func(),
+	onChainDisconnect func(),
+) {
+	ncc := &ncChain{
+		chainID:              chainID,
+		requestOutputHandler: recvRequestCB,
+		aliasOutputHandler:   recvAliasOutput,
+		milestoneHandler:     recvMilestone,
+		sentOutputs:          make(map[iotago.OutputID]struct{}),
+	}
+
+	nc.mutex.Lock()
+	nc.chains[chainID] = ncc
+	ncc.milestoneHandler(nc.ledger.GlobalTime())
+	nc.sendLedgerUpdates(ncc)
+	nc.mutex.Unlock()
+
+	nc.log.Infof("chain %s attached", chainID)
+	onChainConnect()
+
+	go func() {
+		<-ctx.Done()
+		nc.mutex.Lock()
+		delete(nc.chains, chainID)
+		nc.mutex.Unlock()
+
+		nc.log.Infof("chain %s detached", chainID)
+		onChainDisconnect()
+	}()
+}
+
+func (nc *NodeConnection) RefreshOnLedgerRequests(ctx context.Context, chainID isc.ChainID) {
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+
+	ncc, ok := nc.chains[chainID]
+	if !ok {
+		return
+	}
+	ncc.sentOutputs = make(map[iotago.OutputID]struct{})
+	nc.sendLedgerUpdates(ncc)
+}
+
+// RequestFunds sends the configured amount of base tokens from the faucet to the address.
+func (nc *NodeConnection) RequestFunds(addr iotago.Address) (*iotago.Transaction, error) {
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+
+	var amount []uint64
+	if nc.config.FaucetAmount > 0 {
+		amount = append(amount, nc.config.FaucetAmount)
+	}
+	tx, err := nc.ledger.GetFundsFromFaucet(addr, amount...)
+	if err != nil {
+		return nil, err
+	}
+	nc.dirty = true
+	return tx, nil
+}
+
+// PostTx adds the transaction to the ledger. It is confirmed on the next milestone.
+func (nc *NodeConnection) PostTx(tx *iotago.Transaction) error {
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+
+	if err := nc.ledger.AddToLedger(tx); err != nil {
+		return err
+	}
+	nc.dirty = true
+	return nil
+}
+
+// OutputMap returns the unspent outputs owned by the address.
+func (nc *NodeConnection) OutputMap(addr iotago.Address) iotago.OutputSet {
+	outputs, _ := nc.ledger.GetUnspentOutputs(addr)
+	return outputs
+}
+
+// GetAliasOutput returns the unspent alias output with the given alias ID.
+func (nc *NodeConnection) GetAliasOutput(aliasID iotago.AliasID) (iotago.OutputID, *iotago.AliasOutput, error) {
+	outputs, _ := nc.ledger.GetUnspentOutputs(aliasID.ToAddress())
+	for outputID, output := range outputs {
+		if aliasOutput, ok := output.(*iotago.AliasOutput); ok {
+			return outputID, aliasOutput, nil
+		}
+	}
+	return iotago.OutputID{}, nil, fmt.Errorf("alias output %s not found", aliasID.ToHex())
+}
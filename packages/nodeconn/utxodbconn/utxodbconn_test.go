@@ -0,0 +1,108 @@
+package utxodbconn_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/nodeconn/utxodbconn"
+	"github.com/nnikolash/wasp-types-exported/packages/origin"
+	"github.com/nnikolash/wasp-types-exported/packages/testutil/testlogger"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations/allmigrations"
+)
+
+func TestNodeConnection(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	config := utxodbconn.Config{
+		MilestoneInterval: 10 * time.Millisecond,
+		FaucetAmount:      10 * isc.Million,
+		DatabasePath:      filepath.Join(t.TempDir(), "utxodb.json"),
+	}
+	nc, err := utxodbconn.New(log, config)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- nc.Run(ctx) }()
+	require.NoError(t, nc.WaitUntilInitiallySynced(ctx))
+
+	// deploy the chain with funds from the faucet
+	originator := cryptolib.NewKeyPair()
+	_, err = nc.RequestFunds(originator.Address())
+	require.NoError(t, err)
+	outputs := nc.OutputMap(originator.Address())
+	require.Len(t, outputs, 1)
+	for _, output := range outputs {
+		require.EqualValues(t, config.FaucetAmount, output.Deposit())
+	}
+	outputIDs := make(iotago.OutputIDs, 0, len(outputs))
+	for outputID := range outputs {
+		outputIDs = append(outputIDs, outputID)
+	}
+	originTx, _, chainID, err := origin.NewChainOriginTransaction(
+		originator,
+		originator.Address(),
+		originator.Address(),
+		0,
+		nil,
+		outputs,
+		outputIDs,
+		allmigrations.DefaultScheme.LatestSchemaVersion(),
+	)
+	require.NoError(t, err)
+	confirmed := make(chan bool, 1)
+	require.NoError(t, nc.PublishTX(ctx, chainID, originTx, func(_ *iotago.Transaction, ok bool) { confirmed <- ok }))
+	require.True(t, <-confirmed)
+
+	// the alias output is passed on attach, the requests and the milestones afterwards
+	aliasOutputs := make(chan *isc.OutputInfo, 10)
+	requests := make(chan *isc.OutputInfo, 10)
+	milestones := make(chan time.Time, 100)
+	chainCtx, chainCancel := context.WithCancel(ctx)
+	disconnected := make(chan struct{})
+	nc.AttachChain(
+		chainCtx,
+		chainID,
+		func(outputInfo *isc.OutputInfo) { requests <- outputInfo },
+		func(outputInfo *isc.OutputInfo) { aliasOutputs <- outputInfo },
+		func(timestamp time.Time) {
+			select {
+			case milestones <- timestamp:
+			default:
+			}
+		},
+		func() {},
+		func() { close(disconnected) },
+	)
+	aliasOutput := <-aliasOutputs
+	require.Equal(t, chainID.AsAliasID(), aliasOutput.AliasOutputWithID().GetAliasID())
+	<-milestones
+
+	_, err = nc.RequestFunds(chainID.AsAddress())
+	require.NoError(t, err)
+	request := <-requests
+	require.EqualValues(t, config.FaucetAmount, request.Output.Deposit())
+	require.Len(t, requests, 0)
+
+	// the outputs are passed again on refresh
+	nc.RefreshOnLedgerRequests(ctx, chainID)
+	require.Equal(t, request.OutputID, (<-requests).OutputID)
+
+	chainCancel()
+	<-disconnected
+	cancel()
+	require.NoError(t, <-runErr)
+
+	// the ledger is loaded from the database
+	nc2, err := utxodbconn.New(log, config)
+	require.NoError(t, err)
+	outputID, _, err := nc2.GetAliasOutput(chainID.AsAliasID())
+	require.NoError(t, err)
+	require.Equal(t, aliasOutput.OutputID, outputID)
+}
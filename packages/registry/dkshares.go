@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/hive.go/runtime/ioutils"
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
+	"github.com/nnikolash/wasp-types-exported/packages/keystore"
 	"github.com/nnikolash/wasp-types-exported/packages/onchangemap"
 	"github.com/nnikolash/wasp-types-exported/packages/tcrypto"
 	"github.com/nnikolash/wasp-types-exported/packages/util"
@@ -23,12 +24,14 @@ type DKSharesRegistry struct {
 
 	folderPath    string
 	networkPrefix iotago.NetworkPrefix
+	keyStore      keystore.KeyStore // Encrypts the DKShare files, if not nil.
 }
 
 var _ DKShareRegistryProvider = &DKSharesRegistry{}
 
 // NewDKSharesRegistry creates new instance of the DKShare registry implementation.
-func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, networkPrefix iotago.NetworkPrefix) (*DKSharesRegistry, error) {
+// The DKShare files are encrypted by the key store, if it is not nil.
+func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, networkPrefix iotago.NetworkPrefix, keyStore keystore.KeyStore) (*DKSharesRegistry, error) {
 	// create the target directory during initialization
 	if err := ioutils.CreateDirectory(folderPath, 0o770); err != nil {
 		return nil, err
@@ -37,6 +40,7 @@ func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, n
 	registry := &DKSharesRegistry{
 		folderPath:    folderPath,
 		networkPrefix: networkPrefix,
+		keyStore:      keyStore,
 	}
 
 	registry.onChangeMap = onchangemap.NewOnChangeMap(
@@ -46,19 +50,28 @@ func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, n
 	)
 
 	// load DKShares on startup
-	if err := registry.loadDKSharesJSONFromFolder(nodePrivKey); err != nil {
+	unencrypted, err := registry.loadDKSharesJSONFromFolder(nodePrivKey)
+	if err != nil {
 		return nil, fmt.Errorf("unable to read DKShares configuration (%s): %w", folderPath, err)
 	}
 
+	// encrypt the DKShares stored before the key store was configured
+	for _, dkShare := range unencrypted {
+		if err := registry.writeDKShareJSONToFolder(dkShare); err != nil {
+			return nil, fmt.Errorf("unable to encrypt DKShare: %w", err)
+		}
+	}
+
 	registry.onChangeMap.CallbacksEnabled(true)
 
 	return registry, nil
 }
 
-func (p *DKSharesRegistry) loadDKSharesJSONFromFolder(nodePrivKey *cryptolib.PrivateKey) error {
+// loadDKSharesJSONFromFolder returns the DKShares, that have to be encrypted.
+func (p *DKSharesRegistry) loadDKSharesJSONFromFolder(nodePrivKey *cryptolib.PrivateKey) ([]tcrypto.DKShare, error) {
 	if p.folderPath == "" {
 		// do not load entries if no path is given
-		return nil
+		return nil, nil
 	}
 
 	// regex example: atoi1qqqrqtn44e0563utwau9aaygt824qznjkhvr6836eratglg3cp2n6ydplqx.json
@@ -68,45 +81,52 @@ func (p *DKSharesRegistry) loadDKSharesJSONFromFolder(nodePrivKey *cryptolib.Pri
 	if err != nil {
 		if os.IsNotExist(err) {
 			// if the folder doesn't exist, there are no entries yet.
-			return nil
+			return nil, nil
 		}
-		return fmt.Errorf("unable to read dkShares directory (%s), error: %w", p.folderPath, err)
+		return nil, fmt.Errorf("unable to read dkShares directory (%s), error: %w", p.folderPath, err)
 	}
 
+	unencrypted := []tcrypto.DKShare{}
+
 	// loop over all matching files
 	for _, file := range files {
 		if file.IsDir() {
 			// ignore folders
-			return nil
+			return unencrypted, nil
 		}
 
 		if !filesRegex.MatchString(file.Name()) {
 			// ignore unknown files
-			return nil
+			return unencrypted, nil
 		}
 
 		sharedAddressBech32 := filesRegex.FindStringSubmatch(file.Name())[1]
 		_, sharedAddress, err := iotago.ParseBech32(sharedAddressBech32)
 		if err != nil {
-			return fmt.Errorf("unable to parse shared bech32 address (%s), error: %w", sharedAddressBech32, err)
+			return nil, fmt.Errorf("unable to parse shared bech32 address (%s), error: %w", sharedAddressBech32, err)
 		}
 
 		dkShareFilePath := path.Join(p.folderPath, file.Name())
 		dkShare := tcrypto.NewEmptyDKShare(nodePrivKey, tcrypto.DefaultEd25519Suite(), tcrypto.DefaultBLSSuite())
-		if err := ioutils.ReadJSONFromFile(dkShareFilePath, dkShare); err != nil && !os.IsNotExist(err) {
-			return fmt.Errorf("unable to unmarshal json file (%s): %w", dkShareFilePath, err)
+		encrypted, err := keystore.ReadJSONFromFile(p.keyStore, dkShareFilePath, dkShare)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("unable to unmarshal json file (%s): %w", dkShareFilePath, err)
 		}
 
 		if !dkShare.GetAddress().Equal(sharedAddress) {
-			return errors.New("unable to add DKShare to registry: sharedAddress in the file not equal to sharedAddress in folder name")
+			return nil, errors.New("unable to add DKShare to registry: sharedAddress in the file not equal to sharedAddress in folder name")
 		}
 
 		if err := p.SaveDKShare(dkShare); err != nil {
-			return fmt.Errorf("unable to add DKShare to registry: %w", err)
+			return nil, fmt.Errorf("unable to add DKShare to registry: %w", err)
+		}
+
+		if !encrypted && p.keyStore != nil {
+			unencrypted = append(unencrypted, dkShare)
 		}
 	}
 
-	return nil
+	return unencrypted, nil
 }
 
 func (p *DKSharesRegistry) getDKShareFilePath(dkShare tcrypto.DKShare) string {
@@ -126,7 +146,7 @@ func (p *DKSharesRegistry) writeDKShareJSONToFolder(dkShare tcrypto.DKShare) err
 		return err
 	}
 
-	if err := ioutils.WriteJSONToFile(filePath, dkShare, 0o600); err != nil {
+	if err := keystore.WriteJSONToFile(p.keyStore, filePath, dkShare, 0o600); err != nil {
 		return fmt.Errorf("unable to marshal json file: %w", err)
 	}
 
@@ -171,3 +191,10 @@ func (p *DKSharesRegistry) LoadDKShare(sharedAddress iotago.Address) (tcrypto.DK
 	}
 	return dkShare, nil
 }
+
+func (p *DKSharesRegistry) DeleteDKShare(sharedAddress iotago.Address) error {
+	if _, err := p.onChangeMap.Get(util.NewComparableAddress(sharedAddress)); err != nil {
+		return tcrypto.ErrDKShareNotFound
+	}
+	return p.onChangeMap.Delete(util.NewComparableAddress(sharedAddress))
+}
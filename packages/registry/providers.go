@@ -21,6 +21,7 @@ type NodeIdentityProvider interface {
 type DKShareRegistryProvider interface {
 	SaveDKShare(dkShare tcrypto.DKShare) error
 	LoadDKShare(sharedAddress iotago.Address) (tcrypto.DKShare, error)
+	DeleteDKShare(sharedAddress iotago.Address) error
 }
 
 type ChainRecordRegistryProvider interface {
@@ -138,6 +138,30 @@ func (ch *Chain) SetGasFeePolicy(user *cryptolib.KeyPair, fp *gas.FeePolicy) {
 	require.NoError(ch.Env.T, err)
 }
 
+// GetContractFees returns the fee record of the contract, zero fees mean the chain fee policy is in effect
+func (ch *Chain) GetContractFees(scName string) *governance.ContractFeesRecord {
+	res, err := ch.CallView(governance.Contract.Name, governance.ViewGetContractFees.Name,
+		governance.ParamContractHname, isc.Hn(scName),
+	)
+	require.NoError(ch.Env.T, err)
+	return &governance.ContractFeesRecord{
+		OwnerFee:     codec.MustDecodeUint64(res.Get(governance.ParamOwnerFee)),
+		ValidatorFee: codec.MustDecodeUint64(res.Get(governance.ParamValidatorFee)),
+	}
+}
+
+// SetContractFees overrides the chain fee policy for the requests to the contract
+func (ch *Chain) SetContractFees(user *cryptolib.KeyPair, scName string, rec *governance.ContractFeesRecord) error {
+	_, err := ch.PostRequestOffLedger(NewCallParams(
+		governance.Contract.Name,
+		governance.FuncSetContractFees.Name,
+		governance.ParamContractHname, isc.Hn(scName),
+		governance.ParamOwnerFee, rec.OwnerFee,
+		governance.ParamValidatorFee, rec.ValidatorFee,
+	), user)
+	return err
+}
+
 func (ch *Chain) GetGasLimits() *gas.Limits {
 	res, err := ch.CallView(governance.Contract.Name, governance.ViewGetGasLimits.Name)
 	require.NoError(ch.Env.T, err)
@@ -286,6 +310,29 @@ func (ch *Chain) DeployWasmContract(keyPair *cryptolib.KeyPair, name, fname stri
 	return ch.DeployContract(keyPair, name, hprog, params...)
 }
 
+// UpgradeContract replaces the program of the deployed contract, keeping its state
+func (ch *Chain) UpgradeContract(user *cryptolib.KeyPair, name string, programHash hashing.HashValue) error {
+	_, err := ch.PostRequestSync(
+		NewCallParams(root.Contract.Name, root.FuncUpgradeContract.Name,
+			root.ParamHname, isc.Hn(name),
+			root.ParamProgramHash, programHash,
+		).WithGasBudget(math.MaxUint64),
+		user,
+	)
+	return err
+}
+
+// RetireContract disables the deployed contract, further calls to it are rejected
+func (ch *Chain) RetireContract(user *cryptolib.KeyPair, name string) error {
+	_, err := ch.PostRequestSync(
+		NewCallParams(root.Contract.Name, root.FuncRetireContract.Name,
+			root.ParamHname, isc.Hn(name),
+		).WithGasBudget(math.MaxUint64),
+		user,
+	)
+	return err
+}
+
 func EVMCallDataFromArtifacts(t require.TestingT, abiJSON string, bytecode []byte, args ...interface{}) (abi.ABI, []byte) {
 	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
 	require.NoError(t, err)
@@ -345,6 +392,16 @@ func (ch *Chain) GetInfo() (isc.ChainID, isc.AgentID, map[isc.Hname]*root.Contra
 	return ch.ChainID, chainOwnerID, contracts
 }
 
+// GetRetiredContracts returns the hnames of the retired contracts
+func (ch *Chain) GetRetiredContracts() map[isc.Hname]bool {
+	res, err := ch.CallView(root.Contract.Name, root.ViewGetContractRecords.Name)
+	require.NoError(ch.Env.T, err)
+
+	retired, err := root.DecodeRetiredContracts(collections.NewMapReadOnly(res, root.VarRetiredContracts))
+	require.NoError(ch.Env.T, err)
+	return retired
+}
+
 // GetEventsForContract calls the view in the 'blocklog' core smart contract to retrieve events for a given smart contract.
 func (ch *Chain) GetEventsForContract(name string) ([]*isc.Event, error) {
 	ret := blocklog.NewStateAccess(lo.Must(ch.Store().LatestState())).
@@ -520,6 +577,19 @@ func (ch *Chain) GetRequestReceiptsForBlockRangeAsStrings(fromBlockIndex, toBloc
 	return ret
 }
 
+// GetScheduledRequest returns the call scheduled in the blocklog contract, nil if it does not exist
+func (ch *Chain) GetScheduledRequest(scheduleID uint32) *blocklog.ScheduledRecord {
+	res, err := ch.CallView(blocklog.Contract.Name, blocklog.ViewGetScheduledRequest.Name,
+		blocklog.ParamScheduleID, scheduleID)
+	require.NoError(ch.Env.T, err)
+	if !res.Has(blocklog.ParamScheduledRequest) {
+		return nil
+	}
+	rec, err := blocklog.ScheduledRecordFromBytes(res.Get(blocklog.ParamScheduledRequest))
+	require.NoError(ch.Env.T, err)
+	return rec
+}
+
 func (ch *Chain) GetControlAddresses() *isc.ControlAddresses {
 	aliasOutputID, err := ch.LatestAliasOutput(chain.ConfirmedState)
 	if err != nil {
@@ -749,3 +819,17 @@ func (ch *Chain) LatestBlockIndex() uint32 {
 func (ch *Chain) GetMempoolContents() io.Reader {
 	panic("unimplemented")
 }
+
+// GetMempoolOffLedgerRequests implements chain.Chain
+func (ch *Chain) GetMempoolOffLedgerRequests() []isc.OffLedgerRequest {
+	return ch.mempool.OffLedgerRequests()
+}
+
+// GetSyncStatus implements chain.Chain
+func (ch *Chain) GetSyncStatus() *chain.SyncStatus {
+	latestBlockIndex := ch.LatestBlockIndex()
+	return &chain.SyncStatus{
+		CurrentBlockIndex: latestBlockIndex,
+		HighestBlockIndex: latestBlockIndex,
+	}
+}
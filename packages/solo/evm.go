@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -13,15 +14,24 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	hivedb "github.com/iotaledger/hive.go/db"
+	"github.com/iotaledger/hive.go/logger"
 	"github.com/nnikolash/wasp-types-exported/packages/chain"
 	"github.com/nnikolash/wasp-types-exported/packages/chainutil"
 	"github.com/nnikolash/wasp-types-exported/packages/evm/jsonrpc"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
 	"github.com/nnikolash/wasp-types-exported/packages/parameters"
 	"github.com/nnikolash/wasp-types-exported/packages/state"
 	"github.com/nnikolash/wasp-types-exported/packages/trie"
+	"github.com/nnikolash/wasp-types-exported/packages/util"
+	"github.com/nnikolash/wasp-types-exported/packages/util/panicutil"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/accounts"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/evm"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/evm/emulator"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/root"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/gas"
 )
 
@@ -97,6 +107,14 @@ func (b *jsonRPCSoloBackend) ISCLatestState() (state.State, error) {
 	return b.Chain.LatestState(chain.ActiveOrCommittedState)
 }
 
+func (b *jsonRPCSoloBackend) ISCMempoolOffLedgerRequests() []isc.OffLedgerRequest {
+	return b.Chain.GetMempoolOffLedgerRequests()
+}
+
+func (b *jsonRPCSoloBackend) ISCSyncStatus() *chain.SyncStatus {
+	return b.Chain.GetSyncStatus()
+}
+
 func (b *jsonRPCSoloBackend) ISCStateByBlockIndex(blockIndex uint32) (state.State, error) {
 	return b.Chain.store.StateByIndex(blockIndex)
 }
@@ -127,8 +145,108 @@ func (b *jsonRPCSoloBackend) TakeSnapshot() (int, error) {
 	return len(b.snapshots) - 1, nil
 }
 
+var _ jsonrpc.DevChainBackend = &jsonRPCSoloBackend{}
+
+func (b *jsonRPCSoloBackend) DevMine() error {
+	_, err := b.Chain.PostRequestSync(
+		NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).
+			AddBaseTokens(TransferAllowanceToGasBudgetBaseTokens).
+			WithMaxAffordableGasBudget(),
+		nil,
+	)
+	return err
+}
+
+func (b *jsonRPCSoloBackend) DevTime() time.Time {
+	return b.Chain.Env.GlobalTime()
+}
+
+func (b *jsonRPCSoloBackend) DevAdvanceClock(step time.Duration) error {
+	b.Chain.Env.AdvanceClockBy(step)
+	return nil
+}
+
+// DevBalanceSink is the EVM address receiving the base tokens removed by DevSetBalance
+var DevBalanceSink = common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+func (b *jsonRPCSoloBackend) DevSetBalance(addr common.Address, balance *big.Int) error {
+	agentID := isc.NewEthereumAddressAgentID(b.Chain.ChainID, addr)
+	target, _ := util.EthereumDecimalsToBaseTokenDecimals(balance, b.baseToken.Decimals)
+	current := b.Chain.L2BaseTokens(agentID)
+	if target == current {
+		return nil
+	}
+	if target < current {
+		// the base tokens can't be destroyed, they are moved to the sink account
+		return b.debitToSink(agentID, current-target)
+	}
+	wallet, walletAddr := b.Chain.Env.NewKeyPair()
+	if err := panicutil.CatchPanic(func() {
+		_, err := b.Chain.Env.GetFundsFromFaucet(walletAddr, target-current+TransferAllowanceToGasBudgetBaseTokens)
+		if err != nil {
+			panic(err)
+		}
+	}); err != nil {
+		return fmt.Errorf("cannot get funds from the faucet: %w", err)
+	}
+	return b.Chain.TransferAllowanceTo(isc.NewAssetsBaseTokens(target-current), agentID, wallet)
+}
+
+// debitToSink moves the base tokens from the account to DevBalanceSink, and produces a block to apply the change
+func (b *jsonRPCSoloBackend) debitToSink(agentID isc.AgentID, amount uint64) error {
+	latestState, err := b.ISCLatestState()
+	if err != nil {
+		return err
+	}
+	schemaVersion := root.NewStateAccess(latestState).SchemaVersion()
+	sink := isc.NewEthereumAddressAgentID(b.Chain.ChainID, DevBalanceSink)
+	b.Chain.AddStatePatch(migrations.Migration{
+		Contract: accounts.Contract,
+		Apply: func(contractState kv.KVStore, _ *logger.Logger) error {
+			return accounts.MoveBetweenAccounts(schemaVersion, contractState, agentID, sink, isc.NewAssetsBaseTokens(amount), b.Chain.ChainID)
+		},
+	})
+	return b.DevMine()
+}
+
+func (b *jsonRPCSoloBackend) DevSetCode(addr common.Address, code []byte) error {
+	return b.patchEVMState(func(stateDB kv.KVStore) {
+		emulator.SetCode(stateDB, addr, code)
+	})
+}
+
+func (b *jsonRPCSoloBackend) DevSetStorageAt(addr common.Address, key, value common.Hash) error {
+	return b.patchEVMState(func(stateDB kv.KVStore) {
+		emulator.SetState(stateDB, addr, key, value)
+	})
+}
+
+func (b *jsonRPCSoloBackend) DevSetNonce(addr common.Address, nonce uint64) error {
+	return b.patchEVMState(func(stateDB kv.KVStore) {
+		emulator.SetNonce(stateDB, addr, nonce)
+	})
+}
+
+// patchEVMState modifies the EVM state directly, and produces a block to apply the change
+func (b *jsonRPCSoloBackend) patchEVMState(f func(stateDB kv.KVStore)) error {
+	b.Chain.AddStatePatch(migrations.Migration{
+		Contract: evm.Contract,
+		Apply: func(contractState kv.KVStore, _ *logger.Logger) error {
+			f(emulator.StateDBSubrealm(evm.EmulatorStateSubrealm(contractState)))
+			return nil
+		},
+	})
+	return b.DevMine()
+}
+
+func (b *jsonRPCSoloBackend) DevSendImpersonatedTransaction(tx *types.Transaction, sender common.Address) error {
+	_, err := b.Chain.PostImpersonatedEthereumTransaction(tx, sender)
+	return err
+}
+
+// EVM returns a new EVMChain for the chain, which is closed when the test ends
 func (ch *Chain) EVM() *jsonrpc.EVMChain {
-	return jsonrpc.NewEVMChain(
+	evmChain := jsonrpc.NewEVMChain(
 		newJSONRPCSoloBackend(ch, parameters.L1().BaseToken),
 		ch.Env.publisher,
 		true,
@@ -136,6 +254,8 @@ func (ch *Chain) EVM() *jsonrpc.EVMChain {
 		"",
 		ch.log,
 	)
+	ch.Env.T.Cleanup(evmChain.Close)
+	return evmChain
 }
 
 func (ch *Chain) PostEthereumTransaction(tx *types.Transaction) (dict.Dict, error) {
@@ -146,6 +266,11 @@ func (ch *Chain) PostEthereumTransaction(tx *types.Transaction) (dict.Dict, erro
 	return ch.RunOffLedgerRequest(req)
 }
 
+// PostImpersonatedEthereumTransaction runs the unsigned EVM tx on behalf of the sender
+func (ch *Chain) PostImpersonatedEthereumTransaction(tx *types.Transaction, sender common.Address) (dict.Dict, error) {
+	return ch.RunOffLedgerRequest(isc.NewImpersonatedEVMOffLedgerTxRequest(ch.ChainID, tx, sender))
+}
+
 var EthereumAccounts [10]*ecdsa.PrivateKey
 
 func init() {
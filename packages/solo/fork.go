@@ -0,0 +1,105 @@
+package solo
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/chain/statemanager/sm_snapshots"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/subrealm"
+	"github.com/nnikolash/wasp-types-exported/packages/parameters"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+	"github.com/nnikolash/wasp-types-exported/packages/transaction"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/accounts"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/blocklog"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
+)
+
+// NewChainFromStateSnapshot deploys a chain with the state of an existing chain, read from a
+// full state snapshot (in the format of the snapshot files produced by a Wasp node).
+//
+// The chain keeps its chain ID and state, but it is controlled by new keys in the Solo
+// environment, so that requests can be run on it locally. The originator of the chain is a
+// new key pair, which is not the owner of the chain.
+// The native tokens, foundries and NFTs owned by the chain are not present in the L1 ledger
+// of Solo, so the requests moving them out of the chain will fail.
+func (env *Solo) NewChainFromStateSnapshot(name string, r io.Reader) *Chain {
+	env.logger.Debugf("deploying chain '%s' from the state snapshot", name)
+
+	// the chain ID is only known once the state is loaded
+	snapshotDB := mapdb.NewMapDB()
+	snapshotStore := state.NewStoreWithUniqueWriteMutex(snapshotDB)
+	snapshotInfo, err := sm_snapshots.ReadFullSnapshot(snapshotStore, r)
+	require.NoError(env.T, err)
+	require.NoError(env.T, snapshotStore.SetLatest(snapshotInfo.TrieRoot()))
+	chainState, err := snapshotStore.LatestState()
+	require.NoError(env.T, err)
+
+	blockInfo, ok := blocklog.NewStateAccess(chainState).BlockInfo(chainState.BlockIndex())
+	require.True(env.T, ok, "block info of block #%d not found", chainState.BlockIndex())
+	require.NotNil(env.T, blockInfo.PreviousAliasOutput, "cannot fork the chain at the origin block")
+	chainID := isc.ChainIDFromAliasID(blockInfo.PreviousAliasOutput.GetAliasID())
+
+	db, writeMutex, err := env.chainStateDatabaseManager.ChainStateKVStore(chainID)
+	require.NoError(env.T, err)
+	err = snapshotDB.Iterate(nil, func(k, v []byte) bool {
+		require.NoError(env.T, db.Set(k, v))
+		return true
+	})
+	require.NoError(env.T, err)
+
+	chainOriginator := env.NewKeyPairFromIndex(-1000 + len(env.chains))
+	_, err = env.utxoDB.GetFundsFromFaucet(chainOriginator.GetPublicKey().AsEd25519Address())
+	require.NoError(env.T, err)
+	stateControllerKey := env.NewKeyPairFromIndex(-1)
+	stateControllerAddr := stateControllerKey.GetPublicKey().AsEd25519Address()
+
+	// mirror the anchor of the snapshot state in the L1 ledger, controlled by the Solo keys
+	governanceState := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
+	publicURL, _ := governance.GetPublicURL(governanceState)
+	accountsState := subrealm.NewReadOnly(chainState, kv.Key(accounts.Contract.Hname().Bytes()))
+	anchor := blockInfo.PreviousAliasOutput.GetAliasOutput().Clone().(*iotago.AliasOutput)
+	anchor.AliasID = chainID.AsAliasID()
+	anchor.StateIndex = chainState.BlockIndex()
+	anchor.StateMetadata = transaction.NewStateMetadata(
+		snapshotInfo.Commitment(),
+		governance.MustGetGasFeePolicy(governanceState),
+		chainState.SchemaVersion(),
+		publicURL,
+	).Bytes()
+	anchor.NativeTokens = nil
+	anchor.Conditions = iotago.UnlockConditions{
+		&iotago.StateControllerAddressUnlockCondition{Address: stateControllerAddr},
+		&iotago.GovernorAddressUnlockCondition{Address: stateControllerAddr},
+	}
+	anchor.Amount = accounts.GetTotalL2FungibleTokens(chainState.SchemaVersion(), accountsState).BaseTokens +
+		parameters.L1().Protocol.RentStructure.MinRent(anchor)
+	_, err = env.utxoDB.ImportAliasOutput(anchor)
+	require.NoError(env.T, err)
+
+	// the logical time can't go back
+	if step := blockInfo.Timestamp.Sub(env.GlobalTime()); step > 0 {
+		env.AdvanceClockBy(step)
+	}
+
+	env.logger.Infof("deploying chain '%s' from the state snapshot. ID: %s, block index: %d, state controller address: %s",
+		name, chainID.String(), chainState.BlockIndex(), stateControllerAddr.Bech32(parameters.L1().Protocol.Bech32HRP))
+
+	env.chainsMutex.Lock()
+	defer env.chainsMutex.Unlock()
+	ch := env.addChain(chainData{
+		Name:                   name,
+		ChainID:                chainID,
+		StateControllerKeyPair: stateControllerKey,
+		OriginatorPrivateKey:   chainOriginator,
+		ValidatorFeeTarget:     isc.NewAgentID(chainOriginator.GetPublicKey().AsEd25519Address()),
+		db:                     db,
+		writeMutex:             writeMutex,
+	})
+	ch.log.Infof("chain '%s' deployed from the state snapshot. Chain ID: %s", ch.Name, ch.ChainID.String())
+	return ch
+}
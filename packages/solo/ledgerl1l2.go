@@ -450,3 +450,22 @@ func (ch *Chain) SendFromL2ToL2AccountNativeTokens(id iotago.NativeTokenID, targ
 	transfer.AddNativeTokens(id, amount)
 	return ch.SendFromL2ToL2Account(transfer, target, user)
 }
+
+// SetSponsorAllowance sets the amount of base tokens the sponsor pays for the gas fees of the sender's requests
+func (ch *Chain) SetSponsorAllowance(sponsor cryptolib.VariantKeyPair, sender isc.AgentID, amount uint64) error {
+	_, err := ch.PostRequestOffLedger(NewCallParams(accounts.Contract.Name, accounts.FuncSetSponsorAllowance.Name,
+		accounts.ParamAgentID, sender,
+		accounts.ParamSponsorAllowance, amount,
+	), sponsor)
+	return err
+}
+
+// GetSponsorAllowance returns the amount of base tokens the sponsor still pays for the gas fees of the sender's requests
+func (ch *Chain) GetSponsorAllowance(sponsor, sender isc.AgentID) uint64 {
+	res, err := ch.CallView(accounts.Contract.Name, accounts.ViewGetSponsorAllowance.Name,
+		accounts.ParamSponsor, sponsor,
+		accounts.ParamAgentID, sender,
+	)
+	require.NoError(ch.Env.T, err)
+	return codec.MustDecodeUint64(res.Get(accounts.ParamSponsorAllowance))
+}
@@ -7,6 +7,7 @@
 package solo
 
 import (
+	"cmp"
 	"fmt"
 	"slices"
 	"sync"
@@ -19,6 +20,7 @@ type Mempool interface {
 	ReceiveRequests(reqs ...isc.Request)
 	RequestBatchProposal() []isc.Request
 	RemoveRequest(reqs isc.RequestID)
+	OffLedgerRequests() []isc.OffLedgerRequest
 	Info() MempoolInfo
 }
 
@@ -115,6 +117,21 @@ func (mi *mempoolImpl) RemoveRequest(rID isc.RequestID) {
 	delete(mi.requests, rID)
 }
 
+func (mi *mempoolImpl) OffLedgerRequests() []isc.OffLedgerRequest {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	ret := []isc.OffLedgerRequest{}
+	for _, request := range mi.requests {
+		if request, ok := request.(isc.OffLedgerRequest); ok {
+			ret = append(ret, request)
+		}
+	}
+	slices.SortFunc(ret, func(a, b isc.OffLedgerRequest) int {
+		return cmp.Compare(a.Nonce(), b.Nonce())
+	})
+	return ret
+}
+
 func (mi *mempoolImpl) Info() MempoolInfo {
 	return mi.info
 }
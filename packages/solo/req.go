@@ -39,6 +39,7 @@ type CallParams struct {
 	nonce      uint64 // ignored for on-ledger
 	params     dict.Dict
 	sender     iotago.Address
+	sponsor    isc.AgentID // ignored for on-ledger
 }
 
 // NewCallParams creates structure which wraps in one object call parameters, used in PostRequestSync and callViewFull
@@ -181,13 +182,20 @@ func (r *CallParams) WithSender(sender iotago.Address) *CallParams {
 	return r
 }
 
+// WithSponsor names the agent paying the gas fee of the off-ledger request
+func (r *CallParams) WithSponsor(sponsor isc.AgentID) *CallParams {
+	r.sponsor = sponsor
+	return r
+}
+
 // NewRequestOffLedger creates off-ledger request from parameters
 func (r *CallParams) NewRequestOffLedger(ch *Chain, keyPair cryptolib.VariantKeyPair) isc.OffLedgerRequest {
 	if r.nonce == 0 {
 		r.nonce = ch.Nonce(isc.NewAgentID(keyPair.Address()))
 	}
 	ret := isc.NewOffLedgerRequest(ch.ID(), r.target, r.entryPoint, r.params, r.nonce, r.gasBudget).
-		WithAllowance(r.allowance)
+		WithAllowance(r.allowance).
+		WithSponsor(r.sponsor)
 	return ret.Sign(keyPair)
 }
 
@@ -196,11 +204,18 @@ func (r *CallParams) NewRequestImpersonatedOffLedger(ch *Chain, address *iotago.
 		r.nonce = ch.Nonce(isc.NewAgentID(address))
 	}
 	ret := isc.NewOffLedgerRequest(ch.ID(), r.target, r.entryPoint, r.params, r.nonce, r.gasBudget).
-		WithAllowance(r.allowance)
+		WithAllowance(r.allowance).
+		WithSponsor(r.sponsor)
 
 	return isc.NewImpersonatedOffLedgerRequest(ret.(*isc.OffLedgerRequestData)).WithSenderAddress(address)
 }
 
+// PostRequestImpersonatedOffLedger runs the off-ledger request on behalf of the address,
+// without its signature
+func (ch *Chain) PostRequestImpersonatedOffLedger(req *CallParams, address *iotago.Ed25519Address) (dict.Dict, error) {
+	return ch.RunOffLedgerRequest(req.NewRequestImpersonatedOffLedger(ch, address))
+}
+
 func parseParams(params []interface{}) dict.Dict {
 	if len(params) == 1 {
 		return params[0].(dict.Dict)
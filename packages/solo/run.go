@@ -70,6 +70,11 @@ func (ch *Chain) runTaskNoLock(reqs []isc.Request, estimateGas bool) *vm.VMTaskR
 		EnableGasBurnLogging: ch.Env.enableGasBurnLogging,
 		EstimateGasMode:      estimateGas,
 		Migrations:           allmigrations.DefaultScheme,
+		EnableImpersonation:  true,
+	}
+	if !estimateGas {
+		task.StatePatches = ch.statePatches
+		ch.statePatches = nil
 	}
 
 	res, err := vmimpl.Run(task)
@@ -125,6 +130,10 @@ func (ch *Chain) runRequestsNolock(reqs []isc.Request, trace string) (results []
 
 	ch.Env.EnqueueRequests(tx)
 
+	for _, f := range ch.blockHooks {
+		f()
+	}
+
 	return res.RequestResults
 }
 
@@ -127,6 +127,10 @@ type Chain struct {
 	metrics *metrics.ChainMetrics
 
 	migrationScheme *migrations.MigrationScheme
+	// statePatches are applied to the state with the next block
+	statePatches []migrations.Migration
+	// blockHooks are called after each block of the chain is added to the ledger
+	blockHooks []func()
 }
 
 var _ chain.ChainCore = &Chain{}
@@ -524,6 +528,22 @@ func (ch *Chain) AddMigration(m migrations.Migration) {
 	ch.migrationScheme.Migrations = append(ch.migrationScheme.Migrations, m)
 }
 
+// AddStatePatch schedules a direct modification of the contract state, which is
+// applied before running the requests of the next block.
+func (ch *Chain) AddStatePatch(patch migrations.Migration) {
+	ch.runVMMutex.Lock()
+	defer ch.runVMMutex.Unlock()
+	ch.statePatches = append(ch.statePatches, patch)
+}
+
+// OnBlock registers a function to be called after each block of the chain is added to the ledger.
+// The function is called before the next block is produced, so the environment is consistent.
+func (ch *Chain) OnBlock(f func()) {
+	ch.runVMMutex.Lock()
+	defer ch.runVMMutex.Unlock()
+	ch.blockHooks = append(ch.blockHooks, f)
+}
+
 func (ch *Chain) GetCandidateNodes() []*governance.AccessNodeInfo {
 	panic("unimplemented")
 }
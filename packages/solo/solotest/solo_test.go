@@ -1,11 +1,14 @@
 package solo_test
 
 import (
+	"bytes"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/nnikolash/wasp-types-exported/packages/chain/statemanager/sm_snapshots"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/solo"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/accounts"
@@ -67,3 +70,80 @@ func TestLoadSnapshot(t *testing.T) {
 	require.NoError(t, err)
 	ch.AssertL2NativeTokens(ch.OriginatorAgentID, nativeTokenID, 1000)
 }
+
+func TestSaveLoadSnapshotRoundTrip(t *testing.T) {
+	env := solo.New(t)
+	ch := env.NewChain()
+	_, ethAddr := ch.NewEthereumAccountWithL2Funds()
+	ethAgentID := isc.NewEthereumAddressAgentID(ch.ChainID, ethAddr)
+	balance := ch.L2BaseTokens(ethAgentID)
+	require.NotZero(t, balance)
+
+	fname := filepath.Join(t.TempDir(), "snapshot.json")
+	env.SaveSnapshot(env.TakeSnapshot(), fname)
+
+	env2 := solo.New(t)
+	env2.RestoreSnapshot(env2.LoadSnapshot(fname))
+	ch2 := env2.GetChainByName(ch.Name)
+	require.Equal(t, ch.ChainID, ch2.ChainID)
+	require.Equal(t, ch.LatestBlockIndex(), ch2.LatestBlockIndex())
+	require.EqualValues(t, balance, ch2.L2BaseTokens(ethAgentID))
+
+	// the restored chain keeps processing requests
+	ch2.MustDepositBaseTokensToL2(isc.Million, ch2.OriginatorPrivateKey)
+	require.Equal(t, ch.LatestBlockIndex()+1, ch2.LatestBlockIndex())
+}
+
+func TestNewChainFromStateSnapshot(t *testing.T) {
+	env := solo.New(t)
+	ch := env.NewChain()
+	_, ethAddr := ch.NewEthereumAccountWithL2Funds()
+	ethAgentID := isc.NewEthereumAddressAgentID(ch.ChainID, ethAddr)
+	balance := ch.L2BaseTokens(ethAgentID)
+	require.NotZero(t, balance)
+
+	block, err := ch.Store().LatestBlock()
+	require.NoError(t, err)
+	snapshot := new(bytes.Buffer)
+	err = sm_snapshots.WriteFullSnapshot(
+		ch.Store(),
+		sm_snapshots.NewSnapshotInfo(block.StateIndex(), block.L1Commitment()),
+		snapshot,
+	)
+	require.NoError(t, err)
+
+	env2 := solo.New(t)
+	ch2 := env2.NewChainFromStateSnapshot("fork", snapshot)
+	require.Equal(t, ch.ChainID, ch2.ChainID)
+	require.Equal(t, block.StateIndex(), ch2.LatestBlockIndex())
+	require.EqualValues(t, balance, ch2.L2BaseTokens(ethAgentID))
+	_, owner, _ := ch.GetInfo()
+	_, owner2, _ := ch2.GetInfo()
+	require.True(t, owner.Equals(owner2))
+
+	// the forked chain keeps processing requests
+	ch2.MustDepositBaseTokensToL2(isc.Million, ch2.OriginatorPrivateKey)
+	require.Equal(t, block.StateIndex()+1, ch2.LatestBlockIndex())
+	ch2.CheckAccountLedger()
+}
+
+func TestSaveSnapshotOnBlock(t *testing.T) {
+	env := solo.New(t)
+	ch := env.NewChain()
+
+	fname := filepath.Join(t.TempDir(), "snapshot.json")
+	blocks := 0
+	ch.OnBlock(func() {
+		blocks++
+		env.SaveSnapshot(env.TakeSnapshot(), fname)
+	})
+	ch.MustDepositBaseTokensToL2(isc.Million, ch.OriginatorPrivateKey)
+	require.Equal(t, 1, blocks)
+
+	// the saved snapshot includes the latest block
+	env2 := solo.New(t)
+	env2.RestoreSnapshot(env2.LoadSnapshot(fname))
+	ch2 := env2.GetChainByName(ch.Name)
+	require.Equal(t, ch.LatestBlockIndex(), ch2.LatestBlockIndex())
+	ch2.MustDepositBaseTokensToL2(isc.Million, ch2.OriginatorPrivateKey)
+}
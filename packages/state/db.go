@@ -181,7 +181,10 @@ func (db *storeDB) buffered() (*bufferedKVStore, *storeDB) {
 }
 
 // increment when changing the snapshot format
-const snapshotVersion = 0
+const (
+	snapshotVersion      = 0
+	deltaSnapshotVersion = 0x80 | snapshotVersion
+)
 
 func (db *storeDB) takeSnapshot(root trie.Hash, w io.Writer) error {
 	block, err := db.readBlock(root)
@@ -201,12 +204,40 @@ func (db *storeDB) takeSnapshot(root trie.Hash, w io.Writer) error {
 	return trie.TakeSnapshot(w)
 }
 
+func (db *storeDB) takeDeltaSnapshot(baseRoot, root trie.Hash, w io.Writer) error {
+	if !db.hasBlock(baseRoot) {
+		return fmt.Errorf("base of the delta snapshot: %w", ErrTrieRootNotFound)
+	}
+	block, err := db.readBlock(root)
+	if err != nil {
+		return err
+	}
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint8(deltaSnapshotVersion)
+	ww.Write(&baseRoot)
+	ww.WriteBytes(block.Bytes())
+	if ww.Err != nil {
+		return ww.Err
+	}
+	return trie.TakeDeltaSnapshot(trieStore(db), baseRoot, root, w)
+}
+
 func (db *storeDB) restoreSnapshot(root trie.Hash, r io.Reader) error {
 	rr := rwutil.NewReader(r)
 	v := rr.ReadUint8()
-	if v != snapshotVersion {
+	if v != snapshotVersion && v != deltaSnapshotVersion {
 		return errors.New("snapshot version mismatch")
 	}
+	if v == deltaSnapshotVersion {
+		var baseRoot trie.Hash
+		rr.Read(&baseRoot)
+		if rr.Err != nil {
+			return rr.Err
+		}
+		if !db.hasBlock(baseRoot) {
+			return fmt.Errorf("base %s of the delta snapshot: %w", baseRoot, ErrTrieRootNotFound)
+		}
+	}
 	blockBytes := rr.ReadBytes()
 	if rr.Err != nil {
 		return rr.Err
@@ -39,6 +39,10 @@ func (s *state) GetMerkleProof(key []byte) *trie.MerkleProof {
 	return s.trieReader.MerkleProof(key)
 }
 
+func (s *state) GetMerkleMultiProof(keys [][]byte, prefixes [][]byte) *trie.MerkleMultiProof {
+	return s.trieReader.MerkleMultiProof(keys, prefixes)
+}
+
 func (s *state) BlockIndex() uint32 {
 	return loadBlockIndexFromState(s)
 }
@@ -695,6 +695,44 @@ func TestRestoreSnapshotNonEmptyDB(t *testing.T) {
 	require.EqualValues(t, addLargestPrunedBlockIndex(dbCopy, 10), dbCopy2)
 }
 
+func TestDeltaSnapshot(t *testing.T) {
+	csOrig, _ := makeRandomDB(t, 10)
+	baseBlock := csOrig.BlockByIndex(5)
+	block := csOrig.LatestBlock()
+	baseSnapshot := new(bytes.Buffer)
+	err := csOrig.TakeSnapshot(baseBlock.TrieRoot(), baseSnapshot)
+	require.NoError(t, err)
+	deltaSnapshot := new(bytes.Buffer)
+	err = csOrig.TakeDeltaSnapshot(baseBlock.TrieRoot(), block.TrieRoot(), deltaSnapshot)
+	require.NoError(t, err)
+
+	db := mapdb.NewMapDB()
+	cs := mustChainStore{state.NewStoreWithUniqueWriteMutex(db)}
+	err = cs.RestoreSnapshot(block.TrieRoot(), bytes.NewReader(deltaSnapshot.Bytes()))
+	require.ErrorIs(t, err, state.ErrTrieRootNotFound)
+	require.True(t, cs.IsEmpty())
+
+	err = cs.RestoreSnapshot(baseBlock.TrieRoot(), baseSnapshot)
+	require.NoError(t, err)
+	err = cs.RestoreSnapshot(block.TrieRoot(), deltaSnapshot)
+	require.NoError(t, err)
+	cs.SetLatest(block.TrieRoot())
+
+	state := cs.LatestState()
+	for i := byte(1); i <= 10; i++ {
+		require.EqualValues(t, []byte("v"), state.Get(kv.Key(fmt.Sprintf("k%d", i))))
+	}
+	require.EqualValues(t, []byte{10}, state.Get("k"))
+	require.EqualValues(t, []byte(strings.Repeat("v", 70)), state.Get("x"))
+
+	// the trie of the delta snapshot does not depend on the base trie anymore
+	_, err = cs.Prune(baseBlock.TrieRoot())
+	require.NoError(t, err)
+	state = cs.LatestState()
+	require.EqualValues(t, []byte{10}, state.Get("k"))
+	require.EqualValues(t, []byte(strings.Repeat("v", 70)), state.Get("x"))
+}
+
 func TestPrunedSnapshot(t *testing.T) {
 	r := newRandomState(t)
 	for i := 1; i <= 20; i++ {
@@ -259,6 +259,10 @@ func (s *store) TakeSnapshot(root trie.Hash, w io.Writer) error {
 	return s.db.takeSnapshot(root, w)
 }
 
+func (s *store) TakeDeltaSnapshot(baseRoot, root trie.Hash, w io.Writer) error {
+	return s.db.takeDeltaSnapshot(baseRoot, root, w)
+}
+
 func (s *store) RestoreSnapshot(root trie.Hash, r io.Reader) error {
 	if s.db.hasBlock(root) {
 		return nil
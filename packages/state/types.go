@@ -82,8 +82,14 @@ type Store interface {
 	// TakeSnapshot takes a snapshot of the block and trie at the given trie root.
 	TakeSnapshot(trie.Hash, io.Writer) error
 
+	// TakeDeltaSnapshot takes a snapshot of the block and trie at the given trie
+	// root (second parameter), which contains only the trie nodes that are not
+	// present in the trie at the base trie root (first parameter).
+	TakeDeltaSnapshot(trie.Hash, trie.Hash, io.Writer) error
+
 	// RestoreSnapshot restores the block and trie from the given snapshot.
 	// It is not required for the previous trie root to be present in the DB.
+	// A delta snapshot requires its base trie root to be present in the DB.
 	RestoreSnapshot(trie.Hash, io.Reader) error
 }
 
@@ -118,6 +124,7 @@ type State interface {
 	kv.KVStoreReader
 	TrieRoot() trie.Hash
 	GetMerkleProof(key []byte) *trie.MerkleProof
+	GetMerkleMultiProof(keys [][]byte, prefixes [][]byte) *trie.MerkleMultiProof
 	Equals(State) bool
 	StateCommonValues
 }
@@ -42,3 +42,12 @@ func (p *DkgRegistryProvider) LoadDKShare(sharedAddress iotago.Address) (tcrypto
 	}
 	return tcrypto.DKShareFromBytes(dkShareBytes, tcrypto.DefaultEd25519Suite(), tcrypto.DefaultBLSSuite(), p.nodePrivKey)
 }
+
+// DeleteDKShare implements dkg.DKShareRegistryProvider.
+func (p *DkgRegistryProvider) DeleteDKShare(sharedAddress iotago.Address) error {
+	if _, ok := p.DB[sharedAddress.String()]; !ok {
+		return fmt.Errorf("DKShare not found for %v", sharedAddress.String())
+	}
+	delete(p.DB, sharedAddress.String())
+	return nil
+}
@@ -60,15 +60,6 @@ func (p *PeeringNetwork) NetworkProviders() []peering.NetworkProvider {
 	return cp
 }
 
-func (p *PeeringNetwork) nodeByPubKey(nodePubKey *cryptolib.PublicKey) *peeringNode {
-	for i := range p.nodes {
-		if p.nodes[i].identity.GetPublicKey().Equals(nodePubKey) {
-			return p.nodes[i]
-		}
-	}
-	return nil
-}
-
 // Close implements the io.Closer interface.
 func (p *PeeringNetwork) Close() error {
 	for _, n := range p.nodes {
@@ -200,11 +191,11 @@ func (p *peeringNetworkProvider) Self() peering.PeerSender {
 func (p *peeringNetworkProvider) PeerGroup(peeringID peering.PeeringID, peerPubKeys []*cryptolib.PublicKey) (peering.GroupProvider, error) {
 	peers := make([]peering.PeerSender, len(peerPubKeys))
 	for i := range peerPubKeys {
-		n := p.network.nodeByPubKey(peerPubKeys[i])
-		if n == nil {
+		s, err := p.PeerByPubKey(peerPubKeys[i])
+		if err != nil {
 			return nil, errors.New("unknown node location")
 		}
-		peers[i] = p.senders[i]
+		peers[i] = s
 	}
 	return group.NewPeeringGroupProvider(p, peeringID, peers, p.log)
 }
@@ -213,11 +204,11 @@ func (p *peeringNetworkProvider) PeerGroup(peeringID peering.PeeringID, peerPubK
 func (p *peeringNetworkProvider) PeerDomain(peeringID peering.PeeringID, peerPubKeys []*cryptolib.PublicKey) (peering.PeerDomainProvider, error) {
 	peers := make([]peering.PeerSender, len(peerPubKeys))
 	for i := range peerPubKeys {
-		n := p.network.nodeByPubKey(peerPubKeys[i])
-		if n == nil {
+		s, err := p.PeerByPubKey(peerPubKeys[i])
+		if err != nil {
 			return nil, errors.New("unknown node pub key")
 		}
-		peers[i] = p.senders[i]
+		peers[i] = s
 	}
 	return domain.NewPeerDomain(p, peeringID, peers, p.log), nil
 }
@@ -175,17 +175,19 @@ func (u *UtxoDB) GenesisAddress() iotago.Address {
 	return genesisAddress
 }
 
-func (u *UtxoDB) mustGetFundsFromFaucetTx(target iotago.Address, amount ...uint64) *iotago.Transaction {
+func (u *UtxoDB) mustGetGenesisOutput() (*iotago.BasicOutput, iotago.OutputID) {
 	unspentOutputs := u.getUnspentOutputs(genesisAddress)
 	if len(unspentOutputs) != 1 {
 		panic("number of genesis outputs must be 1")
 	}
-	var inputOutput *iotago.BasicOutput
-	var inputOutputID iotago.OutputID
 	for oid, out := range unspentOutputs {
-		inputOutput = out.(*iotago.BasicOutput)
-		inputOutputID = oid
+		return out.(*iotago.BasicOutput), oid
 	}
+	panic("unreachable")
+}
+
+func (u *UtxoDB) mustGetFundsFromFaucetTx(target iotago.Address, amount ...uint64) *iotago.Transaction {
+	inputOutput, inputOutputID := u.mustGetGenesisOutput()
 
 	fundsAmount := FundsFromFaucetAmount
 	if len(amount) > 0 {
@@ -223,6 +225,49 @@ func (u *UtxoDB) GetFundsFromFaucet(target iotago.Address, amount ...uint64) (*i
 	return tx, u.AddToLedger(tx)
 }
 
+// ImportAliasOutput adds the alias output to the ledger without validating the state
+// transition of the alias. It is used to mirror the anchor of a chain, which was created
+// in another ledger (e.g. when forking a chain). The base tokens of the output are taken
+// from the faucet.
+func (u *UtxoDB) ImportAliasOutput(out *iotago.AliasOutput) (iotago.OutputID, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if out.AliasID.Empty() {
+		return iotago.OutputID{}, errors.New("alias ID of the imported output must be set")
+	}
+	inputOutput, inputOutputID := u.mustGetGenesisOutput()
+	if out.Amount > inputOutput.Amount {
+		return iotago.OutputID{}, errors.New("not enough funds in the faucet")
+	}
+	tx, err := builder.NewTransactionBuilder(parameters.L1().Protocol.NetworkID()).
+		AddInput(&builder.TxInput{
+			UnlockTarget: genesisAddress,
+			InputID:      inputOutputID,
+			Input:        inputOutput,
+		}).
+		AddOutput(out).
+		AddOutput(&iotago.BasicOutput{
+			Amount: inputOutput.Amount - out.Amount,
+			Conditions: iotago.UnlockConditions{
+				&iotago.AddressUnlockCondition{Address: genesisAddress},
+			},
+		}).
+		Build(parameters.L1().Protocol, genesisSigner)
+	if err != nil {
+		return iotago.OutputID{}, err
+	}
+	if _, err = tx.Serialize(serializer.DeSeriModePerformValidation, parameters.L1().Protocol); err != nil {
+		return iotago.OutputID{}, err
+	}
+	txID, err := tx.ID()
+	if err != nil {
+		return iotago.OutputID{}, err
+	}
+	u.addTransaction(tx, false)
+	return iotago.OutputIDFromTransactionIDAndIndex(txID, 0), nil
+}
+
 // Supply returns supply of the instance.
 func (u *UtxoDB) Supply() uint64 {
 	return u.supply
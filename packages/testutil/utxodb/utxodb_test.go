@@ -125,3 +125,28 @@ func TestGetOutput(t *testing.T) {
 	outidFail := iotago.OutputIDFromTransactionIDAndIndex(txID, 5)
 	require.Nil(t, u.GetOutput(outidFail))
 }
+
+func TestImportAliasOutput(t *testing.T) {
+	u := New()
+	stateController := tpkg.RandEd25519Address()
+	aliasID := tpkg.RandAliasAddress().AliasID()
+	out := &iotago.AliasOutput{
+		Amount:     1000 * 1000,
+		AliasID:    aliasID,
+		StateIndex: 42,
+		Conditions: iotago.UnlockConditions{
+			&iotago.StateControllerAddressUnlockCondition{Address: stateController},
+			&iotago.GovernorAddressUnlockCondition{Address: stateController},
+		},
+	}
+	outputID, err := u.ImportAliasOutput(out)
+	require.NoError(t, err)
+	require.EqualValues(t, u.Supply()-out.Amount, u.GetAddressBalanceBaseTokens(u.GenesisAddress()))
+
+	outputs := u.GetAliasOutputs(aliasID.ToAddress())
+	require.Len(t, outputs, 1)
+	require.Equal(t, out, outputs[outputID])
+
+	_, err = u.ImportAliasOutput(&iotago.AliasOutput{Amount: 1000 * 1000})
+	require.Error(t, err)
+}
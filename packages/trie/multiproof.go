@@ -0,0 +1,200 @@
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nnikolash/wasp-types-exported/packages/util/rwutil"
+)
+
+// MerkleMultiProof is a proof of inclusion or absence of several keys and of the
+// complete content of key ranges (all keys with a given prefix) at once.
+// The proof is a pruned copy of the trie: the nodes along the paths of the proven
+// keys and all nodes of the proven ranges are expanded, every other subtree is
+// represented only by its commitment. The nodes shared by several paths are
+// included only once.
+type MerkleMultiProof struct {
+	Root *MultiProofNode
+}
+
+// MultiProofNode is a node of the MerkleMultiProof.
+// For each child index, at most one of Children and Expanded is not nil
+type MultiProofNode struct {
+	PathExtension []byte
+	// Terminal is the terminal commitment compressed to the hash size, nil if absent
+	Terminal []byte
+	// Children contains the commitments of the children not expanded in the proof
+	Children [NumChildren]*Hash
+	// Expanded contains the children included in the proof
+	Expanded [NumChildren]*MultiProofNode
+}
+
+// MerkleMultiProof returns a proof for the given keys and for the whole ranges
+// of keys starting with each of the given prefixes
+func (tr *TrieReader) MerkleMultiProof(keys [][]byte, prefixes [][]byte) *MerkleMultiProof {
+	unpackedKeys := make([][]byte, len(keys))
+	for i, k := range keys {
+		unpackedKeys[i] = unpackBytes(k)
+	}
+	unpackedPrefixes := make([][]byte, len(prefixes))
+	for i, p := range prefixes {
+		unpackedPrefixes[i] = unpackBytes(p)
+	}
+	root := tr.nodeStore.MustFetchNodeData(tr.root)
+	return &MerkleMultiProof{
+		Root: tr.multiProofNode(root, nil, unpackedKeys, unpackedPrefixes),
+	}
+}
+
+func (tr *TrieReader) multiProofNode(n *NodeData, triePath []byte, keys, prefixes [][]byte) *MultiProofNode {
+	ret := &MultiProofNode{
+		PathExtension: n.PathExtension,
+	}
+	if n.Terminal != nil {
+		ret.Terminal = compressToHashSize(n.Terminal.Bytes())
+	}
+	fullPath := concat(triePath, n.PathExtension)
+
+	expandAll := false
+	var childKeys, childPrefixes [NumChildren][][]byte
+	for _, p := range prefixes {
+		switch {
+		case bytes.HasPrefix(fullPath, p):
+			// the whole subtree is in the range
+			expandAll = true
+		case len(p) > len(fullPath) && bytes.HasPrefix(p, fullPath):
+			childIndex := p[len(fullPath)]
+			childPrefixes[childIndex] = append(childPrefixes[childIndex], p)
+		}
+	}
+	for _, k := range keys {
+		if len(k) > len(fullPath) && bytes.HasPrefix(k, fullPath) {
+			childIndex := k[len(fullPath)]
+			childKeys[childIndex] = append(childKeys[childIndex], k)
+		}
+	}
+	if expandAll {
+		// all descendants are in the range, so are all the keys and prefixes below
+		prefixes = [][]byte{fullPath}
+	}
+
+	for i := range n.Children {
+		childIndex := byte(i)
+		if n.Children[childIndex] == nil {
+			continue
+		}
+		if !expandAll && len(childKeys[childIndex]) == 0 && len(childPrefixes[childIndex]) == 0 {
+			ret.Children[childIndex] = n.Children[childIndex]
+			continue
+		}
+		child, childPath := tr.nodeStore.FetchChild(n, childIndex, triePath)
+		assertf(child != nil, "TrieReader::multiProofNode: child not found")
+		cp := childPrefixes[childIndex]
+		if expandAll {
+			cp = prefixes
+		}
+		ret.Expanded[childIndex] = tr.multiProofNode(child, childPath, childKeys[childIndex], cp)
+	}
+	return ret
+}
+
+func MerkleMultiProofFromBytes(data []byte) (*MerkleMultiProof, error) {
+	return rwutil.ReadFromBytes(data, new(MerkleMultiProof))
+}
+
+func (p *MerkleMultiProof) Bytes() []byte {
+	return rwutil.WriteToBytes(p)
+}
+
+func (p *MerkleMultiProof) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	p.Root = nil
+	if rr.ReadBool() {
+		p.Root = new(MultiProofNode)
+		rr.Read(p.Root)
+	}
+	return rr.Err
+}
+
+func (p *MerkleMultiProof) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteBool(p.Root != nil)
+	if p.Root != nil {
+		ww.Write(p.Root)
+	}
+	return ww.Err
+}
+
+// Read/Write serialize the subtree in pre-order. Each node is encoded as:
+// path extension, mask and commitments of the pruned children, mask of
+// the expanded children, optional terminal, followed by the expanded children
+func (n *MultiProofNode) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	encoded := rr.ReadBytes()
+	if rr.Err == nil {
+		n.PathExtension, rr.Err = decodeToUnpackedBytes(encoded)
+	}
+	childrenFlags := rr.ReadUint16()
+	for i := 0; i < NumChildren; i++ {
+		n.Children[i] = nil
+		if childrenFlags&(1<<i) != 0 {
+			n.Children[i] = &Hash{}
+			rr.Read(n.Children[i])
+		}
+	}
+	expandedFlags := rr.ReadUint16()
+	if rr.Err == nil && childrenFlags&expandedFlags != 0 {
+		rr.Err = errors.New("child both pruned and expanded")
+	}
+	n.Terminal = nil
+	if rr.ReadBool() {
+		n.Terminal = rr.ReadBytes()
+		if rr.Err == nil && len(n.Terminal) > HashSizeBytes {
+			rr.Err = fmt.Errorf("terminal commitment can't be longer than %d bytes", HashSizeBytes)
+		}
+	}
+	for i := 0; i < NumChildren; i++ {
+		n.Expanded[i] = nil
+		if expandedFlags&(1<<i) != 0 && rr.Err == nil {
+			n.Expanded[i] = new(MultiProofNode)
+			rr.Read(n.Expanded[i])
+		}
+	}
+	return rr.Err
+}
+
+func (n *MultiProofNode) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	var encoded []byte
+	encoded, ww.Err = encodeUnpackedBytes(n.PathExtension)
+	ww.WriteBytes(encoded)
+	childrenFlags := uint16(0)
+	expandedFlags := uint16(0)
+	for i := 0; i < NumChildren; i++ {
+		if n.Children[i] != nil {
+			childrenFlags |= 1 << i
+		}
+		if n.Expanded[i] != nil {
+			expandedFlags |= 1 << i
+		}
+	}
+	ww.WriteUint16(childrenFlags)
+	for _, c := range n.Children {
+		if c != nil {
+			ww.Write(c)
+		}
+	}
+	ww.WriteUint16(expandedFlags)
+	ww.WriteBool(n.Terminal != nil)
+	if n.Terminal != nil {
+		ww.WriteBytes(n.Terminal)
+	}
+	for _, e := range n.Expanded {
+		if e != nil {
+			ww.Write(e)
+		}
+	}
+	return ww.Err
+}
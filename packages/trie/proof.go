@@ -1,5 +1,12 @@
 package trie
 
+import (
+	"fmt"
+	"io"
+
+	"github.com/nnikolash/wasp-types-exported/packages/util/rwutil"
+)
+
 // MerkleProof is a proof of inclusion or absence
 type MerkleProof struct {
 	Key  []byte
@@ -54,3 +61,91 @@ func (tr *TrieReader) MerkleProof(key []byte) *MerkleProof {
 	}
 	return ret
 }
+
+func MerkleProofFromBytes(data []byte) (*MerkleProof, error) {
+	return rwutil.ReadFromBytes(data, new(MerkleProof))
+}
+
+func (p *MerkleProof) Bytes() []byte {
+	return rwutil.WriteToBytes(p)
+}
+
+func (p *MerkleProof) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	encodedKey := rr.ReadBytes()
+	if rr.Err == nil {
+		p.Key, rr.Err = decodeToUnpackedBytes(encodedKey)
+	}
+	size := rr.ReadSize32()
+	p.Path = make([]*MerkleProofElement, size)
+	for i := range p.Path {
+		p.Path[i] = new(MerkleProofElement)
+		rr.Read(p.Path[i])
+	}
+	return rr.Err
+}
+
+func (p *MerkleProof) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	var encodedKey []byte
+	encodedKey, ww.Err = encodeUnpackedBytes(p.Key)
+	ww.WriteBytes(encodedKey)
+	ww.WriteSize32(len(p.Path))
+	for _, e := range p.Path {
+		ww.Write(e)
+	}
+	return ww.Err
+}
+
+// Read/Write serialize the proof element in the same spirit as NodeData:
+// the path extension is packed to nibbles, the children are preceded by
+// a 16 bit presence mask and the terminal by a presence flag
+func (e *MerkleProofElement) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	encoded := rr.ReadBytes()
+	if rr.Err == nil {
+		e.PathExtension, rr.Err = decodeToUnpackedBytes(encoded)
+	}
+	flags := rr.ReadUint16()
+	for i := 0; i < NumChildren; i++ {
+		e.Children[i] = nil
+		if flags&(1<<i) != 0 {
+			e.Children[i] = &Hash{}
+			rr.Read(e.Children[i])
+		}
+	}
+	e.Terminal = nil
+	if rr.ReadBool() {
+		e.Terminal = rr.ReadBytes()
+	}
+	e.ChildIndex = int(rr.ReadUint8())
+	if rr.Err == nil && e.ChildIndex > pathExtensionIndex {
+		rr.Err = fmt.Errorf("wrong child index %d", e.ChildIndex)
+	}
+	return rr.Err
+}
+
+func (e *MerkleProofElement) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	var encoded []byte
+	encoded, ww.Err = encodeUnpackedBytes(e.PathExtension)
+	ww.WriteBytes(encoded)
+	flags := uint16(0)
+	for i, c := range e.Children {
+		if c != nil {
+			flags |= 1 << i
+		}
+	}
+	ww.WriteUint16(flags)
+	for _, c := range e.Children {
+		if c != nil {
+			ww.Write(c)
+		}
+	}
+	ww.WriteBool(e.Terminal != nil)
+	if e.Terminal != nil {
+		ww.WriteBytes(e.Terminal)
+	}
+	ww.WriteUint8(uint8(e.ChildIndex))
+	return ww.Err
+}
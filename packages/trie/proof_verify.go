@@ -170,3 +170,192 @@ func (p *MerkleProof) ValidateValue(trieRoot Hash, value []byte) error {
 	tc := CommitToData(value)
 	return p.ValidateWithTerminal(trieRoot.Bytes(), tc.Bytes())
 }
+
+// ValidateKeyValue checks the proof is about the key and commits to the value under it.
+// nil value means the proof must prove absence of the key
+func (p *MerkleProof) ValidateKeyValue(trieRoot Hash, key, value []byte) error {
+	if !bytes.Equal(p.Key, unpackBytes(key)) {
+		return errors.New("proof is not about the key")
+	}
+	if len(value) == 0 {
+		if err := p.Validate(trieRoot.Bytes()); err != nil {
+			return err
+		}
+		if !p.IsProofOfAbsence() {
+			return errors.New("key is present, expected proof of absence")
+		}
+		return nil
+	}
+	return p.ValidateValue(trieRoot, value)
+}
+
+// Validate checks the multi-proof against the provided root commitment
+func (p *MerkleMultiProof) Validate(rootBytes []byte) error {
+	if p.Root == nil {
+		return errors.New("multi-proof is empty")
+	}
+	c, err := p.Root.commitment(0)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(c[:], rootBytes) {
+		return errors.New("invalid multi-proof: commitment not equal to the root")
+	}
+	return nil
+}
+
+// ValidateValue checks the multi-proof and checks if it commits to the value under the key.
+// nil value means the proof must prove absence of the key
+func (p *MerkleMultiProof) ValidateValue(trieRoot Hash, key, value []byte) error {
+	if err := p.Validate(trieRoot.Bytes()); err != nil {
+		return err
+	}
+	terminal, err := p.Root.terminal(unpackBytes(key))
+	if err != nil {
+		return err
+	}
+	return checkTerminal(terminal, value)
+}
+
+// ValidateRange checks the multi-proof and checks if the set of keys with the given prefix,
+// together with their values, is exactly the one provided. Empty values proves absence
+// of any key with the prefix
+func (p *MerkleMultiProof) ValidateRange(trieRoot Hash, prefix []byte, values map[string][]byte) error {
+	if err := p.Validate(trieRoot.Bytes()); err != nil {
+		return err
+	}
+	terminals := make(map[string][]byte)
+	if err := p.Root.rangeTerminals(unpackBytes(prefix), terminals); err != nil {
+		return err
+	}
+	if len(terminals) != len(values) {
+		return fmt.Errorf("range contains %d keys, expected %d", len(terminals), len(values))
+	}
+	for k, v := range values {
+		terminal, ok := terminals[string(unpackBytes([]byte(k)))]
+		if !ok {
+			return fmt.Errorf("key %x is not in the range", k)
+		}
+		if len(v) == 0 {
+			return fmt.Errorf("empty value for key %x", k)
+		}
+		if err := checkTerminal(terminal, v); err != nil {
+			return fmt.Errorf("key %x: %w", k, err)
+		}
+	}
+	return nil
+}
+
+func checkTerminal(terminal, value []byte) error {
+	if len(value) == 0 {
+		if terminal != nil {
+			return errors.New("key is present, expected proof of absence")
+		}
+		return nil
+	}
+	if terminal == nil {
+		return errors.New("key is absent")
+	}
+	if !bytes.Equal(compressToHashSize(CommitToData(value).Bytes()), terminal) {
+		return errors.New("key does not correspond to the given value commitment")
+	}
+	return nil
+}
+
+// commitment computes the commitment of the node, the depth is only used for the error messages
+func (n *MultiProofNode) commitment(depth int) (Hash, error) {
+	hashes := &hashVector{}
+	for i := 0; i < NumChildren; i++ {
+		switch {
+		case n.Children[i] != nil && n.Expanded[i] != nil:
+			return Hash{}, fmt.Errorf("wrong multi-proof: child %d both pruned and expanded. Depth: %d", i, depth)
+		case n.Children[i] != nil:
+			hashes[i] = n.Children[i][:]
+		case n.Expanded[i] != nil:
+			c, err := n.Expanded[i].commitment(depth + 1)
+			if err != nil {
+				return Hash{}, err
+			}
+			hashes[i] = c[:]
+		}
+	}
+	if len(n.Terminal) > 0 {
+		if len(n.Terminal) > HashSizeBytes {
+			return Hash{}, fmt.Errorf(errTooLongCommitment+" (terminal). Depth: %d", terminalIndex, HashSizeBytes, depth)
+		}
+		hashes[terminalIndex] = n.Terminal
+	}
+	hashes[pathExtensionIndex] = compressToHashSize(n.PathExtension)
+	return hashes.Hash(), nil
+}
+
+// terminal returns the terminal commitment of the unpacked key, nil if the key is absent.
+// It returns an error if the proof does not cover the key
+func (n *MultiProofNode) terminal(key []byte) ([]byte, error) {
+	var triePath []byte
+	for {
+		fullPath := concat(triePath, n.PathExtension)
+		if !bytes.HasPrefix(key, fullPath) {
+			return nil, nil
+		}
+		if len(key) == len(fullPath) {
+			if len(n.Terminal) == 0 {
+				return nil, nil
+			}
+			return n.Terminal, nil
+		}
+		childIndex := key[len(fullPath)]
+		switch {
+		case n.Expanded[childIndex] != nil:
+			n = n.Expanded[childIndex]
+			triePath = concat(fullPath, []byte{childIndex})
+		case n.Children[childIndex] != nil:
+			return nil, errors.New("key is not covered by the multi-proof")
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// rangeTerminals collects the terminal commitments of all keys with the unpacked prefix.
+// It returns an error if the proof does not cover the whole range
+func (n *MultiProofNode) rangeTerminals(prefix []byte, ret map[string][]byte) error {
+	var triePath []byte
+	for {
+		fullPath := concat(triePath, n.PathExtension)
+		if bytes.HasPrefix(fullPath, prefix) {
+			return n.collectTerminals(triePath, ret)
+		}
+		if !bytes.HasPrefix(prefix, fullPath) {
+			return nil
+		}
+		childIndex := prefix[len(fullPath)]
+		switch {
+		case n.Expanded[childIndex] != nil:
+			n = n.Expanded[childIndex]
+			triePath = concat(fullPath, []byte{childIndex})
+		case n.Children[childIndex] != nil:
+			return errors.New("range is not covered by the multi-proof")
+		default:
+			return nil
+		}
+	}
+}
+
+func (n *MultiProofNode) collectTerminals(triePath []byte, ret map[string][]byte) error {
+	fullPath := concat(triePath, n.PathExtension)
+	if len(n.Terminal) > 0 {
+		ret[string(fullPath)] = n.Terminal
+	}
+	for i := 0; i < NumChildren; i++ {
+		if n.Children[i] != nil {
+			return errors.New("range is not covered by the multi-proof")
+		}
+		if n.Expanded[i] != nil {
+			if err := n.Expanded[i].collectTerminals(concat(fullPath, []byte{byte(i)}), ret); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
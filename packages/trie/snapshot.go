@@ -7,6 +7,27 @@ import (
 )
 
 func (tr *TrieReader) TakeSnapshot(w io.Writer) error {
+	return tr.takeSnapshot(w, func(*NodeData) bool { return true })
+}
+
+// TakeDeltaSnapshot writes a snapshot of the trie with the given root, which
+// contains only the nodes (and their values) that are not present in the trie
+// with the base root. The delta snapshot can be restored with RestoreSnapshot
+// into a store, which already contains the base trie.
+func TakeDeltaSnapshot(store KVStore, baseRoot, root Hash, w io.Writer) error {
+	tr, err := NewTrieReader(store, root)
+	if err != nil {
+		return err
+	}
+	_, newNodes := Diff(store, baseRoot, root)
+	return tr.takeSnapshot(w, func(n *NodeData) bool {
+		// if the node is present in the base trie, so is its whole subtree
+		_, isNew := newNodes[n.Commitment]
+		return isNew
+	})
+}
+
+func (tr *TrieReader) takeSnapshot(w io.Writer, include func(*NodeData) bool) error {
 	// Some duplicated nodes and values might be written more than once in the snapshot;
 	// Using a size-capped map to prevent this.
 	// If the cap is reached, the generated snapshot will contain duplicate information,
@@ -17,6 +38,9 @@ func (tr *TrieReader) TakeSnapshot(w io.Writer) error {
 
 	ww := rwutil.NewWriter(w)
 	tr.IterateNodes(func(_ []byte, n *NodeData, depth int) IterateNodesAction {
+		if !include(n) {
+			return IterateSkipSubtree
+		}
 		if _, seen := seenNodes[n.Commitment]; seen {
 			return IterateContinue
 		}
@@ -30,6 +30,12 @@ func TestProofScenariosBlake2b(t *testing.T) {
 				p := trr.MerkleProof([]byte(k))
 				err = p.Validate(root.Bytes())
 				require.NoError(t, err)
+
+				pBack, err := trie.MerkleProofFromBytes(p.Bytes())
+				require.NoError(t, err)
+				require.Equal(t, p.Bytes(), pBack.Bytes())
+				require.NoError(t, pBack.Validate(root.Bytes()))
+				require.Equal(t, p.IsProofOfAbsence(), pBack.IsProofOfAbsence())
 				if v != "" {
 					cID := trie.CommitToData([]byte(v))
 					err = p.ValidateWithTerminal(root.Bytes(), cID.Bytes())
@@ -53,3 +59,94 @@ func TestProofScenariosBlake2b(t *testing.T) {
 	}
 	runScenario("long", longData)
 }
+
+func TestMultiProof(t *testing.T) {
+	store := NewInMemoryKVStore()
+	initRoot := trie.MustInitRoot(store)
+	tr, err := trie.NewTrieUpdatable(store, initRoot)
+	require.NoError(t, err)
+
+	data := map[string]string{
+		"a":     "a",
+		"ab":    "ab",
+		"abc":   strings.Repeat("abc", 50),
+		"abd":   "abd",
+		"b":     "b",
+		"bca":   strings.Repeat("bca", 50),
+		"cde/x": "cde",
+		"cdf":   "cdf",
+	}
+	for k, v := range data {
+		tr.Update([]byte(k), []byte(v))
+	}
+	root, _ := tr.Commit(store)
+	trr, err := trie.NewTrieReader(store, root)
+	require.NoError(t, err)
+
+	t.Run("keys", func(t *testing.T) {
+		keys := []string{"a", "abc", "abd", "cdf", "zz", "abe", "ca"}
+		keysBin := make([][]byte, len(keys))
+		for i, k := range keys {
+			keysBin[i] = []byte(k)
+		}
+		p, err := trie.MerkleMultiProofFromBytes(trr.MerkleMultiProof(keysBin, nil).Bytes())
+		require.NoError(t, err)
+		require.NoError(t, p.Validate(root.Bytes()))
+
+		singleProofsSize := 0
+		for _, k := range keys {
+			var v []byte
+			if s, ok := data[k]; ok {
+				v = []byte(s)
+			}
+			require.NoError(t, p.ValidateValue(root, []byte(k), v))
+			singleProofsSize += len(trr.MerkleProof([]byte(k)).Bytes())
+		}
+		require.Less(t, len(p.Bytes()), singleProofsSize)
+
+		require.Error(t, p.ValidateValue(root, []byte("a"), []byte("b")))
+		require.Error(t, p.ValidateValue(root, []byte("a"), nil))
+		require.Error(t, p.ValidateValue(root, []byte("zz"), []byte("zz")))
+		// not covered by the proof
+		require.Error(t, p.ValidateValue(root, []byte("bca"), []byte(data["bca"])))
+		// wrong root
+		require.Error(t, p.ValidateValue(initRoot, []byte("a"), []byte("a")))
+	})
+
+	t.Run("range", func(t *testing.T) {
+		p, err := trie.MerkleMultiProofFromBytes(trr.MerkleMultiProof(nil, [][]byte{[]byte("ab"), []byte("x")}).Bytes())
+		require.NoError(t, err)
+		require.NoError(t, p.Validate(root.Bytes()))
+
+		abRange := map[string][]byte{
+			"ab":  []byte(data["ab"]),
+			"abc": []byte(data["abc"]),
+			"abd": []byte(data["abd"]),
+		}
+		require.NoError(t, p.ValidateRange(root, []byte("ab"), abRange))
+		require.NoError(t, p.ValidateRange(root, []byte("abc"), map[string][]byte{"abc": []byte(data["abc"])}))
+		require.NoError(t, p.ValidateRange(root, []byte("x"), nil))
+		require.NoError(t, p.ValidateValue(root, []byte("abd"), []byte(data["abd"])))
+
+		// missing key
+		delete(abRange, "abd")
+		require.Error(t, p.ValidateRange(root, []byte("ab"), abRange))
+		// extra key
+		abRange["abd"] = []byte(data["abd"])
+		abRange["abe"] = []byte("abe")
+		require.Error(t, p.ValidateRange(root, []byte("ab"), abRange))
+		// wrong value
+		delete(abRange, "abe")
+		abRange["abd"] = []byte("xxx")
+		require.Error(t, p.ValidateRange(root, []byte("ab"), abRange))
+		// not covered by the proof
+		require.Error(t, p.ValidateRange(root, []byte("a"), map[string][]byte{"a": []byte("a")}))
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		p := trr.MerkleMultiProof([][]byte{[]byte("a")}, [][]byte{[]byte("cd")})
+		require.NoError(t, p.Validate(root.Bytes()))
+		p.Root.Terminal = []byte("x")
+		require.Error(t, p.Validate(root.Bytes()))
+	})
+}
@@ -0,0 +1,69 @@
+package users
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"time"
+
+	"github.com/iotaledger/hive.go/lo"
+)
+
+// APIKeyPrefix is the prefix of the generated API keys.
+const APIKeyPrefix = "wasp_"
+
+// APIKey is a long-lived, revocable key which authenticates as its user.
+// Only the hash of the key is stored.
+type APIKey struct {
+	Name    string
+	KeyHash []byte
+	// Permissions is a subset of the permissions of the user; empty means all the permissions of the user.
+	Permissions map[string]struct{}
+	CreatedAt   time.Time
+	// ExpiresAt is zero, if the key never expires.
+	ExpiresAt time.Time
+}
+
+// GenerateAPIKey returns a new random API key and its hash.
+func GenerateAPIKey() (string, []byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, err
+	}
+
+	key := APIKeyPrefix + hex.EncodeToString(secret)
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hash of the API key, which is stored instead of the key.
+func HashAPIKey(key string) []byte {
+	hash := sha256.Sum256([]byte(key))
+	return hash[:]
+}
+
+// IsExpired returns true, if the key has an expiry time and it has passed.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// PermissionsSlice returns the permissions of the key as a slice.
+func (k *APIKey) PermissionsSlice() []string {
+	permissions := make([]string, 0, len(k.Permissions))
+
+	for permission := range k.Permissions {
+		permissions = append(permissions, permission)
+	}
+
+	return permissions
+}
+
+func (k *APIKey) clone() *APIKey {
+	return &APIKey{
+		Name:        k.Name,
+		KeyHash:     lo.CopySlice(k.KeyHash),
+		Permissions: maps.Clone(k.Permissions),
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+	}
+}
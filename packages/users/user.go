@@ -3,6 +3,8 @@ package users
 import (
 	"encoding/hex"
 	"errors"
+	"maps"
+	"time"
 
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/nnikolash/wasp-types-exported/packages/onchangemap"
@@ -14,6 +16,12 @@ type User struct {
 	PasswordHash []byte
 	PasswordSalt []byte
 	Permissions  map[string]struct{}
+	// APIKeys by their names
+	APIKeys map[string]*APIKey
+	// RevokedTokens maps the IDs of the revoked JWTs to their expiry times
+	RevokedTokens map[string]time.Time
+	// TokensNotBefore revokes all the JWTs issued until this time (zero if none)
+	TokensNotBefore time.Time
 }
 
 func NewUser(username, passwordHashHex, passwordSaltHex string, permissions map[string]struct{}) (*User, error) {
@@ -41,10 +49,12 @@ func NewUser(username, passwordHashHex, passwordSaltHex string, permissions map[
 	}
 
 	return &User{
-		Name:         username,
-		PasswordHash: passwordHash,
-		PasswordSalt: passwordSalt,
-		Permissions:  permissions,
+		Name:          username,
+		PasswordHash:  passwordHash,
+		PasswordSalt:  passwordSalt,
+		Permissions:   permissions,
+		APIKeys:       make(map[string]*APIKey),
+		RevokedTokens: make(map[string]time.Time),
 	}, nil
 }
 
@@ -59,11 +69,24 @@ func (u *User) Clone() onchangemap.Item[string, util.ComparableString] {
 		permissionsCopy[k] = struct{}{}
 	}
 
+	apiKeysCopy := make(map[string]*APIKey, len(u.APIKeys))
+	for name, apiKey := range u.APIKeys {
+		apiKeysCopy[name] = apiKey.clone()
+	}
+
+	revokedTokensCopy := maps.Clone(u.RevokedTokens)
+	if revokedTokensCopy == nil {
+		revokedTokensCopy = make(map[string]time.Time)
+	}
+
 	return &User{
-		Name:         u.Name,
-		PasswordHash: lo.CopySlice(u.PasswordHash),
-		PasswordSalt: lo.CopySlice(u.PasswordSalt),
-		Permissions:  permissionsCopy,
+		Name:            u.Name,
+		PasswordHash:    lo.CopySlice(u.PasswordHash),
+		PasswordSalt:    lo.CopySlice(u.PasswordSalt),
+		Permissions:     permissionsCopy,
+		APIKeys:         apiKeysCopy,
+		RevokedTokens:   revokedTokensCopy,
+		TokensNotBefore: u.TokensNotBefore,
 	}
 }
 
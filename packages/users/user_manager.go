@@ -1,21 +1,21 @@
 package users
 
 import (
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"slices"
 	"strings"
+	"time"
 
 	"golang.org/x/exp/maps"
 
 	"github.com/iotaledger/hive.go/web/basicauth"
+	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared/permissions"
 	"github.com/nnikolash/wasp-types-exported/packages/onchangemap"
 	"github.com/nnikolash/wasp-types-exported/packages/util"
 )
 
-var AllowedPermissions = []string{"read", "write"}
-
 // UserManager handles the list of users that are stored in the user config.
 // It calls a function if the list changed.
 type UserManager struct {
@@ -32,9 +32,10 @@ func NewUserManager(storeCallback func([]*User) error) *UserManager {
 }
 
 func isPermissionAllowed(permission string) bool {
-	return slices.Contains(AllowedPermissions, permission)
+	return permission != permissions.API && permissions.Validate(permission) == nil
 }
 
+// SanitizePermissions drops the unknown permissions, see permissions.Validate.
 func (m *UserManager) SanitizePermissions(permissions map[string]struct{}) map[string]struct{} {
 	sanitizedPermissions := map[string]struct{}{}
 
@@ -90,6 +91,7 @@ func (m *UserManager) ChangeUserPassword(name string, passwordHash, passwordSalt
 
 	user.PasswordHash = passwordHash
 	user.PasswordSalt = passwordSalt
+	revokeAllTokens(user, time.Now()) // The tokens issued with the old password must not be usable anymore.
 
 	if err := m.ModifyUser(user); err != nil {
 		return fmt.Errorf("unable to change password for user \"%s\": %w", name, err)
@@ -114,6 +116,140 @@ func (m *UserManager) ChangeUserPermissions(name string, permissions map[string]
 	return nil
 }
 
+// AddAPIKey adds an API key to a user. The permissions of the key must be included in the permissions of the user.
+func (m *UserManager) AddAPIKey(name string, apiKey *APIKey) error {
+	user, err := m.User(name)
+	if err != nil {
+		return fmt.Errorf("unable to add API key for user \"%s\": user does not exist", name)
+	}
+
+	if apiKey.Name == "" {
+		return errors.New("API key name must not be empty")
+	}
+	if _, exists := user.APIKeys[apiKey.Name]; exists {
+		return fmt.Errorf("API key \"%s\" of user \"%s\" already exists", apiKey.Name, name)
+	}
+	for permission := range apiKey.Permissions {
+		if !isPermissionAllowed(permission) || !permissions.IsGranted(user.Permissions, permission) {
+			return fmt.Errorf("API key permission \"%s\" is not granted to user \"%s\"", permission, name)
+		}
+	}
+
+	user.APIKeys[apiKey.Name] = apiKey.clone()
+
+	if err := m.ModifyUser(user); err != nil {
+		return fmt.Errorf("unable to add API key for user \"%s\": %w", name, err)
+	}
+
+	return nil
+}
+
+// RemoveAPIKey revokes an API key of a user.
+func (m *UserManager) RemoveAPIKey(name, apiKeyName string) error {
+	user, err := m.User(name)
+	if err != nil {
+		return fmt.Errorf("unable to remove API key of user \"%s\": user does not exist", name)
+	}
+
+	if _, exists := user.APIKeys[apiKeyName]; !exists {
+		return fmt.Errorf("API key \"%s\" of user \"%s\" does not exist", apiKeyName, name)
+	}
+	delete(user.APIKeys, apiKeyName)
+
+	if err := m.ModifyUser(user); err != nil {
+		return fmt.Errorf("unable to remove API key of user \"%s\": %w", name, err)
+	}
+
+	return nil
+}
+
+// UserByAPIKey returns a copy of the user the API key belongs to and a copy of the key.
+// Expired keys are not accepted.
+func (m *UserManager) UserByAPIKey(key string) (*User, *APIKey, error) {
+	keyHash := HashAPIKey(key)
+	now := time.Now()
+
+	for _, user := range m.Users() {
+		for _, apiKey := range user.APIKeys {
+			if subtle.ConstantTimeCompare(apiKey.KeyHash, keyHash) == 1 {
+				if apiKey.IsExpired(now) {
+					return nil, nil, errors.New("API key expired")
+				}
+				return user, apiKey, nil
+			}
+		}
+	}
+
+	return nil, nil, errors.New("invalid API key")
+}
+
+// RevokeToken adds the ID of a JWT of a user to the revocation list until the token expires.
+// If the expiry time is unknown (zero), the ID is kept until all the tokens of the user are revoked.
+// The revoked tokens, which have already expired, are removed from the list.
+func (m *UserManager) RevokeToken(name, tokenID string, expiresAt time.Time) error {
+	user, err := m.User(name)
+	if err != nil {
+		return fmt.Errorf("unable to revoke token of user \"%s\": user does not exist", name)
+	}
+
+	if tokenID == "" {
+		return errors.New("token ID must not be empty")
+	}
+
+	now := time.Now()
+	for revokedID, revokedExpiresAt := range user.RevokedTokens {
+		if !revokedExpiresAt.IsZero() && !now.Before(revokedExpiresAt) {
+			delete(user.RevokedTokens, revokedID)
+		}
+	}
+	user.RevokedTokens[tokenID] = expiresAt
+
+	if err := m.ModifyUser(user); err != nil {
+		return fmt.Errorf("unable to revoke token of user \"%s\": %w", name, err)
+	}
+
+	return nil
+}
+
+// RevokeAllTokens revokes all the JWTs issued to the user until now.
+func (m *UserManager) RevokeAllTokens(name string) error {
+	user, err := m.User(name)
+	if err != nil {
+		return fmt.Errorf("unable to revoke tokens of user \"%s\": user does not exist", name)
+	}
+
+	revokeAllTokens(user, time.Now())
+
+	if err := m.ModifyUser(user); err != nil {
+		return fmt.Errorf("unable to revoke tokens of user \"%s\": %w", name, err)
+	}
+
+	return nil
+}
+
+// revokeAllTokens revokes the tokens issued until now. The JWTs have the precision of a second,
+// thus the tokens issued within the current second are revoked as well.
+// The revocation list is not needed anymore, because all the listed tokens were issued before.
+func revokeAllTokens(user *User, now time.Time) {
+	user.TokensNotBefore = now.Truncate(time.Second)
+	user.RevokedTokens = make(map[string]time.Time)
+}
+
+// IsTokenRevoked returns true, if the JWT of the user issued at the given time was revoked.
+func (m *UserManager) IsTokenRevoked(name, tokenID string, issuedAt time.Time) bool {
+	user, err := m.User(name)
+	if err != nil {
+		return false
+	}
+
+	if !user.TokensNotBefore.IsZero() && !issuedAt.After(user.TokensNotBefore) {
+		return true
+	}
+
+	_, revoked := user.RevokedTokens[tokenID]
+	return revoked
+}
+
 // RemoveUser removes a user from the user manager.
 func (m *UserManager) RemoveUser(name string) error {
 	return m.onChangeMap.Delete(util.ComparableString(name))
@@ -28,6 +28,7 @@ var Processor = Contract.Processor(nil,
 	FuncTransferAccountToChain.WithHandler(transferAccountToChain),
 	FuncTransferAllowanceTo.WithHandler(transferAllowanceTo),
 	FuncWithdraw.WithHandler(withdraw),
+	FuncSetSponsorAllowance.WithHandler(setSponsorAllowance),
 
 	// Kept for compatibility
 	FuncFoundryCreateNew.WithHandler(foundryCreateNew),
@@ -50,6 +51,7 @@ var Processor = Contract.Processor(nil,
 	ViewNativeToken.WithHandler(viewFoundryOutput),
 	ViewGetAccountNonce.WithHandler(viewGetAccountNonce),
 	ViewGetNativeTokenIDRegistry.WithHandler(viewGetNativeTokenIDRegistry),
+	ViewGetSponsorAllowance.WithHandler(viewGetSponsorAllowance),
 	ViewNFTData.WithHandler(viewNFTData),
 	ViewTotalAssets.WithHandler(viewTotalAssets),
 )
@@ -178,3 +178,16 @@ func viewNFTData(ctx isc.SandboxView) dict.Dict {
 		ParamNFTData: data.Bytes(),
 	}
 }
+
+// viewGetSponsorAllowance returns the amount of base tokens the sponsor is still willing to pay
+// for the gas fees of the sender's requests
+// Params:
+// - ParamSponsor AgentID
+// - ParamAgentID (optional -- default: caller)
+// Returns: {ParamSponsorAllowance: uint64}
+func viewGetSponsorAllowance(ctx isc.SandboxView) dict.Dict {
+	sponsor := ctx.Params().MustGetAgentID(ParamSponsor)
+	sender := ctx.Params().MustGetAgentID(ParamAgentID, ctx.Caller())
+	allowance := GetSponsorAllowance(ctx.StateR(), sponsor, sender, ctx.ChainID())
+	return dict.Dict{ParamSponsorAllowance: codec.EncodeUint64(allowance)}
+}
@@ -1,11 +1,17 @@
 package accounts
 
 import (
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/isc/coreutil"
 )
 
 var Contract = coreutil.NewContract(coreutil.CoreContractAccounts)
 
+// SchemaVersionGasSponsorship is the first schema version that lets a sponsor
+// pay the gas fee of off-ledger requests. It is reached by applying the m006
+// migration; on older chains the sender always pays.
+const SchemaVersionGasSponsorship isc.SchemaVersion = 6
+
 var (
 	// Funcs
 	FuncDeposit = coreutil.Func("deposit")
@@ -21,6 +27,7 @@ var (
 	FuncTransferAccountToChain = coreutil.Func("transferAccountToChain")
 	FuncTransferAllowanceTo    = coreutil.Func("transferAllowanceTo")
 	FuncWithdraw               = coreutil.Func("withdraw")
+	FuncSetSponsorAllowance    = coreutil.Func("setSponsorAllowance")
 	// TODO implement grant/claim protocol of moving ownership of the foundry
 	//  Including ownership of the foundry by the common account/chain owner
 
@@ -39,6 +46,7 @@ var (
 
 	ViewGetAccountNonce          = coreutil.ViewFunc("getAccountNonce")
 	ViewGetNativeTokenIDRegistry = coreutil.ViewFunc("getNativeTokenIDRegistry")
+	ViewGetSponsorAllowance      = coreutil.ViewFunc("getSponsorAllowance")
 	ViewNFTData                  = coreutil.ViewFunc("nftData")
 	ViewTotalAssets              = coreutil.ViewFunc("totalAssets")
 )
@@ -64,6 +72,8 @@ const (
 	ParamNFTImmutableData       = "I"
 	ParamNFTWithdrawOnMint      = "w"
 	ParamMintID                 = "D"
+	ParamSponsor                = "sp"
+	ParamSponsorAllowance       = "sa"
 	ParamNativeTokenID          = "N"
 	ParamSupplyDeltaAbs         = "d"
 	ParamTokenScheme            = "t"
@@ -24,6 +24,7 @@ var (
 	ErrTooManyNFTsInAllowance               = coreerrors.Register("expected at most 1 NFT in allowance").Create()
 	ErrNFTIDNotFound                        = coreerrors.Register("NFTID not found").Create()
 	ErrImmutableMetadataInvalid             = coreerrors.Register("IRC27 metadata is invalid: '%s'")
+	ErrGasSponsorshipDisabled               = coreerrors.Register("gas sponsorship is not enabled on this chain").Create()
 )
 
 const (
@@ -90,6 +91,10 @@ const (
 	// KeyNewNFTs stores an array of <NFTID>, containing the newly created NFTs that need filling out the OutputID
 	// Covered in: TestDepositNFTWithMinStorageDeposit
 	KeyNewNFTs = "NN"
+
+	// PrefixSponsorAllowances | <sponsor accountID> stores a map of <sender accountID> => base tokens (uint64)
+	// Covered in: TestSponsoredRequest
+	PrefixSponsorAllowances = "S"
 )
 
 func AccountKey(agentID isc.AgentID, chainID isc.ChainID) kv.Key {
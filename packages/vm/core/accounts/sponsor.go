@@ -0,0 +1,65 @@
+package accounts
+
+import (
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/collections"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
+)
+
+func sponsorAllowancesMapKey(sponsor isc.AgentID, chainID isc.ChainID) string {
+	return PrefixSponsorAllowances + string(AccountKey(sponsor, chainID))
+}
+
+func sponsorAllowancesMap(state kv.KVStore, sponsor isc.AgentID, chainID isc.ChainID) *collections.Map {
+	return collections.NewMap(state, sponsorAllowancesMapKey(sponsor, chainID))
+}
+
+func sponsorAllowancesMapR(state kv.KVStoreReader, sponsor isc.AgentID, chainID isc.ChainID) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, sponsorAllowancesMapKey(sponsor, chainID))
+}
+
+// GetSponsorAllowance returns the amount of base tokens the sponsor is still willing to pay
+// for the gas fees of the sender's requests
+func GetSponsorAllowance(state kv.KVStoreReader, sponsor, sender isc.AgentID, chainID isc.ChainID) uint64 {
+	data := sponsorAllowancesMapR(state, sponsor, chainID).GetAt([]byte(AccountKey(sender, chainID)))
+	return codec.MustDecodeUint64(data, 0)
+}
+
+// SetSponsorAllowance sets the amount of base tokens the sponsor is willing to pay for the
+// gas fees of the sender's requests. Zero amount revokes the sponsorship
+func SetSponsorAllowance(state kv.KVStore, sponsor, sender isc.AgentID, chainID isc.ChainID, amount uint64) {
+	allowances := sponsorAllowancesMap(state, sponsor, chainID)
+	if amount == 0 {
+		allowances.DelAt([]byte(AccountKey(sender, chainID)))
+		return
+	}
+	allowances.SetAt([]byte(AccountKey(sender, chainID)), codec.EncodeUint64(amount))
+}
+
+// DebitFromSponsorAllowance decreases the sponsor allowance of the sender by the gas fee paid by the sponsor.
+// The allowance can't go below zero, it is removed when exhausted
+func DebitFromSponsorAllowance(state kv.KVStore, sponsor, sender isc.AgentID, chainID isc.ChainID, amount uint64) {
+	allowance := GetSponsorAllowance(state, sponsor, sender, chainID)
+	if amount > allowance {
+		amount = allowance
+	}
+	SetSponsorAllowance(state, sponsor, sender, chainID, allowance-amount)
+}
+
+// setSponsorAllowance sets the amount of base tokens the caller is willing to pay for the gas fees
+// of the requests of the specified sender. The caller can be a user or a contract
+// Params:
+// - ParamAgentID. AgentID of the sponsored sender. Required
+// - ParamSponsorAllowance. Amount of base tokens, zero revokes the sponsorship. Optional, default 0
+func setSponsorAllowance(ctx isc.Sandbox) dict.Dict {
+	if ctx.SchemaVersion() < SchemaVersionGasSponsorship {
+		panic(ErrGasSponsorshipDisabled)
+	}
+	sender := ctx.Params().MustGetAgentID(ParamAgentID)
+	amount := ctx.Params().MustGetUint64(ParamSponsorAllowance, 0)
+	SetSponsorAllowance(ctx.State(), ctx.Caller(), sender, ctx.ChainID(), amount)
+	ctx.Log().Debugf("accounts.setSponsorAllowance: sponsor: %s, sender: %s, amount: %d", ctx.Caller(), sender, amount)
+	return nil
+}
@@ -30,6 +30,14 @@ func (sa *StateAccess) Nonce(agentID isc.AgentID, chainID isc.ChainID) uint64 {
 	return AccountNonce(sa.state, agentID, chainID)
 }
 
+func (sa *StateAccess) BaseTokensBalance(agentID isc.AgentID, chainID isc.ChainID) uint64 {
+	return GetBaseTokensBalance(sa.chainState.SchemaVersion(), sa.state, agentID, chainID)
+}
+
+func (sa *StateAccess) SponsorAllowance(sponsor, sender isc.AgentID, chainID isc.ChainID) uint64 {
+	return GetSponsorAllowance(sa.state, sponsor, sender, chainID)
+}
+
 func (sa *StateAccess) AccountExists(agentID isc.AgentID, chainID isc.ChainID) bool {
 	return AccountExists(sa.state, agentID, chainID)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/kv"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/collections"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/gas"
 )
 
@@ -27,6 +28,15 @@ func TestSerdeRequestReceipt(t *testing.T) {
 	back, err := RequestReceiptFromBytes(forward, rec.BlockIndex, rec.RequestIndex)
 	require.NoError(t, err)
 	require.EqualValues(t, forward, back.Bytes())
+
+	rec.ContractFees = &governance.ContractFeesRecord{OwnerFee: 10, ValidatorFee: 5}
+	rec.GasBurnLog = gas.NewGasBurnLog()
+	forward = rec.Bytes()
+	back, err = RequestReceiptFromBytes(forward, rec.BlockIndex, rec.RequestIndex)
+	require.NoError(t, err)
+	require.EqualValues(t, rec.ContractFees, back.ContractFees)
+	require.NotNil(t, back.GasBurnLog)
+	require.EqualValues(t, forward, back.Bytes())
 }
 
 func createRequestLookupKeys(blocks uint32, requests uint16) []byte {
@@ -3,6 +3,7 @@ package blocklog
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/kv"
@@ -190,3 +191,15 @@ func ReceiptsFromViewCallResult(res dict.Dict) ([]*RequestReceipt, error) {
 	}
 	return ret, nil
 }
+
+// GetDueScheduledRequests returns the synthetic requests of the scheduled calls which are due
+// in the block with the given timestamp and index, as injected by the VM in that block
+func GetDueScheduledRequests(stateReader kv.KVStoreReader, chainID isc.ChainID, timestamp time.Time, blockIndex uint32) []*isc.ScheduledRequest {
+	partition := subrealm.NewReadOnly(stateReader, kv.Key(Contract.Hname().Bytes()))
+	scheduleIDs := DueScheduledRequests(partition, timestamp, blockIndex)
+	ret := make([]*isc.ScheduledRequest, len(scheduleIDs))
+	for i, scheduleID := range scheduleIDs {
+		ret[i] = GetScheduledRequest(partition, scheduleID).Request(chainID, scheduleID, blockIndex)
+	}
+	return ret
+}
@@ -20,8 +20,11 @@ var Processor = Contract.Processor(nil,
 	ViewGetRequestReceiptsForBlock.WithHandler(viewGetRequestReceiptsForBlock),
 	ViewIsRequestProcessed.WithHandler(viewIsRequestProcessed),
 	ViewHasUnprocessable.WithHandler(viewHasUnprocessable),
+	ViewGetScheduledRequest.WithHandler(viewGetScheduledRequest),
 
 	FuncRetryUnprocessable.WithHandler(retryUnprocessable),
+	FuncScheduleRequest.WithHandler(scheduleRequest),
+	FuncCancelScheduledRequest.WithHandler(cancelScheduledRequest),
 )
 
 var ErrBlockNotFound = coreerrors.Register("Block not found").Create()
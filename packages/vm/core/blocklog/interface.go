@@ -3,14 +3,22 @@
 package blocklog
 
 import (
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/isc/coreutil"
 )
 
 var Contract = coreutil.NewContract(coreutil.CoreContractBlocklog)
 
+// SchemaVersionScheduledRequests is the first schema version that accepts
+// scheduled calls and runs the due ones in each block. It is reached by
+// applying the m005 migration.
+const SchemaVersionScheduledRequests isc.SchemaVersion = 5
+
 var (
 	// Funcs
-	FuncRetryUnprocessable = coreutil.Func("retryUnprocessable")
+	FuncRetryUnprocessable     = coreutil.Func("retryUnprocessable")
+	FuncScheduleRequest        = coreutil.Func("scheduleRequest")
+	FuncCancelScheduledRequest = coreutil.Func("cancelScheduledRequest")
 
 	// Views
 	ViewGetBlockInfo               = coreutil.ViewFunc("getBlockInfo")
@@ -21,6 +29,7 @@ var (
 	ViewGetEventsForRequest        = coreutil.ViewFunc("getEventsForRequest")
 	ViewGetEventsForBlock          = coreutil.ViewFunc("getEventsForBlock")
 	ViewHasUnprocessable           = coreutil.ViewFunc("hasUnprocessable")
+	ViewGetScheduledRequest        = coreutil.ViewFunc("getScheduledRequest")
 )
 
 // request parameters
@@ -37,6 +46,14 @@ const (
 	ParamEvent                      = "e"
 	ParamStateControllerAddress     = "s"
 	ParamUnprocessableRequestExists = "x"
+	ParamEntryPoint                 = "ep"
+	ParamGasBudget                  = "g"
+	ParamScheduleBlock              = "sb"
+	ParamScheduleID                 = "si"
+	ParamScheduleInterval           = "sv"
+	ParamScheduleParams             = "sp"
+	ParamScheduleTime               = "st"
+	ParamScheduledRequest           = "sr"
 )
 
 const (
@@ -68,4 +85,22 @@ const (
 	// Temporary list of unprocessable requests that need updating the outputID field
 	// Covered in: TestUnprocessableWithPruning
 	PrefixNewUnprocessableRequests = "U"
+
+	// Map of scheduleID => ScheduledRecord
+	// Covered in: TestScheduledRequests
+	PrefixScheduledRequests = "s"
+
+	// Last allocated scheduleID (uint32)
+	// Covered in: TestScheduledRequests
+	KeyScheduleCounter = "S"
+
+	// Index of the calls scheduled by block: dueBlock | scheduleID => scheduleID
+	// (big endian, so that the keys are ordered by due block)
+	// Covered in: TestScheduledRequests
+	PrefixScheduledByBlock = "n"
+
+	// Index of the calls scheduled by time: dueTime (unix nanoseconds) | scheduleID => scheduleID
+	// (big endian, so that the keys are ordered by due time)
+	// Covered in: TestScheduledRequests
+	PrefixScheduledByTime = "t"
 )
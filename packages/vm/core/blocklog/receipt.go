@@ -11,6 +11,7 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/kv/subrealm"
 	"github.com/nnikolash/wasp-types-exported/packages/state"
 	"github.com/nnikolash/wasp-types-exported/packages/util/rwutil"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/gas"
 )
 
@@ -24,6 +25,8 @@ type RequestReceipt struct {
 	GasBurned     uint64                 `json:"gasBurned"`
 	GasFeeCharged uint64                 `json:"gasFeeCharged"`
 	SDCharged     uint64                 `json:"storageDepositCharged"`
+	// fee record of the target contract applied to the fee, nil if the chain fee policy was in effect
+	ContractFees *governance.ContractFeesRecord `json:"contractFees"`
 	// not persistent
 	BlockIndex   uint32       `json:"blockIndex"`
 	RequestIndex uint16       `json:"requestIndex"`
@@ -66,6 +69,13 @@ func (rec *RequestReceipt) Bytes() []byte {
 	return rwutil.WriteToBytes(rec)
 }
 
+// flags of the optional parts of the serialized receipt, the error flag is compatible
+// with the former bool encoding
+const (
+	receiptFlagError        = 0x01
+	receiptFlagContractFees = 0x02
+)
+
 func (rec *RequestReceipt) Read(r io.Reader) error {
 	rr := rwutil.NewReader(r)
 	rec.GasBudget = rr.ReadGas64()
@@ -73,11 +83,15 @@ func (rec *RequestReceipt) Read(r io.Reader) error {
 	rec.GasFeeCharged = rr.ReadGas64()
 	rec.SDCharged = rr.ReadAmount64()
 	rec.Request = isc.RequestFromReader(rr)
-	hasError := rr.ReadBool()
-	if hasError {
+	flags := rr.ReadByte()
+	if flags&receiptFlagError != 0 {
 		rec.Error = new(isc.UnresolvedVMError)
 		rr.Read(rec.Error)
 	}
+	if flags&receiptFlagContractFees != 0 {
+		rec.ContractFees = new(governance.ContractFeesRecord)
+		rr.Read(rec.ContractFees)
+	}
 	if len(rr.Bytes()) != 0 {
 		rec.GasBurnLog = new(gas.BurnLog)
 		rr.Read(rec.GasBurnLog)
@@ -93,10 +107,20 @@ func (rec *RequestReceipt) Write(w io.Writer) error {
 	ww.WriteGas64(rec.GasFeeCharged)
 	ww.WriteAmount64(rec.SDCharged)
 	ww.Write(rec.Request)
-	ww.WriteBool(rec.Error != nil)
+	var flags byte
+	if rec.Error != nil {
+		flags |= receiptFlagError
+	}
+	if rec.ContractFees != nil {
+		flags |= receiptFlagContractFees
+	}
+	ww.WriteByte(flags)
 	if rec.Error != nil {
 		ww.Write(rec.Error)
 	}
+	if rec.ContractFees != nil {
+		ww.Write(rec.ContractFees)
+	}
 	if rec.GasBurnLog != nil {
 		ww.Write(rec.GasBurnLog)
 	}
@@ -109,6 +133,9 @@ func (rec *RequestReceipt) String() string {
 	ret += fmt.Sprintf("Block/Request index: %d / %d\n", rec.BlockIndex, rec.RequestIndex)
 	ret += fmt.Sprintf("Gas budget / burned / fee charged: %d / %d /%d\n", rec.GasBudget, rec.GasBurned, rec.GasFeeCharged)
 	ret += fmt.Sprintf("Storage deposit charged: %d\n", rec.SDCharged)
+	if rec.ContractFees != nil {
+		ret += fmt.Sprintf("Contract fees owner / validator: %d / %d\n", rec.ContractFees.OwnerFee, rec.ContractFees.ValidatorFee)
+	}
 	ret += fmt.Sprintf("Call data: %s\n", rec.Request)
 	ret += fmt.Sprintf("burn log: %s\n", rec.GasBurnLog)
 	return ret
@@ -118,6 +145,8 @@ func (rec *RequestReceipt) Short() string {
 	prefix := "tx"
 	if rec.Request.IsOffLedger() {
 		prefix = "api"
+	} else if _, ok := rec.Request.(*isc.ScheduledRequest); ok {
+		prefix = "scheduled"
 	}
 
 	ret := fmt.Sprintf("%s/%s", prefix, rec.Request.ID())
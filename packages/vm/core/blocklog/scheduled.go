@@ -0,0 +1,332 @@
+package blocklog
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/collections"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
+	"github.com/nnikolash/wasp-types-exported/packages/util/rwutil"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/errors/coreerrors"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/gas"
+)
+
+// MaxScheduledRequestsPerBlock is the maximum number of due scheduled calls the VM injects in a block.
+// The remaining ones stay due and run in the next blocks
+const MaxScheduledRequestsPerBlock = 16
+
+// MinScheduledPrepaid is the minimum amount of base tokens prepaid for the gas of a scheduled call.
+// A call is removed as soon as its prepaid gas gets below it, so every pending entry keeps a deposit
+const MinScheduledPrepaid = 100_000
+
+// ScheduledRecord is a call scheduled to run at a future time or block, optionally recurring
+type ScheduledRecord struct {
+	// Owner scheduled the call, it is the sender of the scheduled requests
+	Owner      isc.AgentID
+	Contract   isc.Hname
+	EntryPoint isc.Hname
+	Params     dict.Dict
+	GasBudget  uint64
+	// Prepaid is the amount of base tokens left for the gas fees, kept in the blocklog account
+	Prepaid uint64
+	// DueTime is the time the call is due, zero if the call is scheduled by block
+	DueTime time.Time
+	// DueBlock is the index of the block the call is due, zero if the call is scheduled by time
+	DueBlock uint32
+	// Interval between the runs of a recurring call, nanoseconds for a call scheduled by time
+	// or number of blocks for a call scheduled by block. Zero for a call that runs once
+	Interval uint64
+}
+
+func ScheduledRecordFromBytes(data []byte) (*ScheduledRecord, error) {
+	return rwutil.ReadFromBytes(data, new(ScheduledRecord))
+}
+
+func (rec *ScheduledRecord) Bytes() []byte {
+	return rwutil.WriteToBytes(rec)
+}
+
+func (rec *ScheduledRecord) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	rec.Owner = isc.AgentIDFromReader(rr)
+	rr.Read(&rec.Contract)
+	rr.Read(&rec.EntryPoint)
+	rec.Params = dict.New()
+	rr.Read(&rec.Params)
+	rec.GasBudget = rr.ReadGas64()
+	rec.Prepaid = rr.ReadAmount64()
+	rec.DueTime = time.Time{}
+	if dueTime := rr.ReadInt64(); dueTime != 0 {
+		rec.DueTime = time.Unix(0, dueTime)
+	}
+	rec.DueBlock = rr.ReadUint32()
+	rec.Interval = rr.ReadUint64()
+	return rr.Err
+}
+
+func (rec *ScheduledRecord) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	isc.AgentIDToWriter(ww, rec.Owner)
+	ww.Write(&rec.Contract)
+	ww.Write(&rec.EntryPoint)
+	ww.Write(&rec.Params)
+	ww.WriteGas64(rec.GasBudget)
+	ww.WriteAmount64(rec.Prepaid)
+	dueTime := int64(0)
+	if !rec.DueTime.IsZero() {
+		dueTime = rec.DueTime.UnixNano()
+	}
+	ww.WriteInt64(dueTime)
+	ww.WriteUint32(rec.DueBlock)
+	ww.WriteUint64(rec.Interval)
+	return ww.Err
+}
+
+// IsDue returns whether the call is due in the block with the given timestamp and index
+func (rec *ScheduledRecord) IsDue(timestamp time.Time, blockIndex uint32) bool {
+	if rec.DueBlock != 0 {
+		return rec.DueBlock <= blockIndex
+	}
+	return !rec.DueTime.After(timestamp)
+}
+
+// advance moves the due time or block of a recurring call past the given timestamp or block index.
+// Runs missed in the meantime are skipped. Returns false if the call does not recur
+func (rec *ScheduledRecord) advance(timestamp time.Time, blockIndex uint32) bool {
+	if rec.Interval == 0 {
+		return false
+	}
+	if rec.DueBlock != 0 {
+		missed := uint64(blockIndex-rec.DueBlock)/rec.Interval + 1
+		rec.DueBlock += uint32(missed * rec.Interval)
+		return true
+	}
+	interval := time.Duration(rec.Interval)
+	missed := timestamp.Sub(rec.DueTime)/interval + 1
+	rec.DueTime = rec.DueTime.Add(missed * interval)
+	return true
+}
+
+// Request returns the synthetic request which runs the call in the block with the given index
+func (rec *ScheduledRecord) Request(chainID isc.ChainID, scheduleID uint32, blockIndex uint32) *isc.ScheduledRequest {
+	return isc.NewScheduledRequest(
+		chainID,
+		scheduleID,
+		blockIndex,
+		rec.Owner,
+		rec.Contract,
+		rec.EntryPoint,
+		rec.Params,
+		rec.GasBudget,
+	)
+}
+
+// ScheduledPrepaidAccount is the account which keeps the prepaid gas of all scheduled calls.
+// It is separate from the common account used by the other core contracts
+func ScheduledPrepaidAccount(chainID isc.ChainID) isc.AgentID {
+	return isc.NewContractAgentID(chainID, Contract.Hname())
+}
+
+func ScheduledRequestsMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, PrefixScheduledRequests)
+}
+
+func ScheduledRequestsMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, PrefixScheduledRequests)
+}
+
+// scheduledIndexKey is the key of the call in the index of the calls ordered by due block or time
+func scheduledIndexKey(rec *ScheduledRecord, scheduleID uint32) kv.Key {
+	var key []byte
+	if rec.DueBlock != 0 {
+		key = binary.BigEndian.AppendUint32([]byte(PrefixScheduledByBlock), rec.DueBlock)
+	} else {
+		key = binary.BigEndian.AppendUint64([]byte(PrefixScheduledByTime), uint64(rec.DueTime.UnixNano()))
+	}
+	return kv.Key(binary.BigEndian.AppendUint32(key, scheduleID))
+}
+
+func setScheduledRequest(state kv.KVStore, scheduleID uint32, rec *ScheduledRecord) {
+	ScheduledRequestsMap(state).SetAt(codec.EncodeUint32(scheduleID), rec.Bytes())
+	state.Set(scheduledIndexKey(rec, scheduleID), codec.EncodeUint32(scheduleID))
+}
+
+// ScheduleRequest saves the scheduled call and returns its ID
+func ScheduleRequest(state kv.KVStore, rec *ScheduledRecord) uint32 {
+	scheduleID := codec.MustDecodeUint32(state.Get(KeyScheduleCounter), 0) + 1
+	state.Set(KeyScheduleCounter, codec.EncodeUint32(scheduleID))
+	setScheduledRequest(state, scheduleID, rec)
+	return scheduleID
+}
+
+// GetScheduledRequest returns the scheduled call, nil if it does not exist
+func GetScheduledRequest(state kv.KVStoreReader, scheduleID uint32) *ScheduledRecord {
+	data := ScheduledRequestsMapR(state).GetAt(codec.EncodeUint32(scheduleID))
+	if data == nil {
+		return nil
+	}
+	rec, err := ScheduledRecordFromBytes(data)
+	if err != nil {
+		panic(err)
+	}
+	return rec
+}
+
+func RemoveScheduledRequest(state kv.KVStore, scheduleID uint32) {
+	rec := GetScheduledRequest(state, scheduleID)
+	if rec == nil {
+		return
+	}
+	ScheduledRequestsMap(state).DelAt(codec.EncodeUint32(scheduleID))
+	state.Del(scheduledIndexKey(rec, scheduleID))
+}
+
+// DueScheduledRequests returns the IDs of the scheduled calls due in the block with the given timestamp and index,
+// limited to MaxScheduledRequestsPerBlock. The calls scheduled by block come first, each kind in due order.
+// Only the index entries of the due calls are read, plus the first one which is not due yet
+func DueScheduledRequests(state kv.KVStoreReader, timestamp time.Time, blockIndex uint32) []uint32 {
+	var due []uint32
+	collect := func(prefix string, isDue func(dueKey []byte) bool) {
+		state.IterateKeysSorted(kv.Key(prefix), func(key kv.Key) bool {
+			if len(due) >= MaxScheduledRequestsPerBlock || !isDue([]byte(key[len(prefix):])) {
+				return false
+			}
+			due = append(due, binary.BigEndian.Uint32([]byte(key[len(key)-4:])))
+			return true
+		})
+	}
+	collect(PrefixScheduledByBlock, func(dueKey []byte) bool {
+		return binary.BigEndian.Uint32(dueKey) <= blockIndex
+	})
+	collect(PrefixScheduledByTime, func(dueKey []byte) bool {
+		return int64(binary.BigEndian.Uint64(dueKey)) <= timestamp.UnixNano()
+	})
+	return due
+}
+
+// DebitScheduledPrepaid decreases the prepaid gas of the scheduled call by the gas fee of its run.
+// The prepaid amount can't go below zero
+func DebitScheduledPrepaid(state kv.KVStore, scheduleID uint32, amount uint64) {
+	rec := GetScheduledRequest(state, scheduleID)
+	if rec == nil {
+		return
+	}
+	rec.Prepaid -= min(amount, rec.Prepaid)
+	setScheduledRequest(state, scheduleID, rec)
+}
+
+// AdvanceScheduledRequest reschedules a recurring call after it has run, or removes the call when
+// it does not recur or its prepaid gas is below MinScheduledPrepaid. Returns the owner and the prepaid base tokens
+// to refund when the call is removed
+func AdvanceScheduledRequest(state kv.KVStore, scheduleID uint32, timestamp time.Time, blockIndex uint32) (owner isc.AgentID, refund uint64) {
+	rec := GetScheduledRequest(state, scheduleID)
+	if rec == nil {
+		return nil, 0
+	}
+	indexKey := scheduledIndexKey(rec, scheduleID)
+	if rec.Prepaid < MinScheduledPrepaid || !rec.advance(timestamp, blockIndex) {
+		RemoveScheduledRequest(state, scheduleID)
+		return rec.Owner, rec.Prepaid
+	}
+	// the due block or time has moved, so has the index entry
+	state.Del(indexKey)
+	setScheduledRequest(state, scheduleID, rec)
+	return rec.Owner, 0
+}
+
+// ---- entrypoints
+
+var (
+	ErrScheduleNotFound   = coreerrors.Register("scheduled request not found").Create()
+	ErrScheduleWrongOwner = coreerrors.Register("scheduled request can only be cancelled by its owner").Create()
+	ErrScheduleDisabled   = coreerrors.Register("scheduled requests are not enabled on this chain").Create()
+)
+
+// scheduleRequest schedules a call to run at a future time or block, with the gas prepaid by the caller.
+// The base tokens in the allowance are kept as prepaid gas for the runs of the call, they must cover
+// at least MinScheduledPrepaid and the gas fee of one run
+// Params:
+// - ParamContractHname Hname of the target contract
+// - ParamEntryPoint Hname of the target entry point
+// - ParamScheduleParams dict.Dict (optional)
+// - ParamGasBudget uint64 gas budget of each run
+// - ParamScheduleTime time the call is due, or
+// - ParamScheduleBlock index of the block the call is due
+// - ParamScheduleInterval uint64 (optional) interval of a recurring call, nanoseconds or number of blocks
+// Returns: {ParamScheduleID: uint32}
+func scheduleRequest(ctx isc.Sandbox) dict.Dict {
+	if ctx.SchemaVersion() < SchemaVersionScheduledRequests {
+		panic(ErrScheduleDisabled)
+	}
+	params := ctx.Params()
+	rec := &ScheduledRecord{
+		Owner:      ctx.Caller(),
+		Contract:   params.MustGetHname(ParamContractHname),
+		EntryPoint: params.MustGetHname(ParamEntryPoint),
+		Params:     dict.New(),
+		GasBudget:  params.MustGetUint64(ParamGasBudget),
+		DueTime:    params.MustGetTime(ParamScheduleTime, time.Time{}),
+		DueBlock:   params.MustGetUint32(ParamScheduleBlock, 0),
+		Interval:   params.MustGetUint64(ParamScheduleInterval, 0),
+	}
+	if data := params.MustGetBytes(ParamScheduleParams, nil); data != nil {
+		var err error
+		rec.Params, err = dict.FromBytes(data)
+		ctx.RequireNoError(err)
+	}
+	ctx.Requiref(rec.DueTime.IsZero() != (rec.DueBlock == 0), "either the time or the block of the scheduled call must be specified")
+	ctx.Requiref(rec.DueTime.IsZero() || (rec.DueTime.UnixNano() > 0 && rec.DueTime.Before(time.Unix(0, math.MaxInt64))),
+		"time of the scheduled call is out of range")
+	ctx.Requiref(rec.DueTime.IsZero() || rec.Interval <= math.MaxInt64, "interval of the scheduled call is too big")
+	ctx.Requiref(rec.DueBlock == 0 || rec.Interval <= math.MaxUint32, "interval of the scheduled call is too big")
+	ctx.Requiref(rec.GasBudget > 0, "gas budget of the scheduled call must be specified")
+
+	rec.Prepaid = ctx.AllowanceAvailable().BaseTokens
+	minPrepaid := max(MinScheduledPrepaid, gas.FeeFromGasWithGasPerToken(rec.GasBudget, ctx.ChainInfo().GasFeePolicy.GasPerToken))
+	ctx.Requiref(rec.Prepaid >= minPrepaid, "at least %d base tokens for the gas of the scheduled call must be provided in the allowance", minPrepaid)
+	// funds transferred to a core contract land in the common account, move them out of it
+	prepaid := isc.NewAssetsBaseTokens(rec.Prepaid)
+	ctx.TransferAllowedFunds(ctx.AccountID(), prepaid)
+	ctx.Privileged().MustMoveBetweenAccounts(ctx.AccountID(), ScheduledPrepaidAccount(ctx.ChainID()), prepaid)
+
+	scheduleID := ScheduleRequest(ctx.State(), rec)
+	ctx.Log().Debugf("blocklog.scheduleRequest: id: %d, owner: %s, target: %s.%s", scheduleID, rec.Owner, rec.Contract, rec.EntryPoint)
+	return dict.Dict{ParamScheduleID: codec.EncodeUint32(scheduleID)}
+}
+
+// cancelScheduledRequest removes a scheduled call and refunds the remaining prepaid gas to its owner
+// Params:
+// - ParamScheduleID uint32
+func cancelScheduledRequest(ctx isc.Sandbox) dict.Dict {
+	scheduleID := ctx.Params().MustGetUint32(ParamScheduleID)
+	rec := GetScheduledRequest(ctx.StateR(), scheduleID)
+	if rec == nil {
+		panic(ErrScheduleNotFound)
+	}
+	if !rec.Owner.Equals(ctx.Caller()) {
+		panic(ErrScheduleWrongOwner)
+	}
+	RemoveScheduledRequest(ctx.State(), scheduleID)
+	if rec.Prepaid > 0 {
+		ctx.Privileged().MustMoveBetweenAccounts(ScheduledPrepaidAccount(ctx.ChainID()), rec.Owner, isc.NewAssetsBaseTokens(rec.Prepaid))
+	}
+	return nil
+}
+
+// viewGetScheduledRequest returns the scheduled call with the given ID
+// Params:
+// - ParamScheduleID uint32
+// Returns: {ParamScheduledRequest: ScheduledRecord} or empty if not found
+func viewGetScheduledRequest(ctx isc.SandboxView) dict.Dict {
+	scheduleID := ctx.Params().MustGetUint32(ParamScheduleID)
+	rec := GetScheduledRequest(ctx.StateR(), scheduleID)
+	if rec == nil {
+		return nil
+	}
+	return dict.Dict{ParamScheduledRequest: rec.Bytes()}
+}
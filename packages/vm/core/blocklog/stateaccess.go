@@ -4,6 +4,8 @@
 package blocklog
 
 import (
+	"fmt"
+
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/kv"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
@@ -23,6 +25,45 @@ func (sa *StateAccess) BlockInfo(blockIndex uint32) (*BlockInfo, bool) {
 	return GetBlockInfo(sa.state, blockIndex)
 }
 
+// RequestReceiptsInBlock returns the receipts of the requests processed in the block
+func (sa *StateAccess) RequestReceiptsInBlock(blockIndex uint32) ([]*RequestReceipt, error) {
+	if blockIndex == 0 {
+		// block 0 is an empty state
+		return nil, nil
+	}
+	recsBin, found := getRequestLogRecordsForBlockBin(sa.state, blockIndex)
+	if !found {
+		return nil, fmt.Errorf("block index %v does not exist", blockIndex)
+	}
+	ret := make([]*RequestReceipt, len(recsBin))
+	for i, d := range recsBin {
+		rec, err := RequestReceiptFromBytes(d, blockIndex, uint16(i))
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = rec
+	}
+	return ret, nil
+}
+
+// EventsInBlock returns the events issued in the block
+func (sa *StateAccess) EventsInBlock(blockIndex uint32) ([]*isc.Event, error) {
+	blockInfo, found := GetBlockInfo(sa.state, blockIndex)
+	if !found {
+		return nil, fmt.Errorf("block index %v does not exist", blockIndex)
+	}
+	eventsBin := GetEventsByBlockIndex(sa.state, blockIndex, blockInfo.TotalRequests)
+	ret := make([]*isc.Event, len(eventsBin))
+	for i, d := range eventsBin {
+		event, err := isc.EventFromBytes(d)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = event
+	}
+	return ret, nil
+}
+
 func (sa *StateAccess) GetSmartContractEvents(contractID isc.Hname, fromBlock, toBlock uint32) dict.Dict {
 	events := getSmartContractEventsInternal(sa.state, contractID, fromBlock, toBlock)
 	return eventsToDict(events)
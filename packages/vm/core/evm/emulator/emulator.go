@@ -40,6 +40,9 @@ type Context interface {
 	GasLimits() GasLimits
 	BlockKeepAmount() int32
 	MagicContracts() map[common.Address]vm.ISCMagicContract
+	// TypedTxsEnabled returns whether typed (EIP-2930 and EIP-1559)
+	// transactions are accepted.
+	TypedTxsEnabled() bool
 
 	TakeSnapshot() int
 	RevertToSnapshot(int)
@@ -96,24 +99,24 @@ func getConfig(chainID int) *params.ChainConfig {
 }
 
 const (
-	keyStateDB      = "s"
-	keyBlockchainDB = "b"
+	KeyStateDB      = "s"
+	KeyBlockchainDB = "b"
 )
 
 func StateDBSubrealm(store kv.KVStore) kv.KVStore {
-	return subrealm.New(store, keyStateDB)
+	return subrealm.New(store, KeyStateDB)
 }
 
 func StateDBSubrealmR(store kv.KVStoreReader) kv.KVStoreReader {
-	return subrealm.NewReadOnly(store, keyStateDB)
+	return subrealm.NewReadOnly(store, KeyStateDB)
 }
 
 func BlockchainDBSubrealm(store kv.KVStore) kv.KVStore {
-	return subrealm.New(store, keyBlockchainDB)
+	return subrealm.New(store, KeyBlockchainDB)
 }
 
 func BlockchainDBSubrealmR(store kv.KVStoreReader) kv.KVStoreReader {
-	return subrealm.NewReadOnly(store, keyBlockchainDB)
+	return subrealm.NewReadOnly(store, KeyBlockchainDB)
 }
 
 // Init initializes the EVM state with the provided genesis allocation parameters
@@ -299,6 +302,42 @@ func (e *EVMEmulator) SendTransaction(
 	tx *types.Transaction,
 	tracer *tracing.Hooks,
 	addToBlockchain ...bool,
+) (receipt *types.Receipt, result *core.ExecutionResult, err error) {
+	sender, err := types.Sender(e.Signer(), tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+	return e.sendTransaction(tx, sender, nil, tracer, addToBlockchain...)
+}
+
+// SendImpersonatedTransaction executes an unsigned transaction on behalf of the sender.
+func (e *EVMEmulator) SendImpersonatedTransaction(
+	tx *types.Transaction,
+	sender common.Address,
+	tracer *tracing.Hooks,
+	addToBlockchain ...bool,
+) (receipt *types.Receipt, result *core.ExecutionResult, err error) {
+	return e.sendTransaction(tx, sender, func(signer types.Signer) types.Signer {
+		return &impersonatedSigner{Signer: signer, sender: sender}
+	}, tracer, addToBlockchain...)
+}
+
+// impersonatedSigner returns the impersonated sender instead of recovering it from the signature.
+type impersonatedSigner struct {
+	types.Signer
+	sender common.Address
+}
+
+func (s *impersonatedSigner) Sender(*types.Transaction) (common.Address, error) {
+	return s.sender, nil
+}
+
+func (e *EVMEmulator) sendTransaction(
+	tx *types.Transaction,
+	sender common.Address,
+	wrapSigner func(types.Signer) types.Signer,
+	tracer *tracing.Hooks,
+	addToBlockchain ...bool,
 ) (receipt *types.Receipt, result *core.ExecutionResult, err error) {
 	statedbImpl := e.StateDB()
 	var statedb vm.StateDB = statedbImpl
@@ -307,16 +346,15 @@ func (e *EVMEmulator) SendTransaction(
 	}
 	pendingHeader := e.BlockchainDB().GetPendingHeader(e.ctx.Timestamp())
 
-	sender, err := types.Sender(e.Signer(), tx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("invalid transaction: %w", err)
-	}
 	nonce := e.StateDB().GetNonce(sender)
 	if tx.Nonce() != nonce {
 		return nil, nil, fmt.Errorf("invalid transaction nonce: got %d, want %d", tx.Nonce(), nonce)
 	}
 
 	signer := types.MakeSigner(e.chainConfig, pendingHeader.Number, pendingHeader.Time)
+	if wrapSigner != nil {
+		signer = wrapSigner(signer)
+	}
 	msg, err := core.TransactionToMessage(tx, signer, pendingHeader.BaseFee)
 	if err != nil {
 		return nil, nil, err
@@ -377,7 +415,7 @@ func (e *EVMEmulator) MintBlock() {
 }
 
 func (e *EVMEmulator) Signer() types.Signer {
-	return evmutil.Signer(e.chainConfig.ChainID)
+	return evmutil.SignerForTxTypes(e.chainConfig.ChainID, e.ctx.TypedTxsEnabled())
 }
 
 type chainContext struct {
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/nnikolash/wasp-types-exported/packages/evm/evmtest"
+	"github.com/nnikolash/wasp-types-exported/packages/evm/evmutil"
 	"github.com/nnikolash/wasp-types-exported/packages/hashing"
 	"github.com/nnikolash/wasp-types-exported/packages/kv"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
@@ -142,10 +143,11 @@ func sendTransaction(
 }
 
 type context struct {
-	state     dict.Dict
-	bal       map[common.Address]*big.Int
-	snapshots []*context
-	timestamp uint64
+	state         dict.Dict
+	bal           map[common.Address]*big.Int
+	snapshots     []*context
+	timestamp     uint64
+	legacyTxsOnly bool
 }
 
 var _ Context = &context{}
@@ -181,6 +183,10 @@ func (*context) MagicContracts() map[common.Address]vm.ISCMagicContract {
 	return nil
 }
 
+func (ctx *context) TypedTxsEnabled() bool {
+	return !ctx.legacyTxsOnly
+}
+
 func (ctx *context) State() kv.KVStore {
 	return ctx.state
 }
@@ -300,6 +306,41 @@ func TestBlockchain(t *testing.T) {
 	require.NotEmpty(t, emu.StateDB().GetCode(contractAddress))
 }
 
+func TestTypedTxsGatedBySchemaVersion(t *testing.T) {
+	faucet, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	faucetAddress := crypto.PubkeyToAddress(faucet.PublicKey)
+	receiver := common.HexToAddress("0x1234")
+
+	for _, legacyTxsOnly := range []bool{true, false} {
+		ctx := newContext(map[common.Address]*big.Int{
+			faucetAddress: new(big.Int).SetUint64(math.MaxUint64),
+		})
+		ctx.legacyTxsOnly = legacyTxsOnly
+		Init(ctx.State(), evm.DefaultChainID, ctx.GasLimits(), ctx.Timestamp(), map[common.Address]types.Account{})
+		ctx.timestamp++
+		emu := NewEVMEmulator(ctx)
+
+		tx, err := types.SignNewTx(faucet, evmutil.Signer(big.NewInt(int64(evm.DefaultChainID))), &types.DynamicFeeTx{
+			ChainID:   big.NewInt(int64(evm.DefaultChainID)),
+			Nonce:     0,
+			GasTipCap: big.NewInt(0),
+			GasFeeCap: gasPrice,
+			Gas:       params.TxGas,
+			To:        &receiver,
+			Value:     big.NewInt(0),
+		})
+		require.NoError(t, err)
+
+		_, _, err = emu.SendTransaction(tx, nil)
+		if legacyTxsOnly {
+			require.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
 func TestBlockchainPersistence(t *testing.T) {
 	// faucet address with initial supply
 	faucet, err := crypto.GenerateKey()
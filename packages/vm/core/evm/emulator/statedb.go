@@ -33,15 +33,15 @@ func accountKey(prefix kv.Key, addr common.Address) kv.Key {
 	return prefix + kv.Key(addr.Bytes())
 }
 
-func accountNonceKey(addr common.Address) kv.Key {
+func AccountNonceKey(addr common.Address) kv.Key {
 	return accountKey(KeyAccountNonce, addr)
 }
 
-func accountCodeKey(addr common.Address) kv.Key {
+func AccountCodeKey(addr common.Address) kv.Key {
 	return accountKey(KeyAccountCode, addr)
 }
 
-func accountStateKey(addr common.Address, hash common.Hash) kv.Key {
+func AccountStateKey(addr common.Address, hash common.Hash) kv.Key {
 	return accountKey(KeyAccountState, addr) + kv.Key(hash[:])
 }
 
@@ -105,7 +105,7 @@ func (s *StateDB) CreateContract(addr common.Address) {
 
 // GetStorageRoot implements vm.StateDB.
 func (s *StateDB) GetStorageRoot(addr common.Address) common.Hash {
-	return common.BytesToHash([]byte(accountStateKey(addr, common.Hash{})))
+	return common.BytesToHash([]byte(AccountStateKey(addr, common.Hash{})))
 }
 
 // PointCache implements vm.StateDB.
@@ -142,7 +142,7 @@ func (s *StateDB) GetBalance(addr common.Address) *uint256.Int {
 }
 
 func GetNonce(s kv.KVStoreReader, addr common.Address) uint64 {
-	return codec.MustDecodeUint64(s.Get(accountNonceKey(addr)), 0)
+	return codec.MustDecodeUint64(s.Get(AccountNonceKey(addr)), 0)
 }
 
 func (s *StateDB) GetNonce(addr common.Address) uint64 {
@@ -163,7 +163,7 @@ func (s *StateDB) IncNonce(addr common.Address) {
 }
 
 func SetNonce(kv kv.KVStore, addr common.Address, n uint64) {
-	kv.Set(accountNonceKey(addr), codec.Encode(n))
+	kv.Set(AccountNonceKey(addr), codec.Encode(n))
 }
 
 func (s *StateDB) SetNonce(addr common.Address, n uint64, r tracing.NonceChangeReason) {
@@ -176,7 +176,7 @@ func (s *StateDB) GetCodeHash(addr common.Address) common.Hash {
 }
 
 func GetCode(s kv.KVStoreReader, addr common.Address) []byte {
-	return s.Get(accountCodeKey(addr))
+	return s.Get(AccountCodeKey(addr))
 }
 
 func (s *StateDB) GetCode(addr common.Address) []byte {
@@ -185,9 +185,9 @@ func (s *StateDB) GetCode(addr common.Address) []byte {
 
 func SetCode(kv kv.KVStore, addr common.Address, code []byte) {
 	if code == nil {
-		kv.Del(accountCodeKey(addr))
+		kv.Del(AccountCodeKey(addr))
 	} else {
-		kv.Set(accountCodeKey(addr), code)
+		kv.Set(AccountCodeKey(addr), code)
 	}
 }
 
@@ -222,7 +222,7 @@ func (s *StateDB) GetCommittedState(addr common.Address, key common.Hash) common
 }
 
 func GetState(s kv.KVStoreReader, addr common.Address, key common.Hash) common.Hash {
-	return common.BytesToHash(s.Get(accountStateKey(addr, key)))
+	return common.BytesToHash(s.Get(AccountStateKey(addr, key)))
 }
 
 func (s *StateDB) GetState(addr common.Address, key common.Hash) common.Hash {
@@ -230,7 +230,7 @@ func (s *StateDB) GetState(addr common.Address, key common.Hash) common.Hash {
 }
 
 func SetState(kv kv.KVStore, addr common.Address, key, value common.Hash) {
-	kv.Set(accountStateKey(addr, key), value.Bytes())
+	kv.Set(AccountStateKey(addr, key), value.Bytes())
 }
 
 func (s *StateDB) SetState(addr common.Address, key, value common.Hash) common.Hash {
@@ -247,8 +247,8 @@ func (s *StateDB) SelfDestruct(addr common.Address) uint256.Int {
 		return *uint256.NewInt(0)
 	}
 
-	s.kv.Del(accountNonceKey(addr))
-	s.kv.Del(accountCodeKey(addr))
+	s.kv.Del(AccountNonceKey(addr))
+	s.kv.Del(AccountCodeKey(addr))
 
 	keys := make([]kv.Key, 0)
 	s.kv.IterateKeys(accountKey(KeyAccountState, addr), func(key kv.Key) bool {
@@ -307,7 +307,7 @@ func (s *StateDB) Exist(addr common.Address) bool {
 // Exist reports whether the given account exists in state.
 // expects s to be the stateDB state partition
 func Exist(addr common.Address, s kv.KVStoreReader) bool {
-	return s.Has(accountNonceKey(addr))
+	return s.Has(AccountNonceKey(addr))
 }
 
 // Empty returns whether the given account is empty. Empty
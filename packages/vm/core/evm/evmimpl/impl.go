@@ -113,7 +113,8 @@ func applyTransaction(ctx isc.Sandbox) dict.Dict {
 	tx, err := evmtypes.DecodeTransaction(ctx.Params().Get(evm.FieldTransaction))
 	ctx.RequireNoError(err)
 
-	ctx.RequireCaller(isc.NewEthereumAddressAgentID(ctx.ChainID(), evmutil.MustGetSender(tx)))
+	sender := txSender(ctx, tx)
+	ctx.RequireCaller(isc.NewEthereumAddressAgentID(ctx.ChainID(), sender))
 
 	emu := createEmulator(ctx)
 
@@ -122,7 +123,13 @@ func applyTransaction(ctx isc.Sandbox) dict.Dict {
 	}
 
 	// Execute the tx in the emulator.
-	receipt, result, err := emu.SendTransaction(tx, getTracer(ctx), false)
+	var receipt *types.Receipt
+	var result *core.ExecutionResult
+	if isc.IsImpersonatedRequest(ctx.Request()) {
+		receipt, result, err = emu.SendImpersonatedTransaction(tx, sender, getTracer(ctx), false)
+	} else {
+		receipt, result, err = emu.SendTransaction(tx, getTracer(ctx), false)
+	}
 
 	// Any gas burned by the EVM is converted to ISC gas units and burned as
 	// ISC gas.
@@ -168,7 +175,7 @@ func applyTransaction(ctx isc.Sandbox) dict.Dict {
 	// make sure we always store the EVM tx/receipt in the BlockchainDB, even
 	// if the ISC request is reverted
 	ctx.Privileged().OnWriteReceipt(func(evmPartition kv.KVStore, _ uint64, _ *isc.VMError) {
-		saveExecutedTx(evmPartition, chainInfo, tx, receipt)
+		saveExecutedTx(evmPartition, chainInfo, tx, sender, receipt)
 	})
 
 	// revert the changes in the state / txbuilder in case of error
@@ -62,15 +62,30 @@ func saveExecutedTx(
 	evmPartition kv.KVStore,
 	chainInfo *isc.ChainInfo,
 	tx *types.Transaction,
+	sender common.Address,
 	receipt *types.Receipt,
 ) {
 	createBlockchainDB(evmPartition, chainInfo).AddTransaction(tx, receipt)
 	// make sure the nonce is incremented if the state was rolled back by the VM
 	if receipt.Status != types.ReceiptStatusSuccessful {
-		emulator.IncNonce(emulator.StateDBSubrealm(evm.EmulatorStateSubrealm(evmPartition)), evmutil.MustGetSender(tx))
+		emulator.IncNonce(emulator.StateDBSubrealm(evm.EmulatorStateSubrealm(evmPartition)), sender)
 	}
 }
 
+// txSender returns the sender of the EVM tx. The tx of an impersonated request
+// is unsigned, its sender is the sender of the request.
+func txSender(ctx isc.Sandbox, tx *types.Transaction) common.Address {
+	if isc.IsImpersonatedRequest(ctx.Request()) {
+		sender, ok := ctx.Request().SenderAccount().(*isc.EthereumAddressAgentID)
+		if !ok {
+			// not an EVM account, the caller check fails
+			return common.Address{}
+		}
+		return sender.EthAddress()
+	}
+	return evmutil.MustGetSender(tx)
+}
+
 func gasLimits(chainInfo *isc.ChainInfo) emulator.GasLimits {
 	return emulator.GasLimits{
 		Block: gas.EVMBlockGasLimit(chainInfo.GasLimits, &chainInfo.GasFeePolicy.EVMGasRatio),
@@ -124,6 +139,10 @@ func (ctx *emulatorContext) MagicContracts() map[common.Address]vm.ISCMagicContr
 	return newMagicContract(ctx.sandbox)
 }
 
+func (ctx *emulatorContext) TypedTxsEnabled() bool {
+	return ctx.sandbox.SchemaVersion() >= evm.SchemaVersionTypedTxs
+}
+
 func (ctx *emulatorContext) State() kv.KVStore {
 	return evm.EmulatorStateSubrealm(ctx.sandbox.State())
 }
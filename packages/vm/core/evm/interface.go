@@ -4,12 +4,18 @@
 package evm
 
 import (
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/isc/coreutil"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/evm/evmnames"
 )
 
 var Contract = coreutil.NewContract(evmnames.Contract)
 
+// SchemaVersionTypedTxs is the first schema version that accepts typed
+// (EIP-2930 and EIP-1559) EVM transactions. It is reached by applying the
+// m004 migration; older chains only accept legacy transactions.
+const SchemaVersionTypedTxs isc.SchemaVersion = 4
+
 var (
 	// FuncSendTransaction is the main entry point, called by an
 	// evmOffLedgerTxRequest in order to process an Ethereum tx (e.g.
@@ -5,11 +5,17 @@ package governance
 
 import (
 	"io"
+	"math"
+	"math/big"
 
 	"github.com/nnikolash/wasp-types-exported/packages/util/rwutil"
 )
 
-// ContractFeesRecord is a structure which contains the fee information for a contract
+// ContractFeesGasUnit is the amount of burned gas the fees of a ContractFeesRecord are charged for
+const ContractFeesGasUnit = 1000
+
+// ContractFeesRecord is a structure which contains the fee information for a contract.
+// The fees are expressed in base tokens per ContractFeesGasUnit of burned gas.
 type ContractFeesRecord struct {
 	// Chain owner part of the fee. If it is 0, it means chain-global default is in effect
 	OwnerFee uint64
@@ -25,6 +31,35 @@ func (p *ContractFeesRecord) Bytes() []byte {
 	return rwutil.WriteToBytes(p)
 }
 
+// IsEmpty returns true if the chain-global defaults are in effect for both parts of the fee
+func (p *ContractFeesRecord) IsEmpty() bool {
+	return p.OwnerFee == 0 && p.ValidatorFee == 0
+}
+
+// Apply replaces the parts of the fee, calculated with the chain fee policy for the burned gas,
+// by the non-zero parts of the record charged for the same amount of gas
+func (p *ContractFeesRecord) Apply(gasBurned, ownerFee, validatorFee uint64) (sendToOwner, sendToValidator uint64) {
+	if p.OwnerFee != 0 {
+		ownerFee = feeFromGas(gasBurned, p.OwnerFee)
+	}
+	if p.ValidatorFee != 0 {
+		validatorFee = feeFromGas(gasBurned, p.ValidatorFee)
+	}
+	return ownerFee, validatorFee
+}
+
+// feeFromGas rounds up the fee for the burned gas, it saturates instead of overflowing
+func feeFromGas(gasBurned, feePerGasUnit uint64) uint64 {
+	fee := new(big.Int).SetUint64(gasBurned)
+	fee.Mul(fee, new(big.Int).SetUint64(feePerGasUnit))
+	fee.Add(fee, big.NewInt(ContractFeesGasUnit-1))
+	fee.Div(fee, big.NewInt(ContractFeesGasUnit))
+	if !fee.IsUint64() {
+		return math.MaxUint64
+	}
+	return fee.Uint64()
+}
+
 func (p *ContractFeesRecord) Read(r io.Reader) error {
 	rr := rwutil.NewReader(r)
 	p.OwnerFee = rr.ReadAmount64()
@@ -7,8 +7,10 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
+	"github.com/nnikolash/wasp-types-exported/packages/vm"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/errors/coreerrors"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/root"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/gas"
 )
 
@@ -72,3 +74,49 @@ func getGasLimits(ctx isc.SandboxView) dict.Dict {
 		governance.ParamGasLimitsBytes: governance.MustGetGasLimits(ctx.StateR()).Bytes(),
 	}
 }
+
+// setContractFees overrides the fee policy of the chain for the requests to the contract.
+// Zero fees mean the chain-global defaults are in effect, when both fees are zero the record is removed.
+// Input:
+// - governance.ParamContractHname isc.Hname of the contract
+// - governance.ParamOwnerFee uint64 chain owner part of the fee per governance.ContractFeesGasUnit of gas (optional)
+// - governance.ParamValidatorFee uint64 validator part of the fee per governance.ContractFeesGasUnit of gas (optional)
+func setContractFees(ctx isc.Sandbox) dict.Dict {
+	ctx.RequireCallerIsChainOwner()
+
+	params := ctx.Params()
+	hname := params.MustGetHname(governance.ParamContractHname)
+	rec := &governance.ContractFeesRecord{
+		OwnerFee:     params.MustGetUint64(governance.ParamOwnerFee, 0),
+		ValidatorFee: params.MustGetUint64(governance.ParamValidatorFee, 0),
+	}
+
+	contractFees := governance.ContractFeesMap(ctx.State())
+	if rec.IsEmpty() {
+		contractFees.DelAt(hname.Bytes())
+		return nil
+	}
+	found := ctx.CallView(root.Contract.Hname(), root.ViewFindContract.Hname(), dict.Dict{
+		root.ParamHname: hname.Bytes(),
+	})
+	if !codec.MustDecodeBool(found.Get(root.ParamContractFound)) {
+		panic(vm.ErrContractNotFound.Create(hname))
+	}
+	contractFees.SetAt(hname.Bytes(), rec.Bytes())
+	return nil
+}
+
+// getContractFees returns the fees of the contract, zero fees mean the chain-global defaults are in effect
+// Input:
+// - governance.ParamContractHname isc.Hname of the contract
+func getContractFees(ctx isc.SandboxView) dict.Dict {
+	hname := ctx.Params().MustGetHname(governance.ParamContractHname)
+	rec := governance.GetContractFees(ctx.StateR(), hname)
+	if rec == nil {
+		rec = &governance.ContractFeesRecord{}
+	}
+	return dict.Dict{
+		governance.ParamOwnerFee:     codec.EncodeUint64(rec.OwnerFee),
+		governance.ParamValidatorFee: codec.EncodeUint64(rec.ValidatorFee),
+	}
+}
@@ -33,6 +33,10 @@ var (
 	ViewGetFeePolicy = coreutil.ViewFunc("getFeePolicy")
 	ViewGetGasLimits = coreutil.ViewFunc("getGasLimits")
 
+	// contract fees
+	FuncSetContractFees = coreutil.Func("setContractFees")
+	ViewGetContractFees = coreutil.ViewFunc("getContractFees")
+
 	// evm fees
 	FuncSetEVMGasRatio = coreutil.Func("setEVMGasRatio")
 	ViewGetEVMGasRatio = coreutil.ViewFunc("getEVMGasRatio")
@@ -73,6 +77,9 @@ const (
 	VarGasFeePolicyBytes = "g" // covered in: TestMetadata
 	VarGasLimitsBytes    = "l" // covered in: TestMetadata
 
+	// contract fees
+	VarContractFees = "cf" // covered in: TestContractFees
+
 	// access nodes
 	VarAccessNodes          = "an" // covered in: TestAccessNodes
 	VarAccessNodeCandidates = "ac" // covered in: TestAccessNodes
@@ -104,6 +111,11 @@ const (
 	ParamEVMGasRatio    = "e"
 	ParamGasLimitsBytes = "l"
 
+	// contract fees
+	ParamContractHname = "h"
+	ParamOwnerFee      = "of"
+	ParamValidatorFee  = "vf"
+
 	// chain info
 	ParamChainID = "c"
 
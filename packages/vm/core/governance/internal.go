@@ -65,6 +65,27 @@ func MustGetChainInfo(state kv.KVStoreReader, chainID isc.ChainID) *isc.ChainInf
 	return info
 }
 
+func ContractFeesMap(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarContractFees)
+}
+
+func ContractFeesMapR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, VarContractFees)
+}
+
+// GetContractFees returns the fee record of the contract or nil, if the chain fee policy is in effect for it
+func GetContractFees(state kv.KVStoreReader, hname isc.Hname) *ContractFeesRecord {
+	data := ContractFeesMapR(state).GetAt(hname.Bytes())
+	if data == nil {
+		return nil
+	}
+	ret, err := ContractFeesRecordFromBytes(data)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
 func MustGetMinCommonAccountBalance(state kv.KVStoreReader) uint64 {
 	return kvdecoder.New(state).MustGetUint64(VarMinBaseTokensOnCommonAccount)
 }
@@ -5,6 +5,9 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations/m001"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations/m002"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations/m003"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations/m004"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations/m005"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations/m006"
 )
 
 var DefaultScheme = &migrations.MigrationScheme{
@@ -21,5 +24,8 @@ var DefaultScheme = &migrations.MigrationScheme{
 		m001.AccountDecimals,
 		m002.UpdateEVMISCMagic,
 		m003.UpdateEVMISCMagicFixed,
+		m004.EnableTypedTxs,
+		m005.EnableScheduledRequests,
+		m006.EnableGasSponsorship,
 	},
 }
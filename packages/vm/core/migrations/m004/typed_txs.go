@@ -0,0 +1,19 @@
+package m004
+
+import (
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/evm"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations"
+)
+
+// EnableTypedTxs bumps the schema version to evm.SchemaVersionTypedTxs, from
+// which on typed (EIP-2930 and EIP-1559) EVM transactions are accepted.
+// No state changes are needed.
+var EnableTypedTxs = migrations.Migration{
+	Contract: evm.Contract,
+	Apply: func(state kv.KVStore, log *logger.Logger) error {
+		log.Infof("m004 EnableTypedTxs")
+		return nil
+	},
+}
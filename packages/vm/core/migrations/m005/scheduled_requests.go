@@ -0,0 +1,19 @@
+package m005
+
+import (
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/blocklog"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations"
+)
+
+// EnableScheduledRequests bumps the schema version to blocklog.SchemaVersionScheduledRequests,
+// from which on calls can be scheduled, and the due ones are run in each block.
+// No state changes are needed.
+var EnableScheduledRequests = migrations.Migration{
+	Contract: blocklog.Contract,
+	Apply: func(state kv.KVStore, log *logger.Logger) error {
+		log.Infof("m005 EnableScheduledRequests")
+		return nil
+	},
+}
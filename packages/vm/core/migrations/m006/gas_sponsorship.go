@@ -0,0 +1,19 @@
+package m006
+
+import (
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/accounts"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/migrations"
+)
+
+// EnableGasSponsorship bumps the schema version to accounts.SchemaVersionGasSponsorship,
+// from which on the gas fee of off-ledger requests can be paid by a sponsor.
+// No state changes are needed.
+var EnableGasSponsorship = migrations.Migration{
+	Contract: accounts.Contract,
+	Apply: func(state kv.KVStore, log *logger.Logger) error {
+		log.Infof("m006 EnableGasSponsorship")
+		return nil
+	},
+}
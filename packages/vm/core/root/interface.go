@@ -12,6 +12,8 @@ var (
 	FuncGrantDeployPermission    = coreutil.Func("grantDeployPermission")
 	FuncRevokeDeployPermission   = coreutil.Func("revokeDeployPermission")
 	FuncRequireDeployPermissions = coreutil.Func("requireDeployPermissions")
+	FuncUpgradeContract          = coreutil.Func("upgradeContract")
+	FuncRetireContract           = coreutil.Func("retireContract")
 
 	// Views
 	ViewFindContract       = coreutil.ViewFunc("findContract")
@@ -24,6 +26,7 @@ const (
 	VarContractRegistry         = "r" // covered in: TestDeployNativeContract
 	VarDeployPermissionsEnabled = "a" // covered in: TestDeployNativeContract
 	VarDeployPermissions        = "p" // covered in: TestDeployNativeContract
+	VarRetiredContracts         = "t" // covered in: TestRetireContract
 )
 
 // request parameters
@@ -58,3 +58,32 @@ func DecodeContractRegistry(contractRegistry *collections.ImmutableMap) (map[isc
 	})
 	return ret, err
 }
+
+func GetRetiredContracts(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarRetiredContracts)
+}
+
+func GetRetiredContractsR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, VarRetiredContracts)
+}
+
+// IsContractRetired returns true if the contract was retired and does not accept calls anymore
+func IsContractRetired(state kv.KVStoreReader, hname isc.Hname) bool {
+	return GetRetiredContractsR(state).HasAt(hname.Bytes())
+}
+
+// DecodeRetiredContracts decodes the hnames of the retired contracts from the map into a Go map.
+func DecodeRetiredContracts(retiredContracts *collections.ImmutableMap) (map[isc.Hname]bool, error) {
+	ret := make(map[isc.Hname]bool)
+	var err error
+	retiredContracts.IterateKeys(func(k []byte) bool {
+		var hname isc.Hname
+		hname, err = isc.HnameFromBytes(k)
+		if err != nil {
+			return false
+		}
+		ret[hname] = true
+		return true
+	})
+	return ret, err
+}
@@ -24,3 +24,17 @@ func eventRevoke(ctx isc.Sandbox, deployer isc.AgentID) {
 	ww.Write(deployer)
 	ctx.Event("coreroot.revoke", ww.Bytes())
 }
+
+func eventUpgrade(ctx isc.Sandbox, hname isc.Hname, oldProgHash, progHash hashing.HashValue) {
+	ww := rwutil.NewBytesWriter()
+	ww.Write(&hname)
+	ww.Write(&oldProgHash)
+	ww.Write(&progHash)
+	ctx.Event("coreroot.upgrade", ww.Bytes())
+}
+
+func eventRetire(ctx isc.Sandbox, hname isc.Hname) {
+	ww := rwutil.NewBytesWriter()
+	ww.Write(&hname)
+	ctx.Event("coreroot.retire", ww.Bytes())
+}
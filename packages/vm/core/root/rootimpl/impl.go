@@ -30,6 +30,8 @@ var Processor = root.Contract.Processor(nil,
 	root.FuncGrantDeployPermission.WithHandler(grantDeployPermission),
 	root.FuncRequireDeployPermissions.WithHandler(requireDeployPermissions),
 	root.FuncRevokeDeployPermission.WithHandler(revokeDeployPermission),
+	root.FuncUpgradeContract.WithHandler(upgradeContract),
+	root.FuncRetireContract.WithHandler(retireContract),
 	root.ViewFindContract.WithHandler(findContract),
 	root.ViewGetContractRecords.WithHandler(getContractRecords),
 )
@@ -139,6 +141,43 @@ func requireDeployPermissions(ctx isc.Sandbox) dict.Dict {
 	return nil
 }
 
+// upgradeContract replaces the program of a deployed contract. The hname and the state
+// of the contract are kept, the 'init' constructor is not called.
+// Inputs:
+//   - ParamHname isc.Hname of the contract
+//   - ParamProgramHash HashValue of the blob with the new program binary
+func upgradeContract(ctx isc.Sandbox) dict.Dict {
+	ctx.RequireCallerIsChainOwner()
+	params := ctx.Params()
+	hname := params.MustGetHname(root.ParamHname)
+	progHash := params.MustGetHashValue(root.ParamProgramHash)
+
+	rec := mustFindCustomContract(ctx, hname)
+	// call to load VM from binary to check if it loads successfully
+	err := ctx.Privileged().TryLoadContract(progHash)
+	ctx.RequireNoError(err, "root.upgradeContract.fail: ")
+
+	oldProgHash := rec.ProgramHash
+	rec.ProgramHash = progHash
+	root.GetContractRegistry(ctx.State()).SetAt(hname.Bytes(), rec.Bytes())
+	eventUpgrade(ctx, hname, oldProgHash, progHash)
+	return nil
+}
+
+// retireContract disables a deployed contract. Further calls to the contract are rejected,
+// the contract record and the state of the contract are kept.
+// Input:
+//   - ParamHname isc.Hname of the contract
+func retireContract(ctx isc.Sandbox) dict.Dict {
+	ctx.RequireCallerIsChainOwner()
+	hname := ctx.Params().MustGetHname(root.ParamHname)
+
+	mustFindCustomContract(ctx, hname)
+	root.GetRetiredContracts(ctx.State()).SetAt(hname.Bytes(), []byte{0x01})
+	eventRetire(ctx, hname)
+	return nil
+}
+
 // findContract view finds and returns encoded record of the contract
 // Input:
 // - ParamHname
@@ -163,6 +202,11 @@ func getContractRecords(ctx isc.SandboxView) dict.Dict {
 		dst.SetAt(elemKey, value)
 		return true
 	})
+	retired := collections.NewMap(ret, root.VarRetiredContracts)
+	root.GetRetiredContractsR(ctx.StateR()).Iterate(func(elemKey []byte, value []byte) bool {
+		retired.SetAt(elemKey, value)
+		return true
+	})
 
 	return ret
 }
@@ -5,6 +5,8 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/kv"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/collections"
+	"github.com/nnikolash/wasp-types-exported/packages/vm"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/corecontracts"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/errors/coreerrors"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/root"
 )
@@ -43,3 +45,24 @@ func storeContractRecord(state kv.KVStore, rec *root.ContractRecord) {
 	}
 	contractRegistry.SetAt(hname.Bytes(), rec.Bytes())
 }
+
+var (
+	errCoreContract    = coreerrors.Register("core contract with hname %08x can't be upgraded or retired")
+	errContractRetired = coreerrors.Register("contract with hname %08x is already retired")
+)
+
+// mustFindCustomContract returns the record of a deployed contract, which is neither
+// a core contract nor retired
+func mustFindCustomContract(ctx isc.Sandbox, hname isc.Hname) *root.ContractRecord {
+	if corecontracts.IsCoreHname(hname) {
+		panic(errCoreContract.Create(hname))
+	}
+	rec := root.FindContract(ctx.State(), hname)
+	if rec == nil {
+		panic(vm.ErrContractNotFound.Create(hname))
+	}
+	if root.IsContractRetired(ctx.State(), hname) {
+		panic(errContractRetired.Create(hname))
+	}
+	return rec
+}
@@ -1680,3 +1680,55 @@ func TestNFTMint(t *testing.T) {
 		require.Len(t, env.L1NFTs(address), 1)
 	})
 }
+
+func TestSponsoredRequest(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	ch := env.NewChain()
+
+	sponsorWallet, sponsorAddr := env.NewKeyPairWithFunds()
+	sponsor := isc.NewAgentID(sponsorAddr)
+	ch.MustDepositBaseTokensToL2(10*isc.Million, sponsorWallet)
+
+	// the sender holds no base tokens on the chain
+	senderWallet, senderAddr := env.NewKeyPair()
+	sender := isc.NewAgentID(senderAddr)
+
+	depositReq := func() *solo.CallParams {
+		return solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).
+			WithSponsor(sponsor).
+			WithMaxAffordableGasBudget()
+	}
+
+	// the sponsor has not approved the sender yet, so the sender has to pay
+	_, err := ch.PostRequestOffLedger(depositReq(), senderWallet)
+	require.Error(t, err)
+	require.Zero(t, ch.L2BaseTokens(sender))
+
+	err = ch.SetSponsorAllowance(sponsorWallet, sender, 1*isc.Million)
+	require.NoError(t, err)
+	require.EqualValues(t, 1*isc.Million, ch.GetSponsorAllowance(sponsor, sender))
+
+	sponsorBalance := ch.L2BaseTokens(sponsor)
+	_, err = ch.PostRequestOffLedger(depositReq(), senderWallet)
+	require.NoError(t, err)
+	fee := ch.LastReceipt().GasFeeCharged
+	require.NotZero(t, fee)
+	require.EqualValues(t, sponsorBalance-fee, ch.L2BaseTokens(sponsor))
+	require.EqualValues(t, 1*isc.Million-fee, ch.GetSponsorAllowance(sponsor, sender))
+	require.Zero(t, ch.L2BaseTokens(sender))
+
+	// the fee is capped by the allowance
+	err = ch.SetSponsorAllowance(sponsorWallet, sender, fee/2)
+	require.NoError(t, err)
+	sponsorBalance = ch.L2BaseTokens(sponsor)
+	_, err = ch.PostRequestOffLedger(depositReq(), senderWallet)
+	require.Error(t, err)
+	require.LessOrEqual(t, ch.LastReceipt().GasFeeCharged, fee/2)
+	require.EqualValues(t, sponsorBalance-ch.LastReceipt().GasFeeCharged, ch.L2BaseTokens(sponsor))
+	require.EqualValues(t, fee/2-ch.LastReceipt().GasFeeCharged, ch.GetSponsorAllowance(sponsor, sender))
+
+	// revoke the sponsorship
+	err = ch.SetSponsorAllowance(sponsorWallet, sender, 0)
+	require.NoError(t, err)
+	require.Zero(t, ch.GetSponsorAllowance(sponsor, sender))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
 	"github.com/nnikolash/wasp-types-exported/packages/origin"
 	"github.com/nnikolash/wasp-types-exported/packages/solo"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/accounts"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/blocklog"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/corecontracts"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
 )
@@ -263,3 +266,143 @@ func TestBlocklogFoundriesWithPruning(t *testing.T) {
 	err = ch.DestroyFoundry(sn, ch.OriginatorPrivateKey)
 	require.NoError(t, err)
 }
+
+func TestScheduledRequests(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	ch := env.NewChain()
+
+	wallet, addr := env.NewKeyPairWithFunds()
+	owner := isc.NewAgentID(addr)
+	ch.MustDepositBaseTokensToL2(10*isc.Million, wallet)
+	prepaidAccount := blocklog.ScheduledPrepaidAccount(ch.ChainID)
+
+	_, senderAddr := env.NewKeyPair()
+	sender := isc.NewAgentID(senderAddr)
+
+	// the scheduled call sets a sponsor allowance, which makes its runs visible in the state
+	schedule := func(amount uint64, params ...interface{}) uint32 {
+		callParams := dict.Dict{
+			accounts.ParamAgentID:          codec.Encode(sender),
+			accounts.ParamSponsorAllowance: codec.Encode(amount),
+		}
+		params = append(params,
+			blocklog.ParamContractHname, accounts.Contract.Hname(),
+			blocklog.ParamEntryPoint, accounts.FuncSetSponsorAllowance.Hname(),
+			blocklog.ParamScheduleParams, callParams.Bytes(),
+			blocklog.ParamGasBudget, uint64(100_000),
+		)
+		res, err := ch.PostRequestOffLedger(
+			solo.NewCallParams(blocklog.Contract.Name, blocklog.FuncScheduleRequest.Name, params...).
+				AddAllowanceBaseTokens(1*isc.Million).
+				WithMaxAffordableGasBudget(),
+			wallet,
+		)
+		require.NoError(t, err)
+		return codec.MustDecodeUint32(res.Get(blocklog.ParamScheduleID))
+	}
+	nextBlock := func() {
+		ch.MustDepositBaseTokensToL2(1*isc.Million, wallet)
+	}
+	scheduledRunsInLastBlock := func() (ret int) {
+		for _, rec := range ch.GetRequestReceiptsForBlock() {
+			if _, ok := rec.Request.(*isc.ScheduledRequest); ok {
+				require.Nil(t, rec.Error)
+				ret++
+			}
+		}
+		return ret
+	}
+
+	t.Run("once by block", func(t *testing.T) {
+		// the call is scheduled in the next block, it is due in the block after that
+		scheduleID := schedule(1, blocklog.ParamScheduleBlock, ch.LatestBlockIndex()+3)
+		rec := ch.GetScheduledRequest(scheduleID)
+		require.NotNil(t, rec)
+		require.True(t, rec.Owner.Equals(owner))
+		require.EqualValues(t, 1*isc.Million, rec.Prepaid)
+		require.EqualValues(t, 1*isc.Million, ch.L2BaseTokens(prepaidAccount))
+
+		ownerBalance := ch.L2BaseTokens(owner)
+		nextBlock()
+		require.Zero(t, scheduledRunsInLastBlock())
+		require.Zero(t, ch.GetSponsorAllowance(owner, sender))
+
+		nextBlock()
+		require.Equal(t, 1, scheduledRunsInLastBlock())
+		require.EqualValues(t, 1, ch.GetSponsorAllowance(owner, sender))
+		require.Nil(t, ch.GetScheduledRequest(scheduleID))
+		// the prepaid tokens left after the run are refunded
+		require.Zero(t, ch.L2BaseTokens(prepaidAccount))
+		require.Greater(t, ch.L2BaseTokens(owner), ownerBalance)
+	})
+
+	t.Run("once by time", func(t *testing.T) {
+		scheduleID := schedule(2, blocklog.ParamScheduleTime, env.GlobalTime().Add(time.Hour))
+		nextBlock()
+		require.Zero(t, scheduledRunsInLastBlock())
+		require.NotNil(t, ch.GetScheduledRequest(scheduleID))
+
+		env.AdvanceClockBy(2 * time.Hour)
+		nextBlock()
+		require.Equal(t, 1, scheduledRunsInLastBlock())
+		require.EqualValues(t, 2, ch.GetSponsorAllowance(owner, sender))
+		require.Nil(t, ch.GetScheduledRequest(scheduleID))
+	})
+
+	t.Run("deposit below the minimum", func(t *testing.T) {
+		_, err := ch.PostRequestOffLedger(
+			solo.NewCallParams(blocklog.Contract.Name, blocklog.FuncScheduleRequest.Name,
+				blocklog.ParamContractHname, accounts.Contract.Hname(),
+				blocklog.ParamEntryPoint, accounts.FuncSetSponsorAllowance.Hname(),
+				blocklog.ParamGasBudget, uint64(100_000),
+				blocklog.ParamScheduleBlock, ch.LatestBlockIndex()+2,
+			).
+				AddAllowanceBaseTokens(blocklog.MinScheduledPrepaid-1).
+				WithMaxAffordableGasBudget(),
+			wallet,
+		)
+		require.ErrorContains(t, err, "base tokens for the gas of the scheduled call must be provided")
+		require.Zero(t, ch.L2BaseTokens(prepaidAccount))
+	})
+
+	t.Run("recurring and cancelled", func(t *testing.T) {
+		scheduleID := schedule(3,
+			blocklog.ParamScheduleBlock, ch.LatestBlockIndex()+2,
+			blocklog.ParamScheduleInterval, uint64(2),
+		)
+		runs := 0
+		for i := 0; i < 6; i++ {
+			nextBlock()
+			runs += scheduledRunsInLastBlock()
+		}
+		require.Equal(t, 3, runs)
+		require.EqualValues(t, 3, ch.GetSponsorAllowance(owner, sender))
+
+		rec := ch.GetScheduledRequest(scheduleID)
+		require.NotNil(t, rec)
+		require.Less(t, rec.Prepaid, 1*isc.Million)
+		require.EqualValues(t, rec.Prepaid, ch.L2BaseTokens(prepaidAccount))
+
+		// only the owner can cancel
+		otherWallet, _ := env.NewKeyPairWithFunds()
+		ch.MustDepositBaseTokensToL2(1*isc.Million, otherWallet)
+		cancel := solo.NewCallParams(blocklog.Contract.Name, blocklog.FuncCancelScheduledRequest.Name,
+			blocklog.ParamScheduleID, scheduleID,
+		).WithMaxAffordableGasBudget()
+		_, err := ch.PostRequestOffLedger(cancel, otherWallet)
+		require.ErrorContains(t, err, "only be cancelled by its owner")
+
+		cancel = solo.NewCallParams(blocklog.Contract.Name, blocklog.FuncCancelScheduledRequest.Name,
+			blocklog.ParamScheduleID, scheduleID,
+		).WithMaxAffordableGasBudget()
+		_, err = ch.PostRequestOffLedger(cancel, wallet)
+		require.NoError(t, err)
+		require.Nil(t, ch.GetScheduledRequest(scheduleID))
+		require.Zero(t, ch.L2BaseTokens(prepaidAccount))
+
+		for i := 0; i < 3; i++ {
+			nextBlock()
+			require.Zero(t, scheduledRunsInLastBlock())
+		}
+	})
+}
@@ -912,3 +912,88 @@ func TestGasPayout(t *testing.T) {
 	require.Equal(t, governance.DefaultMinBaseTokensOnCommonAccount, commonBal5.BaseTokens)
 	require.Equal(t, user1Bal4.BaseTokens+gasFees-10, user1Bal5.BaseTokens)
 }
+
+func TestContractFees(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(inccounter.Processor)
+	ch := env.NewChain()
+
+	err := ch.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash)
+	require.NoError(t, err)
+
+	user, userAddr := env.NewKeyPairWithFunds()
+	userAgentID := isc.NewAgentID(userAddr)
+	err = ch.DepositBaseTokensToL2(1*isc.Million, user)
+	require.NoError(t, err)
+
+	_, estimate, err := ch.EstimateGasOffLedger(
+		solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name).WithMaxAffordableGasBudget(),
+		user,
+	)
+	require.NoError(t, err)
+	defaultFee := estimate.GasFeeCharged
+	gasBurned := estimate.GasBurned
+	require.NotZero(t, defaultFee)
+
+	// the fees are charged for the burned gas, not for the budget
+	incCounterReq := func() *solo.CallParams {
+		return solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name).
+			WithGasBudget(2 * gasBurned)
+	}
+	incCounter := func(expectedFee uint64, expectedContractFees *governance.ContractFeesRecord) {
+		_, estimate, err2 := ch.EstimateGasOffLedger(incCounterReq(), user)
+		require.NoError(t, err2)
+		require.EqualValues(t, gasBurned, estimate.GasBurned)
+		require.EqualValues(t, expectedFee, estimate.GasFeeCharged)
+		require.EqualValues(t, expectedContractFees, estimate.ContractFees)
+
+		userBal := ch.L2BaseTokens(userAgentID)
+		_, err2 = ch.PostRequestOffLedger(incCounterReq(), user)
+		require.NoError(t, err2)
+		receipts := ch.GetRequestReceiptsForBlock()
+		receipt := receipts[len(receipts)-1]
+		require.EqualValues(t, expectedFee, receipt.GasFeeCharged)
+		require.EqualValues(t, expectedContractFees, receipt.ContractFees)
+		require.EqualValues(t, userBal-expectedFee, ch.L2BaseTokens(userAgentID))
+	}
+	incCounter(defaultFee, nil)
+
+	// only the chain owner can set the fees of the deployed contracts
+	surcharge := &governance.ContractFeesRecord{OwnerFee: governance.ContractFeesGasUnit}
+	err = ch.SetContractFees(user, inccounter.Contract.Name, surcharge)
+	testmisc.RequireErrorToBe(t, err, vm.ErrUnauthorized)
+	err = ch.SetContractFees(nil, "unknown", surcharge)
+	testmisc.RequireErrorToBe(t, err, vm.ErrContractNotFound)
+
+	// 1 token per gas unit, the validator part is still charged with the chain fee policy
+	err = ch.SetContractFees(nil, inccounter.Contract.Name, surcharge)
+	require.NoError(t, err)
+	require.EqualValues(t, surcharge, ch.GetContractFees(inccounter.Contract.Name))
+	require.EqualValues(t, &governance.ContractFeesRecord{}, ch.GetContractFees(accounts.Contract.Name))
+	require.Greater(t, gasBurned, defaultFee)
+	incCounter(gasBurned, surcharge)
+
+	discount := &governance.ContractFeesRecord{OwnerFee: 1, ValidatorFee: 2}
+	err = ch.SetContractFees(nil, inccounter.Contract.Name, discount)
+	require.NoError(t, err)
+	incCounter((gasBurned+999)/1000+(2*gasBurned+999)/1000, discount)
+
+	// the requests which cannot cover the fees for their gas budget fail, and are charged with the chain fee policy
+	err = ch.SetContractFees(nil, inccounter.Contract.Name, &governance.ContractFeesRecord{OwnerFee: 1 * isc.Million})
+	require.NoError(t, err)
+	userBal := ch.L2BaseTokens(userAgentID)
+	_, err = ch.PostRequestOffLedger(incCounterReq(), user)
+	testmisc.RequireErrorToBe(t, err, vm.ErrNotEnoughFundsForContractFees)
+	receipts := ch.GetRequestReceiptsForBlock()
+	receipt := receipts[len(receipts)-1]
+	require.Nil(t, receipt.ContractFees)
+	require.NotZero(t, receipt.GasFeeCharged)
+	require.LessOrEqual(t, receipt.GasFeeCharged, defaultFee)
+	require.EqualValues(t, userBal-receipt.GasFeeCharged, ch.L2BaseTokens(userAgentID))
+
+	// zero fees remove the record
+	err = ch.SetContractFees(nil, inccounter.Contract.Name, &governance.ContractFeesRecord{})
+	require.NoError(t, err)
+	require.EqualValues(t, &governance.ContractFeesRecord{}, ch.GetContractFees(inccounter.Contract.Name))
+	incCounter(defaultFee, nil)
+}
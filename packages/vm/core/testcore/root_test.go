@@ -8,8 +8,15 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/nnikolash/wasp-types-exported/contracts/native/inccounter"
+	"github.com/nnikolash/wasp-types-exported/packages/hashing"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/isc/coreutil"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/dict"
 	"github.com/nnikolash/wasp-types-exported/packages/solo"
+	"github.com/nnikolash/wasp-types-exported/packages/testutil/testmisc"
+	"github.com/nnikolash/wasp-types-exported/packages/vm"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/accounts"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/blob"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/corecontracts"
@@ -175,3 +182,108 @@ func TestChangeOwnerUnauthorized(t *testing.T) {
 	_, ownerAgentID, _ := chain.GetInfo()
 	require.EqualValues(t, chain.OriginatorAgentID, ownerAgentID)
 }
+
+// counterV2 is an upgrade of the inccounter contract, which increments the counter by 10
+var counterV2 = coreutil.NewContract("inccounter v2")
+
+var counterV2Processor = counterV2.Processor(nil,
+	inccounter.FuncIncCounter.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+		val := codec.MustDecodeInt64(ctx.State().Get(inccounter.VarCounter), 0)
+		ctx.State().Set(inccounter.VarCounter, codec.EncodeInt64(val+10))
+		return nil
+	}),
+	inccounter.ViewGetCounter.WithHandler(func(ctx isc.SandboxView) dict.Dict {
+		return dict.Dict{inccounter.VarCounter: ctx.StateR().Get(inccounter.VarCounter)}
+	}),
+)
+
+func deployCounter(t *testing.T) *solo.Chain {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(inccounter.Processor).
+		WithNativeContract(counterV2Processor)
+	ch := env.NewChain()
+
+	err := ch.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash)
+	require.NoError(t, err)
+	incCounter(t, ch)
+	require.EqualValues(t, 1, getCounter(t, ch))
+	return ch
+}
+
+func incCounter(t *testing.T, ch *solo.Chain) {
+	_, err := ch.PostRequestSync(solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name).AddBaseTokens(1), nil)
+	require.NoError(t, err)
+}
+
+func getCounter(t *testing.T, ch *solo.Chain) int64 {
+	res, err := ch.CallView(inccounter.Contract.Name, inccounter.ViewGetCounter.Name)
+	require.NoError(t, err)
+	return codec.MustDecodeInt64(res.Get(inccounter.VarCounter))
+}
+
+func TestUpgradeContract(t *testing.T) {
+	ch := deployCounter(t)
+
+	// only the chain owner can upgrade contracts
+	user, _ := ch.Env.NewKeyPairWithFunds()
+	err := ch.UpgradeContract(user, inccounter.Contract.Name, counterV2.ProgramHash)
+	testmisc.RequireErrorToBe(t, err, vm.ErrUnauthorized)
+
+	err = ch.UpgradeContract(nil, inccounter.Contract.Name, hashing.PseudoRandomHash(nil))
+	require.Error(t, err)
+	err = ch.UpgradeContract(nil, "unknown", counterV2.ProgramHash)
+	testmisc.RequireErrorToBe(t, err, vm.ErrContractNotFound)
+	err = ch.UpgradeContract(nil, accounts.Contract.Name, counterV2.ProgramHash)
+	require.ErrorContains(t, err, "can't be upgraded or retired")
+
+	err = ch.UpgradeContract(nil, inccounter.Contract.Name, counterV2.ProgramHash)
+	require.NoError(t, err)
+
+	rec, err := ch.FindContract(inccounter.Contract.Name)
+	require.NoError(t, err)
+	require.EqualValues(t, counterV2.ProgramHash, rec.ProgramHash)
+
+	// the state is kept, the new program is called
+	require.EqualValues(t, 1, getCounter(t, ch))
+	incCounter(t, ch)
+	require.EqualValues(t, 11, getCounter(t, ch))
+
+	events, err := ch.GetEventsForContract(root.Contract.Name)
+	require.NoError(t, err)
+	require.Equal(t, "coreroot.upgrade", events[len(events)-1].Topic)
+}
+
+func TestRetireContract(t *testing.T) {
+	ch := deployCounter(t)
+
+	user, _ := ch.Env.NewKeyPairWithFunds()
+	err := ch.RetireContract(user, inccounter.Contract.Name)
+	testmisc.RequireErrorToBe(t, err, vm.ErrUnauthorized)
+	err = ch.RetireContract(nil, root.Contract.Name)
+	require.ErrorContains(t, err, "can't be upgraded or retired")
+	require.Empty(t, ch.GetRetiredContracts())
+
+	err = ch.RetireContract(nil, inccounter.Contract.Name)
+	require.NoError(t, err)
+	require.Equal(t, map[isc.Hname]bool{inccounter.Contract.Hname(): true}, ch.GetRetiredContracts())
+
+	events, err := ch.GetEventsForContract(root.Contract.Name)
+	require.NoError(t, err)
+	require.Equal(t, "coreroot.retire", events[len(events)-1].Topic)
+
+	// the contract rejects calls, but its record is kept
+	_, err = ch.PostRequestSync(solo.NewCallParams(inccounter.Contract.Name, inccounter.FuncIncCounter.Name).AddBaseTokens(1), nil)
+	testmisc.RequireErrorToBe(t, err, vm.ErrContractRetired)
+	_, err = ch.CallView(inccounter.Contract.Name, inccounter.ViewGetCounter.Name)
+	testmisc.RequireErrorToBe(t, err, vm.ErrContractRetired)
+	_, err = ch.FindContract(inccounter.Contract.Name)
+	require.NoError(t, err)
+
+	// retired contracts can't be upgraded, retired again or deployed again
+	err = ch.UpgradeContract(nil, inccounter.Contract.Name, counterV2.ProgramHash)
+	require.ErrorContains(t, err, "is already retired")
+	err = ch.RetireContract(nil, inccounter.Contract.Name)
+	require.ErrorContains(t, err, "is already retired")
+	err = ch.DeployContract(nil, inccounter.Contract.Name, inccounter.Contract.ProgramHash)
+	require.Error(t, err)
+}
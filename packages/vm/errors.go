@@ -19,6 +19,7 @@ var (
 	ErrCantDestroyFoundryBeingCreated       = coreerrors.Register("can't destroy foundry which is being created").Create()
 
 	ErrContractNotFound          = coreerrors.Register("contract with hname %08x not found")
+	ErrContractRetired           = coreerrors.Register("contract with hname %08x is retired")
 	ErrTargetEntryPointNotFound  = coreerrors.Register("entry point not found").Create()
 	ErrEntryPointCantBeAView     = coreerrors.Register("'init' entry point can't be a view").Create()
 	ErrRepeatingInitCall         = coreerrors.Register("repeating init call").Create()
@@ -32,4 +33,6 @@ var (
 	ErrIllegalCall               = coreerrors.Register("illegal call - entrypoint cannot be called from contracts")
 	ErrSendMultipleNFTs          = coreerrors.Register("cannot send more than 1 NFT").Create()
 	ErrEVMExecutionReverted      = coreerrors.Register("execution reverted: %s") // hex-encoded revert data
+
+	ErrNotEnoughFundsForContractFees = coreerrors.Register("not enough funds to cover the fees of the target contract for the gas budget").Create()
 )
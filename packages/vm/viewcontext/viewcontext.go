@@ -208,6 +208,10 @@ func (ctx *ViewContext) callView(targetContract, entryPoint isc.Hname, params di
 	if contractRecord == nil {
 		panic(vm.ErrContractNotFound.Create(targetContract))
 	}
+	// read without burning gas, so that the views on existing chains keep burning the same gas
+	if root.IsContractRetired(subrealm.NewReadOnly(ctx.stateReader, kv.Key(root.Contract.Hname().Bytes())), targetContract) {
+		panic(vm.ErrContractRetired.Create(targetContract))
+	}
 	ep := execution.GetEntryPointByProgHash(ctx, targetContract, entryPoint, contractRecord.ProgramHash)
 
 	if !ep.IsView() {
@@ -81,6 +81,13 @@ func findContractByHname(chainState kv.KVStore, contractHname isc.Hname) (ret *r
 	return ret
 }
 
+func isContractRetired(chainState kv.KVStore, contractHname isc.Hname) (ret bool) {
+	withContractState(chainState, root.Contract, func(s kv.KVStore) {
+		ret = root.IsContractRetired(s, contractHname)
+	})
+	return ret
+}
+
 func (reqctx *requestContext) GetBaseTokensBalance(agentID isc.AgentID) uint64 {
 	var ret uint64
 	reqctx.callCore(accounts.Contract, func(s kv.KVStore) {
@@ -136,6 +143,9 @@ func (reqctx *requestContext) GetNFTData(nftID iotago.NFTID) (ret *isc.NFT) {
 }
 
 func (reqctx *requestContext) GetSenderTokenBalanceForFees() uint64 {
+	if reqctx.gas.sponsor != nil {
+		return reqctx.sponsorFeeTokens()
+	}
 	sender := reqctx.req.SenderAccount()
 	if sender == nil {
 		return 0
@@ -159,6 +169,7 @@ func (reqctx *requestContext) writeReceiptToBlockLog(vmError *isc.VMError) *bloc
 		GasFeeCharged: reqctx.gas.feeCharged,
 		GasBurnLog:    reqctx.gas.burnLog,
 		SDCharged:     reqctx.sdCharged,
+		ContractFees:  reqctx.gas.contractFees,
 	}
 
 	if vmError != nil {
@@ -36,3 +36,14 @@ func (vmctx *vmContext) runMigrations(chainState kv.KVStore, migrationScheme *mi
 		})
 	}
 }
+
+func (vmctx *vmContext) applyStatePatches(chainState kv.KVStore, patches []migrations.Migration) {
+	for _, patch := range patches {
+		withContractState(chainState, patch.Contract, func(s kv.KVStore) {
+			err := patch.Apply(s, vmctx.task.Log)
+			if err != nil {
+				panic(fmt.Sprintf("failed applying state patch: %s", err))
+			}
+		})
+	}
+}
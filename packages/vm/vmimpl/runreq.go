@@ -8,6 +8,7 @@ import (
 	"time"
 
 	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/evm/evmutil"
 	"github.com/nnikolash/wasp-types-exported/packages/hashing"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/isc/coreutil"
@@ -22,6 +23,7 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/util/panicutil"
 	"github.com/nnikolash/wasp-types-exported/packages/vm"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/accounts"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/blocklog"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/errors/coreerrors"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/governance"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/root"
@@ -138,16 +140,17 @@ func (reqctx *requestContext) creditAssetsToChain() {
 
 // txGasPrice returns:
 // for ISC request: nil,
-// for EVM tx: the gas price set in the EVM tx (full decimals), or 0 if gas price is unset
+// for EVM tx: the effective gas price of the EVM tx (full decimals), or 0 if gas price is unset
 func (reqctx *requestContext) txGasPrice() *big.Int {
 	callMsg := reqctx.req.EVMCallMsg()
 	if callMsg == nil {
 		return nil
 	}
-	if callMsg.GasPrice == nil {
+	gasPrice := evmutil.EffectiveGasPrice(callMsg.GasPrice, callMsg.GasFeeCap, callMsg.GasTipCap, reqctx.ChainInfo().GasFeePolicy)
+	if gasPrice == nil {
 		return big.NewInt(0)
 	}
-	return callMsg.GasPrice
+	return gasPrice
 }
 
 // checkAllowance ensure there are enough funds to cover the specified allowance
@@ -183,9 +186,96 @@ func (reqctx *requestContext) prepareGasBudget() {
 	if !reqctx.shouldChargeGasFee() {
 		return
 	}
+	reqctx.gas.sponsor = reqctx.gasSponsor()
 	reqctx.gasSetBudget(reqctx.calculateAffordableGasBudget())
 }
 
+// gasSponsor returns the sponsor named by the request if it has approved paying the gas fee
+// for the sender, nil if the sender pays
+func (reqctx *requestContext) gasSponsor() isc.AgentID {
+	if req, ok := reqctx.req.(*isc.ScheduledRequest); ok {
+		var exists bool
+		withContractState(reqctx.uncommittedState, blocklog.Contract, func(s kv.KVStore) {
+			exists = blocklog.GetScheduledRequest(s, req.ScheduleID()) != nil
+		})
+		if !exists {
+			// without the record there is no prepaid gas, the request is skipped
+			panic(errScheduledRequestCancelled)
+		}
+		// the gas of scheduled calls is prepaid and kept in the blocklog account
+		return blocklog.ScheduledPrepaidAccount(reqctx.ChainID())
+	}
+	if reqctx.SchemaVersion() < accounts.SchemaVersionGasSponsorship {
+		return nil
+	}
+	req, ok := reqctx.req.(isc.OffLedgerRequest)
+	if !ok || req.Sponsor() == nil || req.Sponsor().Equals(req.SenderAccount()) {
+		return nil
+	}
+	if reqctx.sponsorAllowance(req.Sponsor()) == 0 {
+		return nil
+	}
+	return req.Sponsor()
+}
+
+func (reqctx *requestContext) sponsorAllowance(sponsor isc.AgentID) (ret uint64) {
+	if req, ok := reqctx.req.(*isc.ScheduledRequest); ok {
+		withContractState(reqctx.uncommittedState, blocklog.Contract, func(s kv.KVStore) {
+			if rec := blocklog.GetScheduledRequest(s, req.ScheduleID()); rec != nil {
+				ret = rec.Prepaid
+			}
+		})
+		return ret
+	}
+	withContractState(reqctx.uncommittedState, accounts.Contract, func(s kv.KVStore) {
+		ret = accounts.GetSponsorAllowance(s, sponsor, reqctx.req.SenderAccount(), reqctx.ChainID())
+	})
+	return ret
+}
+
+// sponsorFeeTokens returns how many tokens the sponsor can pay for the fee, limited by its balance and the allowance for the sender
+func (reqctx *requestContext) sponsorFeeTokens() uint64 {
+	return min(reqctx.GetBaseTokensBalance(reqctx.gas.sponsor), reqctx.sponsorAllowance(reqctx.gas.sponsor))
+}
+
+// debitSponsorAllowance decreases what the sponsor is still willing to pay by the charged fee
+func (reqctx *requestContext) debitSponsorAllowance(amount uint64) {
+	if req, ok := reqctx.req.(*isc.ScheduledRequest); ok {
+		withContractState(reqctx.uncommittedState, blocklog.Contract, func(s kv.KVStore) {
+			blocklog.DebitScheduledPrepaid(s, req.ScheduleID(), amount)
+		})
+		return
+	}
+	withContractState(reqctx.uncommittedState, accounts.Contract, func(s kv.KVStore) {
+		accounts.DebitFromSponsorAllowance(s, reqctx.gas.sponsor, reqctx.req.SenderAccount(), reqctx.ChainID(), amount)
+	})
+}
+
+// updateScheduledRequest reschedules or removes the scheduled call after its run.
+// The prepaid gas left in a removed call is refunded to its owner
+func (reqctx *requestContext) updateScheduledRequest() {
+	req, ok := reqctx.req.(*isc.ScheduledRequest)
+	if !ok {
+		return
+	}
+	var owner isc.AgentID
+	var refund uint64
+	withContractState(reqctx.uncommittedState, blocklog.Contract, func(s kv.KVStore) {
+		owner, refund = blocklog.AdvanceScheduledRequest(s, req.ScheduleID(), reqctx.vm.task.TimeAssumption, req.BlockIndex())
+	})
+	if refund == 0 {
+		return
+	}
+	mustMoveBetweenAccounts(
+		reqctx.SchemaVersion(),
+		reqctx.uncommittedState,
+		blocklog.ScheduledPrepaidAccount(reqctx.ChainID()),
+		owner,
+		isc.NewAssetsBaseTokens(refund),
+		reqctx.ChainID(),
+	)
+}
+
 // callTheContract runs the contract. if an error is returned, the request will be skipped
 func (reqctx *requestContext) callTheContract() (*vm.RequestResult, error) {
 	// TODO: do not mutate vmContext's txbuilder
@@ -233,6 +323,9 @@ func (reqctx *requestContext) callTheContract() (*vm.RequestResult, error) {
 		}()
 		// ensure there are enough funds to cover the specified allowance
 		reqctx.checkAllowance()
+		if reqctx.gas.contractFeesUnaffordable {
+			panic(vm.ErrNotEnoughFundsForContractFees)
+		}
 
 		reqctx.GasBurnEnable(true)
 		result.Return = reqctx.callFromRequest()
@@ -262,6 +355,7 @@ func (reqctx *requestContext) callTheContract() (*vm.RequestResult, error) {
 		if reqctx.req.IsOffLedger() {
 			reqctx.updateOffLedgerRequestNonce()
 		}
+		reqctx.updateScheduledRequest()
 	})
 	if err != nil {
 		rollback()
@@ -357,6 +451,15 @@ func (reqctx *requestContext) calculateAffordableGasBudget() (budget, maxTokensT
 
 	// calculate how many tokens for gas fee can be guaranteed after taking into account the allowance
 	guaranteedFeeTokens := reqctx.calcGuaranteedFeeTokens()
+	if contractFees := reqctx.getContractFees(); contractFees != nil {
+		// the fees of the contract are not truncated, the whole budget must be covered
+		f1, f2 := reqctx.contractFeeFromGasBurned(contractFees, gasBudget, math.MaxUint64)
+		if f1 <= guaranteedFeeTokens && f2 <= guaranteedFeeTokens-f1 {
+			return gasBudget, f1 + f2
+		}
+		// the request will fail without calling the contract, the sender is charged the fee of the chain fee policy
+		reqctx.gas.contractFeesUnaffordable = true
+	}
 	// calculate how many tokens maximum will be charged taking into account the budget
 	f1, f2 := reqctx.vm.chainInfo.GasFeePolicy.FeeFromGasBurned(
 		gasBudget,
@@ -380,6 +483,10 @@ func (reqctx *requestContext) calculateAffordableGasBudget() (budget, maxTokensT
 // calcGuaranteedFeeTokens return the maximum tokens (base tokens or native) can be guaranteed for the fee,
 // taking into account allowance (which must be 'reserved')
 func (reqctx *requestContext) calcGuaranteedFeeTokens() uint64 {
+	if reqctx.gas.sponsor != nil {
+		// the allowance is taken from the sender's account, it doesn't affect the sponsor
+		return reqctx.sponsorFeeTokens()
+	}
 	tokensGuaranteed := reqctx.GetBaseTokensBalance(reqctx.req.SenderAccount())
 	// safely subtract the allowed from the sender to the target
 	if allowed := reqctx.req.Allowance(); allowed != nil {
@@ -392,7 +499,30 @@ func (reqctx *requestContext) calcGuaranteedFeeTokens() uint64 {
 	return tokensGuaranteed
 }
 
-// chargeGasFee takes burned tokens from the sender's account
+// getContractFees returns the fee record of the target contract, nil if the chain fee policy is in effect
+func (reqctx *requestContext) getContractFees() (ret *governance.ContractFeesRecord) {
+	withContractState(reqctx.uncommittedState, governance.Contract, func(s kv.KVStore) {
+		ret = governance.GetContractFees(s, reqctx.req.CallTarget().Contract)
+	})
+	return ret
+}
+
+// contractFeeFromGasBurned calculates the fee parts of the gas burned by a request to a contract with a fee record.
+// The fee is truncated to the available tokens, the validator part is taken first
+func (reqctx *requestContext) contractFeeFromGasBurned(contractFees *governance.ContractFeesRecord, gasBurned, availableTokens uint64) (sendToOwner, sendToValidator uint64) {
+	sendToOwner, sendToValidator = reqctx.vm.chainInfo.GasFeePolicy.FeeFromGasBurned(
+		gasBurned,
+		math.MaxUint64,
+		reqctx.txGasPrice(),
+		parameters.L1().BaseToken.Decimals,
+	)
+	sendToOwner, sendToValidator = contractFees.Apply(gasBurned, sendToOwner, sendToValidator)
+	sendToValidator = min(sendToValidator, availableTokens)
+	sendToOwner = min(sendToOwner, availableTokens-sendToValidator)
+	return sendToOwner, sendToValidator
+}
+
+// chargeGasFee takes burned tokens from the sender's account, or from the sponsor's account if the request is sponsored
 // It should always be enough because gas budget is set affordable
 func (reqctx *requestContext) chargeGasFee() {
 	defer func() {
@@ -419,14 +549,26 @@ func (reqctx *requestContext) chargeGasFee() {
 		// user didn't specify enough base tokens to cover the minimum request fee, charge whatever is present in the user's account
 		availableToPayFee = reqctx.GetSenderTokenBalanceForFees()
 	}
+	if reqctx.gas.sponsor != nil {
+		// the sponsor's balance or allowance could have changed while running the request
+		availableToPayFee = min(availableToPayFee, reqctx.sponsorFeeTokens())
+	}
 
 	// total fees to charge
-	sendToPayout, sendToValidator := reqctx.vm.chainInfo.GasFeePolicy.FeeFromGasBurned(
-		reqctx.GasBurned(),
-		availableToPayFee,
-		reqctx.txGasPrice(),
-		parameters.L1().BaseToken.Decimals,
-	)
+	var sendToPayout, sendToValidator uint64
+	if !reqctx.gas.contractFeesUnaffordable {
+		reqctx.gas.contractFees = reqctx.getContractFees()
+	}
+	if reqctx.gas.contractFees != nil {
+		sendToPayout, sendToValidator = reqctx.contractFeeFromGasBurned(reqctx.gas.contractFees, reqctx.GasBurned(), availableToPayFee)
+	} else {
+		sendToPayout, sendToValidator = reqctx.vm.chainInfo.GasFeePolicy.FeeFromGasBurned(
+			reqctx.GasBurned(),
+			availableToPayFee,
+			reqctx.txGasPrice(),
+			parameters.L1().BaseToken.Decimals,
+		)
+	}
 	reqctx.gas.feeCharged = sendToPayout + sendToValidator
 
 	// calc gas totals
@@ -438,6 +580,10 @@ func (reqctx *requestContext) chargeGasFee() {
 	}
 
 	sender := reqctx.req.SenderAccount()
+	if reqctx.gas.sponsor != nil {
+		reqctx.debitSponsorAllowance(reqctx.gas.feeCharged)
+		sender = reqctx.gas.sponsor
+	}
 	if sendToValidator != 0 {
 		transferToValidator := &isc.Assets{}
 		transferToValidator.BaseTokens = sendToValidator
@@ -503,6 +649,10 @@ func (reqctx *requestContext) GetContractRecord(contractHname isc.Hname) (ret *r
 		reqctx.GasBurn(gas.BurnCodeCallTargetNotFound)
 		panic(vm.ErrContractNotFound.Create(contractHname))
 	}
+	// read without burning gas, so that the calls on existing chains keep burning the same gas
+	if isContractRetired(reqctx.uncommittedState, contractHname) {
+		panic(vm.ErrContractRetired.Create(contractHname))
+	}
 	return ret
 }
 
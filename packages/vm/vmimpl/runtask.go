@@ -4,7 +4,6 @@ import (
 	"errors"
 	"math"
 
-	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/logger"
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/evm"
@@ -114,6 +113,7 @@ func (vmctx *vmContext) init(prevL1Commitment *state.L1Commitment) {
 	vmctx.withStateUpdate(func(chainState kv.KVStore) {
 		vmctx.runMigrations(chainState, vmctx.task.Migrations)
 		vmctx.schemaVersion = root.NewStateAccess(chainState).SchemaVersion()
+		vmctx.applyStatePatches(chainState, vmctx.task.StatePatches)
 	})
 
 	// save the anchor tx ID of the current state
@@ -164,6 +164,20 @@ func (vmctx *vmContext) init(prevL1Commitment *state.L1Commitment) {
 	)
 }
 
+// dueScheduledRequests returns the synthetic requests of the calls scheduled in the blocklog contract
+// which are due in this block. Nothing is injected when the VM does not produce a block,
+// or before the chain has migrated to blocklog.SchemaVersionScheduledRequests
+func (vmctx *vmContext) dueScheduledRequests() []isc.Request {
+	if !vmctx.task.WillProduceBlock() || vmctx.schemaVersion < blocklog.SchemaVersionScheduledRequests {
+		return nil
+	}
+	var ret []isc.Request
+	for _, req := range blocklog.GetDueScheduledRequests(vmctx.stateDraft, vmctx.ChainID(), vmctx.task.TimeAssumption, vmctx.stateDraft.BlockIndex()) {
+		ret = append(ret, req)
+	}
+	return ret
+}
+
 func (vmctx *vmContext) emitEVMEventL1NFTMint(nftID iotago.NFTID, owner *isc.EthereumAddressAgentID) blockCloseCallback {
 	return func(reqIndex uint16) {
 		// fake a request execution and insert a Mint event on the EVM
@@ -198,7 +212,15 @@ func (vmctx *vmContext) runRequests(
 	unprocessable []isc.OnLedgerRequest,
 ) {
 	results = []*vm.RequestResult{}
-	allReqs := lo.CopySlice(reqs)
+	// due scheduled calls run first, before the requests of the batch. The scheduled requests
+	// proposed by the mempool only trigger the block, they are not taken from the batch
+	allReqs := vmctx.dueScheduledRequests()
+	for _, req := range reqs {
+		if _, ok := req.(*isc.ScheduledRequest); !ok {
+			allReqs = append(allReqs, req)
+		}
+	}
+	numInitial := len(allReqs)
 
 	// main loop over the batch of requests
 	requestIndexCounter := uint16(0)
@@ -207,7 +229,9 @@ func (vmctx *vmContext) runRequests(
 		result, unprocessableToRetry, skipReason := vmctx.runRequest(req, requestIndexCounter, maintenanceMode)
 		if skipReason != nil {
 			if errors.Is(vmexceptions.ErrNotEnoughFundsForSD, skipReason) {
-				unprocessable = append(unprocessable, req.(isc.OnLedgerRequest))
+				if onLedgerReq, ok := req.(isc.OnLedgerRequest); ok {
+					unprocessable = append(unprocessable, onLedgerReq)
+				}
 			}
 
 			// some requests are just ignored (deterministically)
@@ -228,7 +252,7 @@ func (vmctx *vmContext) runRequests(
 		}
 		numSuccess++
 
-		isRetry := reqIndex >= len(reqs)
+		isRetry := reqIndex >= numInitial
 		if isRetry {
 			vmctx.removeUnprocessable(req.ID())
 		}
@@ -17,6 +17,8 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/vm/vmexceptions"
 )
 
+var errScheduledRequestCancelled = errors.New("scheduled request was cancelled")
+
 const (
 	// ExpiryUnlockSafetyWindowDuration creates safety window around time assumption,
 	// the UTXO won't be consumed to avoid race conditions
@@ -43,9 +45,25 @@ func (reqctx *requestContext) earlyCheckReasonToSkip(maintenanceMode bool) error
 	if reqctx.req.IsOffLedger() {
 		return reqctx.checkReasonToSkipOffLedger()
 	}
+	if _, ok := reqctx.req.(*isc.ScheduledRequest); ok {
+		return reqctx.checkReasonToSkipScheduled()
+	}
 	return reqctx.checkReasonToSkipOnLedger()
 }
 
+// checkReasonToSkipScheduled checks if the scheduled call was cancelled by a previous request in the block
+func (reqctx *requestContext) checkReasonToSkipScheduled() error {
+	req := reqctx.req.(*isc.ScheduledRequest)
+	var exists bool
+	withContractState(reqctx.uncommittedState, blocklog.Contract, func(s kv.KVStore) {
+		exists = blocklog.GetScheduledRequest(s, req.ScheduleID()) != nil
+	})
+	if !exists {
+		return errScheduledRequestCancelled
+	}
+	return reqctx.checkReasonRequestProcessed()
+}
+
 // checkReasonRequestProcessed checks if request ID is already in the blocklog
 func (reqctx *requestContext) checkReasonRequestProcessed() error {
 	reqid := reqctx.req.ID()
@@ -65,8 +83,10 @@ func (reqctx *requestContext) checkReasonToSkipOffLedger() error {
 		return nil
 	}
 	offledgerReq := reqctx.req.(isc.OffLedgerRequest)
-	if err := offledgerReq.VerifySignature(); err != nil {
-		return err
+	if !reqctx.vm.task.EnableImpersonation || !isc.IsImpersonatedRequest(offledgerReq) {
+		if err := offledgerReq.VerifySignature(); err != nil {
+			return err
+		}
 	}
 	senderAccount := offledgerReq.SenderAccount()
 
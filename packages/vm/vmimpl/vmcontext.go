@@ -81,6 +81,12 @@ type requestGas struct {
 	burned uint64
 	// tokens charged
 	feeCharged uint64
+	// fee record of the target contract applied to the fee, nil if the chain fee policy is in effect
+	contractFees *governance.ContractFeesRecord
+	// the sender can't cover the fees of the target contract for the gas budget, the request fails
+	contractFeesUnaffordable bool
+	// agent paying the gas fee on behalf of the sender, nil if the sender pays
+	sponsor isc.AgentID
 	// burn history. If disabled, it is nil
 	burnLog *gas.BurnLog
 }
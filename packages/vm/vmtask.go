@@ -34,6 +34,12 @@ type VMTask struct {
 	EnableGasBurnLogging bool // for testing and Solo only
 
 	Migrations *migrations.MigrationScheme // for testing and Solo only
+	// If EnableImpersonation is set, the impersonated requests are executed without
+	// signature checks (for Solo only)
+	EnableImpersonation bool
+	// StatePatches are applied to the chain state before running the requests, without
+	// changing the schema version (for Solo only)
+	StatePatches []migrations.Migration
 
 	Log *logger.Logger
 }
@@ -0,0 +1,197 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package wasmhost
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/nnikolash/wasp-types-exported/packages/wasmvm/wasminterp"
+)
+
+var (
+	interpHostParams  = []wasminterp.ValType{wasminterp.I32, wasminterp.I32, wasminterp.I32, wasminterp.I32}
+	interpHostFunc    = &wasminterp.FuncType{Params: interpHostParams}
+	interpHostFuncI32 = &wasminterp.FuncType{Params: interpHostParams, Results: []wasminterp.ValType{wasminterp.I32}}
+)
+
+// WasmInterpVM runs Wasm code on the pure-Go interpreter, which does not need cgo.
+// It burns exactly the same amount of gas as WasmTimeVM.
+type WasmInterpVM struct {
+	WasmVMBase
+	instance   *wasminterp.Instance
+	linker     *wasminterp.Linker
+	memory     *wasminterp.Memory
+	module     *wasminterp.Module
+	store      *wasminterp.Store
+	lastBudget uint64
+}
+
+func NewWasmInterpVM() WasmVM {
+	vm := &WasmInterpVM{}
+	// prevent WasmVMBase from starting timeout interrupting,
+	// instead we simply let the interpreter run out of fuel
+	vm.timeoutStarted = true // DisableWasmTimeout
+	return vm
+}
+
+// GasBudget sets the gas budget for the VM.
+func (vm *WasmInterpVM) GasBudget(budget uint64) {
+	// save budget, so we can later determine how much the VM burned
+	vm.lastBudget = budget
+
+	// new budget for VM, top up to desired budget
+	err := vm.store.AddFuel(budget)
+	if err != nil {
+		panic("GasBudget.set: " + err.Error())
+	}
+
+	// consume 0 fuel to determine remaining budget
+	remainingBudget, err := vm.store.ConsumeFuel(0)
+	if err != nil {
+		panic("GasBudget.determine: " + err.Error())
+	}
+
+	if remainingBudget > budget {
+		// burn excess budget
+		_, err = vm.store.ConsumeFuel(remainingBudget - budget)
+		if err != nil {
+			panic("GasBudget.burn: " + err.Error())
+		}
+	}
+}
+
+// GasBurned will return the gas burned since the last time GasBudget() was called
+func (vm *WasmInterpVM) GasBurned() uint64 {
+	// consume 0 fuel to determine remaining budget
+	remainingBudget, err := vm.store.ConsumeFuel(0)
+	if err != nil {
+		vm.wc.proc.log.Infof("GasBurned.determine: " + err.Error())
+	}
+
+	burned := vm.lastBudget - remainingBudget
+	return burned
+}
+
+func (vm *WasmInterpVM) Interrupt() {
+	// the interpreter is stopped by running out of fuel
+}
+
+func (vm *WasmInterpVM) LinkHost() (err error) {
+	vm.store = wasminterp.NewStore(true)
+	vm.linker = wasminterp.NewLinker()
+
+	// new Wasm VM interface
+	err = vm.linker.DefineFunc(ModuleWasmLib, FuncHostStateGet, interpHostFuncI32, func(args []uint64) []uint64 {
+		return interpResult(vm.HostStateGet(int32(args[0]), int32(args[1]), int32(args[2]), int32(args[3])))
+	})
+	if err != nil {
+		return err
+	}
+	err = vm.linker.DefineFunc(ModuleWasmLib, FuncHostStateSet, interpHostFunc, func(args []uint64) []uint64 {
+		vm.HostStateSet(int32(args[0]), int32(args[1]), int32(args[2]), int32(args[3]))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// AssemblyScript Wasm versions uses this one to write panic message to console
+	err = vm.linker.DefineFunc(ModuleEnv, FuncAbort, interpHostFunc, func(args []uint64) []uint64 {
+		vm.HostAbort(int32(args[0]), int32(args[1]), int32(args[2]), int32(args[3]))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// TinyGo Wasm versions uses this one to write panic message to console
+	fdWrite := func(args []uint64) []uint64 {
+		return interpResult(vm.HostFdWrite(int32(args[0]), int32(args[1]), int32(args[2]), int32(args[3])))
+	}
+	err = vm.linker.DefineFunc(ModuleWasi1, FuncFdWrite, interpHostFuncI32, fdWrite)
+	if err != nil {
+		return err
+	}
+	return vm.linker.DefineFunc(ModuleWasi2, FuncFdWrite, interpHostFuncI32, fdWrite)
+}
+
+func interpResult(result int32) []uint64 {
+	return []uint64{uint64(uint32(result))}
+}
+
+func (vm *WasmInterpVM) LoadWasm(wasmData []byte) (err error) {
+	vm.module, err = wasminterp.NewModule(wasmData)
+	return err
+}
+
+func (vm *WasmInterpVM) NewInstance(wc *WasmContext) WasmVM {
+	if vm.wc == nil {
+		vm.wc = wc
+	}
+
+	// unlike WasmTime, the store does not retain the instances,
+	// so there is no need to ever relink to get rid of them
+	vmInstance := &WasmInterpVM{
+		module: vm.module,
+		linker: vm.linker,
+		store:  vm.store,
+	}
+	vmInstance.wc = wc
+	vmInstance.timeoutStarted = true // DisableWasmTimeout
+	err := vmInstance.newInstance()
+	if err != nil {
+		panic("cannot instantiate: " + err.Error())
+	}
+	return vmInstance
+}
+
+func (vm *WasmInterpVM) newInstance() (err error) {
+	vm.GasBudget(1_000_000)
+	vm.wc.GasDisable(true)
+	vm.instance, err = vm.linker.Instantiate(vm.store, vm.module)
+	vm.wc.GasDisable(false)
+	if err != nil {
+		return err
+	}
+	vm.memory = vm.instance.Memory("memory")
+	if vm.memory == nil {
+		return errors.New("no memory export")
+	}
+	return nil
+}
+
+func (vm *WasmInterpVM) RunFunction(functionName string, args ...interface{}) error {
+	params := make([]uint64, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case int32:
+			params[i] = uint64(uint32(v))
+		case int64:
+			params[i] = uint64(v)
+		case float32:
+			params[i] = uint64(math.Float32bits(v))
+		case float64:
+			params[i] = math.Float64bits(v)
+		default:
+			return fmt.Errorf("unsupported argument type %T", arg)
+		}
+	}
+	return vm.Run(func() (err error) {
+		_, err = vm.instance.Call(functionName, params...)
+		return err
+	})
+}
+
+func (vm *WasmInterpVM) RunScFunction(index int32) error {
+	return vm.Run(func() (err error) {
+		_, err = vm.instance.Call("on_call", uint64(uint32(index)))
+		return err
+	})
+}
+
+func (vm *WasmInterpVM) UnsafeMemory() []byte {
+	return vm.memory.Data()
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build cgo && !no_wasmhost
+
+package wasmhost_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/packages/hashing"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/solo"
+	"github.com/nnikolash/wasp-types-exported/packages/wasmvm/wasmhost"
+)
+
+const (
+	testcoreName = "testcore"
+	testcoreWasm = "../../vm/core/testcore/sbtests/sbtestsc/testcore_bg.wasm"
+)
+
+type gasResult struct {
+	burned uint64
+	err    string
+}
+
+// runTestcore runs a series of requests on the Rust testcore contract with the given Wasm VM
+func runTestcore(t *testing.T, newVM func() wasmhost.WasmVM) []gasResult {
+	defaultVM := wasmhost.DefaultWasmVM
+	wasmhost.DefaultWasmVM = newVM
+	defer func() { wasmhost.DefaultWasmVM = defaultVM }()
+
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	chain := env.NewChain()
+	require.NoError(t, chain.DeployWasmContract(nil, testcoreName, testcoreWasm))
+
+	requests := []*solo.CallParams{
+		solo.NewCallParams(testcoreName, "doNothing"),
+		solo.NewCallParams(testcoreName, "setInt", "intParamName", "foo", "intParamValue", int64(42)),
+		solo.NewCallParams(testcoreName, "incCounter"),
+		solo.NewCallParams(testcoreName, "incCounter"),
+		// nested calls share the fuel of the store between the instances
+		solo.NewCallParams(testcoreName, "callOnChain",
+			"n", uint64(5),
+			"hnameContract", isc.Hn(testcoreName),
+			"hnameEP", isc.Hn("runRecursion"),
+		),
+		solo.NewCallParams(testcoreName, "testPanicFullEP"),
+		solo.NewCallParams(testcoreName, "passTypesFull",
+			"address", chain.ChainID.AsAddress(),
+			"agentID", chain.OriginatorAgentID,
+			"chainID", chain.ChainID,
+			"contractID", isc.NewContractAgentID(chain.ChainID, isc.Hn(testcoreName)),
+			"Hash", hashing.HashStrings("Hash"),
+			"Hname", isc.Hn("Hname"),
+			"Hname-0", isc.Hname(0),
+			"int64", 42,
+			"int64-0", 0,
+			"string", "string",
+			"string-0", "",
+		),
+		solo.NewCallParams(testcoreName, "infiniteLoop").WithGasBudget(100_000),
+	}
+
+	results := make([]gasResult, 0, len(requests))
+	for _, req := range requests {
+		if req.GasBudget() == 0 {
+			req.WithMaxAffordableGasBudget()
+		}
+		_, err := chain.PostRequestSync(req.AddBaseTokens(1*isc.Million), nil)
+		result := gasResult{burned: chain.LastReceipt().GasBurned}
+		if err != nil {
+			result.err = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	res, err := chain.CallView(testcoreName, "getInt", "intParamName", "foo")
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Get("foo"))
+	return results
+}
+
+func TestWasmInterpVMGasConformance(t *testing.T) {
+	expected := runTestcore(t, wasmhost.NewWasmTimeVM)
+	actual := runTestcore(t, wasmhost.NewWasmInterpVM)
+	require.Equal(t, expected, actual)
+
+	// the infinite loop must have run out of gas
+	require.Contains(t, actual[len(actual)-1].err, "gas budget exceeded")
+}
@@ -36,9 +36,10 @@ func GetProcessor(wasmBytes []byte, log *logger.Logger) (isc.VMProcessor, error)
 		log:        log,
 	}
 
-	// By default, we will use WasmTimeVM, but this can be overruled by setting GoWasmVm
+	// By default, we will use WasmTimeVM, or WasmInterpVM when WasmTime is not available,
+	// but this can be overruled by setting GoWasmVm
 	// This setting will also be propagated to all the sub-processors of this processor
-	wasmVM := NewWasmTimeVM
+	wasmVM := DefaultWasmVM
 	if GoWasmVM != nil {
 		// note that this will never happen except with Solo tests that explicitly
 		// bypass the Wasm VM by using the WasmGoVM, which runs the Go SC code directly
@@ -1,6 +1,6 @@
 // Copyright 2020 IOTA Stiftung
 // SPDX-License-Identifier: Apache-2.0
-//go:build !no_wasmhost
+//go:build !no_wasmhost && cgo
 
 package wasmhost
 
@@ -22,6 +22,9 @@ type WasmTimeVM struct {
 	instances  uint32
 }
 
+// DefaultWasmVM creates the Wasm VM that is used unless overruled by GoWasmVM
+var DefaultWasmVM = NewWasmTimeVM
+
 func NewWasmTimeVM() WasmVM {
 	config := wasmtime.NewConfig()
 	// no need to be interruptable by WasmVMBase
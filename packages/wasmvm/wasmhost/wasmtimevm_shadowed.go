@@ -1,6 +1,6 @@
 // Copyright 2020 IOTA Stiftung
 // SPDX-License-Identifier: Apache-2.0
-//go:build no_wasmhost
+//go:build no_wasmhost || !cgo
 
 package wasmhost
 
@@ -8,6 +8,10 @@ type WasmTimeVM struct {
 	WasmVMBase
 }
 
+// DefaultWasmVM creates the Wasm VM that is used unless overruled by GoWasmVM.
+// Without WasmTime, the Wasm code runs on the pure-Go interpreter.
+var DefaultWasmVM = NewWasmInterpVM
+
 func NewWasmTimeVM() WasmVM {
 	return nil
 }
@@ -0,0 +1,638 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package wasminterp
+
+// instr is a compiled instruction.
+// The meaning of the arguments a, b and c depends on the opcode.
+// The branch instructions use a for the target pc, b for the number of values
+// that are passed to the target and c for the stack height at the target.
+// Any instruction where Wasmtime updates its fuel counter carries the fuel
+// burned by the preceding straight-line code, including itself.
+type instr struct {
+	op   opcode
+	fuel uint32
+	a    uint32
+	b    uint32
+	c    uint64
+}
+
+type branchTarget struct {
+	pc     uint32
+	arity  uint32
+	height uint32
+}
+
+// function is a compiled function body.
+type function struct {
+	typ       *FuncType
+	numParams int
+	numLocals int
+	maxStack  int
+	code      []instr
+	brTables  []branchTarget
+}
+
+const (
+	frameBlock = iota
+	frameLoop
+	frameIf
+	frameElse
+	frameFunc
+)
+
+type ctrlFrame struct {
+	kind        int
+	params      []ValType
+	results     []ValType
+	height      int
+	unreachable bool
+	// start is the pc of the loop header
+	start int
+	// ifPC is the pc of the if instruction that still needs its else target
+	ifPC int
+	// fixups are the branch instructions that target the end of the frame
+	fixups []int
+	// tableFixups are the br_table targets that target the end of the frame
+	tableFixups []int
+}
+
+func (f *ctrlFrame) labelTypes() []ValType {
+	if f.kind == frameLoop {
+		return f.params
+	}
+	return f.results
+}
+
+// compiler validates a function body and translates it into instructions.
+type compiler struct {
+	m      *Module
+	r      *reader
+	fn     *function
+	locals []ValType
+	types  []ValType
+	ctrls  []*ctrlFrame
+	// fuel is burned by the instructions since the last fuel update
+	fuel uint32
+}
+
+func (m *Module) compileFunction(funcIdx uint32, r *reader) *function {
+	ft := m.funcType(funcIdx)
+	c := &compiler{m: m, r: r}
+	c.locals = append(c.locals, ft.Params...)
+	for n := r.count(); n > 0; n-- {
+		count := r.u32()
+		t := decodeValType(r)
+		if uint64(len(c.locals))+uint64(count) > maxFunctionLocals {
+			fail("too many locals")
+		}
+		for i := uint32(0); i < count; i++ {
+			c.locals = append(c.locals, t)
+		}
+	}
+	c.fn = &function{typ: ft, numParams: len(ft.Params), numLocals: len(c.locals)}
+	c.pushCtrl(frameFunc, nil, ft.Results)
+	for len(c.ctrls) != 0 {
+		c.compileInstr()
+	}
+	if !r.eof() {
+		fail("operators remaining after end of function")
+	}
+	return c.fn
+}
+
+func (c *compiler) top() *ctrlFrame {
+	return c.ctrls[len(c.ctrls)-1]
+}
+
+func (c *compiler) label(depth uint32) *ctrlFrame {
+	if depth >= uint32(len(c.ctrls)) {
+		fail("unknown label: branch depth too large")
+	}
+	return c.ctrls[len(c.ctrls)-1-int(depth)]
+}
+
+func (c *compiler) push(t ValType) {
+	c.types = append(c.types, t)
+	if len(c.types) > c.fn.maxStack {
+		c.fn.maxStack = len(c.types)
+	}
+}
+
+func (c *compiler) pushTypes(types []ValType) {
+	for _, t := range types {
+		c.push(t)
+	}
+}
+
+func (c *compiler) pop() ValType {
+	f := c.top()
+	if len(c.types) == f.height {
+		if f.unreachable {
+			return unknown
+		}
+		fail("type mismatch: operand stack underflow")
+	}
+	t := c.types[len(c.types)-1]
+	c.types = c.types[:len(c.types)-1]
+	return t
+}
+
+func (c *compiler) popExpect(expected ValType) ValType {
+	t := c.pop()
+	if t != expected && t != unknown && expected != unknown {
+		fail("type mismatch: expected %s, got %s", expected, t)
+	}
+	return t
+}
+
+// popTypes pops the expected types and returns the actual types.
+func (c *compiler) popTypes(expected []ValType) []ValType {
+	actual := make([]ValType, len(expected))
+	for i := len(expected) - 1; i >= 0; i-- {
+		actual[i] = c.popExpect(expected[i])
+	}
+	return actual
+}
+
+func (c *compiler) pushCtrl(kind int, params, results []ValType) *ctrlFrame {
+	f := &ctrlFrame{kind: kind, params: params, results: results, height: len(c.types), ifPC: -1}
+	c.ctrls = append(c.ctrls, f)
+	c.pushTypes(params)
+	return f
+}
+
+func (c *compiler) popCtrl() *ctrlFrame {
+	f := c.top()
+	c.popTypes(f.results)
+	if len(c.types) != f.height {
+		fail("type mismatch: values remaining on stack at end of block")
+	}
+	c.ctrls = c.ctrls[:len(c.ctrls)-1]
+	return f
+}
+
+func (c *compiler) setUnreachable() {
+	f := c.top()
+	c.types = c.types[:f.height]
+	f.unreachable = true
+}
+
+func (c *compiler) emit(op opcode, a, b uint32, v uint64) int {
+	c.fn.code = append(c.fn.code, instr{op: op, a: a, b: b, c: v})
+	return len(c.fn.code) - 1
+}
+
+// emitFlush emits an instruction that burns the pending fuel.
+func (c *compiler) emitFlush(op opcode, a, b uint32, v uint64) int {
+	pc := c.emit(op, a, b, v)
+	c.fn.code[pc].fuel = c.fuel
+	c.fuel = 0
+	return pc
+}
+
+func (c *compiler) flush() {
+	if c.fuel != 0 {
+		c.emitFlush(opFuel, 0, 0, 0)
+	}
+}
+
+func (c *compiler) emitBranch(op opcode, f *ctrlFrame) {
+	arity := len(f.labelTypes())
+	pc := c.emitFlush(op, 0, uint32(arity), uint64(c.fn.numLocals+f.height))
+	if f.kind == frameLoop {
+		c.fn.code[pc].a = uint32(f.start)
+		return
+	}
+	f.fixups = append(f.fixups, pc)
+}
+
+func (c *compiler) branchTarget(f *ctrlFrame) branchTarget {
+	target := branchTarget{
+		arity:  uint32(len(f.labelTypes())),
+		height: uint32(c.fn.numLocals + f.height),
+	}
+	if f.kind == frameLoop {
+		target.pc = uint32(f.start)
+		return target
+	}
+	f.tableFixups = append(f.tableFixups, len(c.fn.brTables))
+	return target
+}
+
+func (c *compiler) blockType() *FuncType {
+	if c.r.eof() {
+		panic(&decodeError{err: errUnexpectedEnd})
+	}
+	b := c.r.data[c.r.pos]
+	switch {
+	case b == 0x40:
+		c.r.pos++
+		return &FuncType{}
+	case b > 0x40 && b < 0x80:
+		return &FuncType{Results: []ValType{decodeValType(c.r)}}
+	}
+	idx := c.r.s33()
+	if idx < 0 || idx >= int64(len(c.m.types)) {
+		fail("unknown type %d", idx)
+	}
+	return c.m.types[idx]
+}
+
+func (c *compiler) localIndex() uint32 {
+	idx := c.r.u32()
+	if idx >= uint32(len(c.locals)) {
+		fail("unknown local %d", idx)
+	}
+	return idx
+}
+
+func (c *compiler) globalIndex() uint32 {
+	idx := c.r.u32()
+	if idx >= uint32(len(c.m.globals)) {
+		fail("unknown global %d", idx)
+	}
+	return idx
+}
+
+func (c *compiler) tableIndex() uint32 {
+	idx := c.r.u32()
+	if idx >= uint32(len(c.m.tables)) {
+		fail("unknown table %d", idx)
+	}
+	return idx
+}
+
+func (c *compiler) elemIndex() uint32 {
+	idx := c.r.u32()
+	if idx >= uint32(len(c.m.elems)) {
+		fail("unknown elem segment %d", idx)
+	}
+	return idx
+}
+
+func (c *compiler) dataIndex() uint32 {
+	if !c.m.hasCount {
+		fail("data count section required")
+	}
+	idx := c.r.u32()
+	if idx >= c.m.dataCount {
+		fail("unknown data segment %d", idx)
+	}
+	return idx
+}
+
+func (c *compiler) memoryIndex() {
+	if c.r.byte() != 0x00 {
+		fail("zero byte expected")
+	}
+	c.checkMemory()
+}
+
+func (c *compiler) checkMemory() {
+	if len(c.m.memories) == 0 {
+		fail("unknown memory 0")
+	}
+}
+
+//nolint:funlen,gocyclo
+func (c *compiler) compileInstr() {
+	op := opcode(c.r.byte())
+	switch op {
+	case opPrefixFC:
+		sub := c.r.u32()
+		if sub > opTableFill-0x100 {
+			fail("unknown 0xfc subopcode %d", sub)
+		}
+		op = 0x100 + opcode(sub)
+	case opPrefixFD:
+		fail("SIMD is not supported")
+	}
+	if !c.top().unreachable {
+		c.fuel += fuelCost(op)
+	}
+
+	if sig, ok := numericOps[op]; ok {
+		c.popTypes(sig.params)
+		c.push(sig.result)
+		c.emit(op, 0, 0, 0)
+		return
+	}
+	if mop, ok := memoryOps[op]; ok {
+		align := c.r.u32()
+		offset := c.r.u32()
+		c.checkMemory()
+		if align >= 32 || uint32(1)<<align > mop.size {
+			fail("alignment must not be larger than natural")
+		}
+		if op < opI32Store {
+			c.popExpect(I32)
+			c.push(mop.typ)
+		} else {
+			c.popExpect(mop.typ)
+			c.popExpect(I32)
+		}
+		c.emit(op, offset, 0, 0)
+		return
+	}
+
+	switch op {
+	case opUnreachable:
+		c.emitFlush(op, 0, 0, 0)
+		c.setUnreachable()
+	case opNop:
+	case opBlock:
+		bt := c.blockType()
+		c.popTypes(bt.Params)
+		c.pushCtrl(frameBlock, bt.Params, bt.Results)
+	case opLoop:
+		bt := c.blockType()
+		c.popTypes(bt.Params)
+		c.flush()
+		f := c.pushCtrl(frameLoop, bt.Params, bt.Results)
+		f.start = c.emit(opFuelCheck, 0, 0, 0)
+	case opIf:
+		bt := c.blockType()
+		c.popExpect(I32)
+		c.popTypes(bt.Params)
+		pc := c.emitFlush(op, 0, 0, 0)
+		f := c.pushCtrl(frameIf, bt.Params, bt.Results)
+		f.ifPC = pc
+	case opElse:
+		f := c.top()
+		if f.kind != frameIf {
+			fail("else found outside of an if block")
+		}
+		c.popTypes(f.results)
+		if len(c.types) != f.height {
+			fail("type mismatch: values remaining on stack at end of block")
+		}
+		f.fixups = append(f.fixups, c.emitFlush(opJump, 0, 0, 0))
+		c.fn.code[f.ifPC].a = uint32(len(c.fn.code))
+		f.ifPC = -1
+		f.kind = frameElse
+		f.unreachable = false
+		c.pushTypes(f.params)
+	case opEnd:
+		c.compileEnd()
+	case opBr:
+		f := c.label(c.r.u32())
+		c.popTypes(f.labelTypes())
+		c.emitBranch(op, f)
+		c.setUnreachable()
+	case opBrIf:
+		f := c.label(c.r.u32())
+		c.popExpect(I32)
+		types := f.labelTypes()
+		c.popTypes(types)
+		c.emitBranch(op, f)
+		c.pushTypes(types)
+	case opBrTable:
+		c.compileBrTable()
+	case opReturn:
+		f := c.ctrls[0]
+		c.popTypes(f.results)
+		c.emitFlush(op, 0, uint32(len(f.results)), 0)
+		c.setUnreachable()
+	case opCall:
+		funcIdx := c.r.u32()
+		if funcIdx >= uint32(len(c.m.funcTypes)) {
+			fail("unknown function %d", funcIdx)
+		}
+		ft := c.m.funcType(funcIdx)
+		c.popTypes(ft.Params)
+		c.emitFlush(op, funcIdx, 0, 0)
+		c.pushTypes(ft.Results)
+	case opCallIndirect:
+		typeIdx := c.m.typeIndex(c.r.u32())
+		tableIdx := c.tableIndex()
+		if c.m.tables[tableIdx].elem != FuncRef {
+			fail("type mismatch: indirect calls must go through a table of funcref")
+		}
+		ft := c.m.types[typeIdx]
+		c.popExpect(I32)
+		c.popTypes(ft.Params)
+		c.emitFlush(op, typeIdx, tableIdx, 0)
+		c.pushTypes(ft.Results)
+	case opDrop:
+		c.pop()
+		c.emit(op, 0, 0, 0)
+	case opSelect:
+		c.popExpect(I32)
+		t1 := c.pop()
+		t2 := c.pop()
+		if t1.isRef() || t2.isRef() {
+			fail("type mismatch: select only takes numeric types")
+		}
+		if t1 != t2 && t1 != unknown && t2 != unknown {
+			fail("type mismatch: select operands have different types")
+		}
+		if t1 == unknown {
+			t1 = t2
+		}
+		c.push(t1)
+		c.emit(opSelect, 0, 0, 0)
+	case opSelectT:
+		if c.r.u32() != 1 {
+			fail("invalid result arity")
+		}
+		t := decodeValType(c.r)
+		c.popExpect(I32)
+		c.popExpect(t)
+		c.popExpect(t)
+		c.push(t)
+		c.emit(opSelect, 0, 0, 0)
+	case opLocalGet:
+		idx := c.localIndex()
+		c.push(c.locals[idx])
+		c.emit(op, idx, 0, 0)
+	case opLocalSet:
+		idx := c.localIndex()
+		c.popExpect(c.locals[idx])
+		c.emit(op, idx, 0, 0)
+	case opLocalTee:
+		idx := c.localIndex()
+		c.popExpect(c.locals[idx])
+		c.push(c.locals[idx])
+		c.emit(op, idx, 0, 0)
+	case opGlobalGet:
+		idx := c.globalIndex()
+		c.push(c.m.globals[idx].typ)
+		c.emit(op, idx, 0, 0)
+	case opGlobalSet:
+		idx := c.globalIndex()
+		if !c.m.globals[idx].mutable {
+			fail("global is immutable")
+		}
+		c.popExpect(c.m.globals[idx].typ)
+		c.emit(op, idx, 0, 0)
+	case opTableGet:
+		idx := c.tableIndex()
+		c.popExpect(I32)
+		c.push(c.m.tables[idx].elem)
+		c.emit(op, idx, 0, 0)
+	case opTableSet:
+		idx := c.tableIndex()
+		c.popExpect(c.m.tables[idx].elem)
+		c.popExpect(I32)
+		c.emit(op, idx, 0, 0)
+	case opMemorySize:
+		c.memoryIndex()
+		c.push(I32)
+		c.emit(op, 0, 0, 0)
+	case opMemoryGrow:
+		c.memoryIndex()
+		c.popExpect(I32)
+		c.push(I32)
+		c.emit(op, 0, 0, 0)
+	case opI32Const:
+		c.push(I32)
+		c.emit(op, 0, 0, uint64(uint32(c.r.s32())))
+	case opI64Const:
+		c.push(I64)
+		c.emit(op, 0, 0, uint64(c.r.s64()))
+	case opF32Const:
+		c.push(F32)
+		c.emit(op, 0, 0, uint64(c.r.u32le()))
+	case opF64Const:
+		c.push(F64)
+		c.emit(op, 0, 0, c.r.u64le())
+	case opRefNull:
+		c.push(decodeRefType(c.r))
+		c.emit(op, 0, 0, 0)
+	case opRefIsNull:
+		if t := c.pop(); !t.isRef() && t != unknown {
+			fail("type mismatch: expected reference type, got %s", t)
+		}
+		c.push(I32)
+		c.emit(op, 0, 0, 0)
+	case opRefFunc:
+		funcIdx := c.r.u32()
+		if funcIdx >= uint32(len(c.m.funcTypes)) {
+			fail("unknown function %d", funcIdx)
+		}
+		if !c.m.declaredRefs[funcIdx] {
+			fail("undeclared function reference")
+		}
+		c.push(FuncRef)
+		c.emit(op, funcIdx, 0, 0)
+	case opMemoryInit:
+		idx := c.dataIndex()
+		c.memoryIndex()
+		c.popTypes([]ValType{I32, I32, I32})
+		c.emit(op, idx, 0, 0)
+	case opDataDrop:
+		c.emit(op, c.dataIndex(), 0, 0)
+	case opMemoryCopy:
+		c.memoryIndex()
+		c.memoryIndex()
+		c.popTypes([]ValType{I32, I32, I32})
+		c.emit(op, 0, 0, 0)
+	case opMemoryFill:
+		c.memoryIndex()
+		c.popTypes([]ValType{I32, I32, I32})
+		c.emit(op, 0, 0, 0)
+	case opTableInit:
+		elemIdx := c.elemIndex()
+		tableIdx := c.tableIndex()
+		if c.m.elems[elemIdx].typ != c.m.tables[tableIdx].elem {
+			fail("type mismatch: element segment does not match table type")
+		}
+		c.popTypes([]ValType{I32, I32, I32})
+		c.emit(op, elemIdx, tableIdx, 0)
+	case opElemDrop:
+		c.emit(op, c.elemIndex(), 0, 0)
+	case opTableCopy:
+		dst := c.tableIndex()
+		src := c.tableIndex()
+		if c.m.tables[dst].elem != c.m.tables[src].elem {
+			fail("type mismatch: tables have different element types")
+		}
+		c.popTypes([]ValType{I32, I32, I32})
+		c.emit(op, dst, src, 0)
+	case opTableGrow:
+		idx := c.tableIndex()
+		c.popExpect(I32)
+		c.popExpect(c.m.tables[idx].elem)
+		c.push(I32)
+		c.emit(op, idx, 0, 0)
+	case opTableSize:
+		idx := c.tableIndex()
+		c.push(I32)
+		c.emit(op, idx, 0, 0)
+	case opTableFill:
+		idx := c.tableIndex()
+		c.popExpect(I32)
+		c.popExpect(c.m.tables[idx].elem)
+		c.popExpect(I32)
+		c.emit(op, idx, 0, 0)
+	default:
+		fail("illegal opcode 0x%x", op)
+	}
+}
+
+func (c *compiler) compileEnd() {
+	f := c.top()
+	if f.kind == frameIf && !equalTypes(f.params, f.results) {
+		fail("type mismatch: if without else must leave its parameters as results")
+	}
+	c.popCtrl()
+
+	// the code after the end is the target of all branches to this frame,
+	// so burn the fuel of the code that falls through first
+	c.flush()
+	label := len(c.fn.code)
+	if f.kind == frameFunc {
+		c.emit(opReturn, 0, uint32(len(f.results)), 0)
+	}
+	if f.ifPC >= 0 {
+		c.fn.code[f.ifPC].a = uint32(label)
+	}
+	for _, pc := range f.fixups {
+		c.fn.code[pc].a = uint32(label)
+	}
+	for _, i := range f.tableFixups {
+		c.fn.brTables[i].pc = uint32(label)
+	}
+	if f.kind != frameFunc {
+		c.pushTypes(f.results)
+	}
+}
+
+func (c *compiler) compileBrTable() {
+	n := c.r.count()
+	depths := make([]uint32, n+1)
+	for i := range depths {
+		depths[i] = c.r.u32()
+	}
+	c.popExpect(I32)
+	arity := len(c.label(depths[n]).labelTypes())
+	for _, depth := range depths[:n] {
+		types := c.label(depth).labelTypes()
+		if len(types) != arity {
+			fail("type mismatch: br_table targets have different arity")
+		}
+		c.pushTypes(c.popTypes(types))
+	}
+	c.popTypes(c.label(depths[n]).labelTypes())
+
+	start := len(c.fn.brTables)
+	for _, depth := range depths {
+		target := c.branchTarget(c.label(depth))
+		c.fn.brTables = append(c.fn.brTables, target)
+	}
+	c.emitFlush(opBrTable, uint32(start), n, 0)
+	c.setUnreachable()
+}
+
+func equalTypes(a, b []ValType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
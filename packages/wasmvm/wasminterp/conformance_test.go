@@ -0,0 +1,169 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build cgo
+
+package wasminterp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/packages/wasmvm/wasminterp"
+)
+
+const conformanceWat = `(module
+	(type $i2i (func (param i32) (result i32)))
+	(memory 1)
+	(table 3 funcref)
+	(elem (i32.const 0) $id $double)
+	(global $g (mut i32) (i32.const 0))
+	(data (i32.const 16) "\01\02\03\04")
+
+	(func $id (param i32) (result i32) local.get 0)
+	(func $double (param i32) (result i32) (i32.mul (local.get 0) (i32.const 2)))
+	(func $fib (export "fib") (param i32) (result i32)
+		(if (result i32) (i32.lt_u (local.get 0) (i32.const 2))
+			(then local.get 0)
+			(else (i32.add
+				(call $fib (i32.sub (local.get 0) (i32.const 1)))
+				(call $fib (i32.sub (local.get 0) (i32.const 2)))))))
+	(func (export "loop") (param i32) (result i32) (local i32)
+		(loop $l
+			(local.set 1 (i32.add (local.get 1) (i32.const 1)))
+			(br_if $l (i32.lt_u (local.get 1) (local.get 0))))
+		local.get 1)
+	(func (export "divLoop") (param i32) (result i32) (local i32)
+		(loop $l
+			(local.set 1 (i32.add (local.get 1) (i32.const 1)))
+			(br_if $l (i32.lt_u (local.get 1) (i32.const 10))))
+		(i32.div_u (local.get 1) (local.get 0)))
+	(func (export "brTable") (param i32) (result i32)
+		(block (block (block (br_table 0 1 2 (local.get 0)))
+			(return (i32.const 10)))
+			(return (i32.const 11)))
+		i32.const 12)
+	(func (export "indirect") (param i32) (result i32)
+		(call_indirect (type $i2i) (i32.const 21) (local.get 0)))
+	(func (export "memory") (param i32) (result i32)
+		(i32.store (local.get 0) (i32.load (i32.const 16)))
+		(i32.load8_u (i32.add (local.get 0) (i32.const 2))))
+	(func (export "grow") (param i32) (result i32)
+		(drop (memory.grow (local.get 0)))
+		memory.size)
+	(func (export "bulk") (param i32) (result i32)
+		(memory.fill (i32.const 100) (i32.const 7) (local.get 0))
+		(memory.copy (i32.const 200) (i32.const 99) (local.get 0))
+		(i32.load (i32.const 200)))
+	(func (export "global") (param i32) (result i32)
+		(global.set $g (i32.add (global.get $g) (local.get 0)))
+		global.get $g)
+	(func (export "unreachable") (param i32) (result i32)
+		(if (local.get 0) (then unreachable))
+		local.get 0)
+	(func (export "select") (param i32) (result i32)
+		(select (i32.const 1) (i32.const 2) (local.get 0)))
+	(func (export "i64") (param i32) (result i32)
+		(i32.wrap_i64 (i64.rotl (i64.extend_i32_s (local.get 0)) (i64.const 33))))
+	(func (export "float") (param i32) (result i32)
+		(i32.trunc_f64_s (f64.mul (f64.convert_i32_s (local.get 0)) (f64.const 2.5))))
+	(func (export "truncSat") (param i32) (result i32)
+		(i32.trunc_sat_f32_u (f32.sub (f32.convert_i32_s (local.get 0)) (f32.const 10))))
+	(func (export "multi") (param i32) (result i32)
+		local.get 0
+		local.get 0
+		(block (param i32 i32) (result i32) i32.add))
+)`
+
+// runInterp calls the function on the interpreter and returns the result, the trap and the fuel consumed
+func runInterp(t *testing.T, wasm []byte, fuel uint64, name string, arg int32) (int32, string, uint64) {
+	module, err := wasminterp.NewModule(wasm)
+	require.NoError(t, err)
+	store := wasminterp.NewStore(true)
+	require.NoError(t, store.AddFuel(fuel))
+	instance, err := wasminterp.NewLinker().Instantiate(store, module)
+	require.NoError(t, err)
+	results, err := instance.Call(name, uint64(uint32(arg)))
+	consumed, _ := store.FuelConsumed()
+	if err != nil {
+		return 0, err.Error(), consumed
+	}
+	return int32(results[0]), "", consumed
+}
+
+// runWasmTime calls the function on Wasmtime and returns the result, the trap and the fuel consumed
+func runWasmTime(t *testing.T, wasm []byte, fuel uint64, name string, arg int32) (int32, string, uint64) {
+	config := wasmtime.NewConfig()
+	config.SetConsumeFuel(true)
+	engine := wasmtime.NewEngineWithConfig(config)
+	store := wasmtime.NewStore(engine)
+	require.NoError(t, store.AddFuel(fuel))
+	module, err := wasmtime.NewModule(engine, wasm)
+	require.NoError(t, err)
+	instance, err := wasmtime.NewLinker(engine).Instantiate(store, module)
+	require.NoError(t, err)
+	result, err := instance.GetExport(store, name).Func().Call(store, arg)
+	consumed, _ := store.FuelConsumed()
+	if err != nil {
+		return 0, err.Error(), consumed
+	}
+	return result.(int32), "", consumed
+}
+
+func TestConformance(t *testing.T) {
+	wasm, err := wasmtime.Wat2Wasm(conformanceWat)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		args []int32
+	}{
+		{"fib", []int32{0, 1, 2, 10}},
+		{"loop", []int32{0, 1, 5, 100}},
+		{"divLoop", []int32{0, 3}},
+		{"brTable", []int32{0, 1, 2, 3, -1}},
+		{"indirect", []int32{0, 1, 2, 3}},
+		{"memory", []int32{0, 100, 65532, 65533, -1}},
+		{"grow", []int32{0, 1, 100000}},
+		{"bulk", []int32{0, 4, 65536}},
+		{"global", []int32{0, 5}},
+		{"unreachable", []int32{0, 1}},
+		{"select", []int32{0, 1}},
+		{"i64", []int32{1, -7}},
+		{"float", []int32{3, -5, 1 << 30}},
+		{"truncSat", []int32{0, 100}},
+		{"multi", []int32{21}},
+	}
+	for _, test := range tests {
+		for _, arg := range test.args {
+			expected, expectedTrap, expectedFuel := runWasmTime(t, wasm, 1_000_000, test.name, arg)
+			actual, actualTrap, actualFuel := runInterp(t, wasm, 1_000_000, test.name, arg)
+			require.Equal(t, expected, actual, "%s(%d)", test.name, arg)
+			require.Equal(t, expectedFuel, actualFuel, "%s(%d)", test.name, arg)
+			if expectedTrap == "" {
+				require.Empty(t, actualTrap, "%s(%d)", test.name, arg)
+				continue
+			}
+			require.NotEmpty(t, actualTrap, "%s(%d)", test.name, arg)
+			require.Contains(t, expectedTrap, strings.TrimPrefix(actualTrap, "wasm trap: "), "%s(%d)", test.name, arg)
+		}
+	}
+}
+
+func TestConformanceOutOfFuel(t *testing.T) {
+	wasm, err := wasmtime.Wat2Wasm(conformanceWat)
+	require.NoError(t, err)
+
+	for fuel := uint64(0); fuel < 200; fuel++ {
+		for _, name := range []string{"fib", "loop"} {
+			expected, expectedTrap, expectedFuel := runWasmTime(t, wasm, fuel, name, 7)
+			actual, actualTrap, actualFuel := runInterp(t, wasm, fuel, name, 7)
+			require.Equal(t, expected, actual, "%s with fuel %d", name, fuel)
+			require.Equal(t, expectedFuel, actualFuel, "%s with fuel %d", name, fuel)
+			require.Equal(t, expectedTrap != "", actualTrap != "", "%s with fuel %d", name, fuel)
+		}
+	}
+}
@@ -0,0 +1,932 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package wasminterp
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+const (
+	initialStackSize = 1024
+	maxStackSize     = 4 * 1024 * 1024
+	maxCallDepth     = 10000
+)
+
+// the trap messages are the same as the ones used by Wasmtime
+const (
+	trapCallStackExhausted    = "call stack exhausted"
+	trapIndirectCallMismatch  = "indirect call type mismatch"
+	trapIntegerDivideByZero   = "integer divide by zero"
+	trapIntegerOverflow       = "integer overflow"
+	trapInvalidConversion     = "invalid conversion to integer"
+	trapMemoryOutOfBounds     = "out of bounds memory access"
+	trapOutOfFuel             = "all fuel consumed by WebAssembly"
+	trapTableOutOfBounds      = "undefined element: out of bounds table access"
+	trapUninitializedElement  = "uninitialized element"
+	trapUnreachableInstrution = "wasm `unreachable` instruction executed"
+)
+
+// Trap is the error that is returned when the execution of WebAssembly code is aborted.
+type Trap struct {
+	msg string
+}
+
+func (t *Trap) Error() string {
+	return "wasm trap: " + t.msg
+}
+
+func trap(msg string) {
+	panic(&Trap{msg: msg})
+}
+
+// execution is the state of a single call into an instance.
+// The value stack holds the locals of each active function, followed by its operands.
+type execution struct {
+	inst  *Instance
+	store *Store
+	stack []uint64
+	depth int
+}
+
+func newExecution(inst *Instance, size int) *execution {
+	if size < initialStackSize {
+		size = initialStackSize
+	}
+	return &execution{inst: inst, store: inst.store, stack: make([]uint64, size)}
+}
+
+func (e *execution) ensureStack(size int) {
+	if size <= len(e.stack) {
+		return
+	}
+	if size > maxStackSize {
+		trap(trapCallStackExhausted)
+	}
+	newSize := 2 * len(e.stack)
+	for newSize < size {
+		newSize *= 2
+	}
+	stack := make([]uint64, newSize)
+	copy(stack, e.stack)
+	e.stack = stack
+}
+
+// call calls the function with its arguments at stack[fp:], and leaves the results there.
+func (e *execution) call(f *funcInst, fp int) {
+	if f.host == nil {
+		e.run(f.code, fp)
+		return
+	}
+	args := make([]uint64, len(f.typ.Params))
+	copy(args, e.stack[fp:])
+	results := f.host(args)
+	if len(results) != len(f.typ.Results) {
+		panic(errors.New("host function returned the wrong number of results"))
+	}
+	copy(e.stack[fp:], results)
+}
+
+// checkFuel traps when all fuel has been consumed.
+// Like Wasmtime, each function keeps its own fuel counter, which is only
+// written back to the store when control leaves the function normally,
+// or when it runs out of fuel. When any other trap occurs, the fuel
+// burned since the last write-back is lost.
+func (e *execution) checkFuel(fuel int64) {
+	if e.store.consumeFuel && fuel >= 0 {
+		e.store.fuelConsumed = fuel
+		trap(trapOutOfFuel)
+	}
+}
+
+func b2u(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func f32(v uint64) float32 {
+	return math.Float32frombits(uint32(v))
+}
+
+func f64(v uint64) float64 {
+	return math.Float64frombits(v)
+}
+
+func u32f(f float32) uint64 {
+	return uint64(math.Float32bits(f))
+}
+
+func u64f(f float64) uint64 {
+	return math.Float64bits(f)
+}
+
+// address returns the effective address of a memory access of size bytes.
+func address(mem []byte, base uint64, offset uint32, size uint64) uint64 {
+	ea := uint64(uint32(base)) + uint64(offset)
+	if ea+size > uint64(len(mem)) {
+		trap(trapMemoryOutOfBounds)
+	}
+	return ea
+}
+
+// branch moves the arity values on top of the stack down to the given height.
+func branch(s []uint64, sp, height, arity int) int {
+	if arity != 0 {
+		copy(s[height:height+arity], s[sp-arity:sp])
+	}
+	return height + arity
+}
+
+//nolint:funlen,gocyclo
+func (e *execution) run(f *function, fp int) {
+	e.depth++
+	if e.depth > maxCallDepth {
+		trap(trapCallStackExhausted)
+	}
+	// each function call burns 1 fuel on entry
+	store := e.store
+	fuel := store.fuelConsumed + 1
+	e.checkFuel(fuel)
+	e.ensureStack(fp + f.numLocals + f.maxStack)
+
+	inst := e.inst
+	s := e.stack
+	for i := fp + f.numParams; i < fp+f.numLocals; i++ {
+		s[i] = 0
+	}
+	sp := fp + f.numLocals
+	code := f.code
+	pc := 0
+	for {
+		in := &code[pc]
+		pc++
+		switch in.op {
+		case opUnreachable:
+			store.fuelConsumed = fuel + int64(in.fuel)
+			trap(trapUnreachableInstrution)
+		case opFuel:
+			fuel += int64(in.fuel)
+		case opFuelCheck:
+			e.checkFuel(fuel)
+		case opIf:
+			fuel += int64(in.fuel)
+			sp--
+			if uint32(s[sp]) == 0 {
+				pc = int(in.a)
+			}
+		case opJump:
+			fuel += int64(in.fuel)
+			pc = int(in.a)
+		case opBr:
+			fuel += int64(in.fuel)
+			sp = branch(s, sp, fp+int(in.c), int(in.b))
+			pc = int(in.a)
+		case opBrIf:
+			fuel += int64(in.fuel)
+			sp--
+			if uint32(s[sp]) != 0 {
+				sp = branch(s, sp, fp+int(in.c), int(in.b))
+				pc = int(in.a)
+			}
+		case opBrTable:
+			fuel += int64(in.fuel)
+			sp--
+			i := uint32(s[sp])
+			if i > in.b {
+				i = in.b
+			}
+			target := &f.brTables[in.a+i]
+			sp = branch(s, sp, fp+int(target.height), int(target.arity))
+			pc = int(target.pc)
+		case opReturn:
+			store.fuelConsumed = fuel + int64(in.fuel)
+			branch(s, sp, fp, int(in.b))
+			e.depth--
+			return
+		case opCall:
+			store.fuelConsumed = fuel + int64(in.fuel)
+			callee := inst.funcs[in.a]
+			calleeFP := sp - len(callee.typ.Params)
+			e.call(callee, calleeFP)
+			fuel = store.fuelConsumed
+			s = e.stack
+			sp = calleeFP + len(callee.typ.Results)
+		case opCallIndirect:
+			fuel += int64(in.fuel)
+			store.fuelConsumed = fuel
+			sp--
+			i := uint32(s[sp])
+			table := inst.tables[in.b]
+			if uint64(i) >= uint64(len(table.elems)) {
+				trap(trapTableOutOfBounds)
+			}
+			ref := table.elems[i]
+			if ref == 0 {
+				trap(trapUninitializedElement)
+			}
+			callee := inst.funcs[ref-1]
+			if callee.typeID != inst.module.canonTypes[in.a] {
+				trap(trapIndirectCallMismatch)
+			}
+			calleeFP := sp - len(callee.typ.Params)
+			e.call(callee, calleeFP)
+			fuel = store.fuelConsumed
+			s = e.stack
+			sp = calleeFP + len(callee.typ.Results)
+
+		case opDrop:
+			sp--
+		case opSelect:
+			sp -= 2
+			if uint32(s[sp+1]) == 0 {
+				s[sp-1] = s[sp]
+			}
+		case opLocalGet:
+			s[sp] = s[fp+int(in.a)]
+			sp++
+		case opLocalSet:
+			sp--
+			s[fp+int(in.a)] = s[sp]
+		case opLocalTee:
+			s[fp+int(in.a)] = s[sp-1]
+		case opGlobalGet:
+			s[sp] = inst.globals[in.a]
+			sp++
+		case opGlobalSet:
+			sp--
+			inst.globals[in.a] = s[sp]
+		case opTableGet:
+			table := inst.tables[in.a]
+			i := uint32(s[sp-1])
+			if uint64(i) >= uint64(len(table.elems)) {
+				trap(trapTableOutOfBounds)
+			}
+			s[sp-1] = table.elems[i]
+		case opTableSet:
+			sp -= 2
+			table := inst.tables[in.a]
+			i := uint32(s[sp])
+			if uint64(i) >= uint64(len(table.elems)) {
+				trap(trapTableOutOfBounds)
+			}
+			table.elems[i] = s[sp+1]
+
+		case opI32Load:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 4)
+			s[sp-1] = uint64(binary.LittleEndian.Uint32(m[ea:]))
+		case opI64Load:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 8)
+			s[sp-1] = binary.LittleEndian.Uint64(m[ea:])
+		case opF32Load:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 4)
+			s[sp-1] = uint64(binary.LittleEndian.Uint32(m[ea:]))
+		case opF64Load:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 8)
+			s[sp-1] = binary.LittleEndian.Uint64(m[ea:])
+		case opI32Load8S:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 1)
+			s[sp-1] = uint64(uint32(int32(int8(m[ea]))))
+		case opI32Load8U:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 1)
+			s[sp-1] = uint64(m[ea])
+		case opI32Load16S:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 2)
+			s[sp-1] = uint64(uint32(int32(int16(binary.LittleEndian.Uint16(m[ea:])))))
+		case opI32Load16U:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 2)
+			s[sp-1] = uint64(binary.LittleEndian.Uint16(m[ea:]))
+		case opI64Load8S:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 1)
+			s[sp-1] = uint64(int64(int8(m[ea])))
+		case opI64Load8U:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 1)
+			s[sp-1] = uint64(m[ea])
+		case opI64Load16S:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 2)
+			s[sp-1] = uint64(int64(int16(binary.LittleEndian.Uint16(m[ea:]))))
+		case opI64Load16U:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 2)
+			s[sp-1] = uint64(binary.LittleEndian.Uint16(m[ea:]))
+		case opI64Load32S:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 4)
+			s[sp-1] = uint64(int64(int32(binary.LittleEndian.Uint32(m[ea:]))))
+		case opI64Load32U:
+			m := inst.memory.data
+			ea := address(m, s[sp-1], in.a, 4)
+			s[sp-1] = uint64(binary.LittleEndian.Uint32(m[ea:]))
+		case opI32Store, opF32Store, opI64Store32:
+			sp -= 2
+			m := inst.memory.data
+			ea := address(m, s[sp], in.a, 4)
+			binary.LittleEndian.PutUint32(m[ea:], uint32(s[sp+1]))
+		case opI64Store, opF64Store:
+			sp -= 2
+			m := inst.memory.data
+			ea := address(m, s[sp], in.a, 8)
+			binary.LittleEndian.PutUint64(m[ea:], s[sp+1])
+		case opI32Store8, opI64Store8:
+			sp -= 2
+			m := inst.memory.data
+			ea := address(m, s[sp], in.a, 1)
+			m[ea] = byte(s[sp+1])
+		case opI32Store16, opI64Store16:
+			sp -= 2
+			m := inst.memory.data
+			ea := address(m, s[sp], in.a, 2)
+			binary.LittleEndian.PutUint16(m[ea:], uint16(s[sp+1]))
+		case opMemorySize:
+			s[sp] = uint64(len(inst.memory.data) / pageSize)
+			sp++
+		case opMemoryGrow:
+			s[sp-1] = uint64(uint32(inst.memory.grow(uint32(s[sp-1]))))
+
+		case opI32Const, opI64Const, opF32Const, opF64Const:
+			s[sp] = in.c
+			sp++
+
+		case opI32Eqz:
+			s[sp-1] = b2u(uint32(s[sp-1]) == 0)
+		case opI32Eq:
+			sp--
+			s[sp-1] = b2u(uint32(s[sp-1]) == uint32(s[sp]))
+		case opI32Ne:
+			sp--
+			s[sp-1] = b2u(uint32(s[sp-1]) != uint32(s[sp]))
+		case opI32LtS:
+			sp--
+			s[sp-1] = b2u(int32(s[sp-1]) < int32(s[sp]))
+		case opI32LtU:
+			sp--
+			s[sp-1] = b2u(uint32(s[sp-1]) < uint32(s[sp]))
+		case opI32GtS:
+			sp--
+			s[sp-1] = b2u(int32(s[sp-1]) > int32(s[sp]))
+		case opI32GtU:
+			sp--
+			s[sp-1] = b2u(uint32(s[sp-1]) > uint32(s[sp]))
+		case opI32LeS:
+			sp--
+			s[sp-1] = b2u(int32(s[sp-1]) <= int32(s[sp]))
+		case opI32LeU:
+			sp--
+			s[sp-1] = b2u(uint32(s[sp-1]) <= uint32(s[sp]))
+		case opI32GeS:
+			sp--
+			s[sp-1] = b2u(int32(s[sp-1]) >= int32(s[sp]))
+		case opI32GeU:
+			sp--
+			s[sp-1] = b2u(uint32(s[sp-1]) >= uint32(s[sp]))
+
+		case opI64Eqz:
+			s[sp-1] = b2u(s[sp-1] == 0)
+		case opI64Eq:
+			sp--
+			s[sp-1] = b2u(s[sp-1] == s[sp])
+		case opI64Ne:
+			sp--
+			s[sp-1] = b2u(s[sp-1] != s[sp])
+		case opI64LtS:
+			sp--
+			s[sp-1] = b2u(int64(s[sp-1]) < int64(s[sp]))
+		case opI64LtU:
+			sp--
+			s[sp-1] = b2u(s[sp-1] < s[sp])
+		case opI64GtS:
+			sp--
+			s[sp-1] = b2u(int64(s[sp-1]) > int64(s[sp]))
+		case opI64GtU:
+			sp--
+			s[sp-1] = b2u(s[sp-1] > s[sp])
+		case opI64LeS:
+			sp--
+			s[sp-1] = b2u(int64(s[sp-1]) <= int64(s[sp]))
+		case opI64LeU:
+			sp--
+			s[sp-1] = b2u(s[sp-1] <= s[sp])
+		case opI64GeS:
+			sp--
+			s[sp-1] = b2u(int64(s[sp-1]) >= int64(s[sp]))
+		case opI64GeU:
+			sp--
+			s[sp-1] = b2u(s[sp-1] >= s[sp])
+
+		case opF32Eq:
+			sp--
+			s[sp-1] = b2u(f32(s[sp-1]) == f32(s[sp]))
+		case opF32Ne:
+			sp--
+			s[sp-1] = b2u(f32(s[sp-1]) != f32(s[sp]))
+		case opF32Lt:
+			sp--
+			s[sp-1] = b2u(f32(s[sp-1]) < f32(s[sp]))
+		case opF32Gt:
+			sp--
+			s[sp-1] = b2u(f32(s[sp-1]) > f32(s[sp]))
+		case opF32Le:
+			sp--
+			s[sp-1] = b2u(f32(s[sp-1]) <= f32(s[sp]))
+		case opF32Ge:
+			sp--
+			s[sp-1] = b2u(f32(s[sp-1]) >= f32(s[sp]))
+
+		case opF64Eq:
+			sp--
+			s[sp-1] = b2u(f64(s[sp-1]) == f64(s[sp]))
+		case opF64Ne:
+			sp--
+			s[sp-1] = b2u(f64(s[sp-1]) != f64(s[sp]))
+		case opF64Lt:
+			sp--
+			s[sp-1] = b2u(f64(s[sp-1]) < f64(s[sp]))
+		case opF64Gt:
+			sp--
+			s[sp-1] = b2u(f64(s[sp-1]) > f64(s[sp]))
+		case opF64Le:
+			sp--
+			s[sp-1] = b2u(f64(s[sp-1]) <= f64(s[sp]))
+		case opF64Ge:
+			sp--
+			s[sp-1] = b2u(f64(s[sp-1]) >= f64(s[sp]))
+
+		case opI32Clz:
+			s[sp-1] = uint64(bits.LeadingZeros32(uint32(s[sp-1])))
+		case opI32Ctz:
+			s[sp-1] = uint64(bits.TrailingZeros32(uint32(s[sp-1])))
+		case opI32Popcnt:
+			s[sp-1] = uint64(bits.OnesCount32(uint32(s[sp-1])))
+		case opI32Add:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1]) + uint32(s[sp]))
+		case opI32Sub:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1]) - uint32(s[sp]))
+		case opI32Mul:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1]) * uint32(s[sp]))
+		case opI32DivS:
+			sp--
+			a, b := int32(s[sp-1]), int32(s[sp])
+			if b == 0 {
+				trap(trapIntegerDivideByZero)
+			}
+			if a == math.MinInt32 && b == -1 {
+				trap(trapIntegerOverflow)
+			}
+			s[sp-1] = uint64(uint32(a / b))
+		case opI32DivU:
+			sp--
+			a, b := uint32(s[sp-1]), uint32(s[sp])
+			if b == 0 {
+				trap(trapIntegerDivideByZero)
+			}
+			s[sp-1] = uint64(a / b)
+		case opI32RemS:
+			sp--
+			a, b := int32(s[sp-1]), int32(s[sp])
+			if b == 0 {
+				trap(trapIntegerDivideByZero)
+			}
+			if b == -1 {
+				// avoid the overflow of MinInt32 % -1
+				s[sp-1] = 0
+				break
+			}
+			s[sp-1] = uint64(uint32(a % b))
+		case opI32RemU:
+			sp--
+			a, b := uint32(s[sp-1]), uint32(s[sp])
+			if b == 0 {
+				trap(trapIntegerDivideByZero)
+			}
+			s[sp-1] = uint64(a % b)
+		case opI32And:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1]) & uint32(s[sp]))
+		case opI32Or:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1]) | uint32(s[sp]))
+		case opI32Xor:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1]) ^ uint32(s[sp]))
+		case opI32Shl:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1]) << (uint32(s[sp]) & 31))
+		case opI32ShrS:
+			sp--
+			s[sp-1] = uint64(uint32(int32(s[sp-1]) >> (uint32(s[sp]) & 31)))
+		case opI32ShrU:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1]) >> (uint32(s[sp]) & 31))
+		case opI32Rotl:
+			sp--
+			s[sp-1] = uint64(bits.RotateLeft32(uint32(s[sp-1]), int(uint32(s[sp])&31)))
+		case opI32Rotr:
+			sp--
+			s[sp-1] = uint64(bits.RotateLeft32(uint32(s[sp-1]), -int(uint32(s[sp])&31)))
+
+		case opI64Clz:
+			s[sp-1] = uint64(bits.LeadingZeros64(s[sp-1]))
+		case opI64Ctz:
+			s[sp-1] = uint64(bits.TrailingZeros64(s[sp-1]))
+		case opI64Popcnt:
+			s[sp-1] = uint64(bits.OnesCount64(s[sp-1]))
+		case opI64Add:
+			sp--
+			s[sp-1] += s[sp]
+		case opI64Sub:
+			sp--
+			s[sp-1] -= s[sp]
+		case opI64Mul:
+			sp--
+			s[sp-1] *= s[sp]
+		case opI64DivS:
+			sp--
+			a, b := int64(s[sp-1]), int64(s[sp])
+			if b == 0 {
+				trap(trapIntegerDivideByZero)
+			}
+			if a == math.MinInt64 && b == -1 {
+				trap(trapIntegerOverflow)
+			}
+			s[sp-1] = uint64(a / b)
+		case opI64DivU:
+			sp--
+			if s[sp] == 0 {
+				trap(trapIntegerDivideByZero)
+			}
+			s[sp-1] /= s[sp]
+		case opI64RemS:
+			sp--
+			a, b := int64(s[sp-1]), int64(s[sp])
+			if b == 0 {
+				trap(trapIntegerDivideByZero)
+			}
+			if b == -1 {
+				// avoid the overflow of MinInt64 % -1
+				s[sp-1] = 0
+				break
+			}
+			s[sp-1] = uint64(a % b)
+		case opI64RemU:
+			sp--
+			if s[sp] == 0 {
+				trap(trapIntegerDivideByZero)
+			}
+			s[sp-1] %= s[sp]
+		case opI64And:
+			sp--
+			s[sp-1] &= s[sp]
+		case opI64Or:
+			sp--
+			s[sp-1] |= s[sp]
+		case opI64Xor:
+			sp--
+			s[sp-1] ^= s[sp]
+		case opI64Shl:
+			sp--
+			s[sp-1] <<= s[sp] & 63
+		case opI64ShrS:
+			sp--
+			s[sp-1] = uint64(int64(s[sp-1]) >> (s[sp] & 63))
+		case opI64ShrU:
+			sp--
+			s[sp-1] >>= s[sp] & 63
+		case opI64Rotl:
+			sp--
+			s[sp-1] = bits.RotateLeft64(s[sp-1], int(s[sp]&63))
+		case opI64Rotr:
+			sp--
+			s[sp-1] = bits.RotateLeft64(s[sp-1], -int(s[sp]&63))
+
+		case opF32Abs:
+			s[sp-1] = uint64(uint32(s[sp-1]) &^ (1 << 31))
+		case opF32Neg:
+			s[sp-1] = uint64(uint32(s[sp-1]) ^ (1 << 31))
+		case opF32Ceil:
+			s[sp-1] = u32f(float32(math.Ceil(float64(f32(s[sp-1])))))
+		case opF32Floor:
+			s[sp-1] = u32f(float32(math.Floor(float64(f32(s[sp-1])))))
+		case opF32Trunc:
+			s[sp-1] = u32f(float32(math.Trunc(float64(f32(s[sp-1])))))
+		case opF32Nearest:
+			s[sp-1] = u32f(float32(math.RoundToEven(float64(f32(s[sp-1])))))
+		case opF32Sqrt:
+			s[sp-1] = u32f(float32(math.Sqrt(float64(f32(s[sp-1])))))
+		case opF32Add:
+			sp--
+			s[sp-1] = u32f(f32(s[sp-1]) + f32(s[sp]))
+		case opF32Sub:
+			sp--
+			s[sp-1] = u32f(f32(s[sp-1]) - f32(s[sp]))
+		case opF32Mul:
+			sp--
+			s[sp-1] = u32f(f32(s[sp-1]) * f32(s[sp]))
+		case opF32Div:
+			sp--
+			s[sp-1] = u32f(f32(s[sp-1]) / f32(s[sp]))
+		case opF32Min:
+			sp--
+			s[sp-1] = u32f(float32(math.Min(float64(f32(s[sp-1])), float64(f32(s[sp])))))
+		case opF32Max:
+			sp--
+			s[sp-1] = u32f(float32(math.Max(float64(f32(s[sp-1])), float64(f32(s[sp])))))
+		case opF32Copysign:
+			sp--
+			s[sp-1] = uint64(uint32(s[sp-1])&^(1<<31) | uint32(s[sp])&(1<<31))
+
+		case opF64Abs:
+			s[sp-1] &^= 1 << 63
+		case opF64Neg:
+			s[sp-1] ^= 1 << 63
+		case opF64Ceil:
+			s[sp-1] = u64f(math.Ceil(f64(s[sp-1])))
+		case opF64Floor:
+			s[sp-1] = u64f(math.Floor(f64(s[sp-1])))
+		case opF64Trunc:
+			s[sp-1] = u64f(math.Trunc(f64(s[sp-1])))
+		case opF64Nearest:
+			s[sp-1] = u64f(math.RoundToEven(f64(s[sp-1])))
+		case opF64Sqrt:
+			s[sp-1] = u64f(math.Sqrt(f64(s[sp-1])))
+		case opF64Add:
+			sp--
+			s[sp-1] = u64f(f64(s[sp-1]) + f64(s[sp]))
+		case opF64Sub:
+			sp--
+			s[sp-1] = u64f(f64(s[sp-1]) - f64(s[sp]))
+		case opF64Mul:
+			sp--
+			s[sp-1] = u64f(f64(s[sp-1]) * f64(s[sp]))
+		case opF64Div:
+			sp--
+			s[sp-1] = u64f(f64(s[sp-1]) / f64(s[sp]))
+		case opF64Min:
+			sp--
+			s[sp-1] = u64f(math.Min(f64(s[sp-1]), f64(s[sp])))
+		case opF64Max:
+			sp--
+			s[sp-1] = u64f(math.Max(f64(s[sp-1]), f64(s[sp])))
+		case opF64Copysign:
+			sp--
+			s[sp-1] = s[sp-1]&^(1<<63) | s[sp]&(1<<63)
+
+		case opI32WrapI64:
+			s[sp-1] = uint64(uint32(s[sp-1]))
+		case opI32TruncF32S:
+			s[sp-1] = uint64(uint32(truncS32(float64(f32(s[sp-1])))))
+		case opI32TruncF32U:
+			s[sp-1] = uint64(truncU32(float64(f32(s[sp-1]))))
+		case opI32TruncF64S:
+			s[sp-1] = uint64(uint32(truncS32(f64(s[sp-1]))))
+		case opI32TruncF64U:
+			s[sp-1] = uint64(truncU32(f64(s[sp-1])))
+		case opI64ExtendI32S:
+			s[sp-1] = uint64(int64(int32(s[sp-1])))
+		case opI64ExtendI32U:
+			s[sp-1] = uint64(uint32(s[sp-1]))
+		case opI64TruncF32S:
+			s[sp-1] = uint64(truncS64(float64(f32(s[sp-1]))))
+		case opI64TruncF32U:
+			s[sp-1] = truncU64(float64(f32(s[sp-1])))
+		case opI64TruncF64S:
+			s[sp-1] = uint64(truncS64(f64(s[sp-1])))
+		case opI64TruncF64U:
+			s[sp-1] = truncU64(f64(s[sp-1]))
+		case opF32ConvertI32S:
+			s[sp-1] = u32f(float32(int32(s[sp-1])))
+		case opF32ConvertI32U:
+			s[sp-1] = u32f(float32(uint32(s[sp-1])))
+		case opF32ConvertI64S:
+			s[sp-1] = u32f(float32(int64(s[sp-1])))
+		case opF32ConvertI64U:
+			s[sp-1] = u32f(float32(s[sp-1]))
+		case opF32DemoteF64:
+			s[sp-1] = u32f(float32(f64(s[sp-1])))
+		case opF64ConvertI32S:
+			s[sp-1] = u64f(float64(int32(s[sp-1])))
+		case opF64ConvertI32U:
+			s[sp-1] = u64f(float64(uint32(s[sp-1])))
+		case opF64ConvertI64S:
+			s[sp-1] = u64f(float64(int64(s[sp-1])))
+		case opF64ConvertI64U:
+			s[sp-1] = u64f(float64(s[sp-1]))
+		case opF64PromoteF32:
+			s[sp-1] = u64f(float64(f32(s[sp-1])))
+		case opI32ReinterpretF32, opI64ReinterpretF64, opF32ReinterpretI32, opF64ReinterpretI64:
+			// the raw encoding of the value stays the same
+
+		case opI32Extend8S:
+			s[sp-1] = uint64(uint32(int32(int8(s[sp-1]))))
+		case opI32Extend16S:
+			s[sp-1] = uint64(uint32(int32(int16(s[sp-1]))))
+		case opI64Extend8S:
+			s[sp-1] = uint64(int64(int8(s[sp-1])))
+		case opI64Extend16S:
+			s[sp-1] = uint64(int64(int16(s[sp-1])))
+		case opI64Extend32S:
+			s[sp-1] = uint64(int64(int32(s[sp-1])))
+
+		case opRefNull:
+			s[sp] = 0
+			sp++
+		case opRefIsNull:
+			s[sp-1] = b2u(s[sp-1] == 0)
+		case opRefFunc:
+			s[sp] = uint64(in.a) + 1
+			sp++
+
+		case opI32TruncSatF32S:
+			s[sp-1] = uint64(uint32(satS32(float64(f32(s[sp-1])))))
+		case opI32TruncSatF32U:
+			s[sp-1] = uint64(satU32(float64(f32(s[sp-1]))))
+		case opI32TruncSatF64S:
+			s[sp-1] = uint64(uint32(satS32(f64(s[sp-1]))))
+		case opI32TruncSatF64U:
+			s[sp-1] = uint64(satU32(f64(s[sp-1])))
+		case opI64TruncSatF32S:
+			s[sp-1] = uint64(satS64(float64(f32(s[sp-1]))))
+		case opI64TruncSatF32U:
+			s[sp-1] = satU64(float64(f32(s[sp-1])))
+		case opI64TruncSatF64S:
+			s[sp-1] = uint64(satS64(f64(s[sp-1])))
+		case opI64TruncSatF64U:
+			s[sp-1] = satU64(f64(s[sp-1]))
+
+		case opMemoryInit:
+			sp -= 3
+			inst.memoryInit(in.a, uint32(s[sp]), uint32(s[sp+1]), uint32(s[sp+2]))
+		case opDataDrop:
+			inst.datas[in.a] = nil
+		case opMemoryCopy:
+			sp -= 3
+			m := inst.memory.data
+			dst, src, n := uint64(uint32(s[sp])), uint64(uint32(s[sp+1])), uint64(uint32(s[sp+2]))
+			if src+n > uint64(len(m)) || dst+n > uint64(len(m)) {
+				trap(trapMemoryOutOfBounds)
+			}
+			copy(m[dst:dst+n], m[src:src+n])
+		case opMemoryFill:
+			sp -= 3
+			m := inst.memory.data
+			dst, val, n := uint64(uint32(s[sp])), byte(s[sp+1]), uint64(uint32(s[sp+2]))
+			if dst+n > uint64(len(m)) {
+				trap(trapMemoryOutOfBounds)
+			}
+			for i := dst; i < dst+n; i++ {
+				m[i] = val
+			}
+		case opTableInit:
+			sp -= 3
+			inst.tableInit(inst.tables[in.b], in.a, uint32(s[sp]), uint32(s[sp+1]), uint32(s[sp+2]))
+		case opElemDrop:
+			inst.elems[in.a] = nil
+		case opTableCopy:
+			sp -= 3
+			dstTable, srcTable := inst.tables[in.a].elems, inst.tables[in.b].elems
+			dst, src, n := uint64(uint32(s[sp])), uint64(uint32(s[sp+1])), uint64(uint32(s[sp+2]))
+			if src+n > uint64(len(srcTable)) || dst+n > uint64(len(dstTable)) {
+				trap(trapTableOutOfBounds)
+			}
+			copy(dstTable[dst:dst+n], srcTable[src:src+n])
+		case opTableGrow:
+			sp--
+			table := inst.tables[in.a]
+			n := uint32(s[sp])
+			old := uint32(len(table.elems))
+			if uint64(old)+uint64(n) > uint64(table.max) {
+				s[sp-1] = uint64(math.MaxUint32)
+				break
+			}
+			val := s[sp-1]
+			for i := uint32(0); i < n; i++ {
+				table.elems = append(table.elems, val)
+			}
+			s[sp-1] = uint64(old)
+		case opTableSize:
+			s[sp] = uint64(len(inst.tables[in.a].elems))
+			sp++
+		case opTableFill:
+			sp -= 3
+			elems := inst.tables[in.a].elems
+			i, val, n := uint64(uint32(s[sp])), s[sp+1], uint64(uint32(s[sp+2]))
+			if i+n > uint64(len(elems)) {
+				trap(trapTableOutOfBounds)
+			}
+			for ; n > 0; n-- {
+				elems[i] = val
+				i++
+			}
+
+		default:
+			panic(errors.New("invalid compiled instruction"))
+		}
+	}
+}
+
+func truncS32(f float64) int32 {
+	if math.IsNaN(f) {
+		trap(trapInvalidConversion)
+	}
+	t := math.Trunc(f)
+	if t < math.MinInt32 || t > math.MaxInt32 {
+		trap(trapIntegerOverflow)
+	}
+	return int32(t)
+}
+
+func truncU32(f float64) uint32 {
+	if math.IsNaN(f) {
+		trap(trapInvalidConversion)
+	}
+	t := math.Trunc(f)
+	if t < 0 || t > math.MaxUint32 {
+		trap(trapIntegerOverflow)
+	}
+	return uint32(t)
+}
+
+func truncS64(f float64) int64 {
+	if math.IsNaN(f) {
+		trap(trapInvalidConversion)
+	}
+	t := math.Trunc(f)
+	// 2^63 is the first float64 that is too large
+	if t < math.MinInt64 || t >= 1<<63 {
+		trap(trapIntegerOverflow)
+	}
+	return int64(t)
+}
+
+func truncU64(f float64) uint64 {
+	if math.IsNaN(f) {
+		trap(trapInvalidConversion)
+	}
+	t := math.Trunc(f)
+	// 2^64 is the first float64 that is too large
+	if t < 0 || t >= 1<<64 {
+		trap(trapIntegerOverflow)
+	}
+	return uint64(t)
+}
+
+func satS32(f float64) int32 {
+	switch {
+	case math.IsNaN(f):
+		return 0
+	case f <= math.MinInt32:
+		return math.MinInt32
+	case f >= math.MaxInt32:
+		return math.MaxInt32
+	}
+	return int32(f)
+}
+
+func satU32(f float64) uint32 {
+	switch {
+	case math.IsNaN(f) || f <= 0:
+		return 0
+	case f >= math.MaxUint32:
+		return math.MaxUint32
+	}
+	return uint32(f)
+}
+
+func satS64(f float64) int64 {
+	switch {
+	case math.IsNaN(f):
+		return 0
+	case f <= math.MinInt64:
+		return math.MinInt64
+	case f >= 1<<63:
+		return math.MaxInt64
+	}
+	return int64(f)
+}
+
+func satU64(f float64) uint64 {
+	switch {
+	case math.IsNaN(f) || f <= 0:
+		return 0
+	case f >= 1<<64:
+		return math.MaxUint64
+	}
+	return uint64(f)
+}
@@ -0,0 +1,305 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package wasminterp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Store holds the fuel that is shared by all instances that run in it.
+// Like Wasmtime, it keeps track of the consumed fuel as a negative number,
+// so that running out of fuel means that the counter is no longer negative.
+type Store struct {
+	consumeFuel  bool
+	fuelConsumed int64
+	fuelAdj      int64
+}
+
+func NewStore(consumeFuel bool) *Store {
+	return &Store{consumeFuel: consumeFuel}
+}
+
+// AddFuel adds fuel to the store.
+func (s *Store) AddFuel(fuel uint64) error {
+	if !s.consumeFuel {
+		return errors.New("fuel is not configured in this store")
+	}
+	f := int64(math.MaxInt64)
+	if fuel < math.MaxInt64 {
+		f = int64(fuel)
+	}
+	consumed := s.fuelConsumed - f
+	adj := s.fuelAdj + f
+	if consumed > s.fuelConsumed || adj < s.fuelAdj {
+		// overflow, assume infinite fuel, but keep what was consumed already
+		s.fuelConsumed = s.fuelConsumed + s.fuelAdj - math.MaxInt64
+		s.fuelAdj = math.MaxInt64
+		return nil
+	}
+	s.fuelConsumed = consumed
+	s.fuelAdj = adj
+	return nil
+}
+
+// ConsumeFuel consumes fuel from the store and returns the remaining fuel.
+func (s *Store) ConsumeFuel(fuel uint64) (uint64, error) {
+	consumed := s.fuelConsumed + int64(fuel)
+	if fuel > math.MaxInt64 || consumed < s.fuelConsumed || consumed > 0 {
+		return 0, errors.New("not enough fuel remaining in store")
+	}
+	s.fuelConsumed = consumed
+	return uint64(-consumed), nil
+}
+
+// FuelConsumed returns the total amount of fuel consumed by the store.
+func (s *Store) FuelConsumed() (uint64, bool) {
+	if !s.consumeFuel {
+		return 0, false
+	}
+	return uint64(s.fuelAdj + s.fuelConsumed), true
+}
+
+// HostFunc implements an imported function.
+// It receives the arguments and returns the results in their raw encoding.
+type HostFunc func(args []uint64) []uint64
+
+type hostFunc struct {
+	typ *FuncType
+	fn  HostFunc
+}
+
+// Linker provides the imports to the instances of a module.
+type Linker struct {
+	funcs map[string]*hostFunc
+}
+
+func NewLinker() *Linker {
+	return &Linker{funcs: make(map[string]*hostFunc)}
+}
+
+func importKey(module, name string) string {
+	return "`" + module + "::" + name + "`"
+}
+
+// DefineFunc defines the host function for the import with the given module and name.
+func (l *Linker) DefineFunc(module, name string, typ *FuncType, fn HostFunc) error {
+	key := importKey(module, name)
+	if _, ok := l.funcs[key]; ok {
+		return errors.New("import of " + key + " defined twice")
+	}
+	l.funcs[key] = &hostFunc{typ: typ, fn: fn}
+	return nil
+}
+
+type funcInst struct {
+	typ    *FuncType
+	typeID uint32
+	host   HostFunc
+	code   *function
+}
+
+type tableInst struct {
+	elems []uint64
+	max   uint32
+}
+
+// Memory is the linear memory of an instance.
+type Memory struct {
+	data []byte
+	max  uint32
+}
+
+// Data returns the memory contents.
+// The slice is only valid until the memory grows.
+func (m *Memory) Data() []byte {
+	return m.data
+}
+
+// grow grows the memory by delta pages and returns the old number of pages, or -1.
+func (m *Memory) grow(delta uint32) int32 {
+	pages := uint32(len(m.data) / pageSize)
+	if uint64(pages)+uint64(delta) > uint64(m.max) {
+		return -1
+	}
+	m.data = append(m.data, make([]byte, int(delta)*pageSize)...)
+	return int32(pages)
+}
+
+// Instance is an instantiated module.
+// Function references are represented as the function index plus one,
+// so that the null reference is zero.
+type Instance struct {
+	module  *Module
+	store   *Store
+	funcs   []*funcInst
+	tables  []*tableInst
+	memory  *Memory
+	globals []uint64
+	elems   [][]uint64
+	datas   [][]byte
+}
+
+// Instantiate creates a new instance of the module in the store.
+// Any start function will be run before Instantiate returns.
+func (l *Linker) Instantiate(store *Store, m *Module) (*Instance, error) {
+	inst := &Instance{module: m, store: store}
+	for _, imp := range m.imports {
+		key := importKey(imp.module, imp.name)
+		hf, ok := l.funcs[key]
+		if !ok {
+			return nil, errors.New("unknown import: " + key + " has not been defined")
+		}
+		ft := m.types[imp.typeIdx]
+		if !hf.typ.equals(ft) {
+			return nil, errors.New("incompatible import type for " + key)
+		}
+		inst.funcs = append(inst.funcs, &funcInst{typ: ft, typeID: m.canonTypes[imp.typeIdx], host: hf.fn})
+	}
+	for i, fn := range m.funcs {
+		typeIdx := m.funcTypes[m.numImport+uint32(i)]
+		inst.funcs = append(inst.funcs, &funcInst{typ: fn.typ, typeID: m.canonTypes[typeIdx], code: fn})
+	}
+	for _, t := range m.tables {
+		max := uint32(maxTableSize)
+		if t.hasMax && t.max < max {
+			max = t.max
+		}
+		if t.min > max {
+			return nil, fmt.Errorf("table minimum size of %d elements exceeds table limits", t.min)
+		}
+		inst.tables = append(inst.tables, &tableInst{elems: make([]uint64, t.min), max: max})
+	}
+	if len(m.memories) != 0 {
+		mem := m.memories[0]
+		inst.memory = &Memory{data: make([]byte, int(mem.min)*pageSize), max: maxPages}
+		if mem.hasMax {
+			inst.memory.max = mem.max
+		}
+	}
+	for _, g := range m.globals {
+		inst.globals = append(inst.globals, constValue(g.init))
+	}
+	for _, seg := range m.elems {
+		elems := make([]uint64, len(seg.init))
+		for i, expr := range seg.init {
+			elems[i] = constValue(expr)
+		}
+		inst.elems = append(inst.elems, elems)
+	}
+	for _, seg := range m.datas {
+		inst.datas = append(inst.datas, seg.init)
+	}
+
+	if err := inst.initialize(); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func constValue(expr constExpr) uint64 {
+	switch expr.op {
+	case opRefNull:
+		return 0
+	case opRefFunc:
+		return expr.val + 1
+	default:
+		return expr.val
+	}
+}
+
+// initialize copies the active segments into the tables and memory and runs the start function.
+func (inst *Instance) initialize() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			t, ok := r.(*Trap)
+			if !ok {
+				panic(r)
+			}
+			err = t
+		}
+	}()
+
+	m := inst.module
+	for i, seg := range m.elems {
+		if seg.mode == segmentActive {
+			offset := uint32(seg.offset.val)
+			inst.tableInit(inst.tables[seg.table], uint32(i), offset, 0, uint32(len(seg.init)))
+		}
+		if seg.mode != segmentPassive {
+			inst.elems[i] = nil
+		}
+	}
+	for i, seg := range m.datas {
+		if seg.mode == segmentActive {
+			offset := uint32(seg.offset.val)
+			inst.memoryInit(uint32(i), offset, 0, uint32(len(seg.init)))
+			inst.datas[i] = nil
+		}
+	}
+	if m.hasStart {
+		e := newExecution(inst, 0)
+		e.call(inst.funcs[m.start], 0)
+	}
+	return nil
+}
+
+func (inst *Instance) tableInit(t *tableInst, seg, dst, src, n uint32) {
+	elems := inst.elems[seg]
+	if uint64(src)+uint64(n) > uint64(len(elems)) || uint64(dst)+uint64(n) > uint64(len(t.elems)) {
+		trap(trapTableOutOfBounds)
+	}
+	copy(t.elems[dst:], elems[src:src+n])
+}
+
+func (inst *Instance) memoryInit(seg, dst, src, n uint32) {
+	data := inst.datas[seg]
+	mem := inst.memory.data
+	if uint64(src)+uint64(n) > uint64(len(data)) || uint64(dst)+uint64(n) > uint64(len(mem)) {
+		trap(trapMemoryOutOfBounds)
+	}
+	copy(mem[dst:], data[src:src+n])
+}
+
+// Memory returns the exported memory with the given name, or nil.
+func (inst *Instance) Memory(name string) *Memory {
+	exp, ok := inst.module.exports[name]
+	if !ok || exp.kind != externMemory {
+		return nil
+	}
+	return inst.memory
+}
+
+// Call calls the exported function with the given name.
+// The arguments and results are passed in their raw encoding: 32-bit values are
+// zero-extended, floats are passed as their IEEE 754 bits, and references as
+// described for Instance. A trap is returned as a *Trap error, while a panic in
+// a host function is passed on to the caller.
+func (inst *Instance) Call(name string, args ...uint64) (results []uint64, err error) {
+	exp, ok := inst.module.exports[name]
+	if !ok || exp.kind != externFunc {
+		return nil, errors.New("unknown export function: '" + name + "'")
+	}
+	f := inst.funcs[exp.index]
+	if len(args) != len(f.typ.Params) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(f.typ.Params), len(args))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t, ok := r.(*Trap)
+			if !ok {
+				panic(r)
+			}
+			results = nil
+			err = t
+		}
+	}()
+
+	e := newExecution(inst, len(args))
+	copy(e.stack, args)
+	e.call(f, 0)
+	return append([]uint64(nil), e.stack[:len(f.typ.Results)]...), nil
+}
@@ -0,0 +1,599 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// wasminterp package is a pure-Go WebAssembly runtime.
+// It interprets the WebAssembly 2.0 instruction set, except for the SIMD instructions,
+// and meters the executed instructions with fuel the same way Wasmtime does:
+// each function call burns 1 unit of fuel, and so does each instruction, except
+// for nop, drop, block, loop, unreachable, return, else and end, which are free.
+// The fuel is checked at the entry of each function and at each loop header.
+package wasminterp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+type ValType byte
+
+const (
+	I32       ValType = 0x7f
+	I64       ValType = 0x7e
+	F32       ValType = 0x7d
+	F64       ValType = 0x7c
+	V128      ValType = 0x7b
+	FuncRef   ValType = 0x70
+	ExternRef ValType = 0x6f
+
+	// unknown is the type of the operands of the polymorphic stack in unreachable code
+	unknown ValType = 0
+)
+
+func (t ValType) String() string {
+	switch t {
+	case I32:
+		return "i32"
+	case I64:
+		return "i64"
+	case F32:
+		return "f32"
+	case F64:
+		return "f64"
+	case V128:
+		return "v128"
+	case FuncRef:
+		return "funcref"
+	case ExternRef:
+		return "externref"
+	default:
+		return "unknown"
+	}
+}
+
+func (t ValType) isRef() bool {
+	return t == FuncRef || t == ExternRef
+}
+
+type FuncType struct {
+	Params  []ValType
+	Results []ValType
+}
+
+func (ft *FuncType) equals(other *FuncType) bool {
+	return bytes.Equal(valTypeBytes(ft.Params), valTypeBytes(other.Params)) &&
+		bytes.Equal(valTypeBytes(ft.Results), valTypeBytes(other.Results))
+}
+
+func valTypeBytes(types []ValType) []byte {
+	b := make([]byte, len(types))
+	for i, t := range types {
+		b[i] = byte(t)
+	}
+	return b
+}
+
+const (
+	externFunc   byte = 0x00
+	externTable  byte = 0x01
+	externMemory byte = 0x02
+	externGlobal byte = 0x03
+)
+
+const (
+	pageSize = 65536
+	maxPages = 65536
+
+	maxFunctionLocals = 50000
+	maxTableSize      = 10_000_000
+)
+
+type limits struct {
+	min    uint32
+	max    uint32
+	hasMax bool
+}
+
+type tableType struct {
+	elem ValType
+	limits
+}
+
+type globalType struct {
+	typ     ValType
+	mutable bool
+}
+
+type importEntry struct {
+	module  string
+	name    string
+	kind    byte
+	typeIdx uint32
+}
+
+type exportEntry struct {
+	kind  byte
+	index uint32
+}
+
+// constExpr is a constant expression, limited to a single instruction.
+type constExpr struct {
+	op  byte
+	val uint64
+}
+
+type globalDef struct {
+	globalType
+	init constExpr
+}
+
+const (
+	segmentActive byte = iota
+	segmentPassive
+	segmentDeclarative
+)
+
+type elemSegment struct {
+	mode   byte
+	table  uint32
+	offset constExpr
+	typ    ValType
+	init   []constExpr
+}
+
+type dataSegment struct {
+	mode   byte
+	offset constExpr
+	init   []byte
+}
+
+// Module is a decoded and validated WebAssembly module.
+type Module struct {
+	types []*FuncType
+	// canonical index of each type, equal types have the same canonical index
+	canonTypes []uint32
+	imports    []importEntry
+	funcTypes  []uint32 // type indices of all functions, imported ones first
+	numImport  uint32   // number of imported functions
+	funcs      []*function
+	tables     []tableType
+	memories   []limits
+	globals    []globalDef
+	exports    map[string]exportEntry
+	start      uint32
+	hasStart   bool
+	elems      []elemSegment
+	datas      []dataSegment
+	dataCount  uint32
+	hasCount   bool
+	// functions that can be referenced by ref.func
+	declaredRefs map[uint32]bool
+}
+
+// NewModule decodes and validates the binary WebAssembly module.
+func NewModule(wasmData []byte) (m *Module, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			de, ok := r.(*decodeError)
+			if !ok {
+				panic(r)
+			}
+			m = nil
+			err = fmt.Errorf("invalid wasm module: %w", de.err)
+		}
+	}()
+
+	m = &Module{
+		exports:      make(map[string]exportEntry),
+		declaredRefs: make(map[uint32]bool),
+	}
+	m.decode(&reader{data: wasmData})
+	return m, nil
+}
+
+func (m *Module) decode(r *reader) {
+	if !bytes.Equal(r.bytes(4), []byte("\x00asm")) {
+		fail("magic header not detected")
+	}
+	if r.u32le() != 1 {
+		fail("unknown binary version")
+	}
+
+	var codes []*reader
+	lastID := byte(0)
+	hasFuncs := false
+	for !r.eof() {
+		id := r.byte()
+		size := r.u32()
+		section := &reader{data: r.bytes(size)}
+		if id != 0 {
+			// the order of the data count section is between element and code sections
+			order := sectionOrder(id)
+			if order <= sectionOrder(lastID) {
+				fail("unexpected section %d", id)
+			}
+			lastID = id
+		}
+		switch id {
+		case 0:
+			section.name() // custom section, ignored
+			continue
+		case 1:
+			m.decodeTypes(section)
+		case 2:
+			m.decodeImports(section)
+		case 3:
+			hasFuncs = true
+			for n := section.count(); n > 0; n-- {
+				m.funcTypes = append(m.funcTypes, m.typeIndex(section.u32()))
+			}
+		case 4:
+			for n := section.count(); n > 0; n-- {
+				m.tables = append(m.tables, decodeTableType(section))
+			}
+		case 5:
+			for n := section.count(); n > 0; n-- {
+				m.memories = append(m.memories, decodeMemoryType(section))
+			}
+		case 6:
+			m.decodeGlobals(section)
+		case 7:
+			m.decodeExports(section)
+		case 8:
+			m.start = section.u32()
+			m.hasStart = true
+		case 9:
+			m.decodeElems(section)
+		case 10:
+			n := section.count()
+			if n != uint32(len(m.funcTypes))-m.numImport {
+				fail("function and code section have inconsistent lengths")
+			}
+			for ; n > 0; n-- {
+				codes = append(codes, &reader{data: section.bytes(section.u32())})
+			}
+		case 11:
+			m.decodeDatas(section)
+		case 12:
+			m.dataCount = section.u32()
+			m.hasCount = true
+		default:
+			fail("malformed section id %d", id)
+		}
+		if !section.eof() {
+			fail("section size mismatch")
+		}
+	}
+
+	if hasFuncs && codes == nil && uint32(len(m.funcTypes)) != m.numImport {
+		fail("function and code section have inconsistent lengths")
+	}
+	if m.hasCount && m.dataCount != uint32(len(m.datas)) {
+		fail("data count and data section have inconsistent lengths")
+	}
+	m.validate()
+
+	for i, code := range codes {
+		funcIdx := m.numImport + uint32(i)
+		m.funcs = append(m.funcs, m.compileFunction(funcIdx, code))
+	}
+}
+
+func sectionOrder(id byte) int {
+	switch id {
+	case 0:
+		return 0
+	case 12:
+		return 10 // data count section comes before the code section
+	case 10:
+		return 11
+	case 11:
+		return 12
+	default:
+		return int(id)
+	}
+}
+
+func (m *Module) typeIndex(idx uint32) uint32 {
+	if idx >= uint32(len(m.types)) {
+		fail("unknown type %d", idx)
+	}
+	return idx
+}
+
+func (m *Module) funcType(funcIdx uint32) *FuncType {
+	return m.types[m.funcTypes[funcIdx]]
+}
+
+func decodeValType(r *reader) ValType {
+	t := ValType(r.byte())
+	switch t {
+	case I32, I64, F32, F64, FuncRef, ExternRef:
+		return t
+	case V128:
+		fail("SIMD is not supported")
+	}
+	fail("malformed value type 0x%x", byte(t))
+	return unknown
+}
+
+func decodeRefType(r *reader) ValType {
+	t := ValType(r.byte())
+	if !t.isRef() {
+		fail("malformed reference type 0x%x", byte(t))
+	}
+	return t
+}
+
+func decodeLimits(r *reader, maxValue uint64) limits {
+	var l limits
+	flags := r.byte()
+	switch flags {
+	case 0x00:
+	case 0x01:
+		l.hasMax = true
+	default:
+		fail("malformed limits flags 0x%x", flags)
+	}
+	l.min = r.u32()
+	if uint64(l.min) > maxValue {
+		fail("size minimum must not be greater than %d", maxValue)
+	}
+	if l.hasMax {
+		l.max = r.u32()
+		if uint64(l.max) > maxValue {
+			fail("size maximum must not be greater than %d", maxValue)
+		}
+		if l.max < l.min {
+			fail("size minimum must not be greater than maximum")
+		}
+	}
+	return l
+}
+
+func decodeTableType(r *reader) tableType {
+	elem := decodeRefType(r)
+	return tableType{elem: elem, limits: decodeLimits(r, 1<<32-1)}
+}
+
+func decodeMemoryType(r *reader) limits {
+	return decodeLimits(r, maxPages)
+}
+
+func decodeGlobalType(r *reader) globalType {
+	t := decodeValType(r)
+	mut := r.byte()
+	if mut > 1 {
+		fail("malformed mutability")
+	}
+	return globalType{typ: t, mutable: mut == 1}
+}
+
+func (m *Module) decodeTypes(r *reader) {
+	for n := r.count(); n > 0; n-- {
+		if r.byte() != 0x60 {
+			fail("malformed function type")
+		}
+		ft := &FuncType{}
+		for i := r.count(); i > 0; i-- {
+			ft.Params = append(ft.Params, decodeValType(r))
+		}
+		for i := r.count(); i > 0; i-- {
+			ft.Results = append(ft.Results, decodeValType(r))
+		}
+		canon := uint32(len(m.types))
+		for i, other := range m.types {
+			if ft.equals(other) {
+				canon = uint32(i)
+				break
+			}
+		}
+		m.types = append(m.types, ft)
+		m.canonTypes = append(m.canonTypes, canon)
+	}
+}
+
+func (m *Module) decodeImports(r *reader) {
+	for n := r.count(); n > 0; n-- {
+		imp := importEntry{module: r.name(), name: r.name(), kind: r.byte()}
+		switch imp.kind {
+		case externFunc:
+			imp.typeIdx = m.typeIndex(r.u32())
+			m.funcTypes = append(m.funcTypes, imp.typeIdx)
+			m.numImport++
+		case externTable:
+			decodeTableType(r)
+			fail("table imports are not supported: %s.%s", imp.module, imp.name)
+		case externMemory:
+			decodeMemoryType(r)
+			fail("memory imports are not supported: %s.%s", imp.module, imp.name)
+		case externGlobal:
+			decodeGlobalType(r)
+			fail("global imports are not supported: %s.%s", imp.module, imp.name)
+		default:
+			fail("malformed import kind %d", imp.kind)
+		}
+		m.imports = append(m.imports, imp)
+	}
+}
+
+// decodeConstExpr decodes a constant expression of the given type.
+// Since there are no imported globals, global.get is not allowed.
+func (m *Module) decodeConstExpr(r *reader, t ValType) constExpr {
+	expr := constExpr{op: r.byte()}
+	var exprType ValType
+	switch expr.op {
+	case opI32Const:
+		expr.val = uint64(uint32(r.s32()))
+		exprType = I32
+	case opI64Const:
+		expr.val = uint64(r.s64())
+		exprType = I64
+	case opF32Const:
+		expr.val = uint64(r.u32le())
+		exprType = F32
+	case opF64Const:
+		expr.val = r.u64le()
+		exprType = F64
+	case opRefNull:
+		exprType = decodeRefType(r)
+	case opRefFunc:
+		expr.val = uint64(r.u32())
+		if expr.val >= uint64(len(m.funcTypes)) {
+			fail("unknown function %d", expr.val)
+		}
+		m.declaredRefs[uint32(expr.val)] = true
+		exprType = FuncRef
+	case opGlobalGet:
+		fail("unknown global %d", r.u32())
+	default:
+		fail("constant expression required")
+	}
+	if exprType != t {
+		fail("type mismatch: expected %s, got %s", t, exprType)
+	}
+	if r.byte() != opEnd {
+		fail("constant expression required")
+	}
+	return expr
+}
+
+func (m *Module) decodeGlobals(r *reader) {
+	for n := r.count(); n > 0; n-- {
+		gt := decodeGlobalType(r)
+		m.globals = append(m.globals, globalDef{globalType: gt, init: m.decodeConstExpr(r, gt.typ)})
+	}
+}
+
+func (m *Module) decodeExports(r *reader) {
+	for n := r.count(); n > 0; n-- {
+		name := r.name()
+		exp := exportEntry{kind: r.byte(), index: r.u32()}
+		if _, ok := m.exports[name]; ok {
+			fail("duplicate export name %q", name)
+		}
+		var count int
+		switch exp.kind {
+		case externFunc:
+			count = len(m.funcTypes)
+			if exp.index < uint32(count) {
+				m.declaredRefs[exp.index] = true
+			}
+		case externTable:
+			count = len(m.tables)
+		case externMemory:
+			count = len(m.memories)
+		case externGlobal:
+			count = len(m.globals)
+		default:
+			fail("malformed export kind %d", exp.kind)
+		}
+		if exp.index >= uint32(count) {
+			fail("unknown export index %d", exp.index)
+		}
+		m.exports[name] = exp
+	}
+}
+
+func (m *Module) decodeElems(r *reader) {
+	for n := r.count(); n > 0; n-- {
+		flags := r.u32()
+		if flags > 7 {
+			fail("malformed elements segment kind %d", flags)
+		}
+		seg := elemSegment{typ: FuncRef}
+		switch {
+		case flags&0x01 == 0:
+			seg.mode = segmentActive
+			if flags&0x02 != 0 {
+				seg.table = r.u32()
+			}
+			seg.offset = m.decodeConstExpr(r, I32)
+		case flags&0x02 == 0:
+			seg.mode = segmentPassive
+		default:
+			seg.mode = segmentDeclarative
+		}
+		useExprs := flags&0x04 != 0
+		if flags&0x03 != 0 {
+			// explicit element kind or reference type
+			if useExprs {
+				seg.typ = decodeRefType(r)
+			} else if r.byte() != 0x00 {
+				fail("malformed element kind")
+			}
+		}
+		for i := r.count(); i > 0; i-- {
+			if useExprs {
+				seg.init = append(seg.init, m.decodeConstExpr(r, seg.typ))
+				continue
+			}
+			funcIdx := r.u32()
+			if funcIdx >= uint32(len(m.funcTypes)) {
+				fail("unknown function %d", funcIdx)
+			}
+			m.declaredRefs[funcIdx] = true
+			seg.init = append(seg.init, constExpr{op: opRefFunc, val: uint64(funcIdx)})
+		}
+		if seg.mode == segmentActive {
+			if seg.table >= uint32(len(m.tables)) {
+				fail("unknown table %d", seg.table)
+			}
+			if m.tables[seg.table].elem != seg.typ {
+				fail("type mismatch: element segment does not match table type")
+			}
+		}
+		m.elems = append(m.elems, seg)
+	}
+}
+
+func (m *Module) decodeDatas(r *reader) {
+	for n := r.count(); n > 0; n-- {
+		flags := r.u32()
+		seg := dataSegment{}
+		switch flags {
+		case 0:
+			seg.mode = segmentActive
+			seg.offset = m.decodeConstExpr(r, I32)
+		case 1:
+			seg.mode = segmentPassive
+		case 2:
+			seg.mode = segmentActive
+			if r.u32() != 0 {
+				fail("unknown memory")
+			}
+			seg.offset = m.decodeConstExpr(r, I32)
+		default:
+			fail("malformed data segment kind %d", flags)
+		}
+		if seg.mode == segmentActive && len(m.memories) == 0 {
+			fail("unknown memory 0")
+		}
+		seg.init = r.bytes(r.u32())
+		m.datas = append(m.datas, seg)
+	}
+}
+
+func (m *Module) validate() {
+	if len(m.memories) > 1 {
+		fail("multiple memories are not supported")
+	}
+	if m.hasStart {
+		if m.start >= uint32(len(m.funcTypes)) {
+			fail("unknown function %d", m.start)
+		}
+		ft := m.funcType(m.start)
+		if len(ft.Params) != 0 || len(ft.Results) != 0 {
+			fail("start function must not have parameters or results")
+		}
+	}
+}
+
+// ExportedFunc returns the type of the exported function with the given name.
+func (m *Module) ExportedFunc(name string) (*FuncType, error) {
+	exp, ok := m.exports[name]
+	if !ok || exp.kind != externFunc {
+		return nil, errors.New("unknown export function: '" + name + "'")
+	}
+	return m.funcType(exp.index), nil
+}
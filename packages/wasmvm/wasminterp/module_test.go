@@ -0,0 +1,51 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package wasminterp_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/packages/wasmvm/wasminterp"
+)
+
+func TestNewModule(t *testing.T) {
+	for _, path := range []string{
+		"../../vm/core/testcore/sbtests/sbtestsc/testcore_bg.wasm",
+		"../../../documentation/tutorial-examples/test/solotutorial_bg.wasm",
+	} {
+		wasmData, err := os.ReadFile(path)
+		require.NoError(t, err)
+		module, err := wasminterp.NewModule(wasmData)
+		require.NoError(t, err, path)
+		typ, err := module.ExportedFunc("on_call")
+		require.NoError(t, err, path)
+		require.Equal(t, []wasminterp.ValType{wasminterp.I32}, typ.Params, path)
+	}
+}
+
+func TestNewModuleInvalid(t *testing.T) {
+	header := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	_, err := wasminterp.NewModule(nil)
+	require.Error(t, err)
+	_, err = wasminterp.NewModule(header[:7])
+	require.Error(t, err)
+
+	module, err := wasminterp.NewModule(header)
+	require.NoError(t, err)
+	_, err = module.ExportedFunc("on_call")
+	require.Error(t, err)
+
+	// function body that returns an i64 from a function without results
+	invalid := append(append([]byte(nil), header...),
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00, // type section: func() -> ()
+		0x03, 0x02, 0x01, 0x00, // function section
+		0x0a, 0x06, 0x01, 0x04, 0x00, 0x42, 0x00, 0x0b, // code section: i64.const 0
+	)
+	_, err = wasminterp.NewModule(invalid)
+	require.Error(t, err)
+}
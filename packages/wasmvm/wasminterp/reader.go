@@ -0,0 +1,130 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package wasminterp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of data")
+
+// decodeError is used to abort decoding from deep within the reader,
+// it is recovered by NewModule and turned into a regular error.
+type decodeError struct {
+	err error
+}
+
+func fail(format string, args ...interface{}) {
+	panic(&decodeError{err: fmt.Errorf(format, args...)})
+}
+
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func (r *reader) eof() bool {
+	return r.pos >= len(r.data)
+}
+
+func (r *reader) byte() byte {
+	if r.pos >= len(r.data) {
+		panic(&decodeError{err: errUnexpectedEnd})
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *reader) bytes(n uint32) []byte {
+	if uint64(r.pos)+uint64(n) > uint64(len(r.data)) {
+		panic(&decodeError{err: errUnexpectedEnd})
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b
+}
+
+// leb decodes an LEB128 integer of at most the given number of bits.
+// Unused bits of the last byte must be zero (or the sign extension for signed integers).
+func (r *reader) leb(bits uint, signed bool) uint64 {
+	var result uint64
+	var shift uint
+	for {
+		b := r.byte()
+		if shift+7 >= bits {
+			// last allowed byte, check the unused bits
+			remaining := bits - shift
+			if b&0x80 != 0 {
+				fail("integer representation too long")
+			}
+			if signed {
+				mask := byte(0x7f) &^ (byte(1)<<(remaining-1) - 1)
+				if b&mask != 0 && b&mask != mask {
+					fail("integer too large")
+				}
+			} else if remaining < 7 && b>>remaining != 0 {
+				fail("integer too large")
+			}
+			result |= uint64(b&0x7f) << shift
+			shift += 7
+			break
+		}
+		result |= uint64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	if signed && shift < 64 && result&(1<<(shift-1)) != 0 {
+		result |= math.MaxUint64 << shift
+	}
+	return result
+}
+
+func (r *reader) u32() uint32 {
+	return uint32(r.leb(32, false))
+}
+
+func (r *reader) s32() int32 {
+	return int32(r.leb(32, true))
+}
+
+func (r *reader) s33() int64 {
+	return int64(r.leb(33, true))
+}
+
+func (r *reader) s64() int64 {
+	return int64(r.leb(64, true))
+}
+
+func (r *reader) u32le() uint32 {
+	b := r.bytes(4)
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+}
+
+func (r *reader) u64le() uint64 {
+	return uint64(r.u32le()) | uint64(r.u32le())<<32
+}
+
+func (r *reader) name() string {
+	b := r.bytes(r.u32())
+	if !utf8.Valid(b) {
+		fail("malformed UTF-8 encoding")
+	}
+	return string(b)
+}
+
+// count reads a vector length, and checks that there are at least as many bytes
+// left as there are elements, so that a bogus length can't cause a huge allocation.
+func (r *reader) count() uint32 {
+	n := r.u32()
+	if uint64(n) > uint64(len(r.data)-r.pos) {
+		panic(&decodeError{err: errUnexpectedEnd})
+	}
+	return n
+}
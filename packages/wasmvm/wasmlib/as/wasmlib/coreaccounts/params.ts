@@ -106,6 +106,30 @@ export class MutableNativeTokenModifySupplyParams extends wasmtypes.ScProxy {
     }
 }
 
+export class ImmutableSetSponsorAllowanceParams extends wasmtypes.ScProxy {
+    // The sponsored sender
+    agentID(): wasmtypes.ScImmutableAgentID {
+        return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamAgentID));
+    }
+
+    // amount of base tokens, zero (default) revokes the sponsorship
+    sponsorAllowance(): wasmtypes.ScImmutableUint64 {
+        return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ParamSponsorAllowance));
+    }
+}
+
+export class MutableSetSponsorAllowanceParams extends wasmtypes.ScProxy {
+    // The sponsored sender
+    agentID(): wasmtypes.ScMutableAgentID {
+        return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamAgentID));
+    }
+
+    // amount of base tokens, zero (default) revokes the sponsorship
+    sponsorAllowance(): wasmtypes.ScMutableUint64 {
+        return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ParamSponsorAllowance));
+    }
+}
+
 export class ImmutableTransferAccountToChainParams extends wasmtypes.ScProxy {
     // Optional gas amount to reserve in the allowance for the internal
     // call to transferAllowanceTo(). Default 10_000 (MinGasFee).
@@ -292,6 +316,30 @@ export class MutableGetAccountNonceParams extends wasmtypes.ScProxy {
     }
 }
 
+export class ImmutableGetSponsorAllowanceParams extends wasmtypes.ScProxy {
+    // sponsored sender (default: caller)
+    agentID(): wasmtypes.ScImmutableAgentID {
+        return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamAgentID));
+    }
+
+    // sponsor agent ID
+    sponsor(): wasmtypes.ScImmutableAgentID {
+        return new wasmtypes.ScImmutableAgentID(this.proxy.root(sc.ParamSponsor));
+    }
+}
+
+export class MutableGetSponsorAllowanceParams extends wasmtypes.ScProxy {
+    // sponsored sender (default: caller)
+    agentID(): wasmtypes.ScMutableAgentID {
+        return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamAgentID));
+    }
+
+    // sponsor agent ID
+    sponsor(): wasmtypes.ScMutableAgentID {
+        return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamSponsor));
+    }
+}
+
 export class ImmutableNativeTokenParams extends wasmtypes.ScProxy {
     // serial number of the foundry
     foundrySN(): wasmtypes.ScImmutableUint32 {
@@ -244,6 +244,20 @@ export class MutableGetNativeTokenIDRegistryResults extends wasmtypes.ScProxy {
     }
 }
 
+export class ImmutableGetSponsorAllowanceResults extends wasmtypes.ScProxy {
+    // amount of base tokens
+    sponsorAllowance(): wasmtypes.ScImmutableUint64 {
+        return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ResultSponsorAllowance));
+    }
+}
+
+export class MutableGetSponsorAllowanceResults extends wasmtypes.ScProxy {
+    // amount of base tokens
+    sponsorAllowance(): wasmtypes.ScMutableUint64 {
+        return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ResultSponsorAllowance));
+    }
+}
+
 export class ImmutableNativeTokenResults extends wasmtypes.ScProxy {
     // serialized foundry output
     foundryOutputBin(): wasmtypes.ScImmutableBytes {
@@ -6,6 +6,25 @@
 import * as wasmlib from '../index';
 import * as sc from './index';
 
+export class CancelScheduledRequestCall {
+    func:   wasmlib.ScFunc;
+    params: sc.MutableCancelScheduledRequestParams = new sc.MutableCancelScheduledRequestParams(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScFuncClientContext) {
+        this.func = new wasmlib.ScFunc(ctx, sc.HScName, sc.HFuncCancelScheduledRequest);
+    }
+}
+
+export class ScheduleRequestCall {
+    func:    wasmlib.ScFunc;
+    params:  sc.MutableScheduleRequestParams = new sc.MutableScheduleRequestParams(wasmlib.ScView.nilProxy);
+    results: sc.ImmutableScheduleRequestResults = new sc.ImmutableScheduleRequestResults(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScFuncClientContext) {
+        this.func = new wasmlib.ScFunc(ctx, sc.HScName, sc.HFuncScheduleRequest);
+    }
+}
+
 export class GetBlockInfoCall {
     func:    wasmlib.ScView;
     params:  sc.MutableGetBlockInfoParams = new sc.MutableGetBlockInfoParams(wasmlib.ScView.nilProxy);
@@ -66,6 +85,16 @@ export class GetRequestReceiptsForBlockCall {
     }
 }
 
+export class GetScheduledRequestCall {
+    func:    wasmlib.ScView;
+    params:  sc.MutableGetScheduledRequestParams = new sc.MutableGetScheduledRequestParams(wasmlib.ScView.nilProxy);
+    results: sc.ImmutableGetScheduledRequestResults = new sc.ImmutableGetScheduledRequestResults(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScViewClientContext) {
+        this.func = new wasmlib.ScView(ctx, sc.HScName, sc.HViewGetScheduledRequest);
+    }
+}
+
 export class IsRequestProcessedCall {
     func:    wasmlib.ScView;
     params:  sc.MutableIsRequestProcessedParams = new sc.MutableIsRequestProcessedParams(wasmlib.ScView.nilProxy);
@@ -77,6 +106,22 @@ export class IsRequestProcessedCall {
 }
 
 export class ScFuncs {
+    // Cancels a scheduled call and refunds its remaining prepaid gas to the owner.
+    static cancelScheduledRequest(ctx: wasmlib.ScFuncClientContext): CancelScheduledRequestCall {
+        const f = new CancelScheduledRequestCall(ctx);
+        f.params = new sc.MutableCancelScheduledRequestParams(wasmlib.newCallParamsProxy(f.func));
+        return f;
+    }
+
+    // Schedules a call to run at a future time or block, optionally recurring.
+    // The base tokens in the allowance are kept as prepaid gas for the runs of the call.
+    static scheduleRequest(ctx: wasmlib.ScFuncClientContext): ScheduleRequestCall {
+        const f = new ScheduleRequestCall(ctx);
+        f.params = new sc.MutableScheduleRequestParams(wasmlib.newCallParamsProxy(f.func));
+        f.results = new sc.ImmutableScheduleRequestResults(wasmlib.newCallResultsProxy(f.func));
+        return f;
+    }
+
     // Returns information about the given block.
     static getBlockInfo(ctx: wasmlib.ScViewClientContext): GetBlockInfoCall {
         const f = new GetBlockInfoCall(ctx);
@@ -125,6 +170,14 @@ export class ScFuncs {
         return f;
     }
 
+    // Returns the call scheduled with the given ID.
+    static getScheduledRequest(ctx: wasmlib.ScViewClientContext): GetScheduledRequestCall {
+        const f = new GetScheduledRequestCall(ctx);
+        f.params = new sc.MutableGetScheduledRequestParams(wasmlib.newCallParamsProxy(f.func));
+        f.results = new sc.ImmutableGetScheduledRequestResults(wasmlib.newCallResultsProxy(f.func));
+        return f;
+    }
+
     // Returns whether the request with ID u has been processed.
     static isRequestProcessed(ctx: wasmlib.ScViewClientContext): IsRequestProcessedCall {
         const f = new IsRequestProcessedCall(ctx);
@@ -6,6 +6,94 @@
 import * as wasmtypes from '../wasmtypes';
 import * as sc from './index';
 
+export class ImmutableCancelScheduledRequestParams extends wasmtypes.ScProxy {
+    // ID of the scheduled call
+    scheduleID(): wasmtypes.ScImmutableUint32 {
+        return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamScheduleID));
+    }
+}
+
+export class MutableCancelScheduledRequestParams extends wasmtypes.ScProxy {
+    // ID of the scheduled call
+    scheduleID(): wasmtypes.ScMutableUint32 {
+        return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamScheduleID));
+    }
+}
+
+export class ImmutableScheduleRequestParams extends wasmtypes.ScProxy {
+    // target entry point
+    entryPoint(): wasmtypes.ScImmutableHname {
+        return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamEntryPoint));
+    }
+
+    // gas budget of each run
+    gasBudget(): wasmtypes.ScImmutableUint64 {
+        return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ParamGasBudget));
+    }
+
+    // target contract
+    hname(): wasmtypes.ScImmutableHname {
+        return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
+    }
+
+    // index of the block the call is due
+    scheduleBlock(): wasmtypes.ScImmutableUint32 {
+        return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamScheduleBlock));
+    }
+
+    // interval of a recurring call, nanoseconds or number of blocks
+    scheduleInterval(): wasmtypes.ScImmutableUint64 {
+        return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ParamScheduleInterval));
+    }
+
+    // serialized params of the call
+    scheduleParams(): wasmtypes.ScImmutableBytes {
+        return new wasmtypes.ScImmutableBytes(this.proxy.root(sc.ParamScheduleParams));
+    }
+
+    // time the call is due, in nanoseconds since epoch
+    scheduleTime(): wasmtypes.ScImmutableInt64 {
+        return new wasmtypes.ScImmutableInt64(this.proxy.root(sc.ParamScheduleTime));
+    }
+}
+
+export class MutableScheduleRequestParams extends wasmtypes.ScProxy {
+    // target entry point
+    entryPoint(): wasmtypes.ScMutableHname {
+        return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamEntryPoint));
+    }
+
+    // gas budget of each run
+    gasBudget(): wasmtypes.ScMutableUint64 {
+        return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ParamGasBudget));
+    }
+
+    // target contract
+    hname(): wasmtypes.ScMutableHname {
+        return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamHname));
+    }
+
+    // index of the block the call is due
+    scheduleBlock(): wasmtypes.ScMutableUint32 {
+        return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamScheduleBlock));
+    }
+
+    // interval of a recurring call, nanoseconds or number of blocks
+    scheduleInterval(): wasmtypes.ScMutableUint64 {
+        return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ParamScheduleInterval));
+    }
+
+    // serialized params of the call
+    scheduleParams(): wasmtypes.ScMutableBytes {
+        return new wasmtypes.ScMutableBytes(this.proxy.root(sc.ParamScheduleParams));
+    }
+
+    // time the call is due, in nanoseconds since epoch
+    scheduleTime(): wasmtypes.ScMutableInt64 {
+        return new wasmtypes.ScMutableInt64(this.proxy.root(sc.ParamScheduleTime));
+    }
+}
+
 export class ImmutableGetBlockInfoParams extends wasmtypes.ScProxy {
     // default last block
     blockIndex(): wasmtypes.ScImmutableUint32 {
@@ -90,6 +178,20 @@ export class MutableGetRequestReceiptsForBlockParams extends wasmtypes.ScProxy {
     }
 }
 
+export class ImmutableGetScheduledRequestParams extends wasmtypes.ScProxy {
+    // ID of the scheduled call
+    scheduleID(): wasmtypes.ScImmutableUint32 {
+        return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ParamScheduleID));
+    }
+}
+
+export class MutableGetScheduledRequestParams extends wasmtypes.ScProxy {
+    // ID of the scheduled call
+    scheduleID(): wasmtypes.ScMutableUint32 {
+        return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ParamScheduleID));
+    }
+}
+
 export class ImmutableIsRequestProcessedParams extends wasmtypes.ScProxy {
     // target request ID
     requestID(): wasmtypes.ScImmutableRequestID {
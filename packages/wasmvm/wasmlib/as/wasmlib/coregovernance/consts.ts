@@ -18,11 +18,14 @@ export const ParamChainOwner    = 'o';
 export const ParamFeePolicy     = 'g';
 export const ParamGasLimits     = 'l';
 export const ParamGasRatio      = 'e';
+export const ParamHname         = 'h';
 export const ParamMetadata      = 'md';
+export const ParamOwnerFee      = 'of';
 export const ParamPayoutAgentID = 's';
 export const ParamPubKey        = 'ip';
 export const ParamPublicURL     = 'x';
 export const ParamSetMinSD      = 'ms';
+export const ParamValidatorFee  = 'vf';
 
 export const ResultAccessNodeCandidates = 'an';
 export const ResultAccessNodes          = 'ac';
@@ -34,9 +37,11 @@ export const ResultGasLimits            = 'l';
 export const ResultGasRatio             = 'e';
 export const ResultGetMinSD             = 'ms';
 export const ResultMetadata             = 'md';
+export const ResultOwnerFee             = 'of';
 export const ResultPayoutAgentID        = 's';
 export const ResultPublicURL            = 'x';
 export const ResultStatus               = 'm';
+export const ResultValidatorFee         = 'vf';
 
 export const FuncAddAllowedStateControllerAddress    = 'addAllowedStateControllerAddress';
 export const FuncAddCandidateNode                    = 'addCandidateNode';
@@ -46,6 +51,7 @@ export const FuncDelegateChainOwnership              = 'delegateChainOwnership';
 export const FuncRemoveAllowedStateControllerAddress = 'removeAllowedStateControllerAddress';
 export const FuncRevokeAccessNode                    = 'revokeAccessNode';
 export const FuncRotateStateController               = 'rotateStateController';
+export const FuncSetContractFees                     = 'setContractFees';
 export const FuncSetEVMGasRatio                      = 'setEVMGasRatio';
 export const FuncSetFeePolicy                        = 'setFeePolicy';
 export const FuncSetGasLimits                        = 'setGasLimits';
@@ -58,6 +64,7 @@ export const ViewGetAllowedStateControllerAddresses  = 'getAllowedStateControlle
 export const ViewGetChainInfo                        = 'getChainInfo';
 export const ViewGetChainNodes                       = 'getChainNodes';
 export const ViewGetChainOwner                       = 'getChainOwner';
+export const ViewGetContractFees                     = 'getContractFees';
 export const ViewGetEVMGasRatio                      = 'getEVMGasRatio';
 export const ViewGetFeePolicy                        = 'getFeePolicy';
 export const ViewGetGasLimits                        = 'getGasLimits';
@@ -74,6 +81,7 @@ export const HFuncDelegateChainOwnership              = new wasmtypes.ScHname(0x
 export const HFuncRemoveAllowedStateControllerAddress = new wasmtypes.ScHname(0x31f69447);
 export const HFuncRevokeAccessNode                    = new wasmtypes.ScHname(0x5459512d);
 export const HFuncRotateStateController               = new wasmtypes.ScHname(0x244d1038);
+export const HFuncSetContractFees                     = new wasmtypes.ScHname(0x7764488a);
 export const HFuncSetEVMGasRatio                      = new wasmtypes.ScHname(0xaae22338);
 export const HFuncSetFeePolicy                        = new wasmtypes.ScHname(0x5b791c9f);
 export const HFuncSetGasLimits                        = new wasmtypes.ScHname(0xd72fb355);
@@ -86,6 +94,7 @@ export const HViewGetAllowedStateControllerAddresses  = new wasmtypes.ScHname(0x
 export const HViewGetChainInfo                        = new wasmtypes.ScHname(0x434477e2);
 export const HViewGetChainNodes                       = new wasmtypes.ScHname(0xe1832289);
 export const HViewGetChainOwner                       = new wasmtypes.ScHname(0x9b2ef0ac);
+export const HViewGetContractFees                     = new wasmtypes.ScHname(0x001c3237);
 export const HViewGetEVMGasRatio                      = new wasmtypes.ScHname(0xb81c8c34);
 export const HViewGetFeePolicy                        = new wasmtypes.ScHname(0xf8c89790);
 export const HViewGetGasLimits                        = new wasmtypes.ScHname(0x3a493455);
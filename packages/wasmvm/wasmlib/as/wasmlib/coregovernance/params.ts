@@ -168,6 +168,40 @@ export class MutableRotateStateControllerParams extends wasmtypes.ScProxy {
     }
 }
 
+export class ImmutableSetContractFeesParams extends wasmtypes.ScProxy {
+    // contract hname
+    hname(): wasmtypes.ScImmutableHname {
+        return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
+    }
+
+    // chain owner part of the fee per 1000 gas
+    ownerFee(): wasmtypes.ScImmutableUint64 {
+        return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ParamOwnerFee));
+    }
+
+    // validator part of the fee per 1000 gas
+    validatorFee(): wasmtypes.ScImmutableUint64 {
+        return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ParamValidatorFee));
+    }
+}
+
+export class MutableSetContractFeesParams extends wasmtypes.ScProxy {
+    // contract hname
+    hname(): wasmtypes.ScMutableHname {
+        return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamHname));
+    }
+
+    // chain owner part of the fee per 1000 gas
+    ownerFee(): wasmtypes.ScMutableUint64 {
+        return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ParamOwnerFee));
+    }
+
+    // validator part of the fee per 1000 gas
+    validatorFee(): wasmtypes.ScMutableUint64 {
+        return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ParamValidatorFee));
+    }
+}
+
 export class ImmutableSetEVMGasRatioParams extends wasmtypes.ScProxy {
     // serialized gas ratio
     gasRatio(): wasmtypes.ScImmutableBytes {
@@ -259,3 +293,17 @@ export class MutableSetPayoutAgentIDParams extends wasmtypes.ScProxy {
         return new wasmtypes.ScMutableAgentID(this.proxy.root(sc.ParamPayoutAgentID));
     }
 }
+
+export class ImmutableGetContractFeesParams extends wasmtypes.ScProxy {
+    // contract hname
+    hname(): wasmtypes.ScImmutableHname {
+        return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
+    }
+}
+
+export class MutableGetContractFeesParams extends wasmtypes.ScProxy {
+    // contract hname
+    hname(): wasmtypes.ScMutableHname {
+        return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamHname));
+    }
+}
@@ -13,14 +13,18 @@ export class CoreRootEventHandlers implements wasmlib.IEventHandlers {
     /* eslint-disable @typescript-eslint/no-empty-function */
     deploy: (evt: EventDeploy) => void = () => {};
     grant: (evt: EventGrant) => void = () => {};
+    retire: (evt: EventRetire) => void = () => {};
     revoke: (evt: EventRevoke) => void = () => {};
+    upgrade: (evt: EventUpgrade) => void = () => {};
     /* eslint-enable @typescript-eslint/no-empty-function */
 
     public constructor() {
         this.myID = wasmlib.eventHandlersGenerateID();
         this.coreRootHandlers.set('coreroot.deploy', (evt: CoreRootEventHandlers, dec: wasmlib.WasmDecoder) => evt.deploy(new EventDeploy(dec)));
         this.coreRootHandlers.set('coreroot.grant', (evt: CoreRootEventHandlers, dec: wasmlib.WasmDecoder) => evt.grant(new EventGrant(dec)));
+        this.coreRootHandlers.set('coreroot.retire', (evt: CoreRootEventHandlers, dec: wasmlib.WasmDecoder) => evt.retire(new EventRetire(dec)));
         this.coreRootHandlers.set('coreroot.revoke', (evt: CoreRootEventHandlers, dec: wasmlib.WasmDecoder) => evt.revoke(new EventRevoke(dec)));
+        this.coreRootHandlers.set('coreroot.upgrade', (evt: CoreRootEventHandlers, dec: wasmlib.WasmDecoder) => evt.upgrade(new EventUpgrade(dec)));
     }
 
     public callHandler(topic: string, dec: wasmlib.WasmDecoder): void {
@@ -42,9 +46,17 @@ export class CoreRootEventHandlers implements wasmlib.IEventHandlers {
         this.grant = handler;
     }
 
+    public onCoreRootRetire(handler: (evt: EventRetire) => void): void {
+        this.retire = handler;
+    }
+
     public onCoreRootRevoke(handler: (evt: EventRevoke) => void): void {
         this.revoke = handler;
     }
+
+    public onCoreRootUpgrade(handler: (evt: EventUpgrade) => void): void {
+        this.upgrade = handler;
+    }
 }
 
 export class EventDeploy {
@@ -71,6 +83,17 @@ export class EventGrant {
     }
 }
 
+export class EventRetire {
+    public readonly timestamp: u64;
+    public readonly hname: wasmtypes.ScHname;
+
+    public constructor(dec: wasmlib.WasmDecoder) {
+        this.timestamp = wasmtypes.uint64Decode(dec);
+        this.hname = wasmtypes.hnameDecode(dec);
+        dec.close();
+    }
+}
+
 export class EventRevoke {
     public readonly timestamp: u64;
     public readonly deployer: wasmtypes.ScAgentID;
@@ -81,3 +104,18 @@ export class EventRevoke {
         dec.close();
     }
 }
+
+export class EventUpgrade {
+    public readonly timestamp: u64;
+    public readonly hname: wasmtypes.ScHname;
+    public readonly oldProgHash: wasmtypes.ScHash;
+    public readonly progHash: wasmtypes.ScHash;
+
+    public constructor(dec: wasmlib.WasmDecoder) {
+        this.timestamp = wasmtypes.uint64Decode(dec);
+        this.hname = wasmtypes.hnameDecode(dec);
+        this.oldProgHash = wasmtypes.hashDecode(dec);
+        this.progHash = wasmtypes.hashDecode(dec);
+        dec.close();
+    }
+}
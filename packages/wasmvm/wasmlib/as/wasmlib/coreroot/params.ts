@@ -88,6 +88,20 @@ export class MutableRequireDeployPermissionsParams extends wasmtypes.ScProxy {
     }
 }
 
+export class ImmutableRetireContractParams extends wasmtypes.ScProxy {
+    // The smart contract’s Hname
+    hname(): wasmtypes.ScImmutableHname {
+        return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
+    }
+}
+
+export class MutableRetireContractParams extends wasmtypes.ScProxy {
+    // The smart contract’s Hname
+    hname(): wasmtypes.ScMutableHname {
+        return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamHname));
+    }
+}
+
 export class ImmutableRevokeDeployPermissionParams extends wasmtypes.ScProxy {
     // agent to revoke deploy permission for
     deployer(): wasmtypes.ScImmutableAgentID {
@@ -102,6 +116,30 @@ export class MutableRevokeDeployPermissionParams extends wasmtypes.ScProxy {
     }
 }
 
+export class ImmutableUpgradeContractParams extends wasmtypes.ScProxy {
+    // The smart contract’s Hname
+    hname(): wasmtypes.ScImmutableHname {
+        return new wasmtypes.ScImmutableHname(this.proxy.root(sc.ParamHname));
+    }
+
+    // hash of blob that has been previously stored in blob contract
+    programHash(): wasmtypes.ScImmutableHash {
+        return new wasmtypes.ScImmutableHash(this.proxy.root(sc.ParamProgramHash));
+    }
+}
+
+export class MutableUpgradeContractParams extends wasmtypes.ScProxy {
+    // The smart contract’s Hname
+    hname(): wasmtypes.ScMutableHname {
+        return new wasmtypes.ScMutableHname(this.proxy.root(sc.ParamHname));
+    }
+
+    // hash of blob that has been previously stored in blob contract
+    programHash(): wasmtypes.ScMutableHash {
+        return new wasmtypes.ScMutableHash(this.proxy.root(sc.ParamProgramHash));
+    }
+}
+
 export class ImmutableFindContractParams extends wasmtypes.ScProxy {
     // The smart contract’s Hname
     hname(): wasmtypes.ScImmutableHname {
@@ -37,11 +37,23 @@ export class MapHnameToImmutableBytes extends wasmtypes.ScProxy {
     }
 }
 
+export class MapHnameToImmutableBool extends wasmtypes.ScProxy {
+
+    getBool(key: wasmtypes.ScHname): wasmtypes.ScImmutableBool {
+        return new wasmtypes.ScImmutableBool(this.proxy.key(wasmtypes.hnameToBytes(key)));
+    }
+}
+
 export class ImmutableGetContractRecordsResults extends wasmtypes.ScProxy {
     // contract records by Hname
     contractRegistry(): sc.MapHnameToImmutableBytes {
         return new sc.MapHnameToImmutableBytes(this.proxy.root(sc.ResultContractRegistry));
     }
+
+    // retired contracts by Hname
+    retiredContracts(): sc.MapHnameToImmutableBool {
+        return new sc.MapHnameToImmutableBool(this.proxy.root(sc.ResultRetiredContracts));
+    }
 }
 
 export class MapHnameToMutableBytes extends wasmtypes.ScProxy {
@@ -55,9 +67,25 @@ export class MapHnameToMutableBytes extends wasmtypes.ScProxy {
     }
 }
 
+export class MapHnameToMutableBool extends wasmtypes.ScProxy {
+
+    clear(): void {
+        this.proxy.clearMap();
+    }
+
+    getBool(key: wasmtypes.ScHname): wasmtypes.ScMutableBool {
+        return new wasmtypes.ScMutableBool(this.proxy.key(wasmtypes.hnameToBytes(key)));
+    }
+}
+
 export class MutableGetContractRecordsResults extends wasmtypes.ScProxy {
     // contract records by Hname
     contractRegistry(): sc.MapHnameToMutableBytes {
         return new sc.MapHnameToMutableBytes(this.proxy.root(sc.ResultContractRegistry));
     }
+
+    // retired contracts by Hname
+    retiredContracts(): sc.MapHnameToMutableBool {
+        return new sc.MapHnameToMutableBool(this.proxy.root(sc.ResultRetiredContracts));
+    }
 }
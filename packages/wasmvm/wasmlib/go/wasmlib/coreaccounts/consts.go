@@ -14,18 +14,20 @@ const (
 )
 
 const (
-	ParamAgentID        = "a"
-	ParamCollection     = "C"
-	ParamDestroyTokens  = "y"
-	ParamFoundrySN      = "s"
-	ParamGasReserve     = "g"
-	ParamNftID          = "z"
-	ParamSupplyDeltaAbs = "d"
-	ParamTokenDecimals  = "td"
-	ParamTokenID        = "N"
-	ParamTokenName      = "tn"
-	ParamTokenScheme    = "t"
-	ParamTokenSymbol    = "ts"
+	ParamAgentID          = "a"
+	ParamCollection       = "C"
+	ParamDestroyTokens    = "y"
+	ParamFoundrySN        = "s"
+	ParamGasReserve       = "g"
+	ParamNftID            = "z"
+	ParamSponsor          = "sp"
+	ParamSponsorAllowance = "sa"
+	ParamSupplyDeltaAbs   = "d"
+	ParamTokenDecimals    = "td"
+	ParamTokenID          = "N"
+	ParamTokenName        = "tn"
+	ParamTokenScheme      = "t"
+	ParamTokenSymbol      = "ts"
 )
 
 const (
@@ -40,6 +42,7 @@ const (
 	ResultMapping          = "this"
 	ResultNftData          = "e"
 	ResultNftIDs           = "i"
+	ResultSponsorAllowance = "sa"
 	ResultTokens           = "B"
 )
 
@@ -49,6 +52,7 @@ const (
 	FuncNativeTokenCreate            = "nativeTokenCreate"
 	FuncNativeTokenDestroy           = "nativeTokenDestroy"
 	FuncNativeTokenModifySupply      = "nativeTokenModifySupply"
+	FuncSetSponsorAllowance          = "setSponsorAllowance"
 	FuncTransferAccountToChain       = "transferAccountToChain"
 	FuncTransferAllowanceTo          = "transferAllowanceTo"
 	FuncWithdraw                     = "withdraw"
@@ -62,6 +66,7 @@ const (
 	ViewBalanceNativeToken           = "balanceNativeToken"
 	ViewGetAccountNonce              = "getAccountNonce"
 	ViewGetNativeTokenIDRegistry     = "getNativeTokenIDRegistry"
+	ViewGetSponsorAllowance          = "getSponsorAllowance"
 	ViewNativeToken                  = "nativeToken"
 	ViewNftData                      = "nftData"
 	ViewTotalAssets                  = "totalAssets"
@@ -73,6 +78,7 @@ const (
 	HFuncNativeTokenCreate            = wasmtypes.ScHname(0x0c2d1791)
 	HFuncNativeTokenDestroy           = wasmtypes.ScHname(0xf0b0ab00)
 	HFuncNativeTokenModifySupply      = wasmtypes.ScHname(0x24c2eab6)
+	HFuncSetSponsorAllowance          = wasmtypes.ScHname(0x3234927c)
 	HFuncTransferAccountToChain       = wasmtypes.ScHname(0x07005c45)
 	HFuncTransferAllowanceTo          = wasmtypes.ScHname(0x23f4e3a1)
 	HFuncWithdraw                     = wasmtypes.ScHname(0x9dcc0f41)
@@ -86,6 +92,7 @@ const (
 	HViewBalanceNativeToken           = wasmtypes.ScHname(0x1fea3104)
 	HViewGetAccountNonce              = wasmtypes.ScHname(0x529d7df9)
 	HViewGetNativeTokenIDRegistry     = wasmtypes.ScHname(0x2ad8a59f)
+	HViewGetSponsorAllowance          = wasmtypes.ScHname(0xc215a9ff)
 	HViewNativeToken                  = wasmtypes.ScHname(0x28e34b65)
 	HViewNftData                      = wasmtypes.ScHname(0x83c5c4da)
 	HViewTotalAssets                  = wasmtypes.ScHname(0xfab0f8d2)
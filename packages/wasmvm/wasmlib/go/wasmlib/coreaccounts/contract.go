@@ -32,6 +32,11 @@ type NativeTokenModifySupplyCall struct {
 	Params MutableNativeTokenModifySupplyParams
 }
 
+type SetSponsorAllowanceCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableSetSponsorAllowanceParams
+}
+
 type TransferAccountToChainCall struct {
 	Func   *wasmlib.ScFunc
 	Params MutableTransferAccountToChainParams
@@ -105,6 +110,12 @@ type GetNativeTokenIDRegistryCall struct {
 	Results ImmutableGetNativeTokenIDRegistryResults
 }
 
+type GetSponsorAllowanceCall struct {
+	Func    *wasmlib.ScView
+	Params  MutableGetSponsorAllowanceParams
+	Results ImmutableGetSponsorAllowanceResults
+}
+
 type NativeTokenCall struct {
 	Func    *wasmlib.ScView
 	Params  MutableNativeTokenParams
@@ -161,6 +172,14 @@ func (sc Funcs) NativeTokenModifySupply(ctx wasmlib.ScFuncClientContext) *Native
 	return f
 }
 
+// Sets the amount of base tokens the caller pays for the gas fees of
+// the off-ledger requests of the given sender that name the caller as sponsor.
+func (sc Funcs) SetSponsorAllowance(ctx wasmlib.ScFuncClientContext) *SetSponsorAllowanceCall {
+	f := &SetSponsorAllowanceCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncSetSponsorAllowance)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
 // Transfers the specified allowance from the sender SC's L2 account on
 // the target chain to the sender SC's L2 account on the origin chain.
 func (sc Funcs) TransferAccountToChain(ctx wasmlib.ScFuncClientContext) *TransferAccountToChainCall {
@@ -263,6 +282,14 @@ func (sc Funcs) GetNativeTokenIDRegistry(ctx wasmlib.ScViewClientContext) *GetNa
 	return f
 }
 
+// Returns the amount of base tokens the sponsor still pays for the gas fees of the sender.
+func (sc Funcs) GetSponsorAllowance(ctx wasmlib.ScViewClientContext) *GetSponsorAllowanceCall {
+	f := &GetSponsorAllowanceCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetSponsorAllowance)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(f.Func)
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.Proxy)
+	return f
+}
+
 // Returns specified foundry output in serialized form.
 func (sc Funcs) NativeToken(ctx wasmlib.ScViewClientContext) *NativeTokenCall {
 	f := &NativeTokenCall{Func: wasmlib.NewScView(ctx, HScName, HViewNativeToken)}
@@ -293,6 +320,7 @@ var exportMap = wasmlib.ScExportMap{
 		FuncNativeTokenCreate,
 		FuncNativeTokenDestroy,
 		FuncNativeTokenModifySupply,
+		FuncSetSponsorAllowance,
 		FuncTransferAccountToChain,
 		FuncTransferAllowanceTo,
 		FuncWithdraw,
@@ -306,6 +334,7 @@ var exportMap = wasmlib.ScExportMap{
 		ViewBalanceNativeToken,
 		ViewGetAccountNonce,
 		ViewGetNativeTokenIDRegistry,
+		ViewGetSponsorAllowance,
 		ViewNativeToken,
 		ViewNftData,
 		ViewTotalAssets,
@@ -319,6 +348,7 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.FuncError,
 		wasmlib.FuncError,
 		wasmlib.FuncError,
+		wasmlib.FuncError,
 	},
 	Views: []wasmlib.ScViewContextFunction{
 		wasmlib.ViewError,
@@ -334,6 +364,7 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.ViewError,
 		wasmlib.ViewError,
 		wasmlib.ViewError,
+		wasmlib.ViewError,
 	},
 }
 
@@ -142,6 +142,38 @@ func (s MutableNativeTokenModifySupplyParams) SupplyDeltaAbs() wasmtypes.ScMutab
 	return wasmtypes.NewScMutableBigInt(s.Proxy.Root(ParamSupplyDeltaAbs))
 }
 
+type ImmutableSetSponsorAllowanceParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableSetSponsorAllowanceParams() ImmutableSetSponsorAllowanceParams {
+	return ImmutableSetSponsorAllowanceParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// The sponsored sender
+func (s ImmutableSetSponsorAllowanceParams) AgentID() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.Proxy.Root(ParamAgentID))
+}
+
+// amount of base tokens, zero (default) revokes the sponsorship
+func (s ImmutableSetSponsorAllowanceParams) SponsorAllowance() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.Proxy.Root(ParamSponsorAllowance))
+}
+
+type MutableSetSponsorAllowanceParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// The sponsored sender
+func (s MutableSetSponsorAllowanceParams) AgentID() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.Proxy.Root(ParamAgentID))
+}
+
+// amount of base tokens, zero (default) revokes the sponsorship
+func (s MutableSetSponsorAllowanceParams) SponsorAllowance() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.Proxy.Root(ParamSponsorAllowance))
+}
+
 type ImmutableTransferAccountToChainParams struct {
 	Proxy wasmtypes.Proxy
 }
@@ -416,6 +448,38 @@ func (s MutableGetAccountNonceParams) AgentID() wasmtypes.ScMutableAgentID {
 	return wasmtypes.NewScMutableAgentID(s.Proxy.Root(ParamAgentID))
 }
 
+type ImmutableGetSponsorAllowanceParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableGetSponsorAllowanceParams() ImmutableGetSponsorAllowanceParams {
+	return ImmutableGetSponsorAllowanceParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// sponsored sender (default: caller)
+func (s ImmutableGetSponsorAllowanceParams) AgentID() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.Proxy.Root(ParamAgentID))
+}
+
+// sponsor agent ID
+func (s ImmutableGetSponsorAllowanceParams) Sponsor() wasmtypes.ScImmutableAgentID {
+	return wasmtypes.NewScImmutableAgentID(s.Proxy.Root(ParamSponsor))
+}
+
+type MutableGetSponsorAllowanceParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// sponsored sender (default: caller)
+func (s MutableGetSponsorAllowanceParams) AgentID() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.Proxy.Root(ParamAgentID))
+}
+
+// sponsor agent ID
+func (s MutableGetSponsorAllowanceParams) Sponsor() wasmtypes.ScMutableAgentID {
+	return wasmtypes.NewScMutableAgentID(s.Proxy.Root(ParamSponsor))
+}
+
 type ImmutableNativeTokenParams struct {
 	Proxy wasmtypes.Proxy
 }
@@ -344,6 +344,28 @@ func (s MutableGetNativeTokenIDRegistryResults) Mapping() MapTokenIDToMutableBoo
 	return MapTokenIDToMutableBool(s)
 }
 
+type ImmutableGetSponsorAllowanceResults struct {
+	Proxy wasmtypes.Proxy
+}
+
+// amount of base tokens
+func (s ImmutableGetSponsorAllowanceResults) SponsorAllowance() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.Proxy.Root(ResultSponsorAllowance))
+}
+
+type MutableGetSponsorAllowanceResults struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewMutableGetSponsorAllowanceResults() MutableGetSponsorAllowanceResults {
+	return MutableGetSponsorAllowanceResults{Proxy: wasmlib.NewResultsProxy()}
+}
+
+// amount of base tokens
+func (s MutableGetSponsorAllowanceResults) SponsorAllowance() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.Proxy.Root(ResultSponsorAllowance))
+}
+
 type ImmutableNativeTokenResults struct {
 	Proxy wasmtypes.Proxy
 }
@@ -14,8 +14,16 @@ const (
 )
 
 const (
-	ParamBlockIndex = "n"
-	ParamRequestID  = "u"
+	ParamBlockIndex       = "n"
+	ParamEntryPoint       = "ep"
+	ParamGasBudget        = "g"
+	ParamHname            = "h"
+	ParamRequestID        = "u"
+	ParamScheduleBlock    = "sb"
+	ParamScheduleID       = "si"
+	ParamScheduleInterval = "sv"
+	ParamScheduleParams   = "sp"
+	ParamScheduleTime     = "st"
 )
 
 const (
@@ -27,24 +35,32 @@ const (
 	ResultRequestProcessed = "p"
 	ResultRequestReceipt   = "d"
 	ResultRequestReceipts  = "d"
+	ResultScheduledRequest = "sr"
+	ResultScheduleID       = "si"
 )
 
 const (
+	FuncCancelScheduledRequest     = "cancelScheduledRequest"
+	FuncScheduleRequest            = "scheduleRequest"
 	ViewGetBlockInfo               = "getBlockInfo"
 	ViewGetEventsForBlock          = "getEventsForBlock"
 	ViewGetEventsForRequest        = "getEventsForRequest"
 	ViewGetRequestIDsForBlock      = "getRequestIDsForBlock"
 	ViewGetRequestReceipt          = "getRequestReceipt"
 	ViewGetRequestReceiptsForBlock = "getRequestReceiptsForBlock"
+	ViewGetScheduledRequest        = "getScheduledRequest"
 	ViewIsRequestProcessed         = "isRequestProcessed"
 )
 
 const (
+	HFuncCancelScheduledRequest     = wasmtypes.ScHname(0x8f84f7e4)
+	HFuncScheduleRequest            = wasmtypes.ScHname(0xef46a811)
 	HViewGetBlockInfo               = wasmtypes.ScHname(0xbe89f9b3)
 	HViewGetEventsForBlock          = wasmtypes.ScHname(0x36232798)
 	HViewGetEventsForRequest        = wasmtypes.ScHname(0x4f8d68e4)
 	HViewGetRequestIDsForBlock      = wasmtypes.ScHname(0x5a20327a)
 	HViewGetRequestReceipt          = wasmtypes.ScHname(0xb7f9534f)
 	HViewGetRequestReceiptsForBlock = wasmtypes.ScHname(0x77e3beef)
+	HViewGetScheduledRequest        = wasmtypes.ScHname(0x809d3ef7)
 	HViewIsRequestProcessed         = wasmtypes.ScHname(0xd57d50a9)
 )
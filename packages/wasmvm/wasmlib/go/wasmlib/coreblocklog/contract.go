@@ -7,6 +7,17 @@ package coreblocklog
 
 import "github.com/nnikolash/wasp-types-exported/packages/wasmvm/wasmlib/go/wasmlib"
 
+type CancelScheduledRequestCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableCancelScheduledRequestParams
+}
+
+type ScheduleRequestCall struct {
+	Func    *wasmlib.ScFunc
+	Params  MutableScheduleRequestParams
+	Results ImmutableScheduleRequestResults
+}
+
 type GetBlockInfoCall struct {
 	Func    *wasmlib.ScView
 	Params  MutableGetBlockInfoParams
@@ -43,6 +54,12 @@ type GetRequestReceiptsForBlockCall struct {
 	Results ImmutableGetRequestReceiptsForBlockResults
 }
 
+type GetScheduledRequestCall struct {
+	Func    *wasmlib.ScView
+	Params  MutableGetScheduledRequestParams
+	Results ImmutableGetScheduledRequestResults
+}
+
 type IsRequestProcessedCall struct {
 	Func    *wasmlib.ScView
 	Params  MutableIsRequestProcessedParams
@@ -53,6 +70,22 @@ type Funcs struct{}
 
 var ScFuncs Funcs
 
+// Cancels a scheduled call and refunds its remaining prepaid gas to the owner.
+func (sc Funcs) CancelScheduledRequest(ctx wasmlib.ScFuncClientContext) *CancelScheduledRequestCall {
+	f := &CancelScheduledRequestCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncCancelScheduledRequest)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
+// Schedules a call to run at a future time or block, optionally recurring.
+// The base tokens in the allowance are kept as prepaid gas for the runs of the call.
+func (sc Funcs) ScheduleRequest(ctx wasmlib.ScFuncClientContext) *ScheduleRequestCall {
+	f := &ScheduleRequestCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncScheduleRequest)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	wasmlib.NewCallResultsProxy(&f.Func.ScView, &f.Results.Proxy)
+	return f
+}
+
 // Returns information about the given block.
 func (sc Funcs) GetBlockInfo(ctx wasmlib.ScViewClientContext) *GetBlockInfoCall {
 	f := &GetBlockInfoCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetBlockInfo)}
@@ -101,6 +134,14 @@ func (sc Funcs) GetRequestReceiptsForBlock(ctx wasmlib.ScViewClientContext) *Get
 	return f
 }
 
+// Returns the call scheduled with the given ID.
+func (sc Funcs) GetScheduledRequest(ctx wasmlib.ScViewClientContext) *GetScheduledRequestCall {
+	f := &GetScheduledRequestCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetScheduledRequest)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(f.Func)
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.Proxy)
+	return f
+}
+
 // Returns whether the request with ID u has been processed.
 func (sc Funcs) IsRequestProcessed(ctx wasmlib.ScViewClientContext) *IsRequestProcessedCall {
 	f := &IsRequestProcessedCall{Func: wasmlib.NewScView(ctx, HScName, HViewIsRequestProcessed)}
@@ -111,15 +152,20 @@ func (sc Funcs) IsRequestProcessed(ctx wasmlib.ScViewClientContext) *IsRequestPr
 
 var exportMap = wasmlib.ScExportMap{
 	Names: []string{
+		FuncCancelScheduledRequest,
+		FuncScheduleRequest,
 		ViewGetBlockInfo,
 		ViewGetEventsForBlock,
 		ViewGetEventsForRequest,
 		ViewGetRequestIDsForBlock,
 		ViewGetRequestReceipt,
 		ViewGetRequestReceiptsForBlock,
+		ViewGetScheduledRequest,
 		ViewIsRequestProcessed,
 	},
 	Funcs: []wasmlib.ScFuncContextFunction{
+		wasmlib.FuncError,
+		wasmlib.FuncError,
 	},
 	Views: []wasmlib.ScViewContextFunction{
 		wasmlib.ViewError,
@@ -129,6 +175,7 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.ViewError,
 		wasmlib.ViewError,
 		wasmlib.ViewError,
+		wasmlib.ViewError,
 	},
 }
 
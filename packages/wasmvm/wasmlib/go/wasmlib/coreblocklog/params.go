@@ -10,6 +10,110 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/wasmvm/wasmlib/go/wasmlib/wasmtypes"
 )
 
+type ImmutableCancelScheduledRequestParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableCancelScheduledRequestParams() ImmutableCancelScheduledRequestParams {
+	return ImmutableCancelScheduledRequestParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// ID of the scheduled call
+func (s ImmutableCancelScheduledRequestParams) ScheduleID() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.Proxy.Root(ParamScheduleID))
+}
+
+type MutableCancelScheduledRequestParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// ID of the scheduled call
+func (s MutableCancelScheduledRequestParams) ScheduleID() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.Proxy.Root(ParamScheduleID))
+}
+
+type ImmutableScheduleRequestParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableScheduleRequestParams() ImmutableScheduleRequestParams {
+	return ImmutableScheduleRequestParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// target entry point
+func (s ImmutableScheduleRequestParams) EntryPoint() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.Proxy.Root(ParamEntryPoint))
+}
+
+// gas budget of each run
+func (s ImmutableScheduleRequestParams) GasBudget() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.Proxy.Root(ParamGasBudget))
+}
+
+// target contract
+func (s ImmutableScheduleRequestParams) Hname() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.Proxy.Root(ParamHname))
+}
+
+// index of the block the call is due
+func (s ImmutableScheduleRequestParams) ScheduleBlock() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.Proxy.Root(ParamScheduleBlock))
+}
+
+// interval of a recurring call, nanoseconds or number of blocks
+func (s ImmutableScheduleRequestParams) ScheduleInterval() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.Proxy.Root(ParamScheduleInterval))
+}
+
+// serialized params of the call
+func (s ImmutableScheduleRequestParams) ScheduleParams() wasmtypes.ScImmutableBytes {
+	return wasmtypes.NewScImmutableBytes(s.Proxy.Root(ParamScheduleParams))
+}
+
+// time the call is due, in nanoseconds since epoch
+func (s ImmutableScheduleRequestParams) ScheduleTime() wasmtypes.ScImmutableInt64 {
+	return wasmtypes.NewScImmutableInt64(s.Proxy.Root(ParamScheduleTime))
+}
+
+type MutableScheduleRequestParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// target entry point
+func (s MutableScheduleRequestParams) EntryPoint() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.Proxy.Root(ParamEntryPoint))
+}
+
+// gas budget of each run
+func (s MutableScheduleRequestParams) GasBudget() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.Proxy.Root(ParamGasBudget))
+}
+
+// target contract
+func (s MutableScheduleRequestParams) Hname() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.Proxy.Root(ParamHname))
+}
+
+// index of the block the call is due
+func (s MutableScheduleRequestParams) ScheduleBlock() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.Proxy.Root(ParamScheduleBlock))
+}
+
+// interval of a recurring call, nanoseconds or number of blocks
+func (s MutableScheduleRequestParams) ScheduleInterval() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.Proxy.Root(ParamScheduleInterval))
+}
+
+// serialized params of the call
+func (s MutableScheduleRequestParams) ScheduleParams() wasmtypes.ScMutableBytes {
+	return wasmtypes.NewScMutableBytes(s.Proxy.Root(ParamScheduleParams))
+}
+
+// time the call is due, in nanoseconds since epoch
+func (s MutableScheduleRequestParams) ScheduleTime() wasmtypes.ScMutableInt64 {
+	return wasmtypes.NewScMutableInt64(s.Proxy.Root(ParamScheduleTime))
+}
+
 type ImmutableGetBlockInfoParams struct {
 	Proxy wasmtypes.Proxy
 }
@@ -142,6 +246,28 @@ func (s MutableGetRequestReceiptsForBlockParams) BlockIndex() wasmtypes.ScMutabl
 	return wasmtypes.NewScMutableUint32(s.Proxy.Root(ParamBlockIndex))
 }
 
+type ImmutableGetScheduledRequestParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableGetScheduledRequestParams() ImmutableGetScheduledRequestParams {
+	return ImmutableGetScheduledRequestParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// ID of the scheduled call
+func (s ImmutableGetScheduledRequestParams) ScheduleID() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.Proxy.Root(ParamScheduleID))
+}
+
+type MutableGetScheduledRequestParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// ID of the scheduled call
+func (s MutableGetScheduledRequestParams) ScheduleID() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.Proxy.Root(ParamScheduleID))
+}
+
 type ImmutableIsRequestProcessedParams struct {
 	Proxy wasmtypes.Proxy
 }
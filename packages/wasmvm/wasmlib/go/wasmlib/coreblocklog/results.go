@@ -10,6 +10,28 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/wasmvm/wasmlib/go/wasmlib/wasmtypes"
 )
 
+type ImmutableScheduleRequestResults struct {
+	Proxy wasmtypes.Proxy
+}
+
+// ID of the scheduled call
+func (s ImmutableScheduleRequestResults) ScheduleID() wasmtypes.ScImmutableUint32 {
+	return wasmtypes.NewScImmutableUint32(s.Proxy.Root(ResultScheduleID))
+}
+
+type MutableScheduleRequestResults struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewMutableScheduleRequestResults() MutableScheduleRequestResults {
+	return MutableScheduleRequestResults{Proxy: wasmlib.NewResultsProxy()}
+}
+
+// ID of the scheduled call
+func (s MutableScheduleRequestResults) ScheduleID() wasmtypes.ScMutableUint32 {
+	return wasmtypes.NewScMutableUint32(s.Proxy.Root(ResultScheduleID))
+}
+
 type ImmutableGetBlockInfoResults struct {
 	Proxy wasmtypes.Proxy
 }
@@ -256,6 +278,28 @@ func (s MutableGetRequestReceiptsForBlockResults) RequestReceipts() ArrayOfMutab
 	return ArrayOfMutableBytes{Proxy: s.Proxy.Root(ResultRequestReceipts)}
 }
 
+type ImmutableGetScheduledRequestResults struct {
+	Proxy wasmtypes.Proxy
+}
+
+// serialized scheduled call, empty if not found
+func (s ImmutableGetScheduledRequestResults) ScheduledRequest() wasmtypes.ScImmutableBytes {
+	return wasmtypes.NewScImmutableBytes(s.Proxy.Root(ResultScheduledRequest))
+}
+
+type MutableGetScheduledRequestResults struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewMutableGetScheduledRequestResults() MutableGetScheduledRequestResults {
+	return MutableGetScheduledRequestResults{Proxy: wasmlib.NewResultsProxy()}
+}
+
+// serialized scheduled call, empty if not found
+func (s MutableGetScheduledRequestResults) ScheduledRequest() wasmtypes.ScMutableBytes {
+	return wasmtypes.NewScMutableBytes(s.Proxy.Root(ResultScheduledRequest))
+}
+
 type ImmutableIsRequestProcessedResults struct {
 	Proxy wasmtypes.Proxy
 }
@@ -23,11 +23,14 @@ const (
 	ParamFeePolicy     = "g"
 	ParamGasLimits     = "l"
 	ParamGasRatio      = "e"
+	ParamHname         = "h"
 	ParamMetadata      = "md"
+	ParamOwnerFee      = "of"
 	ParamPayoutAgentID = "s"
 	ParamPubKey        = "ip"
 	ParamPublicURL     = "x"
 	ParamSetMinSD      = "ms"
+	ParamValidatorFee  = "vf"
 )
 
 const (
@@ -41,9 +44,11 @@ const (
 	ResultGasRatio             = "e"
 	ResultGetMinSD             = "ms"
 	ResultMetadata             = "md"
+	ResultOwnerFee             = "of"
 	ResultPayoutAgentID        = "s"
 	ResultPublicURL            = "x"
 	ResultStatus               = "m"
+	ResultValidatorFee         = "vf"
 )
 
 const (
@@ -55,6 +60,7 @@ const (
 	FuncRemoveAllowedStateControllerAddress = "removeAllowedStateControllerAddress"
 	FuncRevokeAccessNode                    = "revokeAccessNode"
 	FuncRotateStateController               = "rotateStateController"
+	FuncSetContractFees                     = "setContractFees"
 	FuncSetEVMGasRatio                      = "setEVMGasRatio"
 	FuncSetFeePolicy                        = "setFeePolicy"
 	FuncSetGasLimits                        = "setGasLimits"
@@ -67,6 +73,7 @@ const (
 	ViewGetChainInfo                        = "getChainInfo"
 	ViewGetChainNodes                       = "getChainNodes"
 	ViewGetChainOwner                       = "getChainOwner"
+	ViewGetContractFees                     = "getContractFees"
 	ViewGetEVMGasRatio                      = "getEVMGasRatio"
 	ViewGetFeePolicy                        = "getFeePolicy"
 	ViewGetGasLimits                        = "getGasLimits"
@@ -85,6 +92,7 @@ const (
 	HFuncRemoveAllowedStateControllerAddress = wasmtypes.ScHname(0x31f69447)
 	HFuncRevokeAccessNode                    = wasmtypes.ScHname(0x5459512d)
 	HFuncRotateStateController               = wasmtypes.ScHname(0x244d1038)
+	HFuncSetContractFees                     = wasmtypes.ScHname(0x7764488a)
 	HFuncSetEVMGasRatio                      = wasmtypes.ScHname(0xaae22338)
 	HFuncSetFeePolicy                        = wasmtypes.ScHname(0x5b791c9f)
 	HFuncSetGasLimits                        = wasmtypes.ScHname(0xd72fb355)
@@ -97,6 +105,7 @@ const (
 	HViewGetChainInfo                        = wasmtypes.ScHname(0x434477e2)
 	HViewGetChainNodes                       = wasmtypes.ScHname(0xe1832289)
 	HViewGetChainOwner                       = wasmtypes.ScHname(0x9b2ef0ac)
+	HViewGetContractFees                     = wasmtypes.ScHname(0x001c3237)
 	HViewGetEVMGasRatio                      = wasmtypes.ScHname(0xb81c8c34)
 	HViewGetFeePolicy                        = wasmtypes.ScHname(0xf8c89790)
 	HViewGetGasLimits                        = wasmtypes.ScHname(0x3a493455)
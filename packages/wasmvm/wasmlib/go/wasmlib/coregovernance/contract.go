@@ -46,6 +46,11 @@ type RotateStateControllerCall struct {
 	Params MutableRotateStateControllerParams
 }
 
+type SetContractFeesCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableSetContractFeesParams
+}
+
 type SetEVMGasRatioCall struct {
 	Func   *wasmlib.ScFunc
 	Params MutableSetEVMGasRatioParams
@@ -104,6 +109,12 @@ type GetChainOwnerCall struct {
 	Results ImmutableGetChainOwnerResults
 }
 
+type GetContractFeesCall struct {
+	Func    *wasmlib.ScView
+	Params  MutableGetContractFeesParams
+	Results ImmutableGetContractFeesResults
+}
+
 type GetEVMGasRatioCall struct {
 	Func    *wasmlib.ScView
 	Results ImmutableGetEVMGasRatioResults
@@ -202,6 +213,14 @@ func (sc Funcs) RotateStateController(ctx wasmlib.ScFuncClientContext) *RotateSt
 	return f
 }
 
+// Overrides the fee policy for the requests to a contract.
+// Zero fees mean the chain defaults are in effect, when both are zero the override is removed.
+func (sc Funcs) SetContractFees(ctx wasmlib.ScFuncClientContext) *SetContractFeesCall {
+	f := &SetContractFeesCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncSetContractFees)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
 // Sets the EVM gas ratio for the chain.
 func (sc Funcs) SetEVMGasRatio(ctx wasmlib.ScFuncClientContext) *SetEVMGasRatioCall {
 	f := &SetEVMGasRatioCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncSetEVMGasRatio)}
@@ -281,6 +300,14 @@ func (sc Funcs) GetChainOwner(ctx wasmlib.ScViewClientContext) *GetChainOwnerCal
 	return f
 }
 
+// Returns the fees of a contract, zero fees mean the chain defaults are in effect.
+func (sc Funcs) GetContractFees(ctx wasmlib.ScViewClientContext) *GetContractFeesCall {
+	f := &GetContractFeesCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetContractFees)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(f.Func)
+	wasmlib.NewCallResultsProxy(f.Func, &f.Results.Proxy)
+	return f
+}
+
 // Returns the EVM gas ratio.
 func (sc Funcs) GetEVMGasRatio(ctx wasmlib.ScViewClientContext) *GetEVMGasRatioCall {
 	f := &GetEVMGasRatioCall{Func: wasmlib.NewScView(ctx, HScName, HViewGetEVMGasRatio)}
@@ -338,6 +365,7 @@ var exportMap = wasmlib.ScExportMap{
 		FuncRemoveAllowedStateControllerAddress,
 		FuncRevokeAccessNode,
 		FuncRotateStateController,
+		FuncSetContractFees,
 		FuncSetEVMGasRatio,
 		FuncSetFeePolicy,
 		FuncSetGasLimits,
@@ -350,6 +378,7 @@ var exportMap = wasmlib.ScExportMap{
 		ViewGetChainInfo,
 		ViewGetChainNodes,
 		ViewGetChainOwner,
+		ViewGetContractFees,
 		ViewGetEVMGasRatio,
 		ViewGetFeePolicy,
 		ViewGetGasLimits,
@@ -375,6 +404,7 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.FuncError,
 		wasmlib.FuncError,
 		wasmlib.FuncError,
+		wasmlib.FuncError,
 	},
 	Views: []wasmlib.ScViewContextFunction{
 		wasmlib.ViewError,
@@ -388,6 +418,7 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.ViewError,
 		wasmlib.ViewError,
 		wasmlib.ViewError,
+		wasmlib.ViewError,
 	},
 }
 
@@ -230,6 +230,48 @@ func (s MutableRotateStateControllerParams) Address() wasmtypes.ScMutableAddress
 	return wasmtypes.NewScMutableAddress(s.Proxy.Root(ParamAddress))
 }
 
+type ImmutableSetContractFeesParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableSetContractFeesParams() ImmutableSetContractFeesParams {
+	return ImmutableSetContractFeesParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// contract hname
+func (s ImmutableSetContractFeesParams) Hname() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.Proxy.Root(ParamHname))
+}
+
+// chain owner part of the fee per 1000 gas
+func (s ImmutableSetContractFeesParams) OwnerFee() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.Proxy.Root(ParamOwnerFee))
+}
+
+// validator part of the fee per 1000 gas
+func (s ImmutableSetContractFeesParams) ValidatorFee() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.Proxy.Root(ParamValidatorFee))
+}
+
+type MutableSetContractFeesParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// contract hname
+func (s MutableSetContractFeesParams) Hname() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.Proxy.Root(ParamHname))
+}
+
+// chain owner part of the fee per 1000 gas
+func (s MutableSetContractFeesParams) OwnerFee() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.Proxy.Root(ParamOwnerFee))
+}
+
+// validator part of the fee per 1000 gas
+func (s MutableSetContractFeesParams) ValidatorFee() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.Proxy.Root(ParamValidatorFee))
+}
+
 type ImmutableSetEVMGasRatioParams struct {
 	Proxy wasmtypes.Proxy
 }
@@ -369,3 +411,25 @@ type MutableSetPayoutAgentIDParams struct {
 func (s MutableSetPayoutAgentIDParams) PayoutAgentID() wasmtypes.ScMutableAgentID {
 	return wasmtypes.NewScMutableAgentID(s.Proxy.Root(ParamPayoutAgentID))
 }
+
+type ImmutableGetContractFeesParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableGetContractFeesParams() ImmutableGetContractFeesParams {
+	return ImmutableGetContractFeesParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// contract hname
+func (s ImmutableGetContractFeesParams) Hname() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.Proxy.Root(ParamHname))
+}
+
+type MutableGetContractFeesParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// contract hname
+func (s MutableGetContractFeesParams) Hname() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.Proxy.Root(ParamHname))
+}
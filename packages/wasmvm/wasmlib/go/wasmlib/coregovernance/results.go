@@ -228,6 +228,38 @@ func (s MutableGetChainOwnerResults) ChainOwnerID() wasmtypes.ScMutableAgentID {
 	return wasmtypes.NewScMutableAgentID(s.Proxy.Root(ResultChainOwnerID))
 }
 
+type ImmutableGetContractFeesResults struct {
+	Proxy wasmtypes.Proxy
+}
+
+// chain owner part of the fee per 1000 gas
+func (s ImmutableGetContractFeesResults) OwnerFee() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.Proxy.Root(ResultOwnerFee))
+}
+
+// validator part of the fee per 1000 gas
+func (s ImmutableGetContractFeesResults) ValidatorFee() wasmtypes.ScImmutableUint64 {
+	return wasmtypes.NewScImmutableUint64(s.Proxy.Root(ResultValidatorFee))
+}
+
+type MutableGetContractFeesResults struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewMutableGetContractFeesResults() MutableGetContractFeesResults {
+	return MutableGetContractFeesResults{Proxy: wasmlib.NewResultsProxy()}
+}
+
+// chain owner part of the fee per 1000 gas
+func (s MutableGetContractFeesResults) OwnerFee() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.Proxy.Root(ResultOwnerFee))
+}
+
+// validator part of the fee per 1000 gas
+func (s MutableGetContractFeesResults) ValidatorFee() wasmtypes.ScMutableUint64 {
+	return wasmtypes.NewScMutableUint64(s.Proxy.Root(ResultValidatorFee))
+}
+
 type ImmutableGetEVMGasRatioResults struct {
 	Proxy wasmtypes.Proxy
 }
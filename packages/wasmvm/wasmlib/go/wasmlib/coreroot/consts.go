@@ -26,13 +26,16 @@ const (
 	ResultContractFound    = "cf"
 	ResultContractRecData  = "dt"
 	ResultContractRegistry = "r"
+	ResultRetiredContracts = "t"
 )
 
 const (
 	FuncDeployContract           = "deployContract"
 	FuncGrantDeployPermission    = "grantDeployPermission"
 	FuncRequireDeployPermissions = "requireDeployPermissions"
+	FuncRetireContract           = "retireContract"
 	FuncRevokeDeployPermission   = "revokeDeployPermission"
+	FuncUpgradeContract          = "upgradeContract"
 	ViewFindContract             = "findContract"
 	ViewGetContractRecords       = "getContractRecords"
 )
@@ -41,7 +44,9 @@ const (
 	HFuncDeployContract           = wasmtypes.ScHname(0x28232c27)
 	HFuncGrantDeployPermission    = wasmtypes.ScHname(0xf440263a)
 	HFuncRequireDeployPermissions = wasmtypes.ScHname(0xefff8d83)
+	HFuncRetireContract           = wasmtypes.ScHname(0x59e8c035)
 	HFuncRevokeDeployPermission   = wasmtypes.ScHname(0x850744f1)
+	HFuncUpgradeContract          = wasmtypes.ScHname(0x00d30d5c)
 	HViewFindContract             = wasmtypes.ScHname(0xc145ca00)
 	HViewGetContractRecords       = wasmtypes.ScHname(0x078b3ef3)
 )
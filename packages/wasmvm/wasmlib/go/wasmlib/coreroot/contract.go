@@ -22,11 +22,21 @@ type RequireDeployPermissionsCall struct {
 	Params MutableRequireDeployPermissionsParams
 }
 
+type RetireContractCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableRetireContractParams
+}
+
 type RevokeDeployPermissionCall struct {
 	Func   *wasmlib.ScFunc
 	Params MutableRevokeDeployPermissionParams
 }
 
+type UpgradeContractCall struct {
+	Func   *wasmlib.ScFunc
+	Params MutableUpgradeContractParams
+}
+
 type FindContractCall struct {
 	Func    *wasmlib.ScView
 	Params  MutableFindContractParams
@@ -63,6 +73,13 @@ func (sc Funcs) RequireDeployPermissions(ctx wasmlib.ScFuncClientContext) *Requi
 	return f
 }
 
+// Retires a deployed contract, further calls to the contract are rejected.
+func (sc Funcs) RetireContract(ctx wasmlib.ScFuncClientContext) *RetireContractCall {
+	f := &RetireContractCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncRetireContract)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
 // Revokes deploy permission for an agent.
 func (sc Funcs) RevokeDeployPermission(ctx wasmlib.ScFuncClientContext) *RevokeDeployPermissionCall {
 	f := &RevokeDeployPermissionCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncRevokeDeployPermission)}
@@ -70,6 +87,13 @@ func (sc Funcs) RevokeDeployPermission(ctx wasmlib.ScFuncClientContext) *RevokeD
 	return f
 }
 
+// Replaces the program of a deployed contract, keeping its hname and state.
+func (sc Funcs) UpgradeContract(ctx wasmlib.ScFuncClientContext) *UpgradeContractCall {
+	f := &UpgradeContractCall{Func: wasmlib.NewScFunc(ctx, HScName, HFuncUpgradeContract)}
+	f.Params.Proxy = wasmlib.NewCallParamsProxy(&f.Func.ScView)
+	return f
+}
+
 // Returns the record for a given smart contract
 func (sc Funcs) FindContract(ctx wasmlib.ScViewClientContext) *FindContractCall {
 	f := &FindContractCall{Func: wasmlib.NewScView(ctx, HScName, HViewFindContract)}
@@ -90,7 +114,9 @@ var exportMap = wasmlib.ScExportMap{
 		FuncDeployContract,
 		FuncGrantDeployPermission,
 		FuncRequireDeployPermissions,
+		FuncRetireContract,
 		FuncRevokeDeployPermission,
+		FuncUpgradeContract,
 		ViewFindContract,
 		ViewGetContractRecords,
 	},
@@ -99,6 +125,8 @@ var exportMap = wasmlib.ScExportMap{
 		wasmlib.FuncError,
 		wasmlib.FuncError,
 		wasmlib.FuncError,
+		wasmlib.FuncError,
+		wasmlib.FuncError,
 	},
 	Views: []wasmlib.ScViewContextFunction{
 		wasmlib.ViewError,
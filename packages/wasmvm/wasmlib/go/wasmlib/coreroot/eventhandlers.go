@@ -13,14 +13,18 @@ import (
 var coreRootHandlers = map[string]func(*CoreRootEventHandlers, *wasmtypes.WasmDecoder){
 	"coreroot.deploy": func(evt *CoreRootEventHandlers, dec *wasmtypes.WasmDecoder) { evt.onCoreRootDeployThunk(dec) },
 	"coreroot.grant": func(evt *CoreRootEventHandlers, dec *wasmtypes.WasmDecoder) { evt.onCoreRootGrantThunk(dec) },
+	"coreroot.retire": func(evt *CoreRootEventHandlers, dec *wasmtypes.WasmDecoder) { evt.onCoreRootRetireThunk(dec) },
 	"coreroot.revoke": func(evt *CoreRootEventHandlers, dec *wasmtypes.WasmDecoder) { evt.onCoreRootRevokeThunk(dec) },
+	"coreroot.upgrade": func(evt *CoreRootEventHandlers, dec *wasmtypes.WasmDecoder) { evt.onCoreRootUpgradeThunk(dec) },
 }
 
 type CoreRootEventHandlers struct {
 	myID uint32
 	deploy func(e *EventDeploy)
 	grant func(e *EventGrant)
+	retire func(e *EventRetire)
 	revoke func(e *EventRevoke)
+	upgrade func(e *EventUpgrade)
 }
 
 var _ wasmlib.IEventHandlers = new(CoreRootEventHandlers)
@@ -48,10 +52,18 @@ func (h *CoreRootEventHandlers) OnCoreRootGrant(handler func(e *EventGrant)) {
 	h.grant = handler
 }
 
+func (h *CoreRootEventHandlers) OnCoreRootRetire(handler func(e *EventRetire)) {
+	h.retire = handler
+}
+
 func (h *CoreRootEventHandlers) OnCoreRootRevoke(handler func(e *EventRevoke)) {
 	h.revoke = handler
 }
 
+func (h *CoreRootEventHandlers) OnCoreRootUpgrade(handler func(e *EventUpgrade)) {
+	h.upgrade = handler
+}
+
 type EventDeploy struct {
 	Timestamp uint64
 	Name string
@@ -86,6 +98,22 @@ func (h *CoreRootEventHandlers) onCoreRootGrantThunk(dec *wasmtypes.WasmDecoder)
 	h.grant(e)
 }
 
+type EventRetire struct {
+	Timestamp uint64
+	Hname wasmtypes.ScHname
+}
+
+func (h *CoreRootEventHandlers) onCoreRootRetireThunk(dec *wasmtypes.WasmDecoder) {
+	if h.retire == nil {
+		return
+	}
+	e := &EventRetire{}
+	e.Timestamp = wasmtypes.Uint64Decode(dec)
+	e.Hname = wasmtypes.HnameDecode(dec)
+	dec.Close()
+	h.retire(e)
+}
+
 type EventRevoke struct {
 	Timestamp uint64
 	Deployer wasmtypes.ScAgentID
@@ -101,3 +129,23 @@ func (h *CoreRootEventHandlers) onCoreRootRevokeThunk(dec *wasmtypes.WasmDecoder
 	dec.Close()
 	h.revoke(e)
 }
+
+type EventUpgrade struct {
+	Timestamp uint64
+	Hname wasmtypes.ScHname
+	OldProgHash wasmtypes.ScHash
+	ProgHash wasmtypes.ScHash
+}
+
+func (h *CoreRootEventHandlers) onCoreRootUpgradeThunk(dec *wasmtypes.WasmDecoder) {
+	if h.upgrade == nil {
+		return
+	}
+	e := &EventUpgrade{}
+	e.Timestamp = wasmtypes.Uint64Decode(dec)
+	e.Hname = wasmtypes.HnameDecode(dec)
+	e.OldProgHash = wasmtypes.HashDecode(dec)
+	e.ProgHash = wasmtypes.HashDecode(dec)
+	dec.Close()
+	h.upgrade(e)
+}
@@ -118,6 +118,28 @@ func (s MutableRequireDeployPermissionsParams) DeployPermissionsEnabled() wasmty
 	return wasmtypes.NewScMutableBool(s.Proxy.Root(ParamDeployPermissionsEnabled))
 }
 
+type ImmutableRetireContractParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableRetireContractParams() ImmutableRetireContractParams {
+	return ImmutableRetireContractParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// The smart contract’s Hname
+func (s ImmutableRetireContractParams) Hname() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.Proxy.Root(ParamHname))
+}
+
+type MutableRetireContractParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// The smart contract’s Hname
+func (s MutableRetireContractParams) Hname() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.Proxy.Root(ParamHname))
+}
+
 type ImmutableRevokeDeployPermissionParams struct {
 	Proxy wasmtypes.Proxy
 }
@@ -140,6 +162,38 @@ func (s MutableRevokeDeployPermissionParams) Deployer() wasmtypes.ScMutableAgent
 	return wasmtypes.NewScMutableAgentID(s.Proxy.Root(ParamDeployer))
 }
 
+type ImmutableUpgradeContractParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+func NewImmutableUpgradeContractParams() ImmutableUpgradeContractParams {
+	return ImmutableUpgradeContractParams{Proxy: wasmlib.NewParamsProxy()}
+}
+
+// The smart contract’s Hname
+func (s ImmutableUpgradeContractParams) Hname() wasmtypes.ScImmutableHname {
+	return wasmtypes.NewScImmutableHname(s.Proxy.Root(ParamHname))
+}
+
+// hash of blob that has been previously stored in blob contract
+func (s ImmutableUpgradeContractParams) ProgramHash() wasmtypes.ScImmutableHash {
+	return wasmtypes.NewScImmutableHash(s.Proxy.Root(ParamProgramHash))
+}
+
+type MutableUpgradeContractParams struct {
+	Proxy wasmtypes.Proxy
+}
+
+// The smart contract’s Hname
+func (s MutableUpgradeContractParams) Hname() wasmtypes.ScMutableHname {
+	return wasmtypes.NewScMutableHname(s.Proxy.Root(ParamHname))
+}
+
+// hash of blob that has been previously stored in blob contract
+func (s MutableUpgradeContractParams) ProgramHash() wasmtypes.ScMutableHash {
+	return wasmtypes.NewScMutableHash(s.Proxy.Root(ParamProgramHash))
+}
+
 type ImmutableFindContractParams struct {
 	Proxy wasmtypes.Proxy
 }
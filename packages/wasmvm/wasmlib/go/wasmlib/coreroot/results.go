@@ -50,6 +50,14 @@ func (m MapHnameToImmutableBytes) GetBytes(key wasmtypes.ScHname) wasmtypes.ScIm
 	return wasmtypes.NewScImmutableBytes(m.Proxy.Key(wasmtypes.HnameToBytes(key)))
 }
 
+type MapHnameToImmutableBool struct {
+	Proxy wasmtypes.Proxy
+}
+
+func (m MapHnameToImmutableBool) GetBool(key wasmtypes.ScHname) wasmtypes.ScImmutableBool {
+	return wasmtypes.NewScImmutableBool(m.Proxy.Key(wasmtypes.HnameToBytes(key)))
+}
+
 type ImmutableGetContractRecordsResults struct {
 	Proxy wasmtypes.Proxy
 }
@@ -59,6 +67,11 @@ func (s ImmutableGetContractRecordsResults) ContractRegistry() MapHnameToImmutab
 	return MapHnameToImmutableBytes{Proxy: s.Proxy.Root(ResultContractRegistry)}
 }
 
+// retired contracts by Hname
+func (s ImmutableGetContractRecordsResults) RetiredContracts() MapHnameToImmutableBool {
+	return MapHnameToImmutableBool{Proxy: s.Proxy.Root(ResultRetiredContracts)}
+}
+
 type MapHnameToMutableBytes struct {
 	Proxy wasmtypes.Proxy
 }
@@ -71,6 +84,18 @@ func (m MapHnameToMutableBytes) GetBytes(key wasmtypes.ScHname) wasmtypes.ScMuta
 	return wasmtypes.NewScMutableBytes(m.Proxy.Key(wasmtypes.HnameToBytes(key)))
 }
 
+type MapHnameToMutableBool struct {
+	Proxy wasmtypes.Proxy
+}
+
+func (m MapHnameToMutableBool) Clear() {
+	m.Proxy.ClearMap()
+}
+
+func (m MapHnameToMutableBool) GetBool(key wasmtypes.ScHname) wasmtypes.ScMutableBool {
+	return wasmtypes.NewScMutableBool(m.Proxy.Key(wasmtypes.HnameToBytes(key)))
+}
+
 type MutableGetContractRecordsResults struct {
 	Proxy wasmtypes.Proxy
 }
@@ -83,3 +108,8 @@ func NewMutableGetContractRecordsResults() MutableGetContractRecordsResults {
 func (s MutableGetContractRecordsResults) ContractRegistry() MapHnameToMutableBytes {
 	return MapHnameToMutableBytes{Proxy: s.Proxy.Root(ResultContractRegistry)}
 }
+
+// retired contracts by Hname
+func (s MutableGetContractRecordsResults) RetiredContracts() MapHnameToMutableBool {
+	return MapHnameToMutableBool{Proxy: s.Proxy.Root(ResultRetiredContracts)}
+}
@@ -33,6 +33,11 @@ pub struct NativeTokenModifySupplyCall<'a> {
     pub params: MutableNativeTokenModifySupplyParams,
 }
 
+pub struct SetSponsorAllowanceCall<'a> {
+    pub func:   ScFunc<'a>,
+    pub params: MutableSetSponsorAllowanceParams,
+}
+
 pub struct TransferAccountToChainCall<'a> {
     pub func:   ScFunc<'a>,
     pub params: MutableTransferAccountToChainParams,
@@ -106,6 +111,12 @@ pub struct GetNativeTokenIDRegistryCall<'a> {
     pub results: ImmutableGetNativeTokenIDRegistryResults,
 }
 
+pub struct GetSponsorAllowanceCall<'a> {
+    pub func:    ScView<'a>,
+    pub params:  MutableGetSponsorAllowanceParams,
+    pub results: ImmutableGetSponsorAllowanceResults,
+}
+
 pub struct NativeTokenCall<'a> {
     pub func:    ScView<'a>,
     pub params:  MutableNativeTokenParams,
@@ -177,6 +188,17 @@ impl ScFuncs {
         f
     }
 
+    // Sets the amount of base tokens the caller pays for the gas fees of
+    // the off-ledger requests of the given sender that name the caller as sponsor.
+    pub fn set_sponsor_allowance(ctx: &impl ScFuncClientContext) -> SetSponsorAllowanceCall {
+        let mut f = SetSponsorAllowanceCall {
+            func:    ScFunc::new(ctx, HSC_NAME, HFUNC_SET_SPONSOR_ALLOWANCE),
+            params:  MutableSetSponsorAllowanceParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
     // Transfers the specified allowance from the sender SC's L2 account on
     // the target chain to the sender SC's L2 account on the origin chain.
     pub fn transfer_account_to_chain(ctx: &impl ScFuncClientContext) -> TransferAccountToChainCall {
@@ -326,6 +348,18 @@ impl ScFuncs {
         f
     }
 
+    // Returns the amount of base tokens the sponsor still pays for the gas fees of the sender.
+    pub fn get_sponsor_allowance(ctx: &impl ScViewClientContext) -> GetSponsorAllowanceCall {
+        let mut f = GetSponsorAllowanceCall {
+            func:    ScView::new(ctx, HSC_NAME, HVIEW_GET_SPONSOR_ALLOWANCE),
+            params:  MutableGetSponsorAllowanceParams { proxy: Proxy::nil() },
+            results: ImmutableGetSponsorAllowanceResults { proxy: Proxy::nil() },
+        };
+        ScView::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
     // Returns specified foundry output in serialized form.
     pub fn native_token(ctx: &impl ScViewClientContext) -> NativeTokenCall {
         let mut f = NativeTokenCall {
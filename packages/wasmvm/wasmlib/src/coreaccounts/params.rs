@@ -173,6 +173,46 @@ impl MutableNativeTokenModifySupplyParams {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableSetSponsorAllowanceParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableSetSponsorAllowanceParams {
+    pub fn new() -> ImmutableSetSponsorAllowanceParams {
+        ImmutableSetSponsorAllowanceParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // The sponsored sender
+    pub fn agent_id(&self) -> ScImmutableAgentID {
+        ScImmutableAgentID::new(self.proxy.root(PARAM_AGENT_ID))
+    }
+
+    // amount of base tokens, zero (default) revokes the sponsorship
+    pub fn sponsor_allowance(&self) -> ScImmutableUint64 {
+        ScImmutableUint64::new(self.proxy.root(PARAM_SPONSOR_ALLOWANCE))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableSetSponsorAllowanceParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableSetSponsorAllowanceParams {
+    // The sponsored sender
+    pub fn agent_id(&self) -> ScMutableAgentID {
+        ScMutableAgentID::new(self.proxy.root(PARAM_AGENT_ID))
+    }
+
+    // amount of base tokens, zero (default) revokes the sponsorship
+    pub fn sponsor_allowance(&self) -> ScMutableUint64 {
+        ScMutableUint64::new(self.proxy.root(PARAM_SPONSOR_ALLOWANCE))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableTransferAccountToChainParams {
     pub(crate) proxy: Proxy,
@@ -535,6 +575,46 @@ impl MutableGetAccountNonceParams {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableGetSponsorAllowanceParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetSponsorAllowanceParams {
+    pub fn new() -> ImmutableGetSponsorAllowanceParams {
+        ImmutableGetSponsorAllowanceParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // sponsored sender (default: caller)
+    pub fn agent_id(&self) -> ScImmutableAgentID {
+        ScImmutableAgentID::new(self.proxy.root(PARAM_AGENT_ID))
+    }
+
+    // sponsor agent ID
+    pub fn sponsor(&self) -> ScImmutableAgentID {
+        ScImmutableAgentID::new(self.proxy.root(PARAM_SPONSOR))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableGetSponsorAllowanceParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableGetSponsorAllowanceParams {
+    // sponsored sender (default: caller)
+    pub fn agent_id(&self) -> ScMutableAgentID {
+        ScMutableAgentID::new(self.proxy.root(PARAM_AGENT_ID))
+    }
+
+    // sponsor agent ID
+    pub fn sponsor(&self) -> ScMutableAgentID {
+        ScMutableAgentID::new(self.proxy.root(PARAM_SPONSOR))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableNativeTokenParams {
     pub(crate) proxy: Proxy,
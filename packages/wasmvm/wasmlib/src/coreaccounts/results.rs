@@ -455,6 +455,36 @@ impl MutableGetNativeTokenIDRegistryResults {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableGetSponsorAllowanceResults {
+    pub proxy: Proxy,
+}
+
+impl ImmutableGetSponsorAllowanceResults {
+    // amount of base tokens
+    pub fn sponsor_allowance(&self) -> ScImmutableUint64 {
+        ScImmutableUint64::new(self.proxy.root(RESULT_SPONSOR_ALLOWANCE))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableGetSponsorAllowanceResults {
+    pub proxy: Proxy,
+}
+
+impl MutableGetSponsorAllowanceResults {
+    pub fn new() -> MutableGetSponsorAllowanceResults {
+        MutableGetSponsorAllowanceResults {
+            proxy: results_proxy(),
+        }
+    }
+
+    // amount of base tokens
+    pub fn sponsor_allowance(&self) -> ScMutableUint64 {
+        ScMutableUint64::new(self.proxy.root(RESULT_SPONSOR_ALLOWANCE))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableNativeTokenResults {
     pub proxy: Proxy,
@@ -11,8 +11,16 @@ pub const SC_NAME        : &str = "blocklog";
 pub const SC_DESCRIPTION : &str = "Block log contract";
 pub const HSC_NAME       : ScHname = ScHname(0xf538ef2b);
 
-pub(crate) const PARAM_BLOCK_INDEX : &str = "n";
-pub(crate) const PARAM_REQUEST_ID  : &str = "u";
+pub(crate) const PARAM_BLOCK_INDEX       : &str = "n";
+pub(crate) const PARAM_ENTRY_POINT       : &str = "ep";
+pub(crate) const PARAM_GAS_BUDGET        : &str = "g";
+pub(crate) const PARAM_HNAME             : &str = "h";
+pub(crate) const PARAM_REQUEST_ID        : &str = "u";
+pub(crate) const PARAM_SCHEDULE_BLOCK    : &str = "sb";
+pub(crate) const PARAM_SCHEDULE_ID       : &str = "si";
+pub(crate) const PARAM_SCHEDULE_INTERVAL : &str = "sv";
+pub(crate) const PARAM_SCHEDULE_PARAMS   : &str = "sp";
+pub(crate) const PARAM_SCHEDULE_TIME     : &str = "st";
 
 pub(crate) const RESULT_BLOCK_INDEX       : &str = "n";
 pub(crate) const RESULT_BLOCK_INFO        : &str = "i";
@@ -22,19 +30,27 @@ pub(crate) const RESULT_REQUEST_INDEX     : &str = "r";
 pub(crate) const RESULT_REQUEST_PROCESSED : &str = "p";
 pub(crate) const RESULT_REQUEST_RECEIPT   : &str = "d";
 pub(crate) const RESULT_REQUEST_RECEIPTS  : &str = "d";
+pub(crate) const RESULT_SCHEDULED_REQUEST : &str = "sr";
+pub(crate) const RESULT_SCHEDULE_ID       : &str = "si";
 
+pub(crate) const FUNC_CANCEL_SCHEDULED_REQUEST       : &str = "cancelScheduledRequest";
+pub(crate) const FUNC_SCHEDULE_REQUEST               : &str = "scheduleRequest";
 pub(crate) const VIEW_GET_BLOCK_INFO                 : &str = "getBlockInfo";
 pub(crate) const VIEW_GET_EVENTS_FOR_BLOCK           : &str = "getEventsForBlock";
 pub(crate) const VIEW_GET_EVENTS_FOR_REQUEST         : &str = "getEventsForRequest";
 pub(crate) const VIEW_GET_REQUEST_I_DS_FOR_BLOCK     : &str = "getRequestIDsForBlock";
 pub(crate) const VIEW_GET_REQUEST_RECEIPT            : &str = "getRequestReceipt";
 pub(crate) const VIEW_GET_REQUEST_RECEIPTS_FOR_BLOCK : &str = "getRequestReceiptsForBlock";
+pub(crate) const VIEW_GET_SCHEDULED_REQUEST          : &str = "getScheduledRequest";
 pub(crate) const VIEW_IS_REQUEST_PROCESSED           : &str = "isRequestProcessed";
 
+pub(crate) const HFUNC_CANCEL_SCHEDULED_REQUEST       : ScHname = ScHname(0x8f84f7e4);
+pub(crate) const HFUNC_SCHEDULE_REQUEST               : ScHname = ScHname(0xef46a811);
 pub(crate) const HVIEW_GET_BLOCK_INFO                 : ScHname = ScHname(0xbe89f9b3);
 pub(crate) const HVIEW_GET_EVENTS_FOR_BLOCK           : ScHname = ScHname(0x36232798);
 pub(crate) const HVIEW_GET_EVENTS_FOR_REQUEST         : ScHname = ScHname(0x4f8d68e4);
 pub(crate) const HVIEW_GET_REQUEST_I_DS_FOR_BLOCK     : ScHname = ScHname(0x5a20327a);
 pub(crate) const HVIEW_GET_REQUEST_RECEIPT            : ScHname = ScHname(0xb7f9534f);
 pub(crate) const HVIEW_GET_REQUEST_RECEIPTS_FOR_BLOCK : ScHname = ScHname(0x77e3beef);
+pub(crate) const HVIEW_GET_SCHEDULED_REQUEST          : ScHname = ScHname(0x809d3ef7);
 pub(crate) const HVIEW_IS_REQUEST_PROCESSED           : ScHname = ScHname(0xd57d50a9);
@@ -8,6 +8,17 @@
 use crate::*;
 use crate::coreblocklog::*;
 
+pub struct CancelScheduledRequestCall<'a> {
+    pub func:   ScFunc<'a>,
+    pub params: MutableCancelScheduledRequestParams,
+}
+
+pub struct ScheduleRequestCall<'a> {
+    pub func:    ScFunc<'a>,
+    pub params:  MutableScheduleRequestParams,
+    pub results: ImmutableScheduleRequestResults,
+}
+
 pub struct GetBlockInfoCall<'a> {
     pub func:    ScView<'a>,
     pub params:  MutableGetBlockInfoParams,
@@ -44,6 +55,12 @@ pub struct GetRequestReceiptsForBlockCall<'a> {
     pub results: ImmutableGetRequestReceiptsForBlockResults,
 }
 
+pub struct GetScheduledRequestCall<'a> {
+    pub func:    ScView<'a>,
+    pub params:  MutableGetScheduledRequestParams,
+    pub results: ImmutableGetScheduledRequestResults,
+}
+
 pub struct IsRequestProcessedCall<'a> {
     pub func:    ScView<'a>,
     pub params:  MutableIsRequestProcessedParams,
@@ -54,6 +71,29 @@ pub struct ScFuncs {
 }
 
 impl ScFuncs {
+    // Cancels a scheduled call and refunds its remaining prepaid gas to the owner.
+    pub fn cancel_scheduled_request(ctx: &impl ScFuncClientContext) -> CancelScheduledRequestCall {
+        let mut f = CancelScheduledRequestCall {
+            func:    ScFunc::new(ctx, HSC_NAME, HFUNC_CANCEL_SCHEDULED_REQUEST),
+            params:  MutableCancelScheduledRequestParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
+    // Schedules a call to run at a future time or block, optionally recurring.
+    // The base tokens in the allowance are kept as prepaid gas for the runs of the call.
+    pub fn schedule_request(ctx: &impl ScFuncClientContext) -> ScheduleRequestCall {
+        let mut f = ScheduleRequestCall {
+            func:    ScFunc::new(ctx, HSC_NAME, HFUNC_SCHEDULE_REQUEST),
+            params:  MutableScheduleRequestParams { proxy: Proxy::nil() },
+            results: ImmutableScheduleRequestResults { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        ScFunc::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
     // Returns information about the given block.
     pub fn get_block_info(ctx: &impl ScViewClientContext) -> GetBlockInfoCall {
         let mut f = GetBlockInfoCall {
@@ -126,6 +166,18 @@ impl ScFuncs {
         f
     }
 
+    // Returns the call scheduled with the given ID.
+    pub fn get_scheduled_request(ctx: &impl ScViewClientContext) -> GetScheduledRequestCall {
+        let mut f = GetScheduledRequestCall {
+            func:    ScView::new(ctx, HSC_NAME, HVIEW_GET_SCHEDULED_REQUEST),
+            params:  MutableGetScheduledRequestParams { proxy: Proxy::nil() },
+            results: ImmutableGetScheduledRequestResults { proxy: Proxy::nil() },
+        };
+        ScView::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
     // Returns whether the request with ID u has been processed.
     pub fn is_request_processed(ctx: &impl ScViewClientContext) -> IsRequestProcessedCall {
         let mut f = IsRequestProcessedCall {
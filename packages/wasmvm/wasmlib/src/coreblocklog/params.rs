@@ -9,6 +9,126 @@
 use crate::*;
 use crate::coreblocklog::*;
 
+#[derive(Clone)]
+pub struct ImmutableCancelScheduledRequestParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableCancelScheduledRequestParams {
+    pub fn new() -> ImmutableCancelScheduledRequestParams {
+        ImmutableCancelScheduledRequestParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // ID of the scheduled call
+    pub fn schedule_id(&self) -> ScImmutableUint32 {
+        ScImmutableUint32::new(self.proxy.root(PARAM_SCHEDULE_ID))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableCancelScheduledRequestParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableCancelScheduledRequestParams {
+    // ID of the scheduled call
+    pub fn schedule_id(&self) -> ScMutableUint32 {
+        ScMutableUint32::new(self.proxy.root(PARAM_SCHEDULE_ID))
+    }
+}
+
+#[derive(Clone)]
+pub struct ImmutableScheduleRequestParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableScheduleRequestParams {
+    pub fn new() -> ImmutableScheduleRequestParams {
+        ImmutableScheduleRequestParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // target entry point
+    pub fn entry_point(&self) -> ScImmutableHname {
+        ScImmutableHname::new(self.proxy.root(PARAM_ENTRY_POINT))
+    }
+
+    // gas budget of each run
+    pub fn gas_budget(&self) -> ScImmutableUint64 {
+        ScImmutableUint64::new(self.proxy.root(PARAM_GAS_BUDGET))
+    }
+
+    // target contract
+    pub fn hname(&self) -> ScImmutableHname {
+        ScImmutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+
+    // index of the block the call is due
+    pub fn schedule_block(&self) -> ScImmutableUint32 {
+        ScImmutableUint32::new(self.proxy.root(PARAM_SCHEDULE_BLOCK))
+    }
+
+    // interval of a recurring call, nanoseconds or number of blocks
+    pub fn schedule_interval(&self) -> ScImmutableUint64 {
+        ScImmutableUint64::new(self.proxy.root(PARAM_SCHEDULE_INTERVAL))
+    }
+
+    // serialized params of the call
+    pub fn schedule_params(&self) -> ScImmutableBytes {
+        ScImmutableBytes::new(self.proxy.root(PARAM_SCHEDULE_PARAMS))
+    }
+
+    // time the call is due, in nanoseconds since epoch
+    pub fn schedule_time(&self) -> ScImmutableInt64 {
+        ScImmutableInt64::new(self.proxy.root(PARAM_SCHEDULE_TIME))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableScheduleRequestParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableScheduleRequestParams {
+    // target entry point
+    pub fn entry_point(&self) -> ScMutableHname {
+        ScMutableHname::new(self.proxy.root(PARAM_ENTRY_POINT))
+    }
+
+    // gas budget of each run
+    pub fn gas_budget(&self) -> ScMutableUint64 {
+        ScMutableUint64::new(self.proxy.root(PARAM_GAS_BUDGET))
+    }
+
+    // target contract
+    pub fn hname(&self) -> ScMutableHname {
+        ScMutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+
+    // index of the block the call is due
+    pub fn schedule_block(&self) -> ScMutableUint32 {
+        ScMutableUint32::new(self.proxy.root(PARAM_SCHEDULE_BLOCK))
+    }
+
+    // interval of a recurring call, nanoseconds or number of blocks
+    pub fn schedule_interval(&self) -> ScMutableUint64 {
+        ScMutableUint64::new(self.proxy.root(PARAM_SCHEDULE_INTERVAL))
+    }
+
+    // serialized params of the call
+    pub fn schedule_params(&self) -> ScMutableBytes {
+        ScMutableBytes::new(self.proxy.root(PARAM_SCHEDULE_PARAMS))
+    }
+
+    // time the call is due, in nanoseconds since epoch
+    pub fn schedule_time(&self) -> ScMutableInt64 {
+        ScMutableInt64::new(self.proxy.root(PARAM_SCHEDULE_TIME))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableGetBlockInfoParams {
     pub(crate) proxy: Proxy,
@@ -189,6 +309,36 @@ impl MutableGetRequestReceiptsForBlockParams {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableGetScheduledRequestParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetScheduledRequestParams {
+    pub fn new() -> ImmutableGetScheduledRequestParams {
+        ImmutableGetScheduledRequestParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // ID of the scheduled call
+    pub fn schedule_id(&self) -> ScImmutableUint32 {
+        ScImmutableUint32::new(self.proxy.root(PARAM_SCHEDULE_ID))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableGetScheduledRequestParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableGetScheduledRequestParams {
+    // ID of the scheduled call
+    pub fn schedule_id(&self) -> ScMutableUint32 {
+        ScMutableUint32::new(self.proxy.root(PARAM_SCHEDULE_ID))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableIsRequestProcessedParams {
     pub(crate) proxy: Proxy,
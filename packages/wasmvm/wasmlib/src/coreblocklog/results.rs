@@ -9,6 +9,36 @@
 use crate::*;
 use crate::coreblocklog::*;
 
+#[derive(Clone)]
+pub struct ImmutableScheduleRequestResults {
+    pub proxy: Proxy,
+}
+
+impl ImmutableScheduleRequestResults {
+    // ID of the scheduled call
+    pub fn schedule_id(&self) -> ScImmutableUint32 {
+        ScImmutableUint32::new(self.proxy.root(RESULT_SCHEDULE_ID))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableScheduleRequestResults {
+    pub proxy: Proxy,
+}
+
+impl MutableScheduleRequestResults {
+    pub fn new() -> MutableScheduleRequestResults {
+        MutableScheduleRequestResults {
+            proxy: results_proxy(),
+        }
+    }
+
+    // ID of the scheduled call
+    pub fn schedule_id(&self) -> ScMutableUint32 {
+        ScMutableUint32::new(self.proxy.root(RESULT_SCHEDULE_ID))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableGetBlockInfoResults {
     pub proxy: Proxy,
@@ -315,6 +345,36 @@ impl MutableGetRequestReceiptsForBlockResults {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableGetScheduledRequestResults {
+    pub proxy: Proxy,
+}
+
+impl ImmutableGetScheduledRequestResults {
+    // serialized scheduled call, empty if not found
+    pub fn scheduled_request(&self) -> ScImmutableBytes {
+        ScImmutableBytes::new(self.proxy.root(RESULT_SCHEDULED_REQUEST))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableGetScheduledRequestResults {
+    pub proxy: Proxy,
+}
+
+impl MutableGetScheduledRequestResults {
+    pub fn new() -> MutableGetScheduledRequestResults {
+        MutableGetScheduledRequestResults {
+            proxy: results_proxy(),
+        }
+    }
+
+    // serialized scheduled call, empty if not found
+    pub fn scheduled_request(&self) -> ScMutableBytes {
+        ScMutableBytes::new(self.proxy.root(RESULT_SCHEDULED_REQUEST))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableIsRequestProcessedResults {
     pub proxy: Proxy,
@@ -20,11 +20,14 @@ pub(crate) const PARAM_CHAIN_OWNER     : &str = "o";
 pub(crate) const PARAM_FEE_POLICY      : &str = "g";
 pub(crate) const PARAM_GAS_LIMITS      : &str = "l";
 pub(crate) const PARAM_GAS_RATIO       : &str = "e";
+pub(crate) const PARAM_HNAME           : &str = "h";
 pub(crate) const PARAM_METADATA        : &str = "md";
+pub(crate) const PARAM_OWNER_FEE       : &str = "of";
 pub(crate) const PARAM_PAYOUT_AGENT_ID : &str = "s";
 pub(crate) const PARAM_PUB_KEY         : &str = "ip";
 pub(crate) const PARAM_PUBLIC_URL      : &str = "x";
 pub(crate) const PARAM_SET_MIN_SD      : &str = "ms";
+pub(crate) const PARAM_VALIDATOR_FEE   : &str = "vf";
 
 pub(crate) const RESULT_ACCESS_NODE_CANDIDATES : &str = "an";
 pub(crate) const RESULT_ACCESS_NODES           : &str = "ac";
@@ -36,9 +39,11 @@ pub(crate) const RESULT_GAS_LIMITS             : &str = "l";
 pub(crate) const RESULT_GAS_RATIO              : &str = "e";
 pub(crate) const RESULT_GET_MIN_SD             : &str = "ms";
 pub(crate) const RESULT_METADATA               : &str = "md";
+pub(crate) const RESULT_OWNER_FEE              : &str = "of";
 pub(crate) const RESULT_PAYOUT_AGENT_ID        : &str = "s";
 pub(crate) const RESULT_PUBLIC_URL             : &str = "x";
 pub(crate) const RESULT_STATUS                 : &str = "m";
+pub(crate) const RESULT_VALIDATOR_FEE          : &str = "vf";
 
 pub(crate) const FUNC_ADD_ALLOWED_STATE_CONTROLLER_ADDRESS    : &str = "addAllowedStateControllerAddress";
 pub(crate) const FUNC_ADD_CANDIDATE_NODE                      : &str = "addCandidateNode";
@@ -48,6 +53,7 @@ pub(crate) const FUNC_DELEGATE_CHAIN_OWNERSHIP                : &str = "delegate
 pub(crate) const FUNC_REMOVE_ALLOWED_STATE_CONTROLLER_ADDRESS : &str = "removeAllowedStateControllerAddress";
 pub(crate) const FUNC_REVOKE_ACCESS_NODE                      : &str = "revokeAccessNode";
 pub(crate) const FUNC_ROTATE_STATE_CONTROLLER                 : &str = "rotateStateController";
+pub(crate) const FUNC_SET_CONTRACT_FEES                       : &str = "setContractFees";
 pub(crate) const FUNC_SET_EVM_GAS_RATIO                       : &str = "setEVMGasRatio";
 pub(crate) const FUNC_SET_FEE_POLICY                          : &str = "setFeePolicy";
 pub(crate) const FUNC_SET_GAS_LIMITS                          : &str = "setGasLimits";
@@ -60,6 +66,7 @@ pub(crate) const VIEW_GET_ALLOWED_STATE_CONTROLLER_ADDRESSES  : &str = "getAllow
 pub(crate) const VIEW_GET_CHAIN_INFO                          : &str = "getChainInfo";
 pub(crate) const VIEW_GET_CHAIN_NODES                         : &str = "getChainNodes";
 pub(crate) const VIEW_GET_CHAIN_OWNER                         : &str = "getChainOwner";
+pub(crate) const VIEW_GET_CONTRACT_FEES                       : &str = "getContractFees";
 pub(crate) const VIEW_GET_EVM_GAS_RATIO                       : &str = "getEVMGasRatio";
 pub(crate) const VIEW_GET_FEE_POLICY                          : &str = "getFeePolicy";
 pub(crate) const VIEW_GET_GAS_LIMITS                          : &str = "getGasLimits";
@@ -76,6 +83,7 @@ pub(crate) const HFUNC_DELEGATE_CHAIN_OWNERSHIP                : ScHname = ScHna
 pub(crate) const HFUNC_REMOVE_ALLOWED_STATE_CONTROLLER_ADDRESS : ScHname = ScHname(0x31f69447);
 pub(crate) const HFUNC_REVOKE_ACCESS_NODE                      : ScHname = ScHname(0x5459512d);
 pub(crate) const HFUNC_ROTATE_STATE_CONTROLLER                 : ScHname = ScHname(0x244d1038);
+pub(crate) const HFUNC_SET_CONTRACT_FEES                       : ScHname = ScHname(0x7764488a);
 pub(crate) const HFUNC_SET_EVM_GAS_RATIO                       : ScHname = ScHname(0xaae22338);
 pub(crate) const HFUNC_SET_FEE_POLICY                          : ScHname = ScHname(0x5b791c9f);
 pub(crate) const HFUNC_SET_GAS_LIMITS                          : ScHname = ScHname(0xd72fb355);
@@ -88,6 +96,7 @@ pub(crate) const HVIEW_GET_ALLOWED_STATE_CONTROLLER_ADDRESSES  : ScHname = ScHna
 pub(crate) const HVIEW_GET_CHAIN_INFO                          : ScHname = ScHname(0x434477e2);
 pub(crate) const HVIEW_GET_CHAIN_NODES                         : ScHname = ScHname(0xe1832289);
 pub(crate) const HVIEW_GET_CHAIN_OWNER                         : ScHname = ScHname(0x9b2ef0ac);
+pub(crate) const HVIEW_GET_CONTRACT_FEES                       : ScHname = ScHname(0x001c3237);
 pub(crate) const HVIEW_GET_EVM_GAS_RATIO                       : ScHname = ScHname(0xb81c8c34);
 pub(crate) const HVIEW_GET_FEE_POLICY                          : ScHname = ScHname(0xf8c89790);
 pub(crate) const HVIEW_GET_GAS_LIMITS                          : ScHname = ScHname(0x3a493455);
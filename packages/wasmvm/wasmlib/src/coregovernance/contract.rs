@@ -47,6 +47,11 @@ pub struct RotateStateControllerCall<'a> {
     pub params: MutableRotateStateControllerParams,
 }
 
+pub struct SetContractFeesCall<'a> {
+    pub func:   ScFunc<'a>,
+    pub params: MutableSetContractFeesParams,
+}
+
 pub struct SetEVMGasRatioCall<'a> {
     pub func:   ScFunc<'a>,
     pub params: MutableSetEVMGasRatioParams,
@@ -105,6 +110,12 @@ pub struct GetChainOwnerCall<'a> {
     pub results: ImmutableGetChainOwnerResults,
 }
 
+pub struct GetContractFeesCall<'a> {
+    pub func:    ScView<'a>,
+    pub params:  MutableGetContractFeesParams,
+    pub results: ImmutableGetContractFeesResults,
+}
+
 pub struct GetEVMGasRatioCall<'a> {
     pub func:    ScView<'a>,
     pub results: ImmutableGetEVMGasRatioResults,
@@ -226,6 +237,17 @@ impl ScFuncs {
         f
     }
 
+    // Overrides the fee policy for the requests to a contract.
+    // Zero fees mean the chain defaults are in effect, when both are zero the override is removed.
+    pub fn set_contract_fees(ctx: &impl ScFuncClientContext) -> SetContractFeesCall {
+        let mut f = SetContractFeesCall {
+            func:    ScFunc::new(ctx, HSC_NAME, HFUNC_SET_CONTRACT_FEES),
+            params:  MutableSetContractFeesParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
     // Sets the EVM gas ratio for the chain.
     pub fn set_evm_gas_ratio(ctx: &impl ScFuncClientContext) -> SetEVMGasRatioCall {
         let mut f = SetEVMGasRatioCall {
@@ -339,6 +361,18 @@ impl ScFuncs {
         f
     }
 
+    // Returns the fees of a contract, zero fees mean the chain defaults are in effect.
+    pub fn get_contract_fees(ctx: &impl ScViewClientContext) -> GetContractFeesCall {
+        let mut f = GetContractFeesCall {
+            func:    ScView::new(ctx, HSC_NAME, HVIEW_GET_CONTRACT_FEES),
+            params:  MutableGetContractFeesParams { proxy: Proxy::nil() },
+            results: ImmutableGetContractFeesResults { proxy: Proxy::nil() },
+        };
+        ScView::link_params(&mut f.params.proxy, &f.func);
+        ScView::link_results(&mut f.results.proxy, &f.func);
+        f
+    }
+
     // Returns the EVM gas ratio.
     pub fn get_evm_gas_ratio(ctx: &impl ScViewClientContext) -> GetEVMGasRatioCall {
         let mut f = GetEVMGasRatioCall {
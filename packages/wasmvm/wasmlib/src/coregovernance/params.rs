@@ -291,6 +291,56 @@ impl MutableRotateStateControllerParams {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableSetContractFeesParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableSetContractFeesParams {
+    pub fn new() -> ImmutableSetContractFeesParams {
+        ImmutableSetContractFeesParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // contract hname
+    pub fn hname(&self) -> ScImmutableHname {
+        ScImmutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+
+    // chain owner part of the fee per 1000 gas
+    pub fn owner_fee(&self) -> ScImmutableUint64 {
+        ScImmutableUint64::new(self.proxy.root(PARAM_OWNER_FEE))
+    }
+
+    // validator part of the fee per 1000 gas
+    pub fn validator_fee(&self) -> ScImmutableUint64 {
+        ScImmutableUint64::new(self.proxy.root(PARAM_VALIDATOR_FEE))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableSetContractFeesParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableSetContractFeesParams {
+    // contract hname
+    pub fn hname(&self) -> ScMutableHname {
+        ScMutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+
+    // chain owner part of the fee per 1000 gas
+    pub fn owner_fee(&self) -> ScMutableUint64 {
+        ScMutableUint64::new(self.proxy.root(PARAM_OWNER_FEE))
+    }
+
+    // validator part of the fee per 1000 gas
+    pub fn validator_fee(&self) -> ScMutableUint64 {
+        ScMutableUint64::new(self.proxy.root(PARAM_VALIDATOR_FEE))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableSetEVMGasRatioParams {
     pub(crate) proxy: Proxy,
@@ -478,3 +528,33 @@ impl MutableSetPayoutAgentIDParams {
         ScMutableAgentID::new(self.proxy.root(PARAM_PAYOUT_AGENT_ID))
     }
 }
+
+#[derive(Clone)]
+pub struct ImmutableGetContractFeesParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableGetContractFeesParams {
+    pub fn new() -> ImmutableGetContractFeesParams {
+        ImmutableGetContractFeesParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // contract hname
+    pub fn hname(&self) -> ScImmutableHname {
+        ScImmutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableGetContractFeesParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableGetContractFeesParams {
+    // contract hname
+    pub fn hname(&self) -> ScMutableHname {
+        ScMutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+}
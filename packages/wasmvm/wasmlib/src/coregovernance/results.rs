@@ -277,6 +277,46 @@ impl MutableGetChainOwnerResults {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableGetContractFeesResults {
+    pub proxy: Proxy,
+}
+
+impl ImmutableGetContractFeesResults {
+    // chain owner part of the fee per 1000 gas
+    pub fn owner_fee(&self) -> ScImmutableUint64 {
+        ScImmutableUint64::new(self.proxy.root(RESULT_OWNER_FEE))
+    }
+
+    // validator part of the fee per 1000 gas
+    pub fn validator_fee(&self) -> ScImmutableUint64 {
+        ScImmutableUint64::new(self.proxy.root(RESULT_VALIDATOR_FEE))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableGetContractFeesResults {
+    pub proxy: Proxy,
+}
+
+impl MutableGetContractFeesResults {
+    pub fn new() -> MutableGetContractFeesResults {
+        MutableGetContractFeesResults {
+            proxy: results_proxy(),
+        }
+    }
+
+    // chain owner part of the fee per 1000 gas
+    pub fn owner_fee(&self) -> ScMutableUint64 {
+        ScMutableUint64::new(self.proxy.root(RESULT_OWNER_FEE))
+    }
+
+    // validator part of the fee per 1000 gas
+    pub fn validator_fee(&self) -> ScMutableUint64 {
+        ScMutableUint64::new(self.proxy.root(RESULT_VALIDATOR_FEE))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableGetEVMGasRatioResults {
     pub proxy: Proxy,
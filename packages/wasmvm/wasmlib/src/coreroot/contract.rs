@@ -23,11 +23,21 @@ pub struct RequireDeployPermissionsCall<'a> {
     pub params: MutableRequireDeployPermissionsParams,
 }
 
+pub struct RetireContractCall<'a> {
+    pub func:   ScFunc<'a>,
+    pub params: MutableRetireContractParams,
+}
+
 pub struct RevokeDeployPermissionCall<'a> {
     pub func:   ScFunc<'a>,
     pub params: MutableRevokeDeployPermissionParams,
 }
 
+pub struct UpgradeContractCall<'a> {
+    pub func:   ScFunc<'a>,
+    pub params: MutableUpgradeContractParams,
+}
+
 pub struct FindContractCall<'a> {
     pub func:    ScView<'a>,
     pub params:  MutableFindContractParams,
@@ -73,6 +83,16 @@ impl ScFuncs {
         f
     }
 
+    // Retires a deployed contract, further calls to the contract are rejected.
+    pub fn retire_contract(ctx: &impl ScFuncClientContext) -> RetireContractCall {
+        let mut f = RetireContractCall {
+            func:    ScFunc::new(ctx, HSC_NAME, HFUNC_RETIRE_CONTRACT),
+            params:  MutableRetireContractParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
     // Revokes deploy permission for an agent.
     pub fn revoke_deploy_permission(ctx: &impl ScFuncClientContext) -> RevokeDeployPermissionCall {
         let mut f = RevokeDeployPermissionCall {
@@ -83,6 +103,16 @@ impl ScFuncs {
         f
     }
 
+    // Replaces the program of a deployed contract, keeping its hname and state.
+    pub fn upgrade_contract(ctx: &impl ScFuncClientContext) -> UpgradeContractCall {
+        let mut f = UpgradeContractCall {
+            func:    ScFunc::new(ctx, HSC_NAME, HFUNC_UPGRADE_CONTRACT),
+            params:  MutableUpgradeContractParams { proxy: Proxy::nil() },
+        };
+        ScFunc::link_params(&mut f.params.proxy, &f.func);
+        f
+    }
+
     // Returns the record for a given smart contract
     pub fn find_contract(ctx: &impl ScViewClientContext) -> FindContractCall {
         let mut f = FindContractCall {
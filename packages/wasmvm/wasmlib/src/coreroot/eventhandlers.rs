@@ -15,7 +15,9 @@ pub struct CoreRootEventHandlers {
 
     deploy: Box<dyn Fn(&EventDeploy)>,
     grant: Box<dyn Fn(&EventGrant)>,
+    retire: Box<dyn Fn(&EventRetire)>,
     revoke: Box<dyn Fn(&EventRevoke)>,
+    upgrade: Box<dyn Fn(&EventUpgrade)>,
 }
 
 impl IEventHandlers for CoreRootEventHandlers {
@@ -38,13 +40,17 @@ impl CoreRootEventHandlers {
         let mut handlers: HashMap<&str, fn(evt: &CoreRootEventHandlers, dec: &mut WasmDecoder)> = HashMap::new();
         handlers.insert("coreroot.deploy", |e, m| { (e.deploy)(&EventDeploy::new(m)); });
         handlers.insert("coreroot.grant", |e, m| { (e.grant)(&EventGrant::new(m)); });
+        handlers.insert("coreroot.retire", |e, m| { (e.retire)(&EventRetire::new(m)); });
         handlers.insert("coreroot.revoke", |e, m| { (e.revoke)(&EventRevoke::new(m)); });
+        handlers.insert("coreroot.upgrade", |e, m| { (e.upgrade)(&EventUpgrade::new(m)); });
         return CoreRootEventHandlers {
             my_id: EventHandlers::generate_id(),
             core_root_handlers: handlers,
             deploy: Box::new(|_e| {}),
             grant: Box::new(|_e| {}),
+            retire: Box::new(|_e| {}),
             revoke: Box::new(|_e| {}),
+            upgrade: Box::new(|_e| {}),
         };
     }
 
@@ -58,10 +64,20 @@ impl CoreRootEventHandlers {
         self.grant = Box::new(handler);
     }
 
+    pub fn on_core_root_retire<F>(&mut self, handler: F)
+        where F: Fn(&EventRetire) + 'static {
+        self.retire = Box::new(handler);
+    }
+
     pub fn on_core_root_revoke<F>(&mut self, handler: F)
         where F: Fn(&EventRevoke) + 'static {
         self.revoke = Box::new(handler);
     }
+
+    pub fn on_core_root_upgrade<F>(&mut self, handler: F)
+        where F: Fn(&EventUpgrade) + 'static {
+        self.upgrade = Box::new(handler);
+    }
 }
 
 pub struct EventDeploy {
@@ -94,6 +110,20 @@ impl EventGrant {
     }
 }
 
+pub struct EventRetire {
+    pub timestamp: u64,
+    pub hname: ScHname,
+}
+
+impl EventRetire {
+    pub fn new(dec: &mut WasmDecoder) -> EventRetire {
+        EventRetire {
+            timestamp: uint64_decode(dec),
+            hname: hname_decode(dec),
+        }
+    }
+}
+
 pub struct EventRevoke {
     pub timestamp: u64,
     pub deployer: ScAgentID,
@@ -107,3 +137,21 @@ impl EventRevoke {
         }
     }
 }
+
+pub struct EventUpgrade {
+    pub timestamp: u64,
+    pub hname: ScHname,
+    pub old_prog_hash: ScHash,
+    pub prog_hash: ScHash,
+}
+
+impl EventUpgrade {
+    pub fn new(dec: &mut WasmDecoder) -> EventUpgrade {
+        EventUpgrade {
+            timestamp: uint64_decode(dec),
+            hname: hname_decode(dec),
+            old_prog_hash: hash_decode(dec),
+            prog_hash: hash_decode(dec),
+        }
+    }
+}
@@ -147,6 +147,36 @@ impl MutableRequireDeployPermissionsParams {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableRetireContractParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableRetireContractParams {
+    pub fn new() -> ImmutableRetireContractParams {
+        ImmutableRetireContractParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // The smart contract’s Hname
+    pub fn hname(&self) -> ScImmutableHname {
+        ScImmutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableRetireContractParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableRetireContractParams {
+    // The smart contract’s Hname
+    pub fn hname(&self) -> ScMutableHname {
+        ScMutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableRevokeDeployPermissionParams {
     pub(crate) proxy: Proxy,
@@ -177,6 +207,46 @@ impl MutableRevokeDeployPermissionParams {
     }
 }
 
+#[derive(Clone)]
+pub struct ImmutableUpgradeContractParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl ImmutableUpgradeContractParams {
+    pub fn new() -> ImmutableUpgradeContractParams {
+        ImmutableUpgradeContractParams {
+            proxy: params_proxy(),
+        }
+    }
+
+    // The smart contract’s Hname
+    pub fn hname(&self) -> ScImmutableHname {
+        ScImmutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+
+    // hash of blob that has been previously stored in blob contract
+    pub fn program_hash(&self) -> ScImmutableHash {
+        ScImmutableHash::new(self.proxy.root(PARAM_PROGRAM_HASH))
+    }
+}
+
+#[derive(Clone)]
+pub struct MutableUpgradeContractParams {
+    pub(crate) proxy: Proxy,
+}
+
+impl MutableUpgradeContractParams {
+    // The smart contract’s Hname
+    pub fn hname(&self) -> ScMutableHname {
+        ScMutableHname::new(self.proxy.root(PARAM_HNAME))
+    }
+
+    // hash of blob that has been previously stored in blob contract
+    pub fn program_hash(&self) -> ScMutableHash {
+        ScMutableHash::new(self.proxy.root(PARAM_PROGRAM_HASH))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableFindContractParams {
     pub(crate) proxy: Proxy,
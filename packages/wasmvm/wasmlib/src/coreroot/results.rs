@@ -60,6 +60,17 @@ impl MapHnameToImmutableBytes {
     }
 }
 
+#[derive(Clone)]
+pub struct MapHnameToImmutableBool {
+    pub(crate) proxy: Proxy,
+}
+
+impl MapHnameToImmutableBool {
+    pub fn get_bool(&self, key: ScHname) -> ScImmutableBool {
+        ScImmutableBool::new(self.proxy.key(&hname_to_bytes(key)))
+    }
+}
+
 #[derive(Clone)]
 pub struct ImmutableGetContractRecordsResults {
     pub proxy: Proxy,
@@ -70,6 +81,11 @@ impl ImmutableGetContractRecordsResults {
     pub fn contract_registry(&self) -> MapHnameToImmutableBytes {
         MapHnameToImmutableBytes { proxy: self.proxy.root(RESULT_CONTRACT_REGISTRY) }
     }
+
+    // retired contracts by Hname
+    pub fn retired_contracts(&self) -> MapHnameToImmutableBool {
+        MapHnameToImmutableBool { proxy: self.proxy.root(RESULT_RETIRED_CONTRACTS) }
+    }
 }
 
 #[derive(Clone)]
@@ -87,6 +103,21 @@ impl MapHnameToMutableBytes {
     }
 }
 
+#[derive(Clone)]
+pub struct MapHnameToMutableBool {
+    pub(crate) proxy: Proxy,
+}
+
+impl MapHnameToMutableBool {
+    pub fn clear(&self) {
+        self.proxy.clear_map();
+    }
+
+    pub fn get_bool(&self, key: ScHname) -> ScMutableBool {
+        ScMutableBool::new(self.proxy.key(&hname_to_bytes(key)))
+    }
+}
+
 #[derive(Clone)]
 pub struct MutableGetContractRecordsResults {
     pub proxy: Proxy,
@@ -103,4 +134,9 @@ impl MutableGetContractRecordsResults {
     pub fn contract_registry(&self) -> MapHnameToMutableBytes {
         MapHnameToMutableBytes { proxy: self.proxy.root(RESULT_CONTRACT_REGISTRY) }
     }
+
+    // retired contracts by Hname
+    pub fn retired_contracts(&self) -> MapHnameToMutableBool {
+        MapHnameToMutableBool { proxy: self.proxy.root(RESULT_RETIRED_CONTRACTS) }
+    }
 }
@@ -9,18 +9,20 @@ export const ScName        = 'accounts';
 export const ScDescription = 'Chain account ledger contract';
 export const HScName       = new wasmtypes.ScHname(0x3c4b5e02);
 
-export const ParamAgentID        = 'a';
-export const ParamCollection     = 'C';
-export const ParamDestroyTokens  = 'y';
-export const ParamFoundrySN      = 's';
-export const ParamGasReserve     = 'g';
-export const ParamNftID          = 'z';
-export const ParamSupplyDeltaAbs = 'd';
-export const ParamTokenDecimals  = 'td';
-export const ParamTokenID        = 'N';
-export const ParamTokenName      = 'tn';
-export const ParamTokenScheme    = 't';
-export const ParamTokenSymbol    = 'ts';
+export const ParamAgentID          = 'a';
+export const ParamCollection       = 'C';
+export const ParamDestroyTokens    = 'y';
+export const ParamFoundrySN        = 's';
+export const ParamGasReserve       = 'g';
+export const ParamNftID            = 'z';
+export const ParamSponsor          = 'sp';
+export const ParamSponsorAllowance = 'sa';
+export const ParamSupplyDeltaAbs   = 'd';
+export const ParamTokenDecimals    = 'td';
+export const ParamTokenID          = 'N';
+export const ParamTokenName        = 'tn';
+export const ParamTokenScheme      = 't';
+export const ParamTokenSymbol      = 'ts';
 
 export const ResultAccountNonce     = 'n';
 export const ResultAmount           = 'A';
@@ -33,6 +35,7 @@ export const ResultFoundrySN        = 's';
 export const ResultMapping          = 'this';
 export const ResultNftData          = 'e';
 export const ResultNftIDs           = 'i';
+export const ResultSponsorAllowance = 'sa';
 export const ResultTokens           = 'B';
 
 export const FuncDeposit                      = 'deposit';
@@ -40,6 +43,7 @@ export const FuncFoundryCreateNew             = 'foundryCreateNew';
 export const FuncNativeTokenCreate            = 'nativeTokenCreate';
 export const FuncNativeTokenDestroy           = 'nativeTokenDestroy';
 export const FuncNativeTokenModifySupply      = 'nativeTokenModifySupply';
+export const FuncSetSponsorAllowance          = 'setSponsorAllowance';
 export const FuncTransferAccountToChain       = 'transferAccountToChain';
 export const FuncTransferAllowanceTo          = 'transferAllowanceTo';
 export const FuncWithdraw                     = 'withdraw';
@@ -53,6 +57,7 @@ export const ViewBalanceBaseToken             = 'balanceBaseToken';
 export const ViewBalanceNativeToken           = 'balanceNativeToken';
 export const ViewGetAccountNonce              = 'getAccountNonce';
 export const ViewGetNativeTokenIDRegistry     = 'getNativeTokenIDRegistry';
+export const ViewGetSponsorAllowance          = 'getSponsorAllowance';
 export const ViewNativeToken                  = 'nativeToken';
 export const ViewNftData                      = 'nftData';
 export const ViewTotalAssets                  = 'totalAssets';
@@ -62,6 +67,7 @@ export const HFuncFoundryCreateNew             = new wasmtypes.ScHname(0x41822f5
 export const HFuncNativeTokenCreate            = new wasmtypes.ScHname(0x0c2d1791);
 export const HFuncNativeTokenDestroy           = new wasmtypes.ScHname(0xf0b0ab00);
 export const HFuncNativeTokenModifySupply      = new wasmtypes.ScHname(0x24c2eab6);
+export const HFuncSetSponsorAllowance          = new wasmtypes.ScHname(0x3234927c);
 export const HFuncTransferAccountToChain       = new wasmtypes.ScHname(0x07005c45);
 export const HFuncTransferAllowanceTo          = new wasmtypes.ScHname(0x23f4e3a1);
 export const HFuncWithdraw                     = new wasmtypes.ScHname(0x9dcc0f41);
@@ -75,6 +81,7 @@ export const HViewBalanceBaseToken             = new wasmtypes.ScHname(0x4c8ccd0
 export const HViewBalanceNativeToken           = new wasmtypes.ScHname(0x1fea3104);
 export const HViewGetAccountNonce              = new wasmtypes.ScHname(0x529d7df9);
 export const HViewGetNativeTokenIDRegistry     = new wasmtypes.ScHname(0x2ad8a59f);
+export const HViewGetSponsorAllowance          = new wasmtypes.ScHname(0xc215a9ff);
 export const HViewNativeToken                  = new wasmtypes.ScHname(0x28e34b65);
 export const HViewNftData                      = new wasmtypes.ScHname(0x83c5c4da);
 export const HViewTotalAssets                  = new wasmtypes.ScHname(0xfab0f8d2);
@@ -51,6 +51,15 @@ export class NativeTokenModifySupplyCall {
     }
 }
 
+export class SetSponsorAllowanceCall {
+    func:   wasmlib.ScFunc;
+    params: sc.MutableSetSponsorAllowanceParams = new sc.MutableSetSponsorAllowanceParams(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScFuncClientContext) {
+        this.func = new wasmlib.ScFunc(ctx, sc.HScName, sc.HFuncSetSponsorAllowance);
+    }
+}
+
 export class TransferAccountToChainCall {
     func:   wasmlib.ScFunc;
     params: sc.MutableTransferAccountToChainParams = new sc.MutableTransferAccountToChainParams(wasmlib.ScView.nilProxy);
@@ -176,6 +185,16 @@ export class GetNativeTokenIDRegistryCall {
     }
 }
 
+export class GetSponsorAllowanceCall {
+    func:    wasmlib.ScView;
+    params:  sc.MutableGetSponsorAllowanceParams = new sc.MutableGetSponsorAllowanceParams(wasmlib.ScView.nilProxy);
+    results: sc.ImmutableGetSponsorAllowanceResults = new sc.ImmutableGetSponsorAllowanceResults(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScViewClientContext) {
+        this.func = new wasmlib.ScView(ctx, sc.HScName, sc.HViewGetSponsorAllowance);
+    }
+}
+
 export class NativeTokenCall {
     func:    wasmlib.ScView;
     params:  sc.MutableNativeTokenParams = new sc.MutableNativeTokenParams(wasmlib.ScView.nilProxy);
@@ -241,6 +260,14 @@ export class ScFuncs {
         return f;
     }
 
+    // Sets the amount of base tokens the caller pays for the gas fees of
+    // the off-ledger requests of the given sender that name the caller as sponsor.
+    static setSponsorAllowance(ctx: wasmlib.ScFuncClientContext): SetSponsorAllowanceCall {
+        const f = new SetSponsorAllowanceCall(ctx);
+        f.params = new sc.MutableSetSponsorAllowanceParams(wasmlib.newCallParamsProxy(f.func));
+        return f;
+    }
+
     // Transfers the specified allowance from the sender SC's L2 account on
     // the target chain to the sender SC's L2 account on the origin chain.
     static transferAccountToChain(ctx: wasmlib.ScFuncClientContext): TransferAccountToChainCall {
@@ -343,6 +370,14 @@ export class ScFuncs {
         return f;
     }
 
+    // Returns the amount of base tokens the sponsor still pays for the gas fees of the sender.
+    static getSponsorAllowance(ctx: wasmlib.ScViewClientContext): GetSponsorAllowanceCall {
+        const f = new GetSponsorAllowanceCall(ctx);
+        f.params = new sc.MutableGetSponsorAllowanceParams(wasmlib.newCallParamsProxy(f.func));
+        f.results = new sc.ImmutableGetSponsorAllowanceResults(wasmlib.newCallResultsProxy(f.func));
+        return f;
+    }
+
     // Returns specified foundry output in serialized form.
     static nativeToken(ctx: wasmlib.ScViewClientContext): NativeTokenCall {
         const f = new NativeTokenCall(ctx);
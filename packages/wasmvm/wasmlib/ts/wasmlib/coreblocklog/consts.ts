@@ -9,8 +9,16 @@ export const ScName        = 'blocklog';
 export const ScDescription = 'Block log contract';
 export const HScName       = new wasmtypes.ScHname(0xf538ef2b);
 
-export const ParamBlockIndex = 'n';
-export const ParamRequestID  = 'u';
+export const ParamBlockIndex       = 'n';
+export const ParamEntryPoint       = 'ep';
+export const ParamGasBudget        = 'g';
+export const ParamHname            = 'h';
+export const ParamRequestID        = 'u';
+export const ParamScheduleBlock    = 'sb';
+export const ParamScheduleID       = 'si';
+export const ParamScheduleInterval = 'sv';
+export const ParamScheduleParams   = 'sp';
+export const ParamScheduleTime     = 'st';
 
 export const ResultBlockIndex       = 'n';
 export const ResultBlockInfo        = 'i';
@@ -20,19 +28,27 @@ export const ResultRequestIndex     = 'r';
 export const ResultRequestProcessed = 'p';
 export const ResultRequestReceipt   = 'd';
 export const ResultRequestReceipts  = 'd';
+export const ResultScheduledRequest = 'sr';
+export const ResultScheduleID       = 'si';
 
+export const FuncCancelScheduledRequest     = 'cancelScheduledRequest';
+export const FuncScheduleRequest            = 'scheduleRequest';
 export const ViewGetBlockInfo               = 'getBlockInfo';
 export const ViewGetEventsForBlock          = 'getEventsForBlock';
 export const ViewGetEventsForRequest        = 'getEventsForRequest';
 export const ViewGetRequestIDsForBlock      = 'getRequestIDsForBlock';
 export const ViewGetRequestReceipt          = 'getRequestReceipt';
 export const ViewGetRequestReceiptsForBlock = 'getRequestReceiptsForBlock';
+export const ViewGetScheduledRequest        = 'getScheduledRequest';
 export const ViewIsRequestProcessed         = 'isRequestProcessed';
 
+export const HFuncCancelScheduledRequest     = new wasmtypes.ScHname(0x8f84f7e4);
+export const HFuncScheduleRequest            = new wasmtypes.ScHname(0xef46a811);
 export const HViewGetBlockInfo               = new wasmtypes.ScHname(0xbe89f9b3);
 export const HViewGetEventsForBlock          = new wasmtypes.ScHname(0x36232798);
 export const HViewGetEventsForRequest        = new wasmtypes.ScHname(0x4f8d68e4);
 export const HViewGetRequestIDsForBlock      = new wasmtypes.ScHname(0x5a20327a);
 export const HViewGetRequestReceipt          = new wasmtypes.ScHname(0xb7f9534f);
 export const HViewGetRequestReceiptsForBlock = new wasmtypes.ScHname(0x77e3beef);
+export const HViewGetScheduledRequest        = new wasmtypes.ScHname(0x809d3ef7);
 export const HViewIsRequestProcessed         = new wasmtypes.ScHname(0xd57d50a9);
@@ -6,6 +6,20 @@
 import * as wasmtypes from '../wasmtypes';
 import * as sc from './index';
 
+export class ImmutableScheduleRequestResults extends wasmtypes.ScProxy {
+    // ID of the scheduled call
+    scheduleID(): wasmtypes.ScImmutableUint32 {
+        return new wasmtypes.ScImmutableUint32(this.proxy.root(sc.ResultScheduleID));
+    }
+}
+
+export class MutableScheduleRequestResults extends wasmtypes.ScProxy {
+    // ID of the scheduled call
+    scheduleID(): wasmtypes.ScMutableUint32 {
+        return new wasmtypes.ScMutableUint32(this.proxy.root(sc.ResultScheduleID));
+    }
+}
+
 export class ImmutableGetBlockInfoResults extends wasmtypes.ScProxy {
     // index of returned block
     blockIndex(): wasmtypes.ScImmutableUint32 {
@@ -200,6 +214,20 @@ export class MutableGetRequestReceiptsForBlockResults extends wasmtypes.ScProxy
     }
 }
 
+export class ImmutableGetScheduledRequestResults extends wasmtypes.ScProxy {
+    // serialized scheduled call, empty if not found
+    scheduledRequest(): wasmtypes.ScImmutableBytes {
+        return new wasmtypes.ScImmutableBytes(this.proxy.root(sc.ResultScheduledRequest));
+    }
+}
+
+export class MutableGetScheduledRequestResults extends wasmtypes.ScProxy {
+    // serialized scheduled call, empty if not found
+    scheduledRequest(): wasmtypes.ScMutableBytes {
+        return new wasmtypes.ScMutableBytes(this.proxy.root(sc.ResultScheduledRequest));
+    }
+}
+
 export class ImmutableIsRequestProcessedResults extends wasmtypes.ScProxy {
     // whether request has been processed
     requestProcessed(): wasmtypes.ScImmutableBool {
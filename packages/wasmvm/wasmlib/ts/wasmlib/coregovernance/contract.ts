@@ -77,6 +77,15 @@ export class RotateStateControllerCall {
     }
 }
 
+export class SetContractFeesCall {
+    func:   wasmlib.ScFunc;
+    params: sc.MutableSetContractFeesParams = new sc.MutableSetContractFeesParams(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScFuncClientContext) {
+        this.func = new wasmlib.ScFunc(ctx, sc.HScName, sc.HFuncSetContractFees);
+    }
+}
+
 export class SetEVMGasRatioCall {
     func:   wasmlib.ScFunc;
     params: sc.MutableSetEVMGasRatioParams = new sc.MutableSetEVMGasRatioParams(wasmlib.ScView.nilProxy);
@@ -183,6 +192,16 @@ export class GetChainOwnerCall {
     }
 }
 
+export class GetContractFeesCall {
+    func:    wasmlib.ScView;
+    params:  sc.MutableGetContractFeesParams = new sc.MutableGetContractFeesParams(wasmlib.ScView.nilProxy);
+    results: sc.ImmutableGetContractFeesResults = new sc.ImmutableGetContractFeesResults(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScViewClientContext) {
+        this.func = new wasmlib.ScView(ctx, sc.HScName, sc.HViewGetContractFees);
+    }
+}
+
 export class GetEVMGasRatioCall {
     func:    wasmlib.ScView;
     results: sc.ImmutableGetEVMGasRatioResults = new sc.ImmutableGetEVMGasRatioResults(wasmlib.ScView.nilProxy);
@@ -306,6 +325,14 @@ export class ScFuncs {
         return f;
     }
 
+    // Overrides the fee policy for the requests to a contract.
+    // Zero fees mean the chain defaults are in effect, when both are zero the override is removed.
+    static setContractFees(ctx: wasmlib.ScFuncClientContext): SetContractFeesCall {
+        const f = new SetContractFeesCall(ctx);
+        f.params = new sc.MutableSetContractFeesParams(wasmlib.newCallParamsProxy(f.func));
+        return f;
+    }
+
     // Sets the EVM gas ratio for the chain.
     static setEVMGasRatio(ctx: wasmlib.ScFuncClientContext): SetEVMGasRatioCall {
         const f = new SetEVMGasRatioCall(ctx);
@@ -385,6 +412,14 @@ export class ScFuncs {
         return f;
     }
 
+    // Returns the fees of a contract, zero fees mean the chain defaults are in effect.
+    static getContractFees(ctx: wasmlib.ScViewClientContext): GetContractFeesCall {
+        const f = new GetContractFeesCall(ctx);
+        f.params = new sc.MutableGetContractFeesParams(wasmlib.newCallParamsProxy(f.func));
+        f.results = new sc.ImmutableGetContractFeesResults(wasmlib.newCallResultsProxy(f.func));
+        return f;
+    }
+
     // Returns the EVM gas ratio.
     static getEVMGasRatio(ctx: wasmlib.ScViewClientContext): GetEVMGasRatioCall {
         const f = new GetEVMGasRatioCall(ctx);
@@ -186,6 +186,30 @@ export class MutableGetChainOwnerResults extends wasmtypes.ScProxy {
     }
 }
 
+export class ImmutableGetContractFeesResults extends wasmtypes.ScProxy {
+    // chain owner part of the fee per 1000 gas
+    ownerFee(): wasmtypes.ScImmutableUint64 {
+        return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ResultOwnerFee));
+    }
+
+    // validator part of the fee per 1000 gas
+    validatorFee(): wasmtypes.ScImmutableUint64 {
+        return new wasmtypes.ScImmutableUint64(this.proxy.root(sc.ResultValidatorFee));
+    }
+}
+
+export class MutableGetContractFeesResults extends wasmtypes.ScProxy {
+    // chain owner part of the fee per 1000 gas
+    ownerFee(): wasmtypes.ScMutableUint64 {
+        return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ResultOwnerFee));
+    }
+
+    // validator part of the fee per 1000 gas
+    validatorFee(): wasmtypes.ScMutableUint64 {
+        return new wasmtypes.ScMutableUint64(this.proxy.root(sc.ResultValidatorFee));
+    }
+}
+
 export class ImmutableGetEVMGasRatioResults extends wasmtypes.ScProxy {
     // serialized gas ratio
     gasRatio(): wasmtypes.ScImmutableBytes {
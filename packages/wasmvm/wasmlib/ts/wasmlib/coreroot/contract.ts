@@ -33,6 +33,15 @@ export class RequireDeployPermissionsCall {
     }
 }
 
+export class RetireContractCall {
+    func:   wasmlib.ScFunc;
+    params: sc.MutableRetireContractParams = new sc.MutableRetireContractParams(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScFuncClientContext) {
+        this.func = new wasmlib.ScFunc(ctx, sc.HScName, sc.HFuncRetireContract);
+    }
+}
+
 export class RevokeDeployPermissionCall {
     func:   wasmlib.ScFunc;
     params: sc.MutableRevokeDeployPermissionParams = new sc.MutableRevokeDeployPermissionParams(wasmlib.ScView.nilProxy);
@@ -42,6 +51,15 @@ export class RevokeDeployPermissionCall {
     }
 }
 
+export class UpgradeContractCall {
+    func:   wasmlib.ScFunc;
+    params: sc.MutableUpgradeContractParams = new sc.MutableUpgradeContractParams(wasmlib.ScView.nilProxy);
+
+    public constructor(ctx: wasmlib.ScFuncClientContext) {
+        this.func = new wasmlib.ScFunc(ctx, sc.HScName, sc.HFuncUpgradeContract);
+    }
+}
+
 export class FindContractCall {
     func:    wasmlib.ScView;
     params:  sc.MutableFindContractParams = new sc.MutableFindContractParams(wasmlib.ScView.nilProxy);
@@ -83,6 +101,13 @@ export class ScFuncs {
         return f;
     }
 
+    // Retires a deployed contract, further calls to the contract are rejected.
+    static retireContract(ctx: wasmlib.ScFuncClientContext): RetireContractCall {
+        const f = new RetireContractCall(ctx);
+        f.params = new sc.MutableRetireContractParams(wasmlib.newCallParamsProxy(f.func));
+        return f;
+    }
+
     // Revokes deploy permission for an agent.
     static revokeDeployPermission(ctx: wasmlib.ScFuncClientContext): RevokeDeployPermissionCall {
         const f = new RevokeDeployPermissionCall(ctx);
@@ -90,6 +115,13 @@ export class ScFuncs {
         return f;
     }
 
+    // Replaces the program of a deployed contract, keeping its hname and state.
+    static upgradeContract(ctx: wasmlib.ScFuncClientContext): UpgradeContractCall {
+        const f = new UpgradeContractCall(ctx);
+        f.params = new sc.MutableUpgradeContractParams(wasmlib.newCallParamsProxy(f.func));
+        return f;
+    }
+
     // Returns the record for a given smart contract
     static findContract(ctx: wasmlib.ScViewClientContext): FindContractCall {
         const f = new FindContractCall(ctx);
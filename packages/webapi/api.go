@@ -22,6 +22,7 @@ import (
 	userspkg "github.com/nnikolash/wasp-types-exported/packages/users"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/controllers/chain"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/controllers/corecontracts"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/controllers/l1"
 	apimetrics "github.com/nnikolash/wasp-types-exported/packages/webapi/controllers/metrics"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/controllers/node"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/controllers/requests"
@@ -96,7 +97,8 @@ func Init(
 	accountDumpsPath string,
 	pub *publisher.Publisher,
 	jsonrpcParams *jsonrpc.Parameters,
-) {
+	l1Service interfaces.L1Service,
+) interfaces.EVMService {
 	// load mock files to generate correct echo swagger documentation
 	mocker := NewMocker()
 	mocker.LoadMockFiles()
@@ -124,7 +126,14 @@ func Init(
 		corecontracts.NewCoreContractsController(chainService),
 	}
 
+	// only available in the development mode, when the node runs with an in-process L1 ledger
+	if l1Service != nil {
+		controllersToLoad = append(controllersToLoad, l1.NewL1Controller(l1Service))
+	}
+
 	AddHealthEndpoint(server, chainService, metricsService)
 	addWebSocketEndpoint(server, websocketService)
 	loadControllers(server, mocker, controllersToLoad, authMiddleware)
+
+	return evmService
 }
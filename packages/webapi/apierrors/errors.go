@@ -19,6 +19,10 @@ func UserCanNotBeDeleted(username string, explanation string) *HTTPError {
 	return NewHTTPError(http.StatusBadRequest, fmt.Sprintf("User: %v not be deleted. Reason: %v", username, explanation), nil)
 }
 
+func PermissionNotHeldError(permission string) *HTTPError {
+	return NewHTTPError(http.StatusForbidden, fmt.Sprintf("Permission: %v is not held by the caller", permission), nil)
+}
+
 func BodyIsEmptyError() *HTTPError {
 	return InvalidPropertyError("body", errors.New("a valid body is required"))
 }
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"fmt"
+
+	chainpkg "github.com/nnikolash/wasp-types-exported/packages/chain"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/transaction"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/dto"
+)
+
+// GetStateProof returns the value of the key together with its Merkle proof.
+// The proof is taken from the state committed in the latest confirmed alias output,
+// so it can be verified against L1.
+func GetStateProof(ch chainpkg.Chain, stateKey []byte) (*dto.StateProof, error) {
+	aliasOutput, err := ch.LatestAliasOutput(chainpkg.ConfirmedState)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest confirmed alias output: %w", err)
+	}
+	l1Commitment, err := transaction.L1CommitmentFromAliasOutput(aliasOutput.GetAliasOutput())
+	if err != nil {
+		return nil, fmt.Errorf("error getting L1 commitment: %w", err)
+	}
+	chainState, err := ch.Store().StateByTrieRoot(l1Commitment.TrieRoot())
+	if err != nil {
+		return nil, fmt.Errorf("error getting state by trie root: %w", err)
+	}
+	return &dto.StateProof{
+		Key:          stateKey,
+		Value:        chainState.Get(kv.Key(stateKey)),
+		Proof:        chainState.GetMerkleProof(stateKey),
+		L1Commitment: l1Commitment,
+		AliasOutput:  aliasOutput,
+	}, nil
+}
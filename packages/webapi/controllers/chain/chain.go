@@ -151,6 +151,26 @@ func (c *Controller) getState(e echo.Context) error {
 	return e.JSON(http.StatusOK, response)
 }
 
+func (c *Controller) getStateProof(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_state_proof")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	stateKey, err := iotago.DecodeHex(e.Param(params.ParamStateKey))
+	if err != nil {
+		return apierrors.InvalidPropertyError(params.ParamStateKey, err)
+	}
+
+	proof, err := c.chainService.GetStateProof(chainID, stateKey)
+	if err != nil {
+		panic(err)
+	}
+
+	return e.JSON(http.StatusOK, models.MapStateProofResponse(proof))
+}
+
 var dumpAccountsMutex = sync.Mutex{}
 
 func (c *Controller) dumpAccounts(e echo.Context) error {
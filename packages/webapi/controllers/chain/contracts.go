@@ -29,6 +29,7 @@ func (c *Controller) getContracts(e echo.Context) error {
 			HName:       hName.String(),
 			Name:        contract.Name,
 			ProgramHash: contract.ProgramHash.String(),
+			Retired:     contract.Retired,
 		}
 
 		contractList = append(contractList, contractInfo)
@@ -2,6 +2,7 @@ package chain
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/pangpanglabs/echoswagger/v2"
 
@@ -26,6 +27,8 @@ type Controller struct {
 	registryService  interfaces.RegistryService
 
 	accountDumpsPath string
+	// pruningChains contains the IDs of the chains being pruned
+	pruningChains sync.Map
 }
 
 func NewChainController(log *loggerpkg.Logger,
@@ -82,6 +85,13 @@ func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker inter
 		SetSummary("Fetch the raw value associated with the given key in the chain state").
 		SetOperationId("getStateValue")
 
+	publicAPI.GET("chains/:chainID/state/:stateKey/proof", c.getStateProof).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamPath("", params.ParamStateKey, params.DescriptionStateKey).
+		AddResponse(http.StatusOK, "Result", mocker.Get(models.StateProofResponse{}), nil).
+		SetSummary("Fetch the value associated with the given key in the chain state, together with a proof anchored to the latest confirmed alias output").
+		SetOperationId("getStateProof")
+
 	publicAPI.GET("chains/:chainID/receipts/:requestID", c.getReceipt).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamPath("", params.ParamRequestID, params.DescriptionRequestID).
@@ -134,65 +144,73 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		SetOperationId("getChains").
 		SetSummary("Get a list of all chains")
 
-	adminAPI.POST("chains/:chainID/activate", c.activateChain, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/activate", c.activateChain, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddResponse(http.StatusNotModified, "Chain was not activated", nil, nil).
 		AddResponse(http.StatusOK, "Chain was successfully activated", nil, nil).
 		SetOperationId("activateChain").
 		SetSummary("Activate a chain")
 
-	adminAPI.POST("chains/:chainID/deactivate", c.deactivateChain, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/deactivate", c.deactivateChain, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddResponse(http.StatusNotModified, "Chain was not deactivated", nil, nil).
 		AddResponse(http.StatusOK, "Chain was successfully deactivated", nil, nil).
 		SetOperationId("deactivateChain").
 		SetSummary("Deactivate a chain")
 
-	adminAPI.GET("chains/:chainID/committee", c.getCommitteeInfo, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains/:chainID/committee", c.getCommitteeInfo, authentication.ValidatePermissions([]string{permissions.ChainRead})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
 		AddResponse(http.StatusOK, "A list of all nodes tied to the chain", mocker.Get(models.CommitteeInfoResponse{}), nil).
 		SetOperationId("getCommitteeInfo").
 		SetSummary("Get information about the deployed committee")
 
-	adminAPI.GET("chains/:chainID/contracts", c.getContracts, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains/:chainID/contracts", c.getContracts, authentication.ValidatePermissions([]string{permissions.ChainRead})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
 		AddResponse(http.StatusOK, "A list of all available contracts", mocker.Get([]models.ContractInfoResponse{}), nil).
 		SetOperationId("getContracts").
 		SetSummary("Get all available chain contracts")
 
-	adminAPI.POST("chains/:chainID/chainrecord", c.setChainRecord, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/chainrecord", c.setChainRecord, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamBody(mocker.Get(models.ChainRecord{}), "ChainRecord", "Chain Record", true).
 		AddResponse(http.StatusCreated, "Chain record was saved", nil, nil).
 		SetSummary("Sets the chain record.").
 		SetOperationId("setChainRecord")
 
-	adminAPI.PUT("chains/:chainID/access-node/:peer", c.addAccessNode, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.PUT("chains/:chainID/access-node/:peer", c.addAccessNode, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusCreated, "Access node was successfully added", nil, nil).
 		SetSummary("Configure a trusted node to be an access node.").
 		SetOperationId("addAccessNode")
 
-	adminAPI.DELETE("chains/:chainID/access-node/:peer", c.removeAccessNode, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.DELETE("chains/:chainID/access-node/:peer", c.removeAccessNode, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusOK, "Access node was successfully removed", nil, nil).
 		SetSummary("Remove an access node.").
 		SetOperationId("removeAccessNode")
 
-	adminAPI.GET("chains/:chainID/mempool", c.getMempoolContents, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains/:chainID/mempool", c.getMempoolContents, authentication.ValidatePermissions([]string{permissions.ChainRead})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		SetResponseContentType("application/octet-stream").
 		AddResponse(http.StatusOK, "stream of JSON representation of the requests in the mempool", []byte{}, nil).
 		SetSummary("Get the contents of the mempool.").
 		SetOperationId("getMempoolContents")
 
-	adminAPI.POST("chains/:chainID/dump-accounts", c.dumpAccounts, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/dump-accounts", c.dumpAccounts, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddResponse(http.StatusOK, "Accounts dump will be produced", nil, nil).
 		SetOperationId("dump-accounts").
 		SetSummary("dump accounts information into a humanly-readable format")
+
+	adminAPI.POST("chains/:chainID/prune", c.pruneStates, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamBody(mocker.Get(models.PruneStatesRequest{}), "PruneStatesRequest", "The range of blocks to prune", true).
+		AddResponse(http.StatusOK, "The states were pruned", mocker.Get(models.PruneStatesResponse{}), nil).
+		AddResponse(http.StatusLocked, "Pruning is already in progress", nil, nil).
+		SetOperationId("pruneStates").
+		SetSummary("Prune the historical states of a block range")
 }
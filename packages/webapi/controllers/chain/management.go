@@ -1,10 +1,12 @@
 package chain
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/nnikolash/wasp-types-exported/packages/chainutil"
 	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
 	"github.com/nnikolash/wasp-types-exported/packages/registry"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/apierrors"
@@ -72,3 +74,32 @@ func (c *Controller) setChainRecord(e echo.Context) error {
 
 	return e.NoContent(http.StatusOK)
 }
+
+func (c *Controller) pruneStates(e echo.Context) error {
+	controllerutils.SetOperation(e, "prune_states")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	var request models.PruneStatesRequest
+	if err := e.Bind(&request); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	// the chains are pruned independently, but only once at a time each
+	if _, pruning := c.pruningChains.LoadOrStore(chainID, struct{}{}); pruning {
+		return e.String(http.StatusLocked, "pruning in progress")
+	}
+	defer c.pruningChains.Delete(chainID)
+
+	result, err := c.chainService.PruneStates(e.Request().Context(), chainID, request.FromBlockIndex, request.ToBlockIndex, request.Force)
+	if errors.Is(err, chainutil.ErrInvalidPruneRange) || errors.Is(err, chainutil.ErrArchiveChain) {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, models.MapPruneStatesResponse(result))
+}
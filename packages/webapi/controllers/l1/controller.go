@@ -0,0 +1,65 @@
+package l1
+
+import (
+	"net/http"
+
+	"github.com/pangpanglabs/echoswagger/v2"
+
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/interfaces"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/models"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/params"
+)
+
+// Controller exposes the in-process L1 ledger of the development mode, so that the clients
+// (e.g. wasp-cli) can use the node as their L1 node.
+type Controller struct {
+	l1Service interfaces.L1Service
+}
+
+func NewL1Controller(l1Service interfaces.L1Service) interfaces.APIController {
+	return &Controller{
+		l1Service: l1Service,
+	}
+}
+
+func (c *Controller) Name() string {
+	return "l1"
+}
+
+func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker interfaces.Mocker) {
+	publicAPI.GET("l1/info", c.getInfo).
+		AddResponse(http.StatusOK, "The L1 parameters of the ledger", mocker.Get(models.L1Params{}), nil).
+		SetOperationId("getL1Info").
+		SetSummary("Get the L1 parameters of the in-process ledger")
+
+	publicAPI.POST("l1/faucet/:address", c.requestFunds).
+		AddParamPath("", params.ParamAddress, params.DescriptionAddress).
+		AddResponse(http.StatusBadRequest, "Invalid address", nil, nil).
+		AddResponse(http.StatusOK, "The transaction sending the funds", mocker.Get(models.Transaction{}), nil).
+		SetOperationId("requestL1Funds").
+		SetSummary("Request base tokens from the faucet of the in-process ledger")
+
+	publicAPI.GET("l1/outputs/:address", c.getOutputs).
+		AddParamPath("", params.ParamAddress, params.DescriptionAddress).
+		AddResponse(http.StatusBadRequest, "Invalid address", nil, nil).
+		AddResponse(http.StatusOK, "The unspent outputs owned by the address", mocker.Get([]models.InOutput{}), nil).
+		SetOperationId("getL1Outputs").
+		SetSummary("Get the unspent outputs owned by an address in the in-process ledger")
+
+	publicAPI.GET("l1/aliases/:aliasID", c.getAliasOutput).
+		AddParamPath("", params.ParamAliasID, params.DescriptionAliasID).
+		AddResponse(http.StatusNotFound, "Alias output not found", nil, nil).
+		AddResponse(http.StatusOK, "The unspent alias output", mocker.Get(models.InOutput{}), nil).
+		SetOperationId("getL1AliasOutput").
+		SetSummary("Get the unspent alias output with the given alias ID from the in-process ledger")
+
+	publicAPI.POST("l1/transactions", c.postTransaction).
+		AddParamBody(mocker.Get(models.PostTransactionRequest{}), "", "The transaction to add to the ledger", true).
+		AddResponse(http.StatusBadRequest, "Invalid or rejected transaction", nil, nil).
+		AddResponse(http.StatusOK, "The transaction was added to the ledger", mocker.Get(models.Transaction{}), nil).
+		SetOperationId("postL1Transaction").
+		SetSummary("Add a transaction to the in-process ledger")
+}
+
+func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfaces.Mocker) {
+}
@@ -0,0 +1,72 @@
+package l1_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pangpanglabs/echoswagger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/l1connection"
+	"github.com/nnikolash/wasp-types-exported/packages/nodeconn/utxodbconn"
+	"github.com/nnikolash/wasp-types-exported/packages/testutil/testlogger"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/controllers/l1"
+)
+
+func TestDevClient(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	nc, err := utxodbconn.New(log, utxodbconn.Config{
+		MilestoneInterval: time.Second,
+		FaucetAmount:      10 * isc.Million,
+	})
+	require.NoError(t, err)
+
+	c := l1.NewL1Controller(nc)
+	e := echo.New()
+	server := echoswagger.New(e, "/doc", &echoswagger.Info{
+		Title:       "Test Wasp API",
+		Description: "Test REST API for the Wasp node",
+		Version:     "0",
+	})
+	group := server.Group(c.Name(), fmt.Sprintf("/v%d/", 1))
+	c.RegisterPublic(group, webapi.NewMocker())
+	httpServer := httptest.NewServer(e)
+	defer httpServer.Close()
+
+	client := l1connection.NewDevClient(httpServer.URL, log)
+	healthy, err := client.Health()
+	require.NoError(t, err)
+	require.True(t, healthy)
+
+	sender := cryptolib.NewKeyPair()
+	require.NoError(t, client.RequestFunds(sender.Address()))
+	outputs, err := client.OutputMap(sender.Address())
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+
+	recipient := cryptolib.NewKeyPair()
+	tx, err := l1connection.MakeSimpleValueTX(client, sender, recipient.Address(), 1*isc.Million)
+	require.NoError(t, err)
+	_, err = client.PostTxAndWaitUntilConfirmation(tx)
+	require.NoError(t, err)
+
+	outputs, err = client.OutputMapNonLocked(recipient.Address())
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	for _, output := range outputs {
+		require.EqualValues(t, 1*isc.Million, output.Deposit())
+	}
+
+	// the same tx can't be added twice
+	_, err = client.PostTxAndWaitUntilConfirmation(tx)
+	require.Error(t, err)
+
+	_, _, err = client.GetAliasOutput([32]byte{1})
+	require.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package l1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/apierrors"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/models"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/params"
+)
+
+func (c *Controller) getInfo(e echo.Context) error {
+	return e.JSON(http.StatusOK, models.MapL1Params(c.l1Service.GetL1Params()))
+}
+
+func (c *Controller) requestFunds(e echo.Context) error {
+	address, err := params.DecodeAddress(e)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.l1Service.RequestFunds(address)
+	if err != nil {
+		return apierrors.NewHTTPError(http.StatusInternalServerError, "failed to request funds", err)
+	}
+
+	return e.JSON(http.StatusOK, models.TransactionFromIotaGoTransaction(tx))
+}
+
+func (c *Controller) getOutputs(e echo.Context) error {
+	address, err := params.DecodeAddress(e)
+	if err != nil {
+		return err
+	}
+
+	outputs := c.l1Service.OutputMap(address)
+	result := make([]*models.InOutput, 0, len(outputs))
+	for outputID, output := range outputs {
+		result = append(result, &models.InOutput{
+			OutputID: outputID.ToHex(),
+			Output:   models.OutputFromIotaGoOutput(output),
+		})
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+func (c *Controller) getAliasOutput(e echo.Context) error {
+	aliasID, err := params.DecodeAliasID(e)
+	if err != nil {
+		return err
+	}
+
+	outputID, output, err := c.l1Service.GetAliasOutput(aliasID)
+	if err != nil {
+		return apierrors.NoRecordFoundError(err)
+	}
+
+	return e.JSON(http.StatusOK, &models.InOutput{
+		OutputID: outputID.ToHex(),
+		Output:   models.OutputFromIotaGoOutput(output),
+	})
+}
+
+func (c *Controller) postTransaction(e echo.Context) error {
+	var request models.PostTransactionRequest
+	if err := e.Bind(&request); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	txBytes, err := iotago.DecodeHex(request.Transaction)
+	if err != nil {
+		return apierrors.InvalidPropertyError("transaction", err)
+	}
+
+	tx := new(iotago.Transaction)
+	if _, err := tx.Deserialize(txBytes, serializer.DeSeriModePerformValidation, c.l1Service.GetL1Params().Protocol); err != nil {
+		return apierrors.InvalidPropertyError("transaction", err)
+	}
+
+	if err := c.l1Service.PostTx(tx); err != nil {
+		return apierrors.InvalidPropertyError("transaction", fmt.Errorf("transaction rejected: %w", err))
+	}
+
+	return e.JSON(http.StatusOK, models.TransactionFromIotaGoTransaction(tx))
+}
@@ -49,12 +49,12 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		SetOperationId("getInfo").
 		SetSummary("Returns private information about this node.")
 
-	adminAPI.GET("node/peers/trusted", c.getTrustedPeers, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/peers/trusted", c.getTrustedPeers, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "A list of trusted peers", mocker.Get([]models.PeeringNodeIdentityResponse{}), nil).
 		SetSummary("Get trusted peers").
 		SetOperationId("getTrustedPeers")
 
-	adminAPI.DELETE("node/peers/trusted/:peer", c.distrustPeer, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.DELETE("node/peers/trusted/:peer", c.distrustPeer, authentication.ValidatePermissions([]string{permissions.PeeringWrite})).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusNotFound, "Peer not found", nil, nil).
 		AddResponse(http.StatusOK, "Peer was successfully distrusted", nil, nil).
@@ -66,7 +66,7 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		SetSummary("Gets the node owner").
 		SetOperationId("ownerCertificate")
 
-	adminAPI.POST("node/peers/trusted", c.trustPeer, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("node/peers/trusted", c.trustPeer, authentication.ValidatePermissions([]string{permissions.PeeringWrite})).
 		AddParamBody(mocker.Get(models.PeeringTrustRequest{}), "", "Info of the peer to trust", true).
 		AddResponse(http.StatusOK, "Peer was successfully trusted", nil, nil).
 		SetSummary("Trust a peering node").
@@ -85,12 +85,19 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		SetSummary("Get information about the shared address DKS configuration").
 		SetOperationId("getDKSInfo")
 
-	adminAPI.GET("node/peers/identity", c.getIdentity, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.POST("node/dks/:sharedAddress/reshare", c.reshareDKS, authentication.ValidatePermissions([]string{permissions.Write})).
+		AddParamPath("", params.ParamSharedAddress, params.DescriptionSharedAddress).
+		AddParamBody(mocker.Get(models.DKSharesReshareRequest{}), "DKSharesReshareRequest", "Request parameters", true).
+		AddResponse(http.StatusOK, "DK shares info", mocker.Get(models.DKSharesInfo{}), nil).
+		SetSummary("Hand the distributed key over to a new committee, keeping the shared address").
+		SetOperationId("reshareDKS")
+
+	adminAPI.GET("node/peers/identity", c.getIdentity, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "This node peering identity", mocker.Get(models.PeeringNodeIdentityResponse{}), nil).
 		SetSummary("Get basic peer info of the current node").
 		SetOperationId("getPeeringIdentity")
 
-	adminAPI.GET("node/peers", c.getRegisteredPeers, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/peers", c.getRegisteredPeers, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "A list of all peers", mocker.Get([]models.PeeringNodeStatusResponse{}), nil).
 		SetSummary("Get basic information about all configured peers").
 		SetOperationId("getAllPeers")
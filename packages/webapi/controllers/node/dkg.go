@@ -27,6 +27,26 @@ func (c *Controller) generateDKS(e echo.Context) error {
 	return e.JSON(http.StatusOK, sharesInfo)
 }
 
+func (c *Controller) reshareDKS(e echo.Context) error {
+	_, sharedAddress, err := iotago.ParseBech32(e.Param(params.ParamSharedAddress))
+	if err != nil {
+		return apierrors.InvalidPropertyError(params.ParamSharedAddress, err)
+	}
+
+	reshareDKSRequest := models.DKSharesReshareRequest{}
+
+	if err = e.Bind(&reshareDKSRequest); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	sharesInfo, err := c.dkgService.ReshareDistributedKey(sharedAddress, reshareDKSRequest.PeerPubKeysOrNames, reshareDKSRequest.Threshold, time.Duration(reshareDKSRequest.TimeoutMS)*time.Millisecond)
+	if err != nil {
+		panic(err)
+	}
+
+	return e.JSON(http.StatusOK, sharesInfo)
+}
+
 func (c *Controller) getDKSInfo(e echo.Context) error {
 	_, sharedAddress, err := iotago.ParseBech32(e.Param(params.ParamSharedAddress))
 	if err != nil {
@@ -0,0 +1,107 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/nnikolash/wasp-types-exported/packages/authentication"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/apierrors"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/models"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/params"
+)
+
+func (c *Controller) getAPIKeys(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+
+	apiKeys, err := c.userService.GetAPIKeys(userName)
+	if err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+
+	return e.JSON(http.StatusOK, apiKeys)
+}
+
+func (c *Controller) addAPIKey(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+	authContext := e.Get("auth").(*authentication.AuthContext)
+
+	var addAPIKeyModel models.AddAPIKeyRequest
+
+	if err := e.Bind(&addAPIKeyModel); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	if addAPIKeyModel.Name == "" {
+		return apierrors.InvalidPropertyError("name", errors.New("name is empty"))
+	}
+
+	// A key without the permissions gets all the permissions of the user,
+	// so the caller must hold them, the same as to manage the user.
+	if err := c.validateTargetUser(authContext, userName); err != nil {
+		return err
+	}
+
+	if err := validatePermissions(authContext, addAPIKeyModel.Permissions); err != nil {
+		return err
+	}
+
+	key, err := c.userService.AddAPIKey(userName, addAPIKeyModel.Name, addAPIKeyModel.Permissions, addAPIKeyModel.ExpiresAt)
+	if err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	return e.JSON(http.StatusCreated, models.AddAPIKeyResponse{Key: key})
+}
+
+func (c *Controller) revokeUserToken(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+	authContext := e.Get("auth").(*authentication.AuthContext)
+	tokenID := e.Param(params.ParamTokenID)
+
+	if tokenID == "" {
+		return apierrors.InvalidPropertyError(params.ParamTokenID, errors.New("token ID is empty"))
+	}
+
+	if err := c.validateTargetUser(authContext, userName); err != nil {
+		return err
+	}
+
+	if err := c.userService.RevokeToken(userName, tokenID); err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+
+	return e.NoContent(http.StatusOK)
+}
+
+func (c *Controller) revokeUserTokens(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+	authContext := e.Get("auth").(*authentication.AuthContext)
+
+	if err := c.validateTargetUser(authContext, userName); err != nil {
+		return err
+	}
+
+	if err := c.userService.RevokeAllTokens(userName); err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+
+	return e.NoContent(http.StatusOK)
+}
+
+func (c *Controller) deleteAPIKey(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+	authContext := e.Get("auth").(*authentication.AuthContext)
+	apiKeyName := e.Param(params.ParamAPIKeyName)
+
+	if err := c.validateTargetUser(authContext, userName); err != nil {
+		return err
+	}
+
+	if err := c.userService.DeleteAPIKey(userName, apiKeyName); err != nil {
+		return apierrors.NoRecordFoundError(err)
+	}
+
+	return e.NoContent(http.StatusOK)
+}
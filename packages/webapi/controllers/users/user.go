@@ -7,19 +7,58 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/nnikolash/wasp-types-exported/packages/authentication"
+	"github.com/nnikolash/wasp-types-exported/packages/authentication/shared/permissions"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/apierrors"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/interfaces"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/models"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/params"
 )
 
+// validatePermissions checks that the permissions are valid and that the caller holds all of them,
+// so that nobody can grant more than they have.
+func validatePermissions(authContext *authentication.AuthContext, userPermissions []string) error {
+	for _, permission := range userPermissions {
+		if err := permissions.Validate(permission); err != nil {
+			return apierrors.InvalidPropertyError("permissions", err)
+		}
+		if !authContext.HasPermission(permission) {
+			return apierrors.PermissionNotHeldError(permission)
+		}
+	}
+
+	return nil
+}
+
+// validateTargetUser refuses to manage the users, which hold permissions the caller lacks.
+// Otherwise the caller could take over a more privileged user, e.g. by resetting its password.
+func (c *Controller) validateTargetUser(authContext *authentication.AuthContext, userName string) error {
+	user, err := c.userService.GetUser(userName)
+	if err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+
+	for _, permission := range user.Permissions {
+		if !authContext.HasPermission(permission) {
+			return apierrors.PermissionNotHeldError(permission)
+		}
+	}
+
+	return nil
+}
+
 func (c *Controller) addUser(e echo.Context) error {
+	authContext := e.Get("auth").(*authentication.AuthContext)
+
 	var addUserModel models.AddUserRequest
 
 	if err := e.Bind(&addUserModel); err != nil {
 		return apierrors.InvalidPropertyError("body", err)
 	}
 
+	if err := validatePermissions(authContext, addUserModel.Permissions); err != nil {
+		return err
+	}
+
 	if err := c.userService.AddUser(addUserModel.Username, addUserModel.Password, addUserModel.Permissions); err != nil {
 		panic(err)
 	}
@@ -29,11 +68,16 @@ func (c *Controller) addUser(e echo.Context) error {
 
 func (c *Controller) updateUserPassword(e echo.Context) error {
 	userName := e.Param(params.ParamUsername)
+	authContext := e.Get("auth").(*authentication.AuthContext)
 
 	if userName == "" {
 		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
 	}
 
+	if err := c.validateTargetUser(authContext, userName); err != nil {
+		return err
+	}
+
 	var updateUserPasswordModel models.UpdateUserPasswordRequest
 
 	if err := e.Bind(&updateUserPasswordModel); err != nil {
@@ -65,7 +109,14 @@ func (c *Controller) updateUserPermissions(e echo.Context) error {
 		return apierrors.InvalidPropertyError("body", err)
 	}
 
-	// TODO: Later on, compare the permissions with the permission Wasp actually uses.
+	if err := c.validateTargetUser(authContext, userName); err != nil {
+		return err
+	}
+
+	if err := validatePermissions(authContext, updateUserPermissionsModel.Permissions); err != nil {
+		return err
+	}
+
 	if err := c.userService.UpdateUserPermissions(userName, updateUserPermissionsModel.Permissions); err != nil {
 		return apierrors.UserNotFoundError(userName)
 	}
@@ -85,6 +136,10 @@ func (c *Controller) deleteUser(e echo.Context) error {
 		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
 	}
 
+	if err := c.validateTargetUser(authContext, userName); err != nil {
+		return err
+	}
+
 	if err := c.userService.DeleteUser(userName); err != nil {
 		if errors.Is(err, interfaces.ErrCantDeleteLastUser) {
 			return apierrors.UserCanNotBeDeleted(userName, err.Error())
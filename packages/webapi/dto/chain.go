@@ -2,14 +2,21 @@ package dto
 
 import (
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+	"github.com/nnikolash/wasp-types-exported/packages/trie"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/root"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/gas"
 )
 
 type (
-	ContractsMap map[isc.Hname]*root.ContractRecord
+	ContractsMap map[isc.Hname]*ContractInfo
 )
 
+type ContractInfo struct {
+	*root.ContractRecord
+	Retired bool
+}
+
 type PublicChainMetadata struct {
 	EVMJsonRPCURL   string `json:"evmJsonRpcUrl" swagger:"desc(The EVM json rpc url),required"`
 	EVMWebSocketURL string `json:"evmWebSocketUrl" swagger:"desc(The EVM websocket url)),required"`
@@ -49,3 +56,13 @@ func MapChainInfo(info *isc.ChainInfo, isActive bool) *ChainInfo {
 
 	return chainInfo
 }
+
+// StateProof is a value in the chain state together with the proof of it,
+// anchored to the L1 commitment of the given alias output
+type StateProof struct {
+	Key          []byte
+	Value        []byte
+	Proof        *trie.MerkleProof
+	L1Commitment *state.L1Commitment
+	AliasOutput  *isc.AliasOutputWithID
+}
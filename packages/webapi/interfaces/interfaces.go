@@ -9,9 +9,12 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pangpanglabs/echoswagger/v2"
 
+	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/nnikolash/wasp-types-exported/packages/chain"
+	"github.com/nnikolash/wasp-types-exported/packages/chainutil"
 	"github.com/nnikolash/wasp-types-exported/packages/cryptolib"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/parameters"
 	"github.com/nnikolash/wasp-types-exported/packages/registry"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/dto"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/models"
@@ -39,12 +42,15 @@ type ChainService interface {
 	GetContracts(chainID isc.ChainID, blockIndexOrTrieRoot string) (dto.ContractsMap, error)
 	GetEVMChainID(chainID isc.ChainID, blockIndexOrTrieRoot string) (uint16, error)
 	GetState(chainID isc.ChainID, stateKey []byte) (state []byte, err error)
+	GetStateProof(chainID isc.ChainID, stateKey []byte) (*dto.StateProof, error)
+	PruneStates(ctx context.Context, chainID isc.ChainID, fromIndex, toIndex uint32, force bool) (*chainutil.PruneResult, error)
 	WaitForRequestProcessed(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID, waitForL1Confirmation bool, timeout time.Duration) (*isc.Receipt, error)
 }
 
 type EVMService interface {
 	HandleJSONRPC(chainID isc.ChainID, request *http.Request, response *echo.Response) error
 	HandleWebsocket(ctx context.Context, chainID isc.ChainID, echoCtx echo.Context) error
+	Close()
 }
 
 type MetricsService interface {
@@ -94,6 +100,20 @@ type UserService interface {
 	GetUsers() []*models.User
 	UpdateUserPassword(username string, password string) error
 	UpdateUserPermissions(username string, permissions []string) error
+	AddAPIKey(username string, name string, permissions []string, expiresAt *time.Time) (string, error)
+	DeleteAPIKey(username string, name string) error
+	GetAPIKeys(username string) ([]*models.APIKeyResponse, error)
+	RevokeToken(username string, tokenID string) error
+	RevokeAllTokens(username string) error
+}
+
+// L1Service is implemented by the in-process L1 ledger, which replaces the L1 node in the development mode.
+type L1Service interface {
+	GetL1Params() *parameters.L1Params
+	RequestFunds(addr iotago.Address) (*iotago.Transaction, error)
+	PostTx(tx *iotago.Transaction) error
+	OutputMap(addr iotago.Address) iotago.OutputSet
+	GetAliasOutput(aliasID iotago.AliasID) (iotago.OutputID, *iotago.AliasOutput, error)
 }
 
 type Mocker interface {
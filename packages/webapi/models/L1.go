@@ -165,3 +165,7 @@ func OutputIDFromIotaGoOutputID(outputID iotago.OutputID) *OutputID {
 		OutputID: outputID.ToHex(),
 	}
 }
+
+type PostTransactionRequest struct {
+	Transaction string `json:"transaction" swagger:"desc(The serialized transaction (Hex)),required"`
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"net/url"
 
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/packages/chainutil"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/gas"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/dto"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/routes"
@@ -50,6 +52,7 @@ type ContractInfoResponse struct {
 	HName       string `json:"hName" swagger:"desc(The id (HName as Hex)) of the contract.),required"`
 	Name        string `json:"name" swagger:"desc(The name of the contract.),required"`
 	ProgramHash string `json:"programHash" swagger:"desc(The hash of the contract. (Hex encoded)),required"`
+	Retired     bool   `json:"retired" swagger:"desc(Whether the contract is retired and rejects further calls.),required"`
 }
 
 type PublicChainMetadata struct {
@@ -77,6 +80,48 @@ type StateResponse struct {
 	State string `json:"state" swagger:"desc(The state of the requested key (Hex-encoded)),required"`
 }
 
+type StateProofResponse struct {
+	Key          string `json:"key" swagger:"desc(The requested key (Hex-encoded)),required"`
+	Value        string `json:"value" swagger:"desc(The value of the requested key, empty if absent (Hex-encoded)),required"`
+	Proof        string `json:"proof" swagger:"desc(The Merkle proof of the key/value, or of its absence (Hex-encoded)),required"`
+	TrieRoot     string `json:"trieRoot" swagger:"desc(The trie root the proof is verified against (Hex-encoded)),required"`
+	L1Commitment string `json:"l1Commitment" swagger:"desc(The L1 commitment containing the trie root (Hex-encoded)),required"`
+	AliasOutput  string `json:"aliasOutput" swagger:"desc(The confirmed alias output (with its ID) containing the L1 commitment (Hex-encoded)),required"`
+}
+
+func MapStateProofResponse(proof *dto.StateProof) *StateProofResponse {
+	return &StateProofResponse{
+		Key:          iotago.EncodeHex(proof.Key),
+		Value:        iotago.EncodeHex(proof.Value),
+		Proof:        iotago.EncodeHex(proof.Proof.Bytes()),
+		TrieRoot:     iotago.EncodeHex(proof.L1Commitment.TrieRoot().Bytes()),
+		L1Commitment: iotago.EncodeHex(proof.L1Commitment.Bytes()),
+		AliasOutput:  iotago.EncodeHex(proof.AliasOutput.Bytes()),
+	}
+}
+
+type PruneStatesRequest struct {
+	FromBlockIndex uint32 `json:"fromBlockIndex" swagger:"desc(The index of the first block to prune; must not be above the oldest available block),required,min(0)"`
+	ToBlockIndex   uint32 `json:"toBlockIndex" swagger:"desc(The index of the last block to prune),required,min(0)"`
+	Force          bool   `json:"force" swagger:"desc(Prune the states even if the chain keeps all the blocks),required"`
+}
+
+type PruneStatesResponse struct {
+	PrunedBlocks     uint32 `json:"prunedBlocks" swagger:"desc(The number of pruned states),required,min(0)"`
+	OldestBlockIndex uint32 `json:"oldestBlockIndex" swagger:"desc(The index of the oldest state still available),required,min(0)"`
+	DeletedNodes     uint32 `json:"deletedNodes" swagger:"desc(The number of deleted trie nodes),required,min(0)"`
+	DeletedValues    uint32 `json:"deletedValues" swagger:"desc(The number of deleted trie values),required,min(0)"`
+}
+
+func MapPruneStatesResponse(result *chainutil.PruneResult) *PruneStatesResponse {
+	return &PruneStatesResponse{
+		PrunedBlocks:     result.PrunedBlocks,
+		OldestBlockIndex: result.OldestBlockIndex,
+		DeletedNodes:     uint32(result.DeletedNodes),
+		DeletedValues:    uint32(result.DeletedValues),
+	}
+}
+
 func mapMetadataUrls(response *ChainInfoResponse) {
 	if response.PublicURL == "" {
 		return
@@ -7,6 +7,13 @@ type DKSharesPostRequest struct {
 	TimeoutMS          uint32   `json:"timeoutMS" swagger:"desc(Timeout in milliseconds.),required,min(1)"`
 }
 
+// DKSharesReshareRequest is a POST request for handing an existing DKShare over to a new committee.
+type DKSharesReshareRequest struct {
+	PeerPubKeysOrNames []string `json:"peerIdentities" swagger:"desc(Names or hex encoded public keys of trusted peers of the new committee.),required"`
+	Threshold          uint16   `json:"threshold" swagger:"desc(Should be =< len(PeerPublicIdentities)),required,min(1)"`
+	TimeoutMS          uint32   `json:"timeoutMS" swagger:"desc(Timeout in milliseconds.),required,min(1)"`
+}
+
 // DKSharesInfo stands for the DKShare representation, returned by the GET and POST methods.
 type DKSharesInfo struct {
 	Address         string   `json:"address" swagger:"desc(New generated shared address.),required"`
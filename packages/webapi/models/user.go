@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type User struct {
 	Username    string   `json:"username" swagger:"required"`
 	Permissions []string `json:"permissions" swagger:"required"`
@@ -18,3 +20,20 @@ type UpdateUserPasswordRequest struct {
 type UpdateUserPermissionsRequest struct {
 	Permissions []string `json:"permissions" swagger:"required"`
 }
+
+type APIKeyResponse struct {
+	Name        string     `json:"name" swagger:"required"`
+	Permissions []string   `json:"permissions" swagger:"desc(The permissions of the key; empty means all the permissions of the user),required"`
+	CreatedAt   time.Time  `json:"createdAt" swagger:"required"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" swagger:"desc(Not set, if the key never expires)"`
+}
+
+type AddAPIKeyRequest struct {
+	Name        string     `json:"name" swagger:"required"`
+	Permissions []string   `json:"permissions" swagger:"desc(A subset of the permissions of the user; empty means all the permissions of the user),required"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" swagger:"desc(Optional expiry time of the key)"`
+}
+
+type AddAPIKeyResponse struct {
+	Key string `json:"key" swagger:"desc(The API key; it is only returned once and has to be sent in the X-API-Key header),required"`
+}
@@ -1,6 +1,7 @@
 package params
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
@@ -75,6 +76,31 @@ func DecodeNFTID(e echo.Context) (*iotago.NFTID, error) {
 	return &nftID, nil
 }
 
+func DecodeAddress(e echo.Context) (iotago.Address, error) {
+	_, address, err := iotago.ParseBech32(e.Param(ParamAddress))
+	if err != nil {
+		return nil, apierrors.InvalidPropertyError(ParamAddress, err)
+	}
+
+	return address, nil
+}
+
+func DecodeAliasID(e echo.Context) (iotago.AliasID, error) {
+	aliasIDBytes, err := iotago.DecodeHex(e.Param(ParamAliasID))
+	if err != nil {
+		return iotago.AliasID{}, apierrors.InvalidPropertyError(ParamAliasID, err)
+	}
+
+	if len(aliasIDBytes) != iotago.AliasIDLength {
+		return iotago.AliasID{}, apierrors.InvalidPropertyError(ParamAliasID, fmt.Errorf("invalid length: %d", len(aliasIDBytes)))
+	}
+
+	var aliasID iotago.AliasID
+	copy(aliasID[:], aliasIDBytes)
+
+	return aliasID, nil
+}
+
 func DecodeBlobHash(e echo.Context) (*hashing.HashValue, error) {
 	blobHash, err := hashing.HashValueFromHex(e.Param(ParamBlobHash))
 	if err != nil {
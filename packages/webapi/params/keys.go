@@ -1,7 +1,10 @@
 package params
 
 const (
+	ParamAddress              = "address"
 	ParamAgentID              = "agentID"
+	ParamAliasID              = "aliasID"
+	ParamAPIKeyName           = "apiKeyName"
 	ParamBlobHash             = "blobHash"
 	ParamBlockIndex           = "blockIndex"
 	ParamChainID              = "chainID"
@@ -13,13 +16,17 @@ const (
 	ParamRequestID            = "requestID"
 	ParamSharedAddress        = "sharedAddress"
 	ParamStateKey             = "stateKey"
+	ParamTokenID              = "tokenID"
 	ParamTxHash               = "txHash"
 	ParamUsername             = "username"
 	ParamBlockIndexOrTrieRoot = "block"
 )
 
 const (
+	DescriptionAddress              = "Address (Bech32)"
 	DescriptionAgentID              = "AgentID (Bech32 for WasmVM | Hex for EVM)"
+	DescriptionAliasID              = "AliasID (Hex)"
+	DescriptionAPIKeyName           = "The name of the API key"
 	DescriptionBlobHash             = "BlobHash (Hex)"
 	DescriptionChainID              = "ChainID (Bech32)"
 	DescriptionContractHName        = "The contract hname (Hex)"
@@ -29,6 +36,7 @@ const (
 	DescriptionRequestID            = "RequestID (Hex)"
 	DescriptionSharedAddress        = "SharedAddress (Bech32)"
 	DescriptionStateKey             = "State Key (Hex)"
+	DescriptionTokenID              = "The ID of the JWT (jti claim)"
 	DescriptionTxHash               = "Transaction hash (Hex)"
 	DescriptionUsername             = "The username"
 	DescriptionBlockIndexOrTrieRoot = "Block index or trie root"
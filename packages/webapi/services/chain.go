@@ -8,12 +8,14 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	chainpkg "github.com/nnikolash/wasp-types-exported/packages/chain"
 	"github.com/nnikolash/wasp-types-exported/packages/chains"
+	"github.com/nnikolash/wasp-types-exported/packages/chainutil"
 	"github.com/nnikolash/wasp-types-exported/packages/isc"
 	"github.com/nnikolash/wasp-types-exported/packages/kv"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
 	"github.com/nnikolash/wasp-types-exported/packages/kv/collections"
 	"github.com/nnikolash/wasp-types-exported/packages/metrics"
 	"github.com/nnikolash/wasp-types-exported/packages/registry"
+	"github.com/nnikolash/wasp-types-exported/packages/trie"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/evm"
 	"github.com/nnikolash/wasp-types-exported/packages/vm/core/root"
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/apierrors"
@@ -23,6 +25,8 @@ import (
 	"github.com/nnikolash/wasp-types-exported/packages/webapi/interfaces"
 )
 
+const pruneProgressLogPeriod = 1000
+
 type ChainService struct {
 	log                         *logger.Logger
 	chainsProvider              chains.Provider
@@ -185,11 +189,24 @@ func (c *ChainService) GetContracts(chainID isc.ChainID, blockIndexOrTrieRoot st
 		return nil, err
 	}
 
-	contracts, err := root.DecodeContractRegistry(collections.NewMapReadOnly(recs, root.VarContractRegistry))
+	contractRecords, err := root.DecodeContractRegistry(collections.NewMapReadOnly(recs, root.VarContractRegistry))
+	if err != nil {
+		return nil, err
+	}
+
+	retiredContracts, err := root.DecodeRetiredContracts(collections.NewMapReadOnly(recs, root.VarRetiredContracts))
 	if err != nil {
 		return nil, err
 	}
 
+	contracts := make(dto.ContractsMap, len(contractRecords))
+	for hname, rec := range contractRecords {
+		contracts[hname] = &dto.ContractInfo{
+			ContractRecord: rec,
+			Retired:        retiredContracts[hname],
+		}
+	}
+
 	return contracts, nil
 }
 
@@ -207,6 +224,36 @@ func (c *ChainService) GetState(chainID isc.ChainID, stateKey []byte) (state []b
 	return latestState.Get(kv.Key(stateKey)), nil
 }
 
+func (c *ChainService) GetStateProof(chainID isc.ChainID, stateKey []byte) (*dto.StateProof, error) {
+	ch, err := c.GetChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.GetStateProof(ch, stateKey)
+}
+
+func (c *ChainService) PruneStates(ctx context.Context, chainID isc.ChainID, fromIndex, toIndex uint32, force bool) (*chainutil.PruneResult, error) {
+	ch, err := c.GetChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log.Infof("Pruning states %d-%d of chain %s", fromIndex, toIndex, chainID)
+	start := time.Now()
+	result, err := chainutil.PruneStates(ctx, ch.Store(), fromIndex, toIndex, force, func(blockIndex uint32, stats trie.PruneStats) {
+		c.log.Debugf("Pruned state %d of chain %s: %d nodes and %d values deleted", blockIndex, chainID, stats.DeletedNodes, stats.DeletedValues)
+		if blockIndex%pruneProgressLogPeriod == 0 {
+			c.log.Infof("Pruning states of chain %s: up to %d of %d pruned", chainID, blockIndex, toIndex)
+		}
+	})
+	if result != nil {
+		c.log.Infof("Pruning states of chain %s completed in %v: %d states, %d nodes and %d values deleted, oldest state is %d",
+			chainID, time.Since(start), result.PrunedBlocks, result.DeletedNodes, result.DeletedValues, result.OldestBlockIndex)
+	}
+	return result, err
+}
+
 func (c *ChainService) WaitForRequestProcessed(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID, waitForL1Confirmation bool, timeout time.Duration) (*isc.Receipt, error) {
 	ch, err := c.GetChainByID(chainID)
 	if err != nil {
@@ -56,6 +56,28 @@ func (d *DKGService) GenerateDistributedKey(peerPubKeysOrNames []string, thresho
 	return dkShareInfo, nil
 }
 
+func (d *DKGService) ReshareDistributedKey(sharedAddress iotago.Address, peerPubKeysOrNames []string, threshold uint16, timeout time.Duration) (*models.DKSharesInfo, error) {
+	trustedPeers, err := d.trustedNetworkManager.TrustedPeersByPubKeyOrName(peerPubKeysOrNames)
+	if err != nil {
+		return nil, err
+	}
+	peerPubKeys := lo.Map(trustedPeers, func(tp *peering.TrustedPeer, _ int) *cryptolib.PublicKey {
+		return tp.PubKey()
+	})
+
+	dkShare, err := d.dkgNodeProvider().ReshareDistributedKey(sharedAddress, peerPubKeys, threshold, roundRetry, stepRetry, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	dkShareInfo, err := d.createDKModel(dkShare)
+	if err != nil {
+		return nil, err
+	}
+
+	return dkShareInfo, nil
+}
+
 func (d *DKGService) GetShares(sharedAddress iotago.Address) (*models.DKSharesInfo, error) {
 	dkShare, err := d.dkShareRegistryProvider.LoadDKShare(sharedAddress)
 	if err != nil {
@@ -21,8 +21,9 @@ import (
 )
 
 type chainServer struct {
-	backend *jsonrpc.WaspEVMBackend
-	rpc     *rpc.Server
+	backend  *jsonrpc.WaspEVMBackend
+	evmChain *jsonrpc.EVMChain
+	rpc      *rpc.Server
 }
 
 type EVMService struct {
@@ -84,24 +85,39 @@ func (e *EVMService) getEVMBackend(chainID isc.ChainID) (*chainServer, error) {
 	nodePubKey := e.networkProvider.Self().PubKey()
 	backend := jsonrpc.NewWaspEVMBackend(chain, nodePubKey, parameters.L1().BaseToken)
 
+	evmChain := jsonrpc.NewEVMChain(backend, e.publisher, e.chainsProvider().IsArchiveNode(), hivedb.EngineRocksDB, e.indexDbPath, e.log.Named("EVMChain"))
 	srv, err := jsonrpc.NewServer(
-		jsonrpc.NewEVMChain(backend, e.publisher, e.chainsProvider().IsArchiveNode(), hivedb.EngineRocksDB, e.indexDbPath, e.log.Named("EVMChain")),
+		evmChain,
 		jsonrpc.NewAccountManager(nil),
 		e.metrics.GetChainMetrics(chainID).WebAPI,
 		e.jsonrpcParams,
 	)
 	if err != nil {
+		evmChain.Close()
 		return nil, err
 	}
 
 	e.evmChainServers[chainID] = &chainServer{
-		backend: backend,
-		rpc:     srv,
+		backend:  backend,
+		evmChain: evmChain,
+		rpc:      srv,
 	}
 
 	return e.evmChainServers[chainID], nil
 }
 
+// Close stops the JSON-RPC servers and closes the EVM chains with their indexes
+func (e *EVMService) Close() {
+	e.evmBackendMutex.Lock()
+	defer e.evmBackendMutex.Unlock()
+
+	for chainID, server := range e.evmChainServers {
+		server.rpc.Stop()
+		server.evmChain.Close()
+		delete(e.evmChainServers, chainID)
+	}
+}
+
 func (e *EVMService) HandleJSONRPC(chainID isc.ChainID, request *http.Request, response *echo.Response) error {
 	evmServer, err := e.getEVMBackend(chainID)
 	if err != nil {
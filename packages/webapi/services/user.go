@@ -1,6 +1,9 @@
 package services
 
 import (
+	"sort"
+	"time"
+
 	"golang.org/x/exp/maps"
 
 	"github.com/nnikolash/wasp-types-exported/packages/users"
@@ -98,3 +101,66 @@ func (u *UserService) GetUser(username string) (*models.User, error) {
 		Permissions: permissionsFromMap(user.Permissions),
 	}, nil
 }
+
+func (u *UserService) AddAPIKey(username, name string, permissions []string, expiresAt *time.Time) (string, error) {
+	key, keyHash, err := users.GenerateAPIKey()
+	if err != nil {
+		return "", err
+	}
+
+	apiKey := &users.APIKey{
+		Name:        name,
+		KeyHash:     keyHash,
+		Permissions: permissionsToMap(permissions),
+		CreatedAt:   time.Now(),
+	}
+	if expiresAt != nil {
+		apiKey.ExpiresAt = *expiresAt
+	}
+
+	if err := u.userManager.AddAPIKey(username, apiKey); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (u *UserService) DeleteAPIKey(username, name string) error {
+	return u.userManager.RemoveAPIKey(username, name)
+}
+
+// RevokeToken revokes a JWT of the user by its ID. The expiry of the token is unknown here,
+// so it is kept in the revocation list until all the tokens of the user are revoked.
+func (u *UserService) RevokeToken(username, tokenID string) error {
+	return u.userManager.RevokeToken(username, tokenID, time.Time{})
+}
+
+func (u *UserService) RevokeAllTokens(username string) error {
+	return u.userManager.RevokeAllTokens(username)
+}
+
+func (u *UserService) GetAPIKeys(username string) ([]*models.APIKeyResponse, error) {
+	user, err := u.userManager.User(username)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyModels := make([]*models.APIKeyResponse, 0, len(user.APIKeys))
+	for _, apiKey := range user.APIKeys {
+		apiKeyModel := &models.APIKeyResponse{
+			Name:        apiKey.Name,
+			Permissions: permissionsFromMap(apiKey.Permissions),
+			CreatedAt:   apiKey.CreatedAt,
+		}
+		if !apiKey.ExpiresAt.IsZero() {
+			expiresAt := apiKey.ExpiresAt
+			apiKeyModel.ExpiresAt = &expiresAt
+		}
+		apiKeyModels = append(apiKeyModels, apiKeyModel)
+	}
+	sort.Slice(apiKeyModels, func(i, j int) bool {
+		return apiKeyModels[i].Name < apiKeyModels[j].Name
+	})
+
+	return apiKeyModels, nil
+}
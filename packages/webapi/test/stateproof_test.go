@@ -0,0 +1,81 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
+	"github.com/nnikolash/wasp-types-exported/clients/apiextensions"
+	"github.com/nnikolash/wasp-types-exported/packages/isc/coreutil"
+	"github.com/nnikolash/wasp-types-exported/packages/solo"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/common"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/models"
+)
+
+func getStateProofResponse(t *testing.T, chain *solo.Chain, key []byte) *apiclient.StateProofResponse {
+	proof, err := common.GetStateProof(chain, key)
+	require.NoError(t, err)
+
+	// round-trip through JSON, as a client would receive it
+	jsonBytes, err := json.Marshal(models.MapStateProofResponse(proof))
+	require.NoError(t, err)
+	var resp apiclient.StateProofResponse
+	require.NoError(t, json.Unmarshal(jsonBytes, &resp))
+	return &resp
+}
+
+func TestStateProof(t *testing.T) {
+	env := solo.New(t)
+	chain := env.NewChain()
+
+	t.Run("inclusion", func(t *testing.T) {
+		resp := getStateProofResponse(t, chain, []byte(coreutil.StatePrefixBlockIndex))
+		require.NotEmpty(t, resp.Value)
+
+		ao, err := apiextensions.VerifyStateProof(resp)
+		require.NoError(t, err)
+		l1ao, err := chain.LatestAliasOutput(0)
+		require.NoError(t, err)
+		require.Equal(t, l1ao.OutputID(), ao.OutputID())
+
+		tampered := *resp
+		tampered.Value = iotago.EncodeHex([]byte{1, 2, 3})
+		_, err = apiextensions.VerifyStateProof(&tampered)
+		require.Error(t, err)
+
+		tampered = *resp
+		tampered.Value = ""
+		_, err = apiextensions.VerifyStateProof(&tampered)
+		require.Error(t, err)
+
+		tampered = *resp
+		tampered.Key = iotago.EncodeHex([]byte("other key"))
+		_, err = apiextensions.VerifyStateProof(&tampered)
+		require.Error(t, err)
+	})
+
+	t.Run("absence", func(t *testing.T) {
+		resp := getStateProofResponse(t, chain, []byte("no such key"))
+		require.Empty(t, resp.Value)
+		_, err := apiextensions.VerifyStateProof(resp)
+		require.NoError(t, err)
+
+		tampered := *resp
+		tampered.Value = iotago.EncodeHex([]byte{1})
+		_, err = apiextensions.VerifyStateProof(&tampered)
+		require.Error(t, err)
+	})
+
+	t.Run("wrong anchor", func(t *testing.T) {
+		resp := getStateProofResponse(t, chain, []byte(coreutil.StatePrefixBlockIndex))
+		other := getStateProofResponse(t, env.NewChain(), []byte(coreutil.StatePrefixBlockIndex))
+
+		tampered := *resp
+		tampered.AliasOutput = other.AliasOutput
+		_, err := apiextensions.VerifyStateProof(&tampered)
+		require.Error(t, err)
+	})
+}
@@ -27,6 +27,7 @@ var (
 	ErrFailedToDeserializeCommand = errors.New("failed to deserialize command")
 	ErrFailedToValidateCommand    = errors.New("failed to validate command")
 	ErrFailedToSendMessage        = errors.New("failed to send message")
+	ErrFailedToReplayEvents       = errors.New("failed to replay events")
 )
 
 type CommandHandler interface {
@@ -40,14 +41,19 @@ type CommandManager struct {
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
 }
 
-func NewCommandHandler(log *logger.Logger, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]) *CommandManager {
+func NewCommandHandler(
+	log *logger.Logger,
+	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string],
+	subscriptionListener SubscriptionListener,
+) *CommandManager {
 	return &CommandManager{
 		log: log,
 		commands: []CommandHandler{
 			// Register new commands here
 			&SubscriptionCommandHandler{
-				log:                 log,
-				subscriptionManager: subscriptionManager,
+				log:                  log,
+				subscriptionManager:  subscriptionManager,
+				subscriptionListener: subscriptionListener,
 			},
 		},
 		subscriptionManager: subscriptionManager,
@@ -64,6 +70,8 @@ func (c *CommandManager) handleError(err error, client *websockethub.Client, com
 		c.log.Warnf("Failed to send event to client:[%d], command:[%s], err:[%v]", client.ID(), commandType, unwrappedError)
 	case errors.Is(err, ErrFailedToValidateCommand):
 		c.log.Warnf("Failed to validate received command from client:[%d], command:[%s], err:[%v]", client.ID(), commandType, unwrappedError)
+	case errors.Is(err, ErrFailedToReplayEvents):
+		c.log.Warnf("Failed to replay events to client:[%d], command:[%s], err:[%v]", client.ID(), commandType, unwrappedError)
 	default:
 		c.log.Warnf("Unhandled error in websocket command handler for client:[%d], command:[%s], err:[%v]", client.ID(), commandType, err)
 	}
@@ -18,11 +18,25 @@ const (
 type SubscriptionCommand struct {
 	BaseCommand
 	Topic string `json:"topic"`
+	// FromBlockIndex can only be set when subscribing to a single chain (`chains/<chainID>`).
+	// The events of the blocks starting with this index are sent before the live events.
+	FromBlockIndex *uint32 `json:"fromBlockIndex,omitempty"`
+	// Filter can only be set when subscribing to the chains (`chains` or `chains/<chainID>`).
+	// Only the events matching the filter are sent.
+	Filter *EventFilter `json:"filter,omitempty"`
+}
+
+// EventFilter restricts the events sent to the client. Empty fields match all the events.
+type EventFilter struct {
+	Contract string `json:"contract,omitempty"` // Hname of the target contract of a receipt or of the contract that issued an event
+	Topic    string `json:"topic,omitempty"`    // Topic of an event issued by a contract; receipts do not match, if it is set
+	Sender   string `json:"sender,omitempty"`   // AgentID of the sender of a request; events do not match, if it is set
 }
 
 const (
 	EventClientWasSubscribed   EventType = "subscribed"
 	EventClientWasUnsubscribed EventType = "unsubscribed"
+	EventEventsReplayed        EventType = "replayed"
 )
 
 type SubscriptionEvent struct {
@@ -30,9 +44,29 @@ type SubscriptionEvent struct {
 	Topic string `json:"topic"`
 }
 
+// ReplayEvent is sent after the events of the past blocks were sent to the client.
+type ReplayEvent struct {
+	BaseEvent
+	Topic          string `json:"topic"`
+	FromBlockIndex uint32 `json:"fromBlockIndex"`
+	ToBlockIndex   uint32 `json:"toBlockIndex"`
+}
+
+// SubscriptionListener applies the filters and replays the past events of the subscriptions.
+type SubscriptionListener interface {
+	// BeforeSubscribe validates the subscription; the live events of a chain,
+	// which is going to be replayed, must be withheld from this moment on.
+	BeforeSubscribe(client *websockethub.Client, command *SubscriptionCommand) error
+	// AfterSubscribe replays the past events followed by ReplayEvent, if requested,
+	// and releases the withheld live events.
+	AfterSubscribe(client *websockethub.Client, command *SubscriptionCommand) error
+	AfterUnsubscribe(client *websockethub.Client, topic string)
+}
+
 type SubscriptionCommandHandler struct {
-	log                 *logger.Logger
-	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
+	log                  *logger.Logger
+	subscriptionManager  *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
+	subscriptionListener SubscriptionListener
 }
 
 func (s *SubscriptionCommandHandler) SupportsCommand(commandType CommandType) bool {
@@ -53,6 +87,14 @@ func (s *SubscriptionCommandHandler) HandleCommand(client *websockethub.Client,
 
 	switch command.Command {
 	case CommandSubscribe:
+		if s.subscriptionListener == nil {
+			if command.FromBlockIndex != nil || command.Filter != nil {
+				return errors.Wrap(ErrFailedToValidateCommand, "Replaying and filtering events is not supported")
+			}
+		} else if err = s.subscriptionListener.BeforeSubscribe(client, &command); err != nil {
+			return errors.Wrap(ErrFailedToValidateCommand, err.Error())
+		}
+
 		s.subscriptionManager.Subscribe(client.ID(), command.Topic)
 		err = client.Send(client.Context(), SubscriptionEvent{
 			BaseEvent: BaseEvent{
@@ -61,8 +103,18 @@ func (s *SubscriptionCommandHandler) HandleCommand(client *websockethub.Client,
 			Topic: command.Topic,
 		})
 
+		if s.subscriptionListener != nil {
+			// the withheld live events must be released even if the client was not notified
+			if replayErr := s.subscriptionListener.AfterSubscribe(client, &command); replayErr != nil {
+				return errors.Wrap(ErrFailedToReplayEvents, replayErr.Error())
+			}
+		}
+
 	case CommandUnsubscribe:
 		s.subscriptionManager.Unsubscribe(client.ID(), command.Topic)
+		if s.subscriptionListener != nil {
+			s.subscriptionListener.AfterUnsubscribe(client, command.Topic)
+		}
 		err = client.Send(client.Context(), SubscriptionEvent{
 			BaseEvent: BaseEvent{
 				Event: EventClientWasUnsubscribed,
@@ -0,0 +1,83 @@
+package websocket
+
+import (
+	"github.com/samber/lo"
+
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/publisher"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/websocket/commands"
+)
+
+// eventFilter restricts the events sent to a client, see commands.EventFilter.
+// The new block events are never filtered out.
+type eventFilter struct {
+	contract *isc.Hname
+	topic    string
+	sender   isc.AgentID
+}
+
+func newEventFilter(filter *commands.EventFilter) (*eventFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	result := &eventFilter{topic: filter.Topic}
+	if filter.Contract != "" {
+		contract, err := isc.HnameFromString(filter.Contract)
+		if err != nil {
+			return nil, err
+		}
+		result.contract = &contract
+	}
+	if filter.Sender != "" {
+		sender, err := isc.AgentIDFromString(filter.Sender)
+		if err != nil {
+			return nil, err
+		}
+		result.sender = sender
+	}
+
+	return result, nil
+}
+
+// apply returns the part of the event matching the filter, or nil if nothing matches.
+func (f *eventFilter) apply(iscEvent *ISCEvent) *ISCEvent {
+	if f == nil {
+		return iscEvent
+	}
+
+	switch iscEvent.Kind {
+	case publisher.ISCEventKindReceipt:
+		if f.topic != "" {
+			return nil
+		}
+		if f.contract != nil && iscEvent.contract != *f.contract {
+			return nil
+		}
+		if f.sender != nil && (iscEvent.sender == nil || !iscEvent.sender.Equals(f.sender)) {
+			return nil
+		}
+		return iscEvent
+
+	case publisher.ISCEventKindBlockEvents:
+		if f.sender != nil {
+			return nil
+		}
+		events, ok := iscEvent.Payload.([]*isc.Event)
+		if !ok {
+			return iscEvent
+		}
+		events = lo.Filter(events, func(event *isc.Event, _ int) bool {
+			return (f.contract == nil || event.ContractID == *f.contract) && (f.topic == "" || event.Topic == f.topic)
+		})
+		if len(events) == 0 {
+			return nil
+		}
+		filtered := *iscEvent
+		filtered.Payload = events
+		return &filtered
+
+	default:
+		return iscEvent
+	}
+}
@@ -0,0 +1,62 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/publisher"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/websocket/commands"
+)
+
+func TestEventFilter(t *testing.T) {
+	contract := isc.Hn("contract")
+	sender := isc.NewRandomAgentID()
+
+	receipt := &ISCEvent{Kind: publisher.ISCEventKindReceipt, contract: contract, sender: sender}
+	otherReceipt := &ISCEvent{Kind: publisher.ISCEventKindReceipt, contract: isc.Hn("other"), sender: isc.NewRandomAgentID()}
+	newBlock := &ISCEvent{Kind: publisher.ISCEventKindNewBlock}
+	blockEvents := &ISCEvent{Kind: publisher.ISCEventKindBlockEvents, Payload: []*isc.Event{
+		{ContractID: contract, Topic: "a"},
+		{ContractID: contract, Topic: "b"},
+		{ContractID: isc.Hn("other"), Topic: "a"},
+	}}
+
+	// no filter matches everything
+	filter, err := newEventFilter(nil)
+	require.NoError(t, err)
+	require.Same(t, receipt, filter.apply(receipt))
+	require.Same(t, blockEvents, filter.apply(blockEvents))
+
+	filter, err = newEventFilter(&commands.EventFilter{Contract: contract.String()})
+	require.NoError(t, err)
+	require.Same(t, receipt, filter.apply(receipt))
+	require.Nil(t, filter.apply(otherReceipt))
+	require.Same(t, newBlock, filter.apply(newBlock))
+	filtered := filter.apply(blockEvents)
+	require.Len(t, filtered.Payload, 2)
+	require.Len(t, blockEvents.Payload, 3)
+
+	filter, err = newEventFilter(&commands.EventFilter{Contract: contract.String(), Topic: "a"})
+	require.NoError(t, err)
+	require.Nil(t, filter.apply(receipt))
+	filtered = filter.apply(blockEvents)
+	require.Equal(t, []*isc.Event{{ContractID: contract, Topic: "a"}}, filtered.Payload)
+
+	filter, err = newEventFilter(&commands.EventFilter{Topic: "c"})
+	require.NoError(t, err)
+	require.Nil(t, filter.apply(blockEvents))
+	require.Same(t, newBlock, filter.apply(newBlock))
+
+	filter, err = newEventFilter(&commands.EventFilter{Sender: sender.String()})
+	require.NoError(t, err)
+	require.Same(t, receipt, filter.apply(receipt))
+	require.Nil(t, filter.apply(otherReceipt))
+	require.Nil(t, filter.apply(blockEvents))
+
+	_, err = newEventFilter(&commands.EventFilter{Contract: "not a hname"})
+	require.Error(t, err)
+	_, err = newEventFilter(&commands.EventFilter{Sender: "not an agent ID"})
+	require.Error(t, err)
+}
@@ -11,11 +11,16 @@ import (
 )
 
 type ISCEvent struct {
-	Kind      publisher.ISCEventType `json:"kind"`
-	Issuer    string                 `json:"issuer"`    // (isc.AgentID) nil means issued by the VM
-	RequestID string                 `json:"requestID"` // (isc.RequestID)
-	ChainID   string                 `json:"chainID"`   // (isc.ChainID)
-	Payload   any                    `json:"payload"`
+	Kind       publisher.ISCEventType `json:"kind"`
+	Issuer     string                 `json:"issuer"`     // (isc.AgentID) nil means issued by the VM
+	RequestID  string                 `json:"requestID"`  // (isc.RequestID)
+	ChainID    string                 `json:"chainID"`    // (isc.ChainID)
+	BlockIndex uint32                 `json:"blockIndex"` // can be used as `fromBlockIndex` to resume the stream
+	Payload    any                    `json:"payload"`
+
+	// used to filter the receipts
+	contract isc.Hname
+	sender   isc.AgentID
 }
 
 func MapISCEvent[T any](iscEvent *publisher.ISCEvent[T], mappedPayload any) *ISCEvent {
@@ -27,14 +32,31 @@ func MapISCEvent[T any](iscEvent *publisher.ISCEvent[T], mappedPayload any) *ISC
 	}
 
 	return &ISCEvent{
-		Kind:      iscEvent.Kind,
-		ChainID:   iscEvent.ChainID.String(),
-		RequestID: iscEvent.RequestID.String(),
-		Issuer:    issuer,
-		Payload:   mappedPayload,
+		Kind:       iscEvent.Kind,
+		ChainID:    iscEvent.ChainID.String(),
+		RequestID:  iscEvent.RequestID.String(),
+		Issuer:     issuer,
+		BlockIndex: iscEvent.BlockIndex,
+		Payload:    mappedPayload,
 	}
 }
 
+func mapNewBlockEvent(block *publisher.ISCEvent[*publisher.BlockWithTrieRoot]) *ISCEvent {
+	blockInfo := models.MapBlockInfoResponse(block.Payload.BlockInfo)
+	return MapISCEvent(block, blockInfo)
+}
+
+func mapReceiptEvent(receipt *publisher.ISCEvent[*publisher.ReceiptWithError]) *ISCEvent {
+	iscEvent := MapISCEvent(receipt, models.MapReceiptResponse(receipt.Payload.RequestReceipt))
+	iscEvent.contract = receipt.Payload.RequestReceipt.DeserializedRequest().CallTarget().Contract
+	iscEvent.sender = receipt.Issuer
+	return iscEvent
+}
+
+func mapBlockEventsEvent(events *publisher.ISCEvent[[]*isc.Event]) *ISCEvent {
+	return MapISCEvent(events, events.Payload)
+}
+
 type EventHandler struct {
 	publisher             *publisher.Publisher
 	publishEvent          *event.Event1[*ISCEvent]
@@ -57,9 +79,7 @@ func (p *EventHandler) AttachToEvents() context.CancelFunc {
 				return
 			}
 
-			blockInfo := models.MapBlockInfoResponse(block.Payload.BlockInfo)
-			iscEvent := MapISCEvent(block, blockInfo)
-			p.publishEvent.Trigger(iscEvent)
+			p.publishEvent.Trigger(mapNewBlockEvent(block))
 		}).Unhook,
 
 		p.publisher.Events.RequestReceipt.Hook(func(block *publisher.ISCEvent[*publisher.ReceiptWithError]) {
@@ -67,9 +87,7 @@ func (p *EventHandler) AttachToEvents() context.CancelFunc {
 				return
 			}
 
-			receipt := models.MapReceiptResponse(block.Payload.RequestReceipt)
-			iscEvent := MapISCEvent(block, receipt)
-			p.publishEvent.Trigger(iscEvent)
+			p.publishEvent.Trigger(mapReceiptEvent(block))
 		}).Unhook,
 
 		p.publisher.Events.BlockEvents.Hook(func(block *publisher.ISCEvent[[]*isc.Event]) {
@@ -77,8 +95,7 @@ func (p *EventHandler) AttachToEvents() context.CancelFunc {
 				return
 			}
 
-			iscEvent := MapISCEvent(block, block.Payload)
-			p.publishEvent.Trigger(iscEvent)
+			p.publishEvent.Trigger(mapBlockEventsEvent(block))
 		}).Unhook,
 	)
 }
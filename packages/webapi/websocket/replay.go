@@ -0,0 +1,353 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/hive.go/web/websockethub"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/subrealm"
+	"github.com/nnikolash/wasp-types-exported/packages/publisher"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/blocklog"
+	coreerrors "github.com/nnikolash/wasp-types-exported/packages/vm/core/errors"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/websocket/commands"
+)
+
+const (
+	topicAllChains         = "chains"
+	topicSingleChainPrefix = topicAllChains + "/"
+)
+
+// LatestStateProvider returns the latest state of the chain; its blocklog is used
+// to replay the events of the past blocks.
+type LatestStateProvider func(chainID isc.ChainID) (state.State, error)
+
+// clientState keeps the filters and the replays of the subscriptions of a client.
+type clientState struct {
+	mutex sync.Mutex
+	// filters by the subscribed chain topic; nil filter matches all the events
+	filters map[string]*eventFilter
+	// live events withheld while replaying the past events, by chain ID
+	replays map[string][]*ISCEvent
+	// set once the client exceeded a limit and is being disconnected
+	disconnecting bool
+}
+
+func newClientState() *clientState {
+	return &clientState{
+		filters: make(map[string]*eventFilter),
+		replays: make(map[string][]*ISCEvent),
+	}
+}
+
+func (s *clientState) eventFilter(chainID string) *eventFilter {
+	if filter, ok := s.filters[topicSingleChainPrefix+chainID]; ok {
+		return filter
+	}
+	return s.filters[topicAllChains]
+}
+
+func (p *Service) addClientState(clientID websockethub.ClientID) {
+	p.clientsMutex.Lock()
+	defer p.clientsMutex.Unlock()
+
+	p.clients[clientID] = newClientState()
+}
+
+// getClientState returns the state of a connected client; it is not recreated
+// for the events that are still being sent after the client disconnected.
+func (p *Service) getClientState(clientID websockethub.ClientID) (*clientState, bool) {
+	p.clientsMutex.Lock()
+	defer p.clientsMutex.Unlock()
+
+	state, ok := p.clients[clientID]
+	return state, ok
+}
+
+func (p *Service) removeClientState(clientID websockethub.ClientID) {
+	p.clientsMutex.Lock()
+	defer p.clientsMutex.Unlock()
+
+	delete(p.clients, clientID)
+}
+
+// disconnectClient drops the client, which exceeded a limit. It must be called
+// with the client state mutex locked.
+func (p *Service) disconnectClient(client *websockethub.Client, state *clientState, reason string) {
+	if state.disconnecting {
+		return
+	}
+	state.disconnecting = true
+	state.replays = make(map[string][]*ISCEvent) // release the withheld events
+	p.log.Warnf("disconnecting client:[%d], %s", client.ID(), reason)
+
+	// the hub calls onDisconnect, which must not be waited for while holding the mutex
+	go func() {
+		if err := p.hub.Unregister(client); err != nil {
+			p.log.Warnf("error disconnecting client:[%d], err:[%v]", client.ID(), err)
+		}
+	}()
+}
+
+// sendEvent sends the live event to the client, unless the events of its chain are being replayed.
+func (p *Service) sendEvent(client *websockethub.Client, iscEvent *ISCEvent) {
+	state, ok := p.getClientState(client.ID())
+	if !ok {
+		return
+	}
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if state.disconnecting {
+		return
+	}
+	if withheld, ok := state.replays[iscEvent.ChainID]; ok {
+		if p.maxWithheldEventsPerClient > 0 && len(withheld) >= p.maxWithheldEventsPerClient {
+			p.disconnectClient(client, state, fmt.Sprintf("more than %d live events withheld while replaying", p.maxWithheldEventsPerClient))
+			return
+		}
+		state.replays[iscEvent.ChainID] = append(withheld, iscEvent)
+		return
+	}
+	p.sendFilteredEvent(client, state, iscEvent)
+}
+
+// sendFilteredEvent must be called with the client state mutex locked.
+func (p *Service) sendFilteredEvent(client *websockethub.Client, state *clientState, iscEvent *ISCEvent) {
+	if !p.subscriptionValidator.isClientAllowed(client, iscEvent.ChainID, iscEvent.Kind) {
+		return
+	}
+
+	iscEvent = state.eventFilter(iscEvent.ChainID).apply(iscEvent)
+	if iscEvent == nil {
+		return
+	}
+
+	if err := client.Send(client.Context(), iscEvent); err != nil {
+		p.log.Warnf("error sending message to client:[%d], err:[%v]", client.ID(), err)
+	}
+}
+
+func chainIDFromTopic(topic string) (isc.ChainID, bool, error) {
+	chainIDStr, ok := strings.CutPrefix(topic, topicSingleChainPrefix)
+	if !ok {
+		return isc.ChainID{}, false, nil
+	}
+	chainID, err := isc.ChainIDFromString(chainIDStr)
+	if err != nil {
+		return isc.ChainID{}, false, err
+	}
+	return chainID, true, nil
+}
+
+// -------------------------------------
+// Implementation of commands.SubscriptionListener
+// -------------------------------------
+
+func (p *Service) BeforeSubscribe(client *websockethub.Client, command *commands.SubscriptionCommand) error {
+	chainID, isSingleChain, err := chainIDFromTopic(command.Topic)
+	if err != nil {
+		return err
+	}
+	if !isSingleChain && command.Topic != topicAllChains {
+		if command.Filter != nil || command.FromBlockIndex != nil {
+			return errors.New("events can only be filtered and replayed when subscribing to the chains")
+		}
+		return nil
+	}
+	var latestBlockIndex uint32
+	if command.FromBlockIndex != nil {
+		if !isSingleChain {
+			return errors.New("events can only be replayed when subscribing to a single chain")
+		}
+		if p.latestStateProvider == nil {
+			return errors.New("replaying events is not supported")
+		}
+		latestState, err := p.latestStateProvider(chainID)
+		if err != nil {
+			return err
+		}
+		latestBlockIndex = latestState.BlockIndex()
+	}
+
+	filter, err := newEventFilter(command.Filter)
+	if err != nil {
+		return fmt.Errorf("invalid filter: %w", err)
+	}
+
+	state, ok := p.getClientState(client.ID())
+	if !ok {
+		return errors.New("client is disconnected")
+	}
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if command.FromBlockIndex != nil {
+		if p.maxReplayBlocks > 0 && latestBlockIndex >= *command.FromBlockIndex && latestBlockIndex-*command.FromBlockIndex >= uint32(p.maxReplayBlocks) {
+			err := fmt.Errorf("cannot replay more than %d blocks", p.maxReplayBlocks)
+			p.disconnectClient(client, state, err.Error())
+			return err
+		}
+		if _, ok := state.replays[chainID.String()]; ok {
+			return errors.New("events of the chain are already being replayed")
+		}
+		state.replays[chainID.String()] = make([]*ISCEvent, 0)
+	}
+	state.filters[command.Topic] = filter
+	return nil
+}
+
+func (p *Service) AfterSubscribe(client *websockethub.Client, command *commands.SubscriptionCommand) error {
+	if command.FromBlockIndex == nil {
+		return nil
+	}
+	chainID, _, err := chainIDFromTopic(command.Topic)
+	if err != nil {
+		return err
+	}
+
+	state, ok := p.getClientState(client.ID())
+	if !ok {
+		return errors.New("client is disconnected")
+	}
+	toBlockIndex, replayErr := p.replayEvents(client, state, chainID, *command.FromBlockIndex)
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	withheld := state.replays[chainID.String()]
+	delete(state.replays, chainID.String())
+	if replayErr == nil {
+		err = client.Send(client.Context(), commands.ReplayEvent{
+			BaseEvent: commands.BaseEvent{
+				Event: commands.EventEventsReplayed,
+			},
+			Topic:          command.Topic,
+			FromBlockIndex: *command.FromBlockIndex,
+			ToBlockIndex:   toBlockIndex,
+		})
+		if err != nil {
+			p.log.Warnf("error sending message to client:[%d], err:[%v]", client.ID(), err)
+		}
+	}
+	for _, iscEvent := range withheld {
+		// the events of the replayed blocks are not sent twice
+		if replayErr == nil && iscEvent.BlockIndex <= toBlockIndex {
+			continue
+		}
+		p.sendFilteredEvent(client, state, iscEvent)
+	}
+	return replayErr
+}
+
+func (p *Service) AfterUnsubscribe(client *websockethub.Client, topic string) {
+	state, ok := p.getClientState(client.ID())
+	if !ok {
+		return
+	}
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	delete(state.filters, topic)
+}
+
+// -------------------------------------
+// Replay
+// -------------------------------------
+
+// replayEvents sends to the client the events of the blocks from fromBlockIndex
+// to the latest block of the chain and returns the index of the last replayed
+// block. At most maxReplayBlocks are replayed; as the range was checked by
+// BeforeSubscribe, the events of the later blocks are among the withheld ones.
+func (p *Service) replayEvents(client *websockethub.Client, state *clientState, chainID isc.ChainID, fromBlockIndex uint32) (uint32, error) {
+	latestState, err := p.latestStateProvider(chainID)
+	if err != nil {
+		return 0, err
+	}
+	toBlockIndex := latestState.BlockIndex()
+	if p.maxReplayBlocks > 0 && toBlockIndex >= fromBlockIndex && toBlockIndex-fromBlockIndex >= uint32(p.maxReplayBlocks) {
+		toBlockIndex = fromBlockIndex + uint32(p.maxReplayBlocks) - 1
+	}
+
+	blocklogState := blocklog.NewStateAccess(latestState)
+	errorsState := subrealm.NewReadOnly(latestState, kv.Key(coreerrors.Contract.Hname().Bytes()))
+	for blockIndex := fromBlockIndex; blockIndex <= toBlockIndex; blockIndex++ {
+		if err := client.Context().Err(); err != nil {
+			return 0, err
+		}
+		iscEvents, err := blockISCEvents(chainID, blocklogState, errorsState, blockIndex)
+		if err != nil {
+			return 0, err
+		}
+		err = func() error {
+			state.mutex.Lock()
+			defer state.mutex.Unlock()
+			if state.disconnecting {
+				return errors.New("client is disconnected")
+			}
+			for _, iscEvent := range iscEvents {
+				p.sendFilteredEvent(client, state, iscEvent)
+			}
+			return nil
+		}()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return toBlockIndex, nil
+}
+
+// blockISCEvents reconstructs from the blocklog the events, which were published
+// when the block was applied.
+func blockISCEvents(chainID isc.ChainID, blocklogState *blocklog.StateAccess, errorsState kv.KVStoreReader, blockIndex uint32) ([]*ISCEvent, error) {
+	blockInfo, ok := blocklogState.BlockInfo(blockIndex)
+	if !ok {
+		return nil, fmt.Errorf("block %d is not available in the blocklog", blockIndex)
+	}
+	iscEvents := []*ISCEvent{mapNewBlockEvent(&publisher.ISCEvent[*publisher.BlockWithTrieRoot]{
+		Kind:       publisher.ISCEventKindNewBlock,
+		Issuer:     &isc.NilAgentID{},
+		Payload:    &publisher.BlockWithTrieRoot{BlockInfo: blockInfo},
+		ChainID:    chainID,
+		BlockIndex: blockIndex,
+	})}
+
+	receipts, err := blocklogState.RequestReceiptsInBlock(blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	for _, receipt := range receipts {
+		vmError, err := coreerrors.ResolveFromState(errorsState, receipt.Error)
+		if err != nil {
+			return nil, err
+		}
+		iscEvents = append(iscEvents, mapReceiptEvent(&publisher.ISCEvent[*publisher.ReceiptWithError]{
+			Kind:       publisher.ISCEventKindReceipt,
+			Issuer:     receipt.Request.SenderAccount(),
+			Payload:    &publisher.ReceiptWithError{RequestReceipt: receipt.ToISCReceipt(vmError), Error: vmError},
+			RequestID:  receipt.Request.ID(),
+			ChainID:    chainID,
+			BlockIndex: blockIndex,
+		}))
+	}
+
+	events, err := blocklogState.EventsInBlock(blockIndex)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		events = nil // the same as in the published events
+	}
+	iscEvents = append(iscEvents, mapBlockEventsEvent(&publisher.ISCEvent[[]*isc.Event]{
+		Kind:       publisher.ISCEventKindBlockEvents,
+		Issuer:     &isc.NilAgentID{},
+		Payload:    events,
+		ChainID:    chainID,
+		BlockIndex: blockIndex,
+	}))
+	return iscEvents, nil
+}
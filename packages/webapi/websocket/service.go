@@ -5,6 +5,7 @@ package websocket
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/labstack/echo/v4"
 
@@ -25,8 +26,13 @@ type Service struct {
 	publisherEvent        *event.Event1[*ISCEvent]
 	subscriptionManager   *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
 	subscriptionValidator *SubscriptionValidator
+	clients               map[websockethub.ClientID]*clientState
+	clientsMutex          sync.Mutex
 
 	maxTopicSubscriptionsPerClient int
+	maxReplayBlocks                int
+	maxWithheldEventsPerClient     int
+	latestStateProvider            LatestStateProvider
 }
 
 func WithMaxTopicSubscriptionsPerClient(maxTopicSubscriptionsPerClient int) options.Option[Service] {
@@ -35,9 +41,34 @@ func WithMaxTopicSubscriptionsPerClient(maxTopicSubscriptionsPerClient int) opti
 	}
 }
 
+// WithMaxReplayBlocks limits the number of past blocks whose events can be
+// replayed by a subscription; the clients requesting more are disconnected.
+func WithMaxReplayBlocks(maxReplayBlocks int) options.Option[Service] {
+	return func(d *Service) {
+		d.maxReplayBlocks = maxReplayBlocks
+	}
+}
+
+// WithMaxWithheldEventsPerClient limits the number of live events withheld
+// while replaying; the clients exceeding it are disconnected.
+func WithMaxWithheldEventsPerClient(maxWithheldEventsPerClient int) options.Option[Service] {
+	return func(d *Service) {
+		d.maxWithheldEventsPerClient = maxWithheldEventsPerClient
+	}
+}
+
+// WithLatestStateProvider enables replaying the events of the past blocks.
+func WithLatestStateProvider(latestStateProvider LatestStateProvider) options.Option[Service] {
+	return func(d *Service) {
+		d.latestStateProvider = latestStateProvider
+	}
+}
+
 func NewWebsocketService(log *logger.Logger, hub *websockethub.Hub, msgTypes []publisher.ISCEventType, pub *publisher.Publisher, opts ...options.Option[Service]) *Service {
 	serviceOptions := options.Apply(&Service{
 		maxTopicSubscriptionsPerClient: 0,
+		maxReplayBlocks:                1000,
+		maxWithheldEventsPerClient:     10000,
 	}, opts)
 
 	msgTypesMap := make(map[publisher.ISCEventType]bool)
@@ -53,31 +84,32 @@ func NewWebsocketService(log *logger.Logger, hub *websockethub.Hub, msgTypes []p
 
 	subscriptionValidator := NewSubscriptionValidator(msgTypesMap, subscriptionManager)
 	eventHandler := NewEventHandler(pub, publishEvent, subscriptionValidator)
-	commandHandler := commands.NewCommandHandler(log, subscriptionManager)
 
-	return &Service{
+	service := &Service{
 		log:                   log.Named("Websocket Service"),
 		hub:                   hub,
-		commandHandler:        commandHandler,
 		eventHandler:          eventHandler,
 		publisherEvent:        publishEvent,
 		subscriptionManager:   subscriptionManager,
 		subscriptionValidator: subscriptionValidator,
+		clients:               make(map[websockethub.ClientID]*clientState),
+		latestStateProvider:   serviceOptions.latestStateProvider,
+
+		maxReplayBlocks:            serviceOptions.maxReplayBlocks,
+		maxWithheldEventsPerClient: serviceOptions.maxWithheldEventsPerClient,
 	}
+	service.commandHandler = commands.NewCommandHandler(log, subscriptionManager, service)
+
+	return service
 }
 
 func (p *Service) onClientCreated(client *websockethub.Client) {
 	client.ReceiveChan = make(chan *websockethub.WebsocketMsg, 100)
+	p.addClientState(client.ID())
 
 	go func() {
 		unhook := p.publisherEvent.Hook(func(iscEvent *ISCEvent) {
-			if !p.subscriptionValidator.isClientAllowed(client, iscEvent.ChainID, iscEvent.Kind) {
-				return
-			}
-
-			if err := client.Send(client.Context(), iscEvent); err != nil {
-				p.log.Warnf("error sending message to client:[%d], err:[%v]", client.ID(), err)
-			}
+			p.sendEvent(client, iscEvent)
 		}).Unhook
 		defer unhook()
 
@@ -115,6 +147,7 @@ func (p *Service) onConnect(client *websockethub.Client, request *http.Request)
 
 func (p *Service) onDisconnect(client *websockethub.Client, request *http.Request) {
 	p.subscriptionManager.Disconnect(client.ID())
+	p.removeClientState(client.ID())
 	p.log.Infof("closed websocket connection for client:[%d], from:[%s]", client.ID(), request.RemoteAddr)
 }
 
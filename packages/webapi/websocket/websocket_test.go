@@ -1,6 +1,8 @@
 package websocket
 
 import (
+	"encoding/json"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,8 +14,15 @@ import (
 	appLogger "github.com/iotaledger/hive.go/app/logger"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/web/websockethub"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/subrealm"
 	"github.com/nnikolash/wasp-types-exported/packages/publisher"
 	"github.com/nnikolash/wasp-types-exported/packages/solo"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/blocklog"
+	coreerrors "github.com/nnikolash/wasp-types-exported/packages/vm/core/errors"
+	"github.com/nnikolash/wasp-types-exported/packages/webapi/websocket/commands"
 )
 
 func InitWebsocket(ctx context.Context, t *testing.T, eventsToSubscribe []publisher.ISCEventType) (*Service, *websockethub.Hub, *solo.Chain) {
@@ -77,3 +86,100 @@ func TestWebsocketEvents(t *testing.T) {
 
 	require.ErrorIs(t, ctx.Err(), context.Canceled)
 }
+
+func TestReplayedEventsMatchPublished(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ws, _, chain := InitWebsocket(ctx, t, []publisher.ISCEventType{
+		publisher.ISCEventKindNewBlock,
+		publisher.ISCEventKindReceipt,
+		publisher.ISCEventKindBlockEvents,
+	})
+
+	var mutex sync.Mutex
+	published := make(map[uint32][]*ISCEvent)
+	ws.publisherEvent.Hook(func(iscEvent *ISCEvent) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		published[iscEvent.BlockIndex] = append(published[iscEvent.BlockIndex], iscEvent)
+	})
+
+	chain.DepositBaseTokensToL2(1*isc.Million, nil)
+	chain.DepositBaseTokensToL2(2*isc.Million, nil)
+
+	latestState, err := chain.Store().LatestState()
+	require.NoError(t, err)
+	toBlockIndex := latestState.BlockIndex()
+	fromBlockIndex := toBlockIndex - 1
+
+	// the block events are published last
+	require.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events := published[toBlockIndex]
+		return len(events) > 0 && events[len(events)-1].Kind == publisher.ISCEventKindBlockEvents
+	}, 3*time.Second, 10*time.Millisecond)
+
+	blocklogState := blocklog.NewStateAccess(latestState)
+	errorsState := subrealm.NewReadOnly(latestState, kv.Key(coreerrors.Contract.Hname().Bytes()))
+	for blockIndex := fromBlockIndex; blockIndex <= toBlockIndex; blockIndex++ {
+		replayed, err := blockISCEvents(chain.ChainID, blocklogState, errorsState, blockIndex)
+		require.NoError(t, err)
+
+		mutex.Lock()
+		expected, err := json.Marshal(published[blockIndex])
+		mutex.Unlock()
+		require.NoError(t, err)
+		actual, err := json.Marshal(replayed)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expected), string(actual))
+	}
+}
+
+func TestReplayLimits(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ws, hub, chain := InitWebsocket(ctx, t, nil)
+	ws.latestStateProvider = func(isc.ChainID) (state.State, error) {
+		return chain.Store().LatestState()
+	}
+	ws.maxReplayBlocks = 2
+	ws.maxWithheldEventsPerClient = 2
+	require.Eventually(t, func() bool { return !hub.Stopped() }, time.Second, 10*time.Millisecond)
+
+	chain.DepositBaseTokensToL2(1*isc.Million, nil)
+	chain.DepositBaseTokensToL2(1*isc.Million, nil)
+	latestState, err := chain.Store().LatestState()
+	require.NoError(t, err)
+	topic := topicSingleChainPrefix + chain.ChainID.String()
+
+	// too many blocks to replay
+	client := websockethub.NewClient(hub, nil, nil, nil)
+	ws.addClientState(client.ID())
+	fromBlockIndex := latestState.BlockIndex() - 2
+	err = ws.BeforeSubscribe(client, &commands.SubscriptionCommand{Topic: topic, FromBlockIndex: &fromBlockIndex})
+	require.ErrorContains(t, err, "cannot replay more than 2 blocks")
+	state, ok := ws.getClientState(client.ID())
+	require.True(t, ok)
+	require.True(t, state.disconnecting)
+
+	// too many live events withheld while replaying
+	client = websockethub.NewClient(hub, nil, nil, nil)
+	ws.addClientState(client.ID())
+	fromBlockIndex = latestState.BlockIndex() - 1
+	err = ws.BeforeSubscribe(client, &commands.SubscriptionCommand{Topic: topic, FromBlockIndex: &fromBlockIndex})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		ws.sendEvent(client, &ISCEvent{ChainID: chain.ChainID.String()})
+	}
+	state, ok = ws.getClientState(client.ID())
+	require.True(t, ok)
+	require.True(t, state.disconnecting)
+	require.Empty(t, state.replays)
+
+	// the state of a disconnected client is not recreated
+	ws.removeClientState(client.ID())
+	ws.sendEvent(client, &ISCEvent{ChainID: chain.ChainID.String()})
+	_, ok = ws.getClientState(client.ID())
+	require.False(t, ok)
+}
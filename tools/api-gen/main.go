@@ -58,6 +58,7 @@ func main() {
 		"",
 		nil,
 		jsonrpc.ParametersDefault(),
+		nil,
 	)
 
 	root, ok := swagger.(*echoswagger.Root)
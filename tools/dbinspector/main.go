@@ -25,18 +25,23 @@ type processFunc func(context.Context, kvstore.KVStore)
 var (
 	blockIndex  int64
 	blockIndex2 int64
+	walDir      string
+	force       bool
 )
 
 func main() {
 	flag.Int64Var(&blockIndex, "b", -1, "Block index")
 	flag.Int64Var(&blockIndex2, "B", -1, "Block index 2")
+	flag.StringVar(&walDir, "wal", "", "Block WAL folder of the chain")
+	flag.BoolVar(&force, "force", false, "Prune the states even if the chain keeps all the blocks")
 	flag.Parse()
 
 	if flag.NArg() != 2 {
-		log.Fatalf("usage: %s [-b index] <command> <chain-db-dir>", os.Args[0])
+		log.Fatalf("usage: %s [-b index] [-B index] [-wal path] [-force] <command> <chain-db-dir>", os.Args[0])
 	}
 	args := flag.Args()
 	var f processFunc
+	readOnly := true
 	switch args[0] {
 	case "state-stats-per-hname":
 		f = stateStatsPerHname
@@ -44,11 +49,17 @@ func main() {
 		f = trieStats
 	case "trie-diff":
 		f = trieDiff
+	case "prune":
+		f = pruneStates
+		readOnly = false
+	case "wal-replay":
+		f = walReplay
+		readOnly = false
 	default:
 		log.Fatalf("unknown command: %s", args[0])
 	}
 
-	process(args[1], f)
+	process(args[1], f, readOnly)
 }
 
 func getState(kvs kvstore.KVStore, index int64) state.State {
@@ -63,8 +74,13 @@ func getState(kvs kvstore.KVStore, index int64) state.State {
 	return state
 }
 
-func process(dbDir string, f processFunc) {
-	rocksDatabase, err := rocksdb.OpenDBReadOnly(dbDir,
+func process(dbDir string, f processFunc, readOnly bool) {
+	openDB := rocksdb.OpenDBReadOnly
+	if !readOnly {
+		// the node must be stopped
+		openDB = rocksdb.CreateDB
+	}
+	rocksDatabase, err := openDB(dbDir,
 		rocksdb.IncreaseParallelism(runtime.NumCPU()-1),
 		rocksdb.Custom([]string{
 			"periodic_compaction_seconds=43200",
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/nnikolash/wasp-types-exported/packages/chainutil"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+	"github.com/nnikolash/wasp-types-exported/packages/trie"
+)
+
+// pruneStates prunes the states of the blocks [-b, -B] of a stopped node's database;
+// the states of an archive chain are only pruned with -force
+func pruneStates(ctx context.Context, kvs kvstore.KVStore) {
+	if blockIndex2 < 0 {
+		log.Fatalf("prune: the index of the last block to prune (-B) is required")
+	}
+	fromIndex := uint32(0)
+	if blockIndex >= 0 {
+		fromIndex = uint32(blockIndex)
+	}
+	toIndex := uint32(blockIndex2)
+
+	store := state.NewStoreWithUniqueWriteMutex(kvs)
+	start := time.Now()
+	last := start
+	pruned := 0
+	fmt.Printf("Pruning states %d-%d...\n", fromIndex, toIndex)
+	result, err := chainutil.PruneStates(ctx, store, fromIndex, toIndex, force, func(blockIndex uint32, stats trie.PruneStats) {
+		pruned++
+		now := time.Now()
+		if now.Sub(last) > 1*time.Second {
+			fmt.Printf("Pruned block %d of %d (%d states, %.1f states/s)\n",
+				blockIndex, toIndex, pruned, float64(pruned)/now.Sub(start).Seconds())
+			last = now
+		}
+	})
+	if result != nil {
+		fmt.Println()
+		fmt.Printf("Pruned states: %d\n", result.PrunedBlocks)
+		fmt.Printf("Deleted trie nodes: %d\n", result.DeletedNodes)
+		fmt.Printf("Deleted trie values: %d\n", result.DeletedValues)
+		fmt.Printf("Oldest available state: %d\n", result.OldestBlockIndex)
+		fmt.Printf("Elapsed: %s\n", time.Since(start))
+	}
+	mustNoError(kvs.Flush())
+	mustNoError(err)
+}
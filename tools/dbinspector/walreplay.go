@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/nnikolash/wasp-types-exported/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/metrics"
+	"github.com/nnikolash/wasp-types-exported/packages/state"
+)
+
+// walReplay commits all the blocks of the chain's WAL (-wal) to an empty chain database.
+// The replay is not interruptible: the database is only usable after it is completed.
+func walReplay(_ context.Context, kvs kvstore.KVStore) {
+	if walDir == "" {
+		log.Fatalf("wal-replay: the WAL folder of the chain (-wal) is required")
+	}
+	walDir = filepath.Clean(walDir)
+	chainID, err := isc.ChainIDFromString(filepath.Base(walDir))
+	if err != nil {
+		log.Fatalf("wal-replay: the WAL folder must be named by the chain ID: %v", err)
+	}
+
+	store := state.NewStoreWithUniqueWriteMutex(kvs)
+	if !store.IsEmpty() {
+		log.Fatalf("wal-replay: the chain database is not empty")
+	}
+	wal, err := sm_gpa_utils.NewBlockWAL(
+		logger.NewNopLogger(),
+		filepath.Dir(walDir),
+		chainID,
+		metrics.NewChainMetricsProvider().GetChainMetrics(chainID).BlockWAL,
+		sm_gpa_utils.NewBlockWALParameters(),
+	)
+	mustNoError(err)
+
+	start := time.Now()
+	last := start
+	fmt.Printf("Replaying WAL %s...\n", walDir)
+	count := 0
+	replayed, lastBlock, err := sm_gpa_utils.ReplayBlockWAL(wal, store, func(block state.Block) {
+		count++
+		now := time.Now()
+		if now.Sub(last) > 1*time.Second {
+			fmt.Printf("Replayed block %d (%d blocks, %.1f blocks/s)\n",
+				block.StateIndex(), count, float64(count)/now.Sub(start).Seconds())
+			last = now
+		}
+	})
+	mustNoError(err)
+	if lastBlock != nil {
+		mustNoError(store.SetLatest(lastBlock.TrieRoot()))
+	}
+	mustNoError(kvs.Flush())
+
+	fmt.Println()
+	fmt.Printf("Replayed blocks: %d\n", replayed)
+	if lastBlock != nil {
+		fmt.Printf("Latest state: %d (%s)\n", lastBlock.StateIndex(), lastBlock.L1Commitment())
+	}
+	fmt.Printf("Elapsed: %s\n", time.Since(start))
+}
@@ -6,10 +6,14 @@ package main
 import (
 	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 
@@ -68,7 +72,16 @@ func init() {
 	parameters.InitL1(parameters.L1ForTesting)
 }
 
-var listenAddress string = ":8545"
+const (
+	chainName      = "evmemulator"
+	dbSnapshotFile = "evmemulator.json"
+)
+
+var (
+	listenAddress string = ":8545"
+	dbPath        string
+	forkPath      string
+)
 
 func main() {
 	cmd := &cobra.Command{
@@ -86,39 +99,102 @@ evmemulator does the following:
 
 You can connect any Ethereum tool (eg Metamask) to this JSON-RPC server and use it for testing Ethereum contracts.
 
-Note: chain data is stored in-memory and will be lost upon termination.
+The following development methods (compatible with Anvil and Hardhat) are also available:
+evm_mine, evm_increaseTime, evm_setNextBlockTimestamp, anvil_mine, anvil_setBalance,
+anvil_setCode, anvil_setStorageAt, anvil_setNonce, anvil_impersonateAccount and
+anvil_stopImpersonatingAccount (also available with the hardhat_ prefix).
+
+By default, chain data is stored in-memory and will be lost upon termination.
+Use --db to persist the chain data in a directory across restarts: the chain is
+saved after each block, and loaded on the next start.
+
+Use --fork to start from the state of an existing chain, read from a full state
+snapshot file (*.snap) produced by a Wasp node. The forked chain keeps its chain ID
+and state, but it is controlled locally by evmemulator.
 `,
 		),
 	}
 
 	log.Init(cmd)
 	cmd.PersistentFlags().StringVarP(&listenAddress, "listen", "l", ":8545", "listen address")
+	cmd.PersistentFlags().StringVar(&dbPath, "db", "", "directory where the chain data is persisted (in-memory if empty)")
+	cmd.PersistentFlags().StringVar(&forkPath, "fork", "", "full state snapshot file of the chain to fork")
 
 	err := cmd.Execute()
 	log.Check(err)
 }
 
-func initSolo() (*soloContext, *solo.Chain) {
+// initSolo returns the chain of evmemulator, and whether it was loaded from the database
+func initSolo() (*soloContext, *solo.Chain, bool) {
 	ctx := &soloContext{}
 
 	env := solo.New(ctx, &solo.InitOptions{Debug: log.DebugFlag, PrintStackTrace: log.DebugFlag})
 
+	if dbPath != "" {
+		snapshotPath := filepath.Join(dbPath, dbSnapshotFile)
+		_, err := os.Stat(snapshotPath)
+		if err == nil {
+			if forkPath != "" {
+				log.Fatalf("cannot fork a chain into the existing database %s", dbPath)
+			}
+			log.Printf("loading chain from %s...\n", snapshotPath)
+			env.RestoreSnapshot(env.LoadSnapshot(snapshotPath))
+			return ctx, env.GetChainByName(chainName), true
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Check(err)
+		}
+		log.Check(os.MkdirAll(dbPath, 0o700))
+	}
+
+	if forkPath != "" {
+		if strings.HasSuffix(forkPath, ".delta") {
+			log.Fatalf("cannot fork a chain from a delta snapshot, a full snapshot is needed")
+		}
+		log.Printf("forking chain from %s...\n", forkPath)
+		f, err := os.Open(forkPath)
+		log.Check(err)
+		defer f.Close()
+		return ctx, env.NewChainFromStateSnapshot(chainName, f), false
+	}
+
 	chainOwner, chainOwnerAddr := env.NewKeyPairWithFunds()
-	chain, _ := env.NewChainExt(chainOwner, 1*isc.Million, "evmemulator", dict.Dict{
+	chain, _ := env.NewChainExt(chainOwner, 1*isc.Million, chainName, dict.Dict{
 		origin.ParamChainOwner:      isc.NewAgentID(chainOwnerAddr).Bytes(),
 		origin.ParamEVMChainID:      codec.EncodeUint16(1074),
 		origin.ParamBlockKeepAmount: codec.EncodeInt32(emulator.BlockKeepAll),
 		origin.ParamWaspVersion:     codec.EncodeString(app.Version),
 	})
-	return ctx, chain
+	return ctx, chain, false
+}
+
+// saveSolo persists the Solo environment in the database directory
+func saveSolo(chain *solo.Chain) {
+	snapshotPath := filepath.Join(dbPath, dbSnapshotFile)
+	// write to a temporary file first, so that a failure does not corrupt the database
+	tmpPath := snapshotPath + ".tmp"
+	chain.Env.SaveSnapshot(chain.Env.TakeSnapshot(), tmpPath)
+	log.Check(os.Rename(tmpPath, snapshotPath))
 }
 
-func createAccounts(chain *solo.Chain) (accounts []*ecdsa.PrivateKey) {
-	log.Printf("creating accounts with funds...\n")
+// createAccounts returns the keys of the test accounts. The accounts are funded,
+// unless the chain was loaded from the database (the funds are already there)
+func createAccounts(chain *solo.Chain, funded bool) (accounts []*ecdsa.PrivateKey) {
+	if funded {
+		log.Printf("test accounts:\n")
+	} else {
+		log.Printf("creating accounts with funds...\n")
+	}
 	header := []string{"private key", "address"}
 	var rows [][]string
 	for i := 0; i < len(solo.EthereumAccounts); i++ {
-		pk, addr := chain.EthereumAccountByIndexWithL2Funds(i)
+		var pk *ecdsa.PrivateKey
+		var addr common.Address
+		if funded {
+			pk, addr = solo.EthereumAccountByIndex(i)
+		} else {
+			pk, addr = chain.EthereumAccountByIndexWithL2Funds(i)
+		}
 		accounts = append(accounts, pk)
 		rows = append(rows, []string{hex.EncodeToString(crypto.FromECDSA(pk)), addr.String()})
 	}
@@ -127,10 +203,17 @@ func createAccounts(chain *solo.Chain) (accounts []*ecdsa.PrivateKey) {
 }
 
 func start(cmd *cobra.Command, args []string) {
-	ctx, chain := initSolo()
+	ctx, chain, loaded := initSolo()
 	defer ctx.cleanupAll()
 
-	accounts := createAccounts(chain)
+	accounts := createAccounts(chain, loaded)
+
+	if dbPath != "" {
+		// persist the chain after each block, so that nothing is lost if evmemulator is killed
+		saveSolo(chain)
+		chain.OnBlock(func() { saveSolo(chain) })
+		log.Printf("chain is saved to %s after each block\n", dbPath)
+	}
 
 	jsonRPCServer, err := jsonrpc.NewServer(
 		chain.EVM(),
@@ -61,6 +61,7 @@ func main() {
 	replaceTopicNames := make(map[string]string)
 	replaceTopicNames["app"] = "Application"
 	replaceTopicNames["inx"] = "INX"
+	replaceTopicNames["utxodb"] = "In-process L1 Ledger"
 	replaceTopicNames["log"] = "Shutdown Log"
 	replaceTopicNames["db"] = "Database"
 	replaceTopicNames["jwt"] = "JWT Auth"
@@ -0,0 +1,192 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nnikolash/wasp-types-exported/tools/schema/generator/soltemplates"
+	"github.com/nnikolash/wasp-types-exported/tools/schema/model"
+)
+
+// solidityReserved contains the keywords and type names that cannot be used as Solidity field names
+var solidityReserved = map[string]bool{}
+
+func init() {
+	reserved := "abstract address after alias anonymous apply assembly auto bool break byte bytes bytes32 " +
+		"calldata case catch constant constructor continue contract copyof default define delete do else emit " +
+		"enum event external fallback false final fixed for function if immutable implements import in indexed " +
+		"inline int int8 int16 int32 int64 int256 interface internal is let library macro mapping match memory " +
+		"modifier mutable new null of override partial payable pragma private promise public pure receive " +
+		"reference relocatable return returns revert sealed sizeof static storage string struct super supports " +
+		"switch this throw true try type typedef typeof ufixed uint uint8 uint16 uint32 uint64 uint256 unchecked " +
+		"using var view virtual while"
+	for _, name := range strings.Split(reserved, " ") {
+		solidityReserved[name] = true
+	}
+}
+
+// SolidityGenerator generates a typed Solidity interface for the Wasm contract,
+// and a contract implementing it, which allows EVM contracts to call the Wasm
+// contract through the ISC magic contract.
+// The Wasm contract itself is still implemented in one of the other languages.
+type SolidityGenerator struct {
+	Generator
+}
+
+var _ IGenerator = new(SolidityGenerator)
+
+func NewSolidityGenerator(s *model.Schema) *SolidityGenerator {
+	g := &SolidityGenerator{}
+	g.init(s, soltemplates.TypeDependent, soltemplates.Templates)
+	g.emitters["solField"] = emitSolidityField
+	g.emitters["solMapKey"] = emitSolidityMapKey
+	g.emitters["solParamsSize"] = emitSolidityParamsSize
+	return g
+}
+
+// Build does nothing, because the generated contract is compiled as part of the EVM contracts that import it
+func (g *SolidityGenerator) Build() error {
+	return nil
+}
+
+func (g *SolidityGenerator) Cleanup() {
+	g.generateCommonFolder("", false)
+	g.cleanFolder(g.folder)
+
+	// remove root folder, but only when there is nothing else in it
+	_ = os.Remove(g.rootFolder)
+}
+
+func (g *SolidityGenerator) GenerateImplementation() error {
+	return nil
+}
+
+func (g *SolidityGenerator) GenerateInterface() error {
+	err := os.MkdirAll(g.folder, 0o755)
+	if err != nil {
+		return err
+	}
+	err = g.createSourceFile("consts", true)
+	if err != nil {
+		return err
+	}
+	err = g.createSourceFile("interface", true)
+	if err != nil {
+		return err
+	}
+	return g.createSourceFile("contract", true)
+}
+
+func (g *SolidityGenerator) GenerateTests() error {
+	return nil
+}
+
+func (g *SolidityGenerator) GenerateWasmStub() error {
+	return nil
+}
+
+// emitSolidityField determines how the current field is passed to and from Solidity.
+// Typedefs are resolved to the type they define. Structs are passed as Solidity
+// structs, which are encoded like WasmLib encodes them. Arrays are passed as
+// Solidity arrays and maps as arrays of key/value entry structs, their elements
+// are spread over multiple keys like WasmLib does.
+func emitSolidityField(g *Generator) {
+	fld := g.currentField
+	for _, typeDef := range g.s.Typedefs {
+		if typeDef.Name == fld.Type {
+			fld = typeDef
+			break
+		}
+	}
+
+	codec := fld.Type
+	for key, typeValues := range g.typeDependent {
+		g.keys[key] = typeValues[codec]
+	}
+	g.keys["fldCodec"] = codec
+	g.keys["fldElemType"] = g.keys["fldLangType"]
+	g.keys["fldElemParamType"] = g.keys["fldParamType"]
+	g.keys["fldStruct"] = ""
+	if !fld.IsBaseType {
+		for _, g.currentStruct = range g.s.Structs {
+			if g.currentStruct.Name.Val == codec {
+				break
+			}
+		}
+		g.keys["fldElemType"] = codec
+		g.keys["fldElemParamType"] = codec + " memory"
+		g.keys["fldStruct"] = KeyTrue
+	}
+
+	g.keys["fldContainer"] = ""
+	g.keys["fldMapEntry"] = ""
+	g.keys["fldKeyCodec"] = fld.MapKey
+	g.keys["fldKeyType"] = g.typeDependent["fldLangType"][fld.MapKey]
+	switch {
+	case fld.IsArray:
+		g.keys["fldContainer"] = codec + "Array"
+		g.keys["fldLangType"] = g.keys["fldElemType"] + "[]"
+	case fld.MapKey != "":
+		g.keys["fldContainer"] = fld.MapKey + "To" + codec + "Map"
+		g.keys["fldMapEntry"] = fld.MapKey + "To" + codec + "Entry"
+		g.keys["fldLangType"] = g.keys["fldMapEntry"] + "[]"
+	default:
+		g.keys["fldLangType"] = g.keys["fldElemType"]
+	}
+	g.keys["fldParamType"] = g.keys["fldLangType"]
+	if fld.IsArray || fld.MapKey != "" || !fld.IsBaseType {
+		g.keys["fldParamType"] += " memory"
+	}
+
+	// the items of a container with the alias "this" have no key prefix
+	g.keys["solKey"] = `""`
+	if g.currentField.Alias != KeyThis {
+		g.keys["solKey"] = "bytes(" + g.s.ContractName + "Consts." + g.keys["solPrefix"] + "_" + g.keys["FLD_NAME"] + ")"
+	}
+
+	if solidityReserved[g.keys["fldName"]] {
+		g.keys["fldName"] += "_"
+	}
+}
+
+// emitSolidityMapKey switches the codec keys over to the key type of the current map field,
+// so that its codec can be generated. Use solField to switch back to the field itself.
+func emitSolidityMapKey(g *Generator) {
+	codec := g.keys["fldKeyCodec"]
+	for key, typeValues := range g.typeDependent {
+		g.keys[key] = typeValues[codec]
+	}
+	g.keys["fldCodec"] = codec
+	g.keys["fldElemParamType"] = g.keys["fldParamType"]
+}
+
+// emitSolidityParamsSize determines the maximum number of dict items that are
+// needed for the params of the current function, and whether it has optional params
+func emitSolidityParamsSize(g *Generator) {
+	size := 0
+	items := ""
+	optional := ""
+	for _, g.currentField = range g.currentFunc.Params {
+		g.setFieldKeys(false, 0, 0)
+		emitSolidityField(g)
+		switch {
+		case g.keys["fldMapEntry"] != "":
+			items += " + params." + g.keys["fldName"] + ".length"
+		case g.keys["fldContainer"] != "":
+			// the array length is stored as well
+			size++
+			items += " + params." + g.keys["fldName"] + ".length"
+		default:
+			size++
+		}
+		if g.currentField.IsOptional {
+			optional = KeyTrue
+		}
+	}
+	g.keys["solParamsSize"] = strconv.Itoa(size) + items
+	g.keys["solParamsOptional"] = optional
+}
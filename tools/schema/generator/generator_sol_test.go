@@ -0,0 +1,148 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nnikolash/wasp-types-exported/tools/schema/model"
+	"github.com/nnikolash/wasp-types-exported/tools/schema/model/yaml"
+)
+
+func generateSolidity(t *testing.T, contract string) (consts, interfaceSol, contractSol string) {
+	schemaPath, err := filepath.Abs("../../../contracts/wasm/" + contract + "/schema.yaml")
+	require.NoError(t, err)
+	data, err := os.ReadFile(schemaPath)
+	require.NoError(t, err)
+	return generateSoliditySchema(t, data)
+}
+
+func generateSoliditySchema(t *testing.T, data []byte) (consts, interfaceSol, contractSol string) {
+	schemaDef := model.NewSchemaDef()
+	require.NoError(t, yaml.Unmarshal(data, schemaDef))
+	s := model.NewSchema()
+	require.NoError(t, s.Compile(schemaDef))
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { require.NoError(t, os.Chdir(cwd)) }()
+
+	g := NewSolidityGenerator(s)
+	require.False(t, g.IsLatest())
+	require.NoError(t, g.GenerateInterface())
+
+	contract := s.PackageName
+	b, err := os.ReadFile("sol/" + contract + "/consts.sol")
+	require.NoError(t, err)
+	consts = string(b)
+	b, err = os.ReadFile("sol/" + contract + "/interface.sol")
+	require.NoError(t, err)
+	interfaceSol = string(b)
+	b, err = os.ReadFile("sol/" + contract + "/contract.sol")
+	require.NoError(t, err)
+	contractSol = string(b)
+
+	g.Cleanup()
+	_, err = os.Stat("sol")
+	require.True(t, os.IsNotExist(err))
+	return consts, interfaceSol, contractSol
+}
+
+func TestSolidityGenerator(t *testing.T) {
+	consts, iface, contract := generateSolidity(t, "fairauction")
+	require.NotContains(t, consts, "???")
+	require.NotContains(t, iface, "???")
+	require.NotContains(t, contract, "???")
+
+	require.Contains(t, consts, "library FairAuctionConsts {")
+	require.Contains(t, consts, "ISCHname constant HSC_NAME = ISCHname.wrap(0x1b5c43b1);")
+	require.Contains(t, consts, `string constant PARAM_MINIMUM_BID = "minimumBid";`)
+
+	require.Contains(t, iface, "interface IFairAuction {")
+	require.Contains(t, iface, "function startAuction(StartAuctionParams calldata params, ISCAssets calldata allowance) external;")
+	require.Contains(t, iface, "function getAuctionInfo(GetAuctionInfoParams calldata params) external view returns (GetAuctionInfoResults memory results);")
+
+	require.Contains(t, contract, "contract FairAuction is IFairAuction {")
+	require.Contains(t, contract, "function startAuction(StartAuctionParams calldata params, ISCAssets calldata allowance) external override {")
+	require.Contains(t, contract, "results.highestBidder = _decodeAgentID(_get(dict, bytes(FairAuctionConsts.RESULT_HIGHEST_BIDDER)));")
+
+	// init can only be called on deployment
+	require.NotContains(t, iface, "function init(")
+	require.NotContains(t, contract, "function init(")
+
+	// optional params have a presence flag
+	require.Contains(t, iface, "        bool hasDuration;\n        uint32 duration;\n")
+	require.Contains(t, contract, "        if (params.hasDuration) {\n            dict.items[n++] = ISCDictItem(bytes(FairAuctionConsts.PARAM_DURATION), _encodeUint32(params.duration));\n        }\n")
+	require.Contains(t, contract, "dict.items[n++] = ISCDictItem(bytes(FairAuctionConsts.PARAM_MINIMUM_BID), _encodeUint64(params.minimumBid));")
+}
+
+func TestSolidityGeneratorFieldKinds(t *testing.T) {
+	_, iface, contract := generateSolidity(t, "testwasmlib")
+	require.NotContains(t, iface, "???")
+	require.NotContains(t, contract, "???")
+
+	// field names are escaped when they are Solidity keywords or types
+	require.Contains(t, iface, "        bool hasAddress;\n        L1Address address_;\n")
+	require.Contains(t, iface, "        string string_;")
+
+	// arrays are passed as typed arrays, which are stored as WasmLib arrays
+	require.Contains(t, iface, "        L1Address[] valueAddr;")
+	require.Contains(t, contract, "dict.items = new ISCDictItem[](2 + params.valueAddr.length);")
+	require.Contains(t, contract, "n = _encodeAddressArray(dict.items, n, bytes(TestWasmLibConsts.PARAM_VALUE_ADDR), params.valueAddr);")
+
+	// the entries of a map with the alias "this" are stored under their own key
+	require.Contains(t, iface, "    struct StringToBytesEntry {\n        string key;\n        bytes value;\n    }\n")
+	require.Contains(t, contract, "n = _encodeStringToBytesMap(dict.items, n, \"\", params.param);")
+}
+
+func TestSolidityGeneratorStructs(t *testing.T) {
+	_, iface, contract := generateSoliditySchema(t, []byte(`
+name: Points
+description: Structs in arrays and maps
+structs:
+  Point:
+    label: String
+    x: Int32
+    y: BigInt
+typedefs:
+  PointArray: Point[]
+funcs:
+  setPoints:
+    params:
+      point: Point
+      points: PointArray?
+views:
+  getPoints:
+    results:
+      named: map[Hname]Point?
+      points: Point[]
+`))
+	require.NotContains(t, iface, "???")
+	require.NotContains(t, contract, "???")
+
+	// structs are passed as Solidity structs, typedefs are resolved
+	require.Contains(t, iface, "    struct Point {\n        string label;\n        int32 x;\n        uint256 y;\n    }\n")
+	require.Contains(t, iface, "        Point point;\n        bool hasPoints;\n        Point[] points;\n")
+	require.Contains(t, iface, "        bool hasNamed;\n        HnameToPointEntry[] named;\n")
+
+	// struct fields are encoded like the WasmLib struct encoder does
+	require.Contains(t, contract, "        buf = bytes.concat(buf, _encodeStringField(value.label));\n")
+	require.Contains(t, contract, "        (value.y, offset) = _decodeBigIntField(buf, offset);\n")
+	require.Contains(t, contract, "        return _sized(_reverse(_encodeBigInt(value)));\n")
+	require.Contains(t, contract, "        (bytes memory value, uint256 next) = _readBytes(buf, offset, 4);\n")
+
+	// containers are decoded from their items
+	require.Contains(t, contract, "        results.named = _decodeHnameToPointMap(dict, bytes(PointsConsts.RESULT_NAMED));\n        // a map has no key of its own, so it exists when it has entries\n        results.hasNamed = results.named.length != 0;\n")
+	require.Contains(t, contract, "        results.points = _decodePointArray(dict, bytes(PointsConsts.RESULT_POINTS));\n")
+
+	// each codec is only generated once
+	require.Equal(t, 1, strings.Count(contract, "function _encodePoint("))
+	require.Equal(t, 1, strings.Count(contract, "function _encodePointArray("))
+}
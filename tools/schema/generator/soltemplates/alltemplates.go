@@ -0,0 +1,204 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package soltemplates
+
+import "github.com/nnikolash/wasp-types-exported/tools/schema/model"
+
+var config = map[string]string{
+	"language":   "Solidity",
+	"extension":  ".sol",
+	"rootFolder": "sol",
+	"funcRegexp": `^\s*function (\w+).+$`,
+}
+
+var Templates = []map[string]string{
+	config, // always first one
+	common,
+	constsSol,
+	interfaceSol,
+	contractSol,
+}
+
+// TypeDependent describes how each field type maps onto Solidity.
+// Values are encoded exactly like WasmLib does, so that the Wasm contract
+// can decode the parameters and the results can be decoded in Solidity.
+// Arrays, maps and structs are composed from these in generator_sol.go.
+// Inside a struct the fields are encoded like the WasmLib struct encoder
+// does: fixed size types take fldStructSize bytes, the other types (size 0)
+// are prefixed with their length, and a BigInt is stored in little-endian order.
+var TypeDependent = model.StringMapMap{
+	"fldLangType": {
+		"Address":   "L1Address",
+		"AgentID":   "ISCAgentID",
+		"BigInt":    "uint256",
+		"Bool":      "bool",
+		"Bytes":     "bytes",
+		"ChainID":   "ISCChainID",
+		"Hash":      "bytes32",
+		"Hname":     "ISCHname",
+		"Int8":      "int8",
+		"Int16":     "int16",
+		"Int32":     "int32",
+		"Int64":     "int64",
+		"NftID":     "NFTID",
+		"RequestID": "ISCRequestID",
+		"String":    "string",
+		"TokenID":   "NativeTokenID",
+		"Uint8":     "uint8",
+		"Uint16":    "uint16",
+		"Uint32":    "uint32",
+		"Uint64":    "uint64",
+	},
+	"fldParamType": {
+		"Address":   "L1Address memory",
+		"AgentID":   "ISCAgentID memory",
+		"BigInt":    "uint256",
+		"Bool":      "bool",
+		"Bytes":     "bytes memory",
+		"ChainID":   "ISCChainID",
+		"Hash":      "bytes32",
+		"Hname":     "ISCHname",
+		"Int8":      "int8",
+		"Int16":     "int16",
+		"Int32":     "int32",
+		"Int64":     "int64",
+		"NftID":     "NFTID",
+		"RequestID": "ISCRequestID memory",
+		"String":    "string memory",
+		"TokenID":   "NativeTokenID memory",
+		"Uint8":     "uint8",
+		"Uint16":    "uint16",
+		"Uint32":    "uint32",
+		"Uint64":    "uint64",
+	},
+	"fldEncode": {
+		"Address":   "value.data",
+		"AgentID":   "value.data",
+		"BigInt":    "_encodeBE(value)",
+		"Bool":      "abi.encodePacked(value)",
+		"Bytes":     "value",
+		"ChainID":   "abi.encodePacked(ISCChainID.unwrap(value))",
+		"Hash":      "abi.encodePacked(value)",
+		"Hname":     "_encodeLE(ISCHname.unwrap(value), 4)",
+		"Int8":      "_encodeLE(uint8(value), 1)",
+		"Int16":     "_encodeLE(uint16(value), 2)",
+		"Int32":     "_encodeLE(uint32(value), 4)",
+		"Int64":     "_encodeLE(uint64(value), 8)",
+		"NftID":     "abi.encodePacked(NFTID.unwrap(value))",
+		"RequestID": "value.data",
+		"String":    "bytes(value)",
+		"TokenID":   "value.data",
+		"Uint8":     "_encodeLE(value, 1)",
+		"Uint16":    "_encodeLE(value, 2)",
+		"Uint32":    "_encodeLE(value, 4)",
+		"Uint64":    "_encodeLE(value, 8)",
+	},
+	"fldDecode": {
+		"Address":   "L1Address(value)",
+		"AgentID":   "ISCAgentID(value)",
+		"BigInt":    "_decodeBE(value)",
+		"Bool":      "value.length != 0 && value[0] != 0",
+		"Bytes":     "value",
+		"ChainID":   "ISCChainID.wrap(_decodeBytes32(value))",
+		"Hash":      "_decodeBytes32(value)",
+		"Hname":     "ISCHname.wrap(uint32(_decodeLE(value, 4)))",
+		"Int8":      "int8(uint8(_decodeLE(value, 1)))",
+		"Int16":     "int16(uint16(_decodeLE(value, 2)))",
+		"Int32":     "int32(uint32(_decodeLE(value, 4)))",
+		"Int64":     "int64(uint64(_decodeLE(value, 8)))",
+		"NftID":     "NFTID.wrap(_decodeBytes32(value))",
+		"RequestID": "ISCRequestID(value)",
+		"String":    "string(value)",
+		"TokenID":   "NativeTokenID(value)",
+		"Uint8":     "uint8(_decodeLE(value, 1))",
+		"Uint16":    "uint16(_decodeLE(value, 2))",
+		"Uint32":    "uint32(_decodeLE(value, 4))",
+		"Uint64":    "uint64(_decodeLE(value, 8))",
+	},
+	"fldStructSize": {
+		"Address":   "33",
+		"AgentID":   "0",
+		"BigInt":    "0",
+		"Bool":      "1",
+		"Bytes":     "0",
+		"ChainID":   "32",
+		"Hash":      "32",
+		"Hname":     "4",
+		"Int8":      "1",
+		"Int16":     "2",
+		"Int32":     "4",
+		"Int64":     "8",
+		"NftID":     "32",
+		"RequestID": "34",
+		"String":    "0",
+		"TokenID":   "38",
+		"Uint8":     "1",
+		"Uint16":    "2",
+		"Uint32":    "4",
+		"Uint64":    "8",
+	},
+	"fldStructEncode": {
+		"Address":   "_encodeAddress(value)",
+		"AgentID":   "_sized(_encodeAgentID(value))",
+		"BigInt":    "_sized(_reverse(_encodeBigInt(value)))",
+		"Bool":      "_encodeBool(value)",
+		"Bytes":     "_sized(_encodeBytes(value))",
+		"ChainID":   "_encodeChainID(value)",
+		"Hash":      "_encodeHash(value)",
+		"Hname":     "_encodeHname(value)",
+		"Int8":      "_encodeInt8(value)",
+		"Int16":     "_encodeInt16(value)",
+		"Int32":     "_encodeInt32(value)",
+		"Int64":     "_encodeInt64(value)",
+		"NftID":     "_encodeNftID(value)",
+		"RequestID": "_encodeRequestID(value)",
+		"String":    "_sized(_encodeString(value))",
+		"TokenID":   "_encodeTokenID(value)",
+		"Uint8":     "_encodeUint8(value)",
+		"Uint16":    "_encodeUint16(value)",
+		"Uint32":    "_encodeUint32(value)",
+		"Uint64":    "_encodeUint64(value)",
+	},
+	"fldStructDecode": {
+		"Address":   "_decodeAddress(value)",
+		"AgentID":   "_decodeAgentID(value)",
+		"BigInt":    "_decodeBigInt(_reverse(value))",
+		"Bool":      "_decodeBool(value)",
+		"Bytes":     "_decodeBytes(value)",
+		"ChainID":   "_decodeChainID(value)",
+		"Hash":      "_decodeHash(value)",
+		"Hname":     "_decodeHname(value)",
+		"Int8":      "_decodeInt8(value)",
+		"Int16":     "_decodeInt16(value)",
+		"Int32":     "_decodeInt32(value)",
+		"Int64":     "_decodeInt64(value)",
+		"NftID":     "_decodeNftID(value)",
+		"RequestID": "_decodeRequestID(value)",
+		"String":    "_decodeString(value)",
+		"TokenID":   "_decodeTokenID(value)",
+		"Uint8":     "_decodeUint8(value)",
+		"Uint16":    "_decodeUint16(value)",
+		"Uint32":    "_decodeUint32(value)",
+		"Uint64":    "_decodeUint64(value)",
+	},
+}
+
+var common = map[string]string{
+	// *******************************
+	"pragma": `
+pragma solidity >=0.8.11;
+`,
+	// *******************************
+	"_fldComment": `
+        $nextLine
+`,
+	// *******************************
+	"_funcComment": `
+    $nextLine
+`,
+	// *******************************
+	"_structComment": `
+    $nextLine
+`,
+}
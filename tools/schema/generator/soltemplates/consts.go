@@ -0,0 +1,53 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package soltemplates
+
+var constsSol = map[string]string{
+	// *******************************
+	"consts.sol": `
+$#emit pragma
+
+import "@iota/iscmagic/ISCTypes.sol";
+
+library $Package$+Consts {
+    string constant SC_NAME = "$scName";
+    string constant SC_DESCRIPTION = "$scDesc";
+    ISCHname constant HSC_NAME = ISCHname.wrap(0x$hscName);
+$#if params constParams
+$#if results constResults
+$#if funcs constFuncs
+}
+`,
+	// *******************************
+	"constParams": `
+
+$#set constPrefix PARAM
+$#each params constField
+`,
+	// *******************************
+	"constResults": `
+
+$#set constPrefix RESULT
+$#each results constField
+`,
+	// *******************************
+	"constFuncs": `
+
+$#each func constFunc
+
+$#each func constHFunc
+`,
+	// *******************************
+	"constField": `
+    string constant $constPrefix$+_$FLD_NAME = "$fldAlias";
+`,
+	// *******************************
+	"constFunc": `
+    string constant $KIND$+_$FUNC_NAME = "$funcAlias";
+`,
+	// *******************************
+	"constHFunc": `
+    ISCHname constant H$KIND$+_$FUNC_NAME = ISCHname.wrap(0x$hFuncName);
+`,
+}
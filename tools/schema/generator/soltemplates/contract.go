@@ -0,0 +1,468 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package soltemplates
+
+var contractSol = map[string]string{
+	// *******************************
+	"contract.sol": `
+$#emit pragma
+
+import "@iota/iscmagic/ISC.sol";
+import "./consts.sol";
+import "./interface.sol";
+
+// $Package implements I$Package by calling the Wasm contract through the ISC
+// magic contract, so this contract is the sender that the Wasm contract sees.
+contract $Package is I$Package {
+$#set solOverride  override
+$#set solBody  {
+$#each func solFunc
+$#each func solFuncCodecs
+$#emit solHelpers
+}
+`,
+	// *******************************
+	"solFunc": `
+$#if init else solFuncNotInit
+`,
+	// *******************************
+	"solFuncNotInit": `
+$#emit solFuncSignatureNotInit
+$#if func solTakeAllowance
+$#if param solEncodeParams solNoParams
+$#if func solSetCallFunc solSetCallView
+$#if result solCallResults solCall
+    }
+`,
+	// *******************************
+	"solTakeAllowance": `
+        _takeAllowance(allowance);
+`,
+	// *******************************
+	"solEncodeParams": `
+        ISCDict memory dict = _encode$FuncName$+Params(params);
+`,
+	// *******************************
+	"solNoParams": `
+        ISCDict memory dict;
+`,
+	// *******************************
+	"solSetCallFunc": `
+$#set solCall ISC.sandbox.call($Package$+Consts.HSC_NAME, $Package$+Consts.H$KIND$+_$FUNC_NAME, dict, allowance)
+`,
+	// *******************************
+	"solSetCallView": `
+$#set solCall ISC.sandbox.callView($Package$+Consts.HSC_NAME, $Package$+Consts.H$KIND$+_$FUNC_NAME, dict)
+`,
+	// *******************************
+	"solCall": `
+        $solCall;
+`,
+	// *******************************
+	"solCallResults": `
+        results = _decode$FuncName$+Results($solCall);
+`,
+	// *******************************
+	"solFuncCodecs": `
+$#if init else solFuncCodecsNotInit
+`,
+	// *******************************
+	"solFuncCodecsNotInit": `
+$#if param solEncodeParamsFunc
+$#if result solDecodeResultsFunc
+$#set solPrefix PARAM
+$#each param solFieldCodecs
+$#set solPrefix RESULT
+$#each result solFieldCodecs
+`,
+	// *******************************
+	"solEncodeParamsFunc": `
+
+    function _encode$FuncName$+Params($FuncName$+Params memory params) private pure returns (ISCDict memory dict) {
+$#func solParamsSize
+        dict.items = new ISCDictItem[]($solParamsSize);
+        uint256 n = 0;
+$#set solPrefix PARAM
+$#each param solParamEncode
+$#if solParamsOptional solShrinkItems
+    }
+`,
+	// *******************************
+	"solShrinkItems": `
+        ISCDictItem[] memory items = dict.items;
+        assembly {
+            // drop the slots of omitted optional parameters
+            mstore(items, n)
+        }
+`,
+	// *******************************
+	"solParamEncode": `
+$#func solField
+$#set solIndent $nil
+$#if mandatory solParamEncodeValue solParamEncodeOptional
+`,
+	// *******************************
+	"solParamEncodeOptional": `
+        if (params.has$FldName) {
+$#set solIndent $space$space$space$space
+$#emit solParamEncodeValue
+        }
+`,
+	// *******************************
+	"solParamEncodeValue": `
+$#if fldContainer solParamEncodeContainer solParamEncodeSingle
+`,
+	// *******************************
+	"solParamEncodeSingle": `
+$solIndent        dict.items[n++] = ISCDictItem($solKey, _encode$fldCodec(params.$fldName));
+`,
+	// *******************************
+	"solParamEncodeContainer": `
+$solIndent        n = _encode$fldContainer(dict.items, n, $solKey, params.$fldName);
+`,
+	// *******************************
+	"solDecodeResultsFunc": `
+
+    function _decode$FuncName$+Results(ISCDict memory dict) private pure returns ($FuncName$+Results memory results) {
+$#set solPrefix RESULT
+$#each result solResultDecode
+    }
+`,
+	// *******************************
+	"solResultDecode": `
+$#func solField
+$#if fldContainer solResultDecodeContainer solResultDecodeSingle
+`,
+	// *******************************
+	"solResultDecodeSingle": `
+$#if mandatory else solResultDecodeExists
+        results.$fldName = _decode$fldCodec(_get(dict, $solKey));
+`,
+	// *******************************
+	"solResultDecodeContainer": `
+$#if fldMapEntry else solResultDecodeExists
+        results.$fldName = _decode$fldContainer(dict, $solKey);
+$#if fldMapEntry solResultDecodeMapExists
+`,
+	// *******************************
+	"solResultDecodeExists": `
+$#if mandatory else solResultDecodeExistsKey
+`,
+	// *******************************
+	"solResultDecodeExistsKey": `
+        results.has$FldName = _exists(dict, $solKey);
+`,
+	// *******************************
+	"solResultDecodeMapExists": `
+$#if mandatory else solResultDecodeMapExistsEntries
+`,
+	// *******************************
+	"solResultDecodeMapExistsEntries": `
+        // a map has no key of its own, so it exists when it has entries
+        results.has$FldName = results.$fldName.length != 0;
+`,
+	// *******************************
+	"solFieldCodecs": `
+$#func solField
+$#if fldContainer solContainerCodec
+$#if fldMapEntry solMapKeyCodec
+$#if fldStruct solStructCodec solValueCodec
+`,
+	// *******************************
+	"solMapKeyCodec": `
+$#func solMapKey
+$#emit solValueCodec
+$#func solField
+`,
+	// *******************************
+	"solValueCodec": `
+$#set proxy $fldCodec
+$#if exist else solValueCodecNew
+$#set exist $fldCodec
+`,
+	// *******************************
+	"solValueCodecNew": `
+
+    function _encode$fldCodec($fldElemParamType value) private pure returns (bytes memory) {
+        return $fldEncode;
+    }
+
+    function _decode$fldCodec(bytes memory value) private pure returns ($fldElemParamType) {
+        return $fldDecode;
+    }
+`,
+	// *******************************
+	"solContainerCodec": `
+$#set proxy $fldContainer
+$#if exist else solContainerCodecNew
+$#set exist $fldContainer
+`,
+	// *******************************
+	"solContainerCodecNew": `
+$#if fldMapEntry solMapCodec solArrayCodec
+`,
+	// *******************************
+	"solArrayCodec": `
+
+    // _encode$fldContainer stores the length under the key itself, and the elements under key#index
+    function _encode$fldContainer(ISCDictItem[] memory items, uint256 n, bytes memory key, $fldElemType[] memory values) private pure returns (uint256) {
+        items[n++] = ISCDictItem(key, _encodeVlu(values.length));
+        for (uint256 i = 0; i < values.length; i++) {
+            items[n++] = ISCDictItem(_subKey(key, "#", _encodeVlu(i)), _encode$fldCodec(values[i]));
+        }
+        return n;
+    }
+
+    function _decode$fldContainer(ISCDict memory dict, bytes memory key) private pure returns ($fldElemType[] memory values) {
+        values = new $fldElemType[](_decodeVlu(_get(dict, key)));
+        for (uint256 i = 0; i < dict.items.length; i++) {
+            (bool ok, bytes memory index) = _isSubKey(dict.items[i].key, key, "#");
+            if (ok) {
+                values[_decodeVlu(index)] = _decode$fldCodec(dict.items[i].value);
+            }
+        }
+    }
+`,
+	// *******************************
+	"solMapCodec": `
+
+    // _encode$fldContainer stores the entries under key.mapKey
+    function _encode$fldContainer(ISCDictItem[] memory items, uint256 n, bytes memory key, $fldMapEntry[] memory entries) private pure returns (uint256) {
+        for (uint256 i = 0; i < entries.length; i++) {
+            items[n++] = ISCDictItem(_subKey(key, ".", _encode$fldKeyCodec(entries[i].key)), _encode$fldCodec(entries[i].value));
+        }
+        return n;
+    }
+
+    function _decode$fldContainer(ISCDict memory dict, bytes memory key) private pure returns ($fldMapEntry[] memory entries) {
+        entries = new $fldMapEntry[](dict.items.length);
+        uint256 n = 0;
+        for (uint256 i = 0; i < dict.items.length; i++) {
+            (bool ok, bytes memory mapKey) = _isSubKey(dict.items[i].key, key, ".");
+            if (ok) {
+                entries[n++] = $fldMapEntry(_decode$fldKeyCodec(mapKey), _decode$fldCodec(dict.items[i].value));
+            }
+        }
+        assembly {
+            // drop the slots of the items that are not entries of the map
+            mstore(entries, n)
+        }
+    }
+`,
+	// *******************************
+	"solStructCodec": `
+$#set proxy $fldCodec
+$#if exist else solStructCodecNew
+`,
+	// *******************************
+	"solStructCodecNew": `
+$#set solStruct $fldCodec
+$#set exist $fldCodec
+
+    function _encode$solStruct($solStruct memory value) private pure returns (bytes memory buf) {
+$#each struct solStructFieldEncode
+    }
+
+    function _decode$solStruct(bytes memory buf) private pure returns ($solStruct memory value) {
+        if (buf.length == 0) {
+            // a missing value decodes to zero, like it does for the other types
+            return value;
+        }
+        uint256 offset = 0;
+$#each struct solStructFieldDecode
+        require(offset == buf.length, "extra bytes");
+    }
+$#each struct solStructFieldCodecs
+`,
+	// *******************************
+	"solStructFieldEncode": `
+$#func solField
+        buf = bytes.concat(buf, _encode$fldCodec$+Field(value.$fldName));
+`,
+	// *******************************
+	"solStructFieldDecode": `
+$#func solField
+        (value.$fldName, offset) = _decode$fldCodec$+Field(buf, offset);
+`,
+	// *******************************
+	"solStructFieldCodecs": `
+$#func solField
+$#emit solValueCodec
+$#set proxy $fldCodec$+Field
+$#if exist else solStructFieldCodecNew
+$#set exist $fldCodec$+Field
+`,
+	// *******************************
+	"solStructFieldCodecNew": `
+
+    function _encode$fldCodec$+Field($fldElemParamType value) private pure returns (bytes memory) {
+        return $fldStructEncode;
+    }
+
+    function _decode$fldCodec$+Field(bytes memory buf, uint256 offset) private pure returns ($fldElemParamType, uint256) {
+        (bytes memory value, uint256 next) = _readBytes(buf, offset, $fldStructSize);
+        return ($fldStructDecode, next);
+    }
+`,
+	// *******************************
+	"solHelpers": `
+
+    // _takeAllowance takes the assets that the caller allowed, an empty allowance takes nothing
+    function _takeAllowance(ISCAssets calldata allowance) private {
+        if (allowance.baseTokens != 0 || allowance.nativeTokens.length != 0 || allowance.nfts.length != 0) {
+            ISC.sandbox.takeAllowedFunds(msg.sender, allowance);
+        }
+    }
+
+    function _exists(ISCDict memory dict, bytes memory key) private pure returns (bool) {
+        for (uint256 i = 0; i < dict.items.length; i++) {
+            if (keccak256(dict.items[i].key) == keccak256(key)) {
+                return true;
+            }
+        }
+        return false;
+    }
+
+    // _get returns the value of the key, a missing key returns no bytes, which decode to zero
+    function _get(ISCDict memory dict, bytes memory key) private pure returns (bytes memory) {
+        for (uint256 i = 0; i < dict.items.length; i++) {
+            if (keccak256(dict.items[i].key) == keccak256(key)) {
+                return dict.items[i].value;
+            }
+        }
+        return "";
+    }
+
+    // _subKey returns the key of an array element or map entry, the items of a
+    // container with the alias "this" are stored under their own key
+    function _subKey(bytes memory key, bytes1 sep, bytes memory sub) private pure returns (bytes memory) {
+        if (key.length == 0) {
+            return sub;
+        }
+        return bytes.concat(key, sep, sub);
+    }
+
+    // _isSubKey checks whether item is the key of an array element or map entry, and returns its sub key
+    function _isSubKey(bytes memory item, bytes memory key, bytes1 sep) private pure returns (bool, bytes memory sub) {
+        uint256 start = key.length;
+        if (start != 0) {
+            if (item.length <= start || item[start] != sep) {
+                return (false, sub);
+            }
+            for (uint256 i = 0; i < start; i++) {
+                if (item[i] != key[i]) {
+                    return (false, sub);
+                }
+            }
+            start++;
+        }
+        sub = new bytes(item.length - start);
+        for (uint256 i = 0; i < sub.length; i++) {
+            sub[i] = item[start + i];
+        }
+        return (true, sub);
+    }
+
+    // _encodeVlu encodes an unsigned integer in groups of 7 bits, like WasmLib does
+    function _encodeVlu(uint256 value) private pure returns (bytes memory buf) {
+        do {
+            bytes1 b = bytes1(uint8(value & 0x7f));
+            value >>= 7;
+            if (value != 0) {
+                b |= 0x80;
+            }
+            buf = bytes.concat(buf, b);
+        } while (value != 0);
+    }
+
+    function _decodeVlu(bytes memory buf) private pure returns (uint256 value) {
+        if (buf.length != 0) {
+            uint256 next;
+            (value, next) = _readVlu(buf, 0);
+            require(next == buf.length, "invalid length");
+        }
+    }
+
+    function _readVlu(bytes memory buf, uint256 offset) private pure returns (uint256 value, uint256 next) {
+        for (uint256 shift = 0; shift < 256; shift += 7) {
+            require(offset < buf.length, "insufficient bytes");
+            uint8 b = uint8(buf[offset++]);
+            value |= uint256(b & 0x7f) << shift;
+            if ((b & 0x80) == 0) {
+                return (value, offset);
+            }
+        }
+        revert("invalid length");
+    }
+
+    // _readBytes reads the next struct field, size 0 means that the field is prefixed with its length
+    function _readBytes(bytes memory buf, uint256 offset, uint256 size) private pure returns (bytes memory value, uint256 next) {
+        if (size == 0) {
+            (size, offset) = _readVlu(buf, offset);
+        }
+        require(offset + size <= buf.length, "insufficient bytes");
+        value = new bytes(size);
+        for (uint256 i = 0; i < size; i++) {
+            value[i] = buf[offset + i];
+        }
+        next = offset + size;
+    }
+
+    // _sized prefixes a variable length struct field with its length
+    function _sized(bytes memory value) private pure returns (bytes memory) {
+        return bytes.concat(_encodeVlu(value.length), value);
+    }
+
+    function _reverse(bytes memory buf) private pure returns (bytes memory value) {
+        value = new bytes(buf.length);
+        for (uint256 i = 0; i < buf.length; i++) {
+            value[i] = buf[buf.length - 1 - i];
+        }
+    }
+
+    // _encodeLE encodes an integer as size bytes in little-endian order, like WasmLib does
+    function _encodeLE(uint256 value, uint256 size) private pure returns (bytes memory buf) {
+        buf = new bytes(size);
+        for (uint256 i = 0; i < size; i++) {
+            buf[i] = bytes1(uint8(value >> (8 * i)));
+        }
+    }
+
+    function _decodeLE(bytes memory buf, uint256 size) private pure returns (uint256 value) {
+        require(buf.length == 0 || buf.length == size, "invalid integer length");
+        for (uint256 i = buf.length; i > 0; i--) {
+            value = (value << 8) | uint8(buf[i - 1]);
+        }
+    }
+
+    // _encodeBE encodes a BigInt as big-endian bytes without leading zeroes, like WasmLib does
+    function _encodeBE(uint256 value) private pure returns (bytes memory buf) {
+        uint256 size = 0;
+        for (uint256 v = value; v != 0; v >>= 8) {
+            size++;
+        }
+        buf = new bytes(size);
+        for (uint256 i = size; i > 0; i--) {
+            buf[i - 1] = bytes1(uint8(value));
+            value >>= 8;
+        }
+    }
+
+    function _decodeBE(bytes memory buf) private pure returns (uint256 value) {
+        require(buf.length <= 32, "invalid BigInt length");
+        for (uint256 i = 0; i < buf.length; i++) {
+            value = (value << 8) | uint8(buf[i]);
+        }
+    }
+
+    function _decodeBytes32(bytes memory buf) private pure returns (bytes32 value) {
+        if (buf.length != 0) {
+            require(buf.length == 32, "invalid bytes32 length");
+            assembly {
+                value := mload(add(buf, 32))
+            }
+        }
+    }
+`,
+}
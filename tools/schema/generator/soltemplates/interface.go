@@ -0,0 +1,130 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package soltemplates
+
+var interfaceSol = map[string]string{
+	// *******************************
+	"interface.sol": `
+$#emit pragma
+
+import "@iota/iscmagic/ISCTypes.sol";
+
+// $scDesc
+// Funcs take the allowance that the caller granted the implementing contract
+// with ISC.sandbox.allow(), and pass it on to the Wasm contract.
+interface I$Package {
+$#each structs solStruct
+$#each func solFuncTypes
+$#set solOverride $nil
+$#set solBody ;
+$#each func solFuncSignature
+}
+`,
+	// *******************************
+	"solStruct": `
+
+$#each structComment _structComment
+    struct $StrName {
+$#each struct solStructField
+    }
+`,
+	// *******************************
+	"solFuncTypes": `
+$#if init else solFuncTypesNotInit
+`,
+	// *******************************
+	"solFuncTypesNotInit": `
+$#each param solMapEntryStruct
+$#each result solMapEntryStruct
+$#if param solParamsStruct
+$#if result solResultsStruct
+`,
+	// *******************************
+	"solParamsStruct": `
+
+    struct $FuncName$+Params {
+$#each param solStructField
+    }
+`,
+	// *******************************
+	"solResultsStruct": `
+
+    struct $FuncName$+Results {
+$#each result solStructField
+    }
+`,
+	// *******************************
+	"solStructField": `
+$#func solField
+$#each fldComment _fldComment
+$#if mandatory else solStructFieldPresence
+        $fldLangType $fldName;
+`,
+	// *******************************
+	"solStructFieldPresence": `
+        bool has$FldName;
+`,
+	// *******************************
+	"solMapEntryStruct": `
+$#func solField
+$#if fldMapEntry solMapEntryStructCheck
+`,
+	// *******************************
+	"solMapEntryStructCheck": `
+$#set proxy $fldMapEntry
+$#if exist else solMapEntryStructNew
+$#set exist $fldMapEntry
+`,
+	// *******************************
+	"solMapEntryStructNew": `
+
+    struct $fldMapEntry {
+        $fldKeyType key;
+        $fldElemType value;
+    }
+`,
+	// *******************************
+	"solFuncSignature": `
+$#if init else solFuncSignatureNotInit
+`,
+	// *******************************
+	"solFuncSignatureNotInit": `
+$#set solReturns $nil
+$#if result solSetReturns
+$#if func solSetFuncArgs solSetViewArgs
+
+$#each funcComment _funcComment
+    function $funcName($solArgs) external$solView$solOverride$solReturns$solBody
+`,
+	// *******************************
+	"solSetReturns": `
+$#set solReturns  returns ($FuncName$+Results memory results)
+`,
+	// *******************************
+	"solSetFuncArgs": `
+$#set solView $nil
+$#if param solSetFuncArgsParams solSetFuncArgsNoParams
+`,
+	// *******************************
+	"solSetFuncArgsParams": `
+$#set solArgs $FuncName$+Params calldata params, ISCAssets calldata allowance
+`,
+	// *******************************
+	"solSetFuncArgsNoParams": `
+$#set solArgs ISCAssets calldata allowance
+`,
+	// *******************************
+	"solSetViewArgs": `
+$#set solView  view
+$#if param solSetViewArgsParams solSetViewArgsNoParams
+`,
+	// *******************************
+	"solSetViewArgsParams": `
+$#set solArgs $FuncName$+Params calldata params
+`,
+	// *******************************
+	"solSetViewArgsNoParams": `
+$#set solArgs $nil
+`,
+}
@@ -34,6 +34,7 @@ var (
 	flagGo      = flag.Bool("go", false, "generate Go code")
 	flagInit    = flag.String("init", "", "generate new folder with schema file for smart contract named <string>")
 	flagRust    = flag.Bool("rs", false, "generate Rust code")
+	flagSol     = flag.Bool("sol", false, "generate Solidity interface")
 	flagTs      = flag.Bool("ts", false, "generate TypScript code")
 	flagVersion = flag.Bool("version", false, "show schema tool version")
 )
@@ -174,6 +175,15 @@ func generateSchema(file *os.File, core ...bool) error {
 		}
 	}
 
+	// the core contracts already have Solidity interfaces in iscmagic
+	if *flagSol && !s.CoreContracts {
+		g := generator.NewSolidityGenerator(s)
+		err = generateSchemaFiles(g, s.CoreContracts)
+		if err != nil {
+			return err
+		}
+	}
+
 	if *flagTs {
 		g := generator.NewTypeScriptGenerator(s, "ts")
 		err = generateSchemaFiles(g, s.CoreContracts)
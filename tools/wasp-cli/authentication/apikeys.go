@@ -0,0 +1,185 @@
+package authentication
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/cliclients"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/config"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
+)
+
+func initCreateKeyCmd() *cobra.Command {
+	var node string
+	var expiresIn time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "create-key <username> <name> [permission...]",
+		Short: "Create an API key of a user",
+		Long: "Create an API key of a user. Without permissions the key has all the permissions of the user.\n" +
+			"The key is only shown once; send it in the X-API-Key header or set it in the " + cliclients.APIKeyEnvVar + " environment variable.",
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			request := apiclient.AddAPIKeyRequest{
+				Name:        args[1],
+				Permissions: args[2:],
+			}
+			if expiresIn > 0 {
+				expiresAt := time.Now().Add(expiresIn)
+				request.ExpiresAt = &expiresAt
+			}
+
+			client := cliclients.WaspClient(node)
+			result, _, err := client.UsersApi.
+				AddAPIKey(context.Background(), args[0]).
+				AddAPIKeyRequest(request).
+				Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("API key %q created: %s\n", args[1], result.Key)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "duration until the key expires (e.g. 720h), never if not set")
+
+	return cmd
+}
+
+func initListKeysCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "list-keys <username>",
+		Short: "List the API keys of a user",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			client := cliclients.WaspClient(node)
+			apiKeys, _, err := client.UsersApi.GetAPIKeys(context.Background(), args[0]).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			header := []string{"Name", "Permissions", "Created", "Expires"}
+			rows := make([][]string, len(apiKeys))
+			for i, apiKey := range apiKeys {
+				permissions := "all of the user"
+				if len(apiKey.Permissions) > 0 {
+					permissions = strings.Join(apiKey.Permissions, ", ")
+				}
+				expires := "never"
+				if apiKey.ExpiresAt != nil {
+					expires = apiKey.ExpiresAt.Format(time.RFC3339)
+				}
+				rows[i] = []string{apiKey.Name, permissions, apiKey.CreatedAt.Format(time.RFC3339), expires}
+			}
+			log.PrintTable(header, rows)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+
+	return cmd
+}
+
+func initRevokeKeyCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "revoke-key <username> <name>",
+		Short: "Revoke an API key of a user",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			client := cliclients.WaspClient(node)
+			_, err := client.UsersApi.DeleteAPIKey(context.Background(), args[0], args[1]).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("API key %q revoked\n", args[1])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+
+	return cmd
+}
+
+func initRevokeTokenCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "revoke-token [jwt]",
+		Short: "Revoke a JWT",
+		Long:  "Revoke the given JWT, or the stored token of the node, which is then logged out.",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			var token string
+			if len(args) == 1 {
+				token = args[0]
+			} else {
+				token = config.GetToken(node)
+			}
+			if token == "" {
+				log.Fatalf("no token to revoke")
+			}
+
+			client := cliclients.WaspClient(node)
+			_, err := client.AuthApi.
+				RevokeToken(context.Background()).
+				RevokeTokenRequest(apiclient.RevokeTokenRequest{Jwt: token}).
+				Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			if len(args) == 0 {
+				config.SetToken(node, "")
+			}
+			log.Printf("Token revoked\n")
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+
+	return cmd
+}
+
+func initRevokeUserTokensCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "revoke-user-tokens <username> [token id]",
+		Short: "Revoke the JWTs of a user",
+		Long:  "Revoke the JWT of a user with the given ID, or all the JWTs issued to the user until now.",
+		Args:  cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			client := cliclients.WaspClient(node)
+			if len(args) == 2 {
+				_, err := client.UsersApi.RevokeUserToken(context.Background(), args[0], args[1]).Execute() //nolint:bodyclose // false positive
+				log.Check(err)
+
+				log.Printf("Token %q of user %q revoked\n", args[1], args[0])
+				return
+			}
+
+			_, err := client.UsersApi.RevokeUserTokens(context.Background(), args[0]).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("All the tokens of user %q revoked\n", args[0])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+
+	return cmd
+}
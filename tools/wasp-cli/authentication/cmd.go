@@ -22,6 +22,11 @@ func Init(rootCmd *cobra.Command) {
 	loginCmd := initLoginCmd()
 	infoCmd := initInfoCmd()
 	importCmd := initImportCmd()
+	createKeyCmd := initCreateKeyCmd()
+	listKeysCmd := initListKeysCmd()
+	revokeKeyCmd := initRevokeKeyCmd()
+	revokeTokenCmd := initRevokeTokenCmd()
+	revokeUserTokensCmd := initRevokeUserTokensCmd()
 
 	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(loginCmd)
@@ -29,6 +34,11 @@ func Init(rootCmd *cobra.Command) {
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(infoCmd)
 	authCmd.AddCommand(importCmd)
+	authCmd.AddCommand(createKeyCmd)
+	authCmd.AddCommand(listKeysCmd)
+	authCmd.AddCommand(revokeKeyCmd)
+	authCmd.AddCommand(revokeTokenCmd)
+	authCmd.AddCommand(revokeUserTokensCmd)
 
 	loginCmd.PersistentFlags().StringVarP(&username, "username", "u", "", "username")
 	loginCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "password")
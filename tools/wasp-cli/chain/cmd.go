@@ -28,6 +28,8 @@ func Init(rootCmd *cobra.Command) {
 	chainCmd.AddCommand(initInfoCmd())
 	chainCmd.AddCommand(initListContractsCmd())
 	chainCmd.AddCommand(initDeployContractCmd())
+	chainCmd.AddCommand(initUpgradeContractCmd())
+	chainCmd.AddCommand(initRetireContractCmd())
 	chainCmd.AddCommand(initBalanceCmd())
 	chainCmd.AddCommand(initAccountNFTsCmd())
 	chainCmd.AddCommand(initDepositCmd())
@@ -37,6 +39,9 @@ func Init(rootCmd *cobra.Command) {
 	chainCmd.AddCommand(initRequestCmd())
 	chainCmd.AddCommand(initPostRequestCmd())
 	chainCmd.AddCommand(initCallViewCmd())
+	chainCmd.AddCommand(initStateProofCmd())
+	chainCmd.AddCommand(initVerifyStateProofCmd())
+	chainCmd.AddCommand(initPruneCmd())
 	chainCmd.AddCommand(initActivateCmd())
 	chainCmd.AddCommand(initDeactivateCmd())
 	chainCmd.AddCommand(initRunDKGCmd())
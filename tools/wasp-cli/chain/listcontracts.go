@@ -2,6 +2,7 @@ package chain
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -35,10 +36,8 @@ func initListContractsCmd() *cobra.Command {
 			header := []string{
 				"hname",
 				"name",
-				"description",
 				"proghash",
-				"owner fee",
-				"validator fee",
+				"retired",
 			}
 			rows := make([][]string, len(contracts))
 			i := 0
@@ -47,6 +46,7 @@ func initListContractsCmd() *cobra.Command {
 					contract.HName,
 					contract.Name,
 					contract.ProgramHash,
+					strconv.FormatBool(contract.Retired),
 				}
 				i++
 			}
@@ -75,6 +75,7 @@ func initPostRequestCmd() *cobra.Command {
 				Args:      util.EncodeParams(args[2:], chainID),
 				Transfer:  util.ParseFungibleTokens(postRequestParams.transfer),
 				Allowance: allowanceTokens,
+				Sponsor:   postRequestParams.sponsorAgentID(chainID),
 			}
 			postRequest(node, chain, hname, fname, params, postRequestParams.offLedger, postRequestParams.adjustStorageDeposit)
 		},
@@ -92,6 +93,7 @@ type postRequestParams struct {
 	allowance            []string
 	offLedger            bool
 	adjustStorageDeposit bool
+	sponsor              string
 }
 
 func (p *postRequestParams) initFlags(cmd *cobra.Command) {
@@ -105,4 +107,14 @@ func (p *postRequestParams) initFlags(cmd *cobra.Command) {
 		"post an off-ledger request",
 	)
 	cmd.Flags().BoolVarP(&p.adjustStorageDeposit, "adjust-storage-deposit", "s", false, "adjusts the amount of base tokens sent, if it's lower than the min storage deposit required")
+	cmd.Flags().StringVar(&p.sponsor, "sponsor", "",
+		"agent ID of the sponsor paying the gas fee (only for off-ledger requests)",
+	)
+}
+
+func (p *postRequestParams) sponsorAgentID(chainID isc.ChainID) isc.AgentID {
+	if p.sponsor == "" {
+		return nil
+	}
+	return util.AgentIDFromString(p.sponsor, chainID)
 }
@@ -0,0 +1,61 @@
+package chain
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/cliclients"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/config"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
+)
+
+func initPruneCmd() *cobra.Command {
+	var node string
+	var chain string
+	var fromIndex uint32
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "prune <to-block-index>",
+		Short: "Prune the historical states of the chain up to the given block index",
+		Long: "Prune the historical states of the chain on the selected node, starting from the oldest available " +
+			"state (or --from) up to the given block index. The latest state and the states the chain requires " +
+			"to keep (governance block keep amount) are never pruned. The states of an archive chain (which keeps " +
+			"all the blocks) are only pruned with --force. The progress is reported in the node's log.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+
+			toIndex, err := strconv.ParseUint(args[0], 10, 32)
+			log.Check(err)
+
+			log.Printf("Pruning states %d-%d, this may take a while...\n", fromIndex, toIndex)
+			client := cliclients.WaspClient(node)
+			result, _, err := client.ChainsApi.
+				PruneStates(context.Background(), config.GetChain(chain).String()).
+				PruneStatesRequest(apiclient.PruneStatesRequest{
+					FromBlockIndex: fromIndex,
+					ToBlockIndex:   uint32(toIndex),
+					Force:          force,
+				}).
+				Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("Pruned states: %d\n", result.PrunedBlocks)
+			log.Printf("Deleted trie nodes: %d\n", result.DeletedNodes)
+			log.Printf("Deleted trie values: %d\n", result.DeletedValues)
+			log.Printf("Oldest available state: %d\n", result.OldestBlockIndex)
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	cmd.Flags().Uint32Var(&fromIndex, "from", 0, "index of the first block to prune; must not be above the oldest available block")
+	cmd.Flags().BoolVar(&force, "force", false, "prune the states even if the chain keeps all the blocks")
+
+	return cmd
+}
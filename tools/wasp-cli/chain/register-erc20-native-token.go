@@ -68,6 +68,7 @@ func buildPostRequestCmd(name, desc, hname, fname string, initFlags func(cmd *co
 				Args:      util.EncodeParams(funcArgs(cmd), chainID),
 				Transfer:  util.ParseFungibleTokens(postrequestParams.transfer),
 				Allowance: allowanceTokens,
+				Sponsor:   postrequestParams.sponsorAgentID(chainID),
 			}
 			postRequest(
 				node,
@@ -50,12 +50,20 @@ func initRotateWithDKGCmd() *cobra.Command {
 		chain           string
 		skipMaintenance bool
 		offLedger       bool
+		reshare         bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "rotate-with-dkg --peers=<...>",
 		Short: "Runs the DKG on the selected peers, then issues a tx that changes the chain state controller",
-		Args:  cobra.NoArgs,
+		Long: `Runs the DKG on the selected peers, then issues a tx that changes the chain state controller.
+
+With --reshare, the shares of the current state controller key are handed over to the
+selected peers instead, and no tx is issued, because the state controller address stays
+the same. The node has to be a member of the current committee. The chain has to be
+activated on the new committee members, and the nodes of both committees have to be
+restarted (or the chain deactivated and activated again) to pick up the new shares.`,
+		Args: cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			chain = defaultChainFallback(chain)
 			node = waspcmd.DefaultWaspNodeFallback(node)
@@ -65,6 +73,11 @@ func initRotateWithDKGCmd() *cobra.Command {
 				defer setMaintenanceStatus(chain, node, false, offLedger)
 			}
 
+			if reshare {
+				doReshare(node, currentStateController(chain), peers, quorum)
+				return
+			}
+
 			controllerAddr := doDKG(node, peers, quorum)
 			rotateTo(chain, controllerAddr)
 		},
@@ -78,10 +91,22 @@ func initRotateWithDKGCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", true,
 		"post an off-ledger request",
 	)
+	cmd.Flags().BoolVar(&reshare, "reshare", false, "reshare the current state controller key instead of generating a new one")
 
 	return cmd
 }
 
+func currentStateController(chain string) iotago.Address {
+	aliasID := config.GetChain(chain).AsAliasID()
+	_, chainOutput, err := cliclients.L1Client().GetAliasOutput(aliasID)
+	log.Check(err)
+	aliasOutput, ok := chainOutput.(*iotago.AliasOutput)
+	if !ok {
+		log.Fatalf("unexpected chain output type: %T", chainOutput)
+	}
+	return aliasOutput.StateController()
+}
+
 func rotateTo(chain string, newStateControllerAddr iotago.Address) {
 	l1Client := cliclients.L1Client()
 
@@ -55,40 +55,84 @@ func doDKG(node string, peers []string, quorum int) iotago.Address {
 		peers = append(peers, nodeInfo.PublicKey)
 	}
 
-	// grab the peering info of the peers from the node
-	filteredPeers := make([]apiclient.PeeringNodeIdentityResponse, 0)
-	thisNodeFound := false
-	{
-		var trustedPeers []apiclient.PeeringNodeIdentityResponse
-		trustedPeers, _, err = client.NodeApi.GetTrustedPeers(context.Background()).Execute() //nolint:bodyclose // false positive
-		log.Check(err)
-
-		for _, peer := range peers {
-			foundPeer, exists := lo.Find(trustedPeers, func(p apiclient.PeeringNodeIdentityResponse) bool {
-				return (p.Name == peer || p.PublicKey == peer) && p.IsTrusted
-			})
-			if !exists {
-				log.Fatalf("peer with name {%s} not found in trusted peers", peer)
-			}
-			if foundPeer.PublicKey == nodeInfo.PublicKey {
-				thisNodeFound = true
-			}
-			filteredPeers = append(filteredPeers, foundPeer)
-		}
-	}
+	filteredPeers, thisNodeFound := trustedCommitteePeers(client, nodeInfo, peers)
 	if !thisNodeFound {
 		// TODO: This is temporary, until DKG is fixed to not require the current node in the committee.
 		fmt.Fprintf(os.Stdout, "NOTE: Adding this node as a committee member.\n")
 		filteredPeers = append(filteredPeers, *nodeInfo)
 	}
 
-	committeePubKeys := []string{}
-	for _, peer := range filteredPeers {
-		committeePubKeys = append(committeePubKeys, peer.PublicKey)
+	committeePubKeys := lo.Map(filteredPeers, func(p apiclient.PeeringNodeIdentityResponse, _ int) string {
+		return p.PublicKey
+	})
+	quorum = committeeQuorum(len(committeePubKeys), quorum)
+
+	stateControllerAddr, err := apilib.RunDKG(client, committeePubKeys, uint16(quorum))
+	log.Check(err)
+
+	fmt.Fprintf(os.Stdout,
+		"DKG successful\nAddress: %s\n* committee size = %v\n* quorum = %v\n* members: %s\n",
+		stateControllerAddr.Bech32(parameters.L1().Protocol.Bech32HRP),
+		len(committeePubKeys),
+		quorum,
+		committeeMembersString(filteredPeers),
+	)
+	return stateControllerAddr
+}
+
+// doReshare hands the shares of the existing distributed key over to the specified
+// committee. The shared address remains the same. The node has to hold a share of the key.
+func doReshare(node string, sharedAddress iotago.Address, peers []string, quorum int) {
+	client := cliclients.WaspClient(node)
+	nodeInfo, _, err := client.NodeApi.GetPeeringIdentity(context.Background()).Execute() //nolint:bodyclose // false positive
+	log.Check(err)
+
+	if len(peers) == 0 {
+		log.Fatal("the new committee has to be specified")
 	}
+	filteredPeers, _ := trustedCommitteePeers(client, nodeInfo, peers)
+	committeePubKeys := lo.Map(filteredPeers, func(p apiclient.PeeringNodeIdentityResponse, _ int) string {
+		return p.PublicKey
+	})
+	quorum = committeeQuorum(len(committeePubKeys), quorum)
+
+	err = apilib.ReshareDKG(client, sharedAddress, committeePubKeys, uint16(quorum))
+	log.Check(err)
 
-	// Use default quorum, if it is unspecified.
-	minQuorum := byz_quorum.MinQuorum(len(committeePubKeys))
+	fmt.Fprintf(os.Stdout,
+		"Resharing successful\nAddress: %s\n* committee size = %v\n* quorum = %v\n* members: %s\n",
+		sharedAddress.Bech32(parameters.L1().Protocol.Bech32HRP),
+		len(committeePubKeys),
+		quorum,
+		committeeMembersString(filteredPeers),
+	)
+}
+
+// trustedCommitteePeers grabs the peering info of the peers from the node.
+func trustedCommitteePeers(client *apiclient.APIClient, nodeInfo *apiclient.PeeringNodeIdentityResponse, peers []string) ([]apiclient.PeeringNodeIdentityResponse, bool) {
+	trustedPeers, _, err := client.NodeApi.GetTrustedPeers(context.Background()).Execute() //nolint:bodyclose // false positive
+	log.Check(err)
+
+	filteredPeers := make([]apiclient.PeeringNodeIdentityResponse, 0)
+	thisNodeFound := false
+	for _, peer := range peers {
+		foundPeer, exists := lo.Find(trustedPeers, func(p apiclient.PeeringNodeIdentityResponse) bool {
+			return (p.Name == peer || p.PublicKey == peer) && p.IsTrusted
+		})
+		if !exists {
+			log.Fatalf("peer with name {%s} not found in trusted peers", peer)
+		}
+		if foundPeer.PublicKey == nodeInfo.PublicKey {
+			thisNodeFound = true
+		}
+		filteredPeers = append(filteredPeers, foundPeer)
+	}
+	return filteredPeers, thisNodeFound
+}
+
+// committeeQuorum uses the default quorum, if it is unspecified.
+func committeeQuorum(committeeSize, quorum int) int {
+	minQuorum := byz_quorum.MinQuorum(committeeSize)
 	if quorum == 0 {
 		quorum = minQuorum
 	}
@@ -96,21 +140,13 @@ func doDKG(node string, peers []string, quorum int) iotago.Address {
 	if quorum < minQuorum {
 		log.Fatal("quorum needs to be at least (2/3)+1 of committee size")
 	}
+	return quorum
+}
 
-	stateControllerAddr, err := apilib.RunDKG(client, committeePubKeys, uint16(quorum))
-	log.Check(err)
-
+func committeeMembersString(peers []apiclient.PeeringNodeIdentityResponse) string {
 	committeeMembersStr := ""
-	for _, fp := range filteredPeers {
+	for _, fp := range peers {
 		committeeMembersStr += fmt.Sprintf("%v (%v)\n", fp.PublicKey, fp.Name)
 	}
-
-	fmt.Fprintf(os.Stdout,
-		"DKG successful\nAddress: %s\n* committee size = %v\n* quorum = %v\n* members: %s\n",
-		stateControllerAddr.Bech32(parameters.L1().Protocol.Bech32HRP),
-		len(committeePubKeys),
-		quorum,
-		committeeMembersStr,
-	)
-	return stateControllerAddr
+	return committeeMembersStr
 }
@@ -0,0 +1,85 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
+	"github.com/nnikolash/wasp-types-exported/clients/apiextensions"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/cliclients"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/config"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/util"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
+)
+
+func initStateProofCmd() *cobra.Command {
+	var node string
+	var chain string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "state-proof <0x...>",
+		Short: "Fetch the value of a key in the chain state together with its proof",
+		Long: "Fetch the value of the given (Hex-encoded) key in the chain state, together with its Merkle proof " +
+			"anchored to the latest confirmed alias output. The proof is verified and optionally saved " +
+			"to a file, which can later be verified offline with 'verify-state-proof'.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+
+			client := cliclients.WaspClient(node)
+			proof, _, err := client.ChainsApi.
+				GetStateProof(context.Background(), config.GetChain(chain).String(), args[0]).
+				Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			if output != "" {
+				jsonBytes, err := json.MarshalIndent(proof, "", "  ")
+				log.Check(err)
+				log.Check(os.WriteFile(output, jsonBytes, 0o600))
+			}
+			verifyStateProof(proof)
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "save the proof as JSON to the given file")
+
+	return cmd
+}
+
+func initVerifyStateProofCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify-state-proof <file>",
+		Short: "Verify offline a state proof saved with 'state-proof'",
+		Long: "Verify offline a state proof saved with 'state-proof'. The value is proven to be committed " +
+			"in the printed alias output, which has to be checked against L1 to fully trust the value.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var proof apiclient.StateProofResponse
+			log.Check(json.Unmarshal(util.ReadFile(args[0]), &proof))
+			verifyStateProof(&proof)
+		},
+	}
+}
+
+func verifyStateProof(proof *apiclient.StateProofResponse) {
+	aliasOutput, err := apiextensions.VerifyStateProof(proof)
+	log.Check(err, "proof verification failed")
+
+	log.Printf("Proof is valid\n")
+	log.Printf("Key: %s\n", proof.Key)
+	if proof.Value == "" {
+		log.Printf("Value: <absent>\n")
+	} else {
+		log.Printf("Value: %s\n", proof.Value)
+	}
+	log.Printf("Trie root: %s\n", proof.TrieRoot)
+	log.Printf("Alias output ID: %s\n", aliasOutput.OutputID().ToHex())
+	log.Printf("State index: %d\n", aliasOutput.GetStateIndex())
+}
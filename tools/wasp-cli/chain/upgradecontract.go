@@ -0,0 +1,111 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nnikolash/wasp-types-exported/clients/chainclient"
+	"github.com/nnikolash/wasp-types-exported/packages/hashing"
+	"github.com/nnikolash/wasp-types-exported/packages/isc"
+	"github.com/nnikolash/wasp-types-exported/packages/kv/codec"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/blob"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/core/root"
+	"github.com/nnikolash/wasp-types-exported/packages/vm/vmtypes"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/cliclients"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/config"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/util"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
+)
+
+func initUpgradeContractCmd() *cobra.Command {
+	var node string
+	var chain string
+
+	cmd := &cobra.Command{
+		Use:   "upgrade-contract <vmtype> <name> <description> <filename|program-hash>",
+		Short: "Replace the program of a contract in the chain, keeping its state",
+		Args:  cobra.ExactArgs(4),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+
+			chainID := config.GetChain(chain)
+			client := cliclients.WaspClient(node)
+			vmtype := args[0]
+			name := args[1]
+			description := args[2]
+
+			var progHash hashing.HashValue
+
+			switch vmtype {
+			case vmtypes.Core:
+				log.Fatal("cannot manually upgrade core contracts")
+
+			case vmtypes.Native:
+				var err error
+				progHash, err = hashing.HashValueFromHex(args[3])
+				log.Check(err)
+
+			default:
+				filename := args[3]
+				blobFieldValues := codec.MakeDict(map[string]interface{}{
+					blob.VarFieldVMType:             vmtype,
+					blob.VarFieldProgramDescription: description,
+					blob.VarFieldProgramBinary:      util.ReadFile(filename),
+				})
+				progHash = uploadBlob(client, chainID, blobFieldValues)
+			}
+
+			util.WithOffLedgerRequest(chainID, node, func() (isc.OffLedgerRequest, error) {
+				return cliclients.ChainClient(client, chainID).PostOffLedgerRequest(context.Background(),
+					root.Contract.Hname(),
+					root.FuncUpgradeContract.Hname(),
+					chainclient.PostRequestParams{
+						Args: codec.MakeDict(map[string]interface{}{
+							root.ParamHname:       isc.Hn(name),
+							root.ParamProgramHash: progHash,
+						}),
+					},
+				)
+			})
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	return cmd
+}
+
+func initRetireContractCmd() *cobra.Command {
+	var node string
+	var chain string
+
+	cmd := &cobra.Command{
+		Use:   "retire-contract <name>",
+		Short: "Retire a contract in the chain, rejecting further calls to it",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+
+			chainID := config.GetChain(chain)
+			client := cliclients.WaspClient(node)
+
+			util.WithOffLedgerRequest(chainID, node, func() (isc.OffLedgerRequest, error) {
+				return cliclients.ChainClient(client, chainID).PostOffLedgerRequest(context.Background(),
+					root.Contract.Hname(),
+					root.FuncRetireContract.Hname(),
+					chainclient.PostRequestParams{
+						Args: codec.MakeDict(map[string]interface{}{
+							root.ParamHname: isc.Hn(args[0]),
+						}),
+					},
+				)
+			})
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	return cmd
+}
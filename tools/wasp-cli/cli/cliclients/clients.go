@@ -2,6 +2,7 @@ package cliclients
 
 import (
 	"context"
+	"os"
 
 	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
 	"github.com/nnikolash/wasp-types-exported/clients/apiextensions"
@@ -17,6 +18,9 @@ import (
 
 var SkipCheckVersions bool
 
+// APIKeyEnvVar names the environment variable with an API key, which is used instead of the stored JWT if set.
+const APIKeyEnvVar = "WASP_API_KEY"
+
 func WaspClientForHostName(name string) *apiclient.APIClient {
 	apiAddress := config.MustWaspAPIURL(name)
 	L1Client() // this will fill parameters.L1() with data from the L1 node
@@ -26,7 +30,11 @@ func WaspClientForHostName(name string) *apiclient.APIClient {
 	log.Check(err)
 
 	client.GetConfig().Debug = log.DebugFlag
-	client.GetConfig().AddDefaultHeader("Authorization", "Bearer "+config.GetToken(name))
+	if apiKey := os.Getenv(APIKeyEnvVar); apiKey != "" {
+		client.GetConfig().AddDefaultHeader("X-API-Key", apiKey)
+	} else {
+		client.GetConfig().AddDefaultHeader("Authorization", "Bearer "+config.GetToken(name))
+	}
 
 	return client
 }
@@ -54,6 +62,10 @@ func assertMatchingNodeVersion(name string, client *apiclient.APIClient) {
 func L1Client() l1connection.Client {
 	log.Verbosef("using L1 API %s\n", config.L1APIAddress())
 
+	if config.L1DevNode() {
+		return l1connection.NewDevClient(config.L1APIAddress(), log.HiveLogger())
+	}
+
 	return l1connection.NewClient(
 		l1connection.Config{
 			APIAddress:    config.L1APIAddress(),
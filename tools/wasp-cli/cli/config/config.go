@@ -127,6 +127,12 @@ func L1APIAddress() string {
 	)
 }
 
+// L1DevNode returns true if the L1 API address points to the webapi of a Wasp node
+// running with the in-process L1 ledger (development mode), instead of an L1 node.
+func L1DevNode() bool {
+	return viper.GetBool("l1.devNode")
+}
+
 func L1FaucetAddress() string {
 	address := viper.GetString("l1.faucetAddress")
 	if address != "" {
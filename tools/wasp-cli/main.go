@@ -20,6 +20,7 @@ import (
 	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
 	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/metrics"
 	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/peering"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/users"
 	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/wallet"
 	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
 )
@@ -87,6 +88,7 @@ func init() {
 	decode.Init(rootCmd)
 	peering.Init(rootCmd)
 	metrics.Init(rootCmd)
+	users.Init(rootCmd)
 }
 
 func main() {
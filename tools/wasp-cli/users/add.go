@@ -0,0 +1,53 @@
+package users
+
+import (
+	"context"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/cliclients"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
+)
+
+func initAddCmd() *cobra.Command {
+	var node string
+	var password string
+
+	cmd := &cobra.Command{
+		Use:   "add <username> <permission>...",
+		Short: "Add a user with the given permissions",
+		Long:  "Add a user with the given permissions. The password is read from the terminal, if not given.\n\n" + permissionsHelp,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			if password == "" {
+				log.Printf("Password: ")
+				// int cast is needed for windows
+				passwordBytes, err := term.ReadPassword(int(syscall.Stdin)) //nolint:unconvert
+				log.Check(err)
+				log.Printf("\n")
+				password = string(passwordBytes)
+			}
+
+			client := cliclients.WaspClient(node)
+			_, err := client.UsersApi.AddUser(context.Background()).AddUserRequest(apiclient.AddUserRequest{
+				Username:    args[0],
+				Password:    password,
+				Permissions: args[1:],
+			}).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("User %s added\n", args[0])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	cmd.Flags().StringVarP(&password, "password", "p", "", "password of the new user")
+
+	return cmd
+}
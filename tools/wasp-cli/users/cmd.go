@@ -0,0 +1,34 @@
+package users
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+)
+
+const permissionsHelp = `Permissions:
+  read, write                      node wide access; write includes every scoped permission
+  chain:<chainID>:read|write       access to the chain endpoints of a single chain
+  peering:read|write               access to the peering endpoints
+  users:read|admin                 access to the users endpoints`
+
+func initUsersCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "users <command>",
+		Short: "Manage the users of a Wasp node",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Check(cmd.Help())
+		},
+	}
+}
+
+func Init(rootCmd *cobra.Command) {
+	usersCmd := initUsersCmd()
+	rootCmd.AddCommand(usersCmd)
+
+	usersCmd.AddCommand(initListCmd())
+	usersCmd.AddCommand(initAddCmd())
+	usersCmd.AddCommand(initRemoveCmd())
+	usersCmd.AddCommand(initSetPermissionsCmd())
+}
@@ -0,0 +1,43 @@
+package users
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/cliclients"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
+)
+
+func initListCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the users and their permissions",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			client := cliclients.WaspClient(node)
+			users, _, err := client.UsersApi.GetUsers(context.Background()).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+			header := []string{"Username", "Permissions"}
+			rows := make([][]string, len(users))
+			for i, user := range users {
+				sort.Strings(user.Permissions)
+				rows[i] = []string{user.Username, strings.Join(user.Permissions, ", ")}
+			}
+			log.PrintTable(header, rows)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+
+	return cmd
+}
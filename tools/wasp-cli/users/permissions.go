@@ -0,0 +1,40 @@
+package users
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nnikolash/wasp-types-exported/clients/apiclient"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/cliclients"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
+)
+
+func initSetPermissionsCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "set-permissions <username> <permission>...",
+		Short: "Replace the permissions of a user",
+		Long:  "Replace the permissions of a user; the users can't change their own permissions.\n\n" + permissionsHelp,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			client := cliclients.WaspClient(node)
+			_, err := client.UsersApi.ChangeUserPermissions(context.Background(), args[0]).
+				UpdateUserPermissionsRequest(apiclient.UpdateUserPermissionsRequest{
+					Permissions: args[1:],
+				}).
+				Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("Permissions of user %s set to %v\n", args[0], args[1:])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+
+	return cmd
+}
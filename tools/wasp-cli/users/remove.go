@@ -0,0 +1,34 @@
+package users
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/cli/cliclients"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/log"
+	"github.com/nnikolash/wasp-types-exported/tools/wasp-cli/waspcmd"
+)
+
+func initRemoveCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "remove <username>",
+		Short: "Remove a user",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			client := cliclients.WaspClient(node)
+			_, err := client.UsersApi.DeleteUser(context.Background(), args[0]).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("User %s removed\n", args[0])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+
+	return cmd
+}